@@ -401,6 +401,7 @@ func stateServingInfo() params.StateServingInfo {
 		APIPort:        47,
 		SharedSecret:   "shared",
 		SystemIdentity: "identity",
+		SecretsKey:     "secrets key",
 	}
 }
 
@@ -511,6 +512,7 @@ func (*suite) TestStateServingInfo(c *gc.C) {
 		CAPrivateKey:   "new ca key",
 		SharedSecret:   "new shared",
 		SystemIdentity: "new identity",
+		SecretsKey:     "new secrets key",
 	}
 	conf.SetStateServingInfo(newInfo)
 	gotInfo, ok = conf.StateServingInfo()
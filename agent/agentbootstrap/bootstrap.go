@@ -180,6 +180,7 @@ func paramsStateServingInfoToStateStateServingInfo(i params.StateServingInfo) st
 		CAPrivateKey:   i.CAPrivateKey,
 		SharedSecret:   i.SharedSecret,
 		SystemIdentity: i.SystemIdentity,
+		SecretsKey:     i.SecretsKey,
 	}
 }
 
@@ -55,6 +55,7 @@ type format_2_0Serialization struct {
 	StatePort      int    `yaml:"stateport,omitempty"`
 	SharedSecret   string `yaml:"sharedsecret,omitempty"`
 	SystemIdentity string `yaml:"systemidentity,omitempty"`
+	SecretsKey     string `yaml:"secretskey,omitempty"`
 	MongoVersion   string `yaml:"mongoversion,omitempty"`
 }
 
@@ -120,6 +121,7 @@ func (formatter_2_0) unmarshal(data []byte) (*configInternal, error) {
 			StatePort:      format.StatePort,
 			SharedSecret:   format.SharedSecret,
 			SystemIdentity: format.SystemIdentity,
+			SecretsKey:     format.SecretsKey,
 		}
 		// If private key is not present, infer it from the ports in the state addresses.
 		if config.servingInfo.StatePort == 0 {
@@ -178,6 +180,7 @@ func (formatter_2_0) marshal(config *configInternal) ([]byte, error) {
 		format.StatePort = config.servingInfo.StatePort
 		format.SharedSecret = config.servingInfo.SharedSecret
 		format.SystemIdentity = config.servingInfo.SystemIdentity
+		format.SecretsKey = config.servingInfo.SecretsKey
 	}
 	if config.stateDetails != nil {
 		if len(config.stateDetails.addresses) > 0 {
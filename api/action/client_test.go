@@ -127,3 +127,21 @@ func patchApplicationCharmActions(c *gc.C, apiCli *action.Client, patchResults [
 		},
 	)
 }
+
+func (s *actionSuite) TestRunUnitsMatchingNotSupported(c *gc.C) {
+	called := false
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			called = true
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.Run(params.RunParams{
+		Commands:      "hostname",
+		UnitsMatching: []string{"status=error"},
+	})
+	c.Check(err, gc.ErrorMatches, "unit selectors on this controller not supported")
+	c.Check(called, jc.IsFalse)
+}
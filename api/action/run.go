@@ -6,6 +6,8 @@ package action
 import (
 	"time"
 
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -21,6 +23,9 @@ func (c *Client) RunOnAllMachines(commands string, timeout time.Duration) ([]par
 // Run the Commands specified on the machines identified through the ids
 // provided in the machines, services and units slices.
 func (c *Client) Run(run params.RunParams) ([]params.ActionResult, error) {
+	if len(run.UnitsMatching) > 0 && c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("unit selectors on this controller")
+	}
 	var results params.ActionResults
 	err := c.facade.FacadeCall("Run", run, &results)
 	return results.Results, err
@@ -43,6 +43,7 @@ func (s *servingInfoSuite) TestStateServingInfo(c *gc.C) {
 		PrivateKey:   "some key",
 		Cert:         "Some cert",
 		SharedSecret: "really, really secret",
+		SecretsKey:   "even more secret",
 		APIPort:      33,
 		StatePort:    44,
 	}
@@ -50,6 +51,7 @@ func (s *servingInfoSuite) TestStateServingInfo(c *gc.C) {
 		PrivateKey:   ssi.PrivateKey,
 		Cert:         ssi.Cert,
 		SharedSecret: ssi.SharedSecret,
+		SecretsKey:   ssi.SecretsKey,
 		APIPort:      ssi.APIPort,
 		StatePort:    ssi.StatePort,
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
@@ -86,12 +87,18 @@ type DeployArgs struct {
 	// Collection of resource names for the application, with the value being the
 	// unique ID of a pre-uploaded resources in storage.
 	Resources map[string]string
+	// Trust is true if the application may access the model's
+	// cloud credential.
+	Trust bool
 }
 
 // Deploy obtains the charm, either locally or from the charm store, and deploys
 // it. Placement directives, if provided, specify the machine on which the charm
 // is deployed.
 func (c *Client) Deploy(args DeployArgs) error {
+	if args.Trust && c.BestAPIVersion() < 5 {
+		return errors.NotImplementedf("deploying trusted applications")
+	}
 	deployArgs := params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
 			ApplicationName:  args.ApplicationName,
@@ -105,6 +112,7 @@ func (c *Client) Deploy(args DeployArgs) error {
 			Storage:          args.Storage,
 			EndpointBindings: args.EndpointBindings,
 			Resources:        args.Resources,
+			Trust:            args.Trust,
 		}},
 	}
 	var results params.ErrorResults
@@ -217,6 +225,20 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeToCIDRs changes the juju-managed firewall to expose any ports
+// that were explicitly marked by units as open, allowing access only
+// from the given source CIDRs.
+func (c *Client) ExposeToCIDRs(application string, cidrs []string) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotImplementedf("ExposeToCIDRs")
+	}
+	params := params.ApplicationExpose{
+		ApplicationName: application,
+		CIDRs:           cidrs,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -224,6 +246,68 @@ func (c *Client) Unexpose(application string) error {
 	return c.facade.FacadeCall("Unexpose", params, nil)
 }
 
+// SetTrust grants or revokes the application's access to the model's
+// cloud credential.
+func (c *Client) SetTrust(application string, trust bool) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotImplementedf("SetTrust")
+	}
+	params := params.ApplicationSetTrust{
+		ApplicationName: application,
+		Trust:           trust,
+	}
+	return c.facade.FacadeCall("SetTrust", params, nil)
+}
+
+// SetBindings changes the spaces the given endpoints of the application
+// are bound to.
+func (c *Client) SetBindings(application string, bindings map[string]string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotImplementedf("SetBindings")
+	}
+	params := params.ApplicationSetBindings{
+		ApplicationName:  application,
+		EndpointBindings: bindings,
+	}
+	return c.facade.FacadeCall("SetBindings", params, nil)
+}
+
+// UpdateApplicationSeries changes the series of the application, which
+// is used when adding new units.
+func (c *Client) UpdateApplicationSeries(application, series string, force bool) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotImplementedf("UpdateApplicationSeries")
+	}
+	args := params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: names.NewApplicationTag(application).String()},
+			Series: series,
+			Force:  force,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("UpdateApplicationSeries", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ReplayHooks asks the unit to run again the hooks it skipped when it
+// was resolved without retrying its failed hooks.
+func (c *Client) ReplayHooks(unit names.UnitTag) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.NotImplementedf("ReplayHooks")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: unit.String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ReplayHooks", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // Get returns the configuration for the named application.
 func (c *Client) Get(application string) (*params.ApplicationGetResults, error) {
 	var results params.ApplicationGetResults
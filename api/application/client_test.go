@@ -4,9 +4,11 @@
 package application_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/common"
@@ -163,3 +165,154 @@ func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *serviceSuite) TestServiceSetBindings(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetBindings")
+		args, ok := a.(params.ApplicationSetBindings)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args.ApplicationName, gc.Equals, "application")
+		c.Assert(args.EndpointBindings, jc.DeepEquals, map[string]string{
+			"db": "storage",
+		})
+		return nil
+	})
+	err := s.client.SetBindings("application", map[string]string{"db": "storage"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+// patchFacadeVersion makes the client behave as if the controller
+// supports only the given version of the facade, failing the test if
+// any call is made to it.
+func (s *serviceSuite) patchFacadeVersion(c *gc.C, version int) {
+	application.PatchBestAPIVersion(s, s.client, version)
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+}
+
+func (s *serviceSuite) TestSetBindingsNeedsVersion2(c *gc.C) {
+	s.patchFacadeVersion(c, 1)
+	err := s.client.SetBindings("application", map[string]string{"db": "internal"})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *serviceSuite) TestExposeToCIDRs(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Expose")
+		args, ok := a.(params.ApplicationExpose)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName: "application",
+			CIDRs:           []string{"10.0.0.0/8"},
+		})
+		return nil
+	})
+	err := s.client.ExposeToCIDRs("application", []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestExposeToCIDRsNeedsVersion4(c *gc.C) {
+	s.patchFacadeVersion(c, 3)
+	err := s.client.ExposeToCIDRs("application", []string{"10.0.0.0/8"})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *serviceSuite) TestSetTrust(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetTrust")
+		args, ok := a.(params.ApplicationSetTrust)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args, jc.DeepEquals, params.ApplicationSetTrust{
+			ApplicationName: "application",
+			Trust:           true,
+		})
+		return nil
+	})
+	err := s.client.SetTrust("application", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestSetTrustNeedsVersion5(c *gc.C) {
+	s.patchFacadeVersion(c, 4)
+	err := s.client.SetTrust("application", true)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *serviceSuite) TestDeployTrustedNeedsVersion5(c *gc.C) {
+	s.patchFacadeVersion(c, 4)
+	err := s.client.Deploy(application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/a-charm-1"),
+		},
+		ApplicationName: "application",
+		Trust:           true,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *serviceSuite) TestUpdateApplicationSeries(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "UpdateApplicationSeries")
+		args, ok := a.(params.UpdateSeriesArgs)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args, jc.DeepEquals, params.UpdateSeriesArgs{
+			Args: []params.UpdateSeriesArg{{
+				Entity: params.Entity{Tag: "application-application"},
+				Series: "xenial",
+				Force:  true,
+			}},
+		})
+		result, ok := response.(*params.ErrorResults)
+		c.Assert(ok, jc.IsTrue)
+		result.Results = []params.ErrorResult{{}}
+		return nil
+	})
+	err := s.client.UpdateApplicationSeries("application", "xenial", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestUpdateApplicationSeriesNeedsVersion3(c *gc.C) {
+	s.patchFacadeVersion(c, 2)
+	err := s.client.UpdateApplicationSeries("application", "xenial", false)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *serviceSuite) TestReplayHooks(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ReplayHooks")
+		args, ok := a.(params.Entities)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+		})
+		result, ok := response.(*params.ErrorResults)
+		c.Assert(ok, jc.IsTrue)
+		result.Results = []params.ErrorResult{{}}
+		return nil
+	})
+	err := s.client.ReplayHooks(names.NewUnitTag("mysql/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestReplayHooksNeedsVersion6(c *gc.C) {
+	s.patchFacadeVersion(c, 5)
+	err := s.client.ReplayHooks(names.NewUnitTag("mysql/0"))
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
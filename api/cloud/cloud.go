@@ -116,6 +116,23 @@ func (c *Client) Credentials(user names.UserTag, cloud names.CloudTag) (map[stri
 // the given tags. Exiting credentials that are not named in the map will be
 // untouched.
 func (c *Client) UpdateCredentials(user names.UserTag, cloud names.CloudTag, credentials map[string]jujucloud.Credential) error {
+	return c.updateCredentials("UpdateCredentials", user, cloud, credentials)
+}
+
+// UpdateCredentialsCheckModels updates the cloud credentials for the user
+// and cloud with the given tags, and replaces them in every model using
+// them. The controller first checks the credentials are accepted by the
+// provider of each of those models, and fails without changing anything
+// if they are not. The credentials and the models are then updated
+// together, in a single transaction.
+func (c *Client) UpdateCredentialsCheckModels(user names.UserTag, cloud names.CloudTag, credentials map[string]jujucloud.Credential) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotImplementedf("UpdateCredentialsCheckModels")
+	}
+	return c.updateCredentials("UpdateCredentialsCheckModels", user, cloud, credentials)
+}
+
+func (c *Client) updateCredentials(method string, user names.UserTag, cloud names.CloudTag, credentials map[string]jujucloud.Credential) error {
 	var results params.ErrorResults
 	paramsCredentials := make(map[string]params.CloudCredential)
 	for name, credential := range credentials {
@@ -129,7 +146,7 @@ func (c *Client) UpdateCredentials(user names.UserTag, cloud names.CloudTag, cre
 		CloudTag:    cloud.String(),
 		Credentials: paramsCredentials,
 	}}}
-	if err := c.facade.FacadeCall("UpdateCredentials", args, &results); err != nil {
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
 		return errors.Trace(err)
 	}
 	if len(results.Results) != 1 {
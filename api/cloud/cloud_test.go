@@ -4,6 +4,7 @@
 package cloud_test
 
 import (
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -187,3 +188,67 @@ func (s *cloudSuite) TestUpdateCredentials(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *cloudSuite) TestUpdateCredentialsCheckModels(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Cloud")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "UpdateCredentialsCheckModels")
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			c.Assert(a, jc.DeepEquals, params.UsersCloudCredentials{[]params.UserCloudCredentials{{
+				UserTag:  "user-bob@local",
+				CloudTag: "cloud-foo",
+				Credentials: map[string]params.CloudCredential{
+					"two": {
+						AuthType: "userpass",
+						Attributes: map[string]string{
+							"username": "admin",
+							"password": "r0tated",
+						},
+					},
+				},
+			}}})
+			*result.(*params.ErrorResults) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "credential not valid"},
+				}},
+			}
+			called = true
+			return nil
+		},
+	}
+
+	client := cloudapi.NewClient(apiCaller)
+	err := client.UpdateCredentialsCheckModels(names.NewUserTag("bob@local"), names.NewCloudTag("foo"), map[string]cloud.Credential{
+		"two": cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+			"username": "admin",
+			"password": "r0tated",
+		}),
+	})
+	c.Assert(err, gc.ErrorMatches, "credential not valid")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *cloudSuite) TestUpdateCredentialsCheckModelsNeedsVersion2(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 1,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected call to %s.%s", objType, request)
+			return nil
+		},
+	}
+	client := cloudapi.NewClient(apiCaller)
+	err := client.UpdateCredentialsCheckModels(names.NewUserTag("bob@local"), names.NewCloudTag("foo"), nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
@@ -93,6 +93,17 @@ func (c *Client) RemoveBlocks() error {
 	return c.facade.FacadeCall("RemoveBlocks", args, nil)
 }
 
+// ConfigSet updates the passed controller configuration values. Any
+// settings that aren't passed will be left with their previous
+// values.
+func (c *Client) ConfigSet(values map[string]interface{}) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotImplementedf("ConfigSet")
+	}
+	args := params.ControllerConfigSet{Config: values}
+	return c.facade.FacadeCall("ConfigSet", args, nil)
+}
+
 // WatchAllModels returns an AllWatcher, from which you can request
 // the Next collection of Deltas (for all models).
 func (c *Client) WatchAllModels() (*api.AllWatcher, error) {
@@ -14,6 +14,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/controller"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
 	"github.com/juju/juju/apiserver/params"
@@ -81,6 +82,35 @@ func (s *controllerSuite) TestControllerConfig(c *gc.C) {
 	c.Assert(int(cfg["api-port"].(float64)), gc.Equals, cfgFromDB.APIPort())
 }
 
+func (s *controllerSuite) TestConfigSet(c *gc.C) {
+	sysManager := s.OpenAPI(c)
+	err := sysManager.ConfigSet(map[string]interface{}{
+		"auditing-enabled": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	cfgFromDB, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfgFromDB.AuditingEnabled(), jc.IsTrue)
+
+	err = sysManager.ConfigSet(map[string]interface{}{
+		"state-port": 1234,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot change "state-port" after bootstrap`)
+}
+
+func (s *controllerSuite) TestConfigSetNeedsVersion4(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %s.%s", objType, request)
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.ConfigSet(map[string]interface{}{"auditing-enabled": true})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *controllerSuite) TestDestroyController(c *gc.C) {
 	st := s.Factory.MakeModel(c, &factory.ModelParams{Name: "foo"})
 	factory.NewFactory(st).MakeMachine(c, nil) // make it non-empty
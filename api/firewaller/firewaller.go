@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/watcher"
 )
 
@@ -20,14 +21,16 @@ const firewallerFacade = "Firewaller"
 type State struct {
 	facade base.FacadeCaller
 	*common.ModelWatcher
+	*common.ControllerConfigAPI
 }
 
 // NewState creates a new client-side Firewaller API facade.
 func NewState(caller base.APICaller) *State {
 	facadeCaller := base.NewFacadeCaller(caller, firewallerFacade)
 	return &State{
-		facade:       facadeCaller,
-		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:              facadeCaller,
+		ModelWatcher:        common.NewModelWatcher(facadeCaller),
+		ControllerConfigAPI: common.NewControllerConfig(facadeCaller),
 	}
 }
 
@@ -90,6 +93,35 @@ func (st *State) WatchModelMachines() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// ControllerConfig returns the current controller configuration.
+// Controllers older than version 5 of the Firewaller facade do not
+// support it, and return an error satisfying errors.IsNotImplemented.
+func (st *State) ControllerConfig() (controller.Config, error) {
+	if st.BestAPIVersion() < 5 {
+		return nil, errors.NotImplementedf("ControllerConfig")
+	}
+	return st.ControllerConfigAPI.ControllerConfig()
+}
+
+// WatchForControllerConfigChanges returns a NotifyWatcher that notifies
+// when the controller configuration changes. Controllers older than
+// version 5 of the Firewaller facade do not support it, and return an
+// error satisfying errors.IsNotImplemented.
+func (st *State) WatchForControllerConfigChanges() (watcher.NotifyWatcher, error) {
+	if st.BestAPIVersion() < 5 {
+		return nil, errors.NotImplementedf("WatchForControllerConfigChanges")
+	}
+	var result params.NotifyWatchResult
+	err := st.facade.FacadeCall("WatchForControllerConfigChanges", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result), nil
+}
+
 // WatchOpenedPorts returns a StringsWatcher that notifies of
 // changes to the opened ports for the current model.
 func (st *State) WatchOpenedPorts() (watcher.StringsWatcher, error) {
@@ -6,6 +6,7 @@ package firewaller
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/common"
@@ -75,3 +76,30 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposedCIDRs returns the source CIDRs this service's open ports may
+// be accessed from when it is exposed. An empty result means the ports
+// are accessible from anywhere. Controllers older than version 4 of the
+// Firewaller facade do not support exposing to CIDRs, and return an
+// error satisfying errors.IsNotImplemented.
+func (s *Application) ExposedCIDRs() ([]string, error) {
+	if s.st.BestAPIVersion() < 4 {
+		return nil, errors.NotImplementedf("ExposedCIDRs")
+	}
+	var results params.StringsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetExposedCIDRs", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
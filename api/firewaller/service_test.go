@@ -4,10 +4,12 @@
 package firewaller_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/watcher/watchertest"
@@ -91,3 +93,43 @@ func (s *serviceSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *serviceSuite) TestExposedCIDRs(c *gc.C) {
+	err := s.application.SetExposedToCIDRs([]string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err := s.apiApplication.ExposedCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	err = s.application.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err = s.apiApplication.ExposedCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+}
+
+func (s *serviceSuite) TestExposedCIDRsNeedsVersion4(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Firewaller")
+			c.Assert(request, gc.Equals, "Life")
+			*(result.(*params.LifeResults)) = params.LifeResults{
+				Results: []params.LifeResult{{Life: params.Alive}},
+			}
+			return nil
+		},
+	}
+	unit, err := firewaller.NewState(apiCaller).Unit(names.NewUnitTag("wordpress/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = application.ExposedCIDRs()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
@@ -4,10 +4,14 @@
 package firewaller_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/firewaller"
 	apitesting "github.com/juju/juju/api/testing"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/watcher/watchertest"
@@ -58,6 +62,45 @@ func (s *stateSuite) TestWatchModelMachines(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *stateSuite) TestWatchForControllerConfigChanges(c *gc.C) {
+	w, err := s.firewaller.WatchForControllerConfigChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.APIAllowKey: "10.0.0.0/8",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	cfg, err := s.firewaller.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIAllow(), jc.DeepEquals, []string{"10.0.0.0/8"})
+}
+
+func (s *stateSuite) TestControllerConfigNeedsVersion5(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 4,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected call to %s.%s", objType, request)
+			return nil
+		},
+	}
+	st := firewaller.NewState(apiCaller)
+	_, err := st.ControllerConfig()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	_, err = st.WatchForControllerConfigChanges()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *stateSuite) TestWatchOpenedPorts(c *gc.C) {
 	// Open some ports.
 	err := s.units[0].OpenPorts("tcp", 1234, 1400)
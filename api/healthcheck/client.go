@@ -0,0 +1,54 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthcheck contains an implementation of the api facade used
+// by a unit agent to report the results of its charm's health checks.
+package healthcheck
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+)
+
+// HealthCheckClient defines the methods on the HealthCheck API end point.
+type HealthCheckClient interface {
+	// SetHealth records the result of the unit's health checks.
+	SetHealth(health status.Status, info string, data map[string]interface{}) error
+}
+
+// NewClient creates a new client for accessing the HealthCheck API.
+func NewClient(caller base.APICaller, tag names.UnitTag) HealthCheckClient {
+	return &Client{
+		facade: base.NewFacadeCaller(caller, "HealthCheck"),
+		tag:    tag,
+	}
+}
+
+var _ HealthCheckClient = (*Client)(nil)
+
+// Client provides access to the health check API.
+type Client struct {
+	facade base.FacadeCaller
+	tag    names.UnitTag
+}
+
+// SetHealth is part of the HealthCheckClient interface.
+func (c *Client) SetHealth(health status.Status, info string, data map[string]interface{}) error {
+	var results params.ErrorResults
+	args := params.SetStatus{
+		Entities: []params.EntityStatusArgs{{
+			Tag:    c.tag.String(),
+			Status: health.String(),
+			Info:   info,
+			Data:   data,
+		}},
+	}
+	if err := c.facade.FacadeCall("SetHealth", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
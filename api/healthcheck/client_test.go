@@ -0,0 +1,68 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/healthcheck"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type healthCheckSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&healthCheckSuite{})
+
+func (s *healthCheckSuite) TestSetHealth(c *gc.C) {
+	tag := names.NewUnitTag("wp/1")
+	var called bool
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, response interface{}) error {
+		c.Check(objType, gc.Equals, "HealthCheck")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetHealth")
+		c.Check(arg, jc.DeepEquals, params.SetStatus{
+			Entities: []params.EntityStatusArgs{{
+				Tag:    tag.String(),
+				Status: "unhealthy",
+				Info:   `check "web" failed`,
+				Data:   map[string]interface{}{"web": "connection refused"},
+			}},
+		})
+		c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{}}
+		called = true
+		return nil
+	})
+	client := healthcheck.NewClient(apiCaller, tag)
+	err := client.SetHealth(
+		status.StatusUnhealthy,
+		`check "web" failed`,
+		map[string]interface{}{"web": "connection refused"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *healthCheckSuite) TestSetHealthResultError(c *gc.C) {
+	tag := names.NewUnitTag("wp/1")
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, response interface{}) error {
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{
+			Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+		}}
+		return nil
+	})
+	client := healthcheck.NewClient(apiCaller, tag)
+	err := client.SetHealth(status.StatusHealthy, "", nil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
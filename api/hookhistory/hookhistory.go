@@ -0,0 +1,48 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hookhistory provides the client side of the API used to
+// inspect the hooks recently run by units.
+package hookhistory
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const hookHistoryFacade = "HookHistory"
+
+// Client provides access to the HookHistory API facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new hook history client.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, hookHistoryFacade)
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// HookHistory returns the hooks most recently run by the unit, newest
+// first. If size is positive, at most size records are returned.
+func (c *Client) HookHistory(unit names.UnitTag, size int) ([]params.HookRecord, error) {
+	args := params.HookHistoryRequests{
+		Requests: []params.HookHistoryRequest{{Tag: unit.String(), Size: size}},
+	}
+	var results params.HookHistoryResults
+	if err := c.facade.FacadeCall("HookHistory", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Records, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hookhistory_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/hookhistory"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type hookHistorySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&hookHistorySuite{})
+
+func (s *hookHistorySuite) TestHookHistory(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "HookHistory")
+		c.Check(request, gc.Equals, "HookHistory")
+		c.Check(arg, jc.DeepEquals, params.HookHistoryRequests{
+			Requests: []params.HookHistoryRequest{{Tag: "unit-mysql-0", Size: 5}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.HookHistoryResults{})
+		*(result.(*params.HookHistoryResults)) = params.HookHistoryResults{
+			Results: []params.HookHistoryResult{{
+				Records: []params.HookRecord{{Hook: "install", RelationId: -1}},
+			}},
+		}
+		return nil
+	})
+	client := hookhistory.NewClient(apiCaller)
+	records, err := client.HookHistory(names.NewUnitTag("mysql/0"), 5)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, []params.HookRecord{{Hook: "install", RelationId: -1}})
+}
+
+func (s *hookHistorySuite) TestHookHistoryError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.HookHistoryResults)) = params.HookHistoryResults{
+			Results: []params.HookHistoryResult{{
+				Error: &params.Error{Message: `unit "mysql/0" not found`, Code: params.CodeNotFound},
+			}},
+		}
+		return nil
+	})
+	client := hookhistory.NewClient(apiCaller)
+	_, err := client.HookHistory(names.NewUnitTag("mysql/0"), 0)
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" not found`)
+}
@@ -0,0 +1,94 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package instancedrift provides the client side of the API used by
+// the instance drift worker.
+package instancedrift
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/status"
+)
+
+const instanceDriftFacade = "InstanceDrift"
+
+// MachineInstance holds the instance details of a single machine.
+type MachineInstance struct {
+	// Tag identifies the machine.
+	Tag names.MachineTag
+
+	// InstanceId is the id of the machine's instance, or
+	// empty if the machine has not been provisioned.
+	InstanceId instance.Id
+
+	// InstanceStatus is the current instance status of the
+	// machine.
+	InstanceStatus status.Status
+}
+
+// API provides access to the InstanceDrift API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side InstanceDrift facade.
+func NewAPI(caller base.APICaller) *API {
+	return &API{base.NewFacadeCaller(caller, instanceDriftFacade)}
+}
+
+// MachineInstances returns the instance details of each machine in
+// the model that is expected to have a cloud instance of its own.
+func (api *API) MachineInstances() ([]MachineInstance, error) {
+	var result params.MachineInstancesResult
+	if err := api.facade.FacadeCall("MachineInstances", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	machines := make([]MachineInstance, len(result.Machines))
+	for i, m := range result.Machines {
+		tag, err := names.ParseMachineTag(m.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machines[i] = MachineInstance{
+			Tag:            tag,
+			InstanceId:     m.InstanceId,
+			InstanceStatus: status.Status(m.InstanceStatus),
+		}
+	}
+	return machines, nil
+}
+
+// MarkVanished records that the instances of the given machines can
+// no longer be found in the cloud.
+func (api *API) MarkVanished(tags ...names.MachineTag) error {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var result params.ErrorResults
+	if err := api.facade.FacadeCall("MarkVanished", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.Combine()
+}
+
+// SetLeakedInstances records the instances that are tagged as belonging
+// to the model, but are not known to any of its machines.
+func (api *API) SetLeakedInstances(ids []instance.Id) error {
+	args := params.SetLeakedInstances{InstanceIds: ids}
+	var result params.ErrorResult
+	if err := api.facade.FacadeCall("SetLeakedInstances", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
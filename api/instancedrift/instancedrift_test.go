@@ -0,0 +1,84 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instancedrift_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/instancedrift"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/status"
+)
+
+type InstanceDriftSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&InstanceDriftSuite{})
+
+func (s *InstanceDriftSuite) TestMachineInstances(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "InstanceDrift")
+		c.Check(request, gc.Equals, "MachineInstances")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.MachineInstancesResult{})
+		*(result.(*params.MachineInstancesResult)) = params.MachineInstancesResult{
+			Machines: []params.MachineInstance{{
+				Tag:            "machine-0",
+				InstanceId:     "i-0",
+				InstanceStatus: "running",
+			}, {
+				Tag: "machine-1",
+			}},
+		}
+		return nil
+	})
+	api := instancedrift.NewAPI(apiCaller)
+	machines, err := api.MachineInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, jc.DeepEquals, []instancedrift.MachineInstance{{
+		Tag:            names.NewMachineTag("0"),
+		InstanceId:     "i-0",
+		InstanceStatus: status.StatusRunning,
+	}, {
+		Tag: names.NewMachineTag("1"),
+	}})
+}
+
+func (s *InstanceDriftSuite) TestMarkVanished(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "InstanceDrift")
+		c.Check(request, gc.Equals, "MarkVanished")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	api := instancedrift.NewAPI(apiCaller)
+	err := api.MarkVanished(names.NewMachineTag("0"), names.NewMachineTag("1"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *InstanceDriftSuite) TestSetLeakedInstances(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "InstanceDrift")
+		c.Check(request, gc.Equals, "SetLeakedInstances")
+		c.Check(arg, jc.DeepEquals, params.SetLeakedInstances{
+			InstanceIds: []instance.Id{"i-leaked"},
+		})
+		*(result.(*params.ErrorResult)) = params.ErrorResult{}
+		return nil
+	})
+	api := instancedrift.NewAPI(apiCaller)
+	err := api.SetLeakedInstances([]instance.Id{"i-leaked"})
+	c.Assert(err, jc.ErrorIsNil)
+}
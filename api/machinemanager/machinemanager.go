@@ -5,6 +5,7 @@ package machinemanager
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -36,3 +37,37 @@ func (client *Client) AddMachines(machineParams []params.AddMachineParams) ([]pa
 	}
 	return results.Machines, err
 }
+
+// UpdateMachineSeries changes the series of the machine, and of the
+// units assigned to it, after its OS has been upgraded in place.
+func (client *Client) UpdateMachineSeries(machineName, series string, force bool) error {
+	if client.BestAPIVersion() < 3 {
+		return errors.NotImplementedf("UpdateMachineSeries")
+	}
+	args := params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: names.NewMachineTag(machineName).String()},
+			Series: series,
+			Force:  force,
+		}},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("UpdateMachineSeries", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ModelDrift reports the machines whose instances can no longer be
+// found in the cloud, and any leaked instances tagged as belonging
+// to the model but unknown to it.
+func (client *Client) ModelDrift() (params.ModelDrift, error) {
+	if client.BestAPIVersion() < 4 {
+		return params.ModelDrift{}, errors.NotImplementedf("ModelDrift")
+	}
+	var result params.ModelDrift
+	if err := client.facade.FacadeCall("ModelDrift", nil, &result); err != nil {
+		return params.ModelDrift{}, errors.Trace(err)
+	}
+	return result, nil
+}
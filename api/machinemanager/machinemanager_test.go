@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -116,3 +117,90 @@ func (s *MachinemanagerSuite) TestAddMachinesResultCountInvalid(c *gc.C) {
 		c.Check(err, gc.ErrorMatches, fmt.Sprintf("expected 1 result, got %d", n))
 	}
 }
+
+func (s *MachinemanagerSuite) TestUpdateMachineSeries(c *gc.C) {
+	var called bool
+	apiCaller := testing.BestVersionCaller{BestVersion: 3, APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "UpdateMachineSeries")
+		c.Check(arg, jc.DeepEquals, params.UpdateSeriesArgs{
+			Args: []params.UpdateSeriesArg{{
+				Entity: params.Entity{Tag: "machine-0"},
+				Series: "xenial",
+				Force:  true,
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		called = true
+		return nil
+	}}
+	st := machinemanager.NewClient(apiCaller)
+	err := st.UpdateMachineSeries("0", "xenial", true)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *MachinemanagerSuite) TestUpdateMachineSeriesServerError(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{BestVersion: 3, APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "MSG"},
+			}},
+		}
+		return nil
+	}}
+	st := machinemanager.NewClient(apiCaller)
+	err := st.UpdateMachineSeries("0", "xenial", false)
+	c.Check(err, gc.ErrorMatches, "MSG")
+}
+
+func (s *MachinemanagerSuite) TestUpdateMachineSeriesNeedsVersion3(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{BestVersion: 2, APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s.%s", objType, request)
+		return nil
+	}}
+	st := machinemanager.NewClient(apiCaller)
+	err := st.UpdateMachineSeries("0", "xenial", false)
+	c.Check(err, jc.Satisfies, jujuerrors.IsNotImplemented)
+}
+
+func (s *MachinemanagerSuite) TestModelDrift(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{BestVersion: 4, APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "ModelDrift")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.ModelDrift)) = params.ModelDrift{
+			Vanished: []params.VanishedMachine{{Tag: "machine-1", InstanceId: "i-1"}},
+			Leaked:   []params.LeakedInstance{{InstanceId: "i-leaked"}},
+		}
+		return nil
+	}}
+	st := machinemanager.NewClient(apiCaller)
+	drift, err := st.ModelDrift()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drift, jc.DeepEquals, params.ModelDrift{
+		Vanished: []params.VanishedMachine{{Tag: "machine-1", InstanceId: "i-1"}},
+		Leaked:   []params.LeakedInstance{{InstanceId: "i-leaked"}},
+	})
+}
+
+func (s *MachinemanagerSuite) TestModelDriftError(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{BestVersion: 4, APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	}}
+	st := machinemanager.NewClient(apiCaller)
+	_, err := st.ModelDrift()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *MachinemanagerSuite) TestModelDriftNeedsVersion4(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{BestVersion: 3, APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s.%s", objType, request)
+		return nil
+	}}
+	st := machinemanager.NewClient(apiCaller)
+	_, err := st.ModelDrift()
+	c.Check(err, jc.Satisfies, jujuerrors.IsNotImplemented)
+}
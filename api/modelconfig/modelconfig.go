@@ -4,6 +4,8 @@
 package modelconfig
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/config"
@@ -65,3 +67,73 @@ func (c *Client) ModelUnset(keys ...string) error {
 	args := params.ModelUnset{Keys: keys}
 	return c.facade.FacadeCall("ModelUnset", args, nil)
 }
+
+// ModelDefaults returns the default values for various sources used when
+// creating a new model.
+func (c *Client) ModelDefaults() (config.ModelDefaultAttributes, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotImplementedf("ModelDefaults")
+	}
+	result := params.ModelDefaultsResult{}
+	err := c.facade.FacadeCall("ModelDefaults", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	values := make(config.ModelDefaultAttributes)
+	for name, val := range result.Config {
+		defaultValue := config.AttributeDefaultValues{
+			Default:    val.Default,
+			Controller: val.Controller,
+		}
+		for _, region := range val.Regions {
+			defaultValue.Regions = append(defaultValue.Regions, config.RegionDefaultValue{
+				Name:  region.RegionName,
+				Value: region.Value,
+			})
+		}
+		values[name] = defaultValue
+	}
+	return values, nil
+}
+
+// SetModelDefaults updates the specified default model config values,
+// either for the controller or, if cloudRegion is not empty, for the
+// specified cloud region.
+func (c *Client) SetModelDefaults(cloudRegion string, config map[string]interface{}) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotImplementedf("SetModelDefaults")
+	}
+	args := params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			CloudRegion: cloudRegion,
+			Config:      config,
+		}},
+	}
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("SetModelDefaults", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// UnsetModelDefaults removes the specified default model config values,
+// either for the controller or, if cloudRegion is not empty, for the
+// specified cloud region.
+func (c *Client) UnsetModelDefaults(cloudRegion string, keys ...string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotImplementedf("UnsetModelDefaults")
+	}
+	args := params.UnsetModelDefaults{
+		Keys: []params.ModelUnsetKeys{{
+			CloudRegion: cloudRegion,
+			Keys:        keys,
+		}},
+	}
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("UnsetModelDefaults", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
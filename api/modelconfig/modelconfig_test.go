@@ -4,6 +4,7 @@
 package modelconfig_test
 
 import (
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -127,3 +128,127 @@ func (s *modelconfigrSuite) TestModelUnset(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *modelconfigrSuite) TestModelDefaults(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ModelDefaults")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ModelDefaultsResult{})
+			results := result.(*params.ModelDefaultsResult)
+			results.Config = map[string]params.ModelDefaults{
+				"foo": {"bar", "model", []params.RegionDefaults{{
+					"dummy-region",
+					"dummy-value"}}},
+			}
+			return nil
+		},
+	}
+	client := modelconfig.NewClient(apiCaller)
+	result, err := client.ModelDefaults()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, config.ModelDefaultAttributes{
+		"foo": {"bar", "model", []config.RegionDefaultValue{{
+			"dummy-region",
+			"dummy-value"}}},
+	})
+}
+
+func (s *modelconfigrSuite) TestSetModelDefaults(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "SetModelDefaults")
+			c.Check(a, jc.DeepEquals, params.SetModelDefaults{
+				Config: []params.ModelDefaultValues{{
+					CloudRegion: "dummy-region",
+					Config: map[string]interface{}{
+						"some-name":  "value",
+						"other-name": true,
+					},
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: nil}},
+			}
+			called = true
+			return nil
+		},
+	}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.SetModelDefaults("dummy-region", map[string]interface{}{
+		"some-name":  "value",
+		"other-name": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelconfigrSuite) TestUnsetModelDefaults(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "UnsetModelDefaults")
+			c.Check(a, jc.DeepEquals, params.UnsetModelDefaults{
+				Keys: []params.ModelUnsetKeys{{
+					Keys: []string{"foo", "bar"},
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			called = true
+			return nil
+		},
+	}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.UnsetModelDefaults("", "foo", "bar")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelconfigrSuite) TestModelDefaultsNeedVersion2(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 1,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected call to %s.%s", objType, request)
+			return nil
+		},
+	}
+	client := modelconfig.NewClient(apiCaller)
+	_, err := client.ModelDefaults()
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+	err = client.SetModelDefaults("", map[string]interface{}{"foo": "bar"})
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+	err = client.UnsetModelDefaults("", "foo")
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+}
@@ -85,6 +85,27 @@ func (st *State) WatchMachineErrorRetry() (watcher.NotifyWatcher, error) {
 	return w, nil
 }
 
+// WatchApplicationCharms returns a NotifyWatcher that notifies when
+// the charm of any application in the model changes, including when
+// it is upgraded. Controllers older than version 4 of the facade do
+// not support it, and an error satisfying errors.IsNotImplemented is
+// returned.
+func (st *State) WatchApplicationCharms() (watcher.NotifyWatcher, error) {
+	if st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotImplementedf("WatchApplicationCharms")
+	}
+	var result params.NotifyWatchResult
+	err := st.facade.FacadeCall("WatchApplicationCharms", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // StateAddresses returns the list of addresses used to connect to the state.
 func (st *State) StateAddresses() ([]string, error) {
 	var result params.StringsResult
@@ -21,6 +21,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/provisioner"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/common"
@@ -521,6 +522,36 @@ func (s *provisionerSuite) TestWatchModelMachines(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *provisionerSuite) TestWatchApplicationCharms(c *gc.C) {
+	w, err := s.provisioner.WatchApplicationCharms()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	// Deploying an application triggers a change.
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	wc.AssertOneChange()
+}
+
+func (s *provisionerSuite) TestWatchApplicationCharmsNeedsVersion4(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected call to %s.%s", objType, request)
+			return nil
+		},
+	}
+	_, err := provisioner.NewState(apiCaller).WatchApplicationCharms()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *provisionerSuite) TestStateAddresses(c *gc.C) {
 	err := s.machine.SetProviderAddresses(network.NewAddress("0.1.2.3"))
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,58 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the client side of the API used by model
+// administrators to inspect the secrets stored by charms.
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const secretsFacade = "Secrets"
+
+// Client provides access to the Secrets API facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new secrets client.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, secretsFacade)
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ListSecrets returns the details of all the secrets in the model,
+// without their values.
+func (c *Client) ListSecrets() ([]params.SecretDetails, error) {
+	var result params.ListSecretResults
+	if err := c.facade.FacadeCall("ListSecrets", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Results, nil
+}
+
+// ShowSecret returns the details and audit trail of the secret with
+// the given ID and, if reveal is true, its value. Revealing the value
+// is recorded in the secret's audit trail.
+func (c *Client) ShowSecret(id string, reveal bool) (params.ShowSecretResult, error) {
+	args := params.ShowSecretArgs{
+		Args: []params.ShowSecretArg{{ID: id, Reveal: reveal}},
+	}
+	var results params.ShowSecretResults
+	if err := c.facade.FacadeCall("ShowSecrets", args, &results); err != nil {
+		return params.ShowSecretResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ShowSecretResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ShowSecretResult{}, result.Error
+	}
+	return result, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type secretsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) TestListSecrets(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(request, gc.Equals, "ListSecrets")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ListSecretResults{})
+		*(result.(*params.ListSecretResults)) = params.ListSecretResults{
+			Results: []params.SecretDetails{{ID: "9f5a6ab4", OwnerTag: "application-mysql"}},
+		}
+		return nil
+	})
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []params.SecretDetails{{ID: "9f5a6ab4", OwnerTag: "application-mysql"}})
+}
+
+func (s *secretsSuite) TestShowSecret(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(request, gc.Equals, "ShowSecrets")
+		c.Check(arg, jc.DeepEquals, params.ShowSecretArgs{
+			Args: []params.ShowSecretArg{{ID: "9f5a6ab4", Reveal: true}},
+		})
+		*(result.(*params.ShowSecretResults)) = params.ShowSecretResults{
+			Results: []params.ShowSecretResult{{
+				Secret: &params.SecretDetails{ID: "9f5a6ab4"},
+				Value:  map[string]string{"password": "sekrit"},
+			}},
+		}
+		return nil
+	})
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ShowSecret("9f5a6ab4", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Secret.ID, gc.Equals, "9f5a6ab4")
+	c.Assert(result.Value, jc.DeepEquals, map[string]string{"password": "sekrit"})
+}
+
+func (s *secretsSuite) TestShowSecretError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ShowSecretResults)) = params.ShowSecretResults{
+			Results: []params.ShowSecretResult{{
+				Error: &params.Error{Message: `secret "9f5a6ab4" not found`, Code: params.CodeNotFound},
+			}},
+		}
+		return nil
+	})
+	client := secrets.NewClient(apiCaller)
+	_, err := client.ShowSecret("9f5a6ab4", false)
+	c.Assert(err, gc.ErrorMatches, `secret "9f5a6ab4" not found`)
+}
@@ -0,0 +1,154 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// CreateSecret creates a new secret, and returns its ID.
+func (st *State) CreateSecret(arg params.CreateSecretArg) (string, error) {
+	if st.facade.BestAPIVersion() < 6 {
+		return "", errors.NotImplementedf("CreateSecret")
+	}
+	args := params.CreateSecretArgs{Args: []params.CreateSecretArg{arg}}
+	var results params.StringResults
+	if err := st.facade.FacadeCall("CreateSecrets", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// UpdateSecret changes the value or settings of a secret.
+func (st *State) UpdateSecret(arg params.UpdateSecretArg) error {
+	if st.facade.BestAPIVersion() < 6 {
+		return errors.NotImplementedf("UpdateSecret")
+	}
+	args := params.UpdateSecretArgs{Args: []params.UpdateSecretArg{arg}}
+	return st.secretsCall("UpdateSecrets", args)
+}
+
+// SecretValue returns the value of a secret, identified either by its
+// ID or by the label given to it by the unit or its application.
+func (st *State) SecretValue(arg params.GetSecretArg) (map[string]string, error) {
+	if st.facade.BestAPIVersion() < 6 {
+		return nil, errors.NotImplementedf("SecretValue")
+	}
+	args := params.GetSecretArgs{Args: []params.GetSecretArg{arg}}
+	var results params.SecretValueResults
+	if err := st.facade.FacadeCall("GetSecretValues", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Data, nil
+}
+
+// GrantSecret allows the units of the given application to read the
+// secret with the given ID.
+func (st *State) GrantSecret(id string, app names.ApplicationTag) error {
+	if st.facade.BestAPIVersion() < 6 {
+		return errors.NotImplementedf("GrantSecret")
+	}
+	args := params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{ID: id, ApplicationTag: app.String()}},
+	}
+	return st.secretsCall("GrantSecrets", args)
+}
+
+// RevokeSecret stops the units of the given application from reading
+// the secret with the given ID.
+func (st *State) RevokeSecret(id string, app names.ApplicationTag) error {
+	if st.facade.BestAPIVersion() < 6 {
+		return errors.NotImplementedf("RevokeSecret")
+	}
+	args := params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{ID: id, ApplicationTag: app.String()}},
+	}
+	return st.secretsCall("RevokeSecrets", args)
+}
+
+// SecretRotated records that the secret-rotate hook has been run for
+// the secret with the given ID.
+func (st *State) SecretRotated(id string) error {
+	if st.facade.BestAPIVersion() < 6 {
+		return errors.NotImplementedf("SecretRotated")
+	}
+	args := params.GetSecretArgs{Args: []params.GetSecretArg{{ID: id}}}
+	return st.secretsCall("SecretsRotated", args)
+}
+
+func (st *State) secretsCall(method string, args interface{}) error {
+	var results params.ErrorResults
+	if err := st.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// WatchSecretRotations returns a StringsWatcher that notifies of
+// changes to the secrets owned by the unit and by its application.
+func (u *Unit) WatchSecretRotations() (watcher.StringsWatcher, error) {
+	if u.st.facade.BestAPIVersion() < 6 {
+		return nil, errors.NotImplementedf("WatchSecretRotations")
+	}
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("WatchSecretRotations", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(u.st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// SecretRotations returns the rotation schedules of the secrets the
+// unit is responsible for rotating.
+func (u *Unit) SecretRotations() ([]params.SecretRotation, error) {
+	if u.st.facade.BestAPIVersion() < 6 {
+		return nil, errors.NotImplementedf("SecretRotations")
+	}
+	var results params.SecretRotationResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("SecretRotations", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Rotations, nil
+}
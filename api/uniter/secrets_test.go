@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&secretsSuite{})
+
+type secretsSuite struct {
+	coretesting.BaseSuite
+}
+
+func (s *secretsSuite) TestCreateSecret(c *gc.C) {
+	arg := params.CreateSecretArg{
+		OwnerTag: "application-mysql",
+		Label:    "password",
+		Data:     map[string]string{"password": "sekrit"},
+	}
+	var called bool
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, a, result interface{}) error {
+		c.Check(objType, gc.Equals, "Uniter")
+		c.Check(request, gc.Equals, "CreateSecrets")
+		c.Check(a, jc.DeepEquals, params.CreateSecretArgs{Args: []params.CreateSecretArg{arg}})
+		c.Assert(result, gc.FitsTypeOf, &params.StringResults{})
+		*(result.(*params.StringResults)) = params.StringResults{
+			Results: []params.StringResult{{Result: "secret-id"}},
+		}
+		called = true
+		return nil
+	})
+
+	st := uniter.NewState(testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 6}, names.NewUnitTag("mysql/0"))
+	id, err := st.CreateSecret(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(id, gc.Equals, "secret-id")
+}
+
+func (s *secretsSuite) TestSecretValue(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, a, result interface{}) error {
+		c.Check(request, gc.Equals, "GetSecretValues")
+		c.Check(a, jc.DeepEquals, params.GetSecretArgs{
+			Args: []params.GetSecretArg{{Label: "password"}},
+		})
+		*(result.(*params.SecretValueResults)) = params.SecretValueResults{
+			Results: []params.SecretValueResult{{Data: map[string]string{"password": "sekrit"}}},
+		}
+		return nil
+	})
+
+	st := uniter.NewState(testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 6}, names.NewUnitTag("mysql/0"))
+	value, err := st.SecretValue(params.GetSecretArg{Label: "password"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"password": "sekrit"})
+}
+
+func (s *secretsSuite) TestGrantSecretError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, a, result interface{}) error {
+		c.Check(request, gc.Equals, "GrantSecrets")
+		c.Check(a, jc.DeepEquals, params.GrantRevokeSecretArgs{
+			Args: []params.GrantRevokeSecretArg{{ID: "secret-id", ApplicationTag: "application-wordpress"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+
+	st := uniter.NewState(testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 6}, names.NewUnitTag("mysql/0"))
+	err := st.GrantSecret("secret-id", names.NewApplicationTag("wordpress"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *secretsSuite) TestSecretsNeedVersion6(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, a, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+
+	st := uniter.NewState(testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 5}, names.NewUnitTag("mysql/0"))
+	_, err := st.CreateSecret(params.CreateSecretArg{OwnerTag: "application-mysql"})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	_, err = st.SecretValue(params.GetSecretArg{Label: "password"})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	err = st.GrantSecret("secret-id", names.NewApplicationTag("wordpress"))
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
@@ -278,6 +278,30 @@ func (u *Unit) Resolved() (params.ResolvedMode, error) {
 	return result.Mode, nil
 }
 
+// Series returns the series of the unit, which is the series of the
+// machine it is assigned to.
+func (u *Unit) Series() (string, error) {
+	if u.st.BestAPIVersion() < 5 {
+		return "", errors.NotImplementedf("Series")
+	}
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("Series", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
 // AssignedMachine returns the unit's assigned machine tag or an error
 // satisfying params.IsCodeNotAssigned when the unit has no assigned
 // machine..
@@ -738,3 +762,154 @@ func (u *Unit) NetworkConfig(bindingName string) ([]params.NetworkConfig, error)
 
 	return result.Config, nil
 }
+
+// GoalState returns the intended topology of the unit's application
+// and of the applications related to it.
+func (u *Unit) GoalState() (params.GoalState, error) {
+	if u.st.BestAPIVersion() < 7 {
+		return params.GoalState{}, errors.NotImplementedf("GoalState")
+	}
+	var results params.GoalStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("GoalStates", args, &results)
+	if err != nil {
+		return params.GoalState{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.GoalState{}, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.GoalState{}, result.Error
+	}
+	return *result.Result, nil
+}
+
+// CloudSpec returns the model's cloud specification, including its
+// credential. Only units of trusted applications may read it.
+func (u *Unit) CloudSpec() (*params.CloudSpec, error) {
+	if u.st.BestAPIVersion() < 8 {
+		return nil, errors.NotImplementedf("CloudSpec")
+	}
+	var results params.CloudSpecResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("CloudSpec", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
+
+// State returns the state the uniter has persisted for the unit on the
+// controller.
+func (u *Unit) State() (params.UnitStateResult, error) {
+	if u.st.BestAPIVersion() < 9 {
+		return params.UnitStateResult{}, errors.NotImplementedf("State")
+	}
+	var results params.UnitStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("State", args, &results)
+	if err != nil {
+		return params.UnitStateResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.UnitStateResult{}, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.UnitStateResult{}, result.Error
+	}
+	return result, nil
+}
+
+// SetState persists the given uniter state for the unit on the
+// controller. The tag in unitState is ignored.
+func (u *Unit) SetState(unitState params.SetUnitStateArg) error {
+	if u.st.BestAPIVersion() < 9 {
+		return errors.NotImplementedf("SetState")
+	}
+	unitState.Tag = u.tag.String()
+	var result params.ErrorResults
+	args := params.SetUnitStateArgs{
+		Entities: []params.SetUnitStateArg{unitState},
+	}
+	err := u.st.facade.FacadeCall("SetState", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// AddHookHistory records the given hook executions for the unit on the
+// controller.
+func (u *Unit) AddHookHistory(records []params.HookRecord) error {
+	if u.st.BestAPIVersion() < 10 {
+		return errors.NotImplementedf("AddHookHistory")
+	}
+	var result params.ErrorResults
+	args := params.HookHistoryArgs{
+		Args: []params.HookHistoryArg{{
+			Tag:     u.tag.String(),
+			Records: records,
+		}},
+	}
+	err := u.st.facade.FacadeCall("AddHookHistory", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// ReplayHooksRequested reports whether the unit has been asked to run
+// the hooks it skipped again.
+func (u *Unit) ReplayHooksRequested() (bool, error) {
+	if u.st.BestAPIVersion() < 11 {
+		return false, errors.NotImplementedf("ReplayHooksRequested")
+	}
+	var results params.BoolResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("ReplayHooksRequested", args, &results)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return false, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.Result, nil
+}
+
+// ClearReplayHooks removes any request to replay the unit's skipped
+// hooks.
+func (u *Unit) ClearReplayHooks() error {
+	if u.st.BestAPIVersion() < 11 {
+		return errors.NotImplementedf("ClearReplayHooks")
+	}
+	var result params.ErrorResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("ClearReplayHooks", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
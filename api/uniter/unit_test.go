@@ -255,6 +255,26 @@ func (s *unitSuite) TestResolve(c *gc.C) {
 	c.Assert(mode, gc.Equals, params.ResolvedNone)
 }
 
+func (s *unitSuite) TestSeries(c *gc.C) {
+	series, err := s.apiUnit.Series()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(series, gc.Equals, "quantal")
+
+	err = s.wordpressMachine.UpdateMachineSeries("trusty", true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	series, err = s.apiUnit.Series()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(series, gc.Equals, "trusty")
+}
+
+func (s *unitSuite) TestSeriesNeedsVersion5(c *gc.C) {
+	s.patchNewState(c, uniter.NewStateV4)
+
+	_, err := s.apiUnit.Series()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *unitSuite) TestAssignedMachine(c *gc.C) {
 	machineTag, err := s.apiUnit.AssignedMachine()
 	c.Assert(err, jc.ErrorIsNil)
@@ -886,3 +906,140 @@ func (s *unitMetricBatchesSuite) TestSendMetricBatch(c *gc.C) {
 	c.Assert(batches[0].Metrics()[0].Key, gc.Equals, "pings")
 	c.Assert(batches[0].Metrics()[0].Value, gc.Equals, "5")
 }
+
+func (s *unitSuite) TestGoalState(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	goalState, err := s.apiUnit.GoalState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(goalState, jc.DeepEquals, params.GoalState{
+		Units: params.UnitsGoalState{
+			"wordpress/0": {Status: "waiting"},
+		},
+		Relations: map[string]params.UnitsGoalState{
+			"db": {"mysql": {Status: "joined"}},
+		},
+	})
+}
+
+func (s *unitSuite) TestGoalStateNeedsVersion7(c *gc.C) {
+	s.patchNewState(c, uniter.NewStateV4)
+
+	_, err := s.apiUnit.GoalState()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestCloudSpec(c *gc.C) {
+	_, err := s.apiUnit.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" is not trusted to access the cloud credential`)
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+
+	err = s.wordpressService.SetTrust(true)
+	c.Assert(err, jc.ErrorIsNil)
+	spec, err := s.apiUnit.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, jc.DeepEquals, &params.CloudSpec{
+		Type: "dummy",
+		Name: "dummy",
+	})
+}
+
+func (s *unitSuite) TestCloudSpecNeedsVersion8(c *gc.C) {
+	s.patchNewState(c, uniter.NewStateV4)
+
+	_, err := s.apiUnit.CloudSpec()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestState(c *gc.C) {
+	unitState, err := s.apiUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, params.UnitStateResult{})
+
+	uniterState := "kind: continue\nstep: pending\n"
+	relationState := map[string]string{"1": "members: {}\n"}
+	err = s.apiUnit.SetState(params.SetUnitStateArg{
+		UniterState:   &uniterState,
+		RelationState: &relationState,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitState, err = s.apiUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, params.UnitStateResult{
+		UniterState:   uniterState,
+		RelationState: relationState,
+	})
+}
+
+func (s *unitSuite) TestStateNeedsVersion9(c *gc.C) {
+	s.patchNewState(c, uniter.NewStateV4)
+
+	_, err := s.apiUnit.State()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	uniterState := "kind: continue\nstep: pending\n"
+	err = s.apiUnit.SetState(params.SetUnitStateArg{UniterState: &uniterState})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestAddHookHistory(c *gc.C) {
+	now := time.Now()
+	err := s.apiUnit.AddHookHistory([]params.HookRecord{{
+		Hook:       "install",
+		RelationId: -1,
+		Started:    now,
+		Finished:   now.Add(time.Second),
+		ExitCode:   1,
+		Error:      "exit status 1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	records, err := s.wordpressUnit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0].Hook, gc.Equals, "install")
+	c.Check(records[0].ExitCode, gc.Equals, 1)
+	c.Check(records[0].Error, gc.Equals, "exit status 1")
+}
+
+func (s *unitSuite) TestAddHookHistoryNeedsVersion10(c *gc.C) {
+	s.patchNewState(c, uniter.NewStateV4)
+
+	err := s.apiUnit.AddHookHistory([]params.HookRecord{{Hook: "install", RelationId: -1}})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestReplayHooks(c *gc.C) {
+	requested, err := s.apiUnit.ReplayHooksRequested()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requested, jc.IsFalse)
+
+	skippedHooks := []string{"config-changed"}
+	err = s.apiUnit.SetState(params.SetUnitStateArg{SkippedHooks: &skippedHooks})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressUnit.RequestReplayHooks()
+	c.Assert(err, jc.ErrorIsNil)
+
+	requested, err = s.apiUnit.ReplayHooksRequested()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requested, jc.IsTrue)
+
+	err = s.apiUnit.ClearReplayHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	requested, err = s.apiUnit.ReplayHooksRequested()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requested, jc.IsFalse)
+}
+
+func (s *unitSuite) TestReplayHooksNeedsVersion11(c *gc.C) {
+	s.patchNewState(c, uniter.NewStateV4)
+
+	_, err := s.apiUnit.ReplayHooksRequested()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	err = s.apiUnit.ClearReplayHooks()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
@@ -72,9 +72,16 @@ func newStateForVersionFn(version int) func(base.APICaller, names.UnitTag) *Stat
 // newStateV4 creates a new client-side Uniter facade, version 4.
 var newStateV4 = newStateForVersionFn(4)
 
-// NewState creates a new client-side Uniter facade.
+// NewState creates a new client-side Uniter facade, using the most
+// recent version supported by both the client and the API server.
 // Defined like this to allow patching during tests.
-var NewState = newStateV4
+var NewState = func(caller base.APICaller, authTag names.UnitTag) *State {
+	version := caller.BestFacadeVersion(uniterFacade)
+	if version < 4 {
+		version = 4
+	}
+	return newStateForVersion(caller, authTag, version)
+}
 
 // BestAPIVersion returns the API version that we were able to
 // determine is supported by both the client and the API Server.
@@ -15,6 +15,7 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Action", 2, NewActionAPI)
+	common.RegisterStandardFacade("Action", 3, NewActionAPI)
 }
 
 // ActionAPI implements the client API for interacting with Actions
@@ -112,7 +113,8 @@ func (a *ActionAPI) FindActionsByNames(arg params.FindActionsByNames) (params.Ac
 // Enqueue takes a list of Actions and queues them up to be executed by
 // the designated ActionReceiver, returning the params.Action for each
 // enqueued Action, or an error if there was a problem enqueueing the
-// Action.
+// Action. A receiver of the form "<application>/leader" designates the
+// current leader of the application.
 func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	if err := a.check.ChangeAllowed(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
@@ -122,7 +124,12 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	response := params.ActionResults{Results: make([]params.ActionResult, len(arg.Actions))}
 	for i, action := range arg.Actions {
 		currentResult := &response.Results[i]
-		receiver, err := tagToActionReceiver(action.Receiver)
+		receiverTag, err := resolveReceiver(a.state, action.Receiver)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		receiver, err := tagToActionReceiver(receiverTag)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -6,6 +6,7 @@ package action_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -273,6 +274,33 @@ func (s *actionSuite) TestEnqueue(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *actionSuite) TestEnqueueOnLeader(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", s.wordpressUnit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	arg := params.Actions{
+		Actions: []params.Action{
+			{Receiver: "wordpress/leader", Name: "fakeaction", Parameters: map[string]interface{}{}},
+			// No unit of mysql is leader.
+			{Receiver: "mysql/leader", Name: "fakeaction", Parameters: map[string]interface{}{}},
+		},
+	}
+	res, err := s.action.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 2)
+
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[0].Action, gc.NotNil)
+	c.Check(res.Results[0].Action.Receiver, gc.Equals, s.wordpressUnit.Tag().String())
+
+	c.Check(res.Results[1].Error, gc.ErrorMatches, `cannot resolve "mysql/leader": leader of application "mysql" not found`)
+	c.Check(res.Results[1].Action, gc.IsNil)
+
+	actions, err := s.wordpressUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actions, gc.HasLen, 1)
+}
+
 type testCaseAction struct {
 	Name       string
 	Parameters map[string]interface{}
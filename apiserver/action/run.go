@@ -17,8 +17,32 @@ import (
 
 // getAllUnitNames returns a sequence of valid Unit objects from state. If any
 // of the service names or unit names are not found, an error is returned.
-func getAllUnitNames(st *state.State, units, services []string) (result []names.Tag, err error) {
-	unitsSet := set.NewStrings(units...)
+// Unit names of the form "<application>/leader" are resolved to the current
+// leader of the application. If selectors are supplied, only units matching
+// all of them are returned; selectors without any units or services apply
+// to every unit in the model.
+func getAllUnitNames(st *state.State, units, services, selectors []string) (result []names.Tag, err error) {
+	unitSelectors, err := parseUnitSelectors(selectors)
+	if err != nil {
+		return nil, err
+	}
+	unitsSet := set.NewStrings()
+	for _, name := range units {
+		unitName, err := resolveUnitName(st, name)
+		if err != nil {
+			return nil, err
+		}
+		unitsSet.Add(unitName)
+	}
+	if len(unitSelectors) > 0 && len(units) == 0 && len(services) == 0 {
+		allServices, err := st.AllApplications()
+		if err != nil {
+			return nil, err
+		}
+		for _, service := range allServices {
+			services = append(services, service.Name())
+		}
+	}
 	for _, name := range services {
 		service, err := st.Application(name)
 		if err != nil {
@@ -36,6 +60,19 @@ func getAllUnitNames(st *state.State, units, services []string) (result []names.
 		if !names.IsValidUnit(unitName) {
 			return nil, errors.Errorf("invalid unit name %q", unitName)
 		}
+		if len(unitSelectors) > 0 {
+			unit, err := st.Unit(unitName)
+			if err != nil {
+				return nil, err
+			}
+			match, err := unitMatches(unit, unitSelectors)
+			if err != nil {
+				return nil, err
+			}
+			if !match {
+				continue
+			}
+		}
 		result = append(result, names.NewUnitTag(unitName))
 	}
 	return result, nil
@@ -48,7 +85,7 @@ func (a *ActionAPI) Run(run params.RunParams) (results params.ActionResults, err
 		return results, errors.Trace(err)
 	}
 
-	units, err := getAllUnitNames(a.state, run.Units, run.Applications)
+	units, err := getAllUnitNames(a.state, run.Units, run.Applications, run.UnitsMatching)
 	if err != nil {
 		return results, errors.Trace(err)
 	}
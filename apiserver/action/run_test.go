@@ -4,6 +4,8 @@
 package action_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -14,6 +16,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing"
 )
 
@@ -56,8 +59,12 @@ func (s *runSuite) addUnit(c *gc.C, service *state.Application) *state.Unit {
 func (s *runSuite) TestGetAllUnitNames(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	magic, err := s.State.AddApplication(state.AddApplicationArgs{Name: "magic", Charm: charm})
+	magic0 := s.addUnit(c, magic)
 	s.addUnit(c, magic)
-	s.addUnit(c, magic)
+	err = magic0.SetAgentStatus(status.StatusInfo{Status: status.StatusError, Message: "hook failed"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.LeadershipClaimer().ClaimLeadership("magic", "magic/1", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
 
 	notAssigned, err := s.State.AddApplication(state.AddApplicationArgs{Name: "not-assigned", Charm: charm})
 	c.Assert(err, jc.ErrorIsNil)
@@ -83,11 +90,12 @@ func (s *runSuite) TestGetAllUnitNames(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	for i, test := range []struct {
-		message  string
-		expected []string
-		units    []string
-		services []string
-		error    string
+		message   string
+		expected  []string
+		units     []string
+		services  []string
+		selectors []string
+		error     string
 	}{{
 		message: "no units, expected nil slice",
 	}, {
@@ -122,9 +130,41 @@ func (s *runSuite) TestGetAllUnitNames(c *gc.C) {
 		services: []string{"magic"},
 		units:    []string{"magic/0"},
 		expected: []string{"magic/0", "magic/1"},
+	}, {
+		message:  "Asking for the leader of a service",
+		units:    []string{"magic/leader"},
+		expected: []string{"magic/1"},
+	}, {
+		message: "Asking for the leader of a service with no leader",
+		units:   []string{"no-units/leader"},
+		error:   `cannot resolve "no-units/leader": leader of application "no-units" not found`,
+	}, {
+		message:   "Asking for units of a service matching a status",
+		services:  []string{"magic"},
+		selectors: []string{"status=error"},
+		expected:  []string{"magic/0"},
+	}, {
+		message:   "Asking for all units matching a series",
+		selectors: []string{"series=quantal"},
+		expected:  []string{"magic/0", "magic/1", "not-assigned/0", "wordpress/0", "logging/0"},
+	}, {
+		message:   "Asking for units matching several selectors",
+		units:     []string{"magic/0", "magic/1", "wordpress/0"},
+		selectors: []string{"series=quantal", "agent-status=error"},
+		expected:  []string{"magic/0"},
+	}, {
+		message:   "Asking with a malformed selector",
+		services:  []string{"magic"},
+		selectors: []string{"status"},
+		error:     `unit selector "status" not valid`,
+	}, {
+		message:   "Asking with an unknown selector key",
+		services:  []string{"magic"},
+		selectors: []string{"colour=blue"},
+		error:     `unit selector key "colour" not supported`,
 	}} {
 		c.Logf("%v: %s", i, test.message)
-		result, err := action.GetAllUnitNames(s.State, test.units, test.services)
+		result, err := action.GetAllUnitNames(s.State, test.units, test.services, test.selectors)
 		if test.error == "" {
 			c.Check(err, jc.ErrorIsNil)
 			var units []string
@@ -0,0 +1,124 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// leaderSuffix identifies a unit name of the form "<application>/leader",
+// which refers to whichever unit currently holds leadership of the
+// application.
+const leaderSuffix = "/leader"
+
+// leaderApplication returns the application name and true if the supplied
+// unit name takes the form "<application>/leader".
+func leaderApplication(unitName string) (string, bool) {
+	if !strings.HasSuffix(unitName, leaderSuffix) {
+		return "", false
+	}
+	appName := strings.TrimSuffix(unitName, leaderSuffix)
+	if !names.IsValidApplication(appName) {
+		return "", false
+	}
+	return appName, true
+}
+
+// resolveUnitName returns the name of the current leader if the supplied
+// name takes the form "<application>/leader"; any other name is returned
+// unchanged.
+func resolveUnitName(st *state.State, unitName string) (string, error) {
+	appName, ok := leaderApplication(unitName)
+	if !ok {
+		return unitName, nil
+	}
+	leader, err := st.ApplicationLeader(appName)
+	if err != nil {
+		return "", errors.Annotatef(err, "cannot resolve %q", unitName)
+	}
+	return leader, nil
+}
+
+// resolveReceiver returns the tag of the current leader if the supplied
+// receiver takes the form "<application>/leader"; any other receiver is
+// returned unchanged.
+func resolveReceiver(st *state.State, receiver string) (string, error) {
+	if _, ok := leaderApplication(receiver); !ok {
+		return receiver, nil
+	}
+	unitName, err := resolveUnitName(st, receiver)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return names.NewUnitTag(unitName).String(), nil
+}
+
+// unitSelector reports whether a unit satisfies a single "key=value"
+// selector.
+type unitSelector func(*state.Unit) (bool, error)
+
+// unitSelectors maps each supported selector key to a function creating
+// the selector for a given value.
+var unitSelectors = map[string]func(value string) unitSelector{
+	"status": func(value string) unitSelector {
+		return func(unit *state.Unit) (bool, error) {
+			info, err := unit.Status()
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			return string(info.Status) == value, nil
+		}
+	},
+	"agent-status": func(value string) unitSelector {
+		return func(unit *state.Unit) (bool, error) {
+			info, err := unit.AgentStatus()
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			return string(info.Status) == value, nil
+		}
+	},
+	"series": func(value string) unitSelector {
+		return func(unit *state.Unit) (bool, error) {
+			return unit.Series() == value, nil
+		}
+	},
+}
+
+// parseUnitSelectors converts selectors of the form "key=value" into
+// unitSelectors.
+func parseUnitSelectors(selectors []string) ([]unitSelector, error) {
+	result := make([]unitSelector, len(selectors))
+	for i, selector := range selectors {
+		parts := strings.SplitN(selector, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, errors.NotValidf("unit selector %q", selector)
+		}
+		newSelector, ok := unitSelectors[parts[0]]
+		if !ok {
+			return nil, errors.NotSupportedf("unit selector key %q", parts[0])
+		}
+		result[i] = newSelector(parts[1])
+	}
+	return result, nil
+}
+
+// unitMatches reports whether the unit satisfies all the selectors.
+func unitMatches(unit *state.Unit, selectors []unitSelector) (bool, error) {
+	for _, selector := range selectors {
+		match, err := selector(unit)
+		if err != nil {
+			return false, errors.Annotatef(err, "cannot match unit %q", unit.Name())
+		}
+		if !match {
+			return false, nil
+		}
+	}
+	return true, nil
+}
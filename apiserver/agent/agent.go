@@ -112,6 +112,7 @@ func (api *AgentAPIV2) StateServingInfo() (result params.StateServingInfo, err e
 		CAPrivateKey:   info.CAPrivateKey,
 		SharedSecret:   info.SharedSecret,
 		SystemIdentity: info.SystemIdentity,
+		SecretsKey:     info.SecretsKey,
 	}
 
 	return result, nil
@@ -24,9 +24,11 @@ import (
 	"github.com/juju/juju/apiserver/common/apihttp"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/state"
+	statewatcher "github.com/juju/juju/state/watcher"
 )
 
 var logger = loggo.GetLogger("juju.apiserver")
@@ -51,6 +53,7 @@ type Server struct {
 	modelUUID         string
 	authCtxt          *authContext
 	newObserver       observer.ObserverFactory
+	configChanged     func(controller.Config)
 	connCount         struct {
 		sync.RWMutex
 		value int64
@@ -77,6 +80,12 @@ type ServerConfig struct {
 	// notified of key events during API requests.
 	NewObserver observer.ObserverFactory
 
+	// ControllerConfigChanged, if non-nil, is called with the new
+	// controller config whenever it changes while the server is
+	// running. This allows components configured outside of the API
+	// server, such as the audit observer, to pick up the changes.
+	ControllerConfigChanged func(controller.Config)
+
 	// StatePool only exists to support testing.
 	StatePool *state.StatePool
 }
@@ -220,15 +229,16 @@ func newServer(s *state.State, lis *net.TCPListener, cfg ServerConfig) (_ *Serve
 	}
 
 	srv := &Server{
-		newObserver: cfg.NewObserver,
-		state:       s,
-		statePool:   stPool,
-		lis:         newChangeCertListener(lis, cfg.CertChanged, tlsConfig),
-		tag:         cfg.Tag,
-		dataDir:     cfg.DataDir,
-		logDir:      cfg.LogDir,
-		limiter:     utils.NewLimiter(loginRateLimit),
-		validator:   cfg.Validator,
+		newObserver:   cfg.NewObserver,
+		configChanged: cfg.ControllerConfigChanged,
+		state:         s,
+		statePool:     stPool,
+		lis:           newChangeCertListener(lis, cfg.CertChanged, tlsConfig),
+		tag:           cfg.Tag,
+		dataDir:       cfg.DataDir,
+		logDir:        cfg.LogDir,
+		limiter:       utils.NewLimiter(loginRateLimit),
+		validator:     cfg.Validator,
 		adminApiFactories: map[int]adminApiFactory{
 			3: newAdminApiV3,
 		},
@@ -290,6 +300,12 @@ func (srv *Server) run() {
 		srv.tomb.Kill(srv.mongoPinger())
 	}()
 
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+		srv.tomb.Kill(srv.watchControllerConfig())
+	}()
+
 	// for pat based handlers, they are matched in-order of being
 	// registered, first match wins. So more specific ones have to be
 	// registered first.
@@ -563,6 +579,33 @@ func (srv *Server) mongoPinger() error {
 	}
 }
 
+// watchControllerConfig watches the controller config, updating the
+// server's configuration whenever it changes, so that the controller
+// does not need to be restarted for the changes to take effect.
+func (srv *Server) watchControllerConfig() error {
+	w := srv.state.WatchControllerConfig()
+	defer w.Stop()
+
+	for {
+		select {
+		case <-srv.tomb.Dying():
+			return tomb.ErrDying
+		case _, ok := <-w.Changes():
+			if !ok {
+				return statewatcher.EnsureErr(w)
+			}
+		}
+		cfg, err := srv.state.ControllerConfig()
+		if err != nil {
+			return errors.Annotate(err, "cannot read controller config")
+		}
+		srv.authCtxt.controllerConfigChanged(cfg)
+		if srv.configChanged != nil {
+			srv.configChanged(cfg)
+		}
+	}
+}
+
 func serverError(err error) error {
 	if err := common.ServerError(err); err != nil {
 		return err
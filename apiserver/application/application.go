@@ -11,6 +11,7 @@ import (
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
 	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -30,6 +31,11 @@ var (
 
 func init() {
 	common.RegisterStandardFacade("Application", 1, NewAPI)
+	common.RegisterStandardFacade("Application", 2, NewAPIV2)
+	common.RegisterStandardFacade("Application", 3, NewAPIV3)
+	common.RegisterStandardFacade("Application", 4, NewAPIV4)
+	common.RegisterStandardFacade("Application", 5, NewAPIV5)
+	common.RegisterStandardFacade("Application", 6, NewAPIV6)
 }
 
 // Application defines the methods on the application API end point.
@@ -62,6 +68,101 @@ func NewAPI(
 	}, nil
 }
 
+// APIV2 implements version 2 of the application API end point, which
+// adds SetBindings.
+type APIV2 struct {
+	*API
+}
+
+// NewAPIV2 returns a new application API facade, version 2.
+func NewAPIV2(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV2, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV2{api}, nil
+}
+
+// APIV3 implements version 3 of the application API end point, which
+// adds UpdateApplicationSeries.
+type APIV3 struct {
+	*APIV2
+}
+
+// NewAPIV3 returns a new application API facade, version 3.
+func NewAPIV3(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV3, error) {
+	api, err := NewAPIV2(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV3{api}, nil
+}
+
+// APIV4 implements version 4 of the application API end point, which
+// allows Expose to limit access to given source CIDRs.
+type APIV4 struct {
+	*APIV3
+}
+
+// NewAPIV4 returns a new application API facade, version 4.
+func NewAPIV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV4, error) {
+	api, err := NewAPIV3(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV4{api}, nil
+}
+
+// APIV5 implements version 5 of the application API end point, which
+// adds SetTrust and allows Deploy to deploy trusted applications.
+type APIV5 struct {
+	*APIV4
+}
+
+// NewAPIV5 returns a new application API facade, version 5.
+func NewAPIV5(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV5, error) {
+	api, err := NewAPIV4(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV5{api}, nil
+}
+
+// APIV6 implements version 6 of the application API end point, which
+// adds ReplayHooks.
+type APIV6 struct {
+	*APIV5
+}
+
+// NewAPIV6 returns a new application API facade, version 6.
+func NewAPIV6(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV6, error) {
+	api, err := NewAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV6{api}, nil
+}
+
 // SetMetricCredentials sets credentials on the application.
 func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -87,6 +188,17 @@ func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (
 // Deploy fetches the charms from the charm store and deploys them
 // using the specified placement directives.
 func (api *API) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, error) {
+	return api.deploy(args, false)
+}
+
+// Deploy fetches the charms from the charm store and deploys them
+// using the specified placement directives. The applications may be
+// trusted to access the model's cloud credential.
+func (api *APIV5) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, error) {
+	return api.deploy(args, true)
+}
+
+func (api *API) deploy(args params.ApplicationsDeploy, allowTrust bool) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Applications)),
 	}
@@ -94,7 +206,12 @@ func (api *API) Deploy(args params.ApplicationsDeploy) (params.ErrorResults, err
 		return result, errors.Trace(err)
 	}
 	for i, arg := range args.Applications {
-		err := deployApplication(api.state, arg)
+		var err error
+		if arg.Trust && !allowTrust {
+			err = errors.NotSupportedf("deploying trusted applications before version 5 of the facade")
+		} else {
+			err = deployApplication(api.state, arg)
+		}
 		result.Results[i].Error = common.ServerError(err)
 	}
 	return result, nil
@@ -159,6 +276,7 @@ func deployApplication(st *state.State, args params.ApplicationDeploy) error {
 			Storage:          args.Storage,
 			EndpointBindings: args.EndpointBindings,
 			Resources:        args.Resources,
+			Trust:            args.Trust,
 		})
 	return errors.Trace(err)
 }
@@ -422,6 +540,20 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 // Expose changes the juju-managed firewall to expose any ports that
 // were also explicitly marked by units as open.
 func (api *API) Expose(args params.ApplicationExpose) error {
+	if len(args.CIDRs) > 0 {
+		return errors.NotSupportedf("exposing to CIDRs before version 4 of the facade")
+	}
+	return api.expose(args)
+}
+
+// Expose changes the juju-managed firewall to expose any ports that
+// were also explicitly marked by units as open. If any CIDRs are given,
+// the ports may be accessed only from those sources.
+func (api *APIV4) Expose(args params.ApplicationExpose) error {
+	return api.expose(args)
+}
+
+func (api *API) expose(args params.ApplicationExpose) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
@@ -429,9 +561,25 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
+	if len(args.CIDRs) > 0 {
+		return svc.SetExposedToCIDRs(args.CIDRs)
+	}
 	return svc.SetExposed()
 }
 
+// SetTrust grants or revokes an application's access to the model's
+// cloud credential, which trusted charms may read with credential-get.
+func (api *APIV5) SetTrust(args params.ApplicationSetTrust) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	svc, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return svc.SetTrust(args.Trust)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (api *API) Unexpose(args params.ApplicationUnexpose) error {
@@ -445,6 +593,78 @@ func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	return svc.ClearExposed()
 }
 
+// SetBindings changes the spaces the given application endpoints are bound
+// to. Endpoints not mentioned keep their existing bindings.
+func (api *APIV2) SetBindings(args params.ApplicationSetBindings) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	svc, err := api.state.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return svc.UpdateEndpointBindings(args.EndpointBindings)
+}
+
+// UpdateApplicationSeries changes the series of the given applications,
+// which is used when adding new units.
+func (api *APIV3) UpdateApplicationSeries(args params.UpdateSeriesArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		err := api.updateOneApplicationSeries(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *APIV3) updateOneApplicationSeries(arg params.UpdateSeriesArg) error {
+	if arg.Series == "" {
+		return errors.BadRequestf("series missing from args")
+	}
+	tag, err := names.ParseApplicationTag(arg.Entity.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	application, err := api.state.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return application.UpdateApplicationSeries(arg.Series, arg.Force)
+}
+
+// ReplayHooks asks each given unit to run again the hooks it skipped
+// when it was resolved without retrying its failed hooks.
+func (api *APIV6) ReplayHooks(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		err := api.replayUnitHooks(entity)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) replayUnitHooks(entity params.Entity) error {
+	tag, err := names.ParseUnitTag(entity.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unit, err := api.state.Unit(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return unit.RequestReplayHooks()
+}
+
 // addApplicationUnits adds a given number of units to an application.
 func addApplicationUnits(st *state.State, args params.AddApplicationUnits) ([]*state.Unit, error) {
 	application, err := st.Application(args.ApplicationName)
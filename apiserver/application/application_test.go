@@ -24,6 +24,7 @@ import (
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/apiserver/application"
+	"github.com/juju/juju/apiserver/common"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
@@ -47,7 +48,7 @@ type serviceSuite struct {
 	apiservertesting.CharmStoreSuite
 	commontesting.BlockHelper
 
-	applicationApi *application.API
+	applicationApi *application.APIV6
 	application    *state.Application
 	authorizer     apiservertesting.FakeAuthorizer
 }
@@ -79,10 +80,33 @@ func (s *serviceSuite) SetUpTest(c *gc.C) {
 		Tag: s.AdminUserTag(c),
 	}
 	var err error
-	s.applicationApi, err = application.NewAPI(s.State, nil, s.authorizer)
+	s.applicationApi, err = application.NewAPIV6(s.State, nil, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+// newMethods holds the methods added by each version of the facade.
+var newMethods = map[int][]string{
+	2: {"SetBindings"},
+	3: {"UpdateApplicationSeries"},
+	5: {"SetTrust"},
+	6: {"ReplayHooks"},
+}
+
+func (s *serviceSuite) TestNewMethodsVersioned(c *gc.C) {
+	for version, methods := range newMethods {
+		older, err := common.Facades.GetType("Application", version-1)
+		c.Assert(err, jc.ErrorIsNil)
+		newer, err := common.Facades.GetType("Application", version)
+		c.Assert(err, jc.ErrorIsNil)
+		for _, name := range methods {
+			_, ok := older.MethodByName(name)
+			c.Check(ok, jc.IsFalse, gc.Commentf("v%d has %s", version-1, name))
+			_, ok = newer.MethodByName(name)
+			c.Check(ok, jc.IsTrue, gc.Commentf("v%d lacks %s", version, name))
+		}
+	}
+}
+
 func (s *serviceSuite) TearDownTest(c *gc.C) {
 	s.CharmStoreSuite.TearDownTest(c)
 	s.JujuConnSuite.TearDownTest(c)
@@ -383,6 +407,45 @@ func (s *serviceSuite) TestServiceDeploy(c *gc.C) {
 	c.Assert(units, gc.HasLen, 1)
 }
 
+func (s *serviceSuite) TestServiceDeployTrusted(c *gc.C) {
+	curl, _ := s.UploadCharm(c, "precise/dummy-42", "dummy")
+	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.applicationApi.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "application",
+			CharmUrl:        curl.String(),
+			Trust:           true,
+		}}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	svc, err := s.State.Application("application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.Trust(), jc.IsTrue)
+}
+
+func (s *serviceSuite) TestServiceDeployTrustedNeedsVersion5(c *gc.C) {
+	curl, _ := s.UploadCharm(c, "precise/dummy-42", "dummy")
+	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.applicationApi.APIV4.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "application",
+			CharmUrl:        curl.String(),
+			Trust:           true,
+		}}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotSupported)
+	_, err = s.State.Application("application")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *serviceSuite) TestServiceDeployWithInvalidPlacement(c *gc.C) {
 	curl, _ := s.UploadCharm(c, "precise/dummy-42", "dummy")
 	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
@@ -1870,7 +1933,7 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	c.Assert(svcs[1].IsExposed(), jc.IsTrue)
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationApi.Expose(params.ApplicationExpose{t.service})
+		err = s.applicationApi.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1882,6 +1945,176 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestServiceExposeToCIDRs(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationApi.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		CIDRs:           []string{"192.168.1.0/24", "10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsTrue)
+	c.Assert(application.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	err = s.applicationApi.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		CIDRs:           []string{"10.0.0.0"},
+	})
+	c.Assert(err, gc.ErrorMatches, `CIDR "10.0.0.0" not valid`)
+}
+
+func (s *serviceSuite) TestServiceExposeToCIDRsNeedsVersion4(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationApi.APIV3.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		CIDRs:           []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	application, err := s.State.Application("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsFalse)
+}
+
+func (s *serviceSuite) TestServiceSetTrust(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationApi.SetTrust(params.ApplicationSetTrust{
+		ApplicationName: "dummy-service",
+		Trust:           true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.Trust(), jc.IsTrue)
+
+	err = s.applicationApi.SetTrust(params.ApplicationSetTrust{
+		ApplicationName: "dummy-service",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.Trust(), jc.IsFalse)
+
+	err = s.applicationApi.SetTrust(params.ApplicationSetTrust{
+		ApplicationName: "unknown-service",
+		Trust:           true,
+	})
+	c.Assert(err, gc.ErrorMatches, `application "unknown-service" not found`)
+}
+
+func (s *serviceSuite) TestServiceSetBindings(c *gc.C) {
+	_, err := s.State.AddSpace("storage", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	err = s.applicationApi.SetBindings(params.ApplicationSetBindings{
+		ApplicationName:  "mysql",
+		EndpointBindings: map[string]string{"server": "storage"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := application.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings, jc.DeepEquals, map[string]string{"server": "storage"})
+
+	err = s.applicationApi.SetBindings(params.ApplicationSetBindings{
+		ApplicationName:  "unknown",
+		EndpointBindings: map[string]string{"server": "storage"},
+	})
+	c.Assert(err, gc.ErrorMatches, `application "unknown" not found`)
+}
+
+func (s *serviceSuite) TestBlockChangesServiceSetBindings(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.BlockAllChanges(c, "TestBlockChangesServiceSetBindings")
+	err := s.applicationApi.SetBindings(params.ApplicationSetBindings{
+		ApplicationName:  "mysql",
+		EndpointBindings: map[string]string{"server": "storage"},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesServiceSetBindings")
+}
+
+func (s *serviceSuite) TestUpdateApplicationSeries(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	results, err := s.applicationApi.UpdateApplicationSeries(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: "application-mysql"},
+			Series: "precise",
+		}, {
+			Entity: params.Entity{Tag: "application-mysql"},
+			Series: "precise",
+			Force:  true,
+		}, {
+			Entity: params.Entity{Tag: "application-unknown"},
+			Series: "precise",
+		}, {
+			Entity: params.Entity{Tag: "machine-0"},
+			Series: "precise",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `cannot update series for application "mysql": charm ".*" only supports series "quantal"`)
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `application "unknown" not found`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `"machine-0" is not a valid application tag`)
+
+	application, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.Series(), gc.Equals, "precise")
+}
+
+func (s *serviceSuite) TestBlockChangesUpdateApplicationSeries(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.BlockAllChanges(c, "TestBlockChangesUpdateApplicationSeries")
+	_, err := s.applicationApi.UpdateApplicationSeries(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: "application-mysql"},
+			Series: "precise",
+		}},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesUpdateApplicationSeries")
+}
+
+func (s *serviceSuite) TestReplayHooks(c *gc.C) {
+	application := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	skipped, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = skipped.SetState(state.UnitStateUpdate{SkippedHooks: []string{"config-changed"}})
+	c.Assert(err, jc.ErrorIsNil)
+	healthy, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationApi.ReplayHooks(params.Entities{
+		Entities: []params.Entity{
+			{Tag: skipped.Tag().String()},
+			{Tag: healthy.Tag().String()},
+			{Tag: "unit-unknown-0"},
+			{Tag: "application-mysql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cannot replay hooks for unit "mysql/1": no skipped hooks`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `unit "unknown/0" not found`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `"application-mysql" is not a valid unit tag`)
+
+	err = skipped.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(skipped.ReplayHooksRequested(), jc.IsTrue)
+}
+
+func (s *serviceSuite) TestBlockChangesReplayHooks(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockChangesReplayHooks")
+	_, err := s.applicationApi.ReplayHooks(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesReplayHooks")
+}
+
 func (s *serviceSuite) setupServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -1922,7 +2155,7 @@ var serviceExposeTests = []struct {
 func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationApi.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationApi.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1937,7 +2170,7 @@ func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 func (s *serviceSuite) assertServiceExposeBlocked(c *gc.C, msg string) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationApi.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationApi.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -20,6 +20,7 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	jujuversion "github.com/juju/juju/version"
@@ -198,6 +199,12 @@ type CharmArchive struct {
 
 // StoreCharmArchive stores a charm archive in environment storage.
 func StoreCharmArchive(st *state.State, archive CharmArchive) error {
+	// Reject charms with invalid LXD profiles before storing anything.
+	lxdProfile, err := lxdprofile.ReadCharm(archive.Charm)
+	if err != nil {
+		return errors.NewBadRequest(err, "")
+	}
+
 	storage := newStateStorage(st.ModelUUID(), st.MongoSession())
 	storagePath, err := charmArchiveStoragePath(archive.ID)
 	if err != nil {
@@ -213,6 +220,7 @@ func StoreCharmArchive(st *state.State, archive CharmArchive) error {
 		StoragePath: storagePath,
 		SHA256:      archive.SHA256,
 		Macaroon:    archive.Macaroon,
+		LXDProfile:  lxdProfile,
 	}
 
 	// Now update the charm data in state and mark it as no longer pending.
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/bakerystorage"
 )
@@ -29,10 +30,16 @@ type authContext struct {
 	agentAuth authentication.AgentAuthenticator
 	userAuth  authentication.UserAuthenticator
 
-	// macaroonAuthOnce guards the fields below it.
-	macaroonAuthOnce   sync.Once
+	// macaroonAuthMutex guards the fields below it.
+	macaroonAuthMutex  sync.Mutex
 	_macaroonAuth      *authentication.ExternalMacaroonAuthenticator
 	_macaroonAuthError error
+	_macaroonAuthDone  bool
+
+	// identityURL and identityPublicKey hold the identity
+	// manager settings used to create _macaroonAuth.
+	identityURL       string
+	identityPublicKey string
 }
 
 // newAuthContext creates a new authentication context for st.
@@ -91,27 +98,53 @@ func (ctxt *authContext) authenticatorForTag(tag names.Tag) (authentication.Enti
 }
 
 // macaroonAuth returns an authenticator that can authenticate macaroon-based
-// logins. If it fails once, it will always fail.
+// logins. If it fails once, it will always fail until the identity manager
+// settings in the controller config are changed.
 func (ctxt *authContext) macaroonAuth() (authentication.EntityAuthenticator, error) {
-	ctxt.macaroonAuthOnce.Do(func() {
-		ctxt._macaroonAuth, ctxt._macaroonAuthError = newExternalMacaroonAuth(ctxt.st)
-	})
+	ctxt.macaroonAuthMutex.Lock()
+	defer ctxt.macaroonAuthMutex.Unlock()
+	if !ctxt._macaroonAuthDone {
+		ctxt._macaroonAuthDone = true
+		controllerCfg, err := ctxt.st.ControllerConfig()
+		if err != nil {
+			ctxt._macaroonAuthError = errors.Annotate(err, "cannot get controller config")
+		} else {
+			ctxt.identityURL = controllerCfg.IdentityURL()
+			ctxt.identityPublicKey, _ = controllerCfg[controller.IdentityPublicKey].(string)
+			ctxt._macaroonAuth, ctxt._macaroonAuthError = newExternalMacaroonAuth(ctxt.st, controllerCfg)
+		}
+	}
 	if ctxt._macaroonAuth == nil {
 		return nil, errors.Trace(ctxt._macaroonAuthError)
 	}
 	return ctxt._macaroonAuth, nil
 }
 
+// controllerConfigChanged is called when the controller config changes.
+// If the identity manager settings have changed, the macaroon
+// authenticator will be recreated the next time it is required.
+func (ctxt *authContext) controllerConfigChanged(cfg controller.Config) {
+	ctxt.macaroonAuthMutex.Lock()
+	defer ctxt.macaroonAuthMutex.Unlock()
+	if !ctxt._macaroonAuthDone {
+		return
+	}
+	identityPublicKey, _ := cfg[controller.IdentityPublicKey].(string)
+	if cfg.IdentityURL() == ctxt.identityURL && identityPublicKey == ctxt.identityPublicKey {
+		return
+	}
+	logger.Infof("identity manager settings changed")
+	ctxt._macaroonAuthDone = false
+	ctxt._macaroonAuth = nil
+	ctxt._macaroonAuthError = nil
+}
+
 var errMacaroonAuthNotConfigured = errors.New("macaroon authentication is not configured")
 
 // newExternalMacaroonAuth returns an authenticator that can authenticate
 // macaroon-based logins for external users. This is just a helper function
 // for authCtxt.macaroonAuth.
-func newExternalMacaroonAuth(st *state.State) (*authentication.ExternalMacaroonAuthenticator, error) {
-	controllerCfg, err := st.ControllerConfig()
-	if err != nil {
-		return nil, errors.Annotate(err, "cannot get model config")
-	}
+func newExternalMacaroonAuth(st *state.State, controllerCfg controller.Config) (*authentication.ExternalMacaroonAuthenticator, error) {
 	idURL := controllerCfg.IdentityURL()
 	if idURL == "" {
 		return nil, errMacaroonAuthNotConfigured
@@ -121,6 +154,7 @@ func newExternalMacaroonAuth(st *state.State) (*authentication.ExternalMacaroonA
 	idPK := controllerCfg.IdentityPublicKey()
 	if idPK == nil {
 		// No public key supplied - retrieve it from the identity manager.
+		var err error
 		idPK, err = httpbakery.PublicKeyForLocation(http.DefaultClient, idURL)
 		if err != nil {
 			return nil, errors.Annotate(err, "cannot get identity public key")
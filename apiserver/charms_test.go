@@ -22,6 +22,7 @@ import (
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/testcharms"
@@ -235,6 +236,42 @@ func (s *charmsSuite) TestUploadRespectsLocalRevision(c *gc.C) {
 	c.Assert(downloadedSHA256, gc.Equals, expectedSHA256)
 }
 
+func (s *charmsSuite) TestUploadStoresLXDProfile(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "lxd-profile")
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal"), "application/zip", ch.Path)
+	expectedURL := charm.MustParseURL("local:quantal/lxd-profile-1")
+	s.assertUploadResponse(c, resp, expectedURL.String())
+	sch, err := s.State.Charm(expectedURL)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.LXDProfile(), jc.DeepEquals, &lxdprofile.Profile{
+		Description: "allow nested containers",
+		Config: map[string]string{
+			"security.nesting":     "true",
+			"linux.kernel_modules": "openvswitch",
+		},
+		Devices: map[string]map[string]string{
+			"tun": {"type": "unix-char", "path": "/dev/net/tun"},
+		},
+	})
+}
+
+func (s *charmsSuite) TestUploadRejectsInvalidLXDProfile(c *gc.C) {
+	dir := testcharms.Repo.ClonedDir(c.MkDir(), "lxd-profile")
+	err := ioutil.WriteFile(
+		filepath.Join(dir.Path, lxdprofile.Filename),
+		[]byte("config:\n  boot.autostart: \"true\"\n"), 0644,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	tempFile, err := ioutil.TempFile(c.MkDir(), "charm")
+	c.Assert(err, jc.ErrorIsNil)
+	defer tempFile.Close()
+	err = dir.ArchiveTo(tempFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp := s.uploadRequest(c, s.charmsURI(c, "?series=quantal"), "application/zip", tempFile.Name())
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `invalid lxd-profile.yaml: config key "boot.autostart" not valid`)
+}
+
 func (s *charmsSuite) TestUploadWithMultiSeriesCharm(c *gc.C) {
 	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
 	resp := s.uploadRequest(c, s.charmsURL(c, "").String(), "application/zip", ch.Path)
@@ -69,7 +69,7 @@ func newClient(st *state.State, resources facade.Resources, authorizer facade.Au
 		return environs.GetEnviron(configGetter, environs.New)
 	}
 	blockChecker := common.NewBlockChecker(st)
-	modelConfigAPI, err := modelconfig.NewModelConfigAPI(st, authorizer)
+	modelConfigAPI, err := modelconfig.NewModelConfigAPI(modelconfig.NewStateBackend(st), authorizer)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -75,7 +75,7 @@ func (s *serverSuite) SetUpTest(c *gc.C) {
 		return s.newEnviron()
 	}
 	blockChecker := common.NewBlockChecker(s.State)
-	modelConfigAPI, err := modelconfig.NewModelConfigAPI(s.State, auth)
+	modelConfigAPI, err := modelconfig.NewModelConfigAPI(modelconfig.NewStateBackend(s.State), auth)
 	c.Assert(err, jc.ErrorIsNil)
 	s.client, err = client.NewClient(
 		client.NewStateBackend(s.State),
@@ -709,6 +709,21 @@ func (context *statusContext) processUnit(unit *state.Unit, serviceCharm string)
 		logger.Debugf("error fetching workload version: %v", err)
 	}
 
+	skippedHooks, err := unit.SkippedHooks()
+	if err == nil {
+		result.SkippedHooks = skippedHooks
+	} else {
+		logger.Debugf("error fetching skipped hooks: %v", err)
+	}
+
+	health, err := unit.Health()
+	if err == nil {
+		result.Health = &params.DetailedStatus{}
+		populateStatusFromStatusInfoAndErr(result.Health, health, nil)
+	} else if !errors.IsNotFound(err) {
+		logger.Debugf("error fetching health: %v", err)
+	}
+
 	processUnitAndAgentStatus(unit, &result)
 
 	if subUnits := unit.SubordinateNames(); len(subUnits) > 0 {
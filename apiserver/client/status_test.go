@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -232,6 +233,48 @@ func (s *statusUnitTestSuite) TestWorkloadVersionOkWithUnset(c *gc.C) {
 	checkUnitVersion(c, appStatus, unit, "")
 }
 
+func (s *statusUnitTestSuite) TestSkippedHooks(c *gc.C) {
+	application := s.MakeApplication(c, nil)
+	unit, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetState(state.UnitStateUpdate{
+		SkippedHooks: []string{"config-changed"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	appStatus := s.checkAppVersion(c, application, "")
+	unitStatus, found := appStatus.Units[unit.Name()]
+	c.Assert(found, jc.IsTrue)
+	c.Check(unitStatus.SkippedHooks, jc.DeepEquals, []string{"config-changed"})
+}
+
+func (s *statusUnitTestSuite) TestHealth(c *gc.C) {
+	application := s.MakeApplication(c, nil)
+	healthy, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	unchecked, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	err = healthy.SetHealth(status.StatusInfo{
+		Status:  status.StatusUnhealthy,
+		Message: `check "web" failed`,
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	appStatus := s.checkAppVersion(c, application, "")
+	unitStatus, found := appStatus.Units[healthy.Name()]
+	c.Assert(found, jc.IsTrue)
+	c.Assert(unitStatus.Health, gc.NotNil)
+	c.Check(unitStatus.Health.Status, gc.Equals, "unhealthy")
+	c.Check(unitStatus.Health.Info, gc.Equals, `check "web" failed`)
+
+	unitStatus, found = appStatus.Units[unchecked.Name()]
+	c.Assert(found, jc.IsTrue)
+	c.Check(unitStatus.Health, gc.IsNil)
+}
+
 func (s *statusUnitTestSuite) TestMigrationInProgress(c *gc.C) {
 
 	// Create a host model because controller models can't be migrated.
@@ -4,9 +4,11 @@
 package cloud
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -15,6 +17,14 @@ type Backend interface {
 	CloudCredentials(user names.UserTag, cloudName string) (map[string]cloud.Credential, error)
 	ControllerModel() (Model, error)
 	UpdateCloudCredentials(user names.UserTag, cloudName string, credentials map[string]cloud.Credential) error
+	CredentialModels(user names.UserTag, cloudName, credentialName string) ([]CredentialModel, error)
+	UpdateCloudCredentialsAndModels(
+		user names.UserTag,
+		cloudName string,
+		credentials map[string]cloud.Credential,
+		modelCredentials map[string]string,
+		modelAttrs map[string]map[string]interface{},
+	) error
 
 	IsControllerAdministrator(names.UserTag) (bool, error)
 
@@ -37,8 +47,36 @@ func (s stateShim) ControllerModel() (Model, error) {
 	return m, nil
 }
 
+// CredentialModels returns the models owned by the given user, on the
+// given cloud, that use the named cloud credential.
+func (s stateShim) CredentialModels(user names.UserTag, cloudName, credentialName string) ([]CredentialModel, error) {
+	models, err := s.State.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []CredentialModel
+	for _, m := range models {
+		if m.Owner().Canonical() != user.Canonical() {
+			continue
+		}
+		if m.Cloud() != cloudName || m.CloudCredential() != credentialName {
+			continue
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
 type Model interface {
 	Cloud() string
 	CloudCredential() string
 	CloudRegion() string
 }
+
+// CredentialModel is a model whose cloud resources are managed using
+// one of its owner's cloud credentials.
+type CredentialModel interface {
+	UUID() string
+	Name() string
+	Config() (*config.Config, error)
+}
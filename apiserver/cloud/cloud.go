@@ -6,6 +6,7 @@
 package cloud
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/state"
 )
 
@@ -20,12 +22,14 @@ var logger = loggo.GetLogger("juju.apiserver.cloud")
 
 func init() {
 	common.RegisterStandardFacade("Cloud", 1, newFacade)
+	common.RegisterStandardFacade("Cloud", 2, newFacadeV2)
 }
 
 // CloudAPI implements the model manager interface and is
 // the concrete implementation of the api end point.
 type CloudAPI struct {
 	backend                  Backend
+	newEnviron               environs.NewEnvironFunc
 	authorizer               facade.Authorizer
 	apiUser                  names.UserTag
 	getCredentialsAuthFunc   common.GetAuthFunc
@@ -33,12 +37,13 @@ type CloudAPI struct {
 }
 
 func newFacade(st *state.State, resources facade.Resources, auth facade.Authorizer) (*CloudAPI, error) {
-	return NewCloudAPI(NewStateBackend(st), auth)
+	return NewCloudAPI(NewStateBackend(st), environs.New, auth)
 }
 
 // NewCloudAPI creates a new API server endpoint for managing the controller's
-// cloud definition and cloud credentials.
-func NewCloudAPI(backend Backend, authorizer facade.Authorizer) (*CloudAPI, error) {
+// cloud definition and cloud credentials. The given newEnviron function is
+// used to validate credentials against the models using them.
+func NewCloudAPI(backend Backend, newEnviron environs.NewEnvironFunc, authorizer facade.Authorizer) (*CloudAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
 	}
@@ -58,12 +63,33 @@ func NewCloudAPI(backend Backend, authorizer facade.Authorizer) (*CloudAPI, erro
 	}
 	return &CloudAPI{
 		backend:                  backend,
+		newEnviron:               newEnviron,
 		authorizer:               authorizer,
 		getCredentialsAuthFunc:   getUserAuthFunc,
 		getCloudDefaultsAuthFunc: getUserAuthFunc,
 	}, nil
 }
 
+// CloudAPIV2 implements version 2 of the cloud API end point, which adds
+// UpdateCredentialsCheckModels.
+type CloudAPIV2 struct {
+	*CloudAPI
+}
+
+func newFacadeV2(st *state.State, resources facade.Resources, auth facade.Authorizer) (*CloudAPIV2, error) {
+	return NewCloudAPIV2(NewStateBackend(st), environs.New, auth)
+}
+
+// NewCloudAPIV2 creates a new API server endpoint, version 2, for
+// managing the controller's cloud definition and cloud credentials.
+func NewCloudAPIV2(backend Backend, newEnviron environs.NewEnvironFunc, authorizer facade.Authorizer) (*CloudAPIV2, error) {
+	api, err := NewCloudAPI(backend, newEnviron, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &CloudAPIV2{api}, nil
+}
+
 // Cloud returns the cloud definitions for the specified clouds.
 func (mm *CloudAPI) Cloud(args params.Entities) (params.CloudResults, error) {
 	results := params.CloudResults{
@@ -202,30 +228,115 @@ func (mm *CloudAPI) UpdateCredentials(args params.UsersCloudCredentials) (params
 		return results, err
 	}
 	for i, arg := range args.Users {
-		userTag, err := names.ParseUserTag(arg.UserTag)
+		userTag, cloudTag, in, err := parseUserCloudCredentials(arg, authFunc)
 		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
-		if !authFunc(userTag) {
-			results.Results[i].Error = common.ServerError(common.ErrPerm)
+		if err := mm.backend.UpdateCloudCredentials(userTag, cloudTag.Id(), in); err != nil {
+			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
-		cloudTag, err := names.ParseCloudTag(arg.CloudTag)
+	}
+	return results, nil
+}
+
+// UpdateCredentialsCheckModels updates the cloud credentials for a set of
+// users, and replaces them in every model using them. Before anything is
+// updated, each credential is checked by making a simple call to the
+// provider of every model using it; if any check fails, none of the
+// user's credentials or models are changed. The credentials and the
+// models' config are then updated in a single transaction.
+func (mm *CloudAPIV2) UpdateCredentialsCheckModels(args params.UsersCloudCredentials) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Users)),
+	}
+	authFunc, err := mm.getCredentialsAuthFunc()
+	if err != nil {
+		return results, err
+	}
+	for i, arg := range args.Users {
+		userTag, cloudTag, in, err := parseUserCloudCredentials(arg, authFunc)
 		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
-		in := make(map[string]cloud.Credential)
-		for name, credential := range arg.Credentials {
-			in[name] = cloud.NewCredential(
-				cloud.AuthType(credential.AuthType), credential.Attributes,
-			)
-		}
-		if err := mm.backend.UpdateCloudCredentials(userTag, cloudTag.Id(), in); err != nil {
+		if err := mm.updateCredentialsCheckModels(userTag, cloudTag.Id(), in); err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
 	}
 	return results, nil
 }
+
+func (mm *CloudAPIV2) updateCredentialsCheckModels(
+	user names.UserTag, cloudName string, credentials map[string]cloud.Credential,
+) error {
+	modelCredentials := make(map[string]string)
+	modelAttrs := make(map[string]map[string]interface{})
+	for name, credential := range credentials {
+		models, err := mm.backend.CredentialModels(user, cloudName, name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// Credential attributes are copied into model config when
+		// a model is created, so they must be replaced there too.
+		attrs := make(map[string]interface{})
+		for key, value := range credential.Attributes() {
+			attrs[key] = value
+		}
+		for _, model := range models {
+			if err := mm.checkModelCredential(model, attrs); err != nil {
+				return errors.Annotatef(err, "credential %q not valid for model %q", name, model.Name())
+			}
+			modelCredentials[model.UUID()] = name
+			modelAttrs[model.UUID()] = attrs
+		}
+	}
+	return errors.Trace(mm.backend.UpdateCloudCredentialsAndModels(
+		user, cloudName, credentials, modelCredentials, modelAttrs,
+	))
+}
+
+// checkModelCredential opens an Environ for the model using the given
+// credential attributes, and checks that the provider accepts them.
+func (mm *CloudAPIV2) checkModelCredential(model CredentialModel, attrs map[string]interface{}) error {
+	cfg, err := model.Config()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cfg, err = cfg.Apply(attrs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	env, err := mm.newEnviron(environs.OpenParams{Config: cfg})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return environs.CheckProviderAPI(env)
+}
+
+// parseUserCloudCredentials parses the user and cloud tags of the given
+// credentials, checking the authenticated user may update them.
+func parseUserCloudCredentials(
+	arg params.UserCloudCredentials, authFunc common.AuthFunc,
+) (names.UserTag, names.CloudTag, map[string]cloud.Credential, error) {
+	userTag, err := names.ParseUserTag(arg.UserTag)
+	if err != nil {
+		return names.UserTag{}, names.CloudTag{}, nil, err
+	}
+	if !authFunc(userTag) {
+		return names.UserTag{}, names.CloudTag{}, nil, common.ErrPerm
+	}
+	cloudTag, err := names.ParseCloudTag(arg.CloudTag)
+	if err != nil {
+		return names.UserTag{}, names.CloudTag{}, nil, err
+	}
+	in := make(map[string]cloud.Credential)
+	for name, credential := range arg.Credentials {
+		in[name] = cloud.NewCredential(
+			cloud.AuthType(credential.AuthType), credential.Attributes,
+		)
+	}
+	return userTag, cloudTag, in, nil
+}
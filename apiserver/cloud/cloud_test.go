@@ -4,6 +4,7 @@
 package cloud_test
 
 import (
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -13,13 +14,18 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/testing"
 )
 
 type cloudSuite struct {
 	gitjujutesting.IsolationSuite
 	backend    mockBackend
+	environ    mockEnviron
 	authorizer apiservertesting.FakeAuthorizer
-	api        *cloudfacade.CloudAPI
+	api        *cloudfacade.CloudAPIV2
 }
 
 var _ = gc.Suite(&cloudSuite{})
@@ -43,8 +49,13 @@ func (s *cloudSuite) SetUpTest(c *gc.C) {
 			}),
 		},
 	}
+	s.environ = mockEnviron{}
+	newEnviron := func(args environs.OpenParams) (environs.Environ, error) {
+		s.environ.MethodCall(&s.environ, "Open", args.Config.AllAttrs()["password"])
+		return &s.environ, s.environ.NextErr()
+	}
 	var err error
-	s.api, err = cloudfacade.NewCloudAPI(&s.backend, &s.authorizer)
+	s.api, err = cloudfacade.NewCloudAPIV2(&s.backend, newEnviron, &s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -236,10 +247,117 @@ func (s *cloudSuite) TestUpdateCredentialsAdminAccess(c *gc.C) {
 	c.Assert(results.Results[0].Error, gc.IsNil)
 }
 
+func (s *cloudSuite) TestUpdateCredentialsCheckModelsOnlyInV2(c *gc.C) {
+	v1, err := common.Facades.GetType("Cloud", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := v1.MethodByName("UpdateCredentialsCheckModels")
+	c.Assert(ok, jc.IsFalse)
+	v2, err := common.Facades.GetType("Cloud", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = v2.MethodByName("UpdateCredentialsCheckModels")
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *cloudSuite) TestUpdateCredentialsCheckModels(c *gc.C) {
+	prod := &mockCredentialModel{uuid: "prod-uuid", name: "prod", cfg: testing.ModelConfig(c)}
+	staging := &mockCredentialModel{uuid: "staging-uuid", name: "staging", cfg: testing.ModelConfig(c)}
+	s.backend.models = []cloudfacade.CredentialModel{prod, staging}
+	results, err := s.api.UpdateCredentialsCheckModels(params.UsersCloudCredentials{[]params.UserCloudCredentials{{
+		UserTag:  "user-bruce",
+		CloudTag: "cloud-meep",
+		Credentials: map[string]params.CloudCredential{
+			"two": {
+				AuthType:   "userpass",
+				Attributes: map[string]string{"username": "admin", "password": "r0tated"},
+			},
+		},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.backend.CheckCallNames(c, "IsControllerAdministrator", "CredentialModels", "UpdateCloudCredentialsAndModels")
+	s.backend.CheckCall(c, 1, "CredentialModels", names.NewUserTag("bruce"), "meep", "two")
+	attrs := map[string]interface{}{"username": "admin", "password": "r0tated"}
+	s.backend.CheckCall(c, 2, "UpdateCloudCredentialsAndModels",
+		names.NewUserTag("bruce"),
+		"meep",
+		map[string]cloud.Credential{
+			"two": cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+				"username": "admin", "password": "r0tated",
+			}),
+		},
+		map[string]string{"prod-uuid": "two", "staging-uuid": "two"},
+		map[string]map[string]interface{}{"prod-uuid": attrs, "staging-uuid": attrs},
+	)
+	s.environ.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Open", []interface{}{"r0tated"}},
+		{"AllInstances", nil},
+		{"Open", []interface{}{"r0tated"}},
+		{"AllInstances", nil},
+	})
+}
+
+func (s *cloudSuite) TestUpdateCredentialsCheckModelsInvalidCredential(c *gc.C) {
+	prod := &mockCredentialModel{uuid: "prod-uuid", name: "prod", cfg: testing.ModelConfig(c)}
+	staging := &mockCredentialModel{uuid: "staging-uuid", name: "staging", cfg: testing.ModelConfig(c)}
+	s.backend.models = []cloudfacade.CredentialModel{prod, staging}
+	s.environ.SetErrors(nil, nil, nil, errors.New("authentication failed"))
+	results, err := s.api.UpdateCredentialsCheckModels(params.UsersCloudCredentials{[]params.UserCloudCredentials{{
+		UserTag:  "user-bruce",
+		CloudTag: "cloud-meep",
+		Credentials: map[string]params.CloudCredential{
+			"two": {
+				AuthType:   "userpass",
+				Attributes: map[string]string{"username": "admin", "password": "wr0ng"},
+			},
+		},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`credential "two" not valid for model "staging": cannot make API call to provider: authentication failed`)
+	// Neither the credential nor any model were updated, although the
+	// first model was checked successfully.
+	s.backend.CheckCallNames(c, "IsControllerAdministrator", "CredentialModels")
+}
+
+func (s *cloudSuite) TestUpdateCredentialsCheckModelsUpdateFails(c *gc.C) {
+	prod := &mockCredentialModel{uuid: "prod-uuid", name: "prod", cfg: testing.ModelConfig(c)}
+	s.backend.models = []cloudfacade.CredentialModel{prod}
+	s.backend.SetErrors(nil, nil, errors.New("credentials or models changed concurrently"))
+	results, err := s.api.UpdateCredentialsCheckModels(params.UsersCloudCredentials{[]params.UserCloudCredentials{{
+		UserTag:  "user-bruce",
+		CloudTag: "cloud-meep",
+		Credentials: map[string]params.CloudCredential{
+			"two": {
+				AuthType:   "userpass",
+				Attributes: map[string]string{"username": "admin", "password": "r0tated"},
+			},
+		},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "credentials or models changed concurrently")
+}
+
+func (s *cloudSuite) TestUpdateCredentialsCheckModelsPermissionDenied(c *gc.C) {
+	results, err := s.api.UpdateCredentialsCheckModels(params.UsersCloudCredentials{[]params.UserCloudCredentials{{
+		UserTag:  "user-admin",
+		CloudTag: "cloud-meep",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "IsControllerAdministrator")
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.DeepEquals, &params.Error{
+		Message: "permission denied", Code: params.CodeUnauthorized,
+	})
+}
+
 type mockBackend struct {
 	gitjujutesting.Stub
-	cloud cloud.Cloud
-	creds map[string]cloud.Credential
+	cloud  cloud.Cloud
+	creds  map[string]cloud.Credential
+	models []cloudfacade.CredentialModel
 }
 
 func (st *mockBackend) IsControllerAdministrator(user names.UserTag) (bool, error) {
@@ -267,6 +385,22 @@ func (st *mockBackend) UpdateCloudCredentials(user names.UserTag, cloudName stri
 	return st.NextErr()
 }
 
+func (st *mockBackend) CredentialModels(user names.UserTag, cloudName, credentialName string) ([]cloudfacade.CredentialModel, error) {
+	st.MethodCall(st, "CredentialModels", user, cloudName, credentialName)
+	return st.models, st.NextErr()
+}
+
+func (st *mockBackend) UpdateCloudCredentialsAndModels(
+	user names.UserTag,
+	cloudName string,
+	creds map[string]cloud.Credential,
+	modelCredentials map[string]string,
+	modelAttrs map[string]map[string]interface{},
+) error {
+	st.MethodCall(st, "UpdateCloudCredentialsAndModels", user, cloudName, creds, modelCredentials, modelAttrs)
+	return st.NextErr()
+}
+
 func (st *mockBackend) Close() error {
 	st.MethodCall(st, "Close")
 	return st.NextErr()
@@ -289,3 +423,33 @@ func (m *mockModel) CloudRegion() string {
 func (m *mockModel) CloudCredential() string {
 	return m.cloudCredential
 }
+
+type mockCredentialModel struct {
+	gitjujutesting.Stub
+	uuid string
+	name string
+	cfg  *config.Config
+}
+
+func (m *mockCredentialModel) UUID() string {
+	return m.uuid
+}
+
+func (m *mockCredentialModel) Name() string {
+	return m.name
+}
+
+func (m *mockCredentialModel) Config() (*config.Config, error) {
+	m.MethodCall(m, "Config")
+	return m.cfg, m.NextErr()
+}
+
+type mockEnviron struct {
+	environs.Environ
+	gitjujutesting.Stub
+}
+
+func (e *mockEnviron) AllInstances() ([]instance.Instance, error) {
+	e.MethodCall(e, "AllInstances")
+	return nil, e.NextErr()
+}
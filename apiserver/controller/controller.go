@@ -24,6 +24,7 @@ var logger = loggo.GetLogger("juju.apiserver.controller")
 
 func init() {
 	common.RegisterStandardFacade("Controller", 3, NewControllerAPI)
+	common.RegisterStandardFacade("Controller", 4, NewControllerAPIV4)
 }
 
 // Controller defines the methods on the controller API end point.
@@ -39,6 +40,13 @@ type Controller interface {
 	InitiateModelMigration(params.InitiateModelMigrationArgs) (params.InitiateModelMigrationResults, error)
 }
 
+// ControllerV4 defines the methods on version 4 of the controller API
+// end point.
+type ControllerV4 interface {
+	Controller
+	ConfigSet(args params.ControllerConfigSet) error
+}
+
 // ControllerAPI implements the environment manager interface and is
 // the concrete implementation of the api end point.
 type ControllerAPI struct {
@@ -47,9 +55,19 @@ type ControllerAPI struct {
 	authorizer facade.Authorizer
 	apiUser    names.UserTag
 	resources  facade.Resources
+	check      *common.BlockChecker
+}
+
+// ControllerAPIV4 implements version 4 of the controller API end
+// point, which adds ConfigSet.
+type ControllerAPIV4 struct {
+	*ControllerAPI
 }
 
-var _ Controller = (*ControllerAPI)(nil)
+var (
+	_ Controller   = (*ControllerAPI)(nil)
+	_ ControllerV4 = (*ControllerAPIV4)(nil)
+)
 
 // NewControllerAPI creates a new api server endpoint for managing
 // environments.
@@ -80,9 +98,24 @@ func NewControllerAPI(
 		authorizer:          authorizer,
 		apiUser:             apiUser,
 		resources:           resources,
+		check:               common.NewBlockChecker(st),
 	}, nil
 }
 
+// NewControllerAPIV4 creates a new api server endpoint, version 4, for
+// managing environments.
+func NewControllerAPIV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*ControllerAPIV4, error) {
+	api, err := NewControllerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &ControllerAPIV4{api}, nil
+}
+
 // AllModels allows controller administrators to get the list of all the
 // environments in the controller.
 func (s *ControllerAPI) AllModels() (params.UserModelList, error) {
@@ -207,6 +240,16 @@ func (s *ControllerAPI) ModelConfig() (params.ModelConfigResults, error) {
 	return result, nil
 }
 
+// ConfigSet changes the value of specified controller configuration
+// settings. Only some settings can be changed after bootstrap.
+// Settings that aren't specified in the params are left unchanged.
+func (s *ControllerAPIV4) ConfigSet(args params.ControllerConfigSet) error {
+	if err := s.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.state.UpdateControllerConfig(args.Config, nil))
+}
+
 // RemoveBlocks removes all the blocks in the controller.
 func (s *ControllerAPI) RemoveBlocks(args params.RemoveBlocksArgs) error {
 	if !args.All {
@@ -28,7 +28,7 @@ import (
 type controllerSuite struct {
 	jujutesting.JujuConnSuite
 
-	controller *controller.ControllerAPI
+	controller *controller.ControllerAPIV4
 	resources  *common.Resources
 	authorizer apiservertesting.FakeAuthorizer
 }
@@ -44,7 +44,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 		Tag: s.AdminUserTag(c),
 	}
 
-	controller, err := controller.NewControllerAPI(s.State, s.resources, s.authorizer)
+	controller, err := controller.NewControllerAPIV4(s.State, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	s.controller = controller
 
@@ -195,6 +195,53 @@ func (s *controllerSuite) TestControllerConfigFromNonController(c *gc.C) {
 	c.Assert(cfg.Config["api-port"], gc.Equals, cfgFromDB.APIPort())
 }
 
+func (s *controllerSuite) TestConfigSet(c *gc.C) {
+	config, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	// Sanity check.
+	c.Assert(config.AuditingEnabled(), jc.IsFalse)
+
+	err = s.controller.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"auditing-enabled": true,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	config, err = s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config.AuditingEnabled(), jc.IsTrue)
+}
+
+func (s *controllerSuite) TestConfigSetOnlyInV4(c *gc.C) {
+	v3, err := common.Facades.GetType("Controller", 3)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := v3.MethodByName("ConfigSet")
+	c.Assert(ok, jc.IsFalse)
+	v4, err := common.Facades.GetType("Controller", 4)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = v4.MethodByName("ConfigSet")
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *controllerSuite) TestConfigSetRequiresSettableAttributes(c *gc.C) {
+	err := s.controller.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"api-port": 4321,
+	}})
+	c.Assert(err, gc.ErrorMatches, `cannot change "api-port" after bootstrap`)
+}
+
+func (s *controllerSuite) TestConfigSetBlocked(c *gc.C) {
+	s.State.SwitchBlockOn(state.ChangeBlock, "TestConfigSetBlocked")
+	err := s.controller.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"auditing-enabled": true,
+	}})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "TestConfigSetBlocked")
+
+	config, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config.AuditingEnabled(), jc.IsFalse)
+}
+
 func (s *controllerSuite) TestRemoveBlocks(c *gc.C) {
 	st := s.Factory.MakeModel(c, &factory.ModelParams{
 		Name: "test"})
@@ -18,6 +18,8 @@ import (
 func init() {
 	// Version 0 is no longer supported.
 	common.RegisterStandardFacade("Firewaller", 3, NewFirewallerAPI)
+	common.RegisterStandardFacade("Firewaller", 4, NewFirewallerAPIV4)
+	common.RegisterStandardFacade("Firewaller", 5, NewFirewallerAPIV5)
 }
 
 // FirewallerAPI provides access to the Firewaller API facade.
@@ -108,6 +110,69 @@ func NewFirewallerAPI(
 	}, nil
 }
 
+// FirewallerAPIV4 provides access to version 4 of the Firewaller API
+// facade, which adds GetExposedCIDRs.
+type FirewallerAPIV4 struct {
+	*FirewallerAPI
+}
+
+// NewFirewallerAPIV4 creates a new server-side Firewaller API facade,
+// version 4.
+func NewFirewallerAPIV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*FirewallerAPIV4, error) {
+	api, err := NewFirewallerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV4{api}, nil
+}
+
+// FirewallerAPIV5 provides access to version 5 of the Firewaller API
+// facade, which adds ControllerConfig and
+// WatchForControllerConfigChanges.
+type FirewallerAPIV5 struct {
+	*FirewallerAPIV4
+	*common.ControllerConfigAPI
+}
+
+// NewFirewallerAPIV5 creates a new server-side Firewaller API facade,
+// version 5.
+func NewFirewallerAPIV5(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*FirewallerAPIV5, error) {
+	api, err := NewFirewallerAPIV4(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV5{
+		FirewallerAPIV4:     api,
+		ControllerConfigAPI: common.NewControllerConfig(st),
+	}, nil
+}
+
+// WatchForControllerConfigChanges returns a NotifyWatcher that notifies
+// when the controller configuration changes, so that the firewaller
+// can apply the controller's api-allow setting.
+func (f *FirewallerAPIV5) WatchForControllerConfigChanges() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	watch := f.st.WatchControllerConfig()
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = f.resources.Register(watch)
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
 // WatchOpenedPorts returns a new StringsWatcher for each given
 // environment tag.
 func (f *FirewallerAPI) WatchOpenedPorts(args params.Entities) (params.StringsWatchResults, error) {
@@ -280,6 +345,32 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposedCIDRs returns the source CIDRs each given service is
+// exposed to. An empty result means the service's open ports are
+// accessible from anywhere when it is exposed.
+func (f *FirewallerAPIV4) GetExposedCIDRs(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := f.getService(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result = service.ExposedCIDRs()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
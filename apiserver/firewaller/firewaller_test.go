@@ -10,11 +10,13 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/common"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/firewaller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 )
@@ -23,7 +25,7 @@ type firewallerSuite struct {
 	firewallerBaseSuite
 	*commontesting.ModelWatcherTest
 
-	firewaller *firewaller.FirewallerAPI
+	firewaller *firewaller.FirewallerAPIV5
 }
 
 var _ = gc.Suite(&firewallerSuite{})
@@ -35,7 +37,7 @@ func (s *firewallerSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Create a firewaller API for the machine.
-	firewallerAPI, err := firewaller.NewFirewallerAPI(
+	firewallerAPI, err := firewaller.NewFirewallerAPIV5(
 		s.State,
 		s.resources,
 		s.authorizer,
@@ -77,10 +79,65 @@ func (s *firewallerSuite) TestGetExposed(c *gc.C) {
 	s.testGetExposed(c, s.firewaller)
 }
 
+func (s *firewallerSuite) TestGetExposedCIDRs(c *gc.C) {
+	s.testGetExposedCIDRs(c, s.firewaller)
+}
+
+// newMethods holds the methods added by each version of the facade.
+var newMethods = map[int][]string{
+	4: {"GetExposedCIDRs"},
+	5: {"ControllerConfig", "WatchForControllerConfigChanges"},
+}
+
+func (s *firewallerSuite) TestNewMethodsVersioned(c *gc.C) {
+	for version, methods := range newMethods {
+		older, err := common.Facades.GetType("Firewaller", version-1)
+		c.Assert(err, jc.ErrorIsNil)
+		newer, err := common.Facades.GetType("Firewaller", version)
+		c.Assert(err, jc.ErrorIsNil)
+		for _, name := range methods {
+			_, ok := older.MethodByName(name)
+			c.Check(ok, jc.IsFalse, gc.Commentf("v%d has %s", version-1, name))
+			_, ok = newer.MethodByName(name)
+			c.Check(ok, jc.IsTrue, gc.Commentf("v%d lacks %s", version, name))
+		}
+	}
+}
+
 func (s *firewallerSuite) TestGetAssignedMachine(c *gc.C) {
 	s.testGetAssignedMachine(c, s.firewaller)
 }
 
+func (s *firewallerSuite) TestControllerConfig(c *gc.C) {
+	result, err := s.firewaller.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Config["controller-uuid"], gc.Equals, s.State.ControllerUUID())
+}
+
+func (s *firewallerSuite) TestWatchForControllerConfigChanges(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.firewaller.WatchForControllerConfigChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+
+	// Verify the resource was registered and stop when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event, and
+	// that a change to the controller config is notified.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.APIAllowKey: "10.0.0.0/8",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *firewallerSuite) openPorts(c *gc.C) {
 	// Open some ports on the units.
 	err := s.units[0].OpenPortsOnSubnet("10.20.30.0/24", "tcp", 1234, 1400)
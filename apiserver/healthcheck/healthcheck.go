@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthcheck provides the API facade used by unit agents to
+// report the results of their charms' health checks.
+package healthcheck
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+func init() {
+	common.RegisterStandardFacade("HealthCheck", 1, NewHealthCheckAPI)
+}
+
+// HealthCheck defines the methods exported by the health check API facade.
+type HealthCheck interface {
+	SetHealth(args params.SetStatus) (params.ErrorResults, error)
+}
+
+// HealthCheckAPI implements the HealthCheck interface and is the
+// concrete implementation of the API endpoint.
+type HealthCheckAPI struct {
+	state      *state.State
+	accessUnit common.GetAuthFunc
+}
+
+var _ HealthCheck = (*HealthCheckAPI)(nil)
+
+// NewHealthCheckAPI creates a new API endpoint for reporting the health
+// of units.
+func NewHealthCheckAPI(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*HealthCheckAPI, error) {
+	if !authorizer.AuthUnitAgent() {
+		return nil, common.ErrPerm
+	}
+	return &HealthCheckAPI{
+		state: st,
+		accessUnit: func() (common.AuthFunc, error) {
+			return authorizer.AuthOwner, nil
+		},
+	}, nil
+}
+
+// SetHealth records the results of the health checks of each given unit.
+func (h *HealthCheckAPI) SetHealth(args params.SetStatus) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := h.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			err = h.setOneUnitHealth(tag, arg)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (h *HealthCheckAPI) setOneUnitHealth(tag names.UnitTag, arg params.EntityStatusArgs) error {
+	unit, err := h.state.Unit(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := time.Now()
+	return unit.SetHealth(status.StatusInfo{
+		Status:  status.Status(arg.Status),
+		Message: arg.Info,
+		Data:    arg.Data,
+		Since:   &now,
+	})
+}
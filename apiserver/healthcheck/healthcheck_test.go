@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/healthcheck"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	jujufactory "github.com/juju/juju/testing/factory"
+)
+
+var _ = gc.Suite(&healthCheckSuite{})
+
+type healthCheckSuite struct {
+	jujutesting.JujuConnSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	resources  *common.Resources
+	factory    *jujufactory.Factory
+	unit       *state.Unit
+
+	api healthcheck.HealthCheck
+}
+
+func (s *healthCheckSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+
+	s.factory = jujufactory.NewFactory(s.State)
+	s.unit = s.factory.MakeUnit(c, nil)
+
+	// Set up assuming unit 0 has logged in.
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: s.unit.UnitTag(),
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+
+	api, err := healthcheck.NewHealthCheckAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *healthCheckSuite) TestNewHealthCheckAPIRequiresUnitAgent(c *gc.C) {
+	anAuthorizer := s.authorizer
+	anAuthorizer.Tag = names.NewMachineTag("0")
+	_, err := healthcheck.NewHealthCheckAPI(s.State, s.resources, anAuthorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *healthCheckSuite) TestSetHealth(c *gc.C) {
+	application, err := s.unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	otherUnit := s.factory.MakeUnit(c, &jujufactory.UnitParams{Application: application})
+
+	result, err := s.api.SetHealth(params.SetStatus{
+		Entities: []params.EntityStatusArgs{{
+			Tag:    s.unit.Tag().String(),
+			Status: "unhealthy",
+			Info:   `check "web" failed`,
+			Data:   map[string]interface{}{"web": "connection refused"},
+		}, {
+			Tag:    otherUnit.Tag().String(),
+			Status: "healthy",
+		}, {
+			Tag:    "application-mysql",
+			Status: "healthy",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	health, err := s.unit.Health()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(health.Status, gc.Equals, status.StatusUnhealthy)
+	c.Check(health.Message, gc.Equals, `check "web" failed`)
+	c.Check(health.Data, jc.DeepEquals, map[string]interface{}{"web": "connection refused"})
+}
+
+func (s *healthCheckSuite) TestSetHealthInvalid(c *gc.C) {
+	result, err := s.api.SetHealth(params.SetStatus{
+		Entities: []params.EntityStatusArgs{{
+			Tag:    s.unit.Tag().String(),
+			Status: "active",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `cannot set invalid health status "active"`)
+}
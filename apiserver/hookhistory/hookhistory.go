@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hookhistory provides the API used to inspect the hooks
+// recently run by the units in a model.
+package hookhistory
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("HookHistory", 1, NewHookHistoryAPI)
+}
+
+// HookHistoryAPI provides access to the HookHistory API facade.
+type HookHistoryAPI struct {
+	st *state.State
+}
+
+// NewHookHistoryAPI creates a new server-side HookHistory API facade.
+func NewHookHistoryAPI(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*HookHistoryAPI, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &HookHistoryAPI{st: st}, nil
+}
+
+// HookHistory returns the hooks most recently run by each requested
+// unit, newest first.
+func (api *HookHistoryAPI) HookHistory(args params.HookHistoryRequests) (params.HookHistoryResults, error) {
+	results := params.HookHistoryResults{
+		Results: make([]params.HookHistoryResult, len(args.Requests)),
+	}
+	for i, request := range args.Requests {
+		records, err := api.hookHistory(request)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Records = records
+	}
+	return results, nil
+}
+
+func (api *HookHistoryAPI) hookHistory(request params.HookHistoryRequest) ([]params.HookRecord, error) {
+	tag, err := names.ParseUnitTag(request.Tag)
+	if err != nil {
+		return nil, err
+	}
+	unit, err := api.st.Unit(tag.Id())
+	if err != nil {
+		return nil, err
+	}
+	records, err := unit.HookHistory(request.Size)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]params.HookRecord, len(records))
+	for i, record := range records {
+		result[i] = params.HookRecord{
+			Hook:       record.Hook,
+			RelationId: record.RelationId,
+			RemoteUnit: record.RemoteUnit,
+			Started:    record.Started,
+			Finished:   record.Finished,
+			ExitCode:   record.ExitCode,
+			Error:      record.Error,
+			Output:     record.Output,
+		}
+	}
+	return result, nil
+}
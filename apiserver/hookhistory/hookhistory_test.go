@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hookhistory_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/hookhistory"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+)
+
+type hookHistorySuite struct {
+	jujutesting.JujuConnSuite
+
+	api  *hookhistory.HookHistoryAPI
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&hookHistorySuite{})
+
+func (s *hookHistorySuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+	var err error
+	s.api, err = hookhistory.NewHookHistoryAPI(
+		s.State, common.NewResources(), apiservertesting.FakeAuthorizer{Tag: s.AdminUserTag(c)},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *hookHistorySuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := hookhistory.NewHookHistoryAPI(
+		s.State, common.NewResources(), apiservertesting.FakeAuthorizer{Tag: names.NewUnitTag("mysql/0")},
+	)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *hookHistorySuite) TestHookHistory(c *gc.C) {
+	now := time.Now()
+	err := s.unit.AddHookHistory([]state.HookRecord{{
+		Hook: "install", RelationId: -1, Started: now, Finished: now,
+	}, {
+		Hook: "start", RelationId: -1, Started: now.Add(time.Second), Finished: now.Add(time.Second),
+		ExitCode: 2, Error: "exit status 2", Output: []string{"oops"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.HookHistory(params.HookHistoryRequests{
+		Requests: []params.HookHistoryRequest{
+			{Tag: s.unit.Tag().String()},
+			{Tag: s.unit.Tag().String(), Size: 1},
+			{Tag: "unit-missing-0"},
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Records, gc.HasLen, 2)
+	c.Check(result.Results[0].Records[0].Hook, gc.Equals, "start")
+	c.Check(result.Results[0].Records[0].ExitCode, gc.Equals, 2)
+	c.Check(result.Results[0].Records[0].Error, gc.Equals, "exit status 2")
+	c.Check(result.Results[0].Records[0].Output, jc.DeepEquals, []string{"oops"})
+	c.Check(result.Results[0].Records[1].Hook, gc.Equals, "install")
+
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[1].Records, gc.HasLen, 1)
+	c.Check(result.Results[1].Records[0].Hook, gc.Equals, "start")
+
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `unit "missing/0" not found`)
+	c.Check(result.Results[3].Error, gc.ErrorMatches, `"machine-0" is not a valid unit tag`)
+}
@@ -0,0 +1,132 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package instancedrift provides the API used by the instance drift
+// worker to compare a model's machines with the instances found in
+// its cloud.
+package instancedrift
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+func init() {
+	common.RegisterStandardFacade("InstanceDrift", 1, NewInstanceDriftAPI)
+}
+
+// vanishedMessage is the instance status message recorded against
+// machines whose instances can no longer be found in the cloud.
+const vanishedMessage = "instance not found in cloud"
+
+// InstanceDriftAPI provides access to the InstanceDrift API facade.
+type InstanceDriftAPI struct {
+	st         *state.State
+	authorizer facade.Authorizer
+}
+
+// NewInstanceDriftAPI creates a new server-side InstanceDrift API facade.
+func NewInstanceDriftAPI(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*InstanceDriftAPI, error) {
+	if !authorizer.AuthModelManager() {
+		// InstanceDrift must run as model manager.
+		return nil, common.ErrPerm
+	}
+	return &InstanceDriftAPI{
+		st:         st,
+		authorizer: authorizer,
+	}, nil
+}
+
+// MachineInstances returns the instance details of every machine in
+// the model that is expected to have a cloud instance of its own.
+// Containers, manually provisioned machines and dead machines are
+// omitted.
+func (api *InstanceDriftAPI) MachineInstances() (params.MachineInstancesResult, error) {
+	var result params.MachineInstancesResult
+	machines, err := api.st.AllMachines()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, m := range machines {
+		if m.IsContainer() || m.Life() == state.Dead {
+			continue
+		}
+		manual, err := m.IsManual()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if manual {
+			continue
+		}
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			instId = ""
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		var instStatus status.Status
+		if instId != "" {
+			statusInfo, err := m.InstanceStatus()
+			if err != nil {
+				return result, errors.Trace(err)
+			}
+			instStatus = statusInfo.Status
+		}
+		result.Machines = append(result.Machines, params.MachineInstance{
+			Tag:            m.Tag().String(),
+			InstanceId:     instId,
+			InstanceStatus: string(instStatus),
+		})
+	}
+	return result, nil
+}
+
+// MarkVanished sets the instance status of each of the given machines
+// to indicate that its instance can no longer be found in the cloud.
+func (api *InstanceDriftAPI) MarkVanished(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		err := api.markVanished(arg.Tag)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *InstanceDriftAPI) markVanished(tag string) error {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return common.ErrPerm
+	}
+	machine, err := api.st.Machine(machineTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := time.Now()
+	return machine.SetInstanceStatus(status.StatusInfo{
+		Status:  status.StatusVanished,
+		Message: vanishedMessage,
+		Since:   &now,
+	})
+}
+
+// SetLeakedInstances records the instances that are tagged as
+// belonging to the model, but which are not known to any machine.
+// Any previously recorded instances not in the list are forgotten.
+func (api *InstanceDriftAPI) SetLeakedInstances(args params.SetLeakedInstances) (params.ErrorResult, error) {
+	err := api.st.SetLeakedInstances(args.InstanceIds)
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
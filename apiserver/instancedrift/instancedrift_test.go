@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instancedrift_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/instancedrift"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/instance"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing/factory"
+)
+
+type instanceDriftSuite struct {
+	jujutesting.JujuConnSuite
+
+	api *instancedrift.InstanceDriftAPI
+}
+
+var _ = gc.Suite(&instanceDriftSuite{})
+
+func (s *instanceDriftSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	var err error
+	s.api, err = instancedrift.NewInstanceDriftAPI(
+		s.State, common.NewResources(), apiservertesting.FakeAuthorizer{
+			EnvironManager: true,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *instanceDriftSuite) TestNewAPIRequiresModelManager(c *gc.C) {
+	_, err := instancedrift.NewInstanceDriftAPI(
+		s.State, common.NewResources(), apiservertesting.FakeAuthorizer{},
+	)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *instanceDriftSuite) TestMachineInstances(c *gc.C) {
+	provisioned := s.Factory.MakeMachine(c, &factory.MachineParams{
+		InstanceId: "i-provisioned",
+	})
+	pending, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, provisioned.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.MachineInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	instStatus, err := provisioned.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Machines, jc.SameContents, []params.MachineInstance{{
+		Tag:            provisioned.Tag().String(),
+		InstanceId:     "i-provisioned",
+		InstanceStatus: string(instStatus.Status),
+	}, {
+		Tag: pending.Tag().String(),
+	}})
+}
+
+func (s *instanceDriftSuite) TestMarkVanished(c *gc.C) {
+	m := s.Factory.MakeMachine(c, nil)
+	result, err := s.api.MarkVanished(params.Entities{
+		Entities: []params.Entity{{Tag: m.Tag().String()}, {Tag: "application-foo"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	instStatus, err := m.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instStatus.Status, gc.Equals, status.StatusVanished)
+	c.Assert(instStatus.Message, gc.Equals, "instance not found in cloud")
+}
+
+func (s *instanceDriftSuite) TestSetLeakedInstances(c *gc.C) {
+	result, err := s.api.SetLeakedInstances(params.SetLeakedInstances{
+		InstanceIds: []instance.Id{"i-leaked"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	leaked, err := s.State.LeakedInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(leaked, gc.HasLen, 1)
+	c.Assert(leaked[0].InstanceId, gc.Equals, instance.Id("i-leaked"))
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -14,10 +15,13 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 )
 
 func init() {
 	common.RegisterStandardFacade("MachineManager", 2, NewMachineManagerAPI)
+	common.RegisterStandardFacade("MachineManager", 3, NewMachineManagerAPIV3)
+	common.RegisterStandardFacade("MachineManager", 4, NewMachineManagerAPIV4)
 }
 
 // MachineManagerAPI provides access to the MachineManager API facade.
@@ -50,6 +54,46 @@ func NewMachineManagerAPI(
 	}, nil
 }
 
+// MachineManagerAPIV3 provides access to version 3 of the
+// MachineManager API facade, which adds UpdateMachineSeries.
+type MachineManagerAPIV3 struct {
+	*MachineManagerAPI
+}
+
+// NewMachineManagerAPIV3 creates a new server-side MachineManager API
+// facade, version 3.
+func NewMachineManagerAPIV3(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*MachineManagerAPIV3, error) {
+	api, err := NewMachineManagerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &MachineManagerAPIV3{api}, nil
+}
+
+// MachineManagerAPIV4 provides access to version 4 of the
+// MachineManager API facade, which adds ModelDrift.
+type MachineManagerAPIV4 struct {
+	*MachineManagerAPIV3
+}
+
+// NewMachineManagerAPIV4 creates a new server-side MachineManager API
+// facade, version 4.
+func NewMachineManagerAPIV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*MachineManagerAPIV4, error) {
+	api, err := NewMachineManagerAPIV3(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &MachineManagerAPIV4{api}, nil
+}
+
 // AddMachines adds new machines with the supplied parameters.
 func (mm *MachineManagerAPI) AddMachines(args params.AddMachines) (params.AddMachinesResults, error) {
 	results := params.AddMachinesResults{
@@ -158,3 +202,76 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 	}
 	return mm.st.AddMachineInsideNewMachine(template, template, p.ContainerType)
 }
+
+// UpdateMachineSeries changes the series of the given machines, and of
+// the units assigned to them, after their OS has been upgraded in place.
+func (mm *MachineManagerAPIV3) UpdateMachineSeries(args params.UpdateSeriesArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		err := mm.updateOneMachineSeries(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPIV3) updateOneMachineSeries(arg params.UpdateSeriesArg) error {
+	if arg.Series == "" {
+		return errors.BadRequestf("series missing from args")
+	}
+	tag, err := names.ParseMachineTag(arg.Entity.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machine, err := mm.st.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return machine.UpdateMachineSeries(arg.Series, arg.Force)
+}
+
+// ModelDrift reports the machines whose instances can no longer be
+// found in the cloud, and the instances found in the cloud that are
+// tagged as belonging to the model but unknown to any machine.
+func (mm *MachineManagerAPIV4) ModelDrift() (params.ModelDrift, error) {
+	var result params.ModelDrift
+	machines, err := mm.st.AllMachines()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, m := range machines {
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		statusInfo, err := m.InstanceStatus()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if statusInfo.Status != status.StatusVanished {
+			continue
+		}
+		result.Vanished = append(result.Vanished, params.VanishedMachine{
+			Tag:        m.Tag().String(),
+			InstanceId: instId,
+			Since:      statusInfo.Since,
+		})
+	}
+	leaked, err := mm.st.LeakedInstances()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, l := range leaked {
+		result.Leaked = append(result.Leaked, params.LeakedInstance{
+			InstanceId: l.InstanceId,
+			FirstSeen:  l.FirstSeen,
+		})
+	}
+	return result, nil
+}
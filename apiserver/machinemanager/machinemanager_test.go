@@ -5,7 +5,9 @@ package machinemanager_test
 
 import (
 	"errors"
+	"time"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -18,6 +20,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -29,7 +32,7 @@ type MachineManagerSuite struct {
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
 	st         *mockState
-	api        *machinemanager.MachineManagerAPI
+	api        *machinemanager.MachineManagerAPIV4
 }
 
 func (s *MachineManagerSuite) SetUpTest(c *gc.C) {
@@ -41,7 +44,7 @@ func (s *MachineManagerSuite) SetUpTest(c *gc.C) {
 	machinemanager.PatchState(s, s.st)
 
 	var err error
-	s.api, err = machinemanager.NewMachineManagerAPI(nil, nil, s.authorizer)
+	s.api, err = machinemanager.NewMachineManagerAPIV4(nil, nil, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -118,10 +121,110 @@ func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	c.Assert(s.st.calls, gc.Equals, 1)
 }
 
+// newMethods holds the methods added by each version of the facade.
+var newMethods = map[int][]string{
+	3: {"UpdateMachineSeries"},
+	4: {"ModelDrift"},
+}
+
+func (s *MachineManagerSuite) TestNewMethodsVersioned(c *gc.C) {
+	for version, methods := range newMethods {
+		older, err := common.Facades.GetType("MachineManager", version-1)
+		c.Assert(err, jc.ErrorIsNil)
+		newer, err := common.Facades.GetType("MachineManager", version)
+		c.Assert(err, jc.ErrorIsNil)
+		for _, name := range methods {
+			_, ok := older.MethodByName(name)
+			c.Check(ok, jc.IsFalse, gc.Commentf("v%d has %s", version-1, name))
+			_, ok = newer.MethodByName(name)
+			c.Check(ok, jc.IsTrue, gc.Commentf("v%d lacks %s", version, name))
+		}
+	}
+}
+
+func (s *MachineManagerSuite) TestUpdateMachineSeries(c *gc.C) {
+	s.st.machine = &mockMachine{}
+	results, err := s.api.UpdateMachineSeries(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: "machine-1"},
+			Series: "xenial",
+			Force:  true,
+		}, {
+			Entity: params.Entity{Tag: "application-mysql"},
+			Series: "xenial",
+		}, {
+			Entity: params.Entity{Tag: "machine-1"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"application-mysql" is not a valid machine tag`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "series missing from args")
+	c.Assert(s.st.machine.series, gc.Equals, "xenial")
+	c.Assert(s.st.machine.force, jc.IsTrue)
+}
+
+func (s *MachineManagerSuite) TestUpdateMachineSeriesError(c *gc.C) {
+	s.st.machine = &mockMachine{err: errors.New("boom")}
+	results, err := s.api.UpdateMachineSeries(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: "machine-1"},
+			Series: "xenial",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "boom")
+}
+
+func (s *MachineManagerSuite) TestModelDrift(c *gc.C) {
+	since := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	s.st.allMachines = []machinemanager.Machine{
+		&mockMachine{tag: names.NewMachineTag("0"), instId: "i-0", instStatus: status.StatusRunning},
+		&mockMachine{tag: names.NewMachineTag("1"), instId: "i-1", instStatus: status.StatusVanished, since: &since},
+		&mockMachine{tag: names.NewMachineTag("2")},
+	}
+	s.st.leaked = []state.LeakedInstance{{InstanceId: "i-leaked", FirstSeen: since}}
+	result, err := s.api.ModelDrift()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelDrift{
+		Vanished: []params.VanishedMachine{{
+			Tag:        "machine-1",
+			InstanceId: "i-1",
+			Since:      &since,
+		}},
+		Leaked: []params.LeakedInstance{{
+			InstanceId: "i-leaked",
+			FirstSeen:  since,
+		}},
+	})
+}
+
+func (s *MachineManagerSuite) TestModelDriftError(c *gc.C) {
+	s.st.err = errors.New("boom")
+	_, err := s.api.ModelDrift()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 type mockState struct {
-	calls    int
-	machines []state.MachineTemplate
-	err      error
+	calls       int
+	machines    []state.MachineTemplate
+	machine     *mockMachine
+	allMachines []machinemanager.Machine
+	leaked      []state.LeakedInstance
+	err         error
+}
+
+func (st *mockState) Machine(id string) (machinemanager.Machine, error) {
+	return st.machine, nil
+}
+
+func (st *mockState) AllMachines() ([]machinemanager.Machine, error) {
+	return st.allMachines, st.err
+}
+
+func (st *mockState) LeakedInstances() ([]state.LeakedInstance, error) {
+	return st.leaked, st.err
 }
 
 func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machine, error) {
@@ -151,6 +254,37 @@ func (st *mockState) AddMachineInsideMachine(template state.MachineTemplate, par
 	panic("not implemented")
 }
 
+type mockMachine struct {
+	tag        names.MachineTag
+	instId     instance.Id
+	instStatus status.Status
+	since      *time.Time
+	series     string
+	force      bool
+	err        error
+}
+
+func (m *mockMachine) Tag() names.Tag {
+	return m.tag
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instId == "" {
+		return "", jujuerrors.NotProvisionedf("machine %v", m.tag.Id())
+	}
+	return m.instId, nil
+}
+
+func (m *mockMachine) InstanceStatus() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: m.instStatus, Since: m.since}, nil
+}
+
+func (m *mockMachine) UpdateMachineSeries(series string, force bool) error {
+	m.series = series
+	m.force = force
+	return m.err
+}
+
 type mockBlock struct {
 	state.Block
 }
@@ -4,9 +4,12 @@
 package machinemanager
 
 import (
+	"gopkg.in/juju/names.v2"
+
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 )
 
 type stateInterface interface {
@@ -16,6 +19,17 @@ type stateInterface interface {
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	Machine(id string) (Machine, error)
+	AllMachines() ([]Machine, error)
+	LeakedInstances() ([]state.LeakedInstance, error)
+}
+
+// Machine defines the machine methods used by the MachineManager facade.
+type Machine interface {
+	Tag() names.Tag
+	InstanceId() (instance.Id, error)
+	InstanceStatus() (status.StatusInfo, error)
+	UpdateMachineSeries(series string, force bool) error
 }
 
 type stateShim struct {
@@ -45,3 +59,27 @@ func (s stateShim) AddMachineInsideNewMachine(template, parentTemplate state.Mac
 func (s stateShim) AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error) {
 	return s.State.AddMachineInsideMachine(template, parentId, containerType)
 }
+
+func (s stateShim) Machine(id string) (Machine, error) {
+	m, err := s.State.Machine(id)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s stateShim) AllMachines() ([]Machine, error) {
+	all, err := s.State.AllMachines()
+	if err != nil {
+		return nil, err
+	}
+	machines := make([]Machine, len(all))
+	for i, m := range all {
+		machines[i] = m
+	}
+	return machines, nil
+}
+
+func (s stateShim) LeakedInstances() ([]state.LeakedInstance, error) {
+	return s.State.LeakedInstances()
+}
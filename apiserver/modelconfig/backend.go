@@ -4,7 +4,11 @@
 package modelconfig
 
 import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
@@ -15,6 +19,10 @@ type Backend interface {
 	common.BlockGetter
 	ModelConfigValues() (config.ConfigValues, error)
 	UpdateModelConfig(map[string]interface{}, []string, state.ValidateConfigFunc) error
+	ModelConfigDefaultValues() (config.ModelDefaultAttributes, error)
+	UpdateModelConfigDefaultValues(map[string]interface{}, []string, string) error
+	IsControllerAdministrator(names.UserTag) (bool, error)
+	ControllerCloud() (cloud.Cloud, error)
 }
 
 type stateShim struct {
@@ -25,3 +33,12 @@ type stateShim struct {
 func NewStateBackend(st *state.State) Backend {
 	return stateShim{st}
 }
+
+// ControllerCloud returns the cloud hosting the controller model.
+func (s stateShim) ControllerCloud() (cloud.Cloud, error) {
+	model, err := s.State.ControllerModel()
+	if err != nil {
+		return cloud.Cloud{}, errors.Trace(err)
+	}
+	return s.State.Cloud(model.Cloud())
+}
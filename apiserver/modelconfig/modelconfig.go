@@ -5,6 +5,8 @@ package modelconfig
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/environschema.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -16,12 +18,17 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("ModelConfig", 1, newFacade)
+	common.RegisterStandardFacade("ModelConfig", 2, newFacadeV2)
 }
 
 func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*ModelConfigAPI, error) {
 	return NewModelConfigAPI(NewStateBackend(st), auth)
 }
 
+func newFacadeV2(st *state.State, _ facade.Resources, auth facade.Authorizer) (*ModelConfigAPIV2, error) {
+	return NewModelConfigAPIV2(NewStateBackend(st), auth)
+}
+
 // ModelConfigAPI is the endpoint which implements the model config facade.
 type ModelConfigAPI struct {
 	backend Backend
@@ -42,6 +49,22 @@ func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelCon
 	return client, nil
 }
 
+// ModelConfigAPIV2 implements version 2 of the model config facade,
+// which adds the controller wide model defaults.
+type ModelConfigAPIV2 struct {
+	*ModelConfigAPI
+}
+
+// NewModelConfigAPIV2 creates a new instance of version 2 of the
+// ModelConfig Facade.
+func NewModelConfigAPIV2(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPIV2, error) {
+	api, err := NewModelConfigAPI(backend, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelConfigAPIV2{api}, nil
+}
+
 // ModelGet implements the server-side part of the
 // get-model-config CLI command.
 func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
@@ -128,3 +151,134 @@ func (c *ModelConfigAPI) ModelUnset(args params.ModelUnset) error {
 	// changed underneath us.
 	return c.backend.UpdateModelConfig(nil, args.Keys, nil)
 }
+
+// ModelDefaults returns the default config values used when creating
+// a new model, along with the level at which each value is defined.
+func (c *ModelConfigAPIV2) ModelDefaults() (params.ModelDefaultsResult, error) {
+	result := params.ModelDefaultsResult{}
+	values, err := c.backend.ModelConfigDefaultValues()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Config = make(map[string]params.ModelDefaults)
+	for attr, val := range values {
+		settings := params.ModelDefaults{
+			Controller: val.Controller,
+			Default:    val.Default,
+		}
+		for _, v := range val.Regions {
+			settings.Regions = append(
+				settings.Regions, params.RegionDefaults{
+					RegionName: v.Name,
+					Value:      v.Value})
+		}
+		result.Config[attr] = settings
+	}
+	return result, nil
+}
+
+// SetModelDefaults writes new values for the specified default model
+// settings, either for the controller or for a cloud region.
+func (c *ModelConfigAPIV2) SetModelDefaults(args params.SetModelDefaults) (params.ErrorResults, error) {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Config))}
+	if err := c.checkCanWriteDefaults(); err != nil {
+		return results, errors.Trace(err)
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	fields, err := c.providerSchema()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Config {
+		// Replace any deprecated attributes with their new values.
+		attrs := config.ProcessDeprecatedAttributes(arg.Config)
+		attrs, err := validateModelDefaults(fields, attrs)
+		if err == nil {
+			err = c.backend.UpdateModelConfigDefaultValues(attrs, nil, arg.CloudRegion)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// providerSchema returns the config schema of the controller cloud's
+// provider, or nil if the provider does not publish one. Model defaults
+// apply to every model on the controller's cloud, so they are checked
+// against that rather than against the config of any one model.
+func (c *ModelConfigAPIV2) providerSchema() (environschema.Fields, error) {
+	controllerCloud, err := c.backend.ControllerCloud()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider, err := environs.Provider(controllerCloud.Type)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	schemaProvider, ok := provider.(environs.ProviderSchema)
+	if !ok {
+		return nil, nil
+	}
+	return schemaProvider.Schema(), nil
+}
+
+// validateModelDefaults checks the given attributes against the given
+// provider schema, and returns them coerced to the types it expects.
+// Without a provider schema, only the attributes common to all
+// providers are checked, and any others are left for the provider to
+// validate when a model inheriting them is created.
+func validateModelDefaults(fields environschema.Fields, attrs map[string]interface{}) (map[string]interface{}, error) {
+	if fields == nil {
+		commonFields, err := config.Schema(nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		fields = commonFields
+	} else {
+		for attr := range attrs {
+			if _, ok := fields[attr]; !ok {
+				return nil, errors.NotValidf("unknown model config attribute %q", attr)
+			}
+		}
+	}
+	result, err := config.CoerceForSchema(fields, attrs)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid model defaults")
+	}
+	return result, nil
+}
+
+// UnsetModelDefaults removes the specified default model settings,
+// either for the controller or for a cloud region.
+func (c *ModelConfigAPIV2) UnsetModelDefaults(args params.UnsetModelDefaults) (params.ErrorResults, error) {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Keys))}
+	if err := c.checkCanWriteDefaults(); err != nil {
+		return results, errors.Trace(err)
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Keys {
+		err := c.backend.UpdateModelConfigDefaultValues(nil, arg.Keys, arg.CloudRegion)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// checkCanWriteDefaults returns an error if the authenticated user
+// is not permitted to change the controller wide model defaults.
+func (c *ModelConfigAPIV2) checkCanWriteDefaults() error {
+	apiUser, ok := c.auth.GetAuthTag().(names.UserTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	isAdmin, err := c.backend.IsControllerAdministrator(apiUser)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
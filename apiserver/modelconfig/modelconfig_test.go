@@ -10,9 +10,11 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/modelconfig"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/dummy"
@@ -24,7 +26,7 @@ type modelconfigSuite struct {
 	gitjujutesting.IsolationSuite
 	backend    *mockBackend
 	authorizer apiservertesting.FakeAuthorizer
-	api        *modelconfig.ModelConfigAPI
+	api        *modelconfig.ModelConfigAPIV2
 }
 
 var _ = gc.Suite(&modelconfigSuite{})
@@ -41,9 +43,21 @@ func (s *modelconfigSuite) SetUpTest(c *gc.C) {
 			"ftp-proxy":       {"http://proxy", "model"},
 			"authorized-keys": {testing.FakeAuthKeys, "model"},
 		},
+		defaults: config.ModelDefaultAttributes{
+			"attr": {Default: "val", Controller: "val2"},
+			"attr2": {
+				Controller: "val3",
+				Regions: []config.RegionDefaultValue{{
+					Name:  "dummy",
+					Value: "val4",
+				}},
+			},
+		},
+		admin: true,
+		cloud: cloud.Cloud{Type: "dummy"},
 	}
 	var err error
-	s.api, err = modelconfig.NewModelConfigAPI(s.backend, &s.authorizer)
+	s.api, err = modelconfig.NewModelConfigAPIV2(s.backend, &s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -152,11 +166,155 @@ func (s *modelconfigSuite) TestModelUnsetMissing(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestModelDefaults(c *gc.C) {
+	result, err := s.api.ModelDefaults()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Config, jc.DeepEquals, map[string]params.ModelDefaults{
+		"attr": {Default: "val", Controller: "val2"},
+		"attr2": {
+			Controller: "val3",
+			Regions: []params.RegionDefaults{{
+				RegionName: "dummy",
+				Value:      "val4",
+			}},
+		},
+	})
+}
+
+func (s *modelconfigSuite) TestSetModelDefaults(c *gc.C) {
+	result, err := s.api.SetModelDefaults(params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			Config: map[string]interface{}{"ftp-proxy": "http://new"},
+		}, {
+			CloudRegion: "dummy",
+			Config:      map[string]interface{}{"ftp-proxy": "http://newer"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	c.Assert(s.backend.defaults["ftp-proxy"], jc.DeepEquals, config.AttributeDefaultValues{
+		Controller: "http://new",
+		Regions:    []config.RegionDefaultValue{{"dummy", "http://newer"}},
+	})
+}
+
+func (s *modelconfigSuite) TestSetModelDefaultsUnknownAttribute(c *gc.C) {
+	result, err := s.api.SetModelDefaults(params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			Config: map[string]interface{}{"attr": "new"},
+		}, {
+			Config: map[string]interface{}{"ftp-proxy": "http://new"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `unknown model config attribute "attr" not valid`)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(s.backend.defaults["attr"].Controller, gc.Equals, "val2")
+}
+
+func (s *modelconfigSuite) TestSetModelDefaultsCoerces(c *gc.C) {
+	result, err := s.api.SetModelDefaults(params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			Config: map[string]interface{}{"enable-os-upgrade": "false"},
+		}, {
+			Config: map[string]interface{}{"firewall-mode": "bogus"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `invalid model defaults: firewall-mode: .*`)
+	c.Assert(s.backend.defaults["enable-os-upgrade"].Controller, gc.Equals, false)
+	c.Assert(s.backend.defaults["firewall-mode"].Controller, gc.IsNil)
+}
+
+func (s *modelconfigSuite) TestSetModelDefaultsUsesControllerCloud(c *gc.C) {
+	// The model defaults are checked against the controller cloud's
+	// provider, whatever the type of the current model.
+	s.backend.cfg["type"] = config.ConfigValue{"unknown", "model"}
+	result, err := s.api.SetModelDefaults(params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			Config: map[string]interface{}{"ftp-proxy": "http://new"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	s.backend.cloud.Type = "unknown"
+	_, err = s.api.SetModelDefaults(params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			Config: map[string]interface{}{"ftp-proxy": "http://newer"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, `no registered provider for "unknown"`)
+}
+
+func (s *modelconfigSuite) TestModelDefaultsOnlyInV2(c *gc.C) {
+	v1, err := common.Facades.GetType("ModelConfig", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	v2, err := common.Facades.GetType("ModelConfig", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, method := range []string{"ModelDefaults", "SetModelDefaults", "UnsetModelDefaults"} {
+		_, ok := v1.MethodByName(method)
+		c.Check(ok, jc.IsFalse, gc.Commentf("method %s", method))
+		_, ok = v2.MethodByName(method)
+		c.Check(ok, jc.IsTrue, gc.Commentf("method %s", method))
+	}
+}
+
+func (s *modelconfigSuite) TestUnsetModelDefaults(c *gc.C) {
+	result, err := s.api.UnsetModelDefaults(params.UnsetModelDefaults{
+		Keys: []params.ModelUnsetKeys{{
+			Keys: []string{"attr"},
+		}, {
+			CloudRegion: "dummy",
+			Keys:        []string{"attr2"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	c.Assert(s.backend.defaults["attr"], jc.DeepEquals, config.AttributeDefaultValues{
+		Default: "val",
+	})
+	c.Assert(s.backend.defaults["attr2"], jc.DeepEquals, config.AttributeDefaultValues{
+		Controller: "val3",
+	})
+}
+
+func (s *modelconfigSuite) TestSetModelDefaultsNotAdmin(c *gc.C) {
+	s.backend.admin = false
+	_, err := s.api.SetModelDefaults(params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			Config: map[string]interface{}{"attr": "new"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = s.api.UnsetModelDefaults(params.UnsetModelDefaults{
+		Keys: []params.ModelUnsetKeys{{Keys: []string{"attr"}}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(s.backend.defaults["attr"].Controller, gc.Equals, "val2")
+}
+
+func (s *modelconfigSuite) TestBlockChangesSetModelDefaults(c *gc.C) {
+	s.blockAllChanges(c, "TestBlockChangesSetModelDefaults")
+	_, err := s.api.SetModelDefaults(params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			Config: map[string]interface{}{"attr": "new"},
+		}},
+	})
+	s.assertBlocked(c, err, "TestBlockChangesSetModelDefaults")
+}
+
 type mockBackend struct {
-	cfg config.ConfigValues
-	old *config.Config
-	b   state.BlockType
-	msg string
+	cfg      config.ConfigValues
+	old      *config.Config
+	b        state.BlockType
+	msg      string
+	defaults config.ModelDefaultAttributes
+	admin    bool
+	cloud    cloud.Cloud
 }
 
 func (m *mockBackend) ModelConfigValues() (config.ConfigValues, error) {
@@ -179,6 +337,40 @@ func (m *mockBackend) UpdateModelConfig(update map[string]interface{}, remove []
 	return nil
 }
 
+func (m *mockBackend) ModelConfigDefaultValues() (config.ModelDefaultAttributes, error) {
+	return m.defaults, nil
+}
+
+func (m *mockBackend) UpdateModelConfigDefaultValues(update map[string]interface{}, remove []string, regionName string) error {
+	for k, v := range update {
+		ds := m.defaults[k]
+		if regionName == "" {
+			ds.Controller = v
+		} else {
+			ds.Regions = append(ds.Regions, config.RegionDefaultValue{regionName, v})
+		}
+		m.defaults[k] = ds
+	}
+	for _, n := range remove {
+		ds := m.defaults[n]
+		if regionName == "" {
+			ds.Controller = nil
+		} else {
+			ds.Regions = nil
+		}
+		m.defaults[n] = ds
+	}
+	return nil
+}
+
+func (m *mockBackend) IsControllerAdministrator(user names.UserTag) (bool, error) {
+	return m.admin, nil
+}
+
+func (m *mockBackend) ControllerCloud() (cloud.Cloud, error) {
+	return m.cloud, nil
+}
+
 func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	if m.b == t {
 		return &mockBlock{t: t, m: m.msg}, true, nil
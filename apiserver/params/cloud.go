@@ -90,3 +90,25 @@ type CloudDefaultsResult struct {
 type CloudDefaultsResults struct {
 	Results []CloudDefaultsResult `json:"results,omitempty"`
 }
+
+// CloudSpec holds a cloud specification: the cloud, region and
+// endpoints a model uses, and the credential it authenticates with.
+type CloudSpec struct {
+	Type            string           `json:"type"`
+	Name            string           `json:"name"`
+	Region          string           `json:"region,omitempty"`
+	Endpoint        string           `json:"endpoint,omitempty"`
+	StorageEndpoint string           `json:"storage-endpoint,omitempty"`
+	Credential      *CloudCredential `json:"credential,omitempty"`
+}
+
+// CloudSpecResult contains a CloudSpec or an error.
+type CloudSpecResult struct {
+	Result *CloudSpec `json:"result,omitempty"`
+	Error  *Error     `json:"error,omitempty"`
+}
+
+// CloudSpecResults contains a set of CloudSpecResults.
+type CloudSpecResults struct {
+	Results []CloudSpecResult `json:"results,omitempty"`
+}
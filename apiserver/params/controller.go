@@ -33,6 +33,12 @@ type RemoveBlocksArgs struct {
 	All bool `json:"all"`
 }
 
+// ControllerConfigSet holds new config values for
+// Controller.ConfigSet.
+type ControllerConfigSet struct {
+	Config map[string]interface{} `json:"config"`
+}
+
 // ModelStatus holds information about the status of a juju model.
 type ModelStatus struct {
 	ModelTag           string `json:"model-tag"`
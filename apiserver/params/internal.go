@@ -368,6 +368,26 @@ type InstancesInfo struct {
 	Machines []InstanceInfo `json:"machines"`
 }
 
+// MachineInstance holds a machine tag, the provider-specific id of
+// the machine's instance (empty if the machine is not provisioned),
+// and the machine's current instance status.
+type MachineInstance struct {
+	Tag            string      `json:"tag"`
+	InstanceId     instance.Id `json:"instance-id"`
+	InstanceStatus string      `json:"instance-status"`
+}
+
+// MachineInstancesResult holds the results of a MachineInstances call.
+type MachineInstancesResult struct {
+	Machines []MachineInstance `json:"machines"`
+}
+
+// SetLeakedInstances holds the parameters for making a
+// SetLeakedInstances call.
+type SetLeakedInstances struct {
+	InstanceIds []instance.Id `json:"instance-ids"`
+}
+
 // EntityStatus holds the status of an entity.
 type EntityStatus struct {
 	Status status.Status          `json:"status"`
@@ -566,13 +586,16 @@ type CharmsResponse struct {
 
 // RunParams is used to provide the parameters to the Run method.
 // Commands and Timeout are expected to have values, and one or more
-// values should be in the Machines, Applications, or Units slices.
+// values should be in the Machines, Applications, Units or UnitsMatching
+// slices. UnitsMatching holds "key=value" selectors restricting the
+// targeted units; it is supported from version 3 of the Action facade.
 type RunParams struct {
-	Commands     string        `json:"commands"`
-	Timeout      time.Duration `json:"timeout"`
-	Machines     []string      `json:"machines,omitempty"`
-	Applications []string      `json:"applications,omitempty"`
-	Units        []string      `json:"units,omitempty"`
+	Commands      string        `json:"commands"`
+	Timeout       time.Duration `json:"timeout"`
+	Machines      []string      `json:"machines,omitempty"`
+	Applications  []string      `json:"applications,omitempty"`
+	Units         []string      `json:"units,omitempty"`
+	UnitsMatching []string      `json:"units-matching,omitempty"`
 }
 
 // RunResult contains the result from an individual run call on a machine.
@@ -601,16 +624,24 @@ type AgentVersionResult struct {
 
 // ProvisioningInfo holds machine provisioning info.
 type ProvisioningInfo struct {
-	Constraints      constraints.Value         `json:"constraints"`
-	Series           string                    `json:"series"`
-	Placement        string                    `json:"placement"`
-	Jobs             []multiwatcher.MachineJob `json:"jobs"`
-	Volumes          []VolumeParams            `json:"volumes,omitempty"`
-	Tags             map[string]string         `json:"tags,omitempty"`
-	SubnetsToZones   map[string][]string       `json:"subnets-to-zones,omitempty"`
-	ImageMetadata    []CloudImageMetadata      `json:"image-metadata,omitempty"`
-	EndpointBindings map[string]string         `json:"endpoint-bindings,omitempty"`
-	ControllerConfig map[string]interface{}    `json:"controller-config,omitempty"`
+	Constraints      constraints.Value          `json:"constraints"`
+	Series           string                     `json:"series"`
+	Placement        string                     `json:"placement"`
+	Jobs             []multiwatcher.MachineJob  `json:"jobs"`
+	Volumes          []VolumeParams             `json:"volumes,omitempty"`
+	Tags             map[string]string          `json:"tags,omitempty"`
+	SubnetsToZones   map[string][]string        `json:"subnets-to-zones,omitempty"`
+	ImageMetadata    []CloudImageMetadata       `json:"image-metadata,omitempty"`
+	EndpointBindings map[string]string          `json:"endpoint-bindings,omitempty"`
+	ControllerConfig map[string]interface{}     `json:"controller-config,omitempty"`
+	CharmLXDProfiles map[string]CharmLXDProfile `json:"charm-lxd-profiles,omitempty"`
+}
+
+// CharmLXDProfile holds an LXD profile supplied by a charm.
+type CharmLXDProfile struct {
+	Description string                       `json:"description,omitempty"`
+	Config      map[string]string            `json:"config,omitempty"`
+	Devices     map[string]map[string]string `json:"devices,omitempty"`
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
@@ -707,3 +738,114 @@ type GUIVersionRequest struct {
 	// Version holds the Juju GUI version number.
 	Version version.Number `json:"version"`
 }
+
+// GoalStateStatus describes the expected status of a unit or
+// application: one of "waiting", "joining", "joined" or "dying".
+type GoalStateStatus struct {
+	Status string `json:"status"`
+}
+
+// UnitsGoalState maps unit and application names to their goal state
+// status.
+type UnitsGoalState map[string]GoalStateStatus
+
+// GoalState describes the intended topology of a unit's application
+// and of the applications related to it, keyed by endpoint name.
+type GoalState struct {
+	Units     UnitsGoalState            `json:"units"`
+	Relations map[string]UnitsGoalState `json:"relations"`
+}
+
+// GoalStateResult holds the goal state of a unit, or an error.
+type GoalStateResult struct {
+	Result *GoalState `json:"result,omitempty"`
+	Error  *Error     `json:"error,omitempty"`
+}
+
+// GoalStateResults holds the results of a GoalStates call.
+type GoalStateResults struct {
+	Results []GoalStateResult `json:"results"`
+}
+
+// UnitStateResult holds the state the uniter has persisted for a unit.
+type UnitStateResult struct {
+	// UniterState is the uniter's serialized operation state.
+	UniterState string `json:"uniter-state,omitempty"`
+
+	// RelationState maps relation ids, formatted in decimal, to the
+	// uniter's serialized state for each relation.
+	RelationState map[string]string `json:"relation-state,omitempty"`
+
+	// SkippedHooks describes the hooks the uniter skipped when the
+	// unit was resolved without retrying its failed hooks.
+	SkippedHooks []string `json:"skipped-hooks,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// UnitStateResults holds the results of a State call.
+type UnitStateResults struct {
+	Results []UnitStateResult `json:"results"`
+}
+
+// SetUnitStateArg holds a change to the state the uniter persists for
+// a unit. Fields left nil are not changed.
+type SetUnitStateArg struct {
+	Tag           string             `json:"tag"`
+	UniterState   *string            `json:"uniter-state,omitempty"`
+	RelationState *map[string]string `json:"relation-state,omitempty"`
+	SkippedHooks  *[]string          `json:"skipped-hooks,omitempty"`
+}
+
+// SetUnitStateArgs holds the arguments of a SetState call.
+type SetUnitStateArgs struct {
+	Entities []SetUnitStateArg `json:"entities"`
+}
+
+// HookRecord describes a single execution of a hook by a unit.
+type HookRecord struct {
+	Hook       string    `json:"hook"`
+	RelationId int       `json:"relation-id"`
+	RemoteUnit string    `json:"remote-unit,omitempty"`
+	Started    time.Time `json:"started"`
+	Finished   time.Time `json:"finished"`
+	ExitCode   int       `json:"exit-code"`
+	Error      string    `json:"error,omitempty"`
+	Output     []string  `json:"output,omitempty"`
+}
+
+// HookHistoryArg holds the hooks run by a unit, for recording by the
+// controller.
+type HookHistoryArg struct {
+	Tag     string       `json:"tag"`
+	Records []HookRecord `json:"records"`
+}
+
+// HookHistoryArgs holds the arguments of an AddHookHistory call.
+type HookHistoryArgs struct {
+	Args []HookHistoryArg `json:"args"`
+}
+
+// HookHistoryRequest identifies the unit whose hook history is wanted,
+// and the maximum number of records to return; zero means all.
+type HookHistoryRequest struct {
+	Tag  string `json:"tag"`
+	Size int    `json:"size,omitempty"`
+}
+
+// HookHistoryRequests holds the arguments of a HookHistory call.
+type HookHistoryRequests struct {
+	Requests []HookHistoryRequest `json:"requests"`
+}
+
+// HookHistoryResult holds the hooks most recently run by a unit,
+// newest first.
+type HookHistoryResult struct {
+	Records []HookRecord `json:"records"`
+	Error   *Error       `json:"error,omitempty"`
+}
+
+// HookHistoryResults holds the results of a HookHistory call.
+type HookHistoryResults struct {
+	Results []HookHistoryResult `json:"results"`
+}
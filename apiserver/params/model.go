@@ -34,6 +34,53 @@ type ModelUnset struct {
 	Keys []string `json:"keys"`
 }
 
+// ModelDefaultsResult contains the result of client API calls to get the
+// model default values.
+type ModelDefaultsResult struct {
+	Config map[string]ModelDefaults `json:"config"`
+}
+
+// ModelDefaults holds the settings for a given ModelDefaultsResult config
+// attribute.
+type ModelDefaults struct {
+	Default    interface{}      `json:"default,omitempty"`
+	Controller interface{}      `json:"controller,omitempty"`
+	Regions    []RegionDefaults `json:"regions,omitempty"`
+}
+
+// RegionDefaults contains the settings for regions in a ModelDefaults.
+type RegionDefaults struct {
+	RegionName string      `json:"region-name"`
+	Value      interface{} `json:"value"`
+}
+
+// SetModelDefaults contains the arguments for SetModelDefaults
+// client API call.
+type SetModelDefaults struct {
+	Config []ModelDefaultValues `json:"config"`
+}
+
+// ModelDefaultValues contains the default model values for
+// the controller, or for a cloud region if one is specified.
+type ModelDefaultValues struct {
+	CloudRegion string                 `json:"cloud-region,omitempty"`
+	Config      map[string]interface{} `json:"config"`
+}
+
+// UnsetModelDefaults contains the arguments for UnsetModelDefaults
+// client API call.
+type UnsetModelDefaults struct {
+	Keys []ModelUnsetKeys `json:"keys"`
+}
+
+// ModelUnsetKeys contains the config keys to unset from the default
+// model values for the controller, or for a cloud region if one is
+// specified.
+type ModelUnsetKeys struct {
+	CloudRegion string   `json:"cloud-region,omitempty"`
+	Keys        []string `json:"keys"`
+}
+
 // SetModelAgentVersion contains the arguments for
 // SetModelAgentVersion client API call.
 type SetModelAgentVersion struct {
@@ -176,6 +176,41 @@ type AddMachines struct {
 	MachineParams []AddMachineParams `json:"params"`
 }
 
+// UpdateSeriesArg holds the parameters for changing the series of a
+// single machine or application.
+type UpdateSeriesArg struct {
+	Entity Entity `json:"tag"`
+	Series string `json:"series"`
+	Force  bool   `json:"force"`
+}
+
+// UpdateSeriesArgs holds the parameters for the UpdateMachineSeries
+// and UpdateApplicationSeries calls.
+type UpdateSeriesArgs struct {
+	Args []UpdateSeriesArg `json:"args"`
+}
+
+// VanishedMachine describes a machine whose instance can no longer
+// be found in the cloud.
+type VanishedMachine struct {
+	Tag        string      `json:"tag"`
+	InstanceId instance.Id `json:"instance-id"`
+	Since      *time.Time  `json:"since,omitempty"`
+}
+
+// LeakedInstance describes a cloud instance tagged as belonging to
+// the model, but which is not known to any of the model's machines.
+type LeakedInstance struct {
+	InstanceId instance.Id `json:"instance-id"`
+	FirstSeen  time.Time   `json:"first-seen"`
+}
+
+// ModelDrift holds the results of a ModelDrift call.
+type ModelDrift struct {
+	Vanished []VanishedMachine `json:"vanished"`
+	Leaked   []LeakedInstance  `json:"leaked"`
+}
+
 // AddMachinesResults holds the results of an AddMachines call.
 type AddMachinesResults struct {
 	Machines []AddMachinesResult `json:"machines"`
@@ -213,6 +248,7 @@ type ApplicationDeploy struct {
 	Storage          map[string]storage.Constraints `json:"storage,omitempty"`
 	EndpointBindings map[string]string              `json:"endpoint-bindings,omitempty"`
 	Resources        map[string]string              `json:"resources,omitempty"`
+	Trust            bool                           `json:"trust,omitempty"`
 }
 
 // ApplicationUpdate holds the parameters for making the application Update call.
@@ -247,7 +283,15 @@ type ApplicationSetCharm struct {
 
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
+	ApplicationName string   `json:"application"`
+	CIDRs           []string `json:"cidrs,omitempty"`
+}
+
+// ApplicationSetTrust holds the parameters for making the application
+// SetTrust call.
+type ApplicationSetTrust struct {
 	ApplicationName string `json:"application"`
+	Trust           bool   `json:"trust"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -295,6 +339,14 @@ type ApplicationUnexpose struct {
 	ApplicationName string `json:"application"`
 }
 
+// ApplicationSetBindings holds the parameters for the application
+// SetBindings call. EndpointBindings maps endpoint names to the space
+// names they should be bound to.
+type ApplicationSetBindings struct {
+	ApplicationName  string            `json:"application"`
+	EndpointBindings map[string]string `json:"endpoint-bindings"`
+}
+
 // ApplicationMetricCredential holds parameters for the SetApplicationCredentials call.
 type ApplicationMetricCredential struct {
 	ApplicationName   string `json:"application"`
@@ -475,6 +527,9 @@ type StateServingInfo struct {
 	// this will be passed as the KeyFile argument to MongoDB
 	SharedSecret   string `json:"shared-secret"`
 	SystemIdentity string `json:"system-identity"`
+	// SecretsKey holds, base64 encoded, the key from which the keys
+	// encrypting each model's secrets are derived.
+	SecretsKey string `json:"secrets-key,omitempty"`
 }
 
 // IsMasterResult holds the result of an IsMaster API call.
@@ -514,6 +569,15 @@ type ContainerConfig struct {
 	AptProxy                proxy.Settings `json:"apt-proxy"`
 	AptMirror               string         `json:"apt-mirror"`
 	*UpdateBehavior
+
+	// FanConfig holds the model's Fan network mappings, in the form
+	// accepted by network.ParseFanConfig.
+	FanConfig string `json:"fan-config,omitempty"`
+
+	// ContainerNetworkingMethod is the method by which containers
+	// should be given their network addresses; see the model config
+	// attribute of the same name.
+	ContainerNetworkingMethod string `json:"container-networking-method,omitempty"`
 }
 
 // ProvisioningScriptParams contains the parameters for the
@@ -0,0 +1,162 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// CreateSecretArgs holds the arguments for creating secrets.
+type CreateSecretArgs struct {
+	Args []CreateSecretArg `json:"args"`
+}
+
+// CreateSecretArg holds the arguments for creating a single secret.
+type CreateSecretArg struct {
+	// OwnerTag is the application or unit that will own the secret.
+	OwnerTag string `json:"owner-tag"`
+
+	// Label optionally identifies the secret among those of its owner.
+	Label string `json:"label,omitempty"`
+
+	// Description describes the secret.
+	Description string `json:"description,omitempty"`
+
+	// Data holds the secret's value.
+	Data map[string]string `json:"data"`
+
+	// RotateInterval is how often the owner should rotate the secret.
+	RotateInterval time.Duration `json:"rotate-interval,omitempty"`
+}
+
+// UpdateSecretArgs holds the arguments for updating secrets.
+type UpdateSecretArgs struct {
+	Args []UpdateSecretArg `json:"args"`
+}
+
+// UpdateSecretArg holds the changes to make to a single secret. Fields
+// left empty are not changed.
+type UpdateSecretArg struct {
+	// ID identifies the secret to update.
+	ID string `json:"id"`
+
+	// Data holds the secret's new value.
+	Data map[string]string `json:"data,omitempty"`
+
+	// Description holds the secret's new description.
+	Description *string `json:"description,omitempty"`
+
+	// RotateInterval holds how often the owner should rotate the
+	// secret from now on.
+	RotateInterval *time.Duration `json:"rotate-interval,omitempty"`
+}
+
+// GetSecretArgs holds the arguments for reading secret values.
+type GetSecretArgs struct {
+	Args []GetSecretArg `json:"args"`
+}
+
+// GetSecretArg identifies a secret to read, either by its ID or by
+// the label given to it by the caller's unit or application.
+type GetSecretArg struct {
+	ID    string `json:"id,omitempty"`
+	Label string `json:"label,omitempty"`
+}
+
+// SecretValueResults holds the results of reading secret values.
+type SecretValueResults struct {
+	Results []SecretValueResult `json:"results"`
+}
+
+// SecretValueResult holds the value of a secret, or an error.
+type SecretValueResult struct {
+	Data  map[string]string `json:"data,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// GrantRevokeSecretArgs holds the arguments for changing which
+// applications may read secrets.
+type GrantRevokeSecretArgs struct {
+	Args []GrantRevokeSecretArg `json:"args"`
+}
+
+// GrantRevokeSecretArg identifies a secret and an application to grant
+// or revoke access to it.
+type GrantRevokeSecretArg struct {
+	ID             string `json:"id"`
+	ApplicationTag string `json:"application-tag"`
+}
+
+// SecretRotationResults holds the rotation schedules of the secrets
+// managed by each of a number of units.
+type SecretRotationResults struct {
+	Results []SecretRotationResult `json:"results"`
+}
+
+// SecretRotationResult holds the rotation schedules of the secrets
+// managed by a unit, or an error.
+type SecretRotationResult struct {
+	Rotations []SecretRotation `json:"rotations,omitempty"`
+	Error     *Error           `json:"error,omitempty"`
+}
+
+// SecretRotation records when a secret is next due to be rotated.
+type SecretRotation struct {
+	ID             string    `json:"id"`
+	NextRotateTime time.Time `json:"next-rotate-time"`
+}
+
+// SecretDetails describes a secret, without its value.
+type SecretDetails struct {
+	ID             string        `json:"id"`
+	OwnerTag       string        `json:"owner-tag"`
+	Label          string        `json:"label,omitempty"`
+	Description    string        `json:"description,omitempty"`
+	Revision       int           `json:"revision"`
+	RotateInterval time.Duration `json:"rotate-interval,omitempty"`
+	NextRotateTime *time.Time    `json:"next-rotate-time,omitempty"`
+	Grants         []string      `json:"grants,omitempty"`
+	CreateTime     time.Time     `json:"create-time"`
+	UpdateTime     time.Time     `json:"update-time"`
+}
+
+// ListSecretResults holds the details of all the secrets in a model.
+type ListSecretResults struct {
+	Results []SecretDetails `json:"results"`
+}
+
+// ShowSecretArgs holds the arguments for showing secrets.
+type ShowSecretArgs struct {
+	Args []ShowSecretArg `json:"args"`
+}
+
+// ShowSecretArg identifies a secret to show, and whether to include
+// its value.
+type ShowSecretArg struct {
+	ID     string `json:"id"`
+	Reveal bool   `json:"reveal,omitempty"`
+}
+
+// ShowSecretResults holds the results of showing secrets.
+type ShowSecretResults struct {
+	Results []ShowSecretResult `json:"results"`
+}
+
+// ShowSecretResult holds the details, audit trail and, if requested,
+// the value of a secret; or an error.
+type ShowSecretResult struct {
+	Secret *SecretDetails      `json:"secret,omitempty"`
+	Value  map[string]string   `json:"value,omitempty"`
+	Audit  []SecretAuditRecord `json:"audit,omitempty"`
+	Error  *Error              `json:"error,omitempty"`
+}
+
+// SecretAuditRecord describes an operation on a secret.
+type SecretAuditRecord struct {
+	Time     time.Time `json:"time"`
+	Entity   string    `json:"entity"`
+	Action   string    `json:"action"`
+	Revision int       `json:"revision"`
+	Detail   string    `json:"detail,omitempty"`
+}
@@ -89,6 +89,15 @@ type UnitStatus struct {
 	PublicAddress string                `json:"public-address"`
 	Charm         string                `json:"charm"`
 	Subordinates  map[string]UnitStatus `json:"subordinates"`
+
+	// SkippedHooks describes the hooks the unit skipped when it was
+	// resolved without retrying its failed hooks, and which have not
+	// been replayed since.
+	SkippedHooks []string `json:"skipped-hooks,omitempty"`
+
+	// Health holds the result of the unit's charm-defined health
+	// checks. It is nil if the unit has never reported its health.
+	Health *DetailedStatus `json:"health,omitempty"`
 }
 
 // RelationStatus holds status info about a relation.
@@ -31,6 +31,7 @@ var logger = loggo.GetLogger("juju.apiserver.provisioner")
 
 func init() {
 	common.RegisterStandardFacade("Provisioner", 3, NewProvisionerAPI)
+	common.RegisterStandardFacade("Provisioner", 4, NewProvisionerAPIV4)
 }
 
 // ProvisionerAPI provides access to the Provisioner API facade.
@@ -123,6 +124,22 @@ func NewProvisionerAPI(st *state.State, resources facade.Resources, authorizer f
 	}, nil
 }
 
+// ProvisionerAPIV4 provides access to version 4 of the Provisioner
+// API facade, which adds WatchApplicationCharms.
+type ProvisionerAPIV4 struct {
+	*ProvisionerAPI
+}
+
+// NewProvisionerAPIV4 creates a new server-side Provisioner API facade,
+// version 4.
+func NewProvisionerAPIV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ProvisionerAPIV4, error) {
+	api, err := NewProvisionerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &ProvisionerAPIV4{api}, nil
+}
+
 func (p *ProvisionerAPI) getMachine(canAccess common.AuthFunc, tag names.MachineTag) (*state.Machine, error) {
 	if !canAccess(tag) {
 		return nil, common.ErrPerm
@@ -260,6 +277,8 @@ func (p *ProvisionerAPI) ContainerConfig() (params.ContainerConfig, error) {
 	result.Proxy = config.ProxySettings()
 	result.AptProxy = config.AptProxySettings()
 	result.AptMirror = config.AptMirror()
+	result.FanConfig = config.FanConfig().String()
+	result.ContainerNetworkingMethod = config.ContainerNetworkingMethod()
 
 	return result, nil
 }
@@ -510,6 +529,22 @@ func (p *ProvisionerAPI) WatchMachineErrorRetry() (params.NotifyWatchResult, err
 	return result, nil
 }
 
+// WatchApplicationCharms returns a NotifyWatcher that notifies when
+// the charm of any application in the model changes, so that
+// provisioners can update the charm LXD profiles applied to their
+// machines.
+func (p *ProvisionerAPIV4) WatchApplicationCharms() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	watch := p.st.WatchApplicationCharms()
+	// Consume any initial event and forward it to the result.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = p.resources.Register(watch)
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
 // ReleaseContainerAddresses finds addresses allocated to a container and marks
 // them as Dead, to be released and removed. It accepts container tags as
 // arguments.
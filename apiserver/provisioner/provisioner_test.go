@@ -29,6 +29,7 @@ import (
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage/poolmanager"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 func TestPackage(t *stdtesting.T) {
@@ -1065,6 +1066,34 @@ func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 	c.Check(results.Proxy, gc.DeepEquals, expectedProxy)
 	c.Check(results.AptProxy, gc.DeepEquals, expectedProxy)
 	c.Check(results.AptMirror, gc.DeepEquals, "http://example.mirror.com")
+	c.Check(results.FanConfig, gc.Equals, "")
+	c.Check(results.ContainerNetworkingMethod, gc.Equals, "provider")
+}
+
+func (s *withoutControllerSuite) TestContainerConfigFan(c *gc.C) {
+	attrs := map[string]interface{}{
+		"fan-config": "172.31.0.0/16=252.0.0.0/8",
+	}
+	err := s.State.UpdateModelConfig(attrs, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.provisioner.ContainerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.FanConfig, gc.Equals, "172.31.0.0/16=252.0.0.0/8")
+	c.Check(results.ContainerNetworkingMethod, gc.Equals, "fan")
+}
+
+func (s *withoutControllerSuite) TestContainerConfigLocal(c *gc.C) {
+	attrs := map[string]interface{}{
+		"fan-config":                  "172.31.0.0/16=252.0.0.0/8",
+		"container-networking-method": "local",
+	}
+	err := s.State.UpdateModelConfig(attrs, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.provisioner.ContainerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.ContainerNetworkingMethod, gc.Equals, "local")
 }
 
 func (s *withoutControllerSuite) TestSetSupportedContainers(c *gc.C) {
@@ -1221,6 +1250,61 @@ func (s *withoutControllerSuite) TestWatchMachineErrorRetry(c *gc.C) {
 	c.Assert(result, gc.DeepEquals, params.NotifyWatchResult{})
 }
 
+func (s *withoutControllerSuite) TestWatchApplicationCharms(c *gc.C) {
+	app := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	provisionerV4, err := provisioner.NewProvisionerAPIV4(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	_, err = provisionerV4.WatchApplicationCharms()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned"
+	// in the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	// Changes to applications other than to their charms are ignored.
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Upgrading the charm triggers a change.
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress", URL: "local:quantal/wordpress-42"})
+	err = app.SetCharm(state.SetCharmConfig{Charm: ch})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// So does deploying an application.
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	wc.AssertOneChange()
+}
+
+// newMethods holds the methods added by each version of the facade.
+var newMethods = map[int][]string{
+	4: {"WatchApplicationCharms"},
+}
+
+func (s *withoutControllerSuite) TestNewMethodsVersioned(c *gc.C) {
+	for version, methods := range newMethods {
+		older, err := common.Facades.GetType("Provisioner", version-1)
+		c.Assert(err, jc.ErrorIsNil)
+		newer, err := common.Facades.GetType("Provisioner", version)
+		c.Assert(err, jc.ErrorIsNil)
+		for _, name := range methods {
+			_, ok := older.MethodByName(name)
+			c.Check(ok, jc.IsFalse, gc.Commentf("v%d has %s", version-1, name))
+			_, ok = newer.MethodByName(name)
+			c.Check(ok, jc.IsTrue, gc.Commentf("v%d lacks %s", version, name))
+		}
+	}
+}
+
 func (s *withoutControllerSuite) TestFindTools(c *gc.C) {
 	args := params.FindToolsParams{
 		MajorVersion: -1,
@@ -17,10 +17,12 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	"github.com/juju/juju/state/multiwatcher"
@@ -91,6 +93,10 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot get controller configuration")
 	}
+	lxdProfiles, err := p.machineLXDProfiles(m)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get charm LXD profiles")
+	}
 
 	return &params.ProvisioningInfo{
 		Constraints:      cons,
@@ -103,9 +109,58 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 		EndpointBindings: endpointBindings,
 		ImageMetadata:    imageMetadata,
 		ControllerConfig: controllerCfg,
+		CharmLXDProfiles: lxdProfiles,
 	}, nil
 }
 
+// machineLXDProfiles returns the LXD profiles supplied by the charms
+// of the units assigned to the machine, keyed on profile name. The
+// result is empty for machines that are neither LXD containers nor
+// provisioned by the LXD provider.
+func (p *ProvisionerAPI) machineLXDProfiles(m *state.Machine) (map[string]params.CharmLXDProfile, error) {
+	cfg, err := p.st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if m.ContainerType() != instance.LXD && cfg.Type() != "lxd" {
+		return nil, nil
+	}
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var profiles map[string]params.CharmLXDProfile
+	processedApplications := set.NewStrings()
+	for _, unit := range units {
+		if processedApplications.Contains(unit.ApplicationName()) {
+			continue
+		}
+		processedApplications.Add(unit.ApplicationName())
+		application, err := unit.Application()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := application.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		profile := ch.LXDProfile()
+		if profile.Empty() {
+			continue
+		}
+		if profiles == nil {
+			profiles = make(map[string]params.CharmLXDProfile)
+		}
+		name := lxdprofile.Name(cfg.UUID(), application.Name(), ch.Revision(), profile)
+		profiles[name] = params.CharmLXDProfile{
+			Description: profile.Description,
+			Config:      profile.Config,
+			Devices:     profile.Devices,
+		}
+	}
+	return profiles, nil
+}
+
 // machineVolumeParams retrieves VolumeParams for the volumes that should be
 // provisioned with, and attached to, the machine. The client should ignore
 // parameters that it does not know how to handle.
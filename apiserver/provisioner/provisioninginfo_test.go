@@ -11,7 +11,9 @@ import (
 	"github.com/juju/juju/apiserver/provisioner"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
@@ -263,6 +265,43 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithEndpointBindings(c *gc.
 	c.Assert(result, jc.DeepEquals, expected)
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoWithCharmLXDProfile(c *gc.C) {
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	container, err := s.State.AddMachineInsideNewMachine(template, template, instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.AddTestingCharm(c, "lxd-profile")
+	app := s.AddTestingService(c, "lxd-profile", ch)
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: container.Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	name := lxdprofile.Name(s.State.ModelUUID(), "lxd-profile", 1, ch.LXDProfile())
+	c.Assert(result.Results[0].Result.CharmLXDProfiles, jc.DeepEquals, map[string]params.CharmLXDProfile{
+		name: {
+			Description: "allow nested containers",
+			Config: map[string]string{
+				"security.nesting":     "true",
+				"linux.kernel_modules": "openvswitch",
+			},
+			Devices: map[string]map[string]string{
+				"tun": {"type": "unix-char", "path": "/dev/net/tun"},
+			},
+		},
+	})
+}
+
 func (s *withoutControllerSuite) TestProvisioningInfoWithUnsuitableSpacesConstraints(c *gc.C) {
 	// Add an empty space.
 	_, err := s.State.AddSpace("empty", "", nil, true)
@@ -0,0 +1,150 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the API used by model administrators to
+// inspect the secrets stored by the charms in a model.
+package secrets
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("Secrets", 1, NewSecretsAPI)
+}
+
+// SecretsAPI provides access to the Secrets API facade.
+type SecretsAPI struct {
+	st   *state.State
+	user names.UserTag
+}
+
+// NewSecretsAPI creates a new server-side Secrets API facade. Only
+// controller administrators and administrators of the model may use
+// it.
+func NewSecretsAPI(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*SecretsAPI, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	user, ok := authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return nil, common.ErrPerm
+	}
+	if err := checkIsAdmin(st, user); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &SecretsAPI{
+		st:   st,
+		user: user,
+	}, nil
+}
+
+// checkIsAdmin returns an error unless the user is a controller
+// administrator or an administrator of the model.
+func checkIsAdmin(st *state.State, user names.UserTag) error {
+	isAdmin, err := st.IsControllerAdministrator(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isAdmin {
+		return nil
+	}
+	modelUser, err := st.ModelUser(user)
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if !modelUser.IsAdmin() {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ListSecrets returns the details of all the secrets in the model,
+// without their values.
+func (api *SecretsAPI) ListSecrets() (params.ListSecretResults, error) {
+	var result params.ListSecretResults
+	secrets, err := api.st.AllSecrets()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.SecretDetails, len(secrets))
+	for i, secret := range secrets {
+		result.Results[i] = secretDetails(secret)
+	}
+	return result, nil
+}
+
+// ShowSecrets returns the details and audit trail of each of the given
+// secrets and, if requested, their values. Revealing a secret's value
+// is itself recorded in the secret's audit trail.
+func (api *SecretsAPI) ShowSecrets(args params.ShowSecretArgs) (params.ShowSecretResults, error) {
+	result := params.ShowSecretResults{
+		Results: make([]params.ShowSecretResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.showSecret(arg, &result.Results[i])
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *SecretsAPI) showSecret(arg params.ShowSecretArg, result *params.ShowSecretResult) error {
+	secret, err := api.st.Secret(arg.ID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if arg.Reveal {
+		value, err := api.st.SecretValue(secret.ID(), api.user)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result.Value = value
+	}
+	records, err := api.st.SecretAuditRecords(secret.ID())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	details := secretDetails(secret)
+	result.Secret = &details
+	result.Audit = make([]params.SecretAuditRecord, len(records))
+	for i, record := range records {
+		result.Audit[i] = params.SecretAuditRecord{
+			Time:     record.Time,
+			Entity:   record.Entity,
+			Action:   string(record.Action),
+			Revision: record.Revision,
+			Detail:   record.Detail,
+		}
+	}
+	return nil
+}
+
+func secretDetails(secret *state.Secret) params.SecretDetails {
+	details := params.SecretDetails{
+		ID:             secret.ID(),
+		Label:          secret.Label(),
+		Description:    secret.Description(),
+		Revision:       secret.Revision(),
+		RotateInterval: secret.RotateInterval(),
+		NextRotateTime: secret.NextRotateTime(),
+		Grants:         secret.Grants(),
+		CreateTime:     secret.CreateTime(),
+		UpdateTime:     secret.UpdateTime(),
+	}
+	if owner, err := secret.Owner(); err == nil {
+		details.OwnerTag = owner.String()
+	}
+	return details
+}
@@ -0,0 +1,109 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/secrets"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/description"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type secretsSuite struct {
+	jujutesting.JujuConnSuite
+
+	api    *secrets.SecretsAPI
+	unit   *state.Unit
+	secret *state.Secret
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+	var err error
+	s.secret, err = s.State.AddSecret(state.CreateSecretParams{
+		Owner:       s.unit.Tag(),
+		Actor:       s.unit.Tag(),
+		Label:       "password",
+		Description: "database password",
+		Data:        map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = s.newAPI(c, s.AdminUserTag(c))
+}
+
+func (s *secretsSuite) newAPI(c *gc.C, user names.UserTag) *secrets.SecretsAPI {
+	api, err := secrets.NewSecretsAPI(
+		s.State, common.NewResources(), apiservertesting.FakeAuthorizer{Tag: user},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *secretsSuite) TestNewAPIRequiresAdmin(c *gc.C) {
+	writer := s.Factory.MakeUser(c, &factory.UserParams{Access: description.WriteAccess})
+	_, err := secrets.NewSecretsAPI(
+		s.State, common.NewResources(), apiservertesting.FakeAuthorizer{Tag: writer.UserTag()},
+	)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+
+	_, err = secrets.NewSecretsAPI(
+		s.State, common.NewResources(), apiservertesting.FakeAuthorizer{Tag: names.NewUnitTag("mysql/0")},
+	)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *secretsSuite) TestModelAdmin(c *gc.C) {
+	admin := s.Factory.MakeUser(c, &factory.UserParams{Access: description.AdminAccess})
+	s.newAPI(c, admin.UserTag())
+}
+
+func (s *secretsSuite) TestListSecrets(c *gc.C) {
+	result, err := s.api.ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	details := result.Results[0]
+	c.Assert(details.ID, gc.Equals, s.secret.ID())
+	c.Assert(details.OwnerTag, gc.Equals, s.unit.Tag().String())
+	c.Assert(details.Label, gc.Equals, "password")
+	c.Assert(details.Description, gc.Equals, "database password")
+	c.Assert(details.Revision, gc.Equals, 1)
+}
+
+func (s *secretsSuite) TestShowSecrets(c *gc.C) {
+	result, err := s.api.ShowSecrets(params.ShowSecretArgs{
+		Args: []params.ShowSecretArg{{ID: s.secret.ID()}, {ID: "missing"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Secret.ID, gc.Equals, s.secret.ID())
+	c.Assert(result.Results[0].Value, gc.IsNil)
+	c.Assert(result.Results[0].Audit, gc.HasLen, 1)
+	c.Assert(result.Results[0].Audit[0].Action, gc.Equals, "create")
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `secret "missing" not found`)
+}
+
+func (s *secretsSuite) TestShowSecretsRevealIsAudited(c *gc.C) {
+	result, err := s.api.ShowSecrets(params.ShowSecretArgs{
+		Args: []params.ShowSecretArg{{ID: s.secret.ID(), Reveal: true}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Value, jc.DeepEquals, map[string]string{"password": "sekrit"})
+	audit := result.Results[0].Audit
+	c.Assert(audit, gc.HasLen, 2)
+	c.Assert(audit[1].Action, gc.Equals, "read")
+	c.Assert(audit[1].Entity, gc.Equals, s.AdminUserTag(c).String())
+}
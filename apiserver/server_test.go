@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/juju/loggo"
 	"github.com/juju/testing"
@@ -322,6 +323,42 @@ func (s *serverSuite) TestNoBakeryWhenNoIdentityURL(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "macaroon authentication is not configured")
 }
 
+func (s *serverSuite) TestControllerConfigChanged(c *gc.C) {
+	discharger := bakerytest.NewDischarger(nil, noCheck)
+	defer discharger.Close()
+
+	configs := make(chan controller.Config, 10)
+	srv := newServerWithConfigChanged(c, s.State, func(cfg controller.Config) {
+		configs <- cfg
+	})
+	defer srv.Stop()
+	_, err := apiserver.ServerMacaroon(srv)
+	c.Assert(err, gc.ErrorMatches, "macaroon authentication is not configured")
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AuditingEnabled: true,
+		controller.IdentityURL:     discharger.Location(),
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	for {
+		select {
+		case cfg := <-configs:
+			if cfg.IdentityURL() != discharger.Location() {
+				continue
+			}
+			c.Assert(cfg.AuditingEnabled(), jc.IsTrue)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for controller config change")
+		}
+		break
+	}
+
+	// The new identity manager settings take effect
+	// without restarting the server.
+	_, err = apiserver.ServerMacaroon(srv)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 type macaroonServerSuite struct {
 	jujutesting.JujuConnSuite
 	discharger *bakerytest.Discharger
@@ -447,14 +484,21 @@ func (s *serverSuite) checkApiHandlerTeardown(c *gc.C, srvSt, st *state.State) {
 
 // newServer returns a new running API server.
 func newServer(c *gc.C, st *state.State) *apiserver.Server {
+	return newServerWithConfigChanged(c, st, nil)
+}
+
+// newServerWithConfigChanged returns a new running API server, which
+// will call configChanged when the controller config changes.
+func newServerWithConfigChanged(c *gc.C, st *state.State, configChanged func(controller.Config)) *apiserver.Server {
 	listener, err := net.Listen("tcp", ":0")
 	c.Assert(err, jc.ErrorIsNil)
 	srv, err := apiserver.NewServer(st, listener, apiserver.ServerConfig{
-		Cert:        []byte(coretesting.ServerCert),
-		Key:         []byte(coretesting.ServerKey),
-		Tag:         names.NewMachineTag("0"),
-		LogDir:      c.MkDir(),
-		NewObserver: func() observer.Observer { return &fakeobserver.Instance{} },
+		Cert:                    []byte(coretesting.ServerCert),
+		Key:                     []byte(coretesting.ServerKey),
+		Tag:                     names.NewMachineTag("0"),
+		LogDir:                  c.MkDir(),
+		NewObserver:             func() observer.Observer { return &fakeobserver.Instance{} },
+		ControllerConfigChanged: configChanged,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	return srv
@@ -4,6 +4,8 @@
 package statushistory
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
@@ -30,10 +32,14 @@ func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API,
 
 // Prune endpoint removes status history entries until
 // only the ones newer than now - p.MaxHistoryTime remain and
-// the history is smaller than p.MaxHistoryMB.
+// the history is smaller than p.MaxHistoryMB. Hook history
+// is pruned by the same criteria.
 func (api *API) Prune(p params.StatusHistoryPruneArgs) error {
 	if !api.authorizer.AuthModelManager() {
 		return common.ErrPerm
 	}
-	return state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	if err := state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(state.PruneHookHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB))
 }
@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// credentialGetOperation is the operation recorded in the audit log
+// when a unit reads the model's cloud credential.
+const credentialGetOperation = "credential-get"
+
+// CloudSpec returns the model's cloud specification, including its
+// credential, for each given unit. Only units of trusted applications
+// may read the cloud specification, and each read is audited.
+func (u *UniterAPIV8) CloudSpec(args params.Entities) (params.CloudSpecResults, error) {
+	result := params.CloudSpecResults{
+		Results: make([]params.CloudSpecResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.CloudSpecResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		spec, err := u.oneCloudSpec(unit)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		resultItem.Result = spec
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV8) oneCloudSpec(unit *state.Unit) (*params.CloudSpec, error) {
+	app, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !app.Trust() {
+		return nil, errors.Unauthorizedf("application %q is not trusted to access the cloud credential", app.Name())
+	}
+	spec, err := stateenvirons.EnvironConfigGetter{u.st}.CloudSpec()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := u.auditCredentialAccess(unit, spec.Name); err != nil {
+		return nil, errors.Annotate(err, "cannot audit credential access")
+	}
+	result := &params.CloudSpec{
+		Type:            spec.Type,
+		Name:            spec.Name,
+		Region:          spec.Region,
+		Endpoint:        spec.Endpoint,
+		StorageEndpoint: spec.StorageEndpoint,
+	}
+	if spec.Credential != nil {
+		result.Credential = &params.CloudCredential{
+			AuthType:   string(spec.Credential.AuthType()),
+			Attributes: spec.Credential.Attributes(),
+		}
+	}
+	return result, nil
+}
+
+// auditCredentialAccess records in the audit log that the unit has
+// read the model's cloud credential.
+func (u *UniterAPIV8) auditCredentialAccess(unit *state.Unit, cloudName string) error {
+	model, err := u.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The unit agent runs on the unit's machine, so its private
+	// address is the best available record of where the request
+	// came from.
+	remoteAddress := "unknown"
+	if addr, err := unit.PrivateAddress(); err == nil {
+		remoteAddress = addr.Value
+	}
+	putAuditEntry := u.st.PutAuditEntryFn()
+	return errors.Trace(putAuditEntry(audit.AuditEntry{
+		JujuServerVersion: jujuversion.Current,
+		ModelUUID:         u.st.ModelUUID(),
+		Timestamp:         time.Now().UTC(),
+		RemoteAddress:     remoteAddress,
+		OriginType:        names.UnitTagKind,
+		OriginName:        unit.Name(),
+		Operation:         credentialGetOperation,
+		Data: map[string]interface{}{
+			"application": unit.ApplicationName(),
+			"cloud":       cloudName,
+			"credential":  model.CloudCredential(),
+		},
+	}))
+}
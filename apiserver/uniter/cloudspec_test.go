@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+func (s *uniterSuite) TestCloudSpecPermissions(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-foo-42"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.CloudSpec(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.CloudSpecResults{
+		Results: []params.CloudSpecResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: common.ServerError(errors.New(`"application-wordpress" is not a valid unit tag`))},
+		},
+	})
+}
+
+func (s *uniterSuite) TestCloudSpecNotTrusted(c *gc.C) {
+	result, err := s.uniter.CloudSpec(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `application "wordpress" is not trusted to access the cloud credential`)
+	c.Assert(result.Results[0].Error, jc.Satisfies, params.IsCodeUnauthorized)
+	c.Assert(s.countCredentialAudits(c), gc.Equals, 0)
+}
+
+func (s *uniterSuite) TestCloudSpecTrusted(c *gc.C) {
+	err := s.wordpress.SetTrust(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.CloudSpec(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result, jc.DeepEquals, &params.CloudSpec{
+		Type: "dummy",
+		Name: "dummy",
+	})
+	c.Assert(s.countCredentialAudits(c), gc.Equals, 1)
+}
+
+func (s *uniterSuite) countCredentialAudits(c *gc.C) int {
+	auditLog := s.State.MongoSession().DB("juju").C("audit.log")
+	n, err := auditLog.Find(bson.D{
+		{"operation", "credential-get"},
+		{"origin-name", "wordpress/0"},
+	}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	return n
+}
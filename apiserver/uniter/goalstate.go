@@ -0,0 +1,212 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+// Goal state statuses of units and applications.
+const (
+	goalStateWaiting = "waiting"
+	goalStateJoining = "joining"
+	goalStateJoined  = "joined"
+	goalStateDying   = "dying"
+)
+
+// GoalStates returns, for each given unit, the units expected in its
+// application and, keyed by endpoint name, the applications and units
+// expected at the other end of each of its application's relations.
+//
+// The goal state is computed from the units and relations recorded in
+// state, rather than from relation scope, so a charm can tell units
+// that will eventually join a relation apart from units that never
+// will.
+func (u *UniterAPIV7) GoalStates(args params.Entities) (params.GoalStateResults, error) {
+	result := params.GoalStateResults{
+		Results: make([]params.GoalStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.GoalStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		goalState, err := u.oneGoalState(unit)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		resultItem.Result = goalState
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV7) oneGoalState(unit *state.Unit) (*params.GoalState, error) {
+	app, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	goalState := &params.GoalState{
+		Units:     make(params.UnitsGoalState),
+		Relations: make(map[string]params.UnitsGoalState),
+	}
+	for _, appUnit := range units {
+		unitStatus, err := goalStateUnitStatus(appUnit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		goalState.Units[appUnit.Name()] = params.GoalStateStatus{Status: unitStatus}
+	}
+
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		endpoint, err := rel.Endpoint(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		relatedEndpoints, err := rel.RelatedEndpoints(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		relationGoal, ok := goalState.Relations[endpoint.Name]
+		if !ok {
+			relationGoal = make(params.UnitsGoalState)
+			goalState.Relations[endpoint.Name] = relationGoal
+		}
+		for _, related := range relatedEndpoints {
+			if err := u.addRelatedGoalState(relationGoal, unit, rel, endpoint, related); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+	}
+	return goalState, nil
+}
+
+// addRelatedGoalState records in relationGoal the goal state of the
+// related application, and of those of its units that the given unit
+// will see in the relation.
+func (u *UniterAPIV7) addRelatedGoalState(
+	relationGoal params.UnitsGoalState,
+	unit *state.Unit,
+	rel *state.Relation,
+	endpoint, related state.Endpoint,
+) error {
+	relationStatus := goalStateJoined
+	if rel.Life() != state.Alive {
+		relationStatus = goalStateDying
+	}
+	if endpoint.Role != charm.RolePeer {
+		relationGoal[related.ApplicationName] = params.GoalStateStatus{Status: relationStatus}
+	}
+	relatedApp, err := u.st.Application(related.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	relatedUnits, err := relatedApp.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, relatedUnit := range relatedUnits {
+		if relatedUnit.Name() == unit.Name() {
+			continue
+		}
+		if endpoint.Scope == charm.ScopeContainer && !sameContainer(unit, relatedUnit) {
+			continue
+		}
+		unitStatus := goalStateDying
+		if relationStatus != goalStateDying {
+			unitStatus, err = goalStateRelationUnitStatus(rel, relatedUnit)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+		relationGoal[relatedUnit.Name()] = params.GoalStateStatus{Status: unitStatus}
+	}
+	return nil
+}
+
+// goalStateUnitStatus returns "dying" if the unit is no longer alive,
+// "waiting" if its agent has not yet started, and "joined" otherwise.
+func goalStateUnitStatus(unit *state.Unit) (string, error) {
+	if unit.Life() != state.Alive {
+		return goalStateDying, nil
+	}
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if agentStatus.Status == status.StatusAllocating {
+		return goalStateWaiting, nil
+	}
+	return goalStateJoined, nil
+}
+
+// goalStateRelationUnitStatus returns the unit's goal state status as
+// seen in the given relation: "joining" means that the unit is running
+// but has not yet entered the relation's scope.
+func goalStateRelationUnitStatus(rel *state.Relation, unit *state.Unit) (string, error) {
+	unitStatus, err := goalStateUnitStatus(unit)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if unitStatus != goalStateJoined {
+		return unitStatus, nil
+	}
+	relUnit, err := rel.Unit(unit)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	inScope, err := relUnit.InScope()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !inScope {
+		return goalStateJoining, nil
+	}
+	return goalStateJoined, nil
+}
+
+// sameContainer reports whether the units are a principal and one of
+// its subordinates, and so will see one another in container-scoped
+// relations.
+func sameContainer(unit, other *state.Unit) bool {
+	if principal, ok := unit.PrincipalName(); ok {
+		return other.Name() == principal
+	}
+	for _, name := range unit.SubordinateNames() {
+		if name == other.Name() {
+			return true
+		}
+	}
+	return false
+}
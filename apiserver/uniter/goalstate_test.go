@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"errors"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/status"
+	jujuFactory "github.com/juju/juju/testing/factory"
+)
+
+func (s *uniterSuite) TestGoalStatesPermissions(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-foo-42"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.GoalStates(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.GoalStateResults{
+		Results: []params.GoalStateResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: common.ServerError(errors.New(`"application-wordpress" is not a valid unit tag`))},
+		},
+	})
+}
+
+func (s *uniterSuite) TestGoalStatesNoRelations(c *gc.C) {
+	result, err := s.uniter.GoalStates(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result, jc.DeepEquals, &params.GoalState{
+		Units: params.UnitsGoalState{
+			"wordpress/0": {Status: "waiting"},
+		},
+		Relations: map[string]params.UnitsGoalState{},
+	})
+}
+
+func (s *uniterSuite) TestGoalStatesRelations(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	err := s.mysqlUnit.SetAgentStatus(status.StatusInfo{
+		Status: status.StatusIdle,
+		Since:  &time.Time{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeUnit(c, &jujuFactory.UnitParams{Application: s.mysql})
+
+	expected := &params.GoalState{
+		Units: params.UnitsGoalState{
+			"wordpress/0": {Status: "waiting"},
+		},
+		Relations: map[string]params.UnitsGoalState{
+			"db": {
+				"mysql":   {Status: "joined"},
+				"mysql/0": {Status: "joining"},
+				"mysql/1": {Status: "waiting"},
+			},
+		},
+	}
+	s.assertGoalState(c, expected)
+
+	relUnit, err := rel.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	expected.Relations["db"]["mysql/0"] = params.GoalStateStatus{Status: "joined"}
+	s.assertGoalState(c, expected)
+
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	expected.Relations["db"] = params.UnitsGoalState{
+		"mysql":   {Status: "dying"},
+		"mysql/0": {Status: "dying"},
+		"mysql/1": {Status: "dying"},
+	}
+	s.assertGoalState(c, expected)
+}
+
+func (s *uniterSuite) assertGoalState(c *gc.C, expected *params.GoalState) {
+	result, err := s.uniter.GoalStates(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result, jc.DeepEquals, expected)
+}
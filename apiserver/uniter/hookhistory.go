@@ -0,0 +1,57 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddHookHistory records the hooks run by each given unit.
+func (u *UniterAPIV10) AddHookHistory(args params.HookHistoryArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		records := make([]state.HookRecord, len(arg.Records))
+		for j, record := range arg.Records {
+			records[j] = state.HookRecord{
+				Hook:       record.Hook,
+				RelationId: record.RelationId,
+				RemoteUnit: record.RemoteUnit,
+				Started:    record.Started,
+				Finished:   record.Finished,
+				ExitCode:   record.ExitCode,
+				Error:      record.Error,
+				Output:     record.Output,
+			}
+		}
+		if err := unit.AddHookHistory(records); err != nil {
+			resultItem.Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+func (s *uniterSuite) TestAddHookHistory(c *gc.C) {
+	now := time.Now()
+	record := params.HookRecord{
+		Hook:       "config-changed",
+		RelationId: -1,
+		Started:    now,
+		Finished:   now.Add(time.Second),
+		Output:     []string{"configured"},
+	}
+	result, err := s.uniter.AddHookHistory(params.HookHistoryArgs{
+		Args: []params.HookHistoryArg{
+			{Tag: "unit-wordpress-0", Records: []params.HookRecord{record}},
+			{Tag: "unit-mysql-0", Records: []params.HookRecord{record}},
+			{Tag: "unit-foo-42", Records: []params.HookRecord{record}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	records, err := s.wordpressUnit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0].Hook, gc.Equals, "config-changed")
+	c.Check(records[0].RelationId, gc.Equals, -1)
+	c.Check(records[0].Output, jc.DeepEquals, []string{"configured"})
+}
@@ -0,0 +1,326 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// CreateSecrets creates new secrets owned by the calling unit or by
+// its application, returning their IDs. Only the application's leader
+// may create secrets owned by the application.
+func (u *UniterAPIV6) CreateSecrets(args params.CreateSecretArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		id, err := u.createSecret(arg)
+		result.Results[i].Result = id
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV6) createSecret(arg params.CreateSecretArg) (string, error) {
+	owner, err := names.ParseTag(arg.OwnerTag)
+	if err != nil {
+		return "", common.ErrPerm
+	}
+	if err := u.checkSecretOwner(owner); err != nil {
+		return "", errors.Trace(err)
+	}
+	secret, err := u.st.AddSecret(state.CreateSecretParams{
+		Owner:          owner,
+		Actor:          u.unit.UnitTag(),
+		Label:          arg.Label,
+		Description:    arg.Description,
+		Data:           arg.Data,
+		RotateInterval: arg.RotateInterval,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return secret.ID(), nil
+}
+
+// UpdateSecrets changes the value or settings of secrets owned by the
+// calling unit or by its application.
+func (u *UniterAPIV6) UpdateSecrets(args params.UpdateSecretArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := u.updateSecret(arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV6) updateSecret(arg params.UpdateSecretArg) error {
+	if _, err := u.ownedSecret(arg.ID); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := u.st.UpdateSecret(arg.ID, state.UpdateSecretParams{
+		Actor:          u.unit.UnitTag(),
+		Data:           arg.Data,
+		Description:    arg.Description,
+		RotateInterval: arg.RotateInterval,
+	})
+	return errors.Trace(err)
+}
+
+// GetSecretValues returns the values of the given secrets. A secret
+// may be identified by label only if it is owned by the calling unit
+// or by its application.
+func (u *UniterAPIV6) GetSecretValues(args params.GetSecretArgs) (params.SecretValueResults, error) {
+	result := params.SecretValueResults{
+		Results: make([]params.SecretValueResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		data, err := u.getSecretValue(arg)
+		result.Results[i].Data = data
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV6) getSecretValue(arg params.GetSecretArg) (map[string]string, error) {
+	var secret *state.Secret
+	var err error
+	switch {
+	case arg.ID != "":
+		secret, err = u.st.Secret(arg.ID)
+	case arg.Label != "":
+		secret, err = u.st.SecretByLabel(u.unit.UnitTag(), arg.Label)
+		if errors.IsNotFound(err) {
+			appTag := names.NewApplicationTag(u.unit.ApplicationName())
+			secret, err = u.st.SecretByLabel(appTag, arg.Label)
+		}
+	default:
+		return nil, errors.NotValidf("secret without ID or label")
+	}
+	if errors.IsNotFound(err) {
+		return nil, common.ErrPerm
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !secret.IsReadableBy(u.unit.UnitTag()) {
+		return nil, common.ErrPerm
+	}
+	return u.st.SecretValue(secret.ID(), u.unit.UnitTag())
+}
+
+// GrantSecrets allows the units of related applications to read
+// secrets owned by the calling unit or by its application.
+func (u *UniterAPIV6) GrantSecrets(args params.GrantRevokeSecretArgs) (params.ErrorResults, error) {
+	return u.changeSecretGrants(args, u.st.GrantSecret)
+}
+
+// RevokeSecrets stops the units of the given applications from reading
+// secrets owned by the calling unit or by its application.
+func (u *UniterAPIV6) RevokeSecrets(args params.GrantRevokeSecretArgs) (params.ErrorResults, error) {
+	return u.changeSecretGrants(args, u.st.RevokeSecret)
+}
+
+func (u *UniterAPIV6) changeSecretGrants(
+	args params.GrantRevokeSecretArgs,
+	change func(string, names.ApplicationTag, names.Tag) error,
+) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := u.changeSecretGrant(arg, change)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV6) changeSecretGrant(
+	arg params.GrantRevokeSecretArg,
+	change func(string, names.ApplicationTag, names.Tag) error,
+) error {
+	appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return common.ErrPerm
+	}
+	if _, err := u.ownedSecret(arg.ID); err != nil {
+		return errors.Trace(err)
+	}
+	related, err := u.relatedTo(appTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	} else if !related {
+		return errors.NotValidf("application %q not related to %q", appTag.Id(), u.unit.ApplicationName())
+	}
+	return change(arg.ID, appTag, u.unit.UnitTag())
+}
+
+// relatedTo reports whether the calling unit's application has a
+// relation with the named application.
+func (u *UniterAPIV6) relatedTo(appName string) (bool, error) {
+	app, err := u.st.Application(u.unit.ApplicationName())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	relations, err := app.Relations()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		if _, err := rel.Endpoint(appName); err == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// WatchSecretRotations returns a StringsWatcher for each given unit,
+// notifying of changes to the secrets owned by the unit and by its
+// application.
+func (u *UniterAPIV6) WatchSecretRotations(args params.Entities) (params.StringsWatchResults, error) {
+	result := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringsWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		appName, err := names.UnitApplication(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		watch := u.st.WatchSecrets(tag, names.NewApplicationTag(appName))
+		// Consume the initial event and forward it to the result.
+		if changes, ok := <-watch.Changes(); ok {
+			result.Results[i].StringsWatcherId = u.resources.Register(watch)
+			result.Results[i].Changes = changes
+		} else {
+			result.Results[i].Error = common.ServerError(watcher.EnsureErr(watch))
+		}
+	}
+	return result, nil
+}
+
+// SecretRotations returns the rotation schedules of the secrets each
+// given unit is responsible for rotating: those owned by the unit, and,
+// if it is the leader, those owned by its application.
+func (u *UniterAPIV6) SecretRotations(args params.Entities) (params.SecretRotationResults, error) {
+	result := params.SecretRotationResults{
+		Results: make([]params.SecretRotationResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SecretRotationResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		rotations, err := u.secretRotations(tag)
+		result.Results[i].Rotations = rotations
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV6) secretRotations(tag names.UnitTag) ([]params.SecretRotation, error) {
+	appName, err := names.UnitApplication(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	owners := []names.Tag{tag}
+	token := u.st.LeadershipChecker().LeadershipCheck(appName, tag.Id())
+	if token.Check(nil) == nil {
+		owners = append(owners, names.NewApplicationTag(appName))
+	}
+	secrets, err := u.st.SecretsOwnedBy(owners...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var rotations []params.SecretRotation
+	for _, secret := range secrets {
+		if next := secret.NextRotateTime(); next != nil {
+			rotations = append(rotations, params.SecretRotation{
+				ID:             secret.ID(),
+				NextRotateTime: *next,
+			})
+		}
+	}
+	return rotations, nil
+}
+
+// SecretsRotated records that the calling unit has run the
+// secret-rotate hook for each of the given secrets.
+func (u *UniterAPIV6) SecretsRotated(args params.GetSecretArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := u.secretRotated(arg.ID)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV6) secretRotated(id string) error {
+	if _, err := u.ownedSecret(id); err != nil {
+		return errors.Trace(err)
+	}
+	return u.st.SecretRotated(id, u.unit.UnitTag())
+}
+
+// ownedSecret returns the secret with the given ID if the calling unit
+// may manage it.
+func (u *UniterAPIV6) ownedSecret(id string) (*state.Secret, error) {
+	secret, err := u.st.Secret(id)
+	if errors.IsNotFound(err) {
+		return nil, common.ErrPerm
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	owner, err := secret.Owner()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := u.checkSecretOwner(owner); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secret, nil
+}
+
+// checkSecretOwner returns an error unless the calling unit may manage
+// secrets owned by the given entity: the unit itself, or its
+// application if the unit is the leader.
+func (u *UniterAPIV6) checkSecretOwner(owner names.Tag) error {
+	switch owner := owner.(type) {
+	case names.UnitTag:
+		if owner != u.unit.UnitTag() {
+			return common.ErrPerm
+		}
+		return nil
+	case names.ApplicationTag:
+		if owner.Id() != u.unit.ApplicationName() {
+			return common.ErrPerm
+		}
+		token := u.st.LeadershipChecker().LeadershipCheck(owner.Id(), u.unit.Name())
+		return errors.Trace(token.Check(nil))
+	}
+	return common.ErrPerm
+}
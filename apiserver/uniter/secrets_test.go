@@ -0,0 +1,189 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/uniter"
+	"github.com/juju/juju/state"
+)
+
+func (s *uniterSuite) createSecret(c *gc.C, owner names.Tag) string {
+	result, err := s.uniter.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			OwnerTag: owner.String(),
+			Label:    "password",
+			Data:     map[string]string{"password": "sekrit"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	return result.Results[0].Result
+}
+
+func (s *uniterSuite) TestCreateSecretUnitOwned(c *gc.C) {
+	id := s.createSecret(c, s.wordpressUnit.Tag())
+
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Label(), gc.Equals, "password")
+	records, err := s.State.SecretAuditRecords(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 1)
+	c.Assert(records[0].Entity, gc.Equals, "unit-wordpress-0")
+}
+
+func (s *uniterSuite) TestCreateSecretPermissions(c *gc.C) {
+	result, err := s.uniter.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			OwnerTag: s.mysqlUnit.Tag().String(),
+			Data:     map[string]string{"password": "sekrit"},
+		}, {
+			OwnerTag: s.mysql.Tag().String(),
+			Data:     map[string]string{"password": "sekrit"},
+		}, {
+			// Not the leader.
+			OwnerTag: s.wordpress.Tag().String(),
+			Data:     map[string]string{"password": "sekrit"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[2].Error, gc.NotNil)
+}
+
+func (s *uniterSuite) TestCreateSecretApplicationOwnedByLeader(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	id := s.createSecret(c, s.wordpress.Tag())
+
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, err := secret.Owner()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.Equals, s.wordpress.Tag())
+}
+
+func (s *uniterSuite) TestGetSecretValues(c *gc.C) {
+	id := s.createSecret(c, s.wordpressUnit.Tag())
+
+	result, err := s.uniter.GetSecretValues(params.GetSecretArgs{
+		Args: []params.GetSecretArg{{ID: id}, {Label: "password"}, {ID: "missing"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretValueResults{
+		Results: []params.SecretValueResult{
+			{Data: map[string]string{"password": "sekrit"}},
+			{Data: map[string]string{"password": "sekrit"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestGrantSecretToRelatedApplication(c *gc.C) {
+	id := s.createSecret(c, s.wordpressUnit.Tag())
+
+	grant := params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{ID: id, ApplicationTag: "application-mysql"}},
+	}
+	result, err := s.uniter.GrantSecrets(grant)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `application "mysql" not related to "wordpress" not valid`)
+
+	s.addRelation(c, "wordpress", "mysql")
+	result, err = s.uniter.GrantSecrets(grant)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	// The mysql unit can now read the secret.
+	mysqlAuthorizer := s.authorizer
+	mysqlAuthorizer.Tag = s.mysqlUnit.Tag()
+	mysqlUniter, err := uniter.NewUniterAPIV6(s.State, s.resources, mysqlAuthorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	values, err := mysqlUniter.GetSecretValues(params.GetSecretArgs{
+		Args: []params.GetSecretArg{{ID: id}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values.Results[0].Error, gc.IsNil)
+	c.Assert(values.Results[0].Data, jc.DeepEquals, map[string]string{"password": "sekrit"})
+
+	// ...but cannot change it.
+	updated, err := mysqlUniter.UpdateSecrets(params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{ID: id, Data: map[string]string{"password": "mine"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	result, err = s.uniter.RevokeSecrets(grant)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	values, err = mysqlUniter.GetSecretValues(params.GetSecretArgs{
+		Args: []params.GetSecretArg{{ID: id}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+}
+
+func (s *uniterSuite) TestSecretRotations(c *gc.C) {
+	secret, err := s.State.AddSecret(state.CreateSecretParams{
+		Owner:          s.wordpressUnit.Tag(),
+		Data:           map[string]string{"password": "sekrit"},
+		RotateInterval: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSecret(state.CreateSecretParams{
+		Owner:          s.wordpress.Tag(),
+		Data:           map[string]string{"password": "sekrit"},
+		RotateInterval: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unit is not the leader, so it is not responsible for
+	// rotating the application's secret.
+	result, err := s.uniter.SecretRotations(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-wordpress-0"}, {Tag: "unit-mysql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Rotations, jc.DeepEquals, []params.SecretRotation{{
+		ID:             secret.ID(),
+		NextRotateTime: *secret.NextRotateTime(),
+	}})
+	c.Assert(result.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	rotated, err := s.uniter.SecretsRotated(params.GetSecretArgs{
+		Args: []params.GetSecretArg{{ID: secret.ID()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rotated.Results[0].Error, gc.IsNil)
+	records, err := s.State.SecretAuditRecords(secret.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records[len(records)-1].Action, gc.Equals, state.SecretRotated)
+}
+
+func (s *uniterSuite) TestWatchSecretRotations(c *gc.C) {
+	id := s.createSecret(c, s.wordpressUnit.Tag())
+
+	result, err := s.uniter.WatchSecretRotations(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-wordpress-0"}, {Tag: "unit-mysql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].StringsWatcherId, gc.Equals, "1")
+	c.Assert(result.Results[0].Changes, jc.DeepEquals, []string{id})
+	c.Assert(result.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+}
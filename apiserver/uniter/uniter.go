@@ -29,6 +29,13 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 
 func init() {
 	common.RegisterStandardFacade("Uniter", 4, NewUniterAPIV4)
+	common.RegisterStandardFacade("Uniter", 5, NewUniterAPIV5)
+	common.RegisterStandardFacade("Uniter", 6, NewUniterAPIV6)
+	common.RegisterStandardFacade("Uniter", 7, NewUniterAPIV7)
+	common.RegisterStandardFacade("Uniter", 8, NewUniterAPIV8)
+	common.RegisterStandardFacade("Uniter", 9, NewUniterAPIV9)
+	common.RegisterStandardFacade("Uniter", 10, NewUniterAPIV10)
+	common.RegisterStandardFacade("Uniter", 11, NewUniterAPIV11)
 }
 
 // UniterAPIV3 implements the API version 3, used by the uniter worker.
@@ -53,6 +60,111 @@ type UniterAPIV3 struct {
 	StorageAPI
 }
 
+// UniterAPIV5 implements the API version 5, used by the uniter worker.
+// It adds Series to version 4.
+type UniterAPIV5 struct {
+	*UniterAPIV3
+}
+
+// NewUniterAPIV5 creates a new instance of the Uniter API, version 5.
+func NewUniterAPIV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV5, error) {
+	api, err := NewUniterAPIV4(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV5{api}, nil
+}
+
+// UniterAPIV6 implements the API version 6, used by the uniter worker.
+// It adds secrets to version 5.
+type UniterAPIV6 struct {
+	*UniterAPIV5
+}
+
+// NewUniterAPIV6 creates a new instance of the Uniter API, version 6.
+func NewUniterAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV6, error) {
+	api, err := NewUniterAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV6{api}, nil
+}
+
+// UniterAPIV7 implements the API version 7, used by the uniter worker.
+// It adds goal states to version 6.
+type UniterAPIV7 struct {
+	*UniterAPIV6
+}
+
+// NewUniterAPIV7 creates a new instance of the Uniter API, version 7.
+func NewUniterAPIV7(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV7, error) {
+	api, err := NewUniterAPIV6(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV7{api}, nil
+}
+
+// UniterAPIV8 implements the API version 8, used by the uniter worker.
+// It adds cloud specs to version 7.
+type UniterAPIV8 struct {
+	*UniterAPIV7
+}
+
+// NewUniterAPIV8 creates a new instance of the Uniter API, version 8.
+func NewUniterAPIV8(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV8, error) {
+	api, err := NewUniterAPIV7(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV8{api}, nil
+}
+
+// UniterAPIV9 implements the API version 9, used by the uniter worker.
+// It adds State and SetState to version 8.
+type UniterAPIV9 struct {
+	*UniterAPIV8
+}
+
+// NewUniterAPIV9 creates a new instance of the Uniter API, version 9.
+func NewUniterAPIV9(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV9, error) {
+	api, err := NewUniterAPIV8(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV9{api}, nil
+}
+
+// UniterAPIV10 implements the API version 10, used by the uniter worker.
+// It adds AddHookHistory to version 9.
+type UniterAPIV10 struct {
+	*UniterAPIV9
+}
+
+// NewUniterAPIV10 creates a new instance of the Uniter API, version 10.
+func NewUniterAPIV10(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV10, error) {
+	api, err := NewUniterAPIV9(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV10{api}, nil
+}
+
+// UniterAPIV11 implements the API version 11, used by the uniter worker.
+// It adds ReplayHooksRequested and ClearReplayHooks to version 10.
+type UniterAPIV11 struct {
+	*UniterAPIV10
+}
+
+// NewUniterAPIV11 creates a new instance of the Uniter API, version 11.
+func NewUniterAPIV11(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV11, error) {
+	api, err := NewUniterAPIV10(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV11{api}, nil
+}
+
 // NewUniterAPIV4 creates a new instance of the Uniter API, version 3.
 func NewUniterAPIV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV3, error) {
 	if !authorizer.AuthUnitAgent() {
@@ -379,6 +491,34 @@ func (u *UniterAPIV3) Resolved(args params.Entities) (params.ResolvedModeResults
 	return result, nil
 }
 
+// Series returns the series of each given unit.
+func (u *UniterAPIV5) Series(args params.Entities) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				result.Results[i].Result = unit.Series()
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // ClearResolved removes any resolved setting from each given unit.
 func (u *UniterAPIV3) ClearResolved(args params.Entities) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -1213,7 +1353,8 @@ func (u *UniterAPIV3) WatchRelationUnits(args params.RelationUnits) (params.Rela
 }
 
 // WatchUnitAddresses returns a NotifyWatcher for observing changes
-// to each unit's addresses.
+// to each unit's addresses, or to the endpoint bindings of its
+// application.
 func (u *UniterAPIV3) WatchUnitAddresses(args params.Entities) (params.NotifyWatchResults, error) {
 	result := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
@@ -1399,7 +1540,16 @@ func (u *UniterAPIV3) watchOneUnitAddresses(tag names.UnitTag) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	watch := machine.WatchAddresses()
+	service, err := unit.Application()
+	if err != nil {
+		return "", err
+	}
+	// Changing the endpoint bindings changes the addresses
+	// network-get reports, so treat it as an address change.
+	watch := common.NewMultiNotifyWatcher(
+		machine.WatchAddresses(),
+		service.WatchEndpointBindings(),
+	)
 	// Consume the initial event. Technically, API
 	// calls to Watch 'transmit' the initial event
 	// in the Watch response. But NotifyWatchers
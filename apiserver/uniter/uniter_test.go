@@ -37,7 +37,7 @@ type uniterSuite struct {
 
 	authorizer apiservertesting.FakeAuthorizer
 	resources  *common.Resources
-	uniter     *uniter.UniterAPIV3
+	uniter     *uniter.UniterAPIV11
 
 	machine0      *state.Machine
 	machine1      *state.Machine
@@ -114,13 +114,13 @@ func (s *uniterSuite) SetUpTest(c *gc.C) {
 	s.resources = common.NewResources()
 	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
 
-	uniterAPIV3, err := uniter.NewUniterAPIV4(
+	uniterAPI, err := uniter.NewUniterAPIV11(
 		s.State,
 		s.resources,
 		s.authorizer,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.uniter = uniterAPIV3
+	s.uniter = uniterAPI
 }
 
 func (s *uniterSuite) TestUniterFailsWithNonUnitAgentUser(c *gc.C) {
@@ -131,6 +131,36 @@ func (s *uniterSuite) TestUniterFailsWithNonUnitAgentUser(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+// newMethods holds the methods added by each version of the facade.
+var newMethods = map[int][]string{
+	5: {"Series"},
+	6: {
+		"CreateSecrets", "UpdateSecrets", "GetSecretValues", "GrantSecrets",
+		"RevokeSecrets", "WatchSecretRotations", "SecretRotations",
+		"SecretsRotated",
+	},
+	7:  {"GoalStates"},
+	8:  {"CloudSpec"},
+	9:  {"State", "SetState"},
+	10: {"AddHookHistory"},
+	11: {"ReplayHooksRequested", "ClearReplayHooks"},
+}
+
+func (s *uniterSuite) TestNewMethodsVersioned(c *gc.C) {
+	for version, methods := range newMethods {
+		older, err := common.Facades.GetType("Uniter", version-1)
+		c.Assert(err, jc.ErrorIsNil)
+		newer, err := common.Facades.GetType("Uniter", version)
+		c.Assert(err, jc.ErrorIsNil)
+		for _, name := range methods {
+			_, ok := older.MethodByName(name)
+			c.Check(ok, jc.IsFalse, gc.Commentf("v%d has %s", version-1, name))
+			_, ok = newer.MethodByName(name)
+			c.Check(ok, jc.IsTrue, gc.Commentf("v%d lacks %s", version, name))
+		}
+	}
+}
+
 func (s *uniterSuite) TestSetStatus(c *gc.C) {
 	now := time.Now()
 	sInfo := status.StatusInfo{
@@ -543,6 +573,23 @@ func (s *uniterSuite) TestResolved(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestSeries(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.Series(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: s.wordpressUnit.Series()},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
 func (s *uniterSuite) TestClearResolved(c *gc.C) {
 	err := s.wordpressUnit.SetResolved(state.ResolvedRetryHooks)
 	c.Assert(err, jc.ErrorIsNil)
@@ -2553,7 +2600,7 @@ func (s *uniterNetworkConfigSuite) setupUniterAPIForUnit(c *gc.C, givenUnit *sta
 	}
 
 	var err error
-	s.base.uniter, err = uniter.NewUniterAPIV4(
+	s.base.uniter, err = uniter.NewUniterAPIV11(
 		s.base.State,
 		s.base.resources,
 		s.base.authorizer,
@@ -0,0 +1,172 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// State returns the state the uniter has persisted for each given
+// unit, so that an agent which has lost its local files can resume.
+func (u *UniterAPIV9) State(args params.Entities) (params.UnitStateResults, error) {
+	result := params.UnitStateResults{
+		Results: make([]params.UnitStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.UnitStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		unitState, err := unit.State()
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		resultItem.UniterState = unitState.UniterState
+		if len(unitState.RelationState) > 0 {
+			resultItem.RelationState = make(map[string]string)
+			for id, relationState := range unitState.RelationState {
+				resultItem.RelationState[strconv.Itoa(id)] = relationState
+			}
+		}
+		resultItem.SkippedHooks = unitState.SkippedHooks
+	}
+	return result, nil
+}
+
+// SetState updates the state the uniter persists for each given unit.
+func (u *UniterAPIV9) SetState(args params.SetUnitStateArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		update, err := unitStateUpdate(entity)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if err := unit.SetState(update); err != nil {
+			resultItem.Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+func unitStateUpdate(arg params.SetUnitStateArg) (state.UnitStateUpdate, error) {
+	update := state.UnitStateUpdate{UniterState: arg.UniterState}
+	if arg.SkippedHooks != nil {
+		update.SkippedHooks = append([]string{}, *arg.SkippedHooks...)
+	}
+	if arg.RelationState == nil {
+		return update, nil
+	}
+	update.RelationState = make(map[int]string)
+	for key, relationState := range *arg.RelationState {
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			return state.UnitStateUpdate{}, errors.NotValidf("relation id %q", key)
+		}
+		update.RelationState[id] = relationState
+	}
+	return update, nil
+}
+
+// ReplayHooksRequested reports, for each given unit, whether the unit
+// has been asked to run the hooks it skipped again.
+func (u *UniterAPIV11) ReplayHooksRequested(args params.Entities) (params.BoolResults, error) {
+	result := params.BoolResults{
+		Results: make([]params.BoolResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.BoolResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				result.Results[i].Result = unit.ReplayHooksRequested()
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// ClearReplayHooks removes any request to replay the skipped hooks of
+// each given unit.
+func (u *UniterAPIV11) ClearReplayHooks(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				err = unit.ClearReplayHooks()
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+func (s *uniterSuite) TestStatePermissions(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-foo-42"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.State(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.UnitStateResults{
+		Results: []params.UnitStateResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: common.ServerError(errors.New(`"application-wordpress" is not a valid unit tag`))},
+		},
+	})
+}
+
+func (s *uniterSuite) TestState(c *gc.C) {
+	uniterState := "kind: continue\nstep: pending\n"
+	err := s.wordpressUnit.SetState(state.UnitStateUpdate{
+		UniterState:   &uniterState,
+		RelationState: map[int]string{1: "members: {}\n"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.State(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UnitStateResults{
+		Results: []params.UnitStateResult{{
+			UniterState:   uniterState,
+			RelationState: map[string]string{"1": "members: {}\n"},
+		}},
+	})
+}
+
+func (s *uniterSuite) TestSetState(c *gc.C) {
+	uniterState := "kind: continue\nstep: pending\n"
+	relationState := map[string]string{"1": "members: {}\n"}
+	badRelationState := map[string]string{"one": "members: {}\n"}
+	result, err := s.uniter.SetState(params.SetUnitStateArgs{
+		Entities: []params.SetUnitStateArg{
+			{Tag: "unit-wordpress-0", UniterState: &uniterState, RelationState: &relationState},
+			{Tag: "unit-wordpress-0", RelationState: &badRelationState},
+			{Tag: "unit-mysql-0", UniterState: &uniterState},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `relation id "one" not valid`}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	unitState, err := s.wordpressUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, &state.UnitState{
+		UniterState:   uniterState,
+		RelationState: map[int]string{1: "members: {}\n"},
+	})
+}
+
+func (s *uniterSuite) TestSetStateSkippedHooks(c *gc.C) {
+	skippedHooks := []string{"config-changed"}
+	result, err := s.uniter.SetState(params.SetUnitStateArgs{
+		Entities: []params.SetUnitStateArg{
+			{Tag: "unit-wordpress-0", SkippedHooks: &skippedHooks},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+
+	stateResult, err := s.uniter.State(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stateResult.Results[0].SkippedHooks, jc.DeepEquals, skippedHooks)
+}
+
+func (s *uniterSuite) TestReplayHooks(c *gc.C) {
+	err := s.wordpressUnit.SetState(state.UnitStateUpdate{
+		SkippedHooks: []string{"config-changed"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressUnit.RequestReplayHooks()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-mysql-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.ReplayHooksRequested(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.BoolResults{
+		Results: []params.BoolResult{
+			{Result: true},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	clearResult, err := s.uniter.ClearReplayHooks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clearResult, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressUnit.ReplayHooksRequested(), jc.IsFalse)
+}
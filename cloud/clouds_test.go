@@ -23,6 +23,7 @@ var _ = gc.Suite(&cloudSuite{})
 
 var publicCloudNames = []string{
 	"aws", "aws-china", "aws-gov", "google", "azure", "azure-china", "rackspace", "joyent", "cloudsigma",
+	"digitalocean",
 }
 
 func parsePublicClouds(c *gc.C) map[string]cloud.Cloud {
@@ -171,4 +171,26 @@ clouds:
         endpoint: https://wdc.cloudsigma.com/api/2.0/
       zrh:
         endpoint: https://zrh.cloudsigma.com/api/2.0/
+  digitalocean:
+    type: digitalocean
+    auth-types: [ oauth2 ]
+    regions:
+      nyc1:
+        endpoint: https://api.digitalocean.com
+      nyc3:
+        endpoint: https://api.digitalocean.com
+      sfo2:
+        endpoint: https://api.digitalocean.com
+      tor1:
+        endpoint: https://api.digitalocean.com
+      lon1:
+        endpoint: https://api.digitalocean.com
+      ams3:
+        endpoint: https://api.digitalocean.com
+      fra1:
+        endpoint: https://api.digitalocean.com
+      sgp1:
+        endpoint: https://api.digitalocean.com
+      blr1:
+        endpoint: https://api.digitalocean.com
 `
@@ -34,6 +34,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/paths"
 	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
 	"github.com/juju/juju/state/multiwatcher"
@@ -48,6 +49,11 @@ type InstanceConfig struct {
 	// should be populated using the InstanceTags method in this package.
 	Tags map[string]string
 
+	// Profiles holds the names of LXD profiles, in addition to those
+	// applied by default, to apply to the instance. It is only used
+	// when starting LXD containers.
+	Profiles []string
+
 	// Bootstrap contains bootstrap-specific configuration. If this is set,
 	// Controller must also be set.
 	Bootstrap *BootstrapConfig
@@ -145,6 +151,12 @@ type InstanceConfig struct {
 	// instances. If enabled, the OS will perform any upgrades
 	// available as part of its provisioning.
 	EnableOSUpgrade bool
+
+	// FanConfig holds the Fan network mappings to set up on the
+	// instance, so that containers started on it can be placed on
+	// the Fan. It is empty if the model's containers do not use the
+	// Fan.
+	FanConfig network.FanConfig
 }
 
 // ControllerConfig represents controller-specific initialization information
@@ -766,6 +778,9 @@ func FinishInstanceConfig(icfg *InstanceConfig, cfg *config.Config) (err error)
 	); err != nil {
 		return errors.Trace(err)
 	}
+	if icfg.MachineContainerType == "" && cfg.ContainerNetworkingMethod() == config.ContainerNetworkingFan {
+		icfg.FanConfig = cfg.FanConfig()
+	}
 	if icfg.Controller != nil {
 		// Add NUMACTL preference. Needed to work for both bootstrap and high availability
 		// Only makes sense for controller
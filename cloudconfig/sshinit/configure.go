@@ -36,6 +36,11 @@ type ConfigureParams struct {
 
 	// Series is the series of the machine on which the script will be carried out
 	Series string
+
+	// SSHOptions holds options for the SSH connection to the host,
+	// such as its port and the identity files to use. If SSHOptions
+	// is nil, the defaults will be used.
+	SSHOptions *ssh.Options
 }
 
 // Configure connects to the specified host over SSH,
@@ -81,7 +86,7 @@ cat > $tmpfile
 			`/bin/bash -c "$(echo %s | base64 -d)"`,
 			utils.ShQuote(encoded),
 		),
-	}, nil)
+	}, params.SSHOptions)
 
 	cmd.Stdin = strings.NewReader(script)
 	cmd.Stderr = params.ProgressWriter
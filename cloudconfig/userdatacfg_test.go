@@ -1188,6 +1188,47 @@ export NO_PROXY=localhost,10.0.3.1' > /home/ubuntu/.juju-proxy && chown ubuntu:u
 	c.Assert(found, jc.IsTrue)
 }
 
+func (s *cloudinitSuite) TestFanConfigWritten(c *gc.C) {
+	environConfig := minimalModelConfig(c)
+	environConfig, err := environConfig.Apply(map[string]interface{}{
+		"fan-config": "172.31.0.0/16=252.0.0.0/8",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	instanceCfg := s.createInstanceConfig(c, environConfig)
+	cloudcfg, err := cloudinit.New("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	udata, err := cloudconfig.NewUserdataConfig(instanceCfg, cloudcfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = udata.Configure()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(set.NewStrings(cloudcfg.Packages()...).Contains("ubuntu-fan"), jc.IsTrue)
+	cmds := strings.Join(cloudcfg.RunCmds(), "\n")
+	c.Assert(cmds, jc.Contains, "/etc/network/fan")
+	c.Assert(cmds, jc.Contains, "172.31.0.0/16 252.0.0.0/8 dhcp")
+	c.Assert(cmds, jc.Contains, "\nfanctl up -a")
+}
+
+func (s *cloudinitSuite) TestFanConfigNotWrittenForLocalNetworking(c *gc.C) {
+	environConfig := minimalModelConfig(c)
+	environConfig, err := environConfig.Apply(map[string]interface{}{
+		"fan-config":                  "172.31.0.0/16=252.0.0.0/8",
+		"container-networking-method": "local",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	instanceCfg := s.createInstanceConfig(c, environConfig)
+	cloudcfg, err := cloudinit.New("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	udata, err := cloudconfig.NewUserdataConfig(instanceCfg, cloudcfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = udata.Configure()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(set.NewStrings(cloudcfg.Packages()...).Contains("ubuntu-fan"), jc.IsFalse)
+	cmds := strings.Join(cloudcfg.RunCmds(), "\n")
+	c.Assert(cmds, gc.Not(jc.Contains), "fanctl up -a")
+}
+
 func (s *cloudinitSuite) TestAptMirror(c *gc.C) {
 	environConfig := minimalModelConfig(c)
 	environConfig, err := environConfig.Apply(map[string]interface{}{
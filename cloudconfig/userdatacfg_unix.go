@@ -35,6 +35,10 @@ import (
 )
 
 const (
+	// fanConfigFile is the file from which the ubuntu-fan service
+	// brings up the Fan bridges at boot.
+	fanConfigFile = "/etc/network/fan"
+
 	// curlCommand is the base curl command used to download tools.
 	curlCommand = "curl -sSfw 'tools from %{url_effective} downloaded: HTTP %{http_code}; time %{time_total}s; size %{size_download} bytes; speed %{speed_download} bytes/s '"
 
@@ -213,6 +217,10 @@ func (w *unixConfigure) ConfigureJuju() error {
 	// Write out the introspection helper bash functions in /etc/profile.d.
 	w.conf.AddRunTextFile("/etc/profile.d/juju-introspection.sh", introspectionWorkerBashFuncs, 0644)
 
+	if len(w.icfg.FanConfig) > 0 {
+		w.addFanConfig()
+	}
+
 	// Make the lock dir and change the ownership of the lock dir itself to
 	// ubuntu:ubuntu from root:root so the juju-run command run as the ubuntu
 	// user is able to get access to the hook execution lock (like the uniter
@@ -272,6 +280,22 @@ func (w *unixConfigure) ConfigureJuju() error {
 	return w.addMachineAgentToBoot()
 }
 
+// addFanConfig installs the Fan, and records the instance's Fan network
+// mappings in /etc/network/fan so that the Fan bridges are brought up
+// now and again on every boot.
+func (w *unixConfigure) addFanConfig() {
+	w.conf.AddPackage("ubuntu-fan")
+	var fanConfig bytes.Buffer
+	fanConfig.WriteString("# Added by juju\n")
+	for _, entry := range w.icfg.FanConfig {
+		// The underlay is given as a network, so that each host
+		// uses its own address within it.
+		fmt.Fprintf(&fanConfig, "%s %s dhcp\n", entry.Underlay, entry.Overlay)
+	}
+	w.conf.AddRunTextFile(fanConfigFile, fanConfig.String(), 0644)
+	w.conf.AddScripts("fanctl up -a")
+}
+
 func (w *unixConfigure) configureBootstrap() error {
 	// Add the Juju GUI to the bootstrap node.
 	cleanup, err := w.setUpGUI()
@@ -33,6 +33,10 @@ func (c *RunCommand) UnitTag() names.UnitTag {
 	return c.unitTag
 }
 
+func (c *RunCommand) LeaderOf() string {
+	return c.leaderOf
+}
+
 func (c *RunCommand) ActionName() string {
 	return c.actionName
 }
@@ -30,6 +30,7 @@ func NewRunCommand() cmd.Command {
 type runCommand struct {
 	ActionCommandBase
 	unitTag      names.UnitTag
+	leaderOf     string
 	actionName   string
 	paramsYAML   cmd.FileVar
 	parseStrings bool
@@ -67,6 +68,11 @@ result:
     units: GB
     name: foo.sql
 
+$ juju run-action mysql/leader backup
+...
+The action is queued on whichever mysql unit is currently the leader.
+...
+
 $ juju run-action mysql/3 backup --params parameters.yml
 ...
 Params sent will be the contents of parameters.yml.
@@ -109,6 +115,21 @@ The value for the "time" param will be the string literal "1000".
 // ActionNameRule describes the format an action name must match to be valid.
 var ActionNameRule = regexp.MustCompile("^[a-z](?:[a-z-]*[a-z])?$")
 
+// leaderSuffix completes a unit identifier referring to the current leader
+// of an application.
+const leaderSuffix = "/leader"
+
+// LeaderApplication returns the application name and true if the supplied
+// unit identifier takes the form "<application>/leader". The leader is
+// resolved by the controller when the command is run.
+func LeaderApplication(unitName string) (string, bool) {
+	if !strings.HasSuffix(unitName, leaderSuffix) {
+		return "", false
+	}
+	appName := strings.TrimSuffix(unitName, leaderSuffix)
+	return appName, names.IsValidApplication(appName)
+}
+
 // SetFlags offers an option for YAML output.
 func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
@@ -135,14 +156,19 @@ func (c *runCommand) Init(args []string) error {
 	default:
 		// Grab and verify the unit and action names.
 		unitName := args[0]
-		if !names.IsValidUnit(unitName) {
+		appName, isLeader := LeaderApplication(unitName)
+		if !isLeader && !names.IsValidUnit(unitName) {
 			return errors.Errorf("invalid unit name %q", unitName)
 		}
 		ActionName := args[1]
 		if valid := ActionNameRule.MatchString(ActionName); !valid {
 			return fmt.Errorf("invalid action name %q", ActionName)
 		}
-		c.unitTag = names.NewUnitTag(unitName)
+		if isLeader {
+			c.leaderOf = appName
+		} else {
+			c.unitTag = names.NewUnitTag(unitName)
+		}
 		c.actionName = ActionName
 		if len(args) == 2 {
 			return nil
@@ -230,7 +256,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 
 	actionParam := params.Actions{
 		Actions: []params.Action{{
-			Receiver:   c.unitTag.String(),
+			Receiver:   c.receiver(),
 			Name:       c.actionName,
 			Parameters: actionParams,
 		}},
@@ -262,3 +288,13 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	output := map[string]string{"Action queued with id": tag.Id()}
 	return c.out.Write(ctx, output)
 }
+
+// receiver returns the receiver of the queued action: either the unit's
+// tag or, when targeting an application's leader, "<application>/leader"
+// for the controller to resolve.
+func (c *runCommand) receiver() string {
+	if c.leaderOf != "" {
+		return c.leaderOf + leaderSuffix
+	}
+	return c.unitTag.String()
+}
@@ -60,6 +60,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		should               string
 		args                 []string
 		expectUnit           names.UnitTag
+		expectLeaderOf       string
 		expectAction         string
 		expectParamsYamlPath string
 		expectParseStrings   bool
@@ -100,6 +101,15 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		expectUnit:   names.NewUnitTag(validUnitId),
 		expectAction: "valid-action-name",
 		expectKVArgs: [][]string{{"ok", ""}},
+	}, {
+		should:         "init properly with an application leader",
+		args:           []string{"mysql/leader", "valid-action-name"},
+		expectLeaderOf: "mysql",
+		expectAction:   "valid-action-name",
+	}, {
+		should:      "fail with invalid application leader",
+		args:        []string{"-mysql/leader", "valid-action-name"},
+		expectError: "invalid unit name \"-mysql/leader\"",
 	}, {
 		should:             "handle --parse-strings",
 		args:               []string{validUnitId, "valid-action-name", "--string-args"},
@@ -169,6 +179,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			err := testing.InitCommand(wrappedCommand, args)
 			if t.expectError == "" {
 				c.Check(command.UnitTag(), gc.Equals, t.expectUnit)
+				c.Check(command.LeaderOf(), gc.Equals, t.expectLeaderOf)
 				c.Check(command.ActionName(), gc.Equals, t.expectAction)
 				c.Check(command.ParamsYAML().Path, gc.Equals, t.expectParamsYamlPath)
 				c.Check(command.Args(), jc.DeepEquals, t.expectKVArgs)
@@ -252,6 +263,17 @@ func (s *RunSuite) TestRun(c *gc.C) {
 			Parameters: map[string]interface{}{},
 			Receiver:   names.NewUnitTag(validUnitId).String(),
 		},
+	}, {
+		should:   "enqueue an action on an application leader",
+		withArgs: []string{"mysql/leader", "some-action"},
+		withActionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString},
+		}},
+		expectedActionEnqueued: params.Action{
+			Name:       "some-action",
+			Parameters: map[string]interface{}{},
+			Receiver:   "mysql/leader",
+		},
 	}, {
 		should: "enqueue an action with some explicit params",
 		withArgs: []string{validUnitId, "some-action",
@@ -0,0 +1,130 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageBindSummary = `
+Changes the spaces the endpoints of a deployed application are bound to.`[1:]
+
+var usageBindDetails = `
+Endpoint bindings are normally set when deploying an application with
+"juju deploy --bind". This command changes the bindings of an already
+deployed application, without redeploying it. Endpoints not specified
+keep their existing bindings. An endpoint given an empty space name, as
+in "db=", is bound to the default space again.
+
+Every machine already hosting a unit of the application must have an
+address in each space an endpoint is being bound to. Units are notified
+of the change with a config-changed hook, after which network-get
+reports the addresses in the new spaces.
+
+Examples:
+    juju bind mysql db=storage
+    juju bind mediawiki db=internal website=public
+    juju bind mysql db=
+
+See also:
+    deploy
+    spaces`[1:]
+
+// NewBindCommand returns a command to change the endpoint bindings of
+// an application.
+func NewBindCommand() cmd.Command {
+	return modelcmd.Wrap(&bindCommand{})
+}
+
+// bindCommand changes the endpoint bindings of an application.
+type bindCommand struct {
+	modelcmd.ModelCommandBase
+	api bindAPI
+
+	ApplicationName string
+	Bindings        map[string]string
+}
+
+func (c *bindCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "bind",
+		Args:    "<application name> <endpoint>=<space> ...",
+		Purpose: usageBindSummary,
+		Doc:     usageBindDetails,
+	}
+}
+
+func (c *bindCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	if len(args) == 1 {
+		return errors.New("no bindings specified")
+	}
+	bindings, err := parseBindings(args[1:])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.Bindings = bindings
+	return nil
+}
+
+// parseBindings parses a list of <endpoint>=<space> arguments. An empty
+// space name binds the endpoint to the default space.
+func parseBindings(args []string) (map[string]string, error) {
+	bindings := make(map[string]string, len(args))
+	for _, arg := range args {
+		parts := strings.Split(arg, "=")
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("expected <endpoint>=<space>, got %q", arg)
+		}
+		endpoint, space := parts[0], parts[1]
+		if space != "" && !names.IsValidSpace(space) {
+			return nil, errors.Errorf("invalid space name %q", space)
+		}
+		if _, ok := bindings[endpoint]; ok {
+			return nil, errors.Errorf("endpoint %q specified more than once", endpoint)
+		}
+		bindings[endpoint] = space
+	}
+	return bindings, nil
+}
+
+type bindAPI interface {
+	Close() error
+	SetBindings(application string, bindings map[string]string) error
+}
+
+func (c *bindCommand) getAPI() (bindAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run changes the endpoint bindings of the application.
+func (c *bindCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return block.ProcessBlockedError(client.SetBindings(c.ApplicationName, c.Bindings), block.BlockChange)
+}
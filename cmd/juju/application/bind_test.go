@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type BindSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeBindAPI
+}
+
+var _ = gc.Suite(&BindSuite{})
+
+type fakeBindAPI struct {
+	application string
+	bindings    map[string]string
+	err         error
+}
+
+func (f *fakeBindAPI) Close() error {
+	return nil
+}
+
+func (f *fakeBindAPI) SetBindings(application string, bindings map[string]string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if application != f.application {
+		return errors.NotFoundf("application %q", application)
+	}
+	f.bindings = bindings
+	return nil
+}
+
+func (s *BindSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeBindAPI{application: "mysql"}
+}
+
+var initBindErrorTests = []struct {
+	args []string
+	err  string
+}{
+	{
+		args: []string{},
+		err:  `no application name specified`,
+	}, {
+		args: []string{"mysql"},
+		err:  `no bindings specified`,
+	}, {
+		args: []string{"Mysql!", "db=storage"},
+		err:  `invalid application name "Mysql!"`,
+	}, {
+		args: []string{"mysql", "storage"},
+		err:  `expected <endpoint>=<space>, got "storage"`,
+	}, {
+		args: []string{"mysql", "=storage"},
+		err:  `expected <endpoint>=<space>, got "=storage"`,
+	}, {
+		args: []string{"mysql", "db=a=b"},
+		err:  `expected <endpoint>=<space>, got "db=a=b"`,
+	}, {
+		args: []string{"mysql", "db=Bad_Space"},
+		err:  `invalid space name "Bad_Space"`,
+	}, {
+		args: []string{"mysql", "db=storage", "db=public"},
+		err:  `endpoint "db" specified more than once`,
+	},
+}
+
+func (s *BindSuite) TestInitErrors(c *gc.C) {
+	for i, t := range initBindErrorTests {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(application.NewBindCommandForTest(s.fake), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *BindSuite) TestBind(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewBindCommandForTest(s.fake), "mysql", "db=storage", "cluster=internal")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.bindings, jc.DeepEquals, map[string]string{
+		"db":      "storage",
+		"cluster": "internal",
+	})
+}
+
+func (s *BindSuite) TestBindDefaultSpace(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewBindCommandForTest(s.fake), "mysql", "db=", "cluster=internal")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.bindings, jc.DeepEquals, map[string]string{
+		"db":      "",
+		"cluster": "internal",
+	})
+}
+
+func (s *BindSuite) TestBindUnknownApplication(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewBindCommandForTest(s.fake), "wordpress", "db=storage")
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" not found`)
+}
+
+func (s *BindSuite) TestBlockBind(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockBind")
+	testing.RunCommand(c, application.NewBindCommandForTest(s.fake), "mysql", "db=storage")
+
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlockBind.*")
+}
@@ -71,6 +71,10 @@ type DeployCommand struct {
 	Constraints     constraints.Value
 	BindToSpaces    string
 
+	// Trust is true if the deployed application may access the
+	// model's cloud credential with the credential-get hook tool.
+	Trust bool
+
 	// TODO(axw) move this to UnitCommandBase once we support --storage
 	// on add-unit too.
 	//
@@ -163,6 +167,10 @@ When deploying an application or adding machines, the 'spaces' constraint can
 be used to define a comma-delimited list of required and forbidden spaces (the
 latter prefixed with "^", similar to the 'tags' constraint).
 
+Charms that manage cloud resources themselves, such as storage operators and
+cloud integrators, need access to the model's cloud credential. Deploying with
+--trust allows the application's units to read the credential with the
+credential-get hook tool; see "juju help trust".
 
 Examples:
     juju deploy mysql --to 23       (deploy to machine 23)
@@ -183,6 +191,12 @@ Examples:
     (deploy 2 units to machines that are part of the 'dmz' space but not of the
     'cmd' or the 'database' spaces)
 
+    juju deploy mysql -n 3 --constraints zones=us-east-1a,us-east-1b
+    (provider-dependent; deploy 3 units spread across the two listed AZs)
+
+    juju deploy aws-integrator --trust
+    (allow the application to read the model's cloud credential)
+
 See also:
     spaces
     constraints
@@ -191,6 +205,7 @@ See also:
     get-config
     set-constraints
     get-constraints
+    trust
 `
 
 // DeployStep is an action that needs to be taken during charm deployment.
@@ -224,7 +239,7 @@ func (c *DeployCommand) Info() *cmd.Info {
 var (
 	// charmOnlyFlags and bundleOnlyFlags are used to validate flags based on
 	// whether we are deploying a charm or a bundle.
-	charmOnlyFlags  = []string{"bind", "config", "constraints", "force", "n", "num-units", "series", "to", "resource"}
+	charmOnlyFlags  = []string{"bind", "config", "constraints", "force", "n", "num-units", "series", "to", "resource", "trust"}
 	bundleOnlyFlags = []string{}
 )
 
@@ -241,6 +256,7 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
+	f.BoolVar(&c.Trust, "trust", false, "Allow the application to access the model's cloud credential")
 
 	for _, step := range c.Steps {
 		step.SetFlags(f)
@@ -577,6 +593,7 @@ func (c *DeployCommand) deployCharm(args deployCharmArgs) (rErr error) {
 		storage:         c.Storage,
 		spaceBindings:   c.Bindings,
 		resources:       ids,
+		trust:           c.Trust,
 	}
 	return args.deployer.applicationDeploy(params)
 }
@@ -659,6 +676,7 @@ type applicationDeployParams struct {
 	storage         map[string]storage.Constraints
 	spaceBindings   map[string]string
 	resources       map[string]string
+	trust           bool
 }
 
 type applicationDeployer struct {
@@ -722,6 +740,7 @@ func (c *applicationDeployer) applicationDeploy(args applicationDeployParams) er
 		Storage:          args.storage,
 		EndpointBindings: args.spaceBindings,
 		Resources:        args.resources,
+		Trust:            args.trust,
 	}
 
 	return serviceClient.Deploy(clientArgs)
@@ -278,6 +278,15 @@ func (s *DeploySuite) TestConstraints(c *gc.C) {
 	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=2G cpu-cores=2"))
 }
 
+func (s *DeploySuite) TestTrust(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
+	err := runDeploy(c, ch, "--trust", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.Trust(), jc.IsTrue)
+}
+
 func (s *DeploySuite) TestResources(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
 	dir := c.MkDir()
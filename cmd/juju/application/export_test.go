@@ -32,6 +32,27 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewBindCommandForTest returns a BindCommand with the api provided as specified.
+func NewBindCommandForTest(api bindAPI) cmd.Command {
+	return modelcmd.Wrap(&bindCommand{
+		api: api,
+	})
+}
+
+// NewUpdateSeriesCommandForTest returns an UpdateSeriesCommand with the api provided as specified.
+func NewUpdateSeriesCommandForTest(api updateSeriesAPI) cmd.Command {
+	return modelcmd.Wrap(&updateSeriesCommand{
+		api: api,
+	})
+}
+
+// NewReplayHooksCommandForTest returns a ReplayHooksCommand with the api provided as specified.
+func NewReplayHooksCommandForTest(api replayHooksAPI) cmd.Command {
+	return modelcmd.Wrap(&replayHooksCommand{
+		api: api,
+	})
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
@@ -44,3 +65,10 @@ func PatchNewCharmStoreClient(s Patcher, url string) {
 		})
 	})
 }
+
+// NewTrustCommandForTest returns a TrustCommand with the api provided as specified.
+func NewTrustCommandForTest(api trustAPI) cmd.Command {
+	return modelcmd.Wrap(&trustCommand{
+		api: api,
+	})
+}
@@ -4,8 +4,12 @@
 package application
 
 import (
+	"net"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
@@ -19,8 +23,13 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+By default the application's open ports are accessible from anywhere.
+Use --to-cidrs to restrict access to a comma-separated list of source
+CIDRs instead.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/8,192.168.1.0/24
 
 See also: 
     unexpose`[1:]
@@ -34,6 +43,8 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	CIDRs           []string
+	cidrsArg        string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,17 +56,31 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.cidrsArg, "to-cidrs", "", "Comma-separated list of source CIDRs to allow access from")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	if c.cidrsArg != "" {
+		for _, cidr := range strings.Split(c.cidrsArg, ",") {
+			cidr = strings.TrimSpace(cidr)
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.Errorf("invalid CIDR %q", cidr)
+			}
+			c.CIDRs = append(c.CIDRs, cidr)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeToCIDRs(serviceName string, cidrs []string) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +100,10 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if len(c.CIDRs) > 0 {
+		err = client.ExposeToCIDRs(c.ApplicationName, c.CIDRs)
+	} else {
+		err = client.Expose(c.ApplicationName)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
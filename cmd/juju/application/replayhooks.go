@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageReplayHooksSummary = `
+Runs again the hooks a unit skipped when its errors were resolved.`[1:]
+
+var usageReplayHooksDetails = `
+When a unit in an error state is resolved without --retry, the failed
+hook is skipped and whatever it should have done is lost. Skipped hooks
+are listed for the unit in "juju status --format yaml".
+
+This command asks the unit to run its skipped hooks again, in the order
+they were skipped. Hooks are replayed once the unit has no errors and has
+finished any other pending work. A hook for a relation or storage that
+no longer exists is discarded rather than replayed.
+
+Examples:
+    juju replay-hooks mysql/0
+
+See also:
+    resolved
+    status`[1:]
+
+// NewReplayHooksCommand returns a command to replay the hooks skipped
+// by a unit.
+func NewReplayHooksCommand() cmd.Command {
+	return modelcmd.Wrap(&replayHooksCommand{})
+}
+
+// replayHooksCommand asks a unit to replay the hooks it skipped.
+type replayHooksCommand struct {
+	modelcmd.ModelCommandBase
+	api replayHooksAPI
+
+	UnitName string
+}
+
+func (c *replayHooksCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "replay-hooks",
+		Args:    "<unit name>",
+		Purpose: usageReplayHooksSummary,
+		Doc:     usageReplayHooksDetails,
+	}
+}
+
+func (c *replayHooksCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit name specified")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return errors.Errorf("invalid unit name %q", args[0])
+	}
+	c.UnitName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+type replayHooksAPI interface {
+	Close() error
+	ReplayHooks(unit names.UnitTag) error
+}
+
+func (c *replayHooksCommand) getAPI() (replayHooksAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run asks the unit to replay its skipped hooks.
+func (c *replayHooksCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.ReplayHooks(names.NewUnitTag(c.UnitName))
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
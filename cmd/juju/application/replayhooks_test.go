@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type ReplayHooksSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeReplayHooksAPI
+}
+
+var _ = gc.Suite(&ReplayHooksSuite{})
+
+type fakeReplayHooksAPI struct {
+	unit     string
+	replayed []string
+	err      error
+}
+
+func (f *fakeReplayHooksAPI) Close() error {
+	return nil
+}
+
+func (f *fakeReplayHooksAPI) ReplayHooks(unit names.UnitTag) error {
+	if f.err != nil {
+		return f.err
+	}
+	if unit.Id() != f.unit {
+		return errors.NotFoundf("unit %q", unit.Id())
+	}
+	f.replayed = append(f.replayed, unit.Id())
+	return nil
+}
+
+func (s *ReplayHooksSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeReplayHooksAPI{unit: "mysql/0"}
+}
+
+func (s *ReplayHooksSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no unit name specified`,
+	}, {
+		args: []string{"mysql"},
+		err:  `invalid unit name "mysql"`,
+	}, {
+		args: []string{"mysql/0", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(application.NewReplayHooksCommandForTest(s.fake), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *ReplayHooksSuite) TestReplayHooks(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewReplayHooksCommandForTest(s.fake), "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.replayed, jc.DeepEquals, []string{"mysql/0"})
+}
+
+func (s *ReplayHooksSuite) TestReplayHooksUnknownUnit(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewReplayHooksCommandForTest(s.fake), "wordpress/0")
+	c.Assert(err, gc.ErrorMatches, `unit "wordpress/0" not found`)
+}
+
+func (s *ReplayHooksSuite) TestBlockReplayHooks(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockReplayHooks")
+	testing.RunCommand(c, application.NewReplayHooksCommandForTest(s.fake), "mysql/0")
+
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlockReplayHooks.*")
+}
@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageTrustSummary = `
+Allows an application to access the model's cloud credential.`[1:]
+
+var usageTrustDetails = `
+Charms that manage cloud resources themselves, such as storage operators
+and cloud integrators, need the credential the model uses to talk to the
+cloud. Trusting an application allows its units to read the model's cloud
+specification and credential with the credential-get hook tool. Each read
+is recorded in the controller's audit log.
+
+Applications may also be trusted when they are deployed, with
+"juju deploy --trust". Use --remove to revoke an application's trust.
+
+Examples:
+    juju trust aws-integrator
+    juju trust aws-integrator --remove
+
+See also:
+    deploy`[1:]
+
+// NewTrustCommand returns a command to grant or revoke an
+// application's access to the model's cloud credential.
+func NewTrustCommand() cmd.Command {
+	return modelcmd.Wrap(&trustCommand{})
+}
+
+// trustCommand grants or revokes an application's access to the
+// model's cloud credential.
+type trustCommand struct {
+	modelcmd.ModelCommandBase
+	api trustAPI
+
+	ApplicationName string
+	Remove          bool
+}
+
+func (c *trustCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "trust",
+		Args:    "<application name>",
+		Purpose: usageTrustSummary,
+		Doc:     usageTrustDetails,
+	}
+}
+
+func (c *trustCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.Remove, "remove", false, "Revoke the application's access to the cloud credential")
+}
+
+func (c *trustCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+type trustAPI interface {
+	Close() error
+	SetTrust(application string, trust bool) error
+}
+
+func (c *trustCommand) getAPI() (trustAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run grants or revokes the application's trust.
+func (c *trustCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return block.ProcessBlockedError(client.SetTrust(c.ApplicationName, !c.Remove), block.BlockChange)
+}
@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type TrustSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeTrustAPI
+}
+
+var _ = gc.Suite(&TrustSuite{})
+
+type fakeTrustAPI struct {
+	application string
+	trust       *bool
+	err         error
+}
+
+func (f *fakeTrustAPI) Close() error {
+	return nil
+}
+
+func (f *fakeTrustAPI) SetTrust(application string, trust bool) error {
+	if f.err != nil {
+		return f.err
+	}
+	if application != f.application {
+		return errors.NotFoundf("application %q", application)
+	}
+	f.trust = &trust
+	return nil
+}
+
+func (s *TrustSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeTrustAPI{application: "aws-integrator"}
+}
+
+func (s *TrustSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no application name specified`,
+	}, {
+		args: []string{"Aws!"},
+		err:  `invalid application name "Aws!"`,
+	}, {
+		args: []string{"aws-integrator", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(application.NewTrustCommandForTest(s.fake), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *TrustSuite) TestTrust(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewTrustCommandForTest(s.fake), "aws-integrator")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.trust, gc.NotNil)
+	c.Assert(*s.fake.trust, jc.IsTrue)
+}
+
+func (s *TrustSuite) TestRemoveTrust(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewTrustCommandForTest(s.fake), "aws-integrator", "--remove")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.trust, gc.NotNil)
+	c.Assert(*s.fake.trust, jc.IsFalse)
+}
+
+func (s *TrustSuite) TestTrustUnknownApplication(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewTrustCommandForTest(s.fake), "wordpress")
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" not found`)
+}
+
+func (s *TrustSuite) TestBlockTrust(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockTrust")
+	testing.RunCommand(c, application.NewTrustCommandForTest(s.fake), "aws-integrator")
+
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlockTrust.*")
+}
@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageUpdateSeriesSummary = `
+Updates the series of a machine or application.`[1:]
+
+var usageUpdateSeriesDetails = `
+After the OS of a machine has been upgraded in place (e.g. from trusty
+to xenial), Juju still records the old series. This command records the
+new series, without changing anything on the machine itself.
+
+When a machine is specified, the series of the machine and of every unit
+assigned to it is changed. The charms of those units must support the
+new series. Unit agents rewrite their init system service files for the
+new series, so that they are started correctly after the machine is
+rebooted.
+
+When an application is specified, the series used for new units of the
+application is changed. The application's charm must support the new
+series.
+
+The --force option allows a series not supported by the charm to be used,
+as long as the charm supports the OS of the series.
+
+Examples:
+    juju update-series 0 xenial
+    juju update-series mysql xenial
+    juju update-series --force mysql yakkety
+
+See also:
+    add-machine
+    add-unit
+    upgrade-charm`[1:]
+
+// NewUpdateSeriesCommand returns a command to update the series of a
+// machine or an application.
+func NewUpdateSeriesCommand() cmd.Command {
+	return modelcmd.Wrap(&updateSeriesCommand{})
+}
+
+// updateSeriesCommand updates the series of a machine or an application.
+type updateSeriesCommand struct {
+	modelcmd.ModelCommandBase
+	api updateSeriesAPI
+
+	MachineId       string
+	ApplicationName string
+	Series          string
+	Force           bool
+}
+
+func (c *updateSeriesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "update-series",
+		Args:    "<machine>|<application> <series>",
+		Purpose: usageUpdateSeriesSummary,
+		Doc:     usageUpdateSeriesDetails,
+	}
+}
+
+func (c *updateSeriesCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.Force, "force", false, "Allow a series not supported by the charm")
+}
+
+func (c *updateSeriesCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no machine or application specified")
+	}
+	if len(args) == 1 {
+		return errors.New("no series specified")
+	}
+	if err := cmd.CheckEmpty(args[2:]); err != nil {
+		return err
+	}
+	switch {
+	case names.IsValidMachine(args[0]):
+		c.MachineId = args[0]
+	case names.IsValidApplication(args[0]):
+		c.ApplicationName = args[0]
+	default:
+		return errors.Errorf("invalid machine or application name %q", args[0])
+	}
+	c.Series = args[1]
+	return nil
+}
+
+type updateSeriesAPI interface {
+	Close() error
+	UpdateMachineSeries(machine, series string, force bool) error
+	UpdateApplicationSeries(application, series string, force bool) error
+}
+
+// updateSeriesClient combines the application and machine manager
+// clients used to update the series of an application or machine.
+type updateSeriesClient struct {
+	*application.Client
+	machineManager *machinemanager.Client
+}
+
+func (c *updateSeriesClient) UpdateMachineSeries(machine, series string, force bool) error {
+	return c.machineManager.UpdateMachineSeries(machine, series, force)
+}
+
+func (c *updateSeriesCommand) getAPI() (updateSeriesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &updateSeriesClient{
+		Client:         application.NewClient(root),
+		machineManager: machinemanager.NewClient(root),
+	}, nil
+}
+
+// Run updates the series of the machine or application.
+func (c *updateSeriesCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if c.MachineId != "" {
+		err = client.UpdateMachineSeries(c.MachineId, c.Series, c.Force)
+	} else {
+		err = client.UpdateApplicationSeries(c.ApplicationName, c.Series, c.Force)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/testing"
+)
+
+type UpdateSeriesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeUpdateSeriesAPI
+}
+
+var _ = gc.Suite(&UpdateSeriesSuite{})
+
+type fakeUpdateSeriesAPI struct {
+	machine     string
+	application string
+	series      string
+	force       bool
+	err         error
+}
+
+func (f *fakeUpdateSeriesAPI) Close() error {
+	return nil
+}
+
+func (f *fakeUpdateSeriesAPI) UpdateMachineSeries(machine, series string, force bool) error {
+	f.machine = machine
+	f.series = series
+	f.force = force
+	return f.err
+}
+
+func (f *fakeUpdateSeriesAPI) UpdateApplicationSeries(application, series string, force bool) error {
+	f.application = application
+	f.series = series
+	f.force = force
+	return f.err
+}
+
+func (s *UpdateSeriesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeUpdateSeriesAPI{}
+}
+
+var initUpdateSeriesErrorTests = []struct {
+	args []string
+	err  string
+}{
+	{
+		args: []string{},
+		err:  `no machine or application specified`,
+	}, {
+		args: []string{"mysql"},
+		err:  `no series specified`,
+	}, {
+		args: []string{"Mysql!", "xenial"},
+		err:  `invalid machine or application name "Mysql!"`,
+	}, {
+		args: []string{"mysql", "xenial", "trusty"},
+		err:  `unrecognized args: \["trusty"\]`,
+	},
+}
+
+func (s *UpdateSeriesSuite) TestInitErrors(c *gc.C) {
+	for i, t := range initUpdateSeriesErrorTests {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(application.NewUpdateSeriesCommandForTest(s.fake), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *UpdateSeriesSuite) TestUpdateMachineSeries(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewUpdateSeriesCommandForTest(s.fake), "0", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.machine, gc.Equals, "0")
+	c.Assert(s.fake.application, gc.Equals, "")
+	c.Assert(s.fake.series, gc.Equals, "xenial")
+	c.Assert(s.fake.force, jc.IsFalse)
+}
+
+func (s *UpdateSeriesSuite) TestUpdateApplicationSeries(c *gc.C) {
+	_, err := testing.RunCommand(c, application.NewUpdateSeriesCommandForTest(s.fake), "--force", "mysql", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.machine, gc.Equals, "")
+	c.Assert(s.fake.application, gc.Equals, "mysql")
+	c.Assert(s.fake.series, gc.Equals, "xenial")
+	c.Assert(s.fake.force, jc.IsTrue)
+}
+
+func (s *UpdateSeriesSuite) TestBlockUpdateSeries(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockUpdateSeries")
+	testing.RunCommand(c, application.NewUpdateSeriesCommandForTest(s.fake), "mysql", "xenial")
+
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlockUpdateSeries.*")
+}
@@ -4,7 +4,12 @@
 package cloud
 
 import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
 	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/modelcmd"
 	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	"github.com/juju/juju/jujuclient"
 )
@@ -70,3 +75,20 @@ func NewSetDefaultRegionCommandForTest(testStore jujuclient.CredentialStore) *se
 		store: testStore,
 	}
 }
+
+func NewUpdateCredentialCommandForTest(
+	apiRoot api.Connection,
+	cloudAPI UpdateCredentialAPI,
+	store jujuclient.ClientStore,
+) cmd.Command {
+	c := &updateCredentialCommand{
+		newAPIRoot: func(*updateCredentialCommand) (api.Connection, error) {
+			return apiRoot, nil
+		},
+		newCloudAPI: func(base.APICallCloser) UpdateCredentialAPI {
+			return cloudAPI
+		},
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -0,0 +1,124 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
+	cloudapi "github.com/juju/juju/api/cloud"
+	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageUpdateCredentialSummary = `
+Updates a credential for a cloud on the controller.`[1:]
+
+var usageUpdateCredentialDetails = `
+Replaces the named credential stored on the current controller with the
+credential of the same name in the local client store. Every model on the
+controller using the credential is updated to use the new values.
+
+Before anything is changed, the controller checks that the new credential
+is accepted by the cloud for each of those models. If it is not, neither
+the credential nor any model is updated.
+
+The credential must first be changed locally, for example with
+`[1:] + "`juju add-credential --replace`" + `.
+
+Examples:
+    juju update-credential aws mysecrets
+
+See also: 
+    add-credential
+    credentials`
+
+// NewUpdateCredentialCommand returns a command to update a credential
+// stored on the controller.
+func NewUpdateCredentialCommand() cmd.Command {
+	return modelcmd.WrapController(&updateCredentialCommand{
+		newAPIRoot: func(c *updateCredentialCommand) (api.Connection, error) {
+			return c.NewAPIRoot()
+		},
+		newCloudAPI: func(caller base.APICallCloser) UpdateCredentialAPI {
+			return cloudapi.NewClient(caller)
+		},
+	})
+}
+
+// UpdateCredentialAPI defines the controller API methods used by the
+// update-credential command.
+type UpdateCredentialAPI interface {
+	Cloud(names.CloudTag) (jujucloud.Cloud, error)
+	UpdateCredentialsCheckModels(names.UserTag, names.CloudTag, map[string]jujucloud.Credential) error
+}
+
+type updateCredentialCommand struct {
+	modelcmd.ControllerCommandBase
+
+	newAPIRoot  func(*updateCredentialCommand) (api.Connection, error)
+	newCloudAPI func(base.APICallCloser) UpdateCredentialAPI
+
+	cloud      string
+	credential string
+}
+
+func (c *updateCredentialCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "update-credential",
+		Args:    "<cloud name> <credential name>",
+		Purpose: usageUpdateCredentialSummary,
+		Doc:     usageUpdateCredentialDetails,
+	}
+}
+
+func (c *updateCredentialCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("Usage: juju update-credential <cloud-name> <credential-name>")
+	}
+	c.cloud = args[0]
+	c.credential = args[1]
+	if !names.IsValidCloud(c.cloud) {
+		return errors.NotValidf("cloud name %q", c.cloud)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+func (c *updateCredentialCommand) Run(ctx *cmd.Context) error {
+	store := c.ClientStore()
+	accountDetails, err := store.AccountDetails(c.ControllerName())
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	apiRoot, err := c.newAPIRoot(c)
+	if err != nil {
+		return errors.Annotate(err, "opening API connection")
+	}
+	defer apiRoot.Close()
+	client := c.newCloudAPI(apiRoot)
+
+	cloudTag := names.NewCloudTag(c.cloud)
+	cloudDetails, err := client.Cloud(cloudTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	credential, _, _, err := modelcmd.GetCredentials(
+		store, "", c.credential, c.cloud, cloudDetails.Type,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	userTag := names.NewUserTag(accountDetails.User)
+	credentials := map[string]jujucloud.Credential{c.credential: *credential}
+	if err := client.UpdateCredentialsCheckModels(userTag, cloudTag, credentials); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Credential %q for cloud %q updated on controller %q.", c.credential, c.cloud, c.ControllerName())
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/juju/cloud"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	_ "github.com/juju/juju/provider/ec2"
+	"github.com/juju/juju/testing"
+)
+
+type updateCredentialSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeUpdateCredentialAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&updateCredentialSuite{})
+
+func (s *updateCredentialSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeUpdateCredentialAPI{
+		cloud: jujucloud.Cloud{
+			Type:      "ec2",
+			AuthTypes: []jujucloud.AuthType{jujucloud.AccessKeyAuthType},
+		},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Controllers["ctrl"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["ctrl"] = jujuclient.AccountDetails{User: "bob@local"}
+	s.store.Credentials["aws"] = jujucloud.CloudCredential{
+		AuthCredentials: map[string]jujucloud.Credential{
+			"secrets": jujucloud.NewCredential(jujucloud.AccessKeyAuthType, map[string]string{
+				"access-key": "key",
+				"secret-key": "r0tated",
+			}),
+		},
+	}
+}
+
+func (s *updateCredentialSuite) run(c *gc.C, args ...string) error {
+	command := cloud.NewUpdateCredentialCommandForTest(&fakeAPIConnection{}, s.api, s.store)
+	_, err := testing.RunCommand(c, command, args...)
+	return err
+}
+
+func (s *updateCredentialSuite) TestBadArgs(c *gc.C) {
+	err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "Usage: juju update-credential <cloud-name> <credential-name>")
+	err = s.run(c, "aws", "secrets", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+	err = s.run(c, "a/ws", "secrets")
+	c.Assert(err, gc.ErrorMatches, `cloud name "a/ws" not valid`)
+}
+
+func (s *updateCredentialSuite) TestUpdateCredential(c *gc.C) {
+	err := s.run(c, "aws", "secrets")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Cloud", "UpdateCredentialsCheckModels")
+	s.api.CheckCall(c, 0, "Cloud", names.NewCloudTag("aws"))
+	s.api.CheckCall(c, 1, "UpdateCredentialsCheckModels",
+		names.NewUserTag("bob@local"),
+		names.NewCloudTag("aws"),
+		map[string]jujucloud.Credential{
+			"secrets": jujucloud.NewCredential(jujucloud.AccessKeyAuthType, map[string]string{
+				"access-key": "key",
+				"secret-key": "r0tated",
+			}),
+		},
+	)
+}
+
+func (s *updateCredentialSuite) TestUpdateCredentialNotFoundLocally(c *gc.C) {
+	err := s.run(c, "aws", "missing")
+	c.Assert(err, gc.ErrorMatches, `"missing" credential for cloud "aws" not found`)
+	s.api.CheckCallNames(c, "Cloud")
+}
+
+func (s *updateCredentialSuite) TestUpdateCredentialRejected(c *gc.C) {
+	s.api.SetErrors(nil, errors.New(`credential "secrets" not valid for model "prod"`))
+	err := s.run(c, "aws", "secrets")
+	c.Assert(err, gc.ErrorMatches, `credential "secrets" not valid for model "prod"`)
+}
+
+type fakeAPIConnection struct {
+	api.Connection
+}
+
+func (*fakeAPIConnection) Close() error {
+	return nil
+}
+
+type fakeUpdateCredentialAPI struct {
+	gitjujutesting.Stub
+	cloud jujucloud.Cloud
+}
+
+func (f *fakeUpdateCredentialAPI) Cloud(tag names.CloudTag) (jujucloud.Cloud, error) {
+	f.MethodCall(f, "Cloud", tag)
+	return f.cloud, f.NextErr()
+}
+
+func (f *fakeUpdateCredentialAPI) UpdateCredentialsCheckModels(
+	user names.UserTag, cloud names.CloudTag, credentials map[string]jujucloud.Credential,
+) error {
+	f.MethodCall(f, "UpdateCredentialsCheckModels", user, cloud, credentials)
+	return f.NextErr()
+}
@@ -1164,6 +1164,7 @@ aws-gov
 azure                                        
 azure-china                                  
 cloudsigma                                   
+digitalocean                                 
 google                                       
 joyent                                       
 rackspace                                    
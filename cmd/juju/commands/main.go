@@ -27,9 +27,11 @@ import (
 	"github.com/juju/juju/cmd/juju/cloud"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/cmd/juju/gui"
+	"github.com/juju/juju/cmd/juju/hookhistory"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/cmd/juju/metricsdebug"
 	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/cmd/juju/secrets"
 	"github.com/juju/juju/cmd/juju/setmeterstatus"
 	"github.com/juju/juju/cmd/juju/space"
 	"github.com/juju/juju/cmd/juju/status"
@@ -232,6 +234,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(hookhistory.NewShowCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newRunCommand())
@@ -287,11 +290,13 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(machine.NewRemoveCommand())
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
+	r.Register(machine.NewShowDriftCommand())
 
 	// Manage model
 	r.Register(model.NewGetCommand())
 	r.Register(model.NewSetCommand())
 	r.Register(model.NewUnsetCommand())
+	r.Register(model.NewDefaultsCommand())
 	r.Register(model.NewRetryProvisioningCommand())
 	r.Register(model.NewDestroyCommand())
 	r.Register(model.NewUsersCommand())
@@ -322,6 +327,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewDeployCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
+	r.Register(application.NewBindCommand())
+	r.Register(application.NewTrustCommand())
+	r.Register(application.NewReplayHooksCommand())
+	r.Register(application.NewUpdateSeriesCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
 
@@ -336,6 +345,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewPoolListCommand())
 	r.Register(storage.NewShowCommand())
 
+	// Manage secrets
+	r.Register(secrets.NewListCommand())
+	r.Register(secrets.NewShowCommand())
+
 	// Manage spaces
 	r.Register(space.NewAddCommand())
 	r.Register(space.NewListCommand())
@@ -365,6 +378,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewRemoveBlocksCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewGetConfigCommand())
+	r.Register(controller.NewSetSSHProxyJumpCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -383,6 +397,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(cloud.NewSetDefaultCredentialCommand())
 	r.Register(cloud.NewAddCredentialCommand())
 	r.Register(cloud.NewRemoveCredentialCommand())
+	r.Register(cloud.NewUpdateCredentialCommand())
 
 	// Juju GUI commands.
 	r.Register(gui.NewGUICommand())
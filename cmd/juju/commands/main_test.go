@@ -372,6 +372,7 @@ var commandNames = []string{
 	"allocate",
 	"autoload-credentials",
 	"backups",
+	"bind",
 	"block",
 	"blocks",
 	"bootstrap",
@@ -381,6 +382,7 @@ var commandNames = []string{
 	"charm",
 	"clouds",
 	"collect-metrics",
+	"controller-config",
 	"controllers",
 	"create-backup",
 	"create-budget",
@@ -428,6 +430,7 @@ var commandNames = []string{
 	"list-machines",
 	"list-models",
 	"list-plans",
+	"list-secrets",
 	"list-shares",
 	"list-ssh-key",
 	"list-ssh-keys",
@@ -441,6 +444,7 @@ var commandNames = []string{
 	"machine",
 	"machines",
 	"model-config",
+	"model-defaults",
 	"models",
 	"plans",
 	"register",
@@ -457,6 +461,7 @@ var commandNames = []string{
 	"remove-ssh-key",
 	"remove-ssh-keys",
 	"remove-unit", // alias for destroy-unit
+	"replay-hooks",
 	"resolved",
 	"restore-backup",
 	"retry-provisioning",
@@ -464,6 +469,7 @@ var commandNames = []string{
 	"run",
 	"run-action",
 	"scp",
+	"secrets",
 	"set-budget",
 	"set-config",
 	"set-configs",
@@ -474,6 +480,7 @@ var commandNames = []string{
 	"set-model-config",
 	"set-model-constraints",
 	"set-plan",
+	"set-ssh-proxy-jump",
 	"ssh-key",
 	"ssh-keys",
 	"shares",
@@ -484,9 +491,12 @@ var commandNames = []string{
 	"show-cloud",
 	"show-controller",
 	"show-controllers",
+	"show-drift",
+	"show-hook-history",
 	"show-machine",
 	"show-machines",
 	"show-model",
+	"show-secret",
 	"show-status",
 	"show-storage",
 	"show-user",
@@ -499,6 +509,7 @@ var commandNames = []string{
 	"subnets",
 	"switch",
 	"sync-tools",
+	"trust",
 	"unblock",
 	"unexpose",
 	"update-allocation",
@@ -506,6 +517,8 @@ var commandNames = []string{
 	"unregister",
 	"unset-model-config",
 	"update-clouds",
+	"update-credential",
+	"update-series",
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
@@ -35,6 +35,7 @@ type runCommand struct {
 	machines []string
 	services []string
 	units    []string
+	matching []string
 	commands string
 }
 
@@ -47,6 +48,9 @@ names.  At least one target specifier is needed.
 Multiple values can be set for --machine, --application, and --unit by using
 comma separated values.
 
+A unit may be given as "<application>/leader" to target whichever unit is
+currently the leader of that application.
+
 If the target is a machine, the command is run as the "ubuntu" user on
 the remote machine.
 
@@ -57,6 +61,13 @@ had two units, "mysql/0" and "mysql/1", then
 is equivalent to
   --unit mysql/0,mysql/1
 
+--units-matching restricts the targeted units to those matching every
+given "key=value" selector. Supported keys are "status" (the workload
+status), "agent-status" and "series". Without --application or --unit, the
+selectors are applied to every unit in the model. For example, to run the
+command on all units of mysql currently in error:
+  --application mysql --units-matching status=error
+
 Commands run for applications or units are executed in a 'hook context' for
 the unit.
 
@@ -84,6 +95,7 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "application", "One or more application names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "One or more unit ids")
+	f.Var(cmd.NewStringsValue(nil, &c.matching), "units-matching", "One or more key=value unit selectors")
 }
 
 func (c *runCommand) Init(args []string) error {
@@ -102,9 +114,12 @@ func (c *runCommand) Init(args []string) error {
 		if len(c.units) != 0 {
 			return fmt.Errorf("You cannot specify --all and individual units")
 		}
+		if len(c.matching) != 0 {
+			return fmt.Errorf("You cannot specify --all and --units-matching")
+		}
 	} else {
-		if len(c.machines) == 0 && len(c.services) == 0 && len(c.units) == 0 {
-			return fmt.Errorf("You must specify a target, either through --all, --machine, --application or --unit")
+		if len(c.machines) == 0 && len(c.services) == 0 && len(c.units) == 0 && len(c.matching) == 0 {
+			return fmt.Errorf("You must specify a target, either through --all, --machine, --application, --unit or --units-matching")
 		}
 	}
 
@@ -120,10 +135,18 @@ func (c *runCommand) Init(args []string) error {
 		}
 	}
 	for _, unit := range c.units {
+		if _, isLeader := action.LeaderApplication(unit); isLeader {
+			continue
+		}
 		if !names.IsValidUnit(unit) {
 			nameErrors = append(nameErrors, fmt.Sprintf("  %q is not a valid unit name", unit))
 		}
 	}
+	for _, selector := range c.matching {
+		if parts := strings.SplitN(selector, "=", 2); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			nameErrors = append(nameErrors, fmt.Sprintf("  %q is not a valid unit selector", selector))
+		}
+	}
 	if len(nameErrors) > 0 {
 		return fmt.Errorf("The following run targets are not valid:\n%s",
 			strings.Join(nameErrors, "\n"))
@@ -192,11 +215,12 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
 	} else {
 		params := params.RunParams{
-			Commands:     c.commands,
-			Timeout:      c.timeout,
-			Machines:     c.machines,
-			Applications: c.services,
-			Units:        c.units,
+			Commands:      c.commands,
+			Timeout:       c.timeout,
+			Machines:      c.machines,
+			Applications:  c.services,
+			Units:         c.units,
+			UnitsMatching: c.matching,
 		}
 		runResults, err = client.Run(params)
 	}
@@ -37,6 +37,7 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 		machines []string
 		units    []string
 		services []string
+		matching []string
 		commands string
 		errMatch string
 	}{{
@@ -45,7 +46,7 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 	}, {
 		message:  "no target",
 		args:     []string{"sudo reboot"},
-		errMatch: "You must specify a target, either through --all, --machine, --application or --unit",
+		errMatch: "You must specify a target, either through --all, --machine, --application, --unit or --units-matching",
 	}, {
 		message:  "too many args",
 		args:     []string{"--all", "sudo reboot", "oops"},
@@ -103,6 +104,34 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 			"The following run targets are not valid:\n" +
 			"  \"foo\" is not a valid unit name\n" +
 			"  \"2\" is not a valid unit name",
+	}, {
+		message:  "command to application leader",
+		args:     []string{"--unit=wordpress/leader,mysql/0", "sudo reboot"},
+		commands: "sudo reboot",
+		units:    []string{"wordpress/leader", "mysql/0"},
+	}, {
+		message:  "command to matching units of an application",
+		args:     []string{"--application=mysql", "--units-matching=status=error,series=trusty", "sudo reboot"},
+		commands: "sudo reboot",
+		services: []string{"mysql"},
+		matching: []string{"status=error", "series=trusty"},
+	}, {
+		message:  "command to matching units only",
+		args:     []string{"--units-matching=status=error", "sudo reboot"},
+		commands: "sudo reboot",
+		matching: []string{"status=error"},
+	}, {
+		message:  "all and unit selectors",
+		args:     []string{"--all", "--units-matching=status=error", "sudo reboot"},
+		errMatch: `You cannot specify --all and --units-matching`,
+	}, {
+		message: "bad unit selectors",
+		args:    []string{"--units-matching", "status,=error,series=", "sudo reboot"},
+		errMatch: "" +
+			"The following run targets are not valid:\n" +
+			"  \"status\" is not a valid unit selector\n" +
+			"  \"=error\" is not a valid unit selector\n" +
+			"  \"series=\" is not a valid unit selector",
 	}, {
 		message:  "command to mixed valid targets",
 		args:     []string{"--machine=0", "--unit=wordpress/0,wordpress/1", "--application=mysql", "sudo reboot"},
@@ -120,6 +149,7 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 			c.Check(cmd.machines, gc.DeepEquals, test.machines)
 			c.Check(cmd.services, gc.DeepEquals, test.services)
 			c.Check(cmd.units, gc.DeepEquals, test.units)
+			c.Check(cmd.matching, gc.DeepEquals, test.matching)
 			c.Check(cmd.commands, gc.Equals, test.commands)
 		}
 	}
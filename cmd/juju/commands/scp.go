@@ -38,6 +38,12 @@ The SSH host keys of the target are verified. The --no-host-key-checks option
 can be used to disable these checks. Use of this option is not recommended as
 it opens up the possibility of a man-in-the-middle attack.
 
+If the machines can only be reached through an SSH bastion host, the
+connection is made through the bastion host configured for the controller
+with "juju set-ssh-proxy-jump", or through the host given with --proxy-jump.
+The target's private address is then used, and its host keys are verified
+as usual.
+
 Examples:
 
 Copy file /var/log/syslog from machine 2 to the client's current working
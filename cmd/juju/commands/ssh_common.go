@@ -22,6 +22,7 @@ import (
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/sshclient"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -30,6 +31,7 @@ import (
 type SSHCommon struct {
 	modelcmd.ModelCommandBase
 	proxy           bool
+	proxyJump       string
 	pty             bool
 	noHostKeyChecks bool
 	Target          string
@@ -86,6 +88,7 @@ var sshHostFromTargetAttemptStrategy attemptStarter = attemptStrategy{
 
 func (c *SSHCommon) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.proxy, "proxy", false, "Proxy through the API server")
+	f.StringVar(&c.proxyJump, "proxy-jump", "", "Connect through the given SSH bastion host, [user@]host[:port]")
 	f.BoolVar(&c.pty, "pty", true, "Enable pseudo-tty allocation")
 	f.BoolVar(&c.noHostKeyChecks, "no-host-key-checks", false, "Skip host key checking (INSECURE)")
 }
@@ -94,12 +97,30 @@ func (c *SSHCommon) SetFlags(f *gnuflag.FlagSet) {
 // if SSH proxying is required. It must be called at the top of the
 // command's Run method.
 //
-// The apiClient, apiAddr and proxy fields are initialized after this
-// call.
+// The apiClient, apiAddr, proxy and proxyJump fields are initialized
+// after this call.
 func (c *SSHCommon) initRun() error {
 	if err := c.ensureAPIClient(); err != nil {
 		return errors.Trace(err)
 	}
+	if c.proxy && c.proxyJump != "" {
+		return errors.New("cannot use --proxy with --proxy-jump")
+	}
+	if c.proxy {
+		// Explicitly proxying through the API server
+		// overrides any configured bastion host.
+		return nil
+	}
+	proxyJump, err := common.ResolveSSHProxyJump(c.ClientStore(), c.ControllerName(), c.proxyJump)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.proxyJump = proxyJump
+	if c.proxyJump != "" {
+		// Connections are made through the bastion host,
+		// rather than through the API server.
+		return nil
+	}
 	if proxy, err := c.proxySSH(); err != nil {
 		return errors.Trace(err)
 	} else {
@@ -161,6 +182,8 @@ func (c *SSHCommon) getSSHOptions(enablePty bool, targets ...*resolvedTarget) (*
 		if err := c.setProxyCommand(&options); err != nil {
 			return nil, err
 		}
+	} else if c.proxyJump != "" {
+		options.SetProxyCommand(common.SSHProxyJumpCommand(c.proxyJump)...)
 	}
 
 	return &options, nil
@@ -290,7 +313,9 @@ func (c *SSHCommon) resolveTarget(target string) (*resolvedTarget, error) {
 	// a loop.
 	var err error
 	for a := sshHostFromTargetAttemptStrategy.Start(); a.Next(); {
-		if c.proxy {
+		if c.proxy || c.proxyJump != "" {
+			// Hosts behind a proxy are reached
+			// using their private addresses.
 			out.host, err = c.apiClient.PrivateAddress(out.entity)
 		} else {
 			out.host, err = c.apiClient.PublicAddress(out.entity)
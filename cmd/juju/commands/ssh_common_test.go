@@ -30,6 +30,10 @@ type argsSpec struct {
 	// expected.
 	withProxy bool
 
+	// proxyJump specifies the bastion host that the SSH ProxyCommand
+	// is expected to connect through, if any.
+	proxyJump string
+
 	// enablePty specifies if the forced PTY allocation switches are
 	// expected.
 	enablePty bool
@@ -69,6 +73,9 @@ func (s *argsSpec) check(c *gc.C, output string) {
 		expect("-o ProxyCommand juju ssh --proxy=false --no-host-key-checks " +
 			"--pty=false ubuntu@localhost -q \"nc %h %p\"")
 	}
+	if s.proxyJump != "" {
+		expect("-o ProxyCommand ssh -q -W %h:%p " + regexp.QuoteMeta(s.proxyJump))
+	}
 	expect("-o PasswordAuthentication no -o ServerAliveInterval 30")
 	if s.enablePty {
 		expect("-t -t")
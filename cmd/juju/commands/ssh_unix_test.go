@@ -127,6 +127,22 @@ var sshTests = []struct {
 			args:            "ubuntu@0.private",
 		},
 	},
+	{
+		about: "connect to unit mysql/0 through a bastion host",
+		args:  []string{"--proxy-jump=jump@bastion", "mysql/0"},
+		expected: argsSpec{
+			hostKeyChecking: "yes",
+			knownHosts:      "0",
+			enablePty:       true,
+			proxyJump:       "jump@bastion",
+			args:            "ubuntu@0.private",
+		},
+	},
+	{
+		about:       "connect with both --proxy and --proxy-jump",
+		args:        []string{"--proxy=true", "--proxy-jump=bastion", "mysql/0"},
+		expectedErr: "cannot use --proxy with --proxy-jump",
+	},
 }
 
 func (s *SSHSuite) TestSSHCommand(c *gc.C) {
@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/jujuclient"
+)
+
+// ResolveSSHProxyJump returns the SSH bastion host through which
+// connections to the named controller's machines should be made.
+// If override is non-empty, it is validated and returned; otherwise
+// the bastion host recorded for the controller in the client store
+// is returned. An empty string is returned if no bastion is needed.
+func ResolveSSHProxyJump(store jujuclient.ControllerGetter, controllerName, override string) (string, error) {
+	if override != "" {
+		if err := jujuclient.ValidateSSHProxyJump(override); err != nil {
+			return "", errors.Trace(err)
+		}
+		return override, nil
+	}
+	details, err := store.ControllerByName(controllerName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return details.SSHProxyJump, nil
+}
+
+// SSHProxyJumpCommand returns the SSH ProxyCommand that connects to the
+// target host through the given bastion host, which must be of the form
+// [user@]host[:port]. The given identity files, if any, are used to
+// authenticate with the bastion host.
+func SSHProxyJumpCommand(jump string, identities ...string) []string {
+	return sshProxyJumpCommand(jump, nil, identities)
+}
+
+// SSHBatchProxyJumpCommand is like SSHProxyJumpCommand, but the
+// returned command runs ssh in batch mode, so that it fails rather
+// than prompting if it cannot log in to the bastion host.
+func SSHBatchProxyJumpCommand(jump string, identities ...string) []string {
+	return sshProxyJumpCommand(jump, []string{"-o", "BatchMode=yes"}, identities)
+}
+
+func sshProxyJumpCommand(jump string, options, identities []string) []string {
+	var user string
+	hostPort := jump
+	if at := strings.LastIndex(jump, "@"); at != -1 {
+		user, hostPort = jump[:at], jump[at+1:]
+	}
+	host, port := hostPort, ""
+	if h, p, err := net.SplitHostPort(hostPort); err == nil {
+		host, port = h, p
+	}
+	if user != "" {
+		host = user + "@" + host
+	}
+	command := append([]string{"ssh", "-q"}, options...)
+	if port != "" {
+		command = append(command, "-p", port)
+	}
+	for _, identity := range identities {
+		command = append(command, "-i", identity)
+	}
+	return append(command, "-W", "%h:%p", host)
+}
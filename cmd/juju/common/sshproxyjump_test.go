@@ -0,0 +1,61 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type sshProxyJumpSuite struct {
+	testing.IsolationSuite
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&sshProxyJumpSuite{})
+
+func (s *sshProxyJumpSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.Controllers["ctrl"] = jujuclient.ControllerDetails{
+		ControllerUUID: "uuid",
+		CACert:         "cert",
+		SSHProxyJump:   "jump@bastion",
+	}
+}
+
+func (s *sshProxyJumpSuite) TestResolveSSHProxyJumpFromStore(c *gc.C) {
+	jump, err := common.ResolveSSHProxyJump(s.store, "ctrl", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(jump, gc.Equals, "jump@bastion")
+}
+
+func (s *sshProxyJumpSuite) TestResolveSSHProxyJumpOverride(c *gc.C) {
+	jump, err := common.ResolveSSHProxyJump(s.store, "ctrl", "other:2222")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(jump, gc.Equals, "other:2222")
+
+	_, err = common.ResolveSSHProxyJump(s.store, "ctrl", "@other")
+	c.Assert(err, gc.ErrorMatches, `ssh-proxy-jump "@other" with empty user not valid`)
+}
+
+func (s *sshProxyJumpSuite) TestSSHProxyJumpCommand(c *gc.C) {
+	c.Assert(common.SSHProxyJumpCommand("bastion"), jc.DeepEquals, []string{
+		"ssh", "-q", "-W", "%h:%p", "bastion",
+	})
+	c.Assert(common.SSHProxyJumpCommand("jump@bastion:2222", "/home/me/.ssh/id_rsa"), jc.DeepEquals, []string{
+		"ssh", "-q", "-p", "2222", "-i", "/home/me/.ssh/id_rsa", "-W", "%h:%p", "jump@bastion",
+	})
+}
+
+func (s *sshProxyJumpSuite) TestSSHBatchProxyJumpCommand(c *gc.C) {
+	c.Assert(common.SSHBatchProxyJumpCommand("jump@bastion:2222", "/home/me/.ssh/id_rsa"), jc.DeepEquals, []string{
+		"ssh", "-q", "-o", "BatchMode=yes", "-p", "2222", "-i", "/home/me/.ssh/id_rsa", "-W", "%h:%p", "jump@bastion",
+	})
+}
@@ -81,6 +81,14 @@ func NewRemoveBlocksCommandForTest(api removeBlocksAPI, store jujuclient.ClientS
 	return modelcmd.WrapController(c)
 }
 
+// NewSetSSHProxyJumpCommandForTest returns a SetSSHProxyJumpCommand
+// that uses the given client store.
+func NewSetSSHProxyJumpCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &setSSHProxyJumpCommand{}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the controller and
 // client endpoints mocked out.
 func NewDestroyCommandForTest(
@@ -8,10 +8,12 @@ import (
 	"strings"
 
 	"github.com/juju/cmd"
+	"github.com/juju/utils/keyvalues"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/errors"
 	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/controller"
 )
@@ -21,23 +23,36 @@ func NewGetConfigCommand() cmd.Command {
 }
 
 // getConfigCommand is able to output either the entire environment or
-// the requested value in a format of the user's choosing.
+// the requested value in a format of the user's choosing, or to set
+// new values for those attributes which may be changed.
 type getConfigCommand struct {
 	modelcmd.ControllerCommandBase
-	api controllerAPI
-	key string
-	out cmd.Output
+	api    controllerAPI
+	key    string
+	values map[string]interface{}
+	out    cmd.Output
 }
 
 const getControllerHelpDoc = `
 By default, all configuration (keys and values) for the controller are
-displayed if a key is not specified.
+displayed if a key is not specified. Supplying one or more key=value
+pairs will set those keys to the supplied values.
+
+Only the following attributes may be changed once the controller has
+been bootstrapped, and changes take effect without restarting the
+controller:
+
+    auditing-enabled
+    identity-url
+    identity-public-key
 
 Examples:
 
     juju get-controller-config
     juju get-controller-config api-port
     juju get-controller-config -c mycontroller
+    juju controller-config auditing-enabled=true
+    juju controller-config identity-url=https://api.jujucharms.com/identity
 
 See also: controllers
 `
@@ -45,7 +60,8 @@ See also: controllers
 func (c *getConfigCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "get-controller-config",
-		Args:    "[<attribute key>]",
+		Aliases: []string{"controller-config"},
+		Args:    "[<attribute key> | <attribute key>=<value> ...]",
 		Purpose: "Displays configuration settings for a controller.",
 		Doc:     strings.TrimSpace(getControllerHelpDoc),
 	}
@@ -56,6 +72,20 @@ func (c *getConfigCommand) SetFlags(f *gnuflag.FlagSet) {
 }
 
 func (c *getConfigCommand) Init(args []string) (err error) {
+	for _, arg := range args {
+		if !strings.Contains(arg, "=") {
+			continue
+		}
+		options, err := keyvalues.Parse(args, true)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.values = make(map[string]interface{})
+		for key, value := range options {
+			c.values[key] = value
+		}
+		return nil
+	}
 	c.key, err = cmd.ZeroOrOneArgs(args)
 	return
 }
@@ -63,6 +93,7 @@ func (c *getConfigCommand) Init(args []string) (err error) {
 type controllerAPI interface {
 	Close() error
 	ControllerConfig() (controller.Config, error)
+	ConfigSet(values map[string]interface{}) error
 }
 
 func (c *getConfigCommand) getAPI() (controllerAPI, error) {
@@ -83,6 +114,10 @@ func (c *getConfigCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if len(c.values) > 0 {
+		return block.ProcessBlockedError(client.ConfigSet(c.values), block.BlockChange)
+	}
+
 	attrs, err := client.ControllerConfig()
 	if err != nil {
 		return err
@@ -41,6 +41,12 @@ func (s *GetConfigSuite) TestInit(c *gc.C) {
 	// More than one is not allowed.
 	err = testing.InitCommand(controller.NewGetConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"one", "two"})
 	c.Check(err, gc.ErrorMatches, `unrecognized args: \["two"\]`)
+	// Any number of key=value pairs is fine.
+	err = testing.InitCommand(controller.NewGetConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"one=1", "two=2"})
+	c.Check(err, jc.ErrorIsNil)
+	// But keys and key=value pairs can't be mixed.
+	err = testing.InitCommand(controller.NewGetConfigCommandForTest(&fakeControllerAPI{}, s.store), []string{"one", "two=2"})
+	c.Check(err, gc.ErrorMatches, `expected "key=value", got "one"`)
 }
 
 func (s *GetConfigSuite) TestSingleValue(c *gc.C) {
@@ -85,8 +91,27 @@ func (s *GetConfigSuite) TestError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "error")
 }
 
+func (s *GetConfigSuite) TestSetValues(c *gc.C) {
+	api := &fakeControllerAPI{}
+	command := controller.NewGetConfigCommandForTest(api, s.store)
+	_, err := testing.RunCommand(c, command, "auditing-enabled=true", "identity-url=https://example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.values, jc.DeepEquals, map[string]interface{}{
+		"auditing-enabled": "true",
+		"identity-url":     "https://example.com",
+	})
+}
+
+func (s *GetConfigSuite) TestSetError(c *gc.C) {
+	api := &fakeControllerAPI{err: errors.New(`cannot change "api-port" after bootstrap`)}
+	command := controller.NewGetConfigCommandForTest(api, s.store)
+	_, err := testing.RunCommand(c, command, "api-port=1234")
+	c.Assert(err, gc.ErrorMatches, `cannot change "api-port" after bootstrap`)
+}
+
 type fakeControllerAPI struct {
-	err error
+	err    error
+	values map[string]interface{}
 }
 
 func (f *fakeControllerAPI) Close() error {
@@ -102,3 +127,8 @@ func (f *fakeControllerAPI) ControllerConfig() (jujucontroller.Config, error) {
 		"api-port":        1234,
 	}, nil
 }
+
+func (f *fakeControllerAPI) ConfigSet(values map[string]interface{}) error {
+	f.values = values
+	return f.err
+}
@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewSetSSHProxyJumpCommand returns a command to record the SSH bastion
+// host through which a controller's machines are reached.
+func NewSetSSHProxyJumpCommand() cmd.Command {
+	return modelcmd.WrapController(&setSSHProxyJumpCommand{})
+}
+
+// setSSHProxyJumpCommand updates the ssh-proxy-jump setting of a
+// controller in the local store.
+type setSSHProxyJumpCommand struct {
+	modelcmd.ControllerCommandBase
+	proxyJump string
+	reset     bool
+}
+
+var usageSetSSHProxyJumpDetails = `
+Records, in the local client store, an SSH bastion host through which
+connections to the controller's machines are made. The setting is used
+by juju ssh, scp and debug-hooks, and when manually provisioning
+machines with juju add-machine ssh:[user@]host. Each of those commands
+accepts --proxy-jump to override the setting for a single invocation.
+
+The bastion host is specified as [user@]host[:port]. Use --reset to
+stop connecting through a bastion host.
+
+Examples:
+
+    juju set-ssh-proxy-jump ubuntu@bastion.example.com
+    juju set-ssh-proxy-jump -c mycontroller bastion.example.com:2222
+    juju set-ssh-proxy-jump --reset
+
+See Also:
+    juju ssh
+    juju scp
+    juju add-machine`
+
+// Info implements Command.Info.
+func (c *setSSHProxyJumpCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-ssh-proxy-jump",
+		Args:    "[<[user@]host[:port]>]",
+		Purpose: "Sets the SSH bastion host used to reach a controller's machines.",
+		Doc:     usageSetSSHProxyJumpDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setSSHProxyJumpCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.reset, "reset", false, "Stop connecting through a bastion host")
+}
+
+// Init implements Command.Init.
+func (c *setSSHProxyJumpCommand) Init(args []string) error {
+	if c.reset {
+		return cmd.CheckEmpty(args)
+	}
+	if len(args) < 1 {
+		return errors.New("bastion host must be specified, or use --reset")
+	}
+	c.proxyJump, args = args[0], args[1:]
+	if err := jujuclient.ValidateSSHProxyJump(c.proxyJump); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *setSSHProxyJumpCommand) Run(ctx *cmd.Context) error {
+	store := c.ClientStore()
+	controllerName := c.ControllerName()
+	details, err := store.ControllerByName(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	details.SSHProxyJump = c.proxyJump
+	if err := store.UpdateController(controllerName, *details); err != nil {
+		return errors.Annotate(err, "updating controller")
+	}
+	if c.proxyJump == "" {
+		ctx.Infof("connections to controller %q will not use a bastion host", controllerName)
+	} else {
+		ctx.Infof("connections to controller %q will be made through %s", controllerName, c.proxyJump)
+	}
+	return nil
+}
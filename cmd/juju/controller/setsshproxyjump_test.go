@@ -0,0 +1,81 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type SetSSHProxyJumpSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&SetSSHProxyJumpSuite{})
+
+func (s *SetSSHProxyJumpSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Controllers["ctrl"] = jujuclient.ControllerDetails{
+		ControllerUUID: "uuid",
+		CACert:         "cert",
+	}
+	s.store.Controllers["other"] = jujuclient.ControllerDetails{
+		ControllerUUID: "other-uuid",
+		CACert:         "cert",
+		SSHProxyJump:   "bastion",
+	}
+}
+
+func (s *SetSSHProxyJumpSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx, err := testing.RunCommand(c, controller.NewSetSSHProxyJumpCommandForTest(s.store), args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stderr(ctx), nil
+}
+
+func (s *SetSSHProxyJumpSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		errorString string
+	}{{
+		errorString: "bastion host must be specified, or use --reset",
+	}, {
+		args:        []string{"@bastion"},
+		errorString: `ssh-proxy-jump "@bastion" with empty user not valid`,
+	}, {
+		args:        []string{"bastion", "extra"},
+		errorString: `unrecognized args: \["extra"\]`,
+	}, {
+		args:        []string{"--reset", "bastion"},
+		errorString: `unrecognized args: \["bastion"\]`,
+	}} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(controller.NewSetSSHProxyJumpCommandForTest(s.store), test.args)
+		c.Check(err, gc.ErrorMatches, test.errorString)
+	}
+}
+
+func (s *SetSSHProxyJumpSuite) TestSetCurrentController(c *gc.C) {
+	out, err := s.run(c, "jump@bastion:2222")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "connections to controller \"ctrl\" will be made through jump@bastion:2222\n")
+	c.Assert(s.store.Controllers["ctrl"].SSHProxyJump, gc.Equals, "jump@bastion:2222")
+	c.Assert(s.store.Controllers["other"].SSHProxyJump, gc.Equals, "bastion")
+}
+
+func (s *SetSSHProxyJumpSuite) TestResetNamedController(c *gc.C) {
+	out, err := s.run(c, "-c", "other", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "connections to controller \"other\" will not use a bastion host\n")
+	c.Assert(s.store.Controllers["other"].SSHProxyJump, gc.Equals, "")
+}
@@ -0,0 +1,15 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hookhistory
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewShowCommandForTest returns a showCommand with the api provided as specified.
+func NewShowCommandForTest(api ShowHookHistoryAPI) cmd.Command {
+	return modelcmd.Wrap(&showCommand{api: api})
+}
@@ -0,0 +1,193 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hookhistory provides the command used to inspect the hooks
+// recently run by a unit.
+package hookhistory
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/hookhistory"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const showCommandDoc = `
+Shows the hooks most recently run by a unit, newest first: when each
+hook ran, how long it took, its exit code and, in the yaml and json
+formats, the last lines it wrote.
+
+Hook history is kept alongside status history on the controller and
+is pruned in the same way, so older hooks may no longer be shown.
+
+Examples:
+    juju show-hook-history mysql/0
+    juju show-hook-history mysql/0 -n 5 --format yaml
+
+See also:
+    show-status-log
+`
+
+// NewShowCommand returns a command that shows a unit's hook history.
+func NewShowCommand() cmd.Command {
+	return modelcmd.Wrap(&showCommand{})
+}
+
+// ShowHookHistoryAPI defines the API methods that the
+// show-hook-history command uses.
+type ShowHookHistoryAPI interface {
+	HookHistory(unit names.UnitTag, size int) ([]params.HookRecord, error)
+	Close() error
+}
+
+// showCommand shows the hooks recently run by a unit.
+type showCommand struct {
+	modelcmd.ModelCommandBase
+	out  cmd.Output
+	api  ShowHookHistoryAPI
+	unit names.UnitTag
+	size int
+}
+
+// Info implements Command.Info.
+func (c *showCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-hook-history",
+		Args:    "<unit>",
+		Purpose: "Shows the hooks recently run by a unit.",
+		Doc:     showCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatHookHistoryTabular,
+	})
+	f.IntVar(&c.size, "n", 20, "Show at most the last N hooks (0 for all)")
+}
+
+// Init implements Command.Init.
+func (c *showCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit specified")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return errors.Errorf("invalid unit name %q", args[0])
+	}
+	if c.size < 0 {
+		return errors.Errorf("invalid number of hooks %d", c.size)
+	}
+	c.unit = names.NewUnitTag(args[0])
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *showCommand) getAPI() (ShowHookHistoryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *showCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	records, err := client.HookHistory(c.unit, c.size)
+	if err != nil {
+		return err
+	}
+	info := make([]hookInfo, len(records))
+	for i, record := range records {
+		info[i] = convertHookRecord(record)
+	}
+	return c.out.Write(ctx, info)
+}
+
+// hookInfo is the serialisation format of a hook execution.
+type hookInfo struct {
+	Hook       string    `yaml:"hook" json:"hook"`
+	RelationId *int      `yaml:"relation-id,omitempty" json:"relation-id,omitempty"`
+	RemoteUnit string    `yaml:"remote-unit,omitempty" json:"remote-unit,omitempty"`
+	Started    time.Time `yaml:"started" json:"started"`
+	Duration   string    `yaml:"duration" json:"duration"`
+	ExitCode   int       `yaml:"exit-code" json:"exit-code"`
+	Error      string    `yaml:"error,omitempty" json:"error,omitempty"`
+	Output     []string  `yaml:"output,omitempty" json:"output,omitempty"`
+}
+
+func convertHookRecord(record params.HookRecord) hookInfo {
+	info := hookInfo{
+		Hook:       record.Hook,
+		RemoteUnit: record.RemoteUnit,
+		Started:    record.Started,
+		Duration:   record.Finished.Sub(record.Started).String(),
+		ExitCode:   record.ExitCode,
+		Error:      record.Error,
+		Output:     record.Output,
+	}
+	if record.RelationId >= 0 {
+		relationId := record.RelationId
+		info.RelationId = &relationId
+	}
+	return info
+}
+
+func formatHookHistoryTabular(value interface{}) ([]byte, error) {
+	hooks, ok := value.([]hookInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", hooks, value)
+	}
+	if len(hooks) == 0 {
+		return []byte("No hooks to display."), nil
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "STARTED\tHOOK\tRELATION\tREMOTE UNIT\tDURATION\tEXIT CODE\n")
+	for _, h := range hooks {
+		relation := ""
+		if h.RelationId != nil {
+			relation = strconv.Itoa(*h.RelationId)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\n",
+			h.Started.UTC().Format(time.RFC3339),
+			h.Hook, relation, h.RemoteUnit, h.Duration, h.ExitCode,
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
+
+// newClient returns a client for the HookHistory API facade.
+func newClient(root base.APICallCloser) *hookhistory.Client {
+	return hookhistory.NewClient(root)
+}
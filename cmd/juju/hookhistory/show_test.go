@@ -0,0 +1,126 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hookhistory_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/hookhistory"
+	"github.com/juju/juju/testing"
+)
+
+type ShowSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeHookHistoryAPI
+}
+
+var _ = gc.Suite(&ShowSuite{})
+
+func (s *ShowSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	started := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	s.fake = &fakeHookHistoryAPI{
+		records: []params.HookRecord{{
+			Hook:       "db-relation-changed",
+			RelationId: 3,
+			RemoteUnit: "mysql/1",
+			Started:    started.Add(time.Minute),
+			Finished:   started.Add(time.Minute + 2*time.Second),
+			ExitCode:   1,
+			Error:      "exit status 1",
+			Output:     []string{"cannot connect"},
+		}, {
+			Hook:       "install",
+			RelationId: -1,
+			Started:    started,
+			Finished:   started.Add(30 * time.Second),
+		}},
+	}
+}
+
+func (s *ShowSuite) TestInitNoUnit(c *gc.C) {
+	_, err := testing.RunCommand(c, hookhistory.NewShowCommandForTest(s.fake))
+	c.Assert(err, gc.ErrorMatches, "no unit specified")
+}
+
+func (s *ShowSuite) TestInitInvalidUnit(c *gc.C) {
+	_, err := testing.RunCommand(c, hookhistory.NewShowCommandForTest(s.fake), "mysql")
+	c.Assert(err, gc.ErrorMatches, `invalid unit name "mysql"`)
+}
+
+func (s *ShowSuite) TestInitInvalidSize(c *gc.C) {
+	_, err := testing.RunCommand(c, hookhistory.NewShowCommandForTest(s.fake), "wordpress/0", "-n", "-1")
+	c.Assert(err, gc.ErrorMatches, "invalid number of hooks -1")
+}
+
+func (s *ShowSuite) TestInitTooManyArgs(c *gc.C) {
+	_, err := testing.RunCommand(c, hookhistory.NewShowCommandForTest(s.fake), "wordpress/0", "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ShowSuite) TestShowTabular(c *gc.C) {
+	ctx, err := testing.RunCommand(c, hookhistory.NewShowCommandForTest(s.fake), "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.unit, gc.Equals, names.NewUnitTag("wordpress/0"))
+	c.Assert(s.fake.size, gc.Equals, 20)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"STARTED               HOOK                 RELATION  REMOTE UNIT  DURATION  EXIT CODE\n"+
+		"2016-08-01T10:01:00Z  db-relation-changed  3         mysql/1      2s        1\n"+
+		"2016-08-01T10:00:00Z  install                                     30s       0\n",
+	)
+	c.Assert(s.fake.closed, jc.IsTrue)
+}
+
+func (s *ShowSuite) TestShowYAML(c *gc.C) {
+	ctx, err := testing.RunCommand(c, hookhistory.NewShowCommandForTest(s.fake), "wordpress/0", "-n", "5", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.size, gc.Equals, 5)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"- hook: db-relation-changed\n"+
+		"  relation-id: 3\n"+
+		"  remote-unit: mysql/1\n"+
+		"  started: 2016-08-01T10:01:00Z\n"+
+		"  duration: 2s\n"+
+		"  exit-code: 1\n"+
+		"  error: exit status 1\n"+
+		"  output:\n"+
+		"  - cannot connect\n"+
+		"- hook: install\n"+
+		"  started: 2016-08-01T10:00:00Z\n"+
+		"  duration: 30s\n"+
+		"  exit-code: 0\n",
+	)
+}
+
+func (s *ShowSuite) TestShowEmpty(c *gc.C) {
+	s.fake.records = nil
+	ctx, err := testing.RunCommand(c, hookhistory.NewShowCommandForTest(s.fake), "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "No hooks to display.\n")
+}
+
+type fakeHookHistoryAPI struct {
+	records []params.HookRecord
+	err     error
+	closed  bool
+
+	unit names.UnitTag
+	size int
+}
+
+func (f *fakeHookHistoryAPI) HookHistory(unit names.UnitTag, size int) ([]params.HookRecord, error) {
+	f.unit = unit
+	f.size = size
+	return f.records, f.err
+}
+
+func (f *fakeHookHistoryAPI) Close() error {
+	f.closed = true
+	return nil
+}
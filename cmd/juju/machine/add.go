@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/utils/ssh"
 	"gopkg.in/juju/names.v2"
 	"launchpad.net/gnuflag"
 
@@ -51,6 +52,29 @@ and bringing it under Juju's management; currently this requires that the
 machine be running Ubuntu, that it be accessible via SSH, and be running on
 the same network as the API server.
 
+Many existing machines may be provisioned at once by listing them in an
+inventory file, passed with "--inventory". Hosts are provisioned in parallel,
+up to the limit given by "--parallel", and failed attempts are retried. Hosts
+that are already provisioned are skipped, so the same inventory may be used
+again after adding hosts to it, or after fixing failed hosts. The inventory
+is a YAML file listing each host, with optional SSH settings that may also be
+given as defaults for all hosts:
+
+    defaults:
+      user: admin                 # user to log in as, to set up the ubuntu user
+      key: ~/.ssh/id_rsa          # SSH private key to log in with
+      port: 22                    # port the SSH server listens on
+      bastion: jump@10.10.0.1     # [user@]host to reach the host through
+    hosts:
+      - host: 10.10.0.3
+      - host: 10.10.0.4
+        port: 2222
+
+Because hosts are provisioned concurrently, there is no prompting for
+passwords; each host, and its bastion, must accept the given key, and its
+user must be able to use sudo without a password. A host that would need
+a password fails rather than prompting.
+
 It is possible to override or augment constraints by passing provider-specific
 "placement directives" as an argument; these give the provider additional
 information about how to allocate the machine. For example, one can direct the
@@ -65,6 +89,7 @@ Examples:
    juju add-machine lxd:4                (starts a new lxd container on machine 4)
    juju add-machine --constraints mem=8G (starts a machine with at least 8GB RAM)
    juju add-machine ssh:user@10.10.0.3   (manually provisions a machine with ssh)
+   juju add-machine --inventory hosts.yaml (manually provisions the listed machines)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
 
@@ -106,12 +131,20 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// Inventory is the path to a file listing existing hosts to provision.
+	Inventory string
+	// Parallel is the number of hosts from the inventory to provision at once.
+	Parallel int
+	// ProxyJump is the SSH bastion host through which manually
+	// provisioned hosts are reached, overriding the controller's
+	// ssh-proxy-jump setting.
+	ProxyJump string
 }
 
 func (c *addCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "add-machine",
-		Args:    "[<container>:machine | <container> | ssh:[user@]host | placement]",
+		Args:    "[<container>:machine | <container> | ssh:[user@]host | placement | --inventory <file>]",
 		Purpose: "Start a new, empty machine and optionally a container, or add a container to a machine.",
 		Doc:     addMachineDoc,
 		Aliases: []string{"add-machines"},
@@ -123,6 +156,9 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.Var(constraints.ConstraintsValue{Target: &c.Constraints}, "constraints", "Additional machine constraints")
 	f.Var(disksFlag{&c.Disks}, "disks", "Constraints for disks to attach to the machine")
+	f.StringVar(&c.Inventory, "inventory", "", "Path to a YAML file listing existing hosts to provision")
+	f.IntVar(&c.Parallel, "parallel", defaultInventoryParallel, "The number of hosts from the inventory to provision at once")
+	f.StringVar(&c.ProxyJump, "proxy-jump", "", "Connect to manually provisioned hosts through the given SSH bastion host, [user@]host[:port]")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -133,6 +169,9 @@ func (c *addCommand) Init(args []string) error {
 	if err != nil {
 		return err
 	}
+	if c.Inventory != "" {
+		return c.validateInventoryArgs(placement)
+	}
 	c.Placement, err = instance.ParsePlacement(placement)
 	if err == instance.ErrPlacementScopeMissing {
 		placement = "model-uuid" + ":" + placement
@@ -147,6 +186,22 @@ func (c *addCommand) Init(args []string) error {
 	return nil
 }
 
+func (c *addCommand) validateInventoryArgs(placement string) error {
+	if placement != "" {
+		return errors.New("cannot use --inventory when specifying a placement directive")
+	}
+	if c.NumMachines != 1 {
+		return errors.New("cannot use -n with --inventory")
+	}
+	if c.Series != "" || !constraints.IsEmpty(&c.Constraints) || len(c.Disks) > 0 {
+		return errors.New("cannot use --series, --constraints or --disks with --inventory")
+	}
+	if c.Parallel < 1 {
+		return errors.Errorf("--parallel must be at least 1, got %d", c.Parallel)
+	}
+	return nil
+}
+
 type AddMachineAPI interface {
 	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
 	Close() error
@@ -236,14 +291,13 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	if c.Placement != nil && c.Placement.Scope == "ssh" {
+	if c.Inventory != "" || c.Placement != nil && c.Placement.Scope == "ssh" {
 		logger.Infof("manual provisioning")
 		authKeys, err := common.ReadAuthorizedKeys(ctx, "")
 		if err != nil {
 			return errors.Annotate(err, "reading authorized-keys")
 		}
 		args := manual.ProvisionMachineArgs{
-			Host:           c.Placement.Directive,
 			Client:         client,
 			Stdin:          ctx.Stdin,
 			Stdout:         ctx.Stdout,
@@ -254,6 +308,19 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 				config.EnableOSUpgrade(),
 			},
 		}
+		proxyJump, err := common.ResolveSSHProxyJump(c.ClientStore(), c.ControllerName(), c.ProxyJump)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if c.Inventory != "" {
+			return c.runInventory(ctx, args, proxyJump)
+		}
+		args.Host = c.Placement.Directive
+		if proxyJump != "" {
+			var options ssh.Options
+			options.SetProxyCommand(common.SSHProxyJumpCommand(proxyJump)...)
+			args.SSHOptions = &options
+		}
 		machineId, err := manualProvisioner(args)
 		if err == nil {
 			ctx.Infof("created machine %v", machineId)
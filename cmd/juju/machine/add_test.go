@@ -4,8 +4,13 @@
 package machine_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -16,6 +21,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
@@ -26,6 +33,7 @@ type AddMachineSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
 	fakeAddMachine     *fakeAddMachineAPI
 	fakeMachineManager *fakeMachineManagerAPI
+	store              *jujuclienttesting.MemStore
 }
 
 var _ = gc.Suite(&AddMachineSuite{})
@@ -34,6 +42,12 @@ func (s *AddMachineSuite) SetUpTest(c *gc.C) {
 	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
 	s.fakeAddMachine = &fakeAddMachineAPI{}
 	s.fakeMachineManager = &fakeMachineManagerAPI{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin@local",
+	}
 }
 
 func (s *AddMachineSuite) TestInit(c *gc.C) {
@@ -95,7 +109,7 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 		},
 	} {
 		c.Logf("test %d", i)
-		wrappedCommand, addCmd := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager)
+		wrappedCommand, addCmd := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager, s.store)
 		err := testing.InitCommand(wrappedCommand, test.args)
 		if test.errorString == "" {
 			c.Check(err, jc.ErrorIsNil)
@@ -114,7 +128,7 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 }
 
 func (s *AddMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
-	add, _ := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager)
+	add, _ := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager, s.store)
 	return testing.RunCommand(c, add, args...)
 }
 
@@ -139,6 +153,29 @@ func (s *AddMachineSuite) TestSSHPlacement(c *gc.C) {
 	c.Assert(testing.Stderr(context), gc.Equals, "created machine 42\n")
 }
 
+func (s *AddMachineSuite) TestSSHPlacementProxyJump(c *gc.C) {
+	var withOptions []bool
+	s.PatchValue(machine.ManualProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		withOptions = append(withOptions, args.SSHOptions != nil)
+		return "42", nil
+	})
+	_, err := s.run(c, "ssh:10.1.2.3")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.run(c, "--proxy-jump", "jump@bastion:2222", "ssh:10.1.2.3")
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{
+		SSHProxyJump: "jump@bastion",
+	}
+	_, err = s.run(c, "ssh:10.1.2.3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(withOptions, jc.DeepEquals, []bool{false, true, true})
+}
+
+func (s *AddMachineSuite) TestSSHPlacementInvalidProxyJump(c *gc.C) {
+	_, err := s.run(c, "--proxy-jump", "@bastion", "ssh:10.1.2.3")
+	c.Assert(err, gc.ErrorMatches, `ssh-proxy-jump "@bastion" with empty user not valid`)
+}
+
 func (s *AddMachineSuite) TestSSHPlacementError(c *gc.C) {
 	s.PatchValue(machine.ManualProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
 		return "", errors.New("failed to initialize warp core")
@@ -148,6 +185,112 @@ func (s *AddMachineSuite) TestSSHPlacementError(c *gc.C) {
 	c.Assert(testing.Stderr(context), gc.Equals, "")
 }
 
+func (s *AddMachineSuite) TestInventoryInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		errorString string
+	}{{
+		args:        []string{"--inventory", "hosts.yaml", "ssh:10.1.2.3"},
+		errorString: "cannot use --inventory when specifying a placement directive",
+	}, {
+		args:        []string{"--inventory", "hosts.yaml", "-n", "2"},
+		errorString: "cannot use -n with --inventory",
+	}, {
+		args:        []string{"--inventory", "hosts.yaml", "--constraints", "mem=8G"},
+		errorString: "cannot use --series, --constraints or --disks with --inventory",
+	}, {
+		args:        []string{"--inventory", "hosts.yaml", "--parallel", "0"},
+		errorString: "--parallel must be at least 1, got 0",
+	}} {
+		c.Logf("test %d", i)
+		wrappedCommand, _ := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager, s.store)
+		err := testing.InitCommand(wrappedCommand, test.args)
+		c.Check(err, gc.ErrorMatches, test.errorString)
+	}
+}
+
+func (s *AddMachineSuite) writeInventory(c *gc.C, content string) string {
+	path := filepath.Join(c.MkDir(), "hosts.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *AddMachineSuite) TestInventory(c *gc.C) {
+	s.PatchValue(machine.InventoryRetryDelay, time.Duration(0))
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+	s.PatchValue(machine.ManualProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		c.Check(args.SSHOptions, gc.NotNil)
+		c.Check(args.BatchMode, jc.IsTrue)
+		attempts[args.Host]++
+		switch args.Host {
+		case "admin@10.0.0.1":
+			return "1", nil
+		case "admin@10.0.0.2":
+			return "", manual.ErrProvisioned
+		case "ubuntu@10.0.0.3":
+			if attempts[args.Host] < 2 {
+				return "", errors.New("connection reset")
+			}
+			return "3", nil
+		}
+		return "", errors.New("no route to host")
+	})
+	path := s.writeInventory(c, `
+defaults:
+  user: admin
+  key: ~/.ssh/id_rsa
+hosts:
+  - host: 10.0.0.1
+  - host: 10.0.0.2
+  - host: 10.0.0.3
+    user: ubuntu
+    port: 2222
+  - host: 10.0.0.4
+    bastion: jump.example.com
+`)
+	context, err := s.run(c, "--inventory", path, "--parallel", "2")
+	c.Assert(err, gc.ErrorMatches, "failed to add 1 of 4 hosts")
+	c.Assert(testing.Stdout(context), gc.Equals, `
+HOST      MACHINE  STATUS   MESSAGE
+10.0.0.1  1        added    
+10.0.0.2           skipped  machine is already provisioned
+10.0.0.3  3        added    
+10.0.0.4           failed   no route to host
+`[1:])
+	c.Assert(attempts, jc.DeepEquals, map[string]int{
+		"admin@10.0.0.1":  1,
+		"admin@10.0.0.2":  1,
+		"ubuntu@10.0.0.3": 2,
+		"admin@10.0.0.4":  3,
+	})
+}
+
+func (s *AddMachineSuite) TestInventoryInvalid(c *gc.C) {
+	path := s.writeInventory(c, `
+hosts:
+  - host: 10.0.0.1
+  - host: 10.0.0.1
+`)
+	_, err := s.run(c, "--inventory", path)
+	c.Assert(err, gc.ErrorMatches, `inventory host "10.0.0.1" specified more than once`)
+}
+
+func (s *AddMachineSuite) TestInventorySSHOptionsRejected(c *gc.C) {
+	for inventory, message := range map[string]string{
+		"hosts:\n  - host: -oProxyCommand=true\n":                        `inventory host "-oProxyCommand=true": host must not start with "-"`,
+		"hosts:\n  - host: 10.0.0.1\n    user: -oProxyCommand=true\n":    `inventory host "10.0.0.1": user "-oProxyCommand=true" must not start with "-"`,
+		"hosts:\n  - host: 10.0.0.1\n    bastion: -oProxyCommand=true\n": `inventory host "10.0.0.1": ssh-proxy-jump "-oProxyCommand=true" starting with "-" not valid`,
+	} {
+		path := s.writeInventory(c, inventory)
+		_, err := s.run(c, "--inventory", path)
+		c.Check(err, gc.ErrorMatches, regexp.QuoteMeta(message))
+	}
+}
+
 func (s *AddMachineSuite) TestParamsPassedOn(c *gc.C) {
 	_, err := s.run(c, "--constraints", "mem=8G", "--series=special", "zone=nz")
 	c.Assert(err, jc.ErrorIsNil)
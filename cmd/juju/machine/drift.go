@@ -0,0 +1,201 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const showDriftCommandDoc = `
+Shows differences between the machines recorded in the model and
+the instances found in its cloud.
+
+Vanished machines are those whose instances can no longer be found in
+the cloud, for example because they were terminated outside of Juju.
+Such machines may be removed with "juju remove-machine --force".
+
+Leaked instances are those tagged as belonging to the model, but
+not known to any of its machines. Leaked instances are destroyed by
+the provisioner if the model's "provisioner-harvest-mode" configuration
+is "unknown" or "all"; otherwise they must be removed using the cloud's
+own tools.
+
+Instances are checked periodically, so recent changes may not yet be
+reflected.
+
+Examples:
+    juju show-drift
+    juju show-drift --format yaml
+
+See also:
+    remove-machine
+    model-config
+`
+
+// NewShowDriftCommand returns a command that shows drift between the
+// model's machines and its cloud's instances.
+func NewShowDriftCommand() cmd.Command {
+	return modelcmd.Wrap(&showDriftCommand{})
+}
+
+// ModelDriftAPI defines the API methods that the show-drift command uses.
+type ModelDriftAPI interface {
+	ModelDrift() (params.ModelDrift, error)
+	Close() error
+}
+
+// showDriftCommand shows vanished machines and leaked instances.
+type showDriftCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api ModelDriftAPI
+}
+
+// Info implements Command.Info.
+func (c *showDriftCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-drift",
+		Purpose: "Shows vanished machines and leaked instances.",
+		Doc:     showDriftCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showDriftCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatDriftTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showDriftCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *showDriftCommand) getAPI() (ModelDriftAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *showDriftCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	drift, err := client.ModelDrift()
+	if err != nil {
+		return err
+	}
+	info, err := convertModelDrift(drift)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, info)
+}
+
+// driftInfo is the serialisation format of the show-drift command.
+type driftInfo struct {
+	Vanished []vanishedMachineInfo `yaml:"vanished" json:"vanished"`
+	Leaked   []leakedInstanceInfo  `yaml:"leaked" json:"leaked"`
+}
+
+type vanishedMachineInfo struct {
+	Machine    string     `yaml:"machine" json:"machine"`
+	InstanceId string     `yaml:"instance-id" json:"instance-id"`
+	Since      *time.Time `yaml:"since,omitempty" json:"since,omitempty"`
+}
+
+type leakedInstanceInfo struct {
+	InstanceId string    `yaml:"instance-id" json:"instance-id"`
+	FirstSeen  time.Time `yaml:"first-seen" json:"first-seen"`
+}
+
+func convertModelDrift(drift params.ModelDrift) (driftInfo, error) {
+	info := driftInfo{
+		Vanished: []vanishedMachineInfo{},
+		Leaked:   []leakedInstanceInfo{},
+	}
+	for _, v := range drift.Vanished {
+		tag, err := names.ParseMachineTag(v.Tag)
+		if err != nil {
+			return driftInfo{}, errors.Trace(err)
+		}
+		info.Vanished = append(info.Vanished, vanishedMachineInfo{
+			Machine:    tag.Id(),
+			InstanceId: string(v.InstanceId),
+			Since:      v.Since,
+		})
+	}
+	for _, l := range drift.Leaked {
+		info.Leaked = append(info.Leaked, leakedInstanceInfo{
+			InstanceId: string(l.InstanceId),
+			FirstSeen:  l.FirstSeen,
+		})
+	}
+	return info, nil
+}
+
+func formatDriftTabular(value interface{}) ([]byte, error) {
+	info, ok := value.(driftInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", info, value)
+	}
+	if len(info.Vanished) == 0 && len(info.Leaked) == 0 {
+		return []byte("No drift detected."), nil
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	if len(info.Vanished) > 0 {
+		fmt.Fprintf(tw, "MACHINE\tINSTANCE ID\tVANISHED SINCE\n")
+		for _, v := range info.Vanished {
+			since := ""
+			if v.Since != nil {
+				since = v.Since.Local().Format(time.RFC3339)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", v.Machine, v.InstanceId, since)
+		}
+	}
+	if len(info.Leaked) > 0 {
+		if len(info.Vanished) > 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprintf(tw, "LEAKED INSTANCE ID\tFIRST SEEN\n")
+		for _, l := range info.Leaked {
+			fmt.Fprintf(tw, "%s\t%s\n", l.InstanceId, l.FirstSeen.Local().Format(time.RFC3339))
+		}
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
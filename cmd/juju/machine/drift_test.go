@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"errors"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type ShowDriftSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeModelDriftAPI
+}
+
+var _ = gc.Suite(&ShowDriftSuite{})
+
+func (s *ShowDriftSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	since := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	s.fake = &fakeModelDriftAPI{
+		drift: params.ModelDrift{
+			Vanished: []params.VanishedMachine{{
+				Tag:        "machine-1",
+				InstanceId: "i-1",
+				Since:      &since,
+			}},
+			Leaked: []params.LeakedInstance{{
+				InstanceId: "i-leaked",
+				FirstSeen:  since,
+			}},
+		},
+	}
+}
+
+func (s *ShowDriftSuite) TestInitRejectsArgs(c *gc.C) {
+	_, err := testing.RunCommand(c, machine.NewShowDriftCommandForTest(s.fake), "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ShowDriftSuite) TestShowDriftYaml(c *gc.C) {
+	ctx, err := testing.RunCommand(c, machine.NewShowDriftCommandForTest(s.fake), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"vanished:\n"+
+		"- machine: \"1\"\n"+
+		"  instance-id: i-1\n"+
+		"  since: 2016-08-01T10:00:00Z\n"+
+		"leaked:\n"+
+		"- instance-id: i-leaked\n"+
+		"  first-seen: 2016-08-01T10:00:00Z\n",
+	)
+	c.Assert(s.fake.closed, jc.IsTrue)
+}
+
+func (s *ShowDriftSuite) TestShowDriftTabularNoDrift(c *gc.C) {
+	s.fake.drift = params.ModelDrift{}
+	ctx, err := testing.RunCommand(c, machine.NewShowDriftCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "No drift detected.\n")
+}
+
+func (s *ShowDriftSuite) TestShowDriftError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := testing.RunCommand(c, machine.NewShowDriftCommandForTest(s.fake))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeModelDriftAPI struct {
+	drift  params.ModelDrift
+	err    error
+	closed bool
+}
+
+func (f *fakeModelDriftAPI) ModelDrift() (params.ModelDrift, error) {
+	return f.drift, f.err
+}
+
+func (f *fakeModelDriftAPI) Close() error {
+	f.closed = true
+	return nil
+}
@@ -7,11 +7,13 @@ import (
 	"github.com/juju/cmd"
 
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/storage"
 )
 
 var (
-	ManualProvisioner = &manualProvisioner
+	ManualProvisioner   = &manualProvisioner
+	InventoryRetryDelay = &inventoryRetryDelay
 )
 
 type AddCommand struct {
@@ -19,12 +21,13 @@ type AddCommand struct {
 }
 
 // NewAddCommand returns an AddCommand with the api provided as specified.
-func NewAddCommandForTest(api AddMachineAPI, mcApi ModelConfigAPI, mmApi MachineManagerAPI) (cmd.Command, *AddCommand) {
+func NewAddCommandForTest(api AddMachineAPI, mcApi ModelConfigAPI, mmApi MachineManagerAPI, store jujuclient.ClientStore) (cmd.Command, *AddCommand) {
 	cmd := &addCommand{
 		api:               api,
 		machineManagerAPI: mmApi,
 		modelConfigAPI:    mcApi,
 	}
+	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd), &AddCommand{cmd}
 }
 
@@ -55,3 +58,8 @@ func NewRemoveCommandForTest(api RemoveMachineAPI) (cmd.Command, *RemoveCommand)
 func NewDisksFlag(disks *[]storage.Constraints) *disksFlag {
 	return &disksFlag{disks}
 }
+
+// NewShowDriftCommandForTest returns a showDriftCommand with the api provided as specified.
+func NewShowDriftCommandForTest(api ModelDriftAPI) cmd.Command {
+	return modelcmd.Wrap(&showDriftCommand{api: api})
+}
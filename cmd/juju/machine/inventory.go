@@ -0,0 +1,285 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/ssh"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/jujuclient"
+)
+
+var (
+	// inventoryAttempts is the number of times that enlisting
+	// a host from an inventory is attempted before giving up.
+	inventoryAttempts = 3
+
+	// inventoryRetryDelay is the time to wait between attempts
+	// to enlist a host from an inventory.
+	inventoryRetryDelay = 10 * time.Second
+)
+
+// defaultInventoryParallel is the default number of hosts
+// from an inventory that are enlisted concurrently.
+const defaultInventoryParallel = 4
+
+// inventory describes the existing hosts to be enlisted by
+// "juju add-machine --inventory".
+type inventory struct {
+	// Defaults holds SSH options that apply to every host,
+	// unless overridden by the host.
+	Defaults inventoryHost `yaml:"defaults,omitempty"`
+
+	// Hosts holds the hosts to enlist.
+	Hosts []inventoryHost `yaml:"hosts"`
+}
+
+// inventoryHost describes a host to be enlisted, and how to reach it.
+type inventoryHost struct {
+	// Host is the address or hostname of the host.
+	Host string `yaml:"host,omitempty"`
+
+	// User is the user to log in as, if the ubuntu user
+	// has not already been set up on the host.
+	User string `yaml:"user,omitempty"`
+
+	// Key is the path to the SSH private key used to log in.
+	Key string `yaml:"key,omitempty"`
+
+	// Port is the port that the host's SSH server listens on.
+	Port int `yaml:"port,omitempty"`
+
+	// Bastion is the [user@]host[:port] of a bastion host
+	// through which the host must be reached.
+	Bastion string `yaml:"bastion,omitempty"`
+}
+
+// readInventory reads and validates the inventory file at the given path,
+// applying the inventory's defaults to each of its hosts.
+func readInventory(path string) ([]inventoryHost, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading inventory")
+	}
+	return parseInventory(data)
+}
+
+func parseInventory(data []byte) ([]inventoryHost, error) {
+	var inv inventory
+	if err := yaml.Unmarshal(data, &inv); err != nil {
+		return nil, errors.Annotate(err, "parsing inventory")
+	}
+	if inv.Defaults.Host != "" {
+		return nil, errors.New("inventory defaults cannot specify a host")
+	}
+	if len(inv.Hosts) == 0 {
+		return nil, errors.New("inventory specifies no hosts")
+	}
+	seen := make(map[string]bool)
+	hosts := make([]inventoryHost, len(inv.Hosts))
+	for i, h := range inv.Hosts {
+		if h.Host == "" {
+			return nil, errors.Errorf("inventory host %d: host not specified", i)
+		}
+		if strings.Contains(h.Host, "@") {
+			return nil, errors.Errorf("inventory host %q: specify the user with %q", h.Host, "user")
+		}
+		if strings.HasPrefix(h.Host, "-") {
+			return nil, errors.Errorf("inventory host %q: host must not start with %q", h.Host, "-")
+		}
+		if seen[h.Host] {
+			return nil, errors.Errorf("inventory host %q specified more than once", h.Host)
+		}
+		seen[h.Host] = true
+		if h.User == "" {
+			h.User = inv.Defaults.User
+		}
+		if h.Key == "" {
+			h.Key = inv.Defaults.Key
+		}
+		if h.Port == 0 {
+			h.Port = inv.Defaults.Port
+		}
+		if h.Bastion == "" {
+			h.Bastion = inv.Defaults.Bastion
+		}
+		if strings.HasPrefix(h.User, "-") {
+			return nil, errors.Errorf("inventory host %q: user %q must not start with %q", h.Host, h.User, "-")
+		}
+		if h.Port < 0 || h.Port > 65535 {
+			return nil, errors.Errorf("inventory host %q: invalid port %d", h.Host, h.Port)
+		}
+		if h.Bastion != "" {
+			if err := jujuclient.ValidateSSHProxyJump(h.Bastion); err != nil {
+				return nil, errors.Annotatef(err, "inventory host %q", h.Host)
+			}
+		}
+		hosts[i] = h
+	}
+	return hosts, nil
+}
+
+// sshHost returns the [user@]host used to log in to the host.
+func (h inventoryHost) sshHost() string {
+	if h.User == "" {
+		return h.Host
+	}
+	return h.User + "@" + h.Host
+}
+
+// sshOptions returns the SSH options used to connect to the host.
+func (h inventoryHost) sshOptions() (*ssh.Options, error) {
+	var options ssh.Options
+	if h.Port != 0 {
+		options.SetPort(h.Port)
+	}
+	var key string
+	if h.Key != "" {
+		var err error
+		key, err = utils.NormalizePath(h.Key)
+		if err != nil {
+			return nil, errors.Annotatef(err, "normalizing key path %q", h.Key)
+		}
+		options.SetIdentities(key)
+	}
+	if h.Bastion != "" {
+		var identities []string
+		if key != "" {
+			identities = append(identities, key)
+		}
+		options.SetProxyCommand(common.SSHBatchProxyJumpCommand(h.Bastion, identities...)...)
+	}
+	return &options, nil
+}
+
+const (
+	enlistAdded   = "added"
+	enlistSkipped = "skipped"
+	enlistFailed  = "failed"
+)
+
+// enlistResult records the outcome of enlisting a host from an inventory.
+type enlistResult struct {
+	Host    string
+	Machine string
+	Status  string
+	Message string
+}
+
+// runInventory enlists all of the hosts in the inventory, running up to
+// c.Parallel provisioning operations at once, and writes a table of the
+// results to the context's stdout. Hosts that do not specify a bastion
+// are reached through proxyJump, if it is non-empty.
+func (c *addCommand) runInventory(ctx *cmd.Context, args manual.ProvisionMachineArgs, proxyJump string) error {
+	hosts, err := readInventory(ctx.AbsPath(c.Inventory))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for i := range hosts {
+		if hosts[i].Bastion == "" {
+			hosts[i].Bastion = proxyJump
+		}
+	}
+
+	results := make([]enlistResult, len(hosts))
+	sem := make(chan struct{}, c.Parallel)
+	var wg sync.WaitGroup
+	for i, h := range hosts {
+		wg.Add(1)
+		go func(i int, h inventoryHost) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = enlistHost(h, args)
+		}(i, h)
+	}
+	wg.Wait()
+
+	var failed int
+	for _, result := range results {
+		if result.Status == enlistFailed {
+			failed++
+		}
+	}
+	if err := writeEnlistResults(ctx, results); err != nil {
+		return errors.Trace(err)
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to add %d of %d hosts", failed, len(results))
+	}
+	return nil
+}
+
+// enlistHost provisions a single host from an inventory, retrying on
+// failure. Hosts that have already been provisioned are skipped.
+func enlistHost(h inventoryHost, args manual.ProvisionMachineArgs) enlistResult {
+	result := enlistResult{Host: h.Host}
+	options, err := h.sshOptions()
+	if err != nil {
+		result.Status = enlistFailed
+		result.Message = err.Error()
+		return result
+	}
+	args.Host = h.sshHost()
+	args.SSHOptions = options
+	// Hosts are provisioned concurrently, so there is no terminal
+	// to prompt on; hosts must allow key-based login and passwordless
+	// sudo, and fail immediately otherwise. Progress is only logged.
+	args.BatchMode = true
+	args.Stdin = strings.NewReader("")
+	for attempt := 1; ; attempt++ {
+		var progress bytes.Buffer
+		args.Stdout = &progress
+		args.Stderr = &progress
+		machineId, err := manualProvisioner(args)
+		if err == nil {
+			result.Status = enlistAdded
+			result.Machine = machineId
+			return result
+		}
+		if errors.Cause(err) == manual.ErrProvisioned {
+			result.Status = enlistSkipped
+			result.Message = err.Error()
+			return result
+		}
+		logger.Debugf("provisioning %s: %s", h.Host, progress.String())
+		if attempt >= inventoryAttempts {
+			result.Status = enlistFailed
+			result.Message = err.Error()
+			return result
+		}
+		logger.Warningf("attempt %d to provision %s failed, retrying: %v", attempt, h.Host, err)
+		time.Sleep(inventoryRetryDelay)
+	}
+}
+
+func writeEnlistResults(ctx *cmd.Context, results []enlistResult) error {
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(ctx.Stdout, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "HOST\tMACHINE\tSTATUS\tMESSAGE\n")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Host, r.Machine, r.Status, r.Message)
+	}
+	return tw.Flush()
+}
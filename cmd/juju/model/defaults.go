@@ -0,0 +1,287 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/config"
+)
+
+// NewDefaultsCommand returns a command used to display, set and
+// reset the default model config values.
+func NewDefaultsCommand() cmd.Command {
+	return modelcmd.Wrap(&defaultsCommand{})
+}
+
+// defaultsCommand is able to output the default model config values,
+// and to set or reset them for the controller or a cloud region.
+type defaultsCommand struct {
+	modelcmd.ModelCommandBase
+	api ModelDefaultsAPI
+	out cmd.Output
+
+	region   string
+	key      string
+	values   attributes
+	reset    []string
+	resetArg string
+}
+
+const modelDefaultsHelpDoc = `
+By default, all default configuration (keys and values) are
+displayed if a key is not specified. Supplying key=value will set the
+supplied key to the supplied value. This can be repeated for multiple keys.
+By default, the values are those of the controller; the --region option
+may be used to display or change the values for a region of the
+controller's cloud instead.
+
+New models are created using the default values of the controller,
+overridden by those of the model's region. Changing a default value
+does not change the config of existing models, other than being used
+when a key is unset from a model's config.
+
+Examples:
+
+    juju model-defaults
+    juju model-defaults http-proxy
+    juju model-defaults http-proxy=http://proxy.example.com no-proxy=localhost
+    juju model-defaults --region us-east-1 apt-mirror=http://mirror.example.com
+    juju model-defaults --reset http-proxy,no-proxy
+    juju model-defaults --region us-east-1 --reset apt-mirror
+
+See also:
+    models
+    get-model-config
+    set-model-config
+    unset-model-config
+`
+
+// Info implements part of the cmd.Command interface.
+func (c *defaultsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "model-defaults",
+		Args:    "[<model key>[=<value>] ...]",
+		Purpose: "Displays or sets default configuration settings for new models.",
+		Doc:     strings.TrimSpace(modelDefaultsHelpDoc),
+	}
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *defaultsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatDefaultConfigTabular,
+	})
+	f.StringVar(&c.region, "region", "", "The cloud region to display or change the defaults of")
+	f.StringVar(&c.resetArg, "reset", "", "Reset the provided comma delimited keys")
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *defaultsCommand) Init(args []string) error {
+	if c.resetArg != "" {
+		for _, key := range strings.Split(c.resetArg, ",") {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				continue
+			}
+			if key == config.AgentVersionKey {
+				return errors.New("agent-version cannot be reset")
+			}
+			c.reset = append(c.reset, key)
+		}
+		if len(c.reset) == 0 {
+			return errors.New("no keys specified to reset")
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+	if len(args) == 1 && !strings.Contains(args[0], "=") {
+		if len(c.reset) > 0 {
+			return errors.New("cannot display and reset keys at the same time")
+		}
+		c.key = args[0]
+		return nil
+	}
+
+	options, err := keyvalues.Parse(args, true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.values = make(attributes)
+	for key, value := range options {
+		if key == config.AgentVersionKey {
+			return errors.New("agent-version must be set via upgrade-juju")
+		}
+		c.values[key] = value
+	}
+	for _, key := range c.reset {
+		if _, ok := c.values[key]; ok {
+			return errors.Errorf("key %q cannot be both set and reset in the same command", key)
+		}
+	}
+	return nil
+}
+
+// ModelDefaultsAPI defines the API methods used by the model-defaults
+// command.
+type ModelDefaultsAPI interface {
+	Close() error
+	ModelDefaults() (config.ModelDefaultAttributes, error)
+	SetModelDefaults(cloudRegion string, config map[string]interface{}) error
+	UnsetModelDefaults(cloudRegion string, keys ...string) error
+}
+
+func (c *defaultsCommand) getAPI() (ModelDefaultsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelconfig.NewClient(api), nil
+}
+
+// Run implements part of the cmd.Command interface.
+func (c *defaultsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if len(c.values) > 0 {
+		err := client.SetModelDefaults(c.region, c.values)
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+	}
+	if len(c.reset) > 0 {
+		err := client.UnsetModelDefaults(c.region, c.reset...)
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+	}
+	if len(c.values)+len(c.reset) > 0 {
+		return nil
+	}
+
+	attrs, err := client.ModelDefaults()
+	if err != nil {
+		return err
+	}
+	if c.region != "" {
+		attrs = filterRegion(attrs, c.region)
+	}
+	if c.key != "" {
+		value, found := attrs[c.key]
+		if !found {
+			return errors.Errorf("key %q not found in the model defaults", c.key)
+		}
+		attrs = config.ModelDefaultAttributes{c.key: value}
+	}
+	return c.out.Write(ctx, attrs)
+}
+
+// filterRegion returns the default values, with only the values
+// of the specified region included.
+func filterRegion(attrs config.ModelDefaultAttributes, region string) config.ModelDefaultAttributes {
+	result := make(config.ModelDefaultAttributes)
+	for attr, val := range attrs {
+		var regions []config.RegionDefaultValue
+		for _, r := range val.Regions {
+			if r.Name == region {
+				regions = append(regions, r)
+			}
+		}
+		val.Regions = regions
+		result[attr] = val
+	}
+	return result
+}
+
+// formatDefaultConfigTabular returns a tabular summary of default
+// config information.
+func formatDefaultConfigTabular(value interface{}) ([]byte, error) {
+	defaultValues, ok := value.(config.ModelDefaultAttributes)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", defaultValues, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	p := func(values ...string) {
+		text := strings.Join(values, "\t")
+		fmt.Fprintln(tw, text)
+	}
+	formatValue := func(name string, value interface{}) (string, error) {
+		if value == nil {
+			return "-", nil
+		}
+		val, err := cmd.FormatSmart(value)
+		if err != nil {
+			return "", errors.Annotatef(err, "formatting value for %q", name)
+		}
+		// Some attribute values have a newline appended
+		// which makes the output messy.
+		valString := strings.TrimSuffix(string(val), "\n")
+		if valString == "" {
+			valString = `""`
+		}
+		return valString, nil
+	}
+	var valueNames []string
+	for name := range defaultValues {
+		valueNames = append(valueNames, name)
+	}
+	sort.Strings(valueNames)
+	p("ATTRIBUTE\tDEFAULT\tCONTROLLER")
+
+	for _, name := range valueNames {
+		info := defaultValues[name]
+		defaultValue, err := formatValue(name, info.Default)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		controllerValue, err := formatValue(name, info.Controller)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		p(name, defaultValue, controllerValue)
+		for _, region := range info.Regions {
+			regionValue, err := formatValue(name, region.Value)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			p("  "+region.Name, regionValue, "-")
+		}
+	}
+
+	tw.Flush()
+	return out.Bytes(), nil
+}
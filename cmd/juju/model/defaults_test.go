@@ -0,0 +1,214 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/testing"
+)
+
+type DefaultsCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeModelDefaultsAPI
+}
+
+var _ = gc.Suite(&DefaultsCommandSuite{})
+
+func (s *DefaultsCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeModelDefaultsAPI{
+		defaults: config.ModelDefaultAttributes{
+			"attr": {Default: "foo"},
+			"attr2": {
+				Controller: "bar",
+				Regions: []config.RegionDefaultValue{{
+					Name:  "dummy-region",
+					Value: "dummy-value",
+				}, {
+					Name:  "another-region",
+					Value: "another-value",
+				}},
+			},
+		},
+	}
+}
+
+func (s *DefaultsCommandSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := model.NewDefaultsCommandForTest(s.fake)
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *DefaultsCommandSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args       []string
+		errorMatch string
+		nilErr     bool
+	}{{
+		args:   nil,
+		nilErr: true,
+	}, {
+		args:   []string{"attr"},
+		nilErr: true,
+	}, {
+		args:       []string{"attr", "attr2"},
+		errorMatch: `expected "key=value", got "attr"`,
+	}, {
+		args:       []string{"--reset", "attr", "attr2"},
+		errorMatch: "cannot display and reset keys at the same time",
+	}, {
+		args:       []string{"--reset", ","},
+		errorMatch: "no keys specified to reset",
+	}, {
+		args:       []string{"--reset", "agent-version"},
+		errorMatch: "agent-version cannot be reset",
+	}, {
+		args:       []string{"agent-version=2.0.0"},
+		errorMatch: "agent-version must be set via upgrade-juju",
+	}, {
+		args:       []string{"--reset", "attr", "attr=bar"},
+		errorMatch: `key "attr" cannot be both set and reset in the same command`,
+	}} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(model.NewDefaultsCommandForTest(s.fake), test.args)
+		if test.nilErr {
+			c.Check(err, jc.ErrorIsNil)
+			continue
+		}
+		c.Check(err, gc.ErrorMatches, test.errorMatch)
+	}
+}
+
+func (s *DefaultsCommandSuite) TestDefaultsTabular(c *gc.C) {
+	context, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := strings.TrimSpace(testing.Stdout(context))
+	expected := "" +
+		"ATTRIBUTE         DEFAULT        CONTROLLER\n" +
+		"attr              foo            -\n" +
+		"attr2             -              bar\n" +
+		"  dummy-region    dummy-value    -\n" +
+		"  another-region  another-value  -"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *DefaultsCommandSuite) TestDefaultsSingleKeyRegion(c *gc.C) {
+	context, err := s.run(c, "--region", "dummy-region", "attr2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := strings.TrimSpace(testing.Stdout(context))
+	expected := "" +
+		"ATTRIBUTE       DEFAULT      CONTROLLER\n" +
+		"attr2           -            bar\n" +
+		"  dummy-region  dummy-value  -"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *DefaultsCommandSuite) TestDefaultsYAML(c *gc.C) {
+	context, err := s.run(c, "--format=yaml", "attr2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := strings.TrimSpace(testing.Stdout(context))
+	expected := "" +
+		"attr2:\n" +
+		"  controller: bar\n" +
+		"  regions:\n" +
+		"  - name: dummy-region\n" +
+		"    value: dummy-value\n" +
+		"  - name: another-region\n" +
+		"    value: another-value"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *DefaultsCommandSuite) TestDefaultsMissingKey(c *gc.C) {
+	_, err := s.run(c, "missing")
+	c.Assert(err, gc.ErrorMatches, `key "missing" not found in the model defaults`)
+}
+
+func (s *DefaultsCommandSuite) TestSetDefaults(c *gc.C) {
+	_, err := s.run(c, "special=extra", "attr=baz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.region, gc.Equals, "")
+	c.Assert(s.fake.values, jc.DeepEquals, map[string]interface{}{
+		"special": "extra",
+		"attr":    "baz",
+	})
+}
+
+func (s *DefaultsCommandSuite) TestSetRegionDefaults(c *gc.C) {
+	_, err := s.run(c, "--region", "dummy-region", "attr=baz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.region, gc.Equals, "dummy-region")
+	c.Assert(s.fake.values, jc.DeepEquals, map[string]interface{}{
+		"attr": "baz",
+	})
+}
+
+func (s *DefaultsCommandSuite) TestResetDefaults(c *gc.C) {
+	_, err := s.run(c, "--reset", "attr,attr2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.region, gc.Equals, "")
+	c.Assert(s.fake.keys, jc.DeepEquals, []string{"attr", "attr2"})
+}
+
+func (s *DefaultsCommandSuite) TestSetAndResetDefaults(c *gc.C) {
+	_, err := s.run(c, "--region", "dummy-region", "--reset", "attr2", "attr=baz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.region, gc.Equals, "dummy-region")
+	c.Assert(s.fake.values, jc.DeepEquals, map[string]interface{}{
+		"attr": "baz",
+	})
+	c.Assert(s.fake.keys, jc.DeepEquals, []string{"attr2"})
+}
+
+func (s *DefaultsCommandSuite) TestBlockedError(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "attr=baz")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	// msg is logged
+	c.Check(c.GetTestLog(), jc.Contains, "TestBlockedError")
+}
+
+func (s *DefaultsCommandSuite) TestSetError(c *gc.C) {
+	s.fake.err = &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}
+	_, err := s.run(c, "attr=baz")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeModelDefaultsAPI struct {
+	defaults config.ModelDefaultAttributes
+	region   string
+	values   map[string]interface{}
+	keys     []string
+	err      error
+}
+
+func (f *fakeModelDefaultsAPI) Close() error {
+	return nil
+}
+
+func (f *fakeModelDefaultsAPI) ModelDefaults() (config.ModelDefaultAttributes, error) {
+	return f.defaults, nil
+}
+
+func (f *fakeModelDefaultsAPI) SetModelDefaults(cloudRegion string, config map[string]interface{}) error {
+	f.region = cloudRegion
+	f.values = config
+	return f.err
+}
+
+func (f *fakeModelDefaultsAPI) UnsetModelDefaults(cloudRegion string, keys ...string) error {
+	f.region = cloudRegion
+	f.keys = keys
+	return f.err
+}
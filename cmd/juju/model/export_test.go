@@ -34,6 +34,14 @@ func NewUnsetCommandForTest(api UnsetModelAPI) cmd.Command {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewDefaultsCommandForTest returns a defaultsCommand with the api provided as specified.
+func NewDefaultsCommandForTest(api ModelDefaultsAPI) cmd.Command {
+	cmd := &defaultsCommand{
+		api: api,
+	}
+	return modelcmd.Wrap(cmd)
+}
+
 // NewRetryProvisioningCommandForTest returns a RetryProvisioningCommand with the api provided as specified.
 func NewRetryProvisioningCommandForTest(api RetryProvisioningAPI) cmd.Command {
 	cmd := &retryProvisioningCommand{
@@ -0,0 +1,20 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewListCommandForTest returns a listCommand with the api provided as specified.
+func NewListCommandForTest(api ListSecretsAPI) cmd.Command {
+	return modelcmd.Wrap(&listCommand{api: api})
+}
+
+// NewShowCommandForTest returns a showCommand with the api provided as specified.
+func NewShowCommandForTest(api ShowSecretAPI) cmd.Command {
+	return modelcmd.Wrap(&showCommand{api: api})
+}
@@ -0,0 +1,170 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const listCommandDoc = `
+Lists the secrets stored by the charms deployed in the model. Secret
+values are never shown; use "juju show-secret --reveal" to see the
+value of a single secret.
+
+Only controller and model administrators may list secrets.
+
+Examples:
+    juju secrets
+    juju secrets --format yaml
+
+See also:
+    show-secret
+`
+
+// NewListCommand returns a command that lists the secrets in a model.
+func NewListCommand() cmd.Command {
+	return modelcmd.Wrap(&listCommand{})
+}
+
+// ListSecretsAPI defines the API methods that the secrets command uses.
+type ListSecretsAPI interface {
+	ListSecrets() ([]params.SecretDetails, error)
+	Close() error
+}
+
+// listCommand lists the secrets in a model.
+type listCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api ListSecretsAPI
+}
+
+// Info implements Command.Info.
+func (c *listCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "secrets",
+		Purpose: "Lists the secrets stored by charms in the model.",
+		Doc:     listCommandDoc,
+		Aliases: []string{"list-secrets"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSecretsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *listCommand) getAPI() (ListSecretsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *listCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	secrets, err := client.ListSecrets()
+	if err != nil {
+		return err
+	}
+	info := make([]secretInfo, len(secrets))
+	for i, secret := range secrets {
+		info[i] = convertSecretDetails(secret)
+	}
+	return c.out.Write(ctx, info)
+}
+
+// secretInfo is the serialisation format of a secret's details.
+type secretInfo struct {
+	ID             string     `yaml:"id" json:"id"`
+	Owner          string     `yaml:"owner" json:"owner"`
+	Label          string     `yaml:"label,omitempty" json:"label,omitempty"`
+	Description    string     `yaml:"description,omitempty" json:"description,omitempty"`
+	Revision       int        `yaml:"revision" json:"revision"`
+	RotateInterval string     `yaml:"rotate-interval,omitempty" json:"rotate-interval,omitempty"`
+	NextRotateTime *time.Time `yaml:"next-rotate-time,omitempty" json:"next-rotate-time,omitempty"`
+	Grants         []string   `yaml:"grants,omitempty" json:"grants,omitempty"`
+	Created        time.Time  `yaml:"created" json:"created"`
+	Updated        time.Time  `yaml:"updated" json:"updated"`
+}
+
+func convertSecretDetails(secret params.SecretDetails) secretInfo {
+	info := secretInfo{
+		ID:             secret.ID,
+		Owner:          secret.OwnerTag,
+		Label:          secret.Label,
+		Description:    secret.Description,
+		Revision:       secret.Revision,
+		NextRotateTime: secret.NextRotateTime,
+		Grants:         secret.Grants,
+		Created:        secret.CreateTime,
+		Updated:        secret.UpdateTime,
+	}
+	if tag, err := names.ParseTag(secret.OwnerTag); err == nil {
+		info.Owner = tag.Id()
+	}
+	if secret.RotateInterval > 0 {
+		info.RotateInterval = secret.RotateInterval.String()
+	}
+	return info
+}
+
+func formatSecretsTabular(value interface{}) ([]byte, error) {
+	secrets, ok := value.([]secretInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", secrets, value)
+	}
+	if len(secrets) == 0 {
+		return []byte("No secrets to display."), nil
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "ID\tOWNER\tLABEL\tREVISION\tROTATE\tUPDATED\n")
+	for _, s := range secrets {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n",
+			s.ID, s.Owner, s.Label, s.Revision, s.RotateInterval,
+			s.Updated.UTC().Format(time.RFC3339),
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
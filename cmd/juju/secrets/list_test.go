@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"errors"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/testing"
+)
+
+type ListSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeSecretsAPI
+}
+
+var _ = gc.Suite(&ListSuite{})
+
+func (s *ListSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeSecretsAPI{
+		secrets: []params.SecretDetails{{
+			ID:             "secret-1",
+			OwnerTag:       "unit-mysql-0",
+			Label:          "password",
+			Revision:       2,
+			RotateInterval: time.Hour,
+			Grants:         []string{"wordpress"},
+			CreateTime:     time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC),
+			UpdateTime:     time.Date(2016, 8, 2, 10, 0, 0, 0, time.UTC),
+		}},
+	}
+}
+
+func (s *ListSuite) TestInitRejectsArgs(c *gc.C) {
+	_, err := testing.RunCommand(c, secrets.NewListCommandForTest(s.fake), "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ListSuite) TestListTabular(c *gc.C) {
+	ctx, err := testing.RunCommand(c, secrets.NewListCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"ID        OWNER    LABEL     REVISION  ROTATE  UPDATED\n"+
+		"secret-1  mysql/0  password  2         1h0m0s  2016-08-02T10:00:00Z\n",
+	)
+	c.Assert(s.fake.closed, jc.IsTrue)
+}
+
+func (s *ListSuite) TestListYaml(c *gc.C) {
+	ctx, err := testing.RunCommand(c, secrets.NewListCommandForTest(s.fake), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"- id: secret-1\n"+
+		"  owner: mysql/0\n"+
+		"  label: password\n"+
+		"  revision: 2\n"+
+		"  rotate-interval: 1h0m0s\n"+
+		"  grants:\n"+
+		"  - wordpress\n"+
+		"  created: 2016-08-01T10:00:00Z\n"+
+		"  updated: 2016-08-02T10:00:00Z\n",
+	)
+}
+
+func (s *ListSuite) TestListTabularNoSecrets(c *gc.C) {
+	s.fake.secrets = nil
+	ctx, err := testing.RunCommand(c, secrets.NewListCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "No secrets to display.\n")
+}
+
+func (s *ListSuite) TestListError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := testing.RunCommand(c, secrets.NewListCommandForTest(s.fake))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeSecretsAPI struct {
+	secrets []params.SecretDetails
+	show    params.ShowSecretResult
+	err     error
+	closed  bool
+
+	shownId string
+	reveal  bool
+}
+
+func (f *fakeSecretsAPI) ListSecrets() ([]params.SecretDetails, error) {
+	return f.secrets, f.err
+}
+
+func (f *fakeSecretsAPI) ShowSecret(id string, reveal bool) (params.ShowSecretResult, error) {
+	f.shownId = id
+	f.reveal = reveal
+	return f.show, f.err
+}
+
+func (f *fakeSecretsAPI) Close() error {
+	f.closed = true
+	return nil
+}
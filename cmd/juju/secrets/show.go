@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const showCommandDoc = `
+Shows the details of a secret stored by a charm, along with its audit
+trail: a record of who created, read, changed, shared and rotated the
+secret, and when.
+
+The secret's value is only shown if --reveal is given. Revealing a
+secret is itself recorded in the secret's audit trail.
+
+Only controller and model administrators may show secrets.
+
+Examples:
+    juju show-secret 9f5a6ab4-1f4e-4c1b-8f0e-7b2b7f3e6a1d
+    juju show-secret 9f5a6ab4-1f4e-4c1b-8f0e-7b2b7f3e6a1d --reveal
+
+See also:
+    secrets
+`
+
+// NewShowCommand returns a command that shows the details of a secret.
+func NewShowCommand() cmd.Command {
+	return modelcmd.Wrap(&showCommand{})
+}
+
+// ShowSecretAPI defines the API methods that the show-secret command uses.
+type ShowSecretAPI interface {
+	ShowSecret(id string, reveal bool) (params.ShowSecretResult, error)
+	Close() error
+}
+
+// showCommand shows the details of a secret.
+type showCommand struct {
+	modelcmd.ModelCommandBase
+	out    cmd.Output
+	api    ShowSecretAPI
+	id     string
+	reveal bool
+}
+
+// Info implements Command.Info.
+func (c *showCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-secret",
+		Args:    "<ID>",
+		Purpose: "Shows the details and audit trail of a secret.",
+		Doc:     showCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.BoolVar(&c.reveal, "reveal", false, "Show the secret's value")
+}
+
+// Init implements Command.Init.
+func (c *showCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret ID specified")
+	}
+	c.id = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *showCommand) getAPI() (ShowSecretAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *showCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.ShowSecret(c.id, c.reveal)
+	if err != nil {
+		return err
+	}
+	info := showSecretInfo{
+		Value: result.Value,
+		Audit: make([]auditInfo, len(result.Audit)),
+	}
+	if result.Secret != nil {
+		info.secretInfo = convertSecretDetails(*result.Secret)
+	}
+	for i, record := range result.Audit {
+		info.Audit[i] = auditInfo{
+			Time:     record.Time,
+			Entity:   record.Entity,
+			Action:   record.Action,
+			Revision: record.Revision,
+			Detail:   record.Detail,
+		}
+	}
+	return c.out.Write(ctx, info)
+}
+
+// showSecretInfo is the serialisation format of the show-secret command.
+type showSecretInfo struct {
+	secretInfo `yaml:",inline" json:",inline"`
+	Value      map[string]string `yaml:"value,omitempty" json:"value,omitempty"`
+	Audit      []auditInfo       `yaml:"audit" json:"audit"`
+}
+
+type auditInfo struct {
+	Time     time.Time `yaml:"time" json:"time"`
+	Entity   string    `yaml:"entity" json:"entity"`
+	Action   string    `yaml:"action" json:"action"`
+	Revision int       `yaml:"revision" json:"revision"`
+	Detail   string    `yaml:"detail,omitempty" json:"detail,omitempty"`
+}
+
+// newClient returns a client for the Secrets API facade.
+func newClient(root base.APICallCloser) *secrets.Client {
+	return secrets.NewClient(root)
+}
@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/testing"
+)
+
+type ShowSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeSecretsAPI
+}
+
+var _ = gc.Suite(&ShowSuite{})
+
+func (s *ShowSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	created := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	s.fake = &fakeSecretsAPI{
+		show: params.ShowSecretResult{
+			Secret: &params.SecretDetails{
+				ID:         "secret-1",
+				OwnerTag:   "application-mysql",
+				Revision:   1,
+				CreateTime: created,
+				UpdateTime: created,
+			},
+			Audit: []params.SecretAuditRecord{{
+				Time:     created,
+				Entity:   "unit-mysql-0",
+				Action:   "create",
+				Revision: 1,
+			}},
+		},
+	}
+}
+
+func (s *ShowSuite) TestInitNoId(c *gc.C) {
+	_, err := testing.RunCommand(c, secrets.NewShowCommandForTest(s.fake))
+	c.Assert(err, gc.ErrorMatches, "no secret ID specified")
+}
+
+func (s *ShowSuite) TestInitTooManyArgs(c *gc.C) {
+	_, err := testing.RunCommand(c, secrets.NewShowCommandForTest(s.fake), "secret-1", "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ShowSuite) TestShow(c *gc.C) {
+	ctx, err := testing.RunCommand(c, secrets.NewShowCommandForTest(s.fake), "secret-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.shownId, gc.Equals, "secret-1")
+	c.Assert(s.fake.reveal, jc.IsFalse)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"id: secret-1\n"+
+		"owner: mysql\n"+
+		"revision: 1\n"+
+		"created: 2016-08-01T10:00:00Z\n"+
+		"updated: 2016-08-01T10:00:00Z\n"+
+		"audit:\n"+
+		"- time: 2016-08-01T10:00:00Z\n"+
+		"  entity: unit-mysql-0\n"+
+		"  action: create\n"+
+		"  revision: 1\n",
+	)
+	c.Assert(s.fake.closed, jc.IsTrue)
+}
+
+func (s *ShowSuite) TestShowReveal(c *gc.C) {
+	s.fake.show.Value = map[string]string{"password": "s3cret"}
+	ctx, err := testing.RunCommand(c, secrets.NewShowCommandForTest(s.fake), "secret-1", "--reveal", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.reveal, jc.IsTrue)
+	c.Assert(testing.Stdout(ctx), jc.Contains, `"value":{"password":"s3cret"}`)
+}
@@ -115,6 +115,8 @@ type unitStatus struct {
 	OpenedPorts   []string              `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
 	PublicAddress string                `json:"public-address,omitempty" yaml:"public-address,omitempty"`
 	Subordinates  map[string]unitStatus `json:"subordinates,omitempty" yaml:"subordinates,omitempty"`
+	SkippedHooks  []string              `json:"skipped-hooks,omitempty" yaml:"skipped-hooks,omitempty"`
+	HealthStatus  *statusInfoContents   `json:"health-status,omitempty" yaml:"health-status,omitempty"`
 }
 
 type statusInfoContents struct {
@@ -201,6 +201,12 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		PublicAddress:      info.unit.PublicAddress,
 		Charm:              info.unit.Charm,
 		Subordinates:       make(map[string]unitStatus),
+		SkippedHooks:       info.unit.SkippedHooks,
+	}
+
+	if info.unit.Health != nil {
+		health := sf.getStatusInfoContents(*info.unit.Health)
+		out.HealthStatus = &health
 	}
 
 	if ms, ok := info.meterStatuses[info.unitName]; ok {
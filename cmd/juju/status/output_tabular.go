@@ -217,6 +217,25 @@ func FormatTabular(value interface{}) ([]byte, error) {
 		}
 	}
 
+	// Only units whose charms define health checks report their health.
+	var health [][]interface{}
+	pHealth := func(name string, u unitStatus, level int) {
+		if u.HealthStatus != nil {
+			health = append(health, []interface{}{name, u.HealthStatus.Current, u.HealthStatus.Message})
+		}
+	}
+	for _, name := range utils.SortStringsNaturally(stringKeysFromMap(units)) {
+		u := units[name]
+		pHealth(name, u, 0)
+		recurseUnits(u, 1, pHealth)
+	}
+	if len(health) > 0 {
+		outputHeaders("HEALTH", "STATUS", "MESSAGE")
+		for _, values := range health {
+			p(values...)
+		}
+	}
+
 	var pMachine func(machineStatus)
 	pMachine = func(m machineStatus) {
 		// We want to display availability zone so extract from hardware info".
@@ -3549,6 +3549,80 @@ MACHINE  STATE  DNS  INS-ID  SERIES  AZ
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularHealth(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Units: map[string]unitStatus{
+					"foo/0": {
+						HealthStatus: &statusInfoContents{
+							Current: "unhealthy",
+							Message: `check "web" failed`,
+						},
+						Subordinates: map[string]unitStatus{
+							"bar/0": {
+								HealthStatus: &statusInfoContents{
+									Current: "healthy",
+								},
+							},
+						},
+					},
+					"foo/1": {
+						HealthStatus: &statusInfoContents{
+							Current: "healthy",
+						},
+					},
+				},
+			},
+		},
+	}
+	out, err := FormatTabular(status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, `
+MODEL  CONTROLLER  CLOUD/REGION  VERSION
+                                 
+
+APP  VERSION  STATUS  EXPOSED  ORIGIN  CHARM  REV  OS
+foo                   false                   0    
+
+UNIT     WORKLOAD  AGENT  MACHINE  PUBLIC-ADDRESS  PORTS  MESSAGE
+foo/0                                                     
+  bar/0                                                   
+foo/1                                                     
+
+HEALTH  STATUS     MESSAGE
+foo/0   unhealthy  check "web" failed
+bar/0   healthy    
+foo/1   healthy    
+
+MACHINE  STATE  DNS  INS-ID  SERIES  AZ
+`[1:])
+}
+
+func (s *StatusSuite) TestFormatHealth(c *gc.C) {
+	formatter := NewStatusFormatter(&params.FullStatus{}, true)
+	formatted := formatter.formatUnit(unitFormatInfo{
+		unit: params.UnitStatus{
+			Health: &params.DetailedStatus{
+				Status: "unhealthy",
+				Info:   `check "web" failed`,
+			},
+		},
+		unitName:        "wordpress/0",
+		applicationName: "wordpress",
+	})
+	c.Assert(formatted.HealthStatus, gc.NotNil)
+	c.Check(formatted.HealthStatus.Current, gc.Equals, status.StatusUnhealthy)
+	c.Check(formatted.HealthStatus.Message, gc.Equals, `check "web" failed`)
+
+	formatted = formatter.formatUnit(unitFormatInfo{
+		unit:            params.UnitStatus{},
+		unitName:        "wordpress/1",
+		applicationName: "wordpress",
+	})
+	c.Check(formatted.HealthStatus, gc.IsNil)
+}
+
 //
 // Filtering Feature
 //
@@ -4064,6 +4138,22 @@ func (s *StatusSuite) TestFormatProvisioningError(c *gc.C) {
 	})
 }
 
+func (s *StatusSuite) TestFormatSkippedHooks(c *gc.C) {
+	formatter := NewStatusFormatter(&params.FullStatus{}, true)
+	formatted := formatter.formatUnit(unitFormatInfo{
+		unit: params.UnitStatus{
+			SkippedHooks: []string{"relation-changed (1; mysql/0)"},
+		},
+		unitName:        "wordpress/0",
+		applicationName: "wordpress",
+	})
+	c.Check(formatted.SkippedHooks, jc.DeepEquals, []string{"relation-changed (1; mysql/0)"})
+
+	out, err := goyaml.Marshal(formatted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(out), jc.Contains, "skipped-hooks:\n- relation-changed (1; mysql/0)\n")
+}
+
 type tableSections map[string][]string
 
 func sectionTitle(lines []string) string {
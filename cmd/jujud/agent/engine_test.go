@@ -42,6 +42,7 @@ var (
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
+		"instance-drift",
 		"instance-poller",
 		"metric-worker",
 		"migration-fortress",
@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"encoding/base64"
 	"fmt"
 	"net"
 	"os"
@@ -754,6 +755,10 @@ func (a *MachineAgent) openStateForUpgrade() (*state.State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := setSecretsKey(st, agentConfig); err != nil {
+		st.Close()
+		return nil, errors.Trace(err)
+	}
 	return st, nil
 }
 
@@ -1005,6 +1010,7 @@ func (a *MachineAgent) startModelWorkers(uuid string) (worker.Worker, error) {
 		Clock:                       clock.WallClock,
 		RunFlagDuration:             time.Minute,
 		CharmRevisionUpdateInterval: 24 * time.Hour,
+		InstanceDriftCheckInterval:  15 * time.Minute,
 		InstPollerAggregationDelay:  3 * time.Second,
 		// TODO(perrito666) the status history pruning numbers need
 		// to be adjusting, after collecting user data from large install
@@ -1080,6 +1086,12 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
 
+	// Auditing may be enabled or disabled while the API server
+	// is running, so the observer checks the current setting
+	// for each new connection.
+	auditing := &auditingConfig{}
+	auditing.setEnabled(controllerConfig.AuditingEnabled())
+
 	server, err := apiserver.NewServer(st, listener, apiserver.ServerConfig{
 		Cert:        cert,
 		Key:         key,
@@ -1089,13 +1101,16 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 		Validator:   a.limitLogins,
 		CertChanged: certChanged,
 		NewObserver: newObserverFn(
-			controllerConfig,
+			auditing.enabled,
 			clock.WallClock,
 			jujuversion.Current,
 			agentConfig.Model().Id(),
 			newAuditEntrySink(st, logDir),
 			auditErrorHandler,
 		),
+		ControllerConfigChanged: func(cfg controller.Config) {
+			auditing.setEnabled(cfg.AuditingEnabled())
+		},
 	})
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot start api server worker")
@@ -1128,8 +1143,26 @@ func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 	}
 }
 
+// auditingConfig records whether auditing is enabled. It is safe
+// to use from multiple goroutines.
+type auditingConfig struct {
+	value int32
+}
+
+func (a *auditingConfig) enabled() bool {
+	return atomic.LoadInt32(&a.value) != 0
+}
+
+func (a *auditingConfig) setEnabled(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&a.value, value)
+}
+
 func newObserverFn(
-	controllerConfig controller.Config,
+	auditingEnabled func() bool,
 	clock clock.Clock,
 	jujuServerVersion version.Number,
 	modelUUID string,
@@ -1152,15 +1185,17 @@ func newObserverFn(
 
 	// Auditing observer
 	// TODO(katco): Auditing needs feature tests (lp:1604551)
-	if controllerConfig.AuditingEnabled() {
-		observerFactories = append(observerFactories, func() observer.Observer {
-			ctx := &observer.AuditContext{
-				JujuServerVersion: jujuServerVersion,
-				ModelUUID:         modelUUID,
-			}
-			return observer.NewAudit(ctx, persistAuditEntry, auditErrorHandler)
-		})
-	}
+	observerFactories = append(observerFactories, func() observer.Observer {
+		if !auditingEnabled() {
+			// Nil observers are ignored by the multiplexer.
+			return nil
+		}
+		ctx := &observer.AuditContext{
+			JujuServerVersion: jujuServerVersion,
+			ModelUUID:         modelUUID,
+		}
+		return observer.NewAudit(ctx, persistAuditEntry, auditErrorHandler)
+	})
 
 	return observer.ObserverFactoryMultiplexer(observerFactories...)
 
@@ -1310,6 +1345,9 @@ func openState(agentConfig agent.Config, dialOpts mongo.DialOpts) (_ *state.Stat
 			st.Close()
 		}
 	}()
+	if err := setSecretsKey(st, agentConfig); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	m0, err := st.FindEntity(agentConfig.Tag())
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -1331,6 +1369,23 @@ func openState(agentConfig agent.Config, dialOpts mongo.DialOpts) (_ *state.Stat
 	return st, m, nil
 }
 
+// setSecretsKey gives the State the controller secrets key held in the
+// agent's StateServingInfo. Agents whose serving info predates the key
+// leave the State to read it from the database.
+func setSecretsKey(st *state.State, agentConfig agent.Config) error {
+	info, ok := agentConfig.StateServingInfo()
+	if !ok || info.SecretsKey == "" {
+		logger.Debugf("no secrets key in agent config; reading it from state")
+		return nil
+	}
+	key, err := base64.StdEncoding.DecodeString(info.SecretsKey)
+	if err != nil {
+		return errors.Annotate(err, "cannot decode secrets key")
+	}
+	st.SetSecretsKey(key)
+	return nil
+}
+
 func getMachine(st *state.State, tag names.Tag) (*state.Machine, error) {
 	m0, err := st.FindEntity(tag)
 	if err != nil {
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/worker/firewaller"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
+	"github.com/juju/juju/worker/instancedrift"
 	"github.com/juju/juju/worker/instancepoller"
 	"github.com/juju/juju/worker/lifeflag"
 	"github.com/juju/juju/worker/metricworker"
@@ -75,6 +76,11 @@ type ManifoldsConfig struct {
 	// revision worker will check for new revisions of known charms.
 	CharmRevisionUpdateInterval time.Duration
 
+	// InstanceDriftCheckInterval determines how often the instance-
+	// drift worker will compare the model's machines with the
+	// instances found in the cloud.
+	InstanceDriftCheckInterval time.Duration
+
 	// StatusHistoryPruner* values control status-history pruning
 	// behaviour.
 	StatusHistoryPrunerMaxHistoryTime time.Duration
@@ -248,6 +254,12 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			ClockName:     clockName,
 			Delay:         config.InstPollerAggregationDelay,
 		})),
+		instanceDriftName: ifNotMigrating(instancedrift.Manifold(instancedrift.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			EnvironName:   environTrackerName,
+			Period:        config.InstanceDriftCheckInterval,
+		})),
 		charmRevisionUpdaterName: ifNotMigrating(charmrevisionmanifold.Manifold(charmrevisionmanifold.ManifoldConfig{
 			APICallerName: apiCallerName,
 			ClockName:     clockName,
@@ -348,6 +360,7 @@ const (
 	unitAssignerName         = "unit-assigner"
 	applicationScalerName    = "application-scaler"
 	instancePollerName       = "instance-poller"
+	instanceDriftName        = "instance-drift"
 	charmRevisionUpdaterName = "charm-revision-updater"
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
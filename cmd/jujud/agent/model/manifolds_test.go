@@ -40,6 +40,7 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
+		"instance-drift",
 		"instance-poller",
 		"is-responsible-flag",
 		"metric-worker",
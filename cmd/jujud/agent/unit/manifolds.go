@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/fortress"
+	"github.com/juju/juju/worker/healthcheck"
 	"github.com/juju/juju/worker/introspection"
 	"github.com/juju/juju/worker/leadership"
 	"github.com/juju/juju/worker/logger"
@@ -246,6 +247,20 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewIsolatedStatusWorker:  meterstatus.NewIsolatedStatusWorker,
 		})),
 
+		// The health check worker runs the health checks declared by the
+		// charm, reports the unit's health, and executes the
+		// health-status-changed hook when it changes.
+		healthCheckName: ifNotMigrating(healthcheck.Manifold(healthcheck.ManifoldConfig{
+			AgentName:       agentName,
+			APICallerName:   apiCallerName,
+			CharmDirName:    charmDirName,
+			MachineLockName: coreagent.MachineLockName,
+			Clock:           clock.WallClock,
+			NewHookRunner:   healthcheck.NewHookRunner,
+			NewFacade:       healthcheck.NewFacade,
+			NewWorker:       healthcheck.NewWorker,
+		})),
+
 		// The metric sender worker periodically sends accumulated metrics to the controller.
 		metricSenderName: ifNotMigrating(sender.Manifold(sender.ManifoldConfig{
 			AgentName:       agentName,
@@ -287,4 +302,6 @@ const (
 	meterStatusName   = "meter-status"
 	metricCollectName = "metric-collect"
 	metricSenderName  = "metric-sender"
+
+	healthCheckName = "health-check"
 )
@@ -54,6 +54,7 @@ func (s *ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"meter-status",
 		"metric-collect",
 		"metric-sender",
+		"health-check",
 	}
 	keys := make([]string, 0, len(manifolds))
 	for k := range manifolds {
@@ -199,6 +199,13 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 	}
 	info.SharedSecret = sharedSecret
 	info.SystemIdentity = privateKey
+
+	// Generate the key from which the keys encrypting each model's
+	// secrets are derived.
+	info.SecretsKey, err = state.NewSecretsKey()
+	if err != nil {
+		return errors.Annotate(err, "failed to generate secrets key")
+	}
 	err = c.ChangeConfig(func(agentConfig agent.ConfigSetter) error {
 		agentConfig.SetStateServingInfo(info)
 		return nil
@@ -648,6 +648,28 @@ func (s *BootstrapSuite) TestSystemIdentityWritten(c *gc.C) {
 	c.Assert(string(data), gc.Equals, "private-key")
 }
 
+func (s *BootstrapSuite) TestSecretsKeyRecorded(c *gc.C) {
+	machineConf, cmd, err := s.initBootstrapCommand(c, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmd.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	machineConf1, err := agent.ReadConfig(agent.ConfigPath(machineConf.DataDir(), names.NewMachineTag("0")))
+	c.Assert(err, jc.ErrorIsNil)
+	info, ok := machineConf1.StateServingInfo()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(info.SecretsKey, gc.Not(gc.Equals), "")
+
+	stateinfo, ok := machineConf1.MongoInfo()
+	c.Assert(ok, jc.IsTrue)
+	st, err := state.Open(testing.ModelTag, stateinfo, mongotest.DialOpts(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	stateInfo, err := st.StateServingInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stateInfo.SecretsKey, gc.Equals, info.SecretsKey)
+}
+
 func (s *BootstrapSuite) TestDownloadedToolsMetadata(c *gc.C) {
 	// Tools downloaded by cloud-init script.
 	s.testToolsMetadata(c, false)
@@ -20,6 +20,7 @@ import (
 
 	"github.com/juju/juju/agent"
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
+	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/juju/sockets"
 	"github.com/juju/juju/worker/uniter"
 	jujuos "github.com/juju/utils/os"
@@ -182,7 +183,7 @@ func (c *RunCommand) executeNoContext() (*exec.ExecResponse, error) {
 		Delay: 250 * time.Millisecond,
 	}
 	logger.Debugf("acquire lock %q for juju-run", c.MachineLockName)
-	releaser, err := mutex.Acquire(spec)
+	releaser, err := machinelock.Acquire(spec)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
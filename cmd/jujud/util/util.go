@@ -236,5 +236,6 @@ func ParamsStateServingInfoToStateStateServingInfo(i params.StateServingInfo) st
 		CAPrivateKey:   i.CAPrivateKey,
 		SharedSecret:   i.SharedSecret,
 		SystemIdentity: i.SystemIdentity,
+		SecretsKey:     i.SecretsKey,
 	}
 }
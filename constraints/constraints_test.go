@@ -312,6 +312,22 @@ var parseConstraintsTests = []struct {
 		err:     `bad "virt-type" constraint: already set`,
 	},
 
+	// "zones" in detail.
+	{
+		summary: "single zone",
+		args:    []string{"zones=az1"},
+	}, {
+		summary: "multiple zones",
+		args:    []string{"zones=az1,az2"},
+	}, {
+		summary: "no zones",
+		args:    []string{"zones="},
+	}, {
+		summary: "double set zones",
+		args:    []string{"zones=az1", "zones=az2"},
+		err:     `bad "zones" constraint: already set`,
+	},
+
 	// Everything at once.
 	{
 		summary: "kitchen sink together",
@@ -409,6 +425,16 @@ func (s *ConstraintsSuite) TestIncludeExcludeAndHaveSpaces(c *gc.C) {
 	c.Check(con.HaveSpaces(), jc.IsTrue)
 }
 
+func (s *ConstraintsSuite) TestHasZones(c *gc.C) {
+	con := constraints.MustParse("zones=az1,az2")
+	c.Check(con.HasZones(), jc.IsTrue)
+	c.Check(*con.Zones, jc.DeepEquals, []string{"az1", "az2"})
+	con = constraints.MustParse("zones=")
+	c.Check(con.HasZones(), jc.IsFalse)
+	con = constraints.MustParse("mem=4G")
+	c.Check(con.HasZones(), jc.IsFalse)
+}
+
 func (s *ConstraintsSuite) TestInvalidSpaces(c *gc.C) {
 	invalidNames := []string{
 		"%$pace", "^foo#2", "+", "tcp:ip",
@@ -495,6 +521,9 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces1", constraints.Value{Spaces: nil}},
 	{"Spaces2", constraints.Value{Spaces: &[]string{}}},
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
+	{"Zones1", constraints.Value{Zones: nil}},
+	{"Zones2", constraints.Value{Zones: &[]string{}}},
+	{"Zones3", constraints.Value{Zones: &[]string{"az1", "az2"}}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
 	{"All", constraints.Value{
@@ -507,6 +536,7 @@ var constraintsRoundtripTests = []roundTrip{
 		Tags:         &[]string{"foo", "bar"},
 		Spaces:       &[]string{"space1", "^space2"},
 		InstanceType: strp("foo"),
+		Zones:        &[]string{"az1", "az2"},
 	}},
 }
 
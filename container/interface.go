@@ -6,6 +6,7 @@ package container
 import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/status"
 )
@@ -53,6 +54,19 @@ type Manager interface {
 	Namespace() instance.Namespace
 }
 
+// LXDProfileManager is implemented by container managers that can
+// apply charm LXD profiles to the containers they manage.
+type LXDProfileManager interface {
+	// MaybeWriteLXDProfile creates the named LXD profile, unless a
+	// profile with that name already exists.
+	MaybeWriteLXDProfile(name string, profile *lxdprofile.Profile) error
+
+	// ReplaceCharmProfiles replaces the charm profiles applied to the
+	// container with the given instance id with those named, keeping
+	// any other profiles already applied.
+	ReplaceCharmProfiles(id instance.Id, names []string) error
+}
+
 // Initialiser is responsible for performing the steps required to initialise
 // a host machine so it can run containers.
 type Initialiser interface {
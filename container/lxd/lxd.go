@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
@@ -38,8 +39,10 @@ type containerManager struct {
 	client *lxdclient.Client
 }
 
-// containerManager implements container.Manager.
+// containerManager implements container.Manager and
+// container.LXDProfileManager.
 var _ container.Manager = (*containerManager)(nil)
+var _ container.LXDProfileManager = (*containerManager)(nil)
 
 func ConnectLocal() (*lxdclient.Client, error) {
 	cfg := lxdclient.Config{
@@ -151,6 +154,10 @@ func (manager *containerManager) CreateContainer(
 	} else {
 		logger.Infof("instance %q configured with %v network devices", name, nics)
 	}
+	if len(instanceConfig.Profiles) > 0 {
+		logger.Infof("instance %q configured with charm profiles %v", name, instanceConfig.Profiles)
+		profiles = append(profiles, instanceConfig.Profiles...)
+	}
 
 	spec := lxdclient.InstanceSpec{
 		Name:     name,
@@ -204,6 +211,82 @@ func (manager *containerManager) ListContainers() (result []instance.Instance, e
 	return
 }
 
+// MaybeWriteLXDProfile implements container.LXDProfileManager. Charm
+// profile names include a hash of their contents, so a profile that
+// already exists with the name is left as it is.
+func (manager *containerManager) MaybeWriteLXDProfile(name string, profile *lxdprofile.Profile) error {
+	if !lxdprofile.IsCharmProfile(manager.modelUUID, name) {
+		return errors.NotValidf("charm LXD profile name %q", name)
+	}
+	if err := manager.ensureClient(); err != nil {
+		return errors.Trace(err)
+	}
+	exists, err := manager.client.HasProfile(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		return nil
+	}
+	logger.Infof("creating LXD profile %q", name)
+	devices := make(lxdclient.Devices, len(profile.Devices))
+	for device, props := range profile.Devices {
+		devices[device] = lxdclient.Device(props)
+	}
+	if err := manager.client.CreateProfileWithDevices(name, profile.Config, devices); err != nil {
+		return errors.Annotatef(err, "cannot create LXD profile %q", name)
+	}
+	return nil
+}
+
+// ReplaceCharmProfiles implements container.LXDProfileManager. Only
+// the charm profiles of the manager's model are replaced.
+func (manager *containerManager) ReplaceCharmProfiles(id instance.Id, names []string) error {
+	if err := manager.ensureClient(); err != nil {
+		return errors.Trace(err)
+	}
+	current, err := manager.client.InstanceProfiles(string(id))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var profiles []string
+	for _, profile := range current {
+		if !lxdprofile.IsCharmProfile(manager.modelUUID, profile) {
+			profiles = append(profiles, profile)
+		}
+	}
+	profiles = append(profiles, names...)
+	if stringsEqual(current, profiles) {
+		return nil
+	}
+	logger.Infof("applying profiles %v to instance %q", profiles, id)
+	return errors.Trace(manager.client.SetInstanceProfiles(string(id), profiles))
+}
+
+func (manager *containerManager) ensureClient() error {
+	if manager.client != nil {
+		return nil
+	}
+	client, err := ConnectLocal()
+	if err != nil {
+		return errors.Annotatef(err, "failed to connect to local LXD")
+	}
+	manager.client = client
+	return nil
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (manager *containerManager) IsInitialized() bool {
 	if manager.client != nil {
 		return true
@@ -4,6 +4,10 @@
 package container
 
 import (
+	"net"
+
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/network"
 )
 
@@ -18,6 +22,16 @@ const (
 	DefaultLxcBridge = "lxcbr0"
 	// DefaultKvmBridge is the default bridge for KVM instances.
 	DefaultKvmBridge = "virbr0"
+
+	// FanMTUOverhead is the part of the underlay network's MTU taken
+	// up by the Fan's encapsulation of overlay traffic.
+	FanMTUOverhead = 50
+)
+
+var (
+	// netInterfaces and interfaceAddrs are patched in tests.
+	netInterfaces  = net.Interfaces
+	interfaceAddrs = (*net.Interface).Addrs
 )
 
 // NetworkConfig defines how the container network will be configured.
@@ -58,3 +72,49 @@ func BridgeNetworkConfig(device string, mtu int, interfaces []network.InterfaceI
 func PhysicalNetworkConfig(device string, mtu int, interfaces []network.InterfaceInfo) *NetworkConfig {
 	return &NetworkConfig{PhysicalNetwork, device, mtu, interfaces}
 }
+
+// FanNetworkConfig returns a NetworkConfig which places a container on
+// the host's Fan bridge. The Fan mapping used is the first whose
+// underlay contains an address of one of the host's interfaces. The
+// container is given its address by the Fan's DHCP server, and an MTU
+// leaving room for encapsulation.
+//
+// The Fan bridges are set up, and persisted across reboots, when the
+// host is provisioned; an error satisfying errors.IsNotFound is
+// returned if the bridge is missing.
+func FanNetworkConfig(fanConfig network.FanConfig) (*NetworkConfig, error) {
+	ifaces, err := netInterfaces()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get network interfaces")
+	}
+	existing := make(map[string]bool)
+	for _, iface := range ifaces {
+		existing[iface.Name] = true
+	}
+	for _, iface := range ifaces {
+		addrs, err := interfaceAddrs(&iface)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot get addresses of %q", iface.Name)
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			entry, ok := fanConfig.EntryForAddress(ipNet.IP)
+			if !ok {
+				continue
+			}
+			bridge := entry.BridgeName()
+			if !existing[bridge] {
+				return nil, errors.NotFoundf("fan bridge %q for underlay address %s", bridge, ipNet.IP)
+			}
+			var mtu int
+			if iface.MTU > FanMTUOverhead {
+				mtu = iface.MTU - FanMTUOverhead
+			}
+			return BridgeNetworkConfig(bridge, mtu, nil), nil
+		}
+	}
+	return nil, errors.NotFoundf("host address in fan underlay %q", fanConfig)
+}
@@ -0,0 +1,76 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package container
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&NetworkSuite{})
+
+type NetworkSuite struct {
+	testing.BaseSuite
+}
+
+func (s *NetworkSuite) patchInterfaces(c *gc.C, names ...string) {
+	addrs := map[string][]net.Addr{
+		"lo":      {mustParseCIDR(c, "127.0.0.1/8")},
+		"eth0":    {mustParseCIDR(c, "172.31.16.5/20")},
+		"fan-252": {mustParseCIDR(c, "252.16.5.1/8")},
+	}
+	var ifaces []net.Interface
+	for _, name := range names {
+		ifaces = append(ifaces, net.Interface{Name: name, MTU: 9001})
+	}
+	s.PatchValue(&netInterfaces, func() ([]net.Interface, error) {
+		return ifaces, nil
+	})
+	s.PatchValue(&interfaceAddrs, func(iface *net.Interface) ([]net.Addr, error) {
+		return addrs[iface.Name], nil
+	})
+}
+
+func mustParseCIDR(c *gc.C, value string) *net.IPNet {
+	ip, ipNet, err := net.ParseCIDR(value)
+	c.Assert(err, jc.ErrorIsNil)
+	ipNet.IP = ip
+	return ipNet
+}
+
+func (s *NetworkSuite) TestFanNetworkConfig(c *gc.C) {
+	s.patchInterfaces(c, "lo", "eth0", "fan-252")
+	fanConfig, err := network.ParseFanConfig("10.0.0.0/16=253.0.0.0/8 172.31.0.0/16=252.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+
+	config, err := FanNetworkConfig(fanConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, jc.DeepEquals, BridgeNetworkConfig("fan-252", 8951, nil))
+}
+
+func (s *NetworkSuite) TestFanNetworkConfigMissingBridge(c *gc.C) {
+	s.patchInterfaces(c, "lo", "eth0")
+	fanConfig, err := network.ParseFanConfig("172.31.0.0/16=252.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = FanNetworkConfig(fanConfig)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `fan bridge "fan-252" for underlay address 172.31.16.5 not found`)
+}
+
+func (s *NetworkSuite) TestFanNetworkConfigNoUnderlayAddress(c *gc.C) {
+	s.patchInterfaces(c, "lo", "eth0")
+	fanConfig, err := network.ParseFanConfig("10.0.0.0/16=253.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = FanNetworkConfig(fanConfig)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `host address in fan underlay "10.0.0.0/16=253.0.0.0/8" not found`)
+}
@@ -10,9 +10,11 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/schema"
 	"github.com/juju/utils"
+	"github.com/juju/utils/set"
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/network"
 )
 
 var logger = loggo.GetLogger("juju.controller")
@@ -21,6 +23,10 @@ const (
 	// ApiPort is the port used for api connections.
 	ApiPort = "api-port"
 
+	// APIAllowKey is the key for the comma-separated list of source
+	// CIDRs allowed to connect to the API port.
+	APIAllowKey = "api-allow"
+
 	// AuditingEnabled determines whether the controller will record
 	// auditing information.
 	AuditingEnabled = "auditing-enabled"
@@ -64,6 +70,7 @@ const (
 // for a controller, never a model.
 var ControllerOnlyConfigAttributes = []string{
 	ApiPort,
+	APIAllowKey,
 	StatePort,
 	CACertKey,
 	ControllerUUIDKey,
@@ -72,6 +79,16 @@ var ControllerOnlyConfigAttributes = []string{
 	SetNumaControlPolicyKey,
 }
 
+// AllowedUpdateConfigAttributes contains the controller config
+// attributes that may be changed after the controller has been
+// bootstrapped.
+var AllowedUpdateConfigAttributes = set.NewStrings(
+	APIAllowKey,
+	AuditingEnabled,
+	IdentityURL,
+	IdentityPublicKey,
+)
+
 // ControllerOnlyAttribute returns true if the specified attribute name
 // is only relevant for a controller.
 func ControllerOnlyAttribute(attr string) bool {
@@ -151,6 +168,16 @@ func (c Config) APIPort() int {
 	return c.mustInt(ApiPort)
 }
 
+// APIAllow returns the source CIDRs allowed to connect to the API
+// port. An empty result means connections are allowed from any source.
+func (c Config) APIAllow() []string {
+	cidrs, err := network.ParseCIDRs(c.asString(APIAllowKey))
+	if err != nil {
+		panic(err) // should be prevented by Validate
+	}
+	return cidrs
+}
+
 // AuditingEnabled returns whether or not auditing has been enabled
 // for the environment. The default is false.
 func (c Config) AuditingEnabled() bool {
@@ -223,6 +250,12 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[APIAllowKey].(string); ok {
+		if _, err := network.ParseCIDRs(v); err != nil {
+			return errors.Annotate(err, "invalid api-allow")
+		}
+	}
+
 	caCert, caCertOK := c.CACert()
 	if !caCertOK {
 		return errors.Errorf("missing CA certificate")
@@ -238,21 +271,57 @@ func Validate(c Config) error {
 	return nil
 }
 
+// ValidateUpdate returns a copy of the config with the given attributes
+// updated and removed, after checking that the attributes may be changed
+// after bootstrap and that the resulting config is valid. Updated values
+// are coerced to their expected types, so that values supplied as strings
+// (for example, on the command line) are stored correctly.
+func (c Config) ValidateUpdate(updateAttrs map[string]interface{}, removeAttrs []string) (Config, error) {
+	result := make(Config)
+	for attr, val := range c {
+		result[attr] = val
+	}
+	for attr, val := range updateAttrs {
+		if !AllowedUpdateConfigAttributes.Contains(attr) {
+			return nil, errors.Errorf("cannot change %q after bootstrap", attr)
+		}
+		coerced, err := configFields[attr].Coerce(val, []string{attr})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[attr] = coerced
+	}
+	for _, attr := range removeAttrs {
+		if !AllowedUpdateConfigAttributes.Contains(attr) {
+			return nil, errors.Errorf("cannot remove %q after bootstrap", attr)
+		}
+		delete(result, attr)
+	}
+	if err := result.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
 // GenerateControllerCertAndKey makes sure that the config has a CACert and
 // CAPrivateKey, generates and returns new certificate and key.
 func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string) (string, string, error) {
 	return cert.NewDefaultServer(caCert, caKey, hostAddresses)
 }
 
-var configChecker = schema.FieldMap(schema.Fields{
+var configFields = schema.Fields{
 	AuditingEnabled:         schema.Bool(),
 	ApiPort:                 schema.ForceInt(),
+	APIAllowKey:             schema.String(),
 	StatePort:               schema.ForceInt(),
 	IdentityURL:             schema.String(),
 	IdentityPublicKey:       schema.String(),
 	SetNumaControlPolicyKey: schema.Bool(),
-}, schema.Defaults{
+}
+
+var configChecker = schema.FieldMap(configFields, schema.Defaults{
 	ApiPort:                 DefaultAPIPort,
+	APIAllowKey:             schema.Omit,
 	AuditingEnabled:         DefaultAuditingEnabled,
 	StatePort:               DefaultStatePort,
 	IdentityURL:             schema.Omit,
@@ -72,3 +72,66 @@ func (s *ConfigSuite) TestGenerateControllerCertAndKey(c *gc.C) {
 		c.Assert(sanIPs, jc.SameContents, test.sanValues)
 	}
 }
+
+func (s *ConfigSuite) TestValidateUpdate(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		controller.IdentityURL: "https://example.com",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newCfg, err := cfg.ValidateUpdate(map[string]interface{}{
+		controller.AuditingEnabled: "true",
+	}, []string{controller.IdentityURL})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newCfg.AuditingEnabled(), jc.IsTrue)
+	c.Assert(newCfg.IdentityURL(), gc.Equals, "")
+
+	// The original config is left unchanged.
+	c.Assert(cfg.AuditingEnabled(), jc.IsFalse)
+	c.Assert(cfg.IdentityURL(), gc.Equals, "https://example.com")
+}
+
+func (s *ConfigSuite) TestValidateUpdateErrors(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		update map[string]interface{}
+		remove []string
+		err    string
+	}{{
+		update: map[string]interface{}{controller.ApiPort: 1234},
+		err:    `cannot change "api-port" after bootstrap`,
+	}, {
+		remove: []string{controller.CACertKey},
+		err:    `cannot remove "ca-cert" after bootstrap`,
+	}, {
+		update: map[string]interface{}{controller.AuditingEnabled: "maybe"},
+		err:    `auditing-enabled: expected bool, got string\("maybe"\)`,
+	}, {
+		update: map[string]interface{}{controller.IdentityURL: "http://example.com"},
+		err:    "URL needs to be https",
+	}, {
+		update: map[string]interface{}{controller.IdentityPublicKey: "invalid"},
+		err:    "invalid identity public key: .*",
+	}, {
+		update: map[string]interface{}{controller.APIAllowKey: "10.0.0.0/8,bogus"},
+		err:    `invalid api-allow: CIDR "bogus" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := cfg.ValidateUpdate(test.update, test.remove)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestAPIAllow(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIAllow(), gc.HasLen, 0)
+
+	cfg, err = cfg.ValidateUpdate(map[string]interface{}{
+		controller.APIAllowKey: "10.0.0.0/8,192.168.0.0/16",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIAllow(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+}
@@ -0,0 +1,231 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmmeta reads the settings that charms may declare in their
+// metadata.yaml, alongside the standard charm metadata, to control how
+// juju runs them: hook-timeout, hook-lock and health-checks.
+package charmmeta
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// Filename is the name of the file, in the root of a charm, that
+// holds the charm's metadata.
+const Filename = "metadata.yaml"
+
+const (
+	defaultCheckInterval  = time.Minute
+	defaultCheckTimeout   = 10 * time.Second
+	defaultCheckThreshold = 3
+)
+
+const (
+	// HookLockMachine makes every hook and action hold the machine
+	// lock while it runs, so that no two units on a machine ever run
+	// charm code at the same time.
+	HookLockMachine = "machine"
+
+	// HookLockUnit makes hooks and actions hold only the lock of the
+	// unit running them, except for install and upgrade-charm hooks,
+	// which always hold the machine lock.
+	HookLockUnit = "unit"
+)
+
+// Metadata holds the settings a charm declares in its metadata.yaml to
+// control how juju runs it.
+type Metadata struct {
+	// HookTimeout holds the maximum time any of the charm's hooks may
+	// run for, or nil if the charm leaves it to the model.
+	HookTimeout *time.Duration
+
+	// HookLock holds the lock the charm's hooks and actions must hold,
+	// either HookLockMachine or HookLockUnit, or "" if the charm leaves
+	// it to the model.
+	HookLock string
+
+	// HealthChecks holds the charm's health checks, sorted by name.
+	HealthChecks []HealthCheck
+}
+
+// HealthCheck describes a health check declared in the "health-checks"
+// section of a charm's metadata. Exactly one of Exec, HTTP and TCP
+// is set.
+type HealthCheck struct {
+	// Name identifies the check within the charm.
+	Name string
+
+	// Exec holds a command, run in the charm directory, that exits
+	// with a non-zero code when the check fails.
+	Exec string
+
+	// HTTP holds a URL which must respond to a GET request with a
+	// non-error status code.
+	HTTP string
+
+	// TCP holds a local port which must accept connections.
+	TCP int
+
+	// Interval is how often the check is run.
+	Interval time.Duration
+
+	// Timeout is how long the check may take before it is considered
+	// to have failed.
+	Timeout time.Duration
+
+	// Threshold is the number of consecutive failures after which
+	// the unit is considered unhealthy.
+	Threshold int
+}
+
+// metadataDoc holds the fields of metadata.yaml read by this package.
+type metadataDoc struct {
+	HookTimeout  string                    `yaml:"hook-timeout"`
+	HookLock     string                    `yaml:"hook-lock"`
+	HealthChecks map[string]healthCheckDoc `yaml:"health-checks"`
+}
+
+// healthCheckDoc is the form in which a health check is declared in
+// metadata.yaml, for example:
+//
+//	health-checks:
+//	  web:
+//	    http: http://localhost:8080/health
+//	    interval: 30s
+//	    threshold: 3
+//	  db:
+//	    tcp: 5432
+//	  queue:
+//	    exec: scripts/check-queue
+//	    timeout: 5s
+type healthCheckDoc struct {
+	Exec      string `yaml:"exec"`
+	HTTP      string `yaml:"http"`
+	TCP       int    `yaml:"tcp"`
+	Interval  string `yaml:"interval"`
+	Timeout   string `yaml:"timeout"`
+	Threshold int    `yaml:"threshold"`
+}
+
+// Parse parses and validates the settings in the given contents of a
+// metadata.yaml file.
+func Parse(data []byte) (*Metadata, error) {
+	var doc metadataDoc
+	if err := goyaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Annotate(err, "cannot parse charm metadata")
+	}
+	var meta Metadata
+	if doc.HookTimeout != "" {
+		timeout, err := time.ParseDuration(doc.HookTimeout)
+		if err != nil || timeout < 0 {
+			return nil, errors.NotValidf("charm hook-timeout %q", doc.HookTimeout)
+		}
+		meta.HookTimeout = &timeout
+	}
+	switch doc.HookLock {
+	case "", HookLockMachine, HookLockUnit:
+		meta.HookLock = doc.HookLock
+	default:
+		return nil, errors.NotValidf("charm hook-lock %q", doc.HookLock)
+	}
+	for name, checkDoc := range doc.HealthChecks {
+		check, err := parseHealthCheck(name, checkDoc)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid health check %q", name)
+		}
+		meta.HealthChecks = append(meta.HealthChecks, check)
+	}
+	sort.Sort(byName(meta.HealthChecks))
+	return &meta, nil
+}
+
+func parseHealthCheck(name string, doc healthCheckDoc) (HealthCheck, error) {
+	check := HealthCheck{
+		Name:      name,
+		Exec:      doc.Exec,
+		HTTP:      doc.HTTP,
+		TCP:       doc.TCP,
+		Interval:  defaultCheckInterval,
+		Timeout:   defaultCheckTimeout,
+		Threshold: defaultCheckThreshold,
+	}
+	kinds := 0
+	if check.Exec != "" {
+		kinds++
+	}
+	if check.HTTP != "" {
+		u, err := url.Parse(check.HTTP)
+		if err != nil {
+			return HealthCheck{}, errors.Trace(err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return HealthCheck{}, errors.Errorf("unsupported URL scheme %q", u.Scheme)
+		}
+		kinds++
+	}
+	if check.TCP != 0 {
+		if check.TCP < 0 || check.TCP > 65535 {
+			return HealthCheck{}, errors.Errorf("invalid port %d", check.TCP)
+		}
+		kinds++
+	}
+	if kinds != 1 {
+		return HealthCheck{}, errors.New("exactly one of exec, http and tcp must be specified")
+	}
+	if doc.Interval != "" {
+		interval, err := time.ParseDuration(doc.Interval)
+		if err != nil {
+			return HealthCheck{}, errors.Annotate(err, "invalid interval")
+		}
+		if interval <= 0 {
+			return HealthCheck{}, errors.Errorf("invalid interval %q", doc.Interval)
+		}
+		check.Interval = interval
+	}
+	if doc.Timeout != "" {
+		timeout, err := time.ParseDuration(doc.Timeout)
+		if err != nil {
+			return HealthCheck{}, errors.Annotate(err, "invalid timeout")
+		}
+		if timeout <= 0 {
+			return HealthCheck{}, errors.Errorf("invalid timeout %q", doc.Timeout)
+		}
+		check.Timeout = timeout
+	}
+	if check.Timeout > check.Interval {
+		check.Timeout = check.Interval
+	}
+	if doc.Threshold < 0 {
+		return HealthCheck{}, errors.Errorf("invalid threshold %d", doc.Threshold)
+	} else if doc.Threshold > 0 {
+		check.Threshold = doc.Threshold
+	}
+	return check, nil
+}
+
+type byName []HealthCheck
+
+func (b byName) Len() int           { return len(b) }
+func (b byName) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byName) Less(i, j int) bool { return b[i].Name < b[j].Name }
+
+// ReadDir returns the validated settings in the metadata held in the
+// charm directory at the given path. If the directory holds no
+// metadata, an error satisfying errors.IsNotFound is returned.
+func ReadDir(path string) (*Metadata, error) {
+	data, err := ioutil.ReadFile(filepath.Join(path, Filename))
+	if os.IsNotExist(err) {
+		return nil, errors.NewNotFound(err, "cannot read charm metadata")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot read charm metadata")
+	}
+	return Parse(data)
+}
@@ -0,0 +1,142 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmmeta_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/charmmeta"
+)
+
+type MetadataSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&MetadataSuite{})
+
+func (s *MetadataSuite) TestParse(c *gc.C) {
+	meta, err := charmmeta.Parse([]byte(`
+name: wordpress
+summary: blog
+hook-timeout: 10m
+hook-lock: unit
+health-checks:
+  web:
+    http: http://localhost:8080/health
+    interval: 30s
+    threshold: 5
+  db:
+    tcp: 3306
+  queue:
+    exec: scripts/check-queue
+    interval: 2s
+    timeout: 5s
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	timeout := 10 * time.Minute
+	c.Assert(meta, jc.DeepEquals, &charmmeta.Metadata{
+		HookTimeout: &timeout,
+		HookLock:    "unit",
+		HealthChecks: []charmmeta.HealthCheck{{
+			Name:      "db",
+			TCP:       3306,
+			Interval:  time.Minute,
+			Timeout:   10 * time.Second,
+			Threshold: 3,
+		}, {
+			Name:      "queue",
+			Exec:      "scripts/check-queue",
+			Interval:  2 * time.Second,
+			Timeout:   2 * time.Second,
+			Threshold: 3,
+		}, {
+			Name:      "web",
+			HTTP:      "http://localhost:8080/health",
+			Interval:  30 * time.Second,
+			Timeout:   10 * time.Second,
+			Threshold: 5,
+		}},
+	})
+}
+
+func (s *MetadataSuite) TestParseNone(c *gc.C) {
+	meta, err := charmmeta.Parse([]byte("name: wordpress\nsummary: blog\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(meta, jc.DeepEquals, &charmmeta.Metadata{})
+}
+
+func (s *MetadataSuite) TestParseInvalid(c *gc.C) {
+	for i, test := range []struct {
+		meta string
+		err  string
+	}{{
+		meta: "hook-timeout: soon",
+		err:  `charm hook-timeout "soon" not valid`,
+	}, {
+		meta: "hook-timeout: -1s",
+		err:  `charm hook-timeout "-1s" not valid`,
+	}, {
+		meta: "hook-lock: application",
+		err:  `charm hook-lock "application" not valid`,
+	}, {
+		meta: "health-checks:\n  web: {}",
+		err:  `invalid health check "web": exactly one of exec, http and tcp must be specified`,
+	}, {
+		meta: "health-checks:\n  web: {tcp: 80, exec: /bin/true}",
+		err:  `invalid health check "web": exactly one of exec, http and tcp must be specified`,
+	}, {
+		meta: "health-checks:\n  web: {http: ftp://localhost/}",
+		err:  `invalid health check "web": unsupported URL scheme "ftp"`,
+	}, {
+		meta: "health-checks:\n  web: {tcp: 70000}",
+		err:  `invalid health check "web": invalid port 70000`,
+	}, {
+		meta: "health-checks:\n  web: {tcp: 80, interval: soon}",
+		err:  `invalid health check "web": invalid interval: time: invalid duration "?soon"?`,
+	}, {
+		meta: "health-checks:\n  web: {tcp: 80, interval: -1s}",
+		err:  `invalid health check "web": invalid interval "-1s"`,
+	}, {
+		meta: "health-checks:\n  web: {tcp: 80, timeout: 0s}",
+		err:  `invalid health check "web": invalid timeout "0s"`,
+	}, {
+		meta: "health-checks:\n  web: {tcp: 80, threshold: -2}",
+		err:  `invalid health check "web": invalid threshold -2`,
+	}, {
+		meta: "hook-lock: [unit]",
+		err:  `cannot parse charm metadata: .*`,
+	}, {
+		meta: "hook-timeout: [10m]",
+		err:  `cannot parse charm metadata: .*`,
+	}} {
+		c.Logf("test %d: %s", i, test.meta)
+		_, err := charmmeta.Parse([]byte(test.meta + "\n"))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *MetadataSuite) TestReadDir(c *gc.C) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, charmmeta.Filename), []byte("name: mysql\nhook-timeout: 1m\nhook-lock: machine\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta, err := charmmeta.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(meta.HookTimeout, gc.NotNil)
+	c.Assert(*meta.HookTimeout, gc.Equals, time.Minute)
+	c.Assert(meta.HookLock, gc.Equals, "machine")
+}
+
+func (s *MetadataSuite) TestReadDirMissingMetadata(c *gc.C) {
+	_, err := charmmeta.ReadDir(c.MkDir())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "cannot read charm metadata: .*")
+}
@@ -32,6 +32,14 @@ type application struct {
 	Exposed_    bool `yaml:"exposed,omitempty"`
 	MinUnits_   int  `yaml:"min-units,omitempty"`
 
+	// Trust is true if the application may access the model's
+	// cloud credential.
+	Trust_ bool `yaml:"trust,omitempty"`
+
+	// ExposedCIDRs holds the CIDRs the application is exposed to, if
+	// it is exposed to less than the whole internet.
+	ExposedCIDRs_ []string `yaml:"exposed-cidrs,omitempty"`
+
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
 
@@ -64,6 +72,8 @@ type ApplicationArgs struct {
 	ForceCharm           bool
 	Exposed              bool
 	MinUnits             int
+	Trust                bool
+	ExposedCIDRs         []string
 	Settings             map[string]interface{}
 	SettingsRefCount     int
 	Leader               string
@@ -83,6 +93,8 @@ func newApplication(args ApplicationArgs) *application {
 		ForceCharm_:           args.ForceCharm,
 		Exposed_:              args.Exposed,
 		MinUnits_:             args.MinUnits,
+		Trust_:                args.Trust,
+		ExposedCIDRs_:         args.ExposedCIDRs,
 		Settings_:             args.Settings,
 		SettingsRefCount_:     args.SettingsRefCount,
 		Leader_:               args.Leader,
@@ -144,6 +156,16 @@ func (s *application) MinUnits() int {
 	return s.MinUnits_
 }
 
+// Trust implements Application.
+func (s *application) Trust() bool {
+	return s.Trust_
+}
+
+// ExposedCIDRs implements Application.
+func (s *application) ExposedCIDRs() []string {
+	return s.ExposedCIDRs_
+}
+
 // Settings implements Application.
 func (s *application) Settings() map[string]interface{} {
 	return s.Settings_
@@ -308,6 +330,8 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"force-charm":         schema.Bool(),
 		"exposed":             schema.Bool(),
 		"min-units":           schema.Int(),
+		"trust":               schema.Bool(),
+		"exposed-cidrs":       schema.List(schema.String()),
 		"status":              schema.StringMap(schema.Any()),
 		"settings":            schema.StringMap(schema.Any()),
 		"settings-refcount":   schema.Int(),
@@ -322,6 +346,8 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"force-charm":   false,
 		"exposed":       false,
 		"min-units":     int64(0),
+		"trust":         false,
+		"exposed-cidrs": schema.Omit,
 		"leader":        "",
 		"metrics-creds": "",
 	}
@@ -347,6 +373,8 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		ForceCharm_:           valid["force-charm"].(bool),
 		Exposed_:              valid["exposed"].(bool),
 		MinUnits_:             int(valid["min-units"].(int64)),
+		Trust_:                valid["trust"].(bool),
+		ExposedCIDRs_:         convertToStringSlice(valid["exposed-cidrs"]),
 		Settings_:             valid["settings"].(map[string]interface{}),
 		SettingsRefCount_:     int(valid["settings-refcount"].(int64)),
 		Leader_:               valid["leader"].(string),
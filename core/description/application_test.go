@@ -111,6 +111,8 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 		ForceCharm:           true,
 		Exposed:              true,
 		MinUnits:             42, // no judgement is made by the migration code
+		Trust:                true,
+		ExposedCIDRs:         []string{"10.0.0.0/8"},
 		Settings: map[string]interface{}{
 			"key": "value",
 		},
@@ -133,6 +135,8 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 	c.Assert(application.ForceCharm(), jc.IsTrue)
 	c.Assert(application.Exposed(), jc.IsTrue)
 	c.Assert(application.MinUnits(), gc.Equals, 42)
+	c.Assert(application.Trust(), jc.IsTrue)
+	c.Assert(application.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
 	c.Assert(application.Settings(), jc.DeepEquals, args.Settings)
 	c.Assert(application.SettingsRefCount(), gc.Equals, 1)
 	c.Assert(application.Leader(), gc.Equals, "magic/1")
@@ -180,6 +184,15 @@ func (s *ApplicationSerializationSuite) TestParsingSerializedData(c *gc.C) {
 	c.Assert(application, jc.DeepEquals, svc)
 }
 
+func (s *ApplicationSerializationSuite) TestExposedCIDRs(c *gc.C) {
+	initial := minimalApplication()
+	initial.Exposed_ = true
+	initial.ExposedCIDRs_ = []string{"10.0.0.0/8", "192.168.1.0/24"}
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+}
+
 func (s *ApplicationSerializationSuite) TestAnnotations(c *gc.C) {
 	initial := minimalApplication()
 	annotations := map[string]string{
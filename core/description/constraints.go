@@ -22,6 +22,8 @@ type ConstraintsArgs struct {
 	Tags   []string
 
 	VirtType string
+
+	Zones []string
 }
 
 func newConstraints(args ConstraintsArgs) *constraints {
@@ -35,6 +37,11 @@ func newConstraints(args ConstraintsArgs) *constraints {
 	copy(tags, args.Tags)
 	spaces := make([]string, len(args.Spaces))
 	copy(spaces, args.Spaces)
+	var zones []string
+	if len(args.Zones) > 0 {
+		zones = make([]string, len(args.Zones))
+		copy(zones, args.Zones)
+	}
 	return &constraints{
 		Version:       1,
 		Architecture_: args.Architecture,
@@ -47,6 +54,7 @@ func newConstraints(args ConstraintsArgs) *constraints {
 		Spaces_:       spaces,
 		Tags_:         tags,
 		VirtType_:     args.VirtType,
+		Zones_:        zones,
 	}
 }
 
@@ -65,6 +73,8 @@ type constraints struct {
 	Tags_   []string `yaml:"tags,omitempty"`
 
 	VirtType_ string `yaml:"virt-type,omitempty"`
+
+	Zones_ []string `yaml:"zones,omitempty"`
 }
 
 // Architecture implements Constraints.
@@ -127,6 +137,16 @@ func (c *constraints) VirtType() string {
 	return c.VirtType_
 }
 
+// Zones implements Constraints.
+func (c *constraints) Zones() []string {
+	var zones []string
+	if count := len(c.Zones_); count > 0 {
+		zones = make([]string, count)
+		copy(zones, c.Zones_)
+	}
+	return zones
+}
+
 func importConstraints(source map[string]interface{}) (*constraints, error) {
 	version, err := getVersion(source)
 	if err != nil {
@@ -161,6 +181,8 @@ func importConstraintsV1(source map[string]interface{}) (*constraints, error) {
 		"tags":   schema.List(schema.String()),
 
 		"virt-type": schema.String(),
+
+		"zones": schema.List(schema.String()),
 	}
 	// Some values don't have to be there.
 	defaults := schema.Defaults{
@@ -176,6 +198,10 @@ func importConstraintsV1(source map[string]interface{}) (*constraints, error) {
 		"tags":   schema.Omit,
 
 		"virt-type": "",
+
+		// Zones were added after version 1 was defined, so
+		// older serialized constraints will not have them.
+		"zones": schema.Omit,
 	}
 	checker := schema.FieldMap(fields, defaults)
 
@@ -201,6 +227,8 @@ func importConstraintsV1(source map[string]interface{}) (*constraints, error) {
 		Tags_:   convertToStringSlice(valid["tags"]),
 
 		VirtType_: valid["virt-type"].(string),
+
+		Zones_: convertToStringSlice(valid["zones"]),
 	}, nil
 }
 
@@ -219,5 +247,6 @@ func (c ConstraintsArgs) empty() bool {
 		c.RootDisk == 0 &&
 		c.Spaces == nil &&
 		c.Tags == nil &&
-		c.VirtType == ""
+		c.VirtType == "" &&
+		c.Zones == nil
 }
@@ -106,6 +106,24 @@ func (s *ConstraintsSerializationSuite) assertParsingSerializedConstraints(c *gc
 	c.Assert(instance, jc.DeepEquals, initial)
 }
 
+func (s *ConstraintsSerializationSuite) TestNewConstraintsWithZones(c *gc.C) {
+	args := s.allArgs()
+	args.Zones = []string{"az1", "az2"}
+	instance := newConstraints(args)
+	c.Assert(instance.Zones(), jc.DeepEquals, []string{"az1", "az2"})
+}
+
+func (s *ConstraintsSerializationSuite) TestEmptyZones(c *gc.C) {
+	instance := newConstraints(ConstraintsArgs{Architecture: "amd64"})
+	c.Assert(instance.Zones(), gc.IsNil)
+}
+
+func (s *ConstraintsSerializationSuite) TestParsingSerializedZones(c *gc.C) {
+	args := s.allArgs()
+	args.Zones = []string{"az1", "az2"}
+	s.assertParsingSerializedConstraints(c, newConstraints(args))
+}
+
 func (s *ConstraintsSerializationSuite) TestParsingSerializedVirt(c *gc.C) {
 	args := s.allArgs()
 	args.VirtType = "kvm"
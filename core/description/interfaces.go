@@ -224,6 +224,8 @@ type Constraints interface {
 	Tags() []string
 
 	VirtType() string
+
+	Zones() []string
 }
 
 // Status represents an agent, application, or workload status.
@@ -251,6 +253,8 @@ type Application interface {
 	ForceCharm() bool
 	Exposed() bool
 	MinUnits() int
+	Trust() bool
+	ExposedCIDRs() []string
 
 	Settings() map[string]interface{}
 	SettingsRefCount() int
@@ -283,6 +287,10 @@ type Unit interface {
 	MeterStatusCode() string
 	MeterStatusInfo() string
 
+	UniterState() string
+	RelationState() map[int]string
+	SkippedHooks() []string
+
 	// TODO: storage
 
 	Tools() AgentTools
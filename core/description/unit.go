@@ -44,6 +44,10 @@ type unit struct {
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
+
+	UniterState_   string         `yaml:"uniter-state,omitempty"`
+	RelationState_ map[int]string `yaml:"relation-state,omitempty"`
+	SkippedHooks_  []string       `yaml:"skipped-hooks,omitempty"`
 }
 
 // UnitArgs is an argument struct used to add a Unit to a Application in the Model.
@@ -58,6 +62,12 @@ type UnitArgs struct {
 	MeterStatusCode string
 	MeterStatusInfo string
 
+	// UniterState, RelationState and SkippedHooks hold the state the
+	// unit's uniter has persisted on the controller.
+	UniterState   string
+	RelationState map[int]string
+	SkippedHooks  []string
+
 	// TODO: storage attachment count
 }
 
@@ -75,6 +85,9 @@ func newUnit(args UnitArgs) *unit {
 		WorkloadVersion_:        args.WorkloadVersion,
 		MeterStatusCode_:        args.MeterStatusCode,
 		MeterStatusInfo_:        args.MeterStatusInfo,
+		UniterState_:            args.UniterState,
+		RelationState_:          args.RelationState,
+		SkippedHooks_:           args.SkippedHooks,
 		WorkloadStatusHistory_:  newStatusHistory(),
 		WorkloadVersionHistory_: newStatusHistory(),
 		AgentStatusHistory_:     newStatusHistory(),
@@ -128,6 +141,21 @@ func (u *unit) MeterStatusInfo() string {
 	return u.MeterStatusInfo_
 }
 
+// UniterState implements Unit.
+func (u *unit) UniterState() string {
+	return u.UniterState_
+}
+
+// RelationState implements Unit.
+func (u *unit) RelationState() map[int]string {
+	return u.RelationState_
+}
+
+// SkippedHooks implements Unit.
+func (u *unit) SkippedHooks() []string {
+	return u.SkippedHooks_
+}
+
 // Tools implements Unit.
 func (u *unit) Tools() AgentTools {
 	// To avoid a typed nil, check before returning.
@@ -294,6 +322,10 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 
 		"meter-status-code": schema.String(),
 		"meter-status-info": schema.String(),
+
+		"uniter-state":   schema.String(),
+		"relation-state": schema.Map(schema.Int(), schema.String()),
+		"skipped-hooks":  schema.List(schema.String()),
 	}
 	defaults := schema.Defaults{
 		"principal":         "",
@@ -301,6 +333,9 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 		"workload-version":  "",
 		"meter-status-code": "",
 		"meter-status-info": "",
+		"uniter-state":      "",
+		"relation-state":    schema.Omit,
+		"skipped-hooks":     schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -322,6 +357,7 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 		WorkloadVersion_:        valid["workload-version"].(string),
 		MeterStatusCode_:        valid["meter-status-code"].(string),
 		MeterStatusInfo_:        valid["meter-status-info"].(string),
+		UniterState_:            valid["uniter-state"].(string),
 		WorkloadStatusHistory_:  newStatusHistory(),
 		WorkloadVersionHistory_: newStatusHistory(),
 		AgentStatusHistory_:     newStatusHistory(),
@@ -350,6 +386,13 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 	}
 
 	result.Subordinates_ = convertToStringSlice(valid["subordinates"])
+	result.SkippedHooks_ = convertToStringSlice(valid["skipped-hooks"])
+	if relationState, ok := valid["relation-state"]; ok {
+		result.RelationState_ = make(map[int]string)
+		for id, state := range relationState.(map[interface{}]interface{}) {
+			result.RelationState_[int(id.(int64))] = state.(string)
+		}
+	}
 
 	// Tools and status are required, so we expect them to be there.
 	tools, err := importAgentTools(valid["tools"].(map[string]interface{}))
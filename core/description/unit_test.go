@@ -74,6 +74,9 @@ func (s *UnitSerializationSuite) completeUnit() *unit {
 		WorkloadVersion: "malachite",
 		MeterStatusCode: "meter code",
 		MeterStatusInfo: "meter info",
+		UniterState:     "uniter state",
+		RelationState:   map[int]string{0: "relation state"},
+		SkippedHooks:    []string{"config-changed"},
 	}
 	unit := newUnit(args)
 	unit.SetAgentStatus(minimalStatusArgs())
@@ -97,6 +100,9 @@ func (s *UnitSerializationSuite) TestNewUnit(c *gc.C) {
 	c.Assert(unit.WorkloadVersion(), gc.Equals, "malachite")
 	c.Assert(unit.MeterStatusCode(), gc.Equals, "meter code")
 	c.Assert(unit.MeterStatusInfo(), gc.Equals, "meter info")
+	c.Assert(unit.UniterState(), gc.Equals, "uniter state")
+	c.Assert(unit.RelationState(), jc.DeepEquals, map[int]string{0: "relation state"})
+	c.Assert(unit.SkippedHooks(), jc.DeepEquals, []string{"config-changed"})
 	c.Assert(unit.Tools(), gc.NotNil)
 	c.Assert(unit.WorkloadStatus(), gc.NotNil)
 	c.Assert(unit.AgentStatus(), gc.NotNil)
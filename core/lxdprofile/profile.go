@@ -0,0 +1,270 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package lxdprofile defines the LXD profiles that charms may supply,
+// in an lxd-profile.yaml file, to be applied to the LXD containers
+// their units are deployed to.
+package lxdprofile
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
+)
+
+// Filename is the name of the file, in the root of a charm, that
+// holds the charm's LXD profile.
+const Filename = "lxd-profile.yaml"
+
+// Prefix is prepended, along with the model UUID, to the names of
+// all charm LXD profiles.
+const Prefix = "juju-"
+
+// allowedConfigKeys holds the LXD configuration keys that a charm
+// profile may set. Keys such as security.privileged, which would
+// give the charm root access to the host, are deliberately absent.
+var allowedConfigKeys = map[string]bool{
+	"linux.kernel_modules": true,
+	"security.nesting":     true,
+}
+
+// allowedConfigPrefixes holds the prefixes of LXD configuration keys
+// that a charm profile may set.
+var allowedConfigPrefixes = []string{
+	"limits.",
+}
+
+// allowedDeviceTypes holds the types of LXD device that a charm
+// profile may add, other than unix-char devices.
+var allowedDeviceTypes = map[string]bool{
+	"gpu": true,
+	"usb": true,
+}
+
+// allowedUnixCharPaths holds the paths of the host character devices
+// that a charm profile may pass through to a container. Block devices,
+// and any other character devices, would give the charm access to
+// host disks or memory, so they may not be added.
+var allowedUnixCharPaths = map[string]bool{
+	"/dev/kvm":     true,
+	"/dev/net/tun": true,
+}
+
+// allowedUnixCharKeys holds the properties a unix-char device in a
+// charm profile may set. In particular, major and minor numbers may
+// not be given, as they would select an arbitrary host device.
+var allowedUnixCharKeys = map[string]bool{
+	"type": true,
+	"path": true,
+	"uid":  true,
+	"gid":  true,
+	"mode": true,
+}
+
+// nameFormat matches the names returned by Name, capturing the model
+// UUID.
+var nameFormat = regexp.MustCompile(`^` + Prefix +
+	`([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})-` +
+	`[a-z][a-z0-9]*(?:-[a-z0-9]*[a-z][a-z0-9]*)*-[0-9]+-[0-9a-f]{8}$`)
+
+// Profile holds the LXD profile supplied by a charm.
+type Profile struct {
+	// Description describes the purpose of the profile.
+	Description string `yaml:"description,omitempty"`
+
+	// Config holds LXD configuration keys and values to apply
+	// to the container.
+	Config map[string]string `yaml:"config,omitempty"`
+
+	// Devices holds the LXD devices to add to the container,
+	// keyed on device name.
+	Devices map[string]map[string]string `yaml:"devices,omitempty"`
+}
+
+// Empty returns true if the profile neither sets any configuration
+// nor adds any devices.
+func (p *Profile) Empty() bool {
+	return p == nil || (len(p.Config) == 0 && len(p.Devices) == 0)
+}
+
+// Validate returns an error if the profile sets any configuration, or
+// adds any device, that charms are not allowed to.
+func (p *Profile) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for _, key := range sortedKeys(p.Config) {
+		if !configKeyAllowed(key) {
+			return errors.NotValidf("config key %q", key)
+		}
+	}
+	for _, name := range sortedDeviceNames(p.Devices) {
+		deviceType := p.Devices[name]["type"]
+		if deviceType == "" {
+			return errors.NotValidf("device %q without type", name)
+		}
+		if deviceType == "unix-char" {
+			if err := validateUnixChar(name, p.Devices[name]); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
+		if !allowedDeviceTypes[deviceType] {
+			return errors.NotValidf("device %q of type %q", name, deviceType)
+		}
+	}
+	return nil
+}
+
+func validateUnixChar(name string, device map[string]string) error {
+	for _, key := range sortedKeys(device) {
+		if !allowedUnixCharKeys[key] {
+			return errors.NotValidf("unix-char device %q with %q", name, key)
+		}
+	}
+	path := device["path"]
+	if !allowedUnixCharPaths[path] {
+		return errors.NotValidf("unix-char device %q with path %q", name, path)
+	}
+	return nil
+}
+
+func configKeyAllowed(key string) bool {
+	if allowedConfigKeys[key] {
+		return true
+	}
+	for _, prefix := range allowedConfigPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse parses and validates the contents of an lxd-profile.yaml file.
+func Parse(data []byte) (*Profile, error) {
+	var profile Profile
+	if err := yaml.Unmarshal(data, &profile); err != nil {
+		return nil, errors.Annotatef(err, "cannot parse %s", Filename)
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, errors.Annotatef(err, "invalid %s", Filename)
+	}
+	return &profile, nil
+}
+
+// ReadCharm returns the validated LXD profile of the given charm, or
+// nil if the charm does not supply one. Only charm archives and charm
+// directories can supply profiles.
+func ReadCharm(ch charm.Charm) (*Profile, error) {
+	switch ch := ch.(type) {
+	case *charm.CharmArchive:
+		return ReadArchive(ch.Path)
+	case *charm.CharmDir:
+		return ReadDir(ch.Path)
+	}
+	return nil, nil
+}
+
+// ReadArchive returns the validated LXD profile held in the charm
+// archive at the given path, or nil if the archive does not hold one.
+func ReadArchive(path string) (*Profile, error) {
+	zipr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot open charm archive")
+	}
+	defer zipr.Close()
+	for _, f := range zipr.File {
+		if f.Name != Filename {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot open %s", Filename)
+		}
+		defer r.Close()
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot read %s", Filename)
+		}
+		return Parse(data)
+	}
+	return nil, nil
+}
+
+// ReadDir returns the validated LXD profile held in the charm
+// directory at the given path, or nil if the directory does not
+// hold one.
+func ReadDir(path string) (*Profile, error) {
+	data, err := ioutil.ReadFile(filepath.Join(path, Filename))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot read %s", Filename)
+	}
+	return Parse(data)
+}
+
+// Name returns the name of the LXD profile applied to machines hosting
+// units of the given application, in the model with the given UUID,
+// when its charm at the given revision supplies the given profile.
+// The name ends with a hash of the profile's contents, so a profile
+// that already exists with the name can be used as it is.
+func Name(modelUUID, application string, revision int, profile *Profile) string {
+	return fmt.Sprintf("%s%s-%s-%d-%s", Prefix, modelUUID, application, revision, profile.hash())
+}
+
+// IsCharmProfile returns true if the named LXD profile is one created
+// for a charm deployed to the model with the given UUID.
+func IsCharmProfile(modelUUID, name string) bool {
+	match := nameFormat.FindStringSubmatch(name)
+	return match != nil && match[1] == modelUUID
+}
+
+// hash returns a short hash of the configuration and devices in the
+// profile. Keys are written in sorted order, so the result does not
+// depend on map iteration order.
+func (p *Profile) hash() string {
+	h := sha256.New()
+	if p != nil {
+		for _, key := range sortedKeys(p.Config) {
+			fmt.Fprintf(h, "config %q=%q\n", key, p.Config[key])
+		}
+		for _, name := range sortedDeviceNames(p.Devices) {
+			device := p.Devices[name]
+			for _, key := range sortedKeys(device) {
+				fmt.Fprintf(h, "device %q %q=%q\n", name, key, device[key])
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)[:4])
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedDeviceNames(devices map[string]map[string]string) []string {
+	names := make([]string, 0, len(devices))
+	for name := range devices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
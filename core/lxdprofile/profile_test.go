@@ -0,0 +1,157 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxdprofile_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lxdprofile"
+)
+
+type ProfileSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ProfileSuite{})
+
+const validProfile = `
+description: allow nested containers and kernel modules
+config:
+  security.nesting: "true"
+  linux.kernel_modules: openvswitch,nbd
+  limits.memory: 2GB
+devices:
+  kvm:
+    type: unix-char
+    path: /dev/kvm
+`
+
+func (*ProfileSuite) TestParse(c *gc.C) {
+	profile, err := lxdprofile.Parse([]byte(validProfile))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, &lxdprofile.Profile{
+		Description: "allow nested containers and kernel modules",
+		Config: map[string]string{
+			"security.nesting":     "true",
+			"linux.kernel_modules": "openvswitch,nbd",
+			"limits.memory":        "2GB",
+		},
+		Devices: map[string]map[string]string{
+			"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+		},
+	})
+	c.Assert(profile.Empty(), jc.IsFalse)
+}
+
+func (*ProfileSuite) TestParseInvalid(c *gc.C) {
+	for i, test := range []struct {
+		yaml string
+		err  string
+	}{{
+		yaml: "config: [",
+		err:  "cannot parse lxd-profile.yaml: .*",
+	}, {
+		yaml: "config:\n  boot.autostart: \"true\"",
+		err:  `invalid lxd-profile.yaml: config key "boot.autostart" not valid`,
+	}, {
+		yaml: "config:\n  raw.lxc: lxc.aa_profile=unconfined",
+		err:  `invalid lxd-profile.yaml: config key "raw.lxc" not valid`,
+	}, {
+		yaml: "devices:\n  root:\n    type: disk\n    path: /",
+		err:  `invalid lxd-profile.yaml: device "root" of type "disk" not valid`,
+	}, {
+		yaml: "devices:\n  eth1:\n    nictype: bridged",
+		err:  `invalid lxd-profile.yaml: device "eth1" without type not valid`,
+	}, {
+		yaml: "config:\n  security.privileged: \"true\"",
+		err:  `invalid lxd-profile.yaml: config key "security.privileged" not valid`,
+	}, {
+		yaml: "devices:\n  sda:\n    type: unix-block\n    path: /dev/sda",
+		err:  `invalid lxd-profile.yaml: device "sda" of type "unix-block" not valid`,
+	}, {
+		yaml: "devices:\n  mem:\n    type: unix-char\n    path: /dev/mem",
+		err:  `invalid lxd-profile.yaml: unix-char device "mem" with path "/dev/mem" not valid`,
+	}, {
+		yaml: "devices:\n  kvm:\n    type: unix-char",
+		err:  `invalid lxd-profile.yaml: unix-char device "kvm" with path "" not valid`,
+	}, {
+		yaml: "devices:\n  kvm:\n    type: unix-char\n    path: /dev/kvm\n    major: \"8\"\n    minor: \"0\"",
+		err:  `invalid lxd-profile.yaml: unix-char device "kvm" with "major" not valid`,
+	}, {
+		yaml: "devices:\n  kvm:\n    type: unix-char\n    path: /dev/kvm\n    source: /dev/sda",
+		err:  `invalid lxd-profile.yaml: unix-char device "kvm" with "source" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.yaml)
+		_, err := lxdprofile.Parse([]byte(test.yaml))
+		c.Check(err, gc.ErrorMatches, test.err)
+		if i > 0 {
+			c.Check(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+		}
+	}
+}
+
+func (*ProfileSuite) TestEmpty(c *gc.C) {
+	var profile *lxdprofile.Profile
+	c.Assert(profile.Empty(), jc.IsTrue)
+	c.Assert((&lxdprofile.Profile{Description: "nothing"}).Empty(), jc.IsTrue)
+	c.Assert(profile.Validate(), jc.ErrorIsNil)
+}
+
+func (*ProfileSuite) TestReadDir(c *gc.C) {
+	dir := c.MkDir()
+	profile, err := lxdprofile.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, gc.IsNil)
+
+	err = ioutil.WriteFile(filepath.Join(dir, lxdprofile.Filename), []byte(validProfile), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	profile, err = lxdprofile.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile.Config["security.nesting"], gc.Equals, "true")
+}
+
+const modelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+func (*ProfileSuite) TestName(c *gc.C) {
+	profile := &lxdprofile.Profile{Config: map[string]string{"security.nesting": "true"}}
+	name := lxdprofile.Name(modelUUID, "nova-compute", 42, profile)
+	c.Assert(name, gc.Matches, "juju-"+modelUUID+"-nova-compute-42-[0-9a-f]{8}")
+	c.Assert(lxdprofile.IsCharmProfile(modelUUID, name), jc.IsTrue)
+
+	// The name depends on the contents of the profile.
+	c.Assert(lxdprofile.Name(modelUUID, "nova-compute", 42, profile), gc.Equals, name)
+	other := &lxdprofile.Profile{Config: map[string]string{"security.nesting": "false"}}
+	c.Assert(lxdprofile.Name(modelUUID, "nova-compute", 42, other), gc.Not(gc.Equals), name)
+	described := &lxdprofile.Profile{
+		Description: "nesting",
+		Config:      map[string]string{"security.nesting": "true"},
+	}
+	c.Assert(lxdprofile.Name(modelUUID, "nova-compute", 42, described), gc.Equals, name)
+}
+
+func (*ProfileSuite) TestIsCharmProfile(c *gc.C) {
+	profile := &lxdprofile.Profile{Config: map[string]string{"security.nesting": "true"}}
+	name := lxdprofile.Name(modelUUID, "nova-compute", 42, profile)
+	otherUUID := "deadbeef-0bad-400d-8000-4b1d0d06f00e"
+	for i, test := range []struct {
+		name   string
+		expect bool
+	}{
+		{name, true},
+		{lxdprofile.Name(otherUUID, "nova-compute", 42, profile), false},
+		{"juju-" + modelUUID, false},
+		{"juju-" + modelUUID + "-nova-compute-42", false},
+		{name + "-1", false},
+		{"default", false},
+	} {
+		c.Logf("test %d: %s", i, test.name)
+		c.Check(lxdprofile.IsCharmProfile(modelUUID, test.name), gc.Equals, test.expect)
+	}
+}
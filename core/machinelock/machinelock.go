@@ -0,0 +1,115 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package machinelock provides the lock that serialises hooks and other
+// operations that change a machine. The lock may be held exclusively, as
+// the machine-wide lock always has been, or shared, by the hooks of units
+// whose hook-lock policy allows them to run in parallel with each other.
+// Exclusive holders exclude all other holders; shared holders exclude
+// exclusive holders only.
+//
+// The lock is built from named mutexes: the machine mutex, and a fixed
+// number of shared slots. A shared holder takes any one free slot; an
+// exclusive holder takes the machine mutex and then every slot, always in
+// the same order, so the two can never deadlock. At most SharedSlots
+// holders can share the lock at once; any more wait for a slot to be
+// released.
+package machinelock
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/mutex"
+)
+
+var logger = loggo.GetLogger("juju.core.machinelock")
+
+// SharedSlots is the number of shared holders that may hold the lock at
+// once.
+const SharedSlots = 16
+
+// closed is used as the Cancel channel of a mutex.Spec to try a mutex
+// once without waiting for it.
+var closed = make(chan struct{})
+
+func init() {
+	close(closed)
+}
+
+// Acquire acquires the machine lock named in spec exclusively, waiting
+// until no other process holds it, either exclusively or shared.
+func Acquire(spec mutex.Spec) (mutex.Releaser, error) {
+	logger.Debugf("acquire lock %q exclusively", spec.Name)
+	var held releasers
+	machine, err := mutex.Acquire(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	held = append(held, machine)
+	for i := 0; i < SharedSlots; i++ {
+		slot, err := mutex.Acquire(slotSpec(spec, i))
+		if err != nil {
+			held.Release()
+			return nil, errors.Trace(err)
+		}
+		held = append(held, slot)
+	}
+	logger.Debugf("lock %q acquired exclusively", spec.Name)
+	return held, nil
+}
+
+// AcquireShared acquires the machine lock named in spec on behalf of the
+// named holder, waiting until no process holds it exclusively. Other
+// shared holders may hold the lock at the same time.
+//
+// The holder takes the first free slot, starting from one chosen by its
+// name so that holders rarely contend for the same slot. If every slot
+// is held, it waits for the slot chosen by its name.
+func AcquireShared(spec mutex.Spec, holder string) (mutex.Releaser, error) {
+	logger.Debugf("acquire lock %q shared for %q", spec.Name, holder)
+	first := slotIndex(holder)
+	try := spec
+	try.Cancel = closed
+	for i := 0; i < SharedSlots; i++ {
+		index := (first + i) % SharedSlots
+		slot, err := mutex.Acquire(slotSpec(try, index))
+		if err == nil {
+			logger.Debugf("lock %q acquired shared for %q in slot %d", spec.Name, holder, index)
+			return slot, nil
+		}
+		if errors.Cause(err) != mutex.ErrCancelled {
+			return nil, errors.Trace(err)
+		}
+	}
+	slot, err := mutex.Acquire(slotSpec(spec, first))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Debugf("lock %q acquired shared for %q in slot %d", spec.Name, holder, first)
+	return slot, nil
+}
+
+func slotSpec(spec mutex.Spec, index int) mutex.Spec {
+	spec.Name = fmt.Sprintf("%s-%d", spec.Name, index)
+	return spec
+}
+
+func slotIndex(holder string) int {
+	h := fnv.New32a()
+	h.Write([]byte(holder))
+	return int(h.Sum32() % SharedSlots)
+}
+
+// releasers releases the mutexes it holds in the reverse of the order in
+// which they were acquired.
+type releasers []mutex.Releaser
+
+// Release is part of the mutex.Releaser interface.
+func (r releasers) Release() {
+	for i := len(r) - 1; i >= 0; i-- {
+		r[i].Release()
+	}
+}
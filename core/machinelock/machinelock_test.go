@@ -0,0 +1,154 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinelock_test
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/mutex"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/machinelock"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type MachineLockSuite struct {
+	testing.IsolationSuite
+	spec mutex.Spec
+}
+
+var _ = gc.Suite(&MachineLockSuite{})
+
+func (s *MachineLockSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.spec = mutex.Spec{
+		Name:  fmt.Sprintf("machinelock-test-%d", rand.Int63()),
+		Clock: clock.WallClock,
+		Delay: 10 * time.Millisecond,
+	}
+}
+
+// acquire starts acquiring the lock in the background, and returns a
+// channel on which the releaser is sent once the lock is held.
+func (s *MachineLockSuite) acquire(c *gc.C, acquire func() (mutex.Releaser, error)) <-chan mutex.Releaser {
+	acquired := make(chan mutex.Releaser, 1)
+	go func() {
+		releaser, err := acquire()
+		c.Check(err, jc.ErrorIsNil)
+		acquired <- releaser
+	}()
+	return acquired
+}
+
+func (s *MachineLockSuite) exclusive(c *gc.C) <-chan mutex.Releaser {
+	return s.acquire(c, func() (mutex.Releaser, error) {
+		return machinelock.Acquire(s.spec)
+	})
+}
+
+func (s *MachineLockSuite) shared(c *gc.C, holder string) <-chan mutex.Releaser {
+	return s.acquire(c, func() (mutex.Releaser, error) {
+		return machinelock.AcquireShared(s.spec, holder)
+	})
+}
+
+func assertAcquired(c *gc.C, acquired <-chan mutex.Releaser) mutex.Releaser {
+	select {
+	case releaser := <-acquired:
+		c.Assert(releaser, gc.NotNil)
+		return releaser
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for lock")
+	}
+	panic("unreachable")
+}
+
+func assertNotAcquired(c *gc.C, acquired <-chan mutex.Releaser) {
+	select {
+	case <-acquired:
+		c.Fatalf("lock acquired unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *MachineLockSuite) TestExclusiveExcludesExclusive(c *gc.C) {
+	first := assertAcquired(c, s.exclusive(c))
+	second := s.exclusive(c)
+	assertNotAcquired(c, second)
+	first.Release()
+	assertAcquired(c, second).Release()
+}
+
+func (s *MachineLockSuite) TestExclusiveExcludesShared(c *gc.C) {
+	exclusive := assertAcquired(c, s.exclusive(c))
+	shared := s.shared(c, "unit-mysql-0")
+	assertNotAcquired(c, shared)
+	exclusive.Release()
+	assertAcquired(c, shared).Release()
+}
+
+func (s *MachineLockSuite) TestSharedExcludesExclusive(c *gc.C) {
+	shared := assertAcquired(c, s.shared(c, "unit-mysql-0"))
+	exclusive := s.exclusive(c)
+	assertNotAcquired(c, exclusive)
+	shared.Release()
+	assertAcquired(c, exclusive).Release()
+}
+
+func (s *MachineLockSuite) TestSharedHoldersRunTogether(c *gc.C) {
+	held := assertAcquired(c, s.shared(c, "unit-mysql-0"))
+	assertAcquired(c, s.shared(c, "unit-wordpress-0")).Release()
+	held.Release()
+}
+
+func (s *MachineLockSuite) TestSharedHoldersWithSameFirstSlot(c *gc.C) {
+	first := "unit-mysql-0"
+	var second string
+	for i := 1; second == ""; i++ {
+		name := fmt.Sprintf("unit-wordpress-%d", i)
+		if machinelock.SlotIndex(name) == machinelock.SlotIndex(first) {
+			second = name
+		}
+	}
+
+	held := assertAcquired(c, s.shared(c, first))
+	assertAcquired(c, s.shared(c, second)).Release()
+	held.Release()
+}
+
+func (s *MachineLockSuite) TestSharedHoldersBeyondSlots(c *gc.C) {
+	var held []mutex.Releaser
+	for i := 0; i < machinelock.SharedSlots; i++ {
+		held = append(held, assertAcquired(c, s.shared(c, fmt.Sprintf("unit-mysql-%d", i))))
+	}
+	extra := s.shared(c, "unit-wordpress-0")
+	assertNotAcquired(c, extra)
+
+	// The waiting holder takes the slot chosen by its name once it is
+	// released; release them all, so that it does not matter which.
+	for _, releaser := range held {
+		releaser.Release()
+	}
+	assertAcquired(c, extra).Release()
+}
+
+func (s *MachineLockSuite) TestCancelledReleasesHeldMutexes(c *gc.C) {
+	held := assertAcquired(c, s.shared(c, "unit-mysql-0"))
+
+	cancel := make(chan struct{})
+	close(cancel)
+	spec := s.spec
+	spec.Cancel = cancel
+	_, err := machinelock.Acquire(spec)
+	c.Assert(errors.Cause(err), gc.Equals, mutex.ErrCancelled)
+
+	held.Release()
+	assertAcquired(c, s.exclusive(c)).Release()
+}
@@ -6,6 +6,7 @@ package environs
 import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/instance"
@@ -70,6 +71,17 @@ type StartInstanceParams struct {
 	// that may be used to start this instance.
 	ImageMetadata []*imagemetadata.ImageMetadata
 
+	// APIAllow holds the source CIDRs allowed to connect to the
+	// controller's API port, as configured by the controller's
+	// api-allow attribute. If empty, connections are allowed from
+	// any source.
+	APIAllow []string
+
+	// CharmLXDProfiles holds the LXD profiles supplied by the charms
+	// of the units to be deployed to the instance, keyed on profile
+	// name. It is only used when starting LXD instances.
+	CharmLXDProfiles map[string]*lxdprofile.Profile
+
 	// StatusCallback is a callback to be used by the instance to report changes in status.
 	StatusCallback func(settableStatus status.Status, info string, data map[string]interface{}) error
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -20,9 +21,11 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/charmmeta"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/network"
 )
 
 var logger = loggo.GetLogger("juju.environs.config")
@@ -43,6 +46,32 @@ const (
 	FwNone = "none"
 )
 
+const (
+	// ContainerNetworkingProvider gives containers addresses allocated
+	// by the provider, for providers which support it.
+	ContainerNetworkingProvider = "provider"
+
+	// ContainerNetworkingFan places containers on the host's Fan
+	// bridge, as configured by fan-config.
+	ContainerNetworkingFan = "fan"
+
+	// ContainerNetworkingLocal places containers on a bridge local to
+	// the host, unreachable from other hosts.
+	ContainerNetworkingLocal = "local"
+)
+
+const (
+	// HookLockMachine makes every hook and action hold the machine
+	// lock while it runs, so that no two units on a machine ever run
+	// charm code at the same time.
+	HookLockMachine = charmmeta.HookLockMachine
+
+	// HookLockUnit makes hooks and actions hold only the lock of the
+	// unit running them, except for install and upgrade-charm hooks,
+	// which always hold the machine lock.
+	HookLockUnit = charmmeta.HookLockUnit
+)
+
 // TODO(katco-): Please grow this over time.
 // Centralized place to store values of config keys. This transitions
 // mistakes in referencing key-values to a compile-time error.
@@ -130,6 +159,32 @@ const (
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// HookTimeoutKey is the key for the maximum time a charm hook may
+	// run before it is killed and the unit put into an error state.
+	// Charms may override it with a hook-timeout in their metadata.
+	HookTimeoutKey = "hook-timeout"
+
+	// HookLockKey is the key for the policy deciding which lock charm
+	// hooks and actions hold while they run: "machine" (the default)
+	// or "unit". Charms may demand the machine lock regardless, with
+	// "hook-lock: machine" in their metadata.
+	HookLockKey = "hook-lock"
+
+	// SSHAllowKey is the key for the comma-separated list of source
+	// CIDRs allowed to connect to the SSH port of the model's machines.
+	SSHAllowKey = "ssh-allow"
+
+	// FanConfigKey is the key for the Fan network mappings used to give
+	// containers addresses routable across hosts, as a list of
+	// "underlay=overlay" CIDR pairs.
+	FanConfigKey = "fan-config"
+
+	// ContainerNetworkingMethodKey is the key for the method used to
+	// give containers their network addresses: "provider", "fan" or
+	// "local". If unset, the provider's method is used if it supports
+	// one, otherwise the Fan if it is configured.
+	ContainerNetworkingMethodKey = "container-networking-method"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -381,6 +436,47 @@ func Validate(cfg, old *Config) error {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
 
+	if v, ok := cfg.defined[SSHAllowKey].(string); ok {
+		if _, err := network.ParseCIDRs(v); err != nil {
+			return errors.Annotate(err, "invalid ssh-allow")
+		}
+	}
+
+	if v, ok := cfg.defined[HookTimeoutKey].(string); ok && v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid hook-timeout")
+		}
+		if timeout < 0 {
+			return errors.Errorf("invalid hook-timeout: negative duration %q", v)
+		}
+	}
+
+	if v, ok := cfg.defined[HookLockKey].(string); ok {
+		switch v {
+		case "", HookLockMachine, HookLockUnit:
+		default:
+			return errors.Errorf("invalid hook-lock %q", v)
+		}
+	}
+
+	if v, ok := cfg.defined[FanConfigKey].(string); ok {
+		if _, err := network.ParseFanConfig(v); err != nil {
+			return errors.Annotate(err, "invalid fan-config")
+		}
+	}
+
+	if v, ok := cfg.defined[ContainerNetworkingMethodKey].(string); ok {
+		switch v {
+		case "", ContainerNetworkingProvider, ContainerNetworkingFan, ContainerNetworkingLocal:
+		default:
+			return errors.Errorf("invalid container-networking-method %q", v)
+		}
+		if v == ContainerNetworkingFan && cfg.asString(FanConfigKey) == "" {
+			return errors.New("container-networking-method is fan but no fan-config is set")
+		}
+	}
+
 	// Ensure the resource tags have the expected k=v format.
 	if _, err := cfg.resourceTags(); err != nil {
 		return errors.Annotate(err, "validating resource tags")
@@ -757,6 +853,63 @@ func (c *Config) IgnoreMachineAddresses() (bool, bool) {
 	return v, ok
 }
 
+// SSHAllow returns the source CIDRs allowed to connect to the SSH port
+// of the model's machines. An empty result means connections are
+// allowed from any source.
+func (c *Config) SSHAllow() []string {
+	cidrs, err := network.ParseCIDRs(c.asString(SSHAllowKey))
+	if err != nil {
+		panic(err) // should be prevented by Validate
+	}
+	return cidrs
+}
+
+// HookTimeout returns the maximum time a charm hook may run before it
+// is killed. Zero means hooks may run for as long as they like.
+func (c *Config) HookTimeout() time.Duration {
+	v := c.asString(HookTimeoutKey)
+	if v == "" {
+		return 0
+	}
+	timeout, err := time.ParseDuration(v)
+	if err != nil {
+		panic(err) // should be prevented by Validate
+	}
+	return timeout
+}
+
+// HookLock returns the policy deciding which lock charm hooks and
+// actions hold while they run, either HookLockMachine or HookLockUnit.
+func (c *Config) HookLock() string {
+	if v := c.asString(HookLockKey); v != "" {
+		return v
+	}
+	return HookLockMachine
+}
+
+// FanConfig returns the Fan network mappings for the model.
+func (c *Config) FanConfig() network.FanConfig {
+	fanConfig, err := network.ParseFanConfig(c.asString(FanConfigKey))
+	if err != nil {
+		panic(err) // should be prevented by Validate
+	}
+	return fanConfig
+}
+
+// ContainerNetworkingMethod returns the method used to give containers
+// their network addresses. If none is configured, containers are placed
+// on the Fan when fan-config is set, and are otherwise given addresses
+// by the provider, where it supports doing so.
+func (c *Config) ContainerNetworkingMethod() string {
+	if method := c.asString(ContainerNetworkingMethodKey); method != "" {
+		return method
+	}
+	if c.asString(FanConfigKey) != "" {
+		return ContainerNetworkingFan
+	}
+	return ContainerNetworkingProvider
+}
+
 // StorageDefaultBlockSource returns the default block storage
 // source for the environment.
 func (c *Config) StorageDefaultBlockSource() (string, bool) {
@@ -880,6 +1033,11 @@ var alwaysOptional = schema.Defaults{
 	AgentStreamKey:               schema.Omit,
 	ResourceTagsKey:              schema.Omit,
 	CloudImageBaseURL:            schema.Omit,
+	SSHAllowKey:                  schema.Omit,
+	FanConfigKey:                 schema.Omit,
+	HookTimeoutKey:               schema.Omit,
+	HookLockKey:                  schema.Omit,
+	ContainerNetworkingMethodKey: schema.Omit,
 
 	// AutomaticallyRetryHooks is assumed to be true if missing
 	AutomaticallyRetryHooks: schema.Omit,
@@ -925,6 +1083,19 @@ func allDefaults() schema.Defaults {
 	return d
 }
 
+// ConfigDefaults returns the config default values
+// to be used for any new model where there is no
+// value yet defined.
+func ConfigDefaults() map[string]interface{} {
+	result := make(map[string]interface{})
+	for attr, val := range defaults {
+		if val != schema.Omit {
+			result[attr] = val
+		}
+	}
+	return result
+}
+
 // immutableAttributes holds those attributes
 // which are not allowed to change in the lifetime
 // of an environment.
@@ -1034,6 +1205,30 @@ func Schema(extra environschema.Fields) (environschema.Fields, error) {
 	return fields, nil
 }
 
+// CoerceForSchema checks the given attributes against the given schema,
+// and returns them coerced to the types the schema expects. Attributes
+// not in the schema are returned unchanged.
+func CoerceForSchema(fields environschema.Fields, attrs map[string]interface{}) (map[string]interface{}, error) {
+	checkers, _, err := fields.ValidationSchema()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]interface{})
+	for name, value := range attrs {
+		checker, ok := checkers[name]
+		if !ok {
+			result[name] = value
+			continue
+		}
+		coerced, err := checker.Coerce(value, []string{name})
+		if err != nil {
+			return nil, err
+		}
+		result[name] = coerced
+	}
+	return result, nil
+}
+
 // configSchema holds information on all the fields defined by
 // the config package.
 // TODO(rog) make this available to external packages.
@@ -1220,6 +1415,31 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	SSHAllowKey: {
+		Description: "List of source CIDRs allowed to connect to the SSH port of the model's machines (comma-separated, default any)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	HookTimeoutKey: {
+		Description: `Maximum time a charm hook may run before it is killed, e.g. "30m" (default no limit)`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	HookLockKey: {
+		Description: `Lock held by charm hooks and actions while they run - "machine" or "unit" (default "machine")`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	FanConfigKey: {
+		Description: `Fan network mappings used to give containers addresses routable across hosts, as space-separated "underlay=overlay" CIDR pairs; the Fan is set up on machines provisioned while it is set`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ContainerNetworkingMethodKey: {
+		Description: `Method of container networking setup - one of "provider", "fan" or "local" (default depends on the provider and fan-config)`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -977,6 +977,105 @@ func (s *ConfigSuite) TestCloudImageBaseURLSet(c *gc.C) {
 	c.Assert(config.CloudImageBaseURL(), gc.Equals, "http://local.foo/query")
 }
 
+func (s *ConfigSuite) TestSSHAllowDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.SSHAllow(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestSSHAllow(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"ssh-allow": "10.0.0.0/8, 192.168.0.0/16"})
+	c.Assert(config.SSHAllow(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+}
+
+func (s *ConfigSuite) TestSSHAllowInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.Attrs{
+		"type": "my-type", "name": "my-name",
+		"uuid":      testing.ModelTag.Id(),
+		"ssh-allow": "10.0.0.0/8,10.0.0.1",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid ssh-allow: CIDR "10.0.0.1" not valid`)
+}
+
+func (s *ConfigSuite) TestHookTimeout(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.HookTimeout(), gc.Equals, time.Duration(0))
+	config = newTestConfig(c, testing.Attrs{"hook-timeout": "30m"})
+	c.Assert(config.HookTimeout(), gc.Equals, 30*time.Minute)
+}
+
+func (s *ConfigSuite) TestHookTimeoutInvalid(c *gc.C) {
+	for i, test := range []struct {
+		value string
+		err   string
+	}{
+		{"forever", `invalid hook-timeout: time: invalid duration "?forever"?`},
+		{"-5m", `invalid hook-timeout: negative duration "-5m"`},
+	} {
+		c.Logf("test %d: %q", i, test.value)
+		_, err := config.New(config.UseDefaults, testing.Attrs{
+			"type": "my-type", "name": "my-name",
+			"uuid":         testing.ModelTag.Id(),
+			"hook-timeout": test.value,
+		})
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestHookLock(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.HookLock(), gc.Equals, "machine")
+	cfg = newTestConfig(c, testing.Attrs{"hook-lock": "unit"})
+	c.Assert(cfg.HookLock(), gc.Equals, "unit")
+
+	_, err := config.New(config.UseDefaults, testing.Attrs{
+		"type": "my-type", "name": "my-name",
+		"uuid":      testing.ModelTag.Id(),
+		"hook-lock": "application",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid hook-lock "application"`)
+}
+
+func (s *ConfigSuite) TestFanConfig(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.FanConfig(), gc.HasLen, 0)
+	c.Assert(config.ContainerNetworkingMethod(), gc.Equals, "provider")
+	config = newTestConfig(c, testing.Attrs{
+		"fan-config": "172.31.0.0/16=252.0.0.0/8",
+	})
+	c.Assert(config.FanConfig().String(), gc.Equals, "172.31.0.0/16=252.0.0.0/8")
+	c.Assert(config.ContainerNetworkingMethod(), gc.Equals, "fan")
+	config = newTestConfig(c, testing.Attrs{
+		"fan-config":                  "172.31.0.0/16=252.0.0.0/8",
+		"container-networking-method": "local",
+	})
+	c.Assert(config.ContainerNetworkingMethod(), gc.Equals, "local")
+}
+
+func (s *ConfigSuite) TestFanConfigInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs testing.Attrs
+		err   string
+	}{{
+		attrs: testing.Attrs{"fan-config": "172.31.0.0/16"},
+		err:   `invalid fan-config: fan mapping "172.31.0.0/16" not valid`,
+	}, {
+		attrs: testing.Attrs{"container-networking-method": "bridge"},
+		err:   `invalid container-networking-method "bridge"`,
+	}, {
+		attrs: testing.Attrs{"container-networking-method": "fan"},
+		err:   `container-networking-method is fan but no fan-config is set`,
+	}} {
+		c.Logf("test %d", i)
+		attrs := testing.Attrs{
+			"type": "my-type", "name": "my-name",
+			"uuid": testing.ModelTag.Id(),
+		}.Merge(test.attrs)
+		_, err := config.New(config.UseDefaults, attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *ConfigSuite) TestProxyValuesWithFallback(c *gc.C) {
 	s.addJujuFiles(c)
 
@@ -1080,6 +1179,16 @@ func (s *ConfigSuite) TestAptProxyConfigMap(c *gc.C) {
 	c.Assert(cfg.AptProxySettings(), gc.DeepEquals, proxySettings)
 }
 
+func (s *ConfigSuite) TestConfigDefaults(c *gc.C) {
+	defaults := config.ConfigDefaults()
+	c.Assert(defaults["firewall-mode"], gc.Equals, config.FwInstance)
+	c.Assert(defaults["ssl-hostname-verification"], gc.Equals, true)
+	c.Assert(defaults["default-series"], gc.Equals, "")
+	// Attributes without a default value are omitted.
+	_, ok := defaults["apt-mirror"]
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *ConfigSuite) TestSchemaNoExtra(c *gc.C) {
 	schema, err := config.Schema(nil)
 	c.Assert(err, gc.IsNil)
@@ -7,10 +7,18 @@ package config
 // After a call to UpdateModelConfig, any attributes added/removed
 // will have a source of JujuModelConfigSource.
 const (
+	// JujuDefaultSource is used to label model config attributes that
+	// come from hard coded defaults.
+	JujuDefaultSource = "default"
+
 	// JujuControllerSource is used to label model config attributes that
 	// come from those associated with the controller.
 	JujuControllerSource = "controller"
 
+	// JujuRegionSource is used to label model config attributes that come
+	// from those associated with the cloud region of the model.
+	JujuRegionSource = "region"
+
 	// JujuModelConfigSource is used to label model config attributes that
 	// have been explicitly set by the user.
 	JujuModelConfigSource = "model"
@@ -38,3 +46,29 @@ func (c ConfigValues) AllAttrs() map[string]interface{} {
 	}
 	return result
 }
+
+// ModelDefaultAttributes is a map of configuration values to a list of possible
+// values.
+type ModelDefaultAttributes map[string]AttributeDefaultValues
+
+// AttributeDefaultValues represents all the default values at each level for
+// a given setting.
+type AttributeDefaultValues struct {
+	// Default and Controller represent the values as set at those levels.
+	Default    interface{} `json:"default,omitempty" yaml:"default,omitempty"`
+	Controller interface{} `json:"controller,omitempty" yaml:"controller,omitempty"`
+
+	// Regions is a slice of RegionDefaultValue that models the value of
+	// the attribute for each cloud region in which it is set.
+	Regions []RegionDefaultValue `json:"regions,omitempty" yaml:"regions,omitempty"`
+}
+
+// RegionDefaultValue holds the region information for each region in
+// the model-defaults.
+type RegionDefaultValue struct {
+	// Name represents the region name for this specific setting.
+	Name string `json:"name" yaml:"name"`
+
+	// Value is the value of the setting this represents in the named region.
+	Value interface{} `json:"value" yaml:"value"`
+}
@@ -262,6 +262,35 @@ type Firewaller interface {
 	Ports() ([]network.PortRange, error)
 }
 
+// IngressFirewaller is an optional interface that may be implemented by
+// an Environ whose firewall can restrict open ports to specific source
+// CIDRs. The firewaller worker uses it in preference to Firewaller when
+// operating in the FwGlobal firewall mode.
+type IngressFirewaller interface {
+	// OpenIngressRules opens the given ingress rules for the whole
+	// environment.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules for the whole
+	// environment.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole
+	// environment, as sorted by network.SortIngressRules().
+	IngressRules() ([]network.IngressRule, error)
+}
+
+// AccessFirewaller is an optional interface that may be implemented by
+// an Environ which opens the SSH and API ports on every machine in the
+// model, irrespective of the firewall mode. The firewaller worker uses
+// it to restrict those ports to the CIDRs in the model's ssh-allow and
+// the controller's api-allow configuration.
+type AccessFirewaller interface {
+	// SetAccessRules replaces the environment's SSH and API access
+	// rules with the given rules, as returned by network.AccessRules.
+	SetAccessRules(rules []network.IngressRule) error
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
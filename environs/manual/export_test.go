@@ -8,6 +8,11 @@ var (
 	ProvisionMachineAgent = &provisionMachineAgent
 )
 
+// InitUbuntuUserBatch initialises the ubuntu user without prompting.
+func InitUbuntuUserBatch(host, login, authorizedKeys string) error {
+	return initUbuntuUser(host, login, authorizedKeys, nil, true, nil, nil)
+}
+
 const (
 	DetectionScript = detectionScript
 )
@@ -32,11 +32,15 @@ cat /proc/cpuinfo`
 var CheckProvisioned = checkProvisioned
 
 func checkProvisioned(host string) (bool, error) {
+	return checkProvisionedWithOptions(host, nil)
+}
+
+func checkProvisionedWithOptions(host string, options *ssh.Options) (bool, error) {
 	logger.Infof("Checking if %s is already provisioned", host)
 
 	script := service.ListServicesScript()
 
-	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, options)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -64,8 +68,12 @@ func checkProvisioned(host string) (bool, error) {
 var DetectSeriesAndHardwareCharacteristics = detectSeriesAndHardwareCharacteristics
 
 func detectSeriesAndHardwareCharacteristics(host string) (hc instance.HardwareCharacteristics, series string, err error) {
+	return detectSeriesAndHardwareCharacteristicsWithOptions(host, nil)
+}
+
+func detectSeriesAndHardwareCharacteristicsWithOptions(host string, options *ssh.Options) (hc instance.HardwareCharacteristics, series string, err error) {
 	logger.Infof("Detecting series and characteristics on %s", host)
-	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, options)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -136,6 +144,15 @@ func detectSeriesAndHardwareCharacteristics(host string) (hc instance.HardwareCh
 // stdin and stdout will be used for remote sudo prompts,
 // if the ubuntu user must be created/updated.
 func InitUbuntuUser(host, login, authorizedKeys string, stdin io.Reader, stdout io.Writer) error {
+	return initUbuntuUser(host, login, authorizedKeys, nil, false, stdin, stdout)
+}
+
+// initUbuntuUser is the implementation of InitUbuntuUser. If batch is
+// true, the specified login must be able to use sudo without a password:
+// password authentication is left disabled, no PTY is allocated and
+// sudo is run non-interactively, so that the attempt fails rather than
+// prompting; stdin and stdout are not used.
+func initUbuntuUser(host, login, authorizedKeys string, sshOptions *ssh.Options, batch bool, stdin io.Reader, stdout io.Writer) error {
 	logger.Infof("initialising %q, user %q", host, login)
 
 	// To avoid unnecessary prompting for the specified login,
@@ -145,7 +162,7 @@ func InitUbuntuUser(host, login, authorizedKeys string, stdin io.Reader, stdout
 	//
 	// Note that we explicitly do not allocate a PTY, so we
 	// get a failure if sudo prompts.
-	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "true"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "true"}, sshOptions)
 	if cmd.Run() == nil {
 		logger.Infof("ubuntu user is already initialised")
 		return nil
@@ -158,12 +175,22 @@ func InitUbuntuUser(host, login, authorizedKeys string, stdin io.Reader, stdout
 	}
 	script := fmt.Sprintf(initUbuntuScript, utils.ShQuote(authorizedKeys))
 	var options ssh.Options
-	options.AllowPasswordAuthentication()
-	options.EnablePTY()
-	cmd = ssh.Command(host, []string{"sudo", "/bin/bash -c " + utils.ShQuote(script)}, &options)
+	if sshOptions != nil {
+		options = *sshOptions
+	}
+	sudo := []string{"sudo"}
+	if batch {
+		sudo = append(sudo, "-n")
+	} else {
+		options.AllowPasswordAuthentication()
+		options.EnablePTY()
+	}
+	cmd = ssh.Command(host, append(sudo, "/bin/bash -c "+utils.ShQuote(script)), &options)
 	var stderr bytes.Buffer
-	cmd.Stdin = stdin
-	cmd.Stdout = stdout // for sudo prompt
+	if !batch {
+		cmd.Stdin = stdin
+		cmd.Stdout = stdout // for sudo prompt
+	}
 	cmd.Stderr = &stderr
 	if err := cmd.Run(); err != nil {
 		if stderr.Len() != 0 {
@@ -4,6 +4,8 @@
 package manual_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	jc "github.com/juju/testing/checkers"
@@ -157,3 +159,24 @@ func (s *initialisationSuite) TestInitUbuntuUserError(c *gc.C) {
 	err := manual.InitUbuntuUser("testhost", "testuser", "", nil, nil)
 	c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 123 \\(failed to create ubuntu user\\)")
 }
+
+func (s *initialisationSuite) TestInitUbuntuUserBatch(c *gc.C) {
+	// Record the arguments of each ssh invocation, and fail both the
+	// ubuntu@ login and the attempt with the specified login.
+	fakebin := c.MkDir()
+	script := "#!/bin/bash --norc\necho \"$@\" >> \"$0.args\"\nexit 1\n"
+	err := ioutil.WriteFile(filepath.Join(fakebin, "ssh"), []byte(script), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchEnvPathPrepend(fakebin)
+
+	err = manual.InitUbuntuUserBatch("testhost", "testuser", "")
+	c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 1")
+
+	data, err := ioutil.ReadFile(filepath.Join(fakebin, "ssh.args"))
+	c.Assert(err, jc.ErrorIsNil)
+	calls := strings.Split(strings.TrimSpace(string(data)), "\n")
+	c.Assert(calls, gc.HasLen, 2)
+	c.Check(calls[1], jc.Contains, "PasswordAuthentication no")
+	c.Check(calls[1], gc.Not(jc.Contains), "-t -t")
+	c.Check(calls[1], jc.Contains, "testuser@testhost sudo -n ")
+}
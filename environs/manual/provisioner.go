@@ -13,6 +13,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils"
 	"github.com/juju/utils/shell"
+	"github.com/juju/utils/ssh"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig"
@@ -61,6 +62,19 @@ type ProvisionMachineArgs struct {
 	// ubuntu user's ~/.ssh/authorized_keys.
 	AuthorizedKeys string
 
+	// SSHOptions holds options for the SSH connections made to the host,
+	// such as its port, the identity files to use, and a proxy command
+	// for reaching it through a bastion. If SSHOptions is nil, the
+	// defaults will be used.
+	SSHOptions *ssh.Options
+
+	// BatchMode, if true, means that provisioning must not prompt for
+	// anything: password authentication is disabled, no PTY is
+	// allocated and sudo is run non-interactively, so that a host that
+	// would need a password fails immediately. Stdin and Stdout are
+	// not used for prompts.
+	BatchMode bool
+
 	*params.UpdateBehavior
 }
 
@@ -90,11 +104,11 @@ func ProvisionMachine(args ProvisionMachineArgs) (machineId string, err error) {
 	// user's ~/.ssh directory. The authenticationworker will later update the
 	// ubuntu user's authorized_keys.
 	user, hostname := splitUserHost(args.Host)
-	if err := InitUbuntuUser(hostname, user, args.AuthorizedKeys, args.Stdin, args.Stdout); err != nil {
+	if err := initUbuntuUser(hostname, user, args.AuthorizedKeys, args.SSHOptions, args.BatchMode, args.Stdin, args.Stdout); err != nil {
 		return "", err
 	}
 
-	machineParams, err := gatherMachineParams(hostname)
+	machineParams, err := gatherMachineParams(hostname, args.SSHOptions)
 	if err != nil {
 		return "", err
 	}
@@ -117,7 +131,7 @@ func ProvisionMachine(args ProvisionMachineArgs) (machineId string, err error) {
 	}
 
 	// Finally, provision the machine agent.
-	err = runProvisionScript(provisioningScript, hostname, args.Stderr)
+	err = runProvisionScript(provisioningScript, hostname, args.SSHOptions, args.Stderr)
 	if err != nil {
 		return machineId, err
 	}
@@ -151,7 +165,7 @@ func recordMachineInState(client ProvisioningClientAPI, machineParams params.Add
 // The hostname supplied should not include a username.
 // If we can, we will reverse lookup the hostname by its IP address, and use
 // the DNS resolved name, rather than the name that was supplied
-func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
+func gatherMachineParams(hostname string, sshOptions *ssh.Options) (*params.AddMachineParams, error) {
 
 	// Generate a unique nonce for the machine.
 	uuid, err := utils.NewUUID()
@@ -164,7 +178,7 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		return nil, errors.Annotatef(err, "failed to compute public address for %q", hostname)
 	}
 
-	provisioned, err := checkProvisioned(hostname)
+	provisioned, err := checkProvisionedWithOptions(hostname, sshOptions)
 	if err != nil {
 		err = fmt.Errorf("error checking if provisioned: %v", err)
 		return nil, err
@@ -173,7 +187,7 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		return nil, ErrProvisioned
 	}
 
-	hc, series, err := DetectSeriesAndHardwareCharacteristics(hostname)
+	hc, series, err := detectSeriesAndHardwareCharacteristicsWithOptions(hostname, sshOptions)
 	if err != nil {
 		err = fmt.Errorf("error detecting hardware characteristics: %v", err)
 		return nil, err
@@ -203,7 +217,7 @@ var provisionMachineAgent = func(host string, icfg *instancecfg.InstanceConfig,
 	if err != nil {
 		return err
 	}
-	return runProvisionScript(script, host, progressWriter)
+	return runProvisionScript(script, host, nil, progressWriter)
 }
 
 // ProvisioningScript generates a bash script that can be
@@ -239,10 +253,11 @@ func ProvisioningScript(icfg *instancecfg.InstanceConfig) (string, error) {
 	return buf.String(), nil
 }
 
-func runProvisionScript(script, host string, progressWriter io.Writer) error {
+func runProvisionScript(script, host string, sshOptions *ssh.Options, progressWriter io.Writer) error {
 	params := sshinit.ConfigureParams{
 		Host:           "ubuntu@" + host,
 		ProgressWriter: progressWriter,
+		SSHOptions:     sshOptions,
 	}
 	return sshinit.RunConfigureScript(script, params)
 }
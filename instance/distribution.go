@@ -17,5 +17,8 @@ type Distributor interface {
 	// one is successful. If no instances can be assigned
 	// to (e.g. because of concurrent deployments), then
 	// a new machine will be allocated.
-	DistributeInstances(candidates, distributionGroup []Id) ([]Id, error)
+	//
+	// If limitZones is non-empty, only instances in the
+	// named availability zones will be returned.
+	DistributeInstances(candidates, distributionGroup []Id, limitZones []string) ([]Id, error)
 }
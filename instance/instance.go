@@ -54,6 +54,25 @@ type Instance interface {
 	Ports(machineId string) ([]network.PortRange, error)
 }
 
+// IngressFirewaller is an optional interface that may be implemented by
+// an Instance whose firewall can restrict open ports to specific source
+// CIDRs. The firewaller worker uses it in preference to the port methods
+// of Instance when operating in the FwInstance firewall mode.
+type IngressFirewaller interface {
+	// OpenIngressRules opens the given ingress rules on the instance,
+	// which should have been started with the given machine id.
+	OpenIngressRules(machineId string, rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules on the instance,
+	// which should have been started with the given machine id.
+	CloseIngressRules(machineId string, rules []network.IngressRule) error
+
+	// IngressRules returns the set of ingress rules open on the
+	// instance, which should have been started with the given machine
+	// id. The rules are returned as sorted by network.SortIngressRules().
+	IngressRules(machineId string) ([]network.IngressRule, error)
+}
+
 // HardwareCharacteristics represents the characteristics of the instance (if known).
 // Attributes that are nil are unknown or not supported.
 type HardwareCharacteristics struct {
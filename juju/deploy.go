@@ -33,6 +33,9 @@ type DeployApplicationParams struct {
 	EndpointBindings map[string]string
 	// Resources is a map of resource name to IDs of pending resources.
 	Resources map[string]string
+	// Trust is true if the application may access the model's
+	// cloud credential.
+	Trust bool
 }
 
 type ApplicationDeployer interface {
@@ -70,6 +73,7 @@ func DeployApplication(st ApplicationDeployer, args DeployApplicationParams) (*s
 		Placement:        args.Placement,
 		Resources:        args.Resources,
 		EndpointBindings: effectiveBindings,
+		Trust:            args.Trust,
 	}
 
 	if !args.Charm.Meta().Subordinate {
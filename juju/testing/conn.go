@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/environs/config"
@@ -425,6 +426,7 @@ func newState(environ environs.Environ, mongoInfo *mongo.MongoInfo) (*state.Stat
 		st.Close()
 		return nil, fmt.Errorf("unable to push secrets: %v", err)
 	}
+	st.SetSecretsKey(testing.SecretsKey)
 	return st, nil
 }
 
@@ -524,11 +526,16 @@ func AddCharm(st *state.State, curl *charm.URL, ch charm.Charm) (*state.Charm, e
 	if err := stor.Put(storagePath, f, size); err != nil {
 		return nil, fmt.Errorf("cannot put charm: %v", err)
 	}
+	lxdProfile, err := lxdprofile.ReadCharm(ch)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read charm LXD profile: %v", err)
+	}
 	info := state.CharmInfo{
 		Charm:       ch,
 		ID:          curl,
 		StoragePath: storagePath,
 		SHA256:      digest,
+		LXDProfile:  lxdProfile,
 	}
 	sch, err := st.AddCharm(info)
 	if err != nil {
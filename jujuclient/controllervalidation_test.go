@@ -4,6 +4,9 @@
 package jujuclient_test
 
 import (
+	"regexp"
+
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/jujuclient"
@@ -24,6 +27,7 @@ func (s *ControllerValidationSuite) SetUpTest(c *gc.C) {
 		"test.ca.cert",
 		"aws",
 		"southeastasia",
+		"",
 	}
 }
 
@@ -43,6 +47,41 @@ func (s *ControllerValidationSuite) TestValidateControllerDetailsNoCACert(c *gc.
 	s.assertValidateControllerDetailsFails(c, "missing ca-cert, controller details not valid")
 }
 
+func (s *ControllerValidationSuite) TestValidateControllerDetailsInvalidSSHProxyJump(c *gc.C) {
+	s.controller.SSHProxyJump = "bastion:ssh"
+	s.assertValidateControllerDetailsFails(c, `ssh-proxy-jump "bastion:ssh" port not valid`)
+}
+
+func (s *ControllerValidationSuite) TestValidateSSHProxyJump(c *gc.C) {
+	for _, valid := range []string{
+		"bastion",
+		"jump@bastion.example.com",
+		"jump@10.0.0.1:2222",
+		"[fe80::1]:22",
+		"fe80::1",
+		"jump_user.1@bastion-1.example.com.",
+		"bastion_1",
+	} {
+		c.Check(jujuclient.ValidateSSHProxyJump(valid), jc.ErrorIsNil)
+	}
+	for invalid, message := range map[string]string{
+		"":                            `ssh-proxy-jump "" host not valid`,
+		"@bastion":                    `ssh-proxy-jump "@bastion" with empty user not valid`,
+		"bastion:0":                   `ssh-proxy-jump "bastion:0" port not valid`,
+		"jump@:22":                    `ssh-proxy-jump "jump@:22" host not valid`,
+		"bastion/foo:22":              `ssh-proxy-jump "bastion/foo:22" host not valid`,
+		"-oProxyCommand=touch /tmp/x": `ssh-proxy-jump "-oProxyCommand=touch /tmp/x" starting with "-" not valid`,
+		"-bastion":                    `ssh-proxy-jump "-bastion" starting with "-" not valid`,
+		"-o@bastion":                  `ssh-proxy-jump "-o@bastion" starting with "-" not valid`,
+		"jump@-oProxyCommand=x":       `ssh-proxy-jump "jump@-oProxyCommand=x" host not valid`,
+		"a b@bastion":                 `ssh-proxy-jump "a b@bastion" user not valid`,
+		"jump@bastion -p 22":          `ssh-proxy-jump "jump@bastion -p 22" host not valid`,
+		"bastion:":                    `ssh-proxy-jump "bastion:" port not valid`,
+	} {
+		c.Check(jujuclient.ValidateSSHProxyJump(invalid), gc.ErrorMatches, regexp.QuoteMeta(message))
+	}
+}
+
 func (s *ControllerValidationSuite) assertValidateControllerDetailsFails(c *gc.C, failureMessage string) {
 	err := jujuclient.ValidateControllerDetails(s.controller)
 	c.Assert(err, gc.ErrorMatches, failureMessage)
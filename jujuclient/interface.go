@@ -34,6 +34,11 @@ type ControllerDetails struct {
 	// CloudRegion is the name of the cloud region that this controller
 	// runs in. This will be empty for clouds without regions.
 	CloudRegion string `yaml:"region,omitempty"`
+
+	// SSHProxyJump is the [user@]host[:port] of an SSH bastion host
+	// through which SSH connections to the controller's machines are
+	// made. This will be empty if no bastion host is needed.
+	SSHProxyJump string `yaml:"ssh-proxy-jump,omitempty"`
 }
 
 // ModelDetails holds details of a model.
@@ -4,6 +4,11 @@
 package jujuclient
 
 import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 )
@@ -16,6 +21,53 @@ func ValidateControllerDetails(details ControllerDetails) error {
 	if details.CACert == "" {
 		return errors.NotValidf("missing ca-cert, controller details")
 	}
+	if details.SSHProxyJump != "" {
+		if err := ValidateSSHProxyJump(details.SSHProxyJump); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+var (
+	// validSSHUser matches the user names accepted in an SSH bastion
+	// specification. Names must not start with "-", so that they
+	// cannot be mistaken for ssh options.
+	validSSHUser = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_.-]*$`)
+
+	// validSSHHostname matches the host names accepted in an SSH
+	// bastion specification.
+	validSSHHostname = regexp.MustCompile(`^[a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9_])?(\.[a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9_])?)*\.?$`)
+)
+
+// ValidateSSHProxyJump validates the given SSH bastion host,
+// which must be of the form [user@]host[:port]. The host must be
+// a host name or an IP address; the value is passed to ssh, so
+// anything that ssh could interpret as an option is rejected.
+func ValidateSSHProxyJump(jump string) error {
+	if strings.HasPrefix(jump, "-") {
+		return errors.NotValidf("ssh-proxy-jump %q starting with \"-\"", jump)
+	}
+	hostPort := jump
+	if at := strings.LastIndex(jump, "@"); at != -1 {
+		if at == 0 {
+			return errors.NotValidf("ssh-proxy-jump %q with empty user", jump)
+		}
+		if !validSSHUser.MatchString(jump[:at]) {
+			return errors.NotValidf("ssh-proxy-jump %q user", jump)
+		}
+		hostPort = jump[at+1:]
+	}
+	host := hostPort
+	if h, port, err := net.SplitHostPort(hostPort); err == nil {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return errors.NotValidf("ssh-proxy-jump %q port", jump)
+		}
+		host = h
+	}
+	if net.ParseIP(host) == nil && !validSSHHostname.MatchString(host) {
+		return errors.NotValidf("ssh-proxy-jump %q host", jump)
+	}
 	return nil
 }
 
@@ -0,0 +1,144 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// FanConfigEntry describes a single Fan network mapping: every host
+// address in the underlay network owns a slice of the overlay network,
+// from which the containers on that host are given addresses.
+type FanConfigEntry struct {
+	Underlay *net.IPNet
+	Overlay  *net.IPNet
+}
+
+// String returns the entry in the "underlay=overlay" form used in
+// model config.
+func (e FanConfigEntry) String() string {
+	return fmt.Sprintf("%s=%s", e.Underlay, e.Overlay)
+}
+
+// BridgeName returns the name of the bridge device created on each
+// host for the entry's overlay network, following the convention used
+// by fanctl.
+func (e FanConfigEntry) BridgeName() string {
+	return fmt.Sprintf("fan-%d", e.Overlay.IP.To4()[0])
+}
+
+// FanConfig holds the Fan network mappings for a model.
+type FanConfig []FanConfigEntry
+
+// String returns the config in the form accepted by ParseFanConfig.
+func (c FanConfig) String() string {
+	entries := make([]string, len(c))
+	for i, entry := range c {
+		entries[i] = entry.String()
+	}
+	return strings.Join(entries, " ")
+}
+
+// EntryForAddress returns the entry whose underlay contains the given
+// host address, if there is one.
+func (c FanConfig) EntryForAddress(addr net.IP) (FanConfigEntry, bool) {
+	for _, entry := range c {
+		if entry.Underlay.Contains(addr) {
+			return entry, true
+		}
+	}
+	return FanConfigEntry{}, false
+}
+
+// ParseFanConfig parses a list of Fan network mappings, separated by
+// spaces or commas, each of the form "underlay=overlay". Both networks
+// must be IPv4 CIDRs, and the overlay must be larger than the underlay
+// so that each underlay host owns a slice of the overlay.
+func ParseFanConfig(value string) (FanConfig, error) {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	var config FanConfig
+	for _, field := range fields {
+		parts := strings.Split(field, "=")
+		if len(parts) != 2 {
+			return nil, errors.NotValidf("fan mapping %q", field)
+		}
+		underlay, err := parseFanCIDR(parts[0])
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid underlay in fan mapping %q", field)
+		}
+		overlay, err := parseFanCIDR(parts[1])
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid overlay in fan mapping %q", field)
+		}
+		underlayOnes, _ := underlay.Mask.Size()
+		overlayOnes, _ := overlay.Mask.Size()
+		if overlayOnes >= underlayOnes {
+			return nil, errors.Errorf("invalid fan mapping %q: overlay must be larger than underlay", field)
+		}
+		for _, entry := range config {
+			if entry.Overlay.Contains(overlay.IP) || overlay.Contains(entry.Overlay.IP) {
+				return nil, errors.Errorf("invalid fan mapping %q: overlay overlaps %q", field, entry)
+			}
+		}
+		config = append(config, FanConfigEntry{Underlay: underlay, Overlay: overlay})
+	}
+	return config, nil
+}
+
+func parseFanCIDR(value string) (*net.IPNet, error) {
+	_, ipNet, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, errors.NotValidf("CIDR %q", value)
+	}
+	if ipNet.IP.To4() == nil {
+		return nil, errors.NotSupportedf("IPv6 CIDR %q", value)
+	}
+	return ipNet, nil
+}
+
+// CalculateOverlaySegment returns the part of the entry's overlay
+// network owned by the hosts in the given local underlay subnet. The
+// result is nil if the subnet is not part of the entry's underlay.
+//
+// For example, with an underlay of 172.31.0.0/16 and an overlay of
+// 252.0.0.0/8, the hosts in subnet 172.31.16.0/20 own 252.16.0.0/12.
+func CalculateOverlaySegment(localUnderlay string, entry FanConfigEntry) (*net.IPNet, error) {
+	_, local, err := net.ParseCIDR(localUnderlay)
+	if err != nil {
+		return nil, errors.NotValidf("CIDR %q", localUnderlay)
+	}
+	localIP := local.IP.To4()
+	if localIP == nil {
+		return nil, nil
+	}
+	localOnes, _ := local.Mask.Size()
+	underlayOnes, _ := entry.Underlay.Mask.Size()
+	if localOnes < underlayOnes || !entry.Underlay.Contains(localIP) {
+		return nil, nil
+	}
+	overlayOnes, _ := entry.Overlay.Mask.Size()
+
+	// The host bits of the underlay address select the slice of the
+	// overlay; only those fixed by the local subnet are known here.
+	hostBits := ipv4ToUint32(localIP) &^ ipv4ToUint32(net.IP(entry.Underlay.Mask))
+	hostBits <<= uint(underlayOnes - overlayOnes)
+	ip := uint32ToIPv4(ipv4ToUint32(entry.Overlay.IP.To4()) | hostBits)
+	ones := overlayOnes + localOnes - underlayOnes
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(ones, 32)}, nil
+}
+
+func ipv4ToUint32(ip net.IP) uint32 {
+	ip = ip.To4()
+	return uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])
+}
+
+func uint32ToIPv4(n uint32) net.IP {
+	return net.IPv4(byte(n>>24), byte(n>>16), byte(n>>8), byte(n)).To4()
+}
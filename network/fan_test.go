@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	"net"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type FanSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&FanSuite{})
+
+func (*FanSuite) TestParseFanConfig(c *gc.C) {
+	config, err := network.ParseFanConfig("172.31.0.0/16=252.0.0.0/8, 10.0.0.0/24=253.0.0.0/16")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, gc.HasLen, 2)
+	c.Assert(config[0].Underlay.String(), gc.Equals, "172.31.0.0/16")
+	c.Assert(config[0].Overlay.String(), gc.Equals, "252.0.0.0/8")
+	c.Assert(config[0].BridgeName(), gc.Equals, "fan-252")
+	c.Assert(config[1].BridgeName(), gc.Equals, "fan-253")
+	c.Assert(config.String(), gc.Equals, "172.31.0.0/16=252.0.0.0/8 10.0.0.0/24=253.0.0.0/16")
+}
+
+func (*FanSuite) TestParseFanConfigEmpty(c *gc.C) {
+	config, err := network.ParseFanConfig("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, gc.HasLen, 0)
+}
+
+func (*FanSuite) TestParseFanConfigInvalid(c *gc.C) {
+	for i, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "172.31.0.0/16",
+		err:   `fan mapping "172.31.0.0/16" not valid`,
+	}, {
+		value: "foo=252.0.0.0/8",
+		err:   `invalid underlay in fan mapping "foo=252.0.0.0/8": CIDR "foo" not valid`,
+	}, {
+		value: "172.31.0.0/16=2001:db8::/32",
+		err:   `invalid overlay in fan mapping .*: IPv6 CIDR "2001:db8::/32" not supported`,
+	}, {
+		value: "172.31.0.0/16=252.0.0.0/24",
+		err:   `invalid fan mapping "172.31.0.0/16=252.0.0.0/24": overlay must be larger than underlay`,
+	}, {
+		value: "172.31.0.0/16=252.0.0.0/8 10.0.0.0/16=252.0.0.0/12",
+		err:   `invalid fan mapping "10.0.0.0/16=252.0.0.0/12": overlay overlaps "172.31.0.0/16=252.0.0.0/8"`,
+	}} {
+		c.Logf("test %d: %q", i, test.value)
+		_, err := network.ParseFanConfig(test.value)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (*FanSuite) TestEntryForAddress(c *gc.C) {
+	config, err := network.ParseFanConfig("172.31.0.0/16=252.0.0.0/8 10.0.0.0/24=253.0.0.0/16")
+	c.Assert(err, jc.ErrorIsNil)
+	entry, ok := config.EntryForAddress(net.ParseIP("10.0.0.7"))
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(entry.Overlay.String(), gc.Equals, "253.0.0.0/16")
+	_, ok = config.EntryForAddress(net.ParseIP("192.168.0.1"))
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (*FanSuite) TestCalculateOverlaySegment(c *gc.C) {
+	config, err := network.ParseFanConfig("172.31.0.0/16=252.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	for i, test := range []struct {
+		local   string
+		segment string
+	}{
+		{"172.31.16.0/20", "252.16.0.0/12"},
+		{"172.31.5.0/24", "252.5.0.0/16"},
+		{"172.31.0.0/16", "252.0.0.0/8"},
+		{"192.168.0.0/24", ""},
+		{"172.0.0.0/8", ""},
+	} {
+		c.Logf("test %d: %s", i, test.local)
+		segment, err := network.CalculateOverlaySegment(test.local, config[0])
+		c.Check(err, jc.ErrorIsNil)
+		if test.segment == "" {
+			c.Check(segment, gc.IsNil)
+		} else {
+			c.Check(segment.String(), gc.Equals, test.segment)
+		}
+	}
+}
+
+func (*FanSuite) TestCalculateOverlaySegmentInvalid(c *gc.C) {
+	config, err := network.ParseFanConfig("172.31.0.0/16=252.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = network.CalculateOverlaySegment("foo", config[0])
+	c.Assert(err, gc.ErrorMatches, `CIDR "foo" not valid`)
+}
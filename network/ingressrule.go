@@ -0,0 +1,210 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// AnyCIDR is the source CIDR which matches all IPv4 addresses.
+	AnyCIDR = "0.0.0.0/0"
+
+	// SSHPort is the port on which machines accept SSH connections.
+	SSHPort = 22
+)
+
+// IngressRule represents a range of ports which are open to incoming
+// traffic from a set of source CIDRs. An empty set of source CIDRs
+// means the ports are open to any source.
+type IngressRule struct {
+	PortRange
+	SourceCIDRs []string
+}
+
+// NewIngressRule returns an IngressRule for the given port range and
+// source CIDRs. The CIDRs are validated and sorted; if none are given
+// the rule allows traffic from any source.
+func NewIngressRule(portRange PortRange, sourceCIDRs ...string) (IngressRule, error) {
+	rule := IngressRule{PortRange: portRange}
+	for _, cidr := range sourceCIDRs {
+		if cidr == AnyCIDR {
+			// The rule is open to the world; other CIDRs are redundant.
+			rule.SourceCIDRs = nil
+			break
+		}
+		rule.SourceCIDRs = append(rule.SourceCIDRs, cidr)
+	}
+	sort.Strings(rule.SourceCIDRs)
+	if err := rule.Validate(); err != nil {
+		return IngressRule{}, errors.Trace(err)
+	}
+	return rule, nil
+}
+
+// MustNewIngressRule returns an IngressRule for the given port range
+// and source CIDRs. It panics if the rule is invalid.
+func MustNewIngressRule(portRange PortRange, sourceCIDRs ...string) IngressRule {
+	rule, err := NewIngressRule(portRange, sourceCIDRs...)
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+// NewOpenIngressRule returns an IngressRule allowing traffic from any
+// source to the given port range.
+func NewOpenIngressRule(portRange PortRange) IngressRule {
+	return IngressRule{PortRange: portRange}
+}
+
+// Validate checks the rule's port range and source CIDRs.
+func (r IngressRule) Validate() error {
+	if err := r.PortRange.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	for _, cidr := range r.SourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("source CIDR %q", cidr)
+		}
+	}
+	return nil
+}
+
+// IsOpen reports whether the rule allows traffic from any source.
+func (r IngressRule) IsOpen() bool {
+	return len(r.SourceCIDRs) == 0
+}
+
+// SourceRanges returns the rule's source CIDRs, or AnyCIDR if the rule
+// allows traffic from any source.
+func (r IngressRule) SourceRanges() []string {
+	if r.IsOpen() {
+		return []string{AnyCIDR}
+	}
+	return r.SourceCIDRs
+}
+
+func (r IngressRule) String() string {
+	if r.IsOpen() {
+		return r.PortRange.String()
+	}
+	return fmt.Sprintf("%s from %s", r.PortRange, strings.Join(r.SourceCIDRs, ","))
+}
+
+func (r IngressRule) GoString() string {
+	return r.String()
+}
+
+type ingressRuleSlice []IngressRule
+
+func (r ingressRuleSlice) Len() int      { return len(r) }
+func (r ingressRuleSlice) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r ingressRuleSlice) Less(i, j int) bool {
+	if r[i].PortRange != r[j].PortRange {
+		return portRangeSlice{r[i].PortRange, r[j].PortRange}.Less(0, 1)
+	}
+	return r[i].String() < r[j].String()
+}
+
+// SortIngressRules sorts the given rules, first by port range, then by
+// source CIDRs.
+func SortIngressRules(rules []IngressRule) {
+	sort.Sort(ingressRuleSlice(rules))
+}
+
+// SplitIngressRules returns a rule for each source CIDR of each of the
+// given rules, with open rules returned unchanged. Duplicate rules are
+// dropped and the result is sorted, so that sets of rules can be
+// compared however a provider merges the sources of rules for the same
+// port range.
+func SplitIngressRules(rules []IngressRule) []IngressRule {
+	var result []IngressRule
+	seen := make(map[string]bool)
+	add := func(rule IngressRule) {
+		key := rule.String()
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		result = append(result, rule)
+	}
+	for _, rule := range rules {
+		if rule.IsOpen() {
+			add(rule)
+			continue
+		}
+		for _, cidr := range rule.SourceCIDRs {
+			add(IngressRule{
+				PortRange:   rule.PortRange,
+				SourceCIDRs: []string{cidr},
+			})
+		}
+	}
+	SortIngressRules(result)
+	return result
+}
+
+// OpenIngressRules returns a rule allowing traffic from any source for
+// each of the given port ranges.
+func OpenIngressRules(portRanges []PortRange) []IngressRule {
+	rules := make([]IngressRule, len(portRanges))
+	for i, portRange := range portRanges {
+		rules[i] = NewOpenIngressRule(portRange)
+	}
+	return rules
+}
+
+// IngressRulePortRanges returns the port ranges of the given rules
+// which allow traffic from any source. Rules restricted to specific
+// source CIDRs are omitted.
+func IngressRulePortRanges(rules []IngressRule) []PortRange {
+	var portRanges []PortRange
+	for _, rule := range rules {
+		if rule.IsOpen() {
+			portRanges = append(portRanges, rule.PortRange)
+		}
+	}
+	return portRanges
+}
+
+// ParseCIDRs parses a comma-separated list of CIDRs, as used by the
+// ssh-allow and api-allow configuration attributes. Surrounding white
+// space is ignored, and an empty value yields no CIDRs.
+func ParseCIDRs(value string) ([]string, error) {
+	var cidrs []string
+	for _, cidr := range strings.Split(value, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, errors.NotValidf("CIDR %q", cidr)
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs, nil
+}
+
+// AccessRules returns the ingress rules which allow SSH connections
+// from sshAllow, and API connections to apiPort from apiAllow. An
+// empty list of CIDRs allows connections from any source.
+func AccessRules(sshAllow, apiAllow []string, apiPort int) ([]IngressRule, error) {
+	sshRule, err := NewIngressRule(PortRange{SSHPort, SSHPort, "tcp"}, sshAllow...)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid SSH access rule")
+	}
+	apiRule, err := NewIngressRule(PortRange{apiPort, apiPort, "tcp"}, apiAllow...)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid API access rule")
+	}
+	rules := []IngressRule{sshRule, apiRule}
+	SortIngressRules(rules)
+	return rules, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type IngressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IngressRuleSuite{})
+
+func (*IngressRuleSuite) TestNewIngressRuleSortsCIDRs(c *gc.C) {
+	rule, err := network.NewIngressRule(
+		network.MustParsePortRange("80/tcp"), "192.168.1.0/24", "10.0.0.0/8",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.SourceCIDRs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+	c.Assert(rule.IsOpen(), jc.IsFalse)
+	c.Assert(rule.String(), gc.Equals, "80/tcp from 10.0.0.0/8,192.168.1.0/24")
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleAnyCIDR(c *gc.C) {
+	rule, err := network.NewIngressRule(
+		network.MustParsePortRange("80-90/udp"), "10.0.0.0/8", "0.0.0.0/0",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.IsOpen(), jc.IsTrue)
+	c.Assert(rule.SourceRanges(), jc.DeepEquals, []string{"0.0.0.0/0"})
+	c.Assert(rule.String(), gc.Equals, "80-90/udp")
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleInvalid(c *gc.C) {
+	_, err := network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0")
+	c.Assert(err, gc.ErrorMatches, `source CIDR "10.0.0.0" not valid`)
+
+	_, err = network.NewIngressRule(network.PortRange{90, 80, "tcp"})
+	c.Assert(err, gc.ErrorMatches, "invalid port range 90-80/tcp")
+}
+
+func (*IngressRuleSuite) TestSortIngressRules(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.1.0/24"),
+		network.MustNewIngressRule(network.MustParsePortRange("53/udp")),
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.MustNewIngressRule(network.MustParsePortRange("22/tcp")),
+	}
+	network.SortIngressRules(rules)
+	var strs []string
+	for _, rule := range rules {
+		strs = append(strs, rule.String())
+	}
+	c.Assert(strs, jc.DeepEquals, []string{
+		"22/tcp",
+		"80/tcp from 10.0.0.0/8",
+		"80/tcp from 192.168.1.0/24",
+		"53/udp",
+	})
+}
+
+func (*IngressRuleSuite) TestSplitIngressRules(c *gc.C) {
+	rules := network.SplitIngressRules([]network.IngressRule{
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.1.0/24", "10.0.0.0/8"),
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.MustNewIngressRule(network.MustParsePortRange("53/udp")),
+		network.MustNewIngressRule(network.MustParsePortRange("22/tcp"), "172.16.0.0/12"),
+		network.MustNewIngressRule(network.MustParsePortRange("53/udp")),
+	})
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.MustParsePortRange("22/tcp"), "172.16.0.0/12"),
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.1.0/24"),
+		network.MustNewIngressRule(network.MustParsePortRange("53/udp")),
+	})
+}
+
+func (*IngressRuleSuite) TestIngressRulePortRanges(c *gc.C) {
+	portRanges := []network.PortRange{
+		network.MustParsePortRange("22/tcp"),
+		network.MustParsePortRange("80/tcp"),
+	}
+	rules := network.OpenIngressRules(portRanges)
+	rules = append(rules, network.MustNewIngressRule(
+		network.MustParsePortRange("443/tcp"), "10.0.0.0/8",
+	))
+	c.Assert(network.IngressRulePortRanges(rules), jc.DeepEquals, portRanges)
+}
+
+func (*IngressRuleSuite) TestParseCIDRs(c *gc.C) {
+	cidrs, err := network.ParseCIDRs(" 10.0.0.0/8, 192.168.1.0/24 ,")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	cidrs, err = network.ParseCIDRs("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+
+	_, err = network.ParseCIDRs("10.0.0.0/8,bogus")
+	c.Assert(err, gc.ErrorMatches, `CIDR "bogus" not valid`)
+}
+
+func (*IngressRuleSuite) TestAccessRules(c *gc.C) {
+	rules, err := network.AccessRules([]string{"10.0.0.0/8"}, nil, 17070)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.MustParsePortRange("22/tcp"), "10.0.0.0/8"),
+		network.NewOpenIngressRule(network.MustParsePortRange("17070/tcp")),
+	})
+}
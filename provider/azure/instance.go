@@ -27,6 +27,8 @@ type azureInstance struct {
 	publicIPAddresses []network.PublicIPAddress
 }
 
+var _ instance.IngressFirewaller = (*azureInstance)(nil)
+
 // Id is specified in the Instance interface.
 func (inst *azureInstance) Id() instance.Id {
 	// Note: we use Name and not Id, since all VM operations are in
@@ -185,6 +187,11 @@ func (inst *azureInstance) internalNetworkAddress() (jujunetwork.Address, error)
 
 // OpenPorts is specified in the Instance interface.
 func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortRange) error {
+	return inst.OpenIngressRules(machineId, jujunetwork.OpenIngressRules(ports))
+}
+
+// OpenIngressRules is specified in the instance.IngressFirewaller interface.
+func (inst *azureInstance) OpenIngressRules(machineId string, rules []jujunetwork.IngressRule) error {
 	inst.env.mu.Lock()
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
@@ -216,73 +223,85 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 	// NSG in memory, so we can easily tell which priorities are available.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
-
-		// Check if the rule already exists; OpenPorts must be idempotent.
-		var found bool
-		for _, rule := range securityRules {
-			if to.String(rule.Name) == ruleName {
-				found = true
-				break
+	for _, ingressRule := range rules {
+		ports := ingressRule.PortRange
+		for _, sourcePrefix := range securityRuleSourcePrefixes(ingressRule) {
+			ruleName := securityRuleSourceName(securityRuleName(prefix, ports), sourcePrefix)
+			description := ports.String()
+			if sourcePrefix != "*" {
+				description += " from " + sourcePrefix
 			}
-		}
-		if found {
-			logger.Debugf("security rule %q already exists", ruleName)
-			continue
-		}
-		logger.Debugf("creating security rule %q", ruleName)
 
-		priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMax+1, securityRuleMax)
-		if err != nil {
-			return errors.Annotatef(err, "getting security rule priority for %s", ports)
-		}
+			// Check if the rule already exists; OpenPorts must be idempotent.
+			var found bool
+			for _, rule := range securityRules {
+				if to.String(rule.Name) == ruleName {
+					found = true
+					break
+				}
+			}
+			if found {
+				logger.Debugf("security rule %q already exists", ruleName)
+				continue
+			}
+			logger.Debugf("creating security rule %q", ruleName)
 
-		var protocol network.SecurityRuleProtocol
-		switch ports.Protocol {
-		case "tcp":
-			protocol = network.TCP
-		case "udp":
-			protocol = network.UDP
-		default:
-			return errors.Errorf("invalid protocol %q", ports.Protocol)
-		}
+			priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMax+1, securityRuleMax)
+			if err != nil {
+				return errors.Annotatef(err, "getting security rule priority for %s", ports)
+			}
 
-		var portRange string
-		if ports.FromPort != ports.ToPort {
-			portRange = fmt.Sprintf("%d-%d", ports.FromPort, ports.ToPort)
-		} else {
-			portRange = fmt.Sprint(ports.FromPort)
-		}
+			var protocol network.SecurityRuleProtocol
+			switch ports.Protocol {
+			case "tcp":
+				protocol = network.TCP
+			case "udp":
+				protocol = network.UDP
+			default:
+				return errors.Errorf("invalid protocol %q", ports.Protocol)
+			}
 
-		rule := network.SecurityRule{
-			Properties: &network.SecurityRulePropertiesFormat{
-				Description:              to.StringPtr(ports.String()),
-				Protocol:                 protocol,
-				SourcePortRange:          to.StringPtr("*"),
-				DestinationPortRange:     to.StringPtr(portRange),
-				SourceAddressPrefix:      to.StringPtr("*"),
-				DestinationAddressPrefix: to.StringPtr(internalNetworkAddress.Value),
-				Access:    network.Allow,
-				Priority:  to.IntPtr(priority),
-				Direction: network.Inbound,
-			},
-		}
-		if err := inst.env.callAPI(func() (autorest.Response, error) {
-			result, err := securityRuleClient.CreateOrUpdate(
-				inst.env.resourceGroup, securityGroupName, ruleName, rule,
-			)
-			return result.Response, err
-		}); err != nil {
-			return errors.Annotatef(err, "creating security rule for %s", ports)
+			var portRange string
+			if ports.FromPort != ports.ToPort {
+				portRange = fmt.Sprintf("%d-%d", ports.FromPort, ports.ToPort)
+			} else {
+				portRange = fmt.Sprint(ports.FromPort)
+			}
+
+			rule := network.SecurityRule{
+				Properties: &network.SecurityRulePropertiesFormat{
+					Description:              to.StringPtr(description),
+					Protocol:                 protocol,
+					SourcePortRange:          to.StringPtr("*"),
+					DestinationPortRange:     to.StringPtr(portRange),
+					SourceAddressPrefix:      to.StringPtr(sourcePrefix),
+					DestinationAddressPrefix: to.StringPtr(internalNetworkAddress.Value),
+					Access:    network.Allow,
+					Priority:  to.IntPtr(priority),
+					Direction: network.Inbound,
+				},
+			}
+			if err := inst.env.callAPI(func() (autorest.Response, error) {
+				result, err := securityRuleClient.CreateOrUpdate(
+					inst.env.resourceGroup, securityGroupName, ruleName, rule,
+				)
+				return result.Response, err
+			}); err != nil {
+				return errors.Annotatef(err, "creating security rule for %s", ports)
+			}
+			securityRules = append(securityRules, rule)
 		}
-		securityRules = append(securityRules, rule)
 	}
 	return nil
 }
 
 // ClosePorts is specified in the Instance interface.
 func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.PortRange) error {
+	return inst.CloseIngressRules(machineId, jujunetwork.OpenIngressRules(ports))
+}
+
+// CloseIngressRules is specified in the instance.IngressFirewaller interface.
+func (inst *azureInstance) CloseIngressRules(machineId string, rules []jujunetwork.IngressRule) error {
 	inst.env.mu.Lock()
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
 	inst.env.mu.Unlock()
@@ -292,19 +311,21 @@ func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.Port
 	// on changes made by the provisioner.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
-		logger.Debugf("deleting security rule %q", ruleName)
-		var result autorest.Response
-		if err := inst.env.callAPI(func() (autorest.Response, error) {
-			var err error
-			result, err = securityRuleClient.Delete(
-				inst.env.resourceGroup, securityGroupName, ruleName,
-			)
-			return result, err
-		}); err != nil {
-			if result.Response == nil || result.StatusCode != http.StatusNotFound {
-				return errors.Annotatef(err, "deleting security rule %q", ruleName)
+	for _, ingressRule := range rules {
+		for _, sourcePrefix := range securityRuleSourcePrefixes(ingressRule) {
+			ruleName := securityRuleSourceName(securityRuleName(prefix, ingressRule.PortRange), sourcePrefix)
+			logger.Debugf("deleting security rule %q", ruleName)
+			var result autorest.Response
+			if err := inst.env.callAPI(func() (autorest.Response, error) {
+				var err error
+				result, err = securityRuleClient.Delete(
+					inst.env.resourceGroup, securityGroupName, ruleName,
+				)
+				return result, err
+			}); err != nil {
+				if result.Response == nil || result.StatusCode != http.StatusNotFound {
+					return errors.Annotatef(err, "deleting security rule %q", ruleName)
+				}
 			}
 		}
 	}
@@ -312,7 +333,67 @@ func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.Port
 }
 
 // Ports is specified in the Instance interface.
-func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRange, err error) {
+func (inst *azureInstance) Ports(machineId string) ([]jujunetwork.PortRange, error) {
+	rules, err := inst.securityRuleIngressRules(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// There is a security rule for each source prefix allowed to
+	// access a port range, so only report each port range once.
+	var ports []jujunetwork.PortRange
+	seen := make(map[jujunetwork.PortRange]bool)
+	for _, rule := range rules {
+		if seen[rule.PortRange] {
+			continue
+		}
+		seen[rule.PortRange] = true
+		ports = append(ports, rule.PortRange)
+	}
+	return ports, nil
+}
+
+// IngressRules is specified in the instance.IngressFirewaller interface.
+func (inst *azureInstance) IngressRules(machineId string) ([]jujunetwork.IngressRule, error) {
+	rules, err := inst.securityRuleIngressRules(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Azure has a security rule per source prefix, so collect the
+	// prefixes for each port range into a single ingress rule. A port
+	// range open to all sources is reported as a separate rule, as it
+	// cannot have come from the same rule as any specific prefixes.
+	var result []jujunetwork.IngressRule
+	var portRanges []jujunetwork.PortRange
+	open := make(map[jujunetwork.PortRange]bool)
+	sourceCIDRs := make(map[jujunetwork.PortRange][]string)
+	for _, rule := range rules {
+		if rule.IsOpen() {
+			if !open[rule.PortRange] {
+				open[rule.PortRange] = true
+				result = append(result, jujunetwork.NewOpenIngressRule(rule.PortRange))
+			}
+			continue
+		}
+		if _, ok := sourceCIDRs[rule.PortRange]; !ok {
+			portRanges = append(portRanges, rule.PortRange)
+		}
+		sourceCIDRs[rule.PortRange] = append(sourceCIDRs[rule.PortRange], rule.SourceCIDRs...)
+	}
+	for _, portRange := range portRanges {
+		rule, err := jujunetwork.NewIngressRule(portRange, sourceCIDRs[portRange]...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, rule)
+	}
+	jujunetwork.SortIngressRules(result)
+	return result, nil
+}
+
+// securityRuleIngressRules returns an ingress rule for each port range
+// and protocol of each security rule opened for the machine, in the
+// order the security rules are defined.
+func (inst *azureInstance) securityRuleIngressRules(machineId string) (rules []jujunetwork.IngressRule, err error) {
 	inst.env.mu.Lock()
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	inst.env.mu.Unlock()
@@ -371,12 +452,19 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 		default:
 			protocols = []string{"tcp", "udp"}
 		}
+		var sourceCIDRs []string
+		if sourcePrefix := to.String(rule.Properties.SourceAddressPrefix); sourcePrefix != "" && sourcePrefix != "*" {
+			sourceCIDRs = []string{sourcePrefix}
+		}
 		for _, protocol := range protocols {
 			portRange.Protocol = protocol
-			ports = append(ports, portRange)
+			rules = append(rules, jujunetwork.IngressRule{
+				PortRange:   portRange,
+				SourceCIDRs: sourceCIDRs,
+			})
 		}
 	}
-	return ports, nil
+	return rules, nil
 }
 
 // deleteInstanceNetworkSecurityRules deletes network security rules in the
@@ -428,6 +516,25 @@ func instanceNetworkSecurityRulePrefix(id instance.Id) string {
 	return string(id) + "-"
 }
 
+// securityRuleSourcePrefixes returns the source address prefixes for
+// the security rules implementing the given ingress rule.
+func securityRuleSourcePrefixes(rule jujunetwork.IngressRule) []string {
+	if rule.IsOpen() {
+		return []string{"*"}
+	}
+	return rule.SourceCIDRs
+}
+
+// securityRuleSourceName returns the security rule name for the given
+// rule name, restricted to the given source address prefix. Security
+// rule names may not contain '/', so it is replaced in the CIDR.
+func securityRuleSourceName(ruleName, sourcePrefix string) string {
+	if sourcePrefix == "*" {
+		return ruleName
+	}
+	return ruleName + "-from-" + strings.Replace(sourcePrefix, "/", "-", -1)
+}
+
 // securityRuleName returns the security rule name for the given port range,
 // and prefix returned by instanceNetworkSecurityRulePrefix.
 func securityRuleName(prefix string, ports jujunetwork.PortRange) string {
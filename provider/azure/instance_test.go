@@ -341,6 +341,48 @@ func (s *instanceSuite) TestInstancePorts(c *gc.C) {
 	}})
 }
 
+func (s *instanceSuite) TestInstanceIngressRules(c *gc.C) {
+	inst := s.getInstance(c)
+	securityRule := func(name, portRange, sourcePrefix string, priority int) network.SecurityRule {
+		return network.SecurityRule{
+			Name: to.StringPtr(name),
+			Properties: &network.SecurityRulePropertiesFormat{
+				Protocol:             network.TCP,
+				DestinationPortRange: to.StringPtr(portRange),
+				SourceAddressPrefix:  to.StringPtr(sourcePrefix),
+				Access:               network.Allow,
+				Priority:             to.IntPtr(priority),
+				Direction:            network.Inbound,
+			},
+		}
+	}
+	securityRules := []network.SecurityRule{
+		securityRule("machine-0-tcp-80", "80", "*", 200),
+		securityRule("machine-0-tcp-80-cidr-10-0-0-0-8", "80", "10.0.0.0/8", 201),
+		securityRule("machine-0-tcp-80-cidr-192-168-1-0-24", "80", "192.168.1.0/24", 202),
+		securityRule("machine-0-tcp-22-cidr-10-0-0-0-8", "22", "10.0.0.0/8", 203),
+	}
+	s.sender = azuretesting.Senders{
+		networkSecurityGroupSender(securityRules),
+		networkSecurityGroupSender(securityRules),
+	}
+
+	rules, err := inst.IngressRules("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []jujunetwork.IngressRule{
+		jujunetwork.MustNewIngressRule(jujunetwork.PortRange{22, 22, "tcp"}, "10.0.0.0/8"),
+		jujunetwork.MustNewIngressRule(jujunetwork.PortRange{80, 80, "tcp"}),
+		jujunetwork.MustNewIngressRule(jujunetwork.PortRange{80, 80, "tcp"}, "10.0.0.0/8", "192.168.1.0/24"),
+	})
+
+	ports, err := inst.Ports("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, []jujunetwork.PortRange{
+		{80, 80, "tcp"},
+		{22, 22, "tcp"},
+	})
+}
+
 func (s *instanceSuite) TestInstanceClosePorts(c *gc.C) {
 	inst := s.getInstance(c)
 	sender := mocks.NewSender()
@@ -436,6 +478,53 @@ func (s *instanceSuite) TestInstanceOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *instanceSuite) TestInstanceOpenIngressRules(c *gc.C) {
+	internalSubnetId := path.Join(
+		"/subscriptions", fakeSubscriptionId,
+		"resourceGroups/juju-testenv-model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"providers/Microsoft.Network/virtualnetworks/juju-internal-network/subnets/juju-internal-subnet",
+	)
+	ipConfiguration := network.InterfaceIPConfiguration{
+		Properties: &network.InterfaceIPConfigurationPropertiesFormat{
+			PrivateIPAddress: to.StringPtr("10.0.0.4"),
+			Subnet: &network.SubResource{
+				ID: to.StringPtr(internalSubnetId),
+			},
+		},
+	}
+	s.networkInterfaces = []network.Interface{
+		makeNetworkInterface("nic-0", "machine-0", ipConfiguration),
+	}
+
+	inst := s.getInstance(c)
+	okSender := mocks.NewSender()
+	okSender.EmitContent("{}")
+	nsgSender := networkSecurityGroupSender(nil)
+	s.sender = azuretesting.Senders{nsgSender, okSender}
+
+	err := inst.(instance.IngressFirewaller).OpenIngressRules("0", []jujunetwork.IngressRule{
+		jujunetwork.MustNewIngressRule(jujunetwork.PortRange{1000, 1000, "tcp"}, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[1].Method, gc.Equals, "PUT")
+	c.Assert(s.requests[1].URL.Path, gc.Equals, securityRulePath("machine-0-tcp-1000-from-10.0.0.0-8"))
+	assertRequestBody(c, s.requests[1], &network.SecurityRule{
+		Properties: &network.SecurityRulePropertiesFormat{
+			Description:              to.StringPtr("1000/tcp from 10.0.0.0/8"),
+			Protocol:                 network.TCP,
+			SourcePortRange:          to.StringPtr("*"),
+			SourceAddressPrefix:      to.StringPtr("10.0.0.0/8"),
+			DestinationPortRange:     to.StringPtr("1000"),
+			DestinationAddressPrefix: to.StringPtr("10.0.0.4"),
+			Access:    network.Allow,
+			Priority:  to.IntPtr(200),
+			Direction: network.Inbound,
+		},
+	})
+}
+
 func (s *instanceSuite) TestInstanceOpenPortsAlreadyOpen(c *gc.C) {
 	internalSubnetId := path.Join(
 		"/subscriptions", fakeSubscriptionId,
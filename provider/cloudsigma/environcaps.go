@@ -35,6 +35,7 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.Zones,
 }
 
 // ConstraintsValidator returns a Validator instance which
@@ -133,15 +133,41 @@ func AvailabilityZoneAllocations(env ZonedEnviron, group []instance.Id) ([]Avail
 
 var internalAvailabilityZoneAllocations = AvailabilityZoneAllocations
 
+// FilterZoneInstances returns the subset of the availability zone
+// allocations whose zone is named in limitZones, preserving the
+// order of the allocations. If limitZones is empty, all of the
+// allocations are returned.
+func FilterZoneInstances(zoneInstances []AvailabilityZoneInstances, limitZones []string) []AvailabilityZoneInstances {
+	if len(limitZones) == 0 {
+		return zoneInstances
+	}
+	allowed := make(map[string]bool)
+	for _, zone := range limitZones {
+		allowed[zone] = true
+	}
+	var filtered []AvailabilityZoneInstances
+	for _, z := range zoneInstances {
+		if allowed[z.ZoneName] {
+			filtered = append(filtered, z)
+		}
+	}
+	return filtered
+}
+
 // DistributeInstances is a common function for implement the
 // state.InstanceDistributor policy based on availability zone
-// spread.
-func DistributeInstances(env ZonedEnviron, candidates, group []instance.Id) ([]instance.Id, error) {
+// spread. If limitZones is non-empty, only instances in the named
+// zones are considered.
+func DistributeInstances(env ZonedEnviron, candidates, group []instance.Id, limitZones []string) ([]instance.Id, error) {
 	// Determine the best availability zones for the group.
 	zoneInstances, err := internalAvailabilityZoneAllocations(env, group)
-	if err != nil || len(zoneInstances) == 0 {
+	if err != nil {
 		return nil, err
 	}
+	zoneInstances = FilterZoneInstances(zoneInstances, limitZones)
+	if len(zoneInstances) == 0 {
+		return nil, nil
+	}
 
 	// Determine which of the candidates are eligible based on whether
 	// they are allocated in one of the best availability zones.
@@ -158,7 +184,7 @@ func DistributeInstances(env ZonedEnviron, candidates, group []instance.Id) ([]i
 	eligible := make([]instance.Id, 0, len(candidates))
 	for _, candidate := range candidates {
 		n := sort.SearchStrings(allEligible, string(candidate))
-		if n >= 0 && n < len(allEligible) {
+		if n < len(allEligible) && allEligible[n] == string(candidate) {
 			eligible = append(eligible, candidate)
 		}
 	}
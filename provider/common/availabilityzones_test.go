@@ -163,7 +163,7 @@ func (s *AvailabilityZoneSuite) TestDistributeInstancesGroup(c *gc.C) {
 		called = true
 		return nil, nil
 	})
-	common.DistributeInstances(&s.env, nil, expectedGroup)
+	common.DistributeInstances(&s.env, nil, expectedGroup, nil)
 	c.Assert(called, jc.IsTrue)
 }
 
@@ -172,7 +172,7 @@ func (s *AvailabilityZoneSuite) TestDistributeInstancesGroupErrors(c *gc.C) {
 	s.PatchValue(common.InternalAvailabilityZoneAllocations, func(_ common.ZonedEnviron, group []instance.Id) ([]common.AvailabilityZoneInstances, error) {
 		return nil, resultErr
 	})
-	_, err := common.DistributeInstances(&s.env, nil, nil)
+	_, err := common.DistributeInstances(&s.env, nil, nil, nil)
 	c.Assert(err, gc.Equals, resultErr)
 }
 
@@ -185,6 +185,7 @@ func (s *AvailabilityZoneSuite) TestDistributeInstances(c *gc.C) {
 	type distributeInstancesTest struct {
 		zoneInstances []common.AvailabilityZoneInstances
 		candidates    []instance.Id
+		limitZones    []string
 		eligible      []instance.Id
 	}
 
@@ -227,6 +228,21 @@ func (s *AvailabilityZoneSuite) TestDistributeInstances(c *gc.C) {
 		}},
 		candidates: []instance.Id{"i3", "i4", "i5"},
 		eligible:   []instance.Id{},
+	}, {
+		// Candidates which sort between the instances in the best
+		// zones are not in any of those zones, so are not eligible.
+		zoneInstances: []common.AvailabilityZoneInstances{{
+			ZoneName:  "az0",
+			Instances: []instance.Id{"i0"},
+		}, {
+			ZoneName:  "az1",
+			Instances: []instance.Id{"i2"},
+		}, {
+			ZoneName:  "az2",
+			Instances: []instance.Id{"i1", "i3"},
+		}},
+		candidates: []instance.Id{"i0", "i1", "i3"},
+		eligible:   []instance.Id{"i0"},
 	}, {
 		zoneInstances: []common.AvailabilityZoneInstances{{
 			ZoneName:  "az0",
@@ -244,13 +260,52 @@ func (s *AvailabilityZoneSuite) TestDistributeInstances(c *gc.C) {
 		zoneInstances: []common.AvailabilityZoneInstances{},
 		candidates:    []instance.Id{"i0"},
 		eligible:      []instance.Id{},
+	}, {
+		zoneInstances: []common.AvailabilityZoneInstances{{
+			ZoneName:  "az0",
+			Instances: []instance.Id{"i0"},
+		}, {
+			ZoneName:  "az1",
+			Instances: []instance.Id{"i1"},
+		}, {
+			ZoneName:  "az2",
+			Instances: []instance.Id{"i2"},
+		}},
+		candidates: []instance.Id{"i0", "i1", "i2"},
+		limitZones: []string{"az0", "az2"},
+		eligible:   []instance.Id{"i0", "i2"},
+	}, {
+		zoneInstances: []common.AvailabilityZoneInstances{{
+			ZoneName:  "az0",
+			Instances: []instance.Id{"i0"},
+		}},
+		candidates: []instance.Id{"i0"},
+		limitZones: []string{"az1"},
+		eligible:   []instance.Id{},
 	}}
 
 	for i, test := range tests {
 		c.Logf("test %d", i)
 		zoneInstances = test.zoneInstances
-		eligible, err := common.DistributeInstances(&s.env, test.candidates, nil)
+		eligible, err := common.DistributeInstances(&s.env, test.candidates, nil, test.limitZones)
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(eligible, jc.SameContents, test.eligible)
 	}
 }
+
+func (s *AvailabilityZoneSuite) TestFilterZoneInstances(c *gc.C) {
+	zoneInstances := []common.AvailabilityZoneInstances{{
+		ZoneName: "az0",
+	}, {
+		ZoneName: "az1",
+	}, {
+		ZoneName: "az2",
+	}}
+	c.Assert(common.FilterZoneInstances(zoneInstances, nil), jc.DeepEquals, zoneInstances)
+	c.Assert(common.FilterZoneInstances(zoneInstances, []string{"az2", "az0"}), jc.DeepEquals, []common.AvailabilityZoneInstances{{
+		ZoneName: "az0",
+	}, {
+		ZoneName: "az2",
+	}})
+	c.Assert(common.FilterZoneInstances(zoneInstances, []string{"az3"}), gc.HasLen, 0)
+}
@@ -144,6 +144,7 @@ func BootstrapInstance(ctx environs.BootstrapContext, env environs.Environ, args
 		InstanceConfig: instanceConfig,
 		Placement:      args.Placement,
 		ImageMetadata:  imageMetadata,
+		APIAllow:       args.ControllerConfig.APIAllow(),
 		StatusCallback: instanceStatus,
 	})
 	if err != nil {
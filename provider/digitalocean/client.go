@@ -0,0 +1,485 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+// client is a minimal client for the parts of the DigitalOcean v2
+// REST API that are used by the provider.
+type client struct {
+	endpoint string
+	token    string
+	http     *http.Client
+}
+
+// newClient returns a client that makes requests to the API at the
+// given endpoint, authenticating with the given access token.
+func newClient(endpoint, token string) *client {
+	return &client{
+		endpoint: strings.TrimRight(endpoint, "/"),
+		token:    token,
+		http:     utils.GetValidatingHTTPClient(),
+	}
+}
+
+// apiError is the error returned by the API for failed requests.
+type apiError struct {
+	StatusCode int    `json:"-"`
+	ID         string `json:"id"`
+	Message    string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("digitalocean API request failed with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("digitalocean API request failed: %s (%s)", e.Message, e.ID)
+}
+
+// links holds the pagination links of a collection response.
+type links struct {
+	Pages struct {
+		Next string `json:"next"`
+	} `json:"pages"`
+}
+
+type region struct {
+	Slug      string   `json:"slug"`
+	Name      string   `json:"name"`
+	Available bool     `json:"available"`
+	Sizes     []string `json:"sizes"`
+}
+
+type size struct {
+	Slug         string   `json:"slug"`
+	Memory       int      `json:"memory"`
+	VCPUs        int      `json:"vcpus"`
+	Disk         int      `json:"disk"`
+	PriceMonthly float64  `json:"price_monthly"`
+	Available    bool     `json:"available"`
+	Regions      []string `json:"regions"`
+}
+
+type dropletNetwork struct {
+	IPAddress string `json:"ip_address"`
+	Type      string `json:"type"`
+}
+
+type droplet struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Memory   int    `json:"memory"`
+	VCPUs    int    `json:"vcpus"`
+	Disk     int    `json:"disk"`
+	Status   string `json:"status"`
+	SizeSlug string `json:"size_slug"`
+	Region   region `json:"region"`
+	Networks struct {
+		V4 []dropletNetwork `json:"v4"`
+		V6 []dropletNetwork `json:"v6"`
+	} `json:"networks"`
+	Tags      []string `json:"tags"`
+	VolumeIDs []string `json:"volume_ids"`
+}
+
+type dropletCreateRequest struct {
+	Name              string   `json:"name"`
+	Region            string   `json:"region"`
+	Size              string   `json:"size"`
+	Image             string   `json:"image"`
+	SSHKeys           []int    `json:"ssh_keys,omitempty"`
+	PrivateNetworking bool     `json:"private_networking"`
+	IPv6              bool     `json:"ipv6"`
+	UserData          string   `json:"user_data,omitempty"`
+	Tags              []string `json:"tags,omitempty"`
+}
+
+type sshKey struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	PublicKey   string `json:"public_key"`
+}
+
+type firewallTargets struct {
+	Addresses  []string `json:"addresses,omitempty"`
+	DropletIDs []int    `json:"droplet_ids,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+}
+
+type firewallRule struct {
+	Protocol     string           `json:"protocol"`
+	Ports        string           `json:"ports,omitempty"`
+	Sources      *firewallTargets `json:"sources,omitempty"`
+	Destinations *firewallTargets `json:"destinations,omitempty"`
+}
+
+type firewall struct {
+	ID            string         `json:"id,omitempty"`
+	Name          string         `json:"name"`
+	InboundRules  []firewallRule `json:"inbound_rules"`
+	OutboundRules []firewallRule `json:"outbound_rules"`
+	DropletIDs    []int          `json:"droplet_ids"`
+	Tags          []string       `json:"tags"`
+}
+
+type volume struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	SizeGigabytes int    `json:"size_gigabytes"`
+	Region        region `json:"region"`
+	DropletIDs    []int  `json:"droplet_ids"`
+}
+
+type volumeCreateRequest struct {
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Region        string `json:"region"`
+	SizeGigabytes int    `json:"size_gigabytes"`
+}
+
+type volumeActionRequest struct {
+	Type      string `json:"type"`
+	DropletID int    `json:"droplet_id"`
+	Region    string `json:"region"`
+}
+
+type action struct {
+	ID     int    `json:"id"`
+	Status string `json:"status"`
+	Type   string `json:"type"`
+}
+
+const (
+	actionCompleted = "completed"
+	actionErrored   = "errored"
+)
+
+// account checks that the client's access token is valid.
+func (c *client) account() error {
+	return errors.Trace(c.do("GET", "/v2/account", nil, nil))
+}
+
+// regions returns all of the regions.
+func (c *client) regions() ([]region, error) {
+	var all []region
+	err := c.list("/v2/regions", func(page func(interface{}) error) error {
+		var resp struct {
+			Regions []region `json:"regions"`
+		}
+		if err := page(&resp); err != nil {
+			return err
+		}
+		all = append(all, resp.Regions...)
+		return nil
+	})
+	return all, errors.Trace(err)
+}
+
+// sizes returns all of the droplet sizes.
+func (c *client) sizes() ([]size, error) {
+	var all []size
+	err := c.list("/v2/sizes", func(page func(interface{}) error) error {
+		var resp struct {
+			Sizes []size `json:"sizes"`
+		}
+		if err := page(&resp); err != nil {
+			return err
+		}
+		all = append(all, resp.Sizes...)
+		return nil
+	})
+	return all, errors.Trace(err)
+}
+
+// droplets returns the droplets with the given tag.
+func (c *client) droplets(tag string) ([]droplet, error) {
+	var all []droplet
+	path := "/v2/droplets?tag_name=" + url.QueryEscape(tag)
+	err := c.list(path, func(page func(interface{}) error) error {
+		var resp struct {
+			Droplets []droplet `json:"droplets"`
+		}
+		if err := page(&resp); err != nil {
+			return err
+		}
+		all = append(all, resp.Droplets...)
+		return nil
+	})
+	return all, errors.Trace(err)
+}
+
+// createDroplet creates a droplet and returns it. The droplet
+// is returned before it has become active.
+func (c *client) createDroplet(req dropletCreateRequest) (*droplet, error) {
+	var resp struct {
+		Droplet droplet `json:"droplet"`
+	}
+	if err := c.do("POST", "/v2/droplets", req, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &resp.Droplet, nil
+}
+
+// deleteDroplet deletes the droplet with the given ID.
+func (c *client) deleteDroplet(id int) error {
+	return errors.Trace(c.do("DELETE", fmt.Sprintf("/v2/droplets/%d", id), nil, nil))
+}
+
+// createTag creates a tag with the given name, if it does not
+// already exist.
+func (c *client) createTag(name string) error {
+	req := struct {
+		Name string `json:"name"`
+	}{name}
+	return errors.Trace(c.do("POST", "/v2/tags", req, nil))
+}
+
+// sshKeys returns all of the SSH keys registered with the account.
+func (c *client) sshKeys() ([]sshKey, error) {
+	var all []sshKey
+	err := c.list("/v2/account/keys", func(page func(interface{}) error) error {
+		var resp struct {
+			SSHKeys []sshKey `json:"ssh_keys"`
+		}
+		if err := page(&resp); err != nil {
+			return err
+		}
+		all = append(all, resp.SSHKeys...)
+		return nil
+	})
+	return all, errors.Trace(err)
+}
+
+// createSSHKey registers an SSH public key with the account.
+func (c *client) createSSHKey(name, publicKey string) (*sshKey, error) {
+	var resp struct {
+		SSHKey sshKey `json:"ssh_key"`
+	}
+	req := sshKey{Name: name, PublicKey: publicKey}
+	if err := c.do("POST", "/v2/account/keys", req, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &resp.SSHKey, nil
+}
+
+// deleteSSHKey removes the SSH key with the given ID from the account.
+func (c *client) deleteSSHKey(id int) error {
+	return errors.Trace(c.do("DELETE", fmt.Sprintf("/v2/account/keys/%d", id), nil, nil))
+}
+
+// firewalls returns all of the cloud firewalls.
+func (c *client) firewalls() ([]firewall, error) {
+	var all []firewall
+	err := c.list("/v2/firewalls", func(page func(interface{}) error) error {
+		var resp struct {
+			Firewalls []firewall `json:"firewalls"`
+		}
+		if err := page(&resp); err != nil {
+			return err
+		}
+		all = append(all, resp.Firewalls...)
+		return nil
+	})
+	return all, errors.Trace(err)
+}
+
+// createFirewall creates a cloud firewall and returns it.
+func (c *client) createFirewall(fw firewall) (*firewall, error) {
+	var resp struct {
+		Firewall firewall `json:"firewall"`
+	}
+	if err := c.do("POST", "/v2/firewalls", fw, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &resp.Firewall, nil
+}
+
+// updateFirewall replaces the rules and targets of the cloud
+// firewall with the same ID as the one given.
+func (c *client) updateFirewall(fw firewall) (*firewall, error) {
+	var resp struct {
+		Firewall firewall `json:"firewall"`
+	}
+	if err := c.do("PUT", "/v2/firewalls/"+fw.ID, fw, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &resp.Firewall, nil
+}
+
+// deleteFirewall deletes the cloud firewall with the given ID.
+func (c *client) deleteFirewall(id string) error {
+	return errors.Trace(c.do("DELETE", "/v2/firewalls/"+id, nil, nil))
+}
+
+// volumes returns the block storage volumes in the given region.
+func (c *client) volumes(region string) ([]volume, error) {
+	var all []volume
+	path := "/v2/volumes?region=" + url.QueryEscape(region)
+	err := c.list(path, func(page func(interface{}) error) error {
+		var resp struct {
+			Volumes []volume `json:"volumes"`
+		}
+		if err := page(&resp); err != nil {
+			return err
+		}
+		all = append(all, resp.Volumes...)
+		return nil
+	})
+	return all, errors.Trace(err)
+}
+
+// volume returns the block storage volume with the given ID.
+func (c *client) volume(id string) (*volume, error) {
+	var resp struct {
+		Volume volume `json:"volume"`
+	}
+	if err := c.do("GET", "/v2/volumes/"+id, nil, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &resp.Volume, nil
+}
+
+// createVolume creates a block storage volume and returns it.
+func (c *client) createVolume(req volumeCreateRequest) (*volume, error) {
+	var resp struct {
+		Volume volume `json:"volume"`
+	}
+	if err := c.do("POST", "/v2/volumes", req, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &resp.Volume, nil
+}
+
+// deleteVolume deletes the block storage volume with the given ID.
+func (c *client) deleteVolume(id string) error {
+	return errors.Trace(c.do("DELETE", "/v2/volumes/"+id, nil, nil))
+}
+
+// volumeAction initiates an action, such as attaching or detaching,
+// on the block storage volume with the given ID.
+func (c *client) volumeAction(id string, req volumeActionRequest) (*action, error) {
+	var resp struct {
+		Action action `json:"action"`
+	}
+	if err := c.do("POST", "/v2/volumes/"+id+"/actions", req, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &resp.Action, nil
+}
+
+// action returns the action with the given ID.
+func (c *client) action(id int) (*action, error) {
+	var resp struct {
+		Action action `json:"action"`
+	}
+	if err := c.do("GET", fmt.Sprintf("/v2/actions/%d", id), nil, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &resp.Action, nil
+}
+
+// list requests each page of the collection at the given path in turn,
+// calling f with a function that decodes the current page into its
+// argument.
+func (c *client) list(path string, f func(page func(interface{}) error) error) error {
+	if strings.Contains(path, "?") {
+		path += "&per_page=200"
+	} else {
+		path += "?per_page=200"
+	}
+	for path != "" {
+		var raw json.RawMessage
+		if err := c.do("GET", path, nil, &raw); err != nil {
+			return errors.Trace(err)
+		}
+		if err := f(func(v interface{}) error {
+			return json.Unmarshal(raw, v)
+		}); err != nil {
+			return errors.Trace(err)
+		}
+		var resp struct {
+			Links links `json:"links"`
+		}
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return errors.Trace(err)
+		}
+		next, err := c.nextPage(resp.Links)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		path = next
+	}
+	return nil
+}
+
+// nextPage returns the path of the next page of a collection, or the
+// empty string if there are no more pages.
+func (c *client) nextPage(l links) (string, error) {
+	if l.Pages.Next == "" {
+		return "", nil
+	}
+	u, err := url.Parse(l.Pages.Next)
+	if err != nil {
+		return "", errors.Annotate(err, "parsing next page URL")
+	}
+	return u.RequestURI(), nil
+}
+
+// do makes an API request, encoding in as the request body if it is
+// non-nil, and decoding the response body into out if it is non-nil.
+// A NotFound error is returned if the requested resource does not
+// exist.
+func (c *client) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, c.endpoint+path, body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return errors.Annotatef(err, "%s %s", method, path)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &apiError{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil {
+			logger.Debugf("cannot decode error response: %v", err)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return errors.NewNotFound(apiErr, "")
+		}
+		return apiErr
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.Annotatef(err, "decoding response to %s %s", method, path)
+	}
+	return nil
+}
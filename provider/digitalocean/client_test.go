@@ -0,0 +1,56 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type clientSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestAccount(c *gc.C) {
+	err := s.env.client.account()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestUnauthorized(c *gc.C) {
+	err := newClient(s.api.URL, "wrong").account()
+	c.Assert(err, gc.ErrorMatches, `digitalocean API request failed: Unable to authenticate you. \(unauthorized\)`)
+	c.Assert(errors.Cause(err).(*apiError).StatusCode, gc.Equals, 401)
+}
+
+func (s *clientSuite) TestNotFound(c *gc.C) {
+	_, err := s.env.client.volume("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *clientSuite) TestListFollowsPages(c *gc.C) {
+	s.api.perPage = 1
+	sizes, err := s.env.client.sizes()
+	c.Assert(err, jc.ErrorIsNil)
+	var slugs []string
+	for _, size := range sizes {
+		slugs = append(slugs, size.Slug)
+	}
+	c.Assert(slugs, jc.DeepEquals, []string{"512mb", "1gb", "2gb", "4gb"})
+}
+
+func (s *clientSuite) TestDropletsByTag(c *gc.C) {
+	_, err := s.env.client.createDroplet(dropletCreateRequest{Name: "a", Size: "512mb", Tags: []string{"x"}})
+	c.Assert(err, jc.ErrorIsNil)
+	d, err := s.env.client.createDroplet(dropletCreateRequest{Name: "b", Size: "512mb", Tags: []string{"y"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	droplets, err := s.env.client.droplets("y")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(droplets, gc.HasLen, 1)
+	c.Assert(droplets[0].ID, gc.Equals, d.ID)
+	c.Assert(droplets[0].Name, gc.Equals, "b")
+}
@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/config"
+)
+
+const (
+	cfgToken    = "token"
+	cfgRegion   = "region"
+	cfgEndpoint = "endpoint"
+
+	// defaultEndpoint is the endpoint of the DigitalOcean API.
+	defaultEndpoint = "https://api.digitalocean.com"
+)
+
+var configFields = schema.Fields{
+	cfgToken:    schema.String(),
+	cfgRegion:   schema.String(),
+	cfgEndpoint: schema.String(),
+}
+
+var configDefaultFields = schema.Defaults{
+	cfgEndpoint: defaultEndpoint,
+}
+
+var configSecretFields = []string{
+	cfgToken,
+}
+
+var configImmutableFields = []string{
+	cfgRegion,
+	cfgEndpoint,
+}
+
+type environConfig struct {
+	*config.Config
+	attrs map[string]interface{}
+}
+
+func validateConfig(cfg *config.Config, old *environConfig) (*environConfig, error) {
+	// Check sanity of juju-level fields.
+	var oldCfg *config.Config
+	if old != nil {
+		oldCfg = old.Config
+	}
+	if err := config.Validate(cfg, oldCfg); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	newAttrs, err := cfg.ValidateUnknownAttrs(configFields, configDefaultFields)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for field := range configFields {
+		if newAttrs[field] == "" {
+			return nil, errors.Errorf("%s: must not be empty", field)
+		}
+	}
+
+	// If an old config was supplied, check any immutable fields have not changed.
+	if old != nil {
+		for _, field := range configImmutableFields {
+			if old.attrs[field] != newAttrs[field] {
+				return nil, errors.Errorf(
+					"%s: cannot change from %v to %v",
+					field, old.attrs[field], newAttrs[field],
+				)
+			}
+		}
+	}
+
+	// Merge the validated provider-specific fields into the original config,
+	// to ensure the object we return is internally consistent.
+	newCfg, err := cfg.Apply(newAttrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &environConfig{
+		Config: newCfg,
+		attrs:  newAttrs,
+	}, nil
+}
+
+func (c environConfig) token() string {
+	return c.attrs[cfgToken].(string)
+}
+
+func (c environConfig) region() string {
+	return c.attrs[cfgRegion].(string)
+}
+
+func (c environConfig) endpoint() string {
+	return c.attrs[cfgEndpoint].(string)
+}
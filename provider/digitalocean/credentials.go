@@ -0,0 +1,52 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/cloud"
+)
+
+// tokenEnvKey is the environment variable in which the DigitalOcean
+// command line tools look for an access token.
+const tokenEnvKey = "DIGITALOCEAN_ACCESS_TOKEN"
+
+type environProviderCredentials struct{}
+
+// CredentialSchemas is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.OAuth2AuthType: {{
+			Name: cfgToken,
+			CredentialAttr: cloud.CredentialAttr{
+				Description: "personal access token",
+				Hidden:      true,
+			},
+		}},
+	}
+}
+
+// DetectCredentials is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
+	token := os.Getenv(tokenEnvKey)
+	if token == "" {
+		return nil, errors.NotFoundf("digitalocean credentials")
+	}
+	user, err := utils.LocalUsername()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cred := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{
+		cfgToken: token,
+	})
+	cred.Label = "digitalocean access token from " + tokenEnvKey
+	return &cloud.CloudCredential{
+		AuthCredentials: map[string]cloud.Credential{
+			user: cred,
+		}}, nil
+}
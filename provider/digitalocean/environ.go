@@ -0,0 +1,263 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+)
+
+const (
+	// modelTagPrefix prefixes the UUID of the model in the
+	// tag applied to each of the model's droplets.
+	modelTagPrefix = "juju-model-"
+
+	// controllerTagPrefix prefixes the UUID of the controller in
+	// the tag applied to each droplet managed by the controller.
+	controllerTagPrefix = "juju-controller-"
+
+	// isControllerTag is the tag applied to controller droplets.
+	isControllerTag = "juju-is-controller"
+)
+
+func modelTag(modelUUID string) string {
+	return modelTagPrefix + modelUUID
+}
+
+func controllerTag(controllerUUID string) string {
+	return controllerTagPrefix + controllerUUID
+}
+
+type environ struct {
+	name      string
+	uuid      string
+	client    *client
+	namespace instance.Namespace
+
+	lock sync.Mutex // lock protects access to ecfg
+	ecfg *environConfig
+}
+
+var _ environs.Environ = (*environ)(nil)
+var _ common.ZonedEnviron = (*environ)(nil)
+
+// Function entry points defined as variables so they can be overridden
+// for testing purposes.
+var (
+	destroyEnv = common.Destroy
+	bootstrap  = common.Bootstrap
+)
+
+func newEnviron(cfg *config.Config) (*environ, error) {
+	ecfg, err := validateConfig(cfg, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+	namespace, err := instance.NewNamespace(cfg.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &environ{
+		name:      cfg.Name(),
+		uuid:      cfg.UUID(),
+		client:    newClient(ecfg.endpoint(), ecfg.token()),
+		namespace: namespace,
+		ecfg:      ecfg,
+	}, nil
+}
+
+// Name returns the name of the environment.
+func (env *environ) Name() string {
+	return env.name
+}
+
+// Provider returns the environment provider that created this env.
+func (*environ) Provider() environs.EnvironProvider {
+	return providerInstance
+}
+
+// SetConfig updates the env's configuration.
+func (env *environ) SetConfig(cfg *config.Config) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	ecfg, err := validateConfig(cfg, env.ecfg)
+	if err != nil {
+		return errors.Annotate(err, "invalid config change")
+	}
+	if ecfg.token() != env.ecfg.token() {
+		env.client = newClient(ecfg.endpoint(), ecfg.token())
+	}
+	env.ecfg = ecfg
+	return nil
+}
+
+// Config returns the configuration data with which the env was created.
+func (env *environ) Config() *config.Config {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	return env.ecfg.Config
+}
+
+func (env *environ) region() string {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	return env.ecfg.region()
+}
+
+// PrepareForBootstrap is part of the environs.Environ interface.
+func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext) error {
+	if ctx.ShouldVerifyCredentials() {
+		if err := env.client.account(); err != nil {
+			return errors.Annotate(err, "verifying credentials")
+		}
+	}
+	return nil
+}
+
+// Bootstrap is part of the environs.Environ interface.
+func (env *environ) Bootstrap(ctx environs.BootstrapContext, params environs.BootstrapParams) (*environs.BootstrapResult, error) {
+	return bootstrap(ctx, env, params)
+}
+
+// Destroy is part of the environs.Environ interface.
+func (env *environ) Destroy() error {
+	if err := destroyEnv(env); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(env.destroyModelResources(env.uuid))
+}
+
+// DestroyController is part of the environs.Environ interface.
+func (env *environ) DestroyController(controllerUUID string) error {
+	if err := env.Destroy(); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Destroy the droplets of the hosted models, and then the
+	// firewalls and SSH keys that were created for them.
+	droplets, err := env.client.droplets(controllerTag(controllerUUID))
+	if err != nil {
+		return errors.Annotate(err, "listing hosted model droplets")
+	}
+	modelUUIDs := set.NewStrings()
+	for _, d := range droplets {
+		for _, tag := range d.Tags {
+			if strings.HasPrefix(tag, modelTagPrefix) {
+				modelUUIDs.Add(strings.TrimPrefix(tag, modelTagPrefix))
+			}
+		}
+		if err := env.client.deleteDroplet(d.ID); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting droplet %d", d.ID)
+		}
+	}
+	for _, modelUUID := range modelUUIDs.SortedValues() {
+		if err := env.destroyModelResources(modelUUID); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// destroyModelResources deletes the cloud firewalls and the SSH key
+// that were created for the model with the given UUID.
+func (env *environ) destroyModelResources(modelUUID string) error {
+	firewalls, err := env.client.firewalls()
+	if err != nil {
+		return errors.Annotate(err, "listing firewalls")
+	}
+	prefix := firewallPrefix(modelUUID)
+	for _, fw := range firewalls {
+		if fw.Name != prefix && !strings.HasPrefix(fw.Name, prefix+"-") {
+			continue
+		}
+		if err := env.client.deleteFirewall(fw.ID); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting firewall %q", fw.Name)
+		}
+	}
+	keys, err := env.client.sshKeys()
+	if err != nil {
+		return errors.Annotate(err, "listing SSH keys")
+	}
+	for _, key := range keys {
+		if key.Name != sshKeyName(modelUUID) {
+			continue
+		}
+		if err := env.client.deleteSSHKey(key.ID); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting SSH key %q", key.Name)
+		}
+	}
+	return nil
+}
+
+// PrecheckInstance is part of the environs.Environ interface.
+func (env *environ) PrecheckInstance(series string, cons constraints.Value, placement string) error {
+	if _, err := seriesImage(series); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := env.parsePlacement(placement); err != nil {
+		return errors.Trace(err)
+	}
+	if cons.HasInstanceType() {
+		instanceTypes, err := env.instanceTypes()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, itype := range instanceTypes {
+			if itype.Name == *cons.InstanceType {
+				return nil
+			}
+		}
+		return errors.Errorf("invalid DigitalOcean instance type %q specified", *cons.InstanceType)
+	}
+	return nil
+}
+
+var unsupportedConstraints = []string{
+	constraints.CpuPower,
+	constraints.Tags,
+	constraints.VirtType,
+}
+
+// ConstraintsValidator is part of the environs.Environ interface.
+func (env *environ) ConstraintsValidator() (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	validator.RegisterConflicts(
+		[]string{constraints.InstanceType},
+		[]string{constraints.Mem, constraints.CpuCores},
+	)
+	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterVocabulary(constraints.Arch, []string{arch.AMD64})
+
+	instanceTypes, err := env.instanceTypes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	instTypeNames := make([]string, len(instanceTypes))
+	for i, itype := range instanceTypes {
+		instTypeNames[i] = itype.Name
+	}
+	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
+
+	zones, err := env.AvailabilityZones()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get availability zones")
+	}
+	zoneNames := make([]string, len(zones))
+	for i, zone := range zones {
+		zoneNames[i] = zone.Name()
+	}
+	validator.RegisterVocabulary(constraints.Zones, zoneNames)
+	return validator, nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+)
+
+// availabilityZone exposes a DigitalOcean region as an availability
+// zone. DigitalOcean has no finer-grained placement than the region,
+// so a model has exactly one zone: its configured region.
+type availabilityZone struct {
+	region region
+}
+
+// Name implements common.AvailabilityZone.
+func (z *availabilityZone) Name() string {
+	return z.region.Slug
+}
+
+// Available implements common.AvailabilityZone.
+func (z *availabilityZone) Available() bool {
+	return z.region.Available
+}
+
+// AvailabilityZones returns all availability zones in the environment.
+func (env *environ) AvailabilityZones() ([]common.AvailabilityZone, error) {
+	r, err := env.availZone(env.region())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []common.AvailabilityZone{r}, nil
+}
+
+// InstanceAvailabilityZoneNames returns the names of the availability
+// zones for the specified instances. The error returned follows the same
+// rules as Environ.Instances.
+func (env *environ) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error) {
+	instances, err := env.Instances(ids)
+	if err != nil && err != environs.ErrPartialInstances && err != environs.ErrNoInstances {
+		return nil, errors.Trace(err)
+	}
+	// We let the two environs errors pass on through. However, we do
+	// not use errors.Trace in that case since callers may not call
+	// errors.Cause.
+
+	results := make([]string, len(ids))
+	for i, inst := range instances {
+		if eInst, ok := inst.(*environInstance); ok && eInst != nil {
+			results[i] = eInst.droplet.Region.Slug
+		}
+	}
+	return results, err
+}
+
+func (env *environ) availZone(name string) (*availabilityZone, error) {
+	regions, err := env.client.regions()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, r := range regions {
+		if r.Slug == name {
+			return &availabilityZone{region: r}, nil
+		}
+	}
+	return nil, errors.NotFoundf("invalid availability zone %q", name)
+}
+
+type instPlacement struct {
+	Zone *availabilityZone
+}
+
+// parsePlacement extracts the availability zone from the placement
+// string and returns it. If no zone is found there then an error is
+// returned.
+func (env *environ) parsePlacement(placement string) (*instPlacement, error) {
+	if placement == "" {
+		return nil, nil
+	}
+
+	pos := strings.IndexRune(placement, '=')
+	if pos == -1 {
+		return nil, errors.Errorf("unknown placement directive: %v", placement)
+	}
+
+	switch key, value := placement[:pos], placement[pos+1:]; key {
+	case "zone":
+		if value != env.region() {
+			return nil, errors.Errorf(
+				"availability zone %q is not in the model's region %q", value, env.region(),
+			)
+		}
+		zone, err := env.availZone(value)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !zone.Available() {
+			return nil, errors.Errorf("availability zone %q is unavailable", zone.Name())
+		}
+		return &instPlacement{Zone: zone}, nil
+	}
+	return nil, errors.Errorf("unknown placement directive: %v", placement)
+}
+
+var availabilityZoneAllocations = common.AvailabilityZoneAllocations
+
+// parseAvailabilityZones returns the availability zones that should be
+// tried for the given instance spec. If a placement argument was
+// provided then only that one is returned. Otherwise the environment is
+// queried for available zones, limited by any zones constraint.
+func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([]string, error) {
+	if args.Placement != "" {
+		placement, err := env.parsePlacement(args.Placement)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []string{placement.Zone.Name()}, nil
+	}
+
+	var group []instance.Id
+	var err error
+	if args.DistributionGroup != nil {
+		group, err = args.DistributionGroup()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	zoneInstances, err := availabilityZoneAllocations(env, group)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if args.Constraints.HasZones() {
+		zoneInstances = common.FilterZoneInstances(zoneInstances, *args.Constraints.Zones)
+	}
+
+	var zoneNames []string
+	for _, z := range zoneInstances {
+		zoneNames = append(zoneNames, z.ZoneName)
+	}
+	if len(zoneNames) == 0 {
+		return nil, errors.NotFoundf("failed to determine availability zones")
+	}
+	return zoneNames, nil
+}
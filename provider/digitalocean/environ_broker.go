@@ -0,0 +1,263 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/ssh"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/tools"
+)
+
+func isController(icfg *instancecfg.InstanceConfig) bool {
+	return multiwatcher.AnyJobNeedsState(icfg.Jobs...)
+}
+
+// sshKeyName returns the name of the SSH key registered with
+// DigitalOcean for the model with the given UUID, if the model's
+// authorized key was not already registered.
+func sshKeyName(modelUUID string) string {
+	return "juju-" + modelUUID
+}
+
+// MaintainInstance is specified in the InstanceBroker interface.
+func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
+	return nil
+}
+
+// StartInstance implements environs.InstanceBroker.
+func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	zones, err := env.parseAvailabilityZones(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	region := zones[0]
+
+	series := args.Tools.OneSeries()
+	possibleImages, err := images(series)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	instanceTypes, err := env.instanceTypes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec, err := instances.FindInstanceSpec(possibleImages, &instances.InstanceConstraint{
+		Region:      region,
+		Series:      series,
+		Arches:      args.Tools.Arches(),
+		Constraints: args.Constraints,
+	}, instanceTypes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	envTools, err := args.Tools.Match(tools.Filter{Arch: spec.Image.Arch})
+	if err != nil {
+		return nil, errors.Errorf("chosen architecture %v not present in %v", spec.Image.Arch, args.Tools.Arches())
+	}
+	if err := args.InstanceConfig.SetTools(envTools); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := instancecfg.FinishInstanceConfig(args.InstanceConfig, env.Config()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	userData, err := providerinit.ComposeUserData(args.InstanceConfig, nil, DigitalOceanRenderer{})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot make user data")
+	}
+	logger.Debugf("digitalocean user data; %d bytes", len(userData))
+
+	hostname, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	keyID, err := env.ensureSSHKey(args.InstanceConfig.AuthorizedKeys)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot register SSH key")
+	}
+
+	var apiPort int
+	if args.InstanceConfig.Controller != nil {
+		apiPort = args.InstanceConfig.Controller.Config.APIPort()
+	} else {
+		apiPort = args.InstanceConfig.APIInfo.Ports()[0]
+	}
+	accessRules, err := network.AccessRules(env.Config().SSHAllow(), args.APIAllow, apiPort)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The access firewall targets droplets by the model tag, which
+	// must exist before the firewall can be created.
+	if err := env.client.createTag(modelTag(env.uuid)); err != nil {
+		return nil, errors.Annotate(err, "cannot create model tag")
+	}
+	if err := env.ensureAccessFirewall(accessRules); err != nil {
+		return nil, errors.Annotate(err, "cannot set up firewall")
+	}
+
+	dropletTags := []string{modelTag(env.uuid), controllerTag(args.ControllerUUID)}
+	if isController(args.InstanceConfig) {
+		dropletTags = append(dropletTags, isControllerTag)
+	}
+	d, err := env.client.createDroplet(dropletCreateRequest{
+		Name:              hostname,
+		Region:            region,
+		Size:              spec.InstanceType.Name,
+		Image:             spec.Image.Id,
+		SSHKeys:           []int{keyID},
+		PrivateNetworking: true,
+		IPv6:              true,
+		UserData:          string(userData),
+		Tags:              dropletTags,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create droplet")
+	}
+	inst := newInstance(*d, env)
+	logger.Infof("started instance %q in region %q", inst.Id(), region)
+
+	rootDisk := spec.InstanceType.RootDisk
+	hwc := &instance.HardwareCharacteristics{
+		Arch:             &spec.Image.Arch,
+		Mem:              &spec.InstanceType.Mem,
+		CpuCores:         &spec.InstanceType.CpuCores,
+		RootDisk:         &rootDisk,
+		AvailabilityZone: &region,
+	}
+	return &environs.StartInstanceResult{
+		Instance: inst,
+		Hardware: hwc,
+	}, nil
+}
+
+// ensureSSHKey returns the ID of the DigitalOcean SSH key matching the
+// first of the given authorized keys, registering it if necessary.
+// DigitalOcean requires a registered key for a droplet to be created
+// without a root password being emailed to the account owner.
+func (env *environ) ensureSSHKey(authorizedKeys string) (int, error) {
+	keys := ssh.SplitAuthorisedKeys(authorizedKeys)
+	if len(keys) == 0 {
+		return 0, errors.New("no authorized keys")
+	}
+	fingerprint, _, err := ssh.KeyFingerprint(keys[0])
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	registered, err := env.client.sshKeys()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	for _, key := range registered {
+		if key.Fingerprint == fingerprint {
+			return key.ID, nil
+		}
+	}
+	key, err := env.client.createSSHKey(sshKeyName(env.uuid), keys[0])
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return key.ID, nil
+}
+
+// StopInstances implements environs.InstanceBroker.
+func (env *environ) StopInstances(ids ...instance.Id) error {
+	for _, id := range ids {
+		dropletID, err := strconv.Atoi(string(id))
+		if err != nil {
+			return errors.NotValidf("instance ID %q", id)
+		}
+		if err := env.client.deleteDroplet(dropletID); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting droplet %d", dropletID)
+		}
+		// Remove the instance firewall, if the droplet had one.
+		fw, err := env.findFirewall(env.instanceFirewallName(dropletID))
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if err := env.client.deleteFirewall(fw.ID); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting firewall %q", fw.Name)
+		}
+	}
+	return nil
+}
+
+// AllInstances implements environs.InstanceBroker.
+func (env *environ) AllInstances() ([]instance.Instance, error) {
+	droplets, err := env.client.droplets(modelTag(env.uuid))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]instance.Instance, len(droplets))
+	for i, d := range droplets {
+		results[i] = newInstance(d, env)
+	}
+	return results, nil
+}
+
+// Instances returns the available instances in the environment that
+// match the provided instance IDs. For IDs that did not match any
+// instances, the result at the corresponding index will be nil. In that
+// case the error will be environs.ErrPartialInstances (or
+// ErrNoInstances if none of the IDs match an instance).
+func (env *environ) Instances(ids []instance.Id) ([]instance.Instance, error) {
+	if len(ids) == 0 {
+		return nil, environs.ErrNoInstances
+	}
+	all, err := env.AllInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	byID := make(map[instance.Id]instance.Instance)
+	for _, inst := range all {
+		byID[inst.Id()] = inst
+	}
+
+	var found int
+	results := make([]instance.Instance, len(ids))
+	for i, id := range ids {
+		if inst, ok := byID[id]; ok {
+			results[i] = inst
+			found++
+		}
+	}
+	switch found {
+	case 0:
+		return nil, environs.ErrNoInstances
+	case len(ids):
+		return results, nil
+	}
+	return results, environs.ErrPartialInstances
+}
+
+// ControllerInstances returns the IDs of the instances corresponding
+// to juju controllers.
+func (env *environ) ControllerInstances(controllerUUID string) ([]instance.Id, error) {
+	droplets, err := env.client.droplets(isControllerTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []instance.Id
+	for _, d := range droplets {
+		if !containsString(d.Tags, controllerTag(controllerUUID)) {
+			continue
+		}
+		results = append(results, instance.Id(strconv.Itoa(d.ID)))
+	}
+	if len(results) == 0 {
+		return nil, environs.ErrNotBootstrapped
+	}
+	return results, nil
+}
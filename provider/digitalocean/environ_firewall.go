@@ -0,0 +1,305 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+)
+
+const (
+	// anyIPv6CIDR is the source CIDR which matches all IPv6 addresses.
+	// DigitalOcean firewalls treat IPv4 and IPv6 sources separately,
+	// so rules open to the world are given both.
+	anyIPv6CIDR = "::/0"
+
+	// allPorts is the value of a firewall rule's ports that
+	// DigitalOcean uses to mean every port.
+	allPorts = "0"
+)
+
+var _ environs.Firewaller = (*environ)(nil)
+var _ environs.IngressFirewaller = (*environ)(nil)
+var _ environs.AccessFirewaller = (*environ)(nil)
+
+// firewallPrefix returns the prefix of the names of all of the cloud
+// firewalls created for the model with the given UUID. The model's
+// access firewall is named with the prefix alone.
+func firewallPrefix(modelUUID string) string {
+	return "juju-" + modelUUID
+}
+
+// accessFirewallName returns the name of the firewall that allows SSH
+// and API access to every droplet in the model.
+func (env *environ) accessFirewallName() string {
+	return firewallPrefix(env.uuid)
+}
+
+// globalFirewallName returns the name of the firewall holding the
+// rules opened for the whole model in the global firewall mode.
+func (env *environ) globalFirewallName() string {
+	return firewallPrefix(env.uuid) + "-global"
+}
+
+// instanceFirewallName returns the name of the firewall holding the
+// rules opened for a single droplet in the instance firewall mode.
+func (env *environ) instanceFirewallName(dropletID int) string {
+	return fmt.Sprintf("%s-%d", firewallPrefix(env.uuid), dropletID)
+}
+
+// OpenPorts is part of the environs.Firewaller interface.
+func (env *environ) OpenPorts(ports []network.PortRange) error {
+	return env.OpenIngressRules(network.OpenIngressRules(ports))
+}
+
+// ClosePorts is part of the environs.Firewaller interface.
+func (env *environ) ClosePorts(ports []network.PortRange) error {
+	return env.CloseIngressRules(network.OpenIngressRules(ports))
+}
+
+// Ports is part of the environs.Firewaller interface.
+func (env *environ) Ports() ([]network.PortRange, error) {
+	rules, err := env.IngressRules()
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulePortRanges(rules), nil
+}
+
+// OpenIngressRules is part of the environs.IngressFirewaller interface.
+func (env *environ) OpenIngressRules(rules []network.IngressRule) error {
+	if env.Config().FirewallMode() != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for opening ports on model", env.Config().FirewallMode())
+	}
+	targets := firewallTargets{Tags: []string{modelTag(env.uuid)}}
+	if err := env.openIngressRules(env.globalFirewallName(), targets, rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened ports in global firewall: %v", rules)
+	return nil
+}
+
+// CloseIngressRules is part of the environs.IngressFirewaller interface.
+func (env *environ) CloseIngressRules(rules []network.IngressRule) error {
+	if env.Config().FirewallMode() != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for closing ports on model", env.Config().FirewallMode())
+	}
+	if err := env.closeIngressRules(env.globalFirewallName(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed ports in global firewall: %v", rules)
+	return nil
+}
+
+// IngressRules is part of the environs.IngressFirewaller interface.
+func (env *environ) IngressRules() ([]network.IngressRule, error) {
+	if env.Config().FirewallMode() != config.FwGlobal {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving ports from model", env.Config().FirewallMode())
+	}
+	return env.ingressRules(env.globalFirewallName())
+}
+
+// SetAccessRules is part of the environs.AccessFirewaller interface.
+func (env *environ) SetAccessRules(rules []network.IngressRule) error {
+	name := env.accessFirewallName()
+	fw, err := env.findFirewall(name)
+	if errors.IsNotFound(err) {
+		// No machines have been started yet. The rules will be
+		// applied when the firewall is created.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	fw.InboundRules = inboundRules(rules)
+	_, err = env.client.updateFirewall(*fw)
+	return errors.Annotatef(err, "updating firewall %q", name)
+}
+
+// ensureAccessFirewall creates the firewall that allows SSH and API
+// access to, and all outbound traffic from, every droplet in the
+// model, if it does not already exist.
+func (env *environ) ensureAccessFirewall(rules []network.IngressRule) error {
+	name := env.accessFirewallName()
+	if _, err := env.findFirewall(name); err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	everywhere := &firewallTargets{Addresses: []string{network.AnyCIDR, anyIPv6CIDR}}
+	fw := firewall{
+		Name:         name,
+		InboundRules: inboundRules(rules),
+		OutboundRules: []firewallRule{
+			{Protocol: "tcp", Ports: allPorts, Destinations: everywhere},
+			{Protocol: "udp", Ports: allPorts, Destinations: everywhere},
+			{Protocol: "icmp", Destinations: everywhere},
+		},
+		Tags: []string{modelTag(env.uuid)},
+	}
+	_, err := env.client.createFirewall(fw)
+	return errors.Annotatef(err, "creating firewall %q", name)
+}
+
+// findFirewall returns the firewall with the given name, or a NotFound
+// error if there is none.
+func (env *environ) findFirewall(name string) (*firewall, error) {
+	firewalls, err := env.client.firewalls()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing firewalls")
+	}
+	for _, fw := range firewalls {
+		if fw.Name == name {
+			return &fw, nil
+		}
+	}
+	return nil, errors.NotFoundf("firewall %q", name)
+}
+
+// openIngressRules adds the given rules to the named firewall,
+// creating it with the given targets if it does not exist.
+func (env *environ) openIngressRules(name string, targets firewallTargets, rules []network.IngressRule) error {
+	fw, err := env.findFirewall(name)
+	if errors.IsNotFound(err) {
+		fw := firewall{
+			Name:         name,
+			InboundRules: inboundRules(rules),
+			DropletIDs:   targets.DropletIDs,
+			Tags:         targets.Tags,
+		}
+		_, err := env.client.createFirewall(fw)
+		return errors.Annotatef(err, "creating firewall %q", name)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	have := ingressRules(fw.InboundRules)
+	existing := make(map[string]bool)
+	for _, rule := range have {
+		existing[rule.String()] = true
+	}
+	for _, rule := range rules {
+		if !existing[rule.String()] {
+			have = append(have, rule)
+			existing[rule.String()] = true
+		}
+	}
+	fw.InboundRules = inboundRules(have)
+	_, err = env.client.updateFirewall(*fw)
+	return errors.Annotatef(err, "updating firewall %q", name)
+}
+
+// closeIngressRules removes the given rules from the named firewall.
+// The firewall is deleted when no rules remain.
+func (env *environ) closeIngressRules(name string, rules []network.IngressRule) error {
+	fw, err := env.findFirewall(name)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	closing := make(map[string]bool)
+	for _, rule := range rules {
+		closing[rule.String()] = true
+	}
+	var remaining []network.IngressRule
+	for _, rule := range ingressRules(fw.InboundRules) {
+		if !closing[rule.String()] {
+			remaining = append(remaining, rule)
+		}
+	}
+	if len(remaining) == 0 {
+		err := env.client.deleteFirewall(fw.ID)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Annotatef(err, "deleting firewall %q", name)
+	}
+	fw.InboundRules = inboundRules(remaining)
+	_, err = env.client.updateFirewall(*fw)
+	return errors.Annotatef(err, "updating firewall %q", name)
+}
+
+// ingressRules returns the rules opened in the named firewall, sorted
+// by network.SortIngressRules.
+func (env *environ) ingressRules(name string) ([]network.IngressRule, error) {
+	fw, err := env.findFirewall(name)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rules := ingressRules(fw.InboundRules)
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// inboundRules converts ingress rules to DigitalOcean inbound rules.
+func inboundRules(rules []network.IngressRule) []firewallRule {
+	result := make([]firewallRule, len(rules))
+	for i, rule := range rules {
+		ports := strconv.Itoa(rule.FromPort)
+		if rule.ToPort != rule.FromPort {
+			ports = fmt.Sprintf("%d-%d", rule.FromPort, rule.ToPort)
+		}
+		sources := rule.SourceRanges()
+		if rule.IsOpen() {
+			sources = append(sources, anyIPv6CIDR)
+		}
+		result[i] = firewallRule{
+			Protocol: strings.ToLower(rule.Protocol),
+			Ports:    ports,
+			Sources:  &firewallTargets{Addresses: sources},
+		}
+	}
+	return result
+}
+
+// ingressRules converts DigitalOcean inbound rules to ingress rules.
+// Rules which cannot be expressed as ingress rules, such as those for
+// ICMP or with droplet or tag sources, are ignored.
+func ingressRules(rules []firewallRule) []network.IngressRule {
+	var result []network.IngressRule
+	for _, rule := range rules {
+		if rule.Sources == nil || len(rule.Sources.Addresses) == 0 {
+			continue
+		}
+		portRange, err := parseFirewallPorts(rule.Protocol, rule.Ports)
+		if err != nil {
+			logger.Debugf("ignoring firewall rule %+v: %v", rule, err)
+			continue
+		}
+		var sources []string
+		for _, addr := range rule.Sources.Addresses {
+			if addr != anyIPv6CIDR {
+				sources = append(sources, addr)
+			}
+		}
+		ingressRule, err := network.NewIngressRule(portRange, sources...)
+		if err != nil {
+			logger.Debugf("ignoring firewall rule %+v: %v", rule, err)
+			continue
+		}
+		result = append(result, ingressRule)
+	}
+	return result
+}
+
+// parseFirewallPorts returns the port range for the protocol and ports
+// of a DigitalOcean firewall rule.
+func parseFirewallPorts(protocol, ports string) (network.PortRange, error) {
+	if ports == "" || ports == allPorts || ports == "all" {
+		portRange := network.PortRange{FromPort: 1, ToPort: 65535, Protocol: protocol}
+		return portRange, errors.Trace(portRange.Validate())
+	}
+	portRange, err := network.ParsePortRange(ports + "/" + protocol)
+	return portRange, errors.Trace(err)
+}
@@ -0,0 +1,124 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type firewallSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&firewallSuite{})
+
+func (s *firewallSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+	s.env = s.newEnviron(c, coretesting.Attrs{"firewall-mode": config.FwGlobal})
+}
+
+func (s *firewallSuite) TestOpenIngressRules(c *gc.C) {
+	http := network.MustNewIngressRule(network.PortRange{80, 80, "tcp"})
+	dns := network.MustNewIngressRule(network.PortRange{53, 53, "udp"}, "10.0.0.0/8")
+	err := s.env.OpenIngressRules([]network.IngressRule{http})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.OpenIngressRules([]network.IngressRule{http, dns})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err := s.env.IngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{http, dns})
+
+	fw, err := s.env.findFirewall(s.env.globalFirewallName())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fw.Tags, jc.DeepEquals, []string{modelTag(s.env.uuid)})
+	c.Assert(fw.InboundRules, jc.DeepEquals, []firewallRule{{
+		Protocol: "tcp",
+		Ports:    "80",
+		Sources:  &firewallTargets{Addresses: []string{"0.0.0.0/0", "::/0"}},
+	}, {
+		Protocol: "udp",
+		Ports:    "53",
+		Sources:  &firewallTargets{Addresses: []string{"10.0.0.0/8"}},
+	}})
+
+	ports, err := s.env.Ports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, []network.PortRange{{80, 80, "tcp"}})
+}
+
+func (s *firewallSuite) TestCloseIngressRules(c *gc.C) {
+	err := s.env.OpenPorts([]network.PortRange{{80, 80, "tcp"}, {8000, 8080, "tcp"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.env.ClosePorts([]network.PortRange{{80, 80, "tcp"}})
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := s.env.Ports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, []network.PortRange{{8000, 8080, "tcp"}})
+
+	// Closing the last rule deletes the firewall.
+	err = s.env.ClosePorts([]network.PortRange{{8000, 8080, "tcp"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.firewalls, gc.HasLen, 0)
+	ports, err = s.env.Ports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.HasLen, 0)
+}
+
+func (s *firewallSuite) TestInstanceFirewallModeMismatch(c *gc.C) {
+	env := s.newEnviron(c, nil)
+	err := env.OpenPorts([]network.PortRange{{80, 80, "tcp"}})
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for opening ports on model`)
+
+	inst := newInstance(droplet{ID: 1}, s.env)
+	_, err = inst.IngressRules("1")
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "global" for retrieving ports from instance`)
+}
+
+func (s *firewallSuite) TestSetAccessRules(c *gc.C) {
+	// Without an access firewall there is nothing to update.
+	rules, err := network.AccessRules([]string{"192.168.0.0/24"}, nil, 17070)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.SetAccessRules(rules)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.firewalls, gc.HasLen, 0)
+
+	open, err := network.AccessRules(nil, nil, 17070)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.client.createTag(modelTag(s.env.uuid))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.ensureAccessFirewall(open)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.env.SetAccessRules(rules)
+	c.Assert(err, jc.ErrorIsNil)
+	fw, err := s.env.findFirewall(s.env.accessFirewallName())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ingressRules(fw.InboundRules), jc.DeepEquals, rules)
+	c.Assert(fw.OutboundRules, gc.HasLen, 3)
+}
+
+func (s *firewallSuite) TestIngressRulesIgnoresUnsupported(c *gc.C) {
+	rules := ingressRules([]firewallRule{{
+		Protocol: "icmp",
+		Sources:  &firewallTargets{Addresses: []string{"0.0.0.0/0"}},
+	}, {
+		Protocol: "tcp",
+		Ports:    "22",
+		Sources:  &firewallTargets{Tags: []string{"bastion"}},
+	}, {
+		Protocol: "tcp",
+		Ports:    "all",
+		Sources:  &firewallTargets{Addresses: []string{"::/0"}},
+	}})
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{1, 65535, "tcp"}),
+	})
+}
@@ -0,0 +1,290 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"strconv"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/instance"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type environSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&environSuite{})
+
+var testControllerUUID = coretesting.FakeControllerConfig().ControllerUUID()
+
+func (s *environSuite) startInstanceParams(c *gc.C, machineId string, cons constraints.Value) environs.StartInstanceParams {
+	icfg, err := instancecfg.NewInstanceConfig(
+		machineId, "fake_nonce", imagemetadata.ReleasedStream, "xenial", true,
+		jujutesting.FakeAPIInfo(machineId),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return environs.StartInstanceParams{
+		ControllerUUID: testControllerUUID,
+		InstanceConfig: icfg,
+		Tools: coretools.List{{
+			Version: version.MustParseBinary("2.0.0-xenial-amd64"),
+			URL:     "https://example.com/tools.tgz",
+		}},
+		Constraints: cons,
+	}
+}
+
+func (s *environSuite) startInstance(c *gc.C, machineId string) *environs.StartInstanceResult {
+	result, err := s.env.StartInstance(s.startInstanceParams(c, machineId, constraints.Value{}))
+	c.Assert(err, jc.ErrorIsNil)
+	return result
+}
+
+func (s *environSuite) TestStartInstance(c *gc.C) {
+	result := s.startInstance(c, "1")
+
+	c.Assert(s.api.dropletRequests, gc.HasLen, 1)
+	req := s.api.dropletRequests[0]
+	hostname, err := s.env.namespace.Hostname("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(req.Name, gc.Equals, hostname)
+	c.Check(req.Region, gc.Equals, "nyc3")
+	c.Check(req.Size, gc.Equals, "512mb")
+	c.Check(req.Image, gc.Equals, "ubuntu-16-04-x64")
+	c.Check(req.UserData, gc.Not(gc.Equals), "")
+	c.Check(req.SSHKeys, gc.HasLen, 1)
+	c.Check(req.Tags, jc.DeepEquals, []string{
+		modelTag(s.env.uuid),
+		controllerTag(testControllerUUID),
+	})
+
+	c.Check(string(result.Instance.Id()), gc.Equals, strconv.Itoa(s.api.nextID))
+	c.Check(*result.Hardware.Arch, gc.Equals, arch.AMD64)
+	c.Check(*result.Hardware.Mem, gc.Equals, uint64(512))
+	c.Check(*result.Hardware.CpuCores, gc.Equals, uint64(1))
+	c.Check(*result.Hardware.RootDisk, gc.Equals, uint64(20*1024))
+	c.Check(*result.Hardware.AvailabilityZone, gc.Equals, "nyc3")
+
+	// The SSH key and the access firewall are created once per model.
+	c.Check(s.api.sshKeys, gc.HasLen, 1)
+	for _, key := range s.api.sshKeys {
+		c.Check(key.Name, gc.Equals, sshKeyName(s.env.uuid))
+		c.Check(key.PublicKey, gc.Equals, coretesting.FakeAuthKeys)
+	}
+	s.startInstance(c, "2")
+	c.Check(s.api.sshKeys, gc.HasLen, 1)
+	fw, err := s.env.findFirewall(s.env.accessFirewallName())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fw.Tags, jc.DeepEquals, []string{modelTag(s.env.uuid)})
+	c.Check(ingressRules(fw.InboundRules), jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{22, 22, "tcp"}),
+		network.MustNewIngressRule(network.PortRange{17777, 17777, "tcp"}),
+	})
+	c.Check(s.api.firewalls, gc.HasLen, 1)
+}
+
+func (s *environSuite) TestStartInstanceController(c *gc.C) {
+	params := s.startInstanceParams(c, "0", constraints.Value{})
+	params.InstanceConfig.Jobs = []multiwatcher.MachineJob{
+		multiwatcher.JobHostUnits, multiwatcher.JobManageModel,
+	}
+	result, err := s.env.StartInstance(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.dropletRequests[0].Tags, jc.Contains, isControllerTag)
+
+	ids, err := s.env.ControllerInstances(testControllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []instance.Id{result.Instance.Id()})
+}
+
+func (s *environSuite) TestControllerInstancesNotBootstrapped(c *gc.C) {
+	s.startInstance(c, "1")
+	_, err := s.env.ControllerInstances(testControllerUUID)
+	c.Assert(err, gc.Equals, environs.ErrNotBootstrapped)
+}
+
+func (s *environSuite) TestStartInstanceConstraints(c *gc.C) {
+	cons := constraints.MustParse("mem=2G")
+	_, err := s.env.StartInstance(s.startInstanceParams(c, "1", cons))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.dropletRequests[0].Size, gc.Equals, "2gb")
+}
+
+func (s *environSuite) TestStartInstanceZoneConstraint(c *gc.C) {
+	cons := constraints.MustParse("zones=ams3")
+	_, err := s.env.StartInstance(s.startInstanceParams(c, "1", cons))
+	c.Assert(err, gc.ErrorMatches, "failed to determine availability zones not found")
+}
+
+func (s *environSuite) TestStartInstanceUnsupportedSeries(c *gc.C) {
+	params := s.startInstanceParams(c, "1", constraints.Value{})
+	params.Tools = coretools.List{{
+		Version: version.MustParseBinary("2.0.0-win2012r2-amd64"),
+		URL:     "https://example.com/tools.tgz",
+	}}
+	_, err := s.env.StartInstance(params)
+	c.Assert(err, gc.ErrorMatches, `series "win2012r2" not supported`)
+}
+
+func (s *environSuite) TestInstances(c *gc.C) {
+	inst1 := s.startInstance(c, "1").Instance
+	inst2 := s.startInstance(c, "2").Instance
+
+	all, err := s.env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+
+	insts, err := s.env.Instances([]instance.Id{inst2.Id(), "404", inst1.Id()})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(insts, gc.HasLen, 3)
+	c.Check(insts[0].Id(), gc.Equals, inst2.Id())
+	c.Check(insts[1], gc.IsNil)
+	c.Check(insts[2].Id(), gc.Equals, inst1.Id())
+
+	_, err = s.env.Instances([]instance.Id{"404"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+
+	// Droplets of other models are not included.
+	other := s.newEnviron(c, coretesting.Attrs{"uuid": "deadbeef-0bad-400d-8000-4b1d0d06f00d"})
+	all, err = other.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+}
+
+func (s *environSuite) TestInstanceStatusAndAddresses(c *gc.C) {
+	inst := s.startInstance(c, "1").Instance
+	c.Assert(inst.Status().Status, gc.Equals, status.StatusProvisioning)
+
+	addrs, err := inst.Addresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, gc.HasLen, 2)
+	c.Check(addrs[0].Scope, gc.Equals, network.ScopePublic)
+	c.Check(addrs[1].Scope, gc.Equals, network.ScopeCloudLocal)
+}
+
+func (s *environSuite) TestStopInstances(c *gc.C) {
+	inst1 := s.startInstance(c, "1").Instance
+	inst2 := s.startInstance(c, "2").Instance
+	err := inst1.(instance.IngressFirewaller).OpenIngressRules("1", []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.firewalls, gc.HasLen, 2)
+
+	err = s.env.StopInstances(inst1.Id(), "404")
+	c.Assert(err, jc.ErrorIsNil)
+	all, err := s.env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all[0].Id(), gc.Equals, inst2.Id())
+
+	// Only the access firewall remains.
+	c.Assert(s.api.firewalls, gc.HasLen, 1)
+	_, err = s.env.findFirewall(s.env.accessFirewallName())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *environSuite) TestDestroy(c *gc.C) {
+	s.PatchValue(&destroyEnv, func(env environs.Environ) error {
+		insts, err := env.AllInstances()
+		c.Assert(err, jc.ErrorIsNil)
+		ids := make([]instance.Id, len(insts))
+		for i, inst := range insts {
+			ids[i] = inst.Id()
+		}
+		return env.StopInstances(ids...)
+	})
+	s.startInstance(c, "1")
+
+	err := s.env.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.droplets, gc.HasLen, 0)
+	c.Assert(s.api.firewalls, gc.HasLen, 0)
+	c.Assert(s.api.sshKeys, gc.HasLen, 0)
+}
+
+func (s *environSuite) TestDestroyController(c *gc.C) {
+	s.PatchValue(&destroyEnv, func(environs.Environ) error { return nil })
+	hostedUUID := "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	hosted := s.newEnviron(c, coretesting.Attrs{"uuid": hostedUUID})
+	_, err := hosted.StartInstance(s.startInstanceParams(c, "1", constraints.Value{}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.droplets, gc.HasLen, 1)
+	c.Assert(s.api.firewalls, gc.HasLen, 1)
+
+	err = s.env.DestroyController(testControllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.droplets, gc.HasLen, 0)
+	c.Assert(s.api.firewalls, gc.HasLen, 0)
+	c.Assert(s.api.sshKeys, gc.HasLen, 0)
+}
+
+func (s *environSuite) TestAvailabilityZones(c *gc.C) {
+	zones, err := s.env.AvailabilityZones()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.HasLen, 1)
+	c.Assert(zones[0].Name(), gc.Equals, "nyc3")
+	c.Assert(zones[0].Available(), jc.IsTrue)
+
+	inst := s.startInstance(c, "1").Instance
+	names, err := s.env.InstanceAvailabilityZoneNames([]instance.Id{inst.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"nyc3"})
+}
+
+func (s *environSuite) TestPrecheckInstance(c *gc.C) {
+	err := s.env.PrecheckInstance("xenial", constraints.MustParse("instance-type=1gb"), "zone=nyc3")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.env.PrecheckInstance("xenial", constraints.MustParse("instance-type=4gb"), "")
+	c.Assert(err, gc.ErrorMatches, `invalid DigitalOcean instance type "4gb" specified`)
+
+	err = s.env.PrecheckInstance("xenial", constraints.Value{}, "zone=ams3")
+	c.Assert(err, gc.ErrorMatches, `availability zone "ams3" is not in the model's region "nyc3"`)
+
+	err = s.env.PrecheckInstance("xenial", constraints.Value{}, "host=foo")
+	c.Assert(err, gc.ErrorMatches, `unknown placement directive: host=foo`)
+
+	err = s.env.PrecheckInstance("precise", constraints.Value{}, "")
+	c.Assert(err, gc.ErrorMatches, `series "precise" not supported`)
+}
+
+func (s *environSuite) TestConstraintsValidator(c *gc.C) {
+	validator, err := s.env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+
+	unsupported, err := validator.Validate(constraints.MustParse("arch=amd64 tags=foo virt-type=kvm"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.SameContents, []string{"tags", "virt-type"})
+
+	_, err = validator.Validate(constraints.MustParse("instance-type=4gb"))
+	c.Assert(err, gc.ErrorMatches, `invalid constraint value: instance-type=4gb\nvalid values are:.*`)
+
+	_, err = validator.Validate(constraints.MustParse("arch=arm64"))
+	c.Assert(err, gc.ErrorMatches, `invalid constraint value: arch=arm64\nvalid values are:.*`)
+
+	_, err = validator.Validate(constraints.MustParse("zones=ams3"))
+	c.Assert(err, gc.ErrorMatches, `invalid constraint value: zones=ams3\nvalid values are:.*`)
+}
+
+func (s *environSuite) TestSetConfigTokenChange(c *gc.C) {
+	err := s.env.SetConfig(s.newConfig(c, coretesting.Attrs{cfgToken: "new-token"}))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.client.account()
+	c.Assert(err, gc.ErrorMatches, `digitalocean API request failed: .*\(unauthorized\)`)
+}
@@ -0,0 +1,417 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/juju/utils/ssh"
+)
+
+const fakeToken = "fake-token"
+
+// fakeAPI is an in-memory fake of the parts of the DigitalOcean API
+// used by the provider, served over HTTP.
+type fakeAPI struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	regions   []region
+	sizes     []size
+	droplets  map[int]droplet
+	tags      map[string]bool
+	sshKeys   map[int]sshKey
+	firewalls map[string]firewall
+	volumes   map[string]volume
+	actions   map[int]action
+	nextID    int
+
+	// perPage, if non-zero, overrides the page size requested
+	// by the client.
+	perPage int
+
+	// dropletRequests records the requests made to create droplets.
+	dropletRequests []dropletCreateRequest
+
+	// actionStatus is the status given to new volume actions.
+	actionStatus string
+}
+
+func newFakeAPI() *fakeAPI {
+	f := &fakeAPI{
+		regions: []region{{
+			Slug:      "nyc3",
+			Name:      "New York 3",
+			Available: true,
+			Sizes:     []string{"512mb", "1gb", "2gb"},
+		}, {
+			Slug:      "ams3",
+			Name:      "Amsterdam 3",
+			Available: true,
+			Sizes:     []string{"512mb", "1gb"},
+		}},
+		sizes: []size{{
+			Slug: "512mb", Memory: 512, VCPUs: 1, Disk: 20,
+			PriceMonthly: 5, Available: true, Regions: []string{"nyc3", "ams3"},
+		}, {
+			Slug: "1gb", Memory: 1024, VCPUs: 1, Disk: 30,
+			PriceMonthly: 10, Available: true, Regions: []string{"nyc3", "ams3"},
+		}, {
+			Slug: "2gb", Memory: 2048, VCPUs: 2, Disk: 40,
+			PriceMonthly: 20, Available: true, Regions: []string{"nyc3"},
+		}, {
+			Slug: "4gb", Memory: 4096, VCPUs: 2, Disk: 60,
+			PriceMonthly: 40, Available: false, Regions: []string{"nyc3"},
+		}},
+		droplets:     make(map[int]droplet),
+		tags:         make(map[string]bool),
+		sshKeys:      make(map[int]sshKey),
+		firewalls:    make(map[string]firewall),
+		volumes:      make(map[string]volume),
+		actions:      make(map[int]action),
+		nextID:       1000,
+		actionStatus: actionCompleted,
+	}
+	f.Server = httptest.NewServer(f)
+	return f
+}
+
+func (f *fakeAPI) newID() int {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Authorization") != "Bearer "+fakeToken {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "Unable to authenticate you.")
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "v2" {
+		writeError(w, http.StatusNotFound, "not_found", "The resource you were accessing could not be found.")
+		return
+	}
+	parts = parts[1:]
+	switch {
+	case parts[0] == "account" && len(parts) == 1:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"account": map[string]string{"status": "active"}})
+	case parts[0] == "account" && len(parts) > 1 && parts[1] == "keys":
+		f.serveSSHKeys(w, req, parts[2:])
+	case parts[0] == "regions":
+		items := make([]interface{}, len(f.regions))
+		for i, r := range f.regions {
+			items[i] = r
+		}
+		f.writePage(w, req, "regions", items)
+	case parts[0] == "sizes":
+		items := make([]interface{}, len(f.sizes))
+		for i, s := range f.sizes {
+			items[i] = s
+		}
+		f.writePage(w, req, "sizes", items)
+	case parts[0] == "droplets":
+		f.serveDroplets(w, req, parts[1:])
+	case parts[0] == "tags" && req.Method == "POST":
+		var body struct {
+			Name string `json:"name"`
+		}
+		json.NewDecoder(req.Body).Decode(&body)
+		f.tags[body.Name] = true
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"tag": body})
+	case parts[0] == "firewalls":
+		f.serveFirewalls(w, req, parts[1:])
+	case parts[0] == "volumes":
+		f.serveVolumes(w, req, parts[1:])
+	case parts[0] == "actions" && len(parts) == 2:
+		id, _ := strconv.Atoi(parts[1])
+		a, ok := f.actions[id]
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+		// Actions complete the second time they are queried.
+		a.Status = actionCompleted
+		f.actions[id] = a
+		writeJSON(w, http.StatusOK, map[string]interface{}{"action": a})
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (f *fakeAPI) serveDroplets(w http.ResponseWriter, req *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && req.Method == "GET":
+		tag := req.URL.Query().Get("tag_name")
+		var ids []int
+		for id, d := range f.droplets {
+			if tag == "" || containsString(d.Tags, tag) {
+				ids = append(ids, id)
+			}
+		}
+		sort.Ints(ids)
+		items := make([]interface{}, len(ids))
+		for i, id := range ids {
+			items[i] = f.droplets[id]
+		}
+		f.writePage(w, req, "droplets", items)
+	case len(parts) == 0 && req.Method == "POST":
+		var body dropletCreateRequest
+		json.NewDecoder(req.Body).Decode(&body)
+		f.dropletRequests = append(f.dropletRequests, body)
+		// Droplet tags are created on demand.
+		for _, tag := range body.Tags {
+			f.tags[tag] = true
+		}
+		var sz size
+		for _, s := range f.sizes {
+			if s.Slug == body.Size {
+				sz = s
+			}
+		}
+		d := droplet{
+			ID:       f.newID(),
+			Name:     body.Name,
+			Memory:   sz.Memory,
+			VCPUs:    sz.VCPUs,
+			Disk:     sz.Disk,
+			Status:   "new",
+			SizeSlug: body.Size,
+			Region:   region{Slug: body.Region},
+			Tags:     body.Tags,
+		}
+		d.Networks.V4 = []dropletNetwork{
+			{IPAddress: fmt.Sprintf("203.0.113.%d", d.ID%250), Type: "public"},
+			{IPAddress: fmt.Sprintf("10.0.0.%d", d.ID%250), Type: "private"},
+		}
+		f.droplets[d.ID] = d
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"droplet": d})
+	case len(parts) == 1 && req.Method == "DELETE":
+		id, _ := strconv.Atoi(parts[0])
+		if _, ok := f.droplets[id]; !ok {
+			writeNotFound(w)
+			return
+		}
+		delete(f.droplets, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (f *fakeAPI) serveSSHKeys(w http.ResponseWriter, req *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && req.Method == "GET":
+		var ids []int
+		for id := range f.sshKeys {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+		items := make([]interface{}, len(ids))
+		for i, id := range ids {
+			items[i] = f.sshKeys[id]
+		}
+		f.writePage(w, req, "ssh_keys", items)
+	case len(parts) == 0 && req.Method == "POST":
+		var body sshKey
+		json.NewDecoder(req.Body).Decode(&body)
+		body.ID = f.newID()
+		body.Fingerprint, _, _ = ssh.KeyFingerprint(body.PublicKey)
+		f.sshKeys[body.ID] = body
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"ssh_key": body})
+	case len(parts) == 1 && req.Method == "DELETE":
+		id, _ := strconv.Atoi(parts[0])
+		if _, ok := f.sshKeys[id]; !ok {
+			writeNotFound(w)
+			return
+		}
+		delete(f.sshKeys, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (f *fakeAPI) serveFirewalls(w http.ResponseWriter, req *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && req.Method == "GET":
+		var ids []string
+		for id := range f.firewalls {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		items := make([]interface{}, len(ids))
+		for i, id := range ids {
+			items[i] = f.firewalls[id]
+		}
+		f.writePage(w, req, "firewalls", items)
+	case len(parts) == 0 && req.Method == "POST":
+		var body firewall
+		json.NewDecoder(req.Body).Decode(&body)
+		for _, tag := range body.Tags {
+			if !f.tags[tag] {
+				writeError(w, http.StatusUnprocessableEntity, "unprocessable_entity", "tag does not exist: "+tag)
+				return
+			}
+		}
+		body.ID = fmt.Sprintf("fw-%d", f.newID())
+		f.firewalls[body.ID] = body
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"firewall": body})
+	case len(parts) == 1 && req.Method == "PUT":
+		if _, ok := f.firewalls[parts[0]]; !ok {
+			writeNotFound(w)
+			return
+		}
+		var body firewall
+		json.NewDecoder(req.Body).Decode(&body)
+		body.ID = parts[0]
+		f.firewalls[body.ID] = body
+		writeJSON(w, http.StatusOK, map[string]interface{}{"firewall": body})
+	case len(parts) == 1 && req.Method == "DELETE":
+		if _, ok := f.firewalls[parts[0]]; !ok {
+			writeNotFound(w)
+			return
+		}
+		delete(f.firewalls, parts[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (f *fakeAPI) serveVolumes(w http.ResponseWriter, req *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && req.Method == "GET":
+		region := req.URL.Query().Get("region")
+		var ids []string
+		for id, v := range f.volumes {
+			if region == "" || v.Region.Slug == region {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+		items := make([]interface{}, len(ids))
+		for i, id := range ids {
+			items[i] = f.volumes[id]
+		}
+		f.writePage(w, req, "volumes", items)
+	case len(parts) == 0 && req.Method == "POST":
+		var body volumeCreateRequest
+		json.NewDecoder(req.Body).Decode(&body)
+		v := volume{
+			ID:            fmt.Sprintf("vol-%d", f.newID()),
+			Name:          body.Name,
+			Description:   body.Description,
+			SizeGigabytes: body.SizeGigabytes,
+			Region:        region{Slug: body.Region},
+		}
+		f.volumes[v.ID] = v
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"volume": v})
+	case len(parts) == 1 && req.Method == "GET":
+		v, ok := f.volumes[parts[0]]
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"volume": v})
+	case len(parts) == 1 && req.Method == "DELETE":
+		if _, ok := f.volumes[parts[0]]; !ok {
+			writeNotFound(w)
+			return
+		}
+		delete(f.volumes, parts[0])
+		w.WriteHeader(http.StatusNoContent)
+	case len(parts) == 2 && parts[1] == "actions" && req.Method == "POST":
+		v, ok := f.volumes[parts[0]]
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+		var body volumeActionRequest
+		json.NewDecoder(req.Body).Decode(&body)
+		switch body.Type {
+		case "attach":
+			v.DropletIDs = append(v.DropletIDs, body.DropletID)
+		case "detach":
+			var ids []int
+			for _, id := range v.DropletIDs {
+				if id != body.DropletID {
+					ids = append(ids, id)
+				}
+			}
+			v.DropletIDs = ids
+		}
+		f.volumes[v.ID] = v
+		a := action{ID: f.newID(), Status: f.actionStatus, Type: body.Type + "_volume"}
+		if a.Status == "" {
+			a.Status = "in-progress"
+		}
+		f.actions[a.ID] = a
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"action": a})
+	default:
+		writeNotFound(w)
+	}
+}
+
+// writePage writes the page of items requested, with a link to the
+// next page if there is one.
+func (f *fakeAPI) writePage(w http.ResponseWriter, req *http.Request, key string, items []interface{}) {
+	query := req.URL.Query()
+	perPage, _ := strconv.Atoi(query.Get("per_page"))
+	if f.perPage != 0 {
+		perPage = f.perPage
+	}
+	if perPage <= 0 {
+		perPage = 20
+	}
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+	start := (page - 1) * perPage
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+	pages := map[string]string{}
+	if end < len(items) {
+		query.Set("page", strconv.Itoa(page+1))
+		pages["next"] = f.URL + req.URL.Path + "?" + query.Encode()
+	}
+	pageItems := items[start:end]
+	if pageItems == nil {
+		pageItems = []interface{}{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		key:     pageItems,
+		"links": map[string]interface{}{"pages": pages},
+		"meta":  map[string]int{"total": len(items)},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, id, message string) {
+	writeJSON(w, code, map[string]string{"id": id, "message": message})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "not_found", "The resource you were accessing could not be found.")
+}
@@ -0,0 +1,23 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/storage/provider/registry"
+)
+
+const (
+	providerType = "digitalocean"
+)
+
+func init() {
+	environs.RegisterProvider(providerType, providerInstance)
+
+	// Register the DigitalOcean block storage provider.
+	registry.RegisterProvider(storageProviderType, &storageProvider{})
+
+	// Inform the storage provider registry about the DigitalOcean providers.
+	registry.RegisterEnvironStorageProviders(providerType, storageProviderType)
+}
@@ -0,0 +1,139 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/status"
+)
+
+type environInstance struct {
+	droplet droplet
+	env     *environ
+}
+
+var _ instance.Instance = (*environInstance)(nil)
+var _ instance.IngressFirewaller = (*environInstance)(nil)
+
+func newInstance(d droplet, env *environ) *environInstance {
+	return &environInstance{
+		droplet: d,
+		env:     env,
+	}
+}
+
+// Id implements instance.Instance.
+func (inst *environInstance) Id() instance.Id {
+	return instance.Id(strconv.Itoa(inst.droplet.ID))
+}
+
+// Status implements instance.Instance.
+func (inst *environInstance) Status() instance.InstanceStatus {
+	var jujuStatus status.Status
+	switch inst.droplet.Status {
+	case "new":
+		jujuStatus = status.StatusProvisioning
+	case "active":
+		jujuStatus = status.StatusRunning
+	default:
+		// "off" and "archive".
+		jujuStatus = status.StatusEmpty
+	}
+	return instance.InstanceStatus{
+		Status:  jujuStatus,
+		Message: inst.droplet.Status,
+	}
+}
+
+// Addresses implements instance.Instance.
+func (inst *environInstance) Addresses() ([]network.Address, error) {
+	var addresses []network.Address
+	add := func(nets []dropletNetwork) {
+		for _, n := range nets {
+			addr := network.NewAddress(n.IPAddress)
+			switch n.Type {
+			case "public":
+				addr.Scope = network.ScopePublic
+			case "private":
+				addr.Scope = network.ScopeCloudLocal
+			}
+			addresses = append(addresses, addr)
+		}
+	}
+	add(inst.droplet.Networks.V4)
+	add(inst.droplet.Networks.V6)
+	return addresses, nil
+}
+
+// OpenPorts opens the given ports on the instance, which
+// should have been started with the given machine id.
+func (inst *environInstance) OpenPorts(machineId string, ports []network.PortRange) error {
+	return inst.OpenIngressRules(machineId, network.OpenIngressRules(ports))
+}
+
+// ClosePorts closes the given ports on the instance, which
+// should have been started with the given machine id.
+func (inst *environInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+	return inst.CloseIngressRules(machineId, network.OpenIngressRules(ports))
+}
+
+// Ports returns the set of ports open on the instance, which
+// should have been started with the given machine id.
+// The ports are returned as sorted by SortPorts.
+func (inst *environInstance) Ports(machineId string) ([]network.PortRange, error) {
+	rules, err := inst.IngressRules(machineId)
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulePortRanges(rules), nil
+}
+
+// OpenIngressRules is part of the instance.IngressFirewaller interface.
+func (inst *environInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	if err := inst.checkFirewallMode("opening ports on"); err != nil {
+		return err
+	}
+	name := inst.env.instanceFirewallName(inst.droplet.ID)
+	targets := firewallTargets{DropletIDs: []int{inst.droplet.ID}}
+	if err := inst.env.openIngressRules(name, targets, rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened ports in firewall %s: %v", name, rules)
+	return nil
+}
+
+// CloseIngressRules is part of the instance.IngressFirewaller interface.
+func (inst *environInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	if err := inst.checkFirewallMode("closing ports on"); err != nil {
+		return err
+	}
+	name := inst.env.instanceFirewallName(inst.droplet.ID)
+	if err := inst.env.closeIngressRules(name, rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed ports in firewall %s: %v", name, rules)
+	return nil
+}
+
+// IngressRules is part of the instance.IngressFirewaller interface.
+func (inst *environInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	if err := inst.checkFirewallMode("retrieving ports from"); err != nil {
+		return nil, err
+	}
+	return inst.env.ingressRules(inst.env.instanceFirewallName(inst.droplet.ID))
+}
+
+func (inst *environInstance) checkFirewallMode(operation string) error {
+	if mode := inst.env.Config().FirewallMode(); mode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for %s instance", mode, operation)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"math"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/environs/instances"
+)
+
+// seriesImages maps the series supported by the provider to the slug
+// of the DigitalOcean distribution image used to boot them.
+var seriesImages = map[string]string{
+	"trusty":  "ubuntu-14-04-x64",
+	"xenial":  "ubuntu-16-04-x64",
+	"centos7": "centos-7-x64",
+}
+
+// seriesImage returns the image slug for the given series.
+func seriesImage(series string) (string, error) {
+	slug, ok := seriesImages[series]
+	if !ok {
+		return "", errors.NotSupportedf("series %q", series)
+	}
+	return slug, nil
+}
+
+// images returns the images that may be used to start an instance of
+// the given series. DigitalOcean distribution images are only
+// available for amd64.
+func images(series string) ([]instances.Image, error) {
+	slug, err := seriesImage(series)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []instances.Image{{Id: slug, Arch: arch.AMD64}}, nil
+}
+
+// instanceTypes returns the droplet sizes that are available in the
+// model's region as instance types.
+func (env *environ) instanceTypes() ([]instances.InstanceType, error) {
+	sizes, err := env.client.sizes()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing droplet sizes")
+	}
+	region := env.region()
+	var result []instances.InstanceType
+	for _, s := range sizes {
+		if !s.Available || !containsString(s.Regions, region) {
+			continue
+		}
+		result = append(result, instances.InstanceType{
+			Id:       s.Slug,
+			Name:     s.Slug,
+			Arches:   []string{arch.AMD64},
+			CpuCores: uint64(s.VCPUs),
+			Mem:      uint64(s.Memory),
+			RootDisk: uint64(s.Disk) * 1024,
+			// Cost is expressed in cents per month, so that
+			// sizes can be ordered by price.
+			Cost: uint64(math.Ceil(s.PriceMonthly * 100)),
+		})
+	}
+	return result, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"testing"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+)
+
+func TestPackage(t *testing.T) {
+	gc.TestingT(t)
+}
+
+// baseSuite starts a fake DigitalOcean API for each test, and opens
+// an environ that talks to it.
+type baseSuite struct {
+	coretesting.BaseSuite
+
+	api *fakeAPI
+	env *environ
+}
+
+func (s *baseSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.api = newFakeAPI()
+	s.AddCleanup(func(*gc.C) { s.api.Close() })
+	s.env = s.newEnviron(c, nil)
+}
+
+// newConfig returns a model config for the fake API, with the given
+// attributes overriding the defaults.
+func (s *baseSuite) newConfig(c *gc.C, extra coretesting.Attrs) *config.Config {
+	attrs := coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"type":          providerType,
+		"agent-version": "2.0.0",
+		cfgToken:        fakeToken,
+		cfgRegion:       "nyc3",
+		cfgEndpoint:     s.api.URL,
+	}).Merge(extra)
+	cfg, err := config.New(config.NoDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func (s *baseSuite) newEnviron(c *gc.C, extra coretesting.Attrs) *environ {
+	env, err := newEnviron(s.newConfig(c, extra))
+	c.Assert(err, jc.ErrorIsNil)
+	return env
+}
@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package digitalocean implements a Juju provider for DigitalOcean.
+// Machines are DigitalOcean droplets, exposed ports are managed with
+// cloud firewalls, and volumes are block storage volumes.
+package digitalocean
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+)
+
+var logger = loggo.GetLogger("juju.provider.digitalocean")
+
+type environProvider struct {
+	environProviderCredentials
+}
+
+var providerInstance = environProvider{}
+
+var _ environs.EnvironProvider = (*environProvider)(nil)
+
+// Open is part of the environs.EnvironProvider interface.
+func (environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
+	logger.Infof("opening model %q", args.Config.Name())
+	env, err := newEnviron(args.Config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return env, nil
+}
+
+// RestrictedConfigAttributes is part of the environs.EnvironProvider interface.
+func (environProvider) RestrictedConfigAttributes() []string {
+	return []string{cfgRegion, cfgEndpoint}
+}
+
+// PrepareForCreateEnvironment is part of the environs.EnvironProvider interface.
+func (environProvider) PrepareForCreateEnvironment(controllerUUID string, cfg *config.Config) (*config.Config, error) {
+	// Hosted models share the controller's region, endpoint and
+	// token, which are inherited from the controller model.
+	return cfg, nil
+}
+
+// BootstrapConfig is part of the environs.EnvironProvider interface.
+func (environProvider) BootstrapConfig(args environs.BootstrapConfigParams) (*config.Config, error) {
+	attrs := map[string]interface{}{
+		cfgRegion: args.Cloud.Region,
+	}
+	if args.Cloud.Endpoint != "" {
+		attrs[cfgEndpoint] = args.Cloud.Endpoint
+	}
+	if args.Cloud.Credential == nil {
+		return nil, errors.NotValidf("missing credential")
+	}
+	switch authType := args.Cloud.Credential.AuthType(); authType {
+	case cloud.OAuth2AuthType:
+		attrs[cfgToken] = args.Cloud.Credential.Attributes()[cfgToken]
+	default:
+		return nil, errors.NotSupportedf("%q auth-type", authType)
+	}
+	cfg, err := args.Config.Apply(attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return providerInstance.Validate(cfg, nil)
+}
+
+// Validate is part of the config.Validator interface.
+func (environProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
+	newEcfg, err := validateConfig(cfg, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+	if old != nil {
+		oldEcfg, err := validateConfig(old, nil)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid base config")
+		}
+		if newEcfg, err = validateConfig(cfg, oldEcfg); err != nil {
+			return nil, errors.Annotate(err, "invalid config change")
+		}
+	}
+	return newEcfg.Config, nil
+}
+
+// SecretAttrs is part of the environs.EnvironProvider interface.
+func (environProvider) SecretAttrs(cfg *config.Config) (map[string]string, error) {
+	ecfg, err := validateConfig(cfg, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	secretAttrs := make(map[string]string)
+	for _, field := range configSecretFields {
+		if value, ok := ecfg.attrs[field].(string); ok {
+			secretAttrs[field] = value
+		}
+	}
+	return secretAttrs, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type providerSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) TestRegistered(c *gc.C) {
+	provider, err := environs.Provider(providerType)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider, gc.Equals, providerInstance)
+}
+
+func (s *providerSuite) TestOpen(c *gc.C) {
+	env, err := providerInstance.Open(environs.OpenParams{s.newConfig(c, nil)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.Config().Name(), gc.Equals, "testenv")
+}
+
+func (s *providerSuite) TestValidateMissingToken(c *gc.C) {
+	cfg := s.newConfig(c, coretesting.Attrs{cfgToken: ""})
+	_, err := providerInstance.Validate(cfg, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid config: .*token.*`)
+}
+
+func (s *providerSuite) TestValidateImmutableRegion(c *gc.C) {
+	old := s.newConfig(c, nil)
+	cfg := s.newConfig(c, coretesting.Attrs{cfgRegion: "ams3"})
+	_, err := providerInstance.Validate(cfg, old)
+	c.Assert(err, gc.ErrorMatches, `invalid config change: .*region.*`)
+}
+
+func (s *providerSuite) TestBootstrapConfig(c *gc.C) {
+	credential := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{
+		cfgToken: "secret",
+	})
+	base := s.newConfig(c, coretesting.Attrs{cfgToken: "", cfgRegion: ""})
+	cfg, err := providerInstance.BootstrapConfig(environs.BootstrapConfigParams{
+		Config: base,
+		Cloud: environs.CloudSpec{
+			Type:       providerType,
+			Region:     "ams3",
+			Endpoint:   "https://api.example.com",
+			Credential: &credential,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	attrs := cfg.UnknownAttrs()
+	c.Assert(attrs[cfgToken], gc.Equals, "secret")
+	c.Assert(attrs[cfgRegion], gc.Equals, "ams3")
+	c.Assert(attrs[cfgEndpoint], gc.Equals, "https://api.example.com")
+}
+
+func (s *providerSuite) TestBootstrapConfigMissingCredential(c *gc.C) {
+	_, err := providerInstance.BootstrapConfig(environs.BootstrapConfigParams{
+		Config: s.newConfig(c, nil),
+		Cloud:  environs.CloudSpec{Type: providerType, Region: "nyc3"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *providerSuite) TestSecretAttrs(c *gc.C) {
+	attrs, err := providerInstance.SecretAttrs(s.newConfig(c, nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attrs, jc.DeepEquals, map[string]string{cfgToken: fakeToken})
+}
+
+func (s *providerSuite) TestCredentialSchemas(c *gc.C) {
+	schemas := providerInstance.CredentialSchemas()
+	c.Assert(schemas, gc.HasLen, 1)
+	schema, ok := schemas[cloud.OAuth2AuthType]
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(schema, gc.HasLen, 1)
+	c.Assert(schema[0].Name, gc.Equals, cfgToken)
+	c.Assert(schema[0].Hidden, jc.IsTrue)
+}
+
+func (s *providerSuite) TestDetectCredentials(c *gc.C) {
+	s.PatchEnvironment("USER", "fred")
+	s.PatchEnvironment(tokenEnvKey, "secret")
+	credentials, err := providerInstance.DetectCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	expected := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{
+		cfgToken: "secret",
+	})
+	expected.Label = "digitalocean access token from " + tokenEnvKey
+	c.Assert(credentials.AuthCredentials["fred"], jc.DeepEquals, expected)
+}
+
+func (s *providerSuite) TestDetectCredentialsNotFound(c *gc.C) {
+	s.PatchEnvironment(tokenEnvKey, "")
+	_, err := providerInstance.DetectCredentials()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,301 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	storageProviderType = storage.ProviderType("digitalocean")
+
+	// volumeDeviceLinkPrefix prefixes the name of a volume in the
+	// stable device link created for it by udev on the droplet.
+	volumeDeviceLinkPrefix = "/dev/disk/by-id/scsi-0DO_Volume_"
+)
+
+// actionAttempt is the strategy used to wait for volume attach and
+// detach actions to complete.
+var actionAttempt = utils.AttemptStrategy{
+	Total: 2 * time.Minute,
+	Delay: 2 * time.Second,
+}
+
+type storageProvider struct{}
+
+var _ storage.Provider = (*storageProvider)(nil)
+
+// ValidateConfig is part of the storage.Provider interface.
+func (*storageProvider) ValidateConfig(cfg *storage.Config) error {
+	return nil
+}
+
+// Supports is part of the storage.Provider interface.
+func (*storageProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is part of the storage.Provider interface.
+func (*storageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is part of the storage.Provider interface.
+func (*storageProvider) Dynamic() bool {
+	return true
+}
+
+// FilesystemSource is part of the storage.Provider interface.
+func (*storageProvider) FilesystemSource(environConfig *config.Config, providerConfig *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// VolumeSource is part of the storage.Provider interface.
+func (*storageProvider) VolumeSource(environConfig *config.Config, cfg *storage.Config) (storage.VolumeSource, error) {
+	env, err := newEnviron(environConfig)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create an environ with this config")
+	}
+	return &volumeSource{
+		client:    env.client,
+		region:    env.region(),
+		prefix:    env.namespace.Prefix(),
+		modelUUID: env.uuid,
+	}, nil
+}
+
+// volumeSource manages DigitalOcean block storage volumes. Volumes
+// are identified by their DigitalOcean IDs, and their descriptions
+// record the UUID of the model that created them.
+type volumeSource struct {
+	client    *client
+	region    string
+	prefix    string
+	modelUUID string
+}
+
+var _ storage.VolumeSource = (*volumeSource)(nil)
+
+// mibToGib converts mebibytes to gibibytes.
+// DigitalOcean expects GiB, we work in MiB; round up
+// to nearest GiB.
+func mibToGib(m uint64) uint64 {
+	return (m + 1023) / 1024
+}
+
+// CreateVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) CreateVolumes(params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(params))
+	for i, p := range params {
+		if err := v.ValidateVolumeParams(p); err != nil {
+			results[i].Error = err
+			continue
+		}
+		vol, attachment, err := v.createOneVolume(p)
+		if err != nil {
+			logger.Errorf("could not create one volume (or attach it): %v", err)
+			results[i].Error = err
+			continue
+		}
+		results[i].Volume = vol
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+func (v *volumeSource) createOneVolume(p storage.VolumeParams) (*storage.Volume, *storage.VolumeAttachment, error) {
+	name := v.prefix + "volume-" + strings.Replace(p.Tag.Id(), "/", "-", -1)
+	vol, err := v.client.createVolume(volumeCreateRequest{
+		Name:          name,
+		Description:   v.modelUUID,
+		Region:        v.region,
+		SizeGigabytes: int(mibToGib(p.Size)),
+	})
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "cannot create volume")
+	}
+	result := &storage.Volume{
+		p.Tag,
+		storage.VolumeInfo{
+			VolumeId:   vol.ID,
+			Size:       uint64(vol.SizeGigabytes) * 1024,
+			Persistent: true,
+		},
+	}
+	if p.Attachment == nil || p.Attachment.InstanceId == "" {
+		return result, nil, nil
+	}
+
+	info, err := v.attachOneVolume(vol.ID, p.Attachment.InstanceId)
+	if err != nil {
+		// Leave the volume behind; the storage provisioner will
+		// retry attaching it.
+		return result, nil, errors.Annotatef(err, "attaching %q to %q", vol.ID, p.Attachment.InstanceId)
+	}
+	attachment := &storage.VolumeAttachment{
+		p.Tag,
+		p.Attachment.Machine,
+		*info,
+	}
+	return result, attachment, nil
+}
+
+// ListVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ListVolumes() ([]string, error) {
+	volumes, err := v.client.volumes(v.region)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing volumes")
+	}
+	var ids []string
+	for _, vol := range volumes {
+		// We don't want to lay hands on volumes we did not create.
+		if vol.Description == v.modelUUID {
+			ids = append(ids, vol.ID)
+		}
+	}
+	return ids, nil
+}
+
+// DescribeVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVolumesResult, error) {
+	results := make([]storage.DescribeVolumesResult, len(volIds))
+	for i, id := range volIds {
+		vol, err := v.client.volume(id)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "cannot get volume %q", id)
+			continue
+		}
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId:   vol.ID,
+			Size:       uint64(vol.SizeGigabytes) * 1024,
+			Persistent: true,
+		}
+	}
+	return results, nil
+}
+
+// DestroyVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DestroyVolumes(volIds []string) ([]error, error) {
+	results := make([]error, len(volIds))
+	for i, id := range volIds {
+		if err := v.client.deleteVolume(id); err != nil && !errors.IsNotFound(err) {
+			results[i] = errors.Annotatef(err, "cannot destroy volume %q", id)
+		}
+	}
+	return results, nil
+}
+
+// ValidateVolumeParams is part of the storage.VolumeSource interface.
+func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	if params.Attachment != nil && params.Attachment.ReadOnly {
+		return errors.NotSupportedf("read-only volume attachments")
+	}
+	return nil
+}
+
+// AttachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) AttachVolumes(params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(params))
+	for i, p := range params {
+		if p.ReadOnly {
+			results[i].Error = errors.NotSupportedf("read-only volume attachments")
+			continue
+		}
+		info, err := v.attachOneVolume(p.VolumeId, p.InstanceId)
+		if err != nil {
+			logger.Errorf("could not attach %q to %q: %v", p.VolumeId, p.InstanceId, err)
+			results[i].Error = err
+			continue
+		}
+		results[i].VolumeAttachment = &storage.VolumeAttachment{
+			p.Volume,
+			p.Machine,
+			*info,
+		}
+	}
+	return results, nil
+}
+
+func (v *volumeSource) attachOneVolume(volumeId string, instanceId instance.Id) (*storage.VolumeAttachmentInfo, error) {
+	dropletID, err := strconv.Atoi(string(instanceId))
+	if err != nil {
+		return nil, errors.NotValidf("instance ID %q", instanceId)
+	}
+	vol, err := v.client.volume(volumeId)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get volume %q", volumeId)
+	}
+	info := &storage.VolumeAttachmentInfo{
+		DeviceLink: volumeDeviceLinkPrefix + vol.Name,
+	}
+	// Is it already attached?
+	for _, id := range vol.DropletIDs {
+		if id == dropletID {
+			return info, nil
+		}
+	}
+	if err := v.runVolumeAction(volumeId, volumeActionRequest{
+		Type:      "attach",
+		DropletID: dropletID,
+		Region:    v.region,
+	}); err != nil {
+		return nil, errors.Annotate(err, "cannot attach volume")
+	}
+	return info, nil
+}
+
+// DetachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(params))
+	for i, p := range params {
+		results[i] = v.detachOneVolume(p.VolumeId, p.InstanceId)
+	}
+	return results, nil
+}
+
+func (v *volumeSource) detachOneVolume(volumeId string, instanceId instance.Id) error {
+	dropletID, err := strconv.Atoi(string(instanceId))
+	if err != nil {
+		return errors.NotValidf("instance ID %q", instanceId)
+	}
+	err = v.runVolumeAction(volumeId, volumeActionRequest{
+		Type:      "detach",
+		DropletID: dropletID,
+		Region:    v.region,
+	})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Annotatef(err, "cannot detach volume %q from %q", volumeId, instanceId)
+}
+
+// runVolumeAction starts the given action on a volume and waits for
+// it to complete.
+func (v *volumeSource) runVolumeAction(volumeId string, req volumeActionRequest) error {
+	a, err := v.client.volumeAction(volumeId, req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for attempt := actionAttempt.Start(); a.Status != actionCompleted; {
+		if a.Status == actionErrored {
+			return errors.Errorf("%s action %d failed", a.Type, a.ID)
+		}
+		if !attempt.Next() {
+			return errors.Errorf("timed out waiting for %s action %d", a.Type, a.ID)
+		}
+		if a, err = v.client.action(a.ID); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package digitalocean
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+)
+
+type storageSuite struct {
+	baseSuite
+
+	source    storage.VolumeSource
+	dropletID int
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+	s.PatchValue(&actionAttempt, utils.AttemptStrategy{})
+
+	var err error
+	s.source, err = (&storageProvider{}).VolumeSource(s.env.Config(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	d, err := s.env.client.createDroplet(dropletCreateRequest{Name: "machine-0", Size: "512mb"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.dropletID = d.ID
+}
+
+func (s *storageSuite) instanceId() instance.Id {
+	return instance.Id(strconv.Itoa(s.dropletID))
+}
+
+func (s *storageSuite) TestProvider(c *gc.C) {
+	p := &storageProvider{}
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+	_, err := p.FilesystemSource(s.env.Config(), nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageSuite) TestCreateVolumes(c *gc.C) {
+	results, err := s.source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1536,
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				Machine:    names.NewMachineTag("0"),
+				InstanceId: s.instanceId(),
+			},
+			Volume: names.NewVolumeTag("0"),
+		},
+	}, {
+		Tag:  names.NewVolumeTag("0/1"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[1].Error, jc.ErrorIsNil)
+
+	vol := s.api.volumes[results[0].Volume.VolumeId]
+	prefix := s.env.namespace.Prefix()
+	c.Check(vol.Name, gc.Equals, prefix+"volume-0")
+	c.Check(vol.Description, gc.Equals, s.env.uuid)
+	c.Check(vol.SizeGigabytes, gc.Equals, 2)
+	c.Check(vol.DropletIDs, jc.DeepEquals, []int{s.dropletID})
+	c.Check(results[0].Volume.Size, gc.Equals, uint64(2048))
+	c.Check(results[0].VolumeAttachment.DeviceLink, gc.Equals, "/dev/disk/by-id/scsi-0DO_Volume_"+prefix+"volume-0")
+
+	c.Check(s.api.volumes[results[1].Volume.VolumeId].Name, gc.Equals, prefix+"volume-0-1")
+	c.Check(results[1].VolumeAttachment, gc.IsNil)
+}
+
+func (s *storageSuite) createVolume(c *gc.C, id string) string {
+	results, err := s.source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag(id),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	return results[0].Volume.VolumeId
+}
+
+func (s *storageSuite) TestListVolumes(c *gc.C) {
+	volumeId := s.createVolume(c, "0")
+	// Volumes created outside of the model are ignored.
+	_, err := s.env.client.createVolume(volumeCreateRequest{Name: "other", Region: "nyc3", SizeGigabytes: 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ids, err := s.source.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []string{volumeId})
+}
+
+func (s *storageSuite) TestDescribeVolumes(c *gc.C) {
+	volumeId := s.createVolume(c, "0")
+	results, err := s.source.DescribeVolumes([]string{volumeId, "missing"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId:   volumeId,
+		Size:       1024,
+		Persistent: true,
+	})
+	c.Assert(results[1].Error, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
+	volumeId := s.createVolume(c, "0")
+	results, err := s.source.DestroyVolumes([]string{volumeId, "missing"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil, nil})
+	c.Assert(s.api.volumes, gc.HasLen, 0)
+}
+
+func (s *storageSuite) TestAttachDetachVolumes(c *gc.C) {
+	volumeId := s.createVolume(c, "0")
+	s.api.actionStatus = ""
+	params := []storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: s.instanceId(),
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: volumeId,
+	}}
+
+	results, err := s.source.AttachVolumes(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment.Machine, gc.Equals, names.NewMachineTag("0"))
+	c.Assert(s.api.volumes[volumeId].DropletIDs, jc.DeepEquals, []int{s.dropletID})
+
+	// Attaching again is a no-op.
+	results, err = s.source.AttachVolumes(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(s.api.volumes[volumeId].DropletIDs, jc.DeepEquals, []int{s.dropletID})
+
+	errs, err := s.source.DetachVolumes(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+	c.Assert(s.api.volumes[volumeId].DropletIDs, gc.HasLen, 0)
+}
+
+func (s *storageSuite) TestAttachVolumesActionErrored(c *gc.C) {
+	volumeId := s.createVolume(c, "0")
+	s.api.actionStatus = actionErrored
+	results, err := s.source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{InstanceId: s.instanceId()},
+		VolumeId:         volumeId,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, `cannot attach volume: attach_volume action \d+ failed`)
+}
+
+func (s *storageSuite) TestAttachVolumesReadOnly(c *gc.C) {
+	results, err := s.source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{InstanceId: s.instanceId(), ReadOnly: true},
+		VolumeId:         "vol",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.Satisfies, errors.IsNotSupported)
+}
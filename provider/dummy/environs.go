@@ -230,6 +230,7 @@ type environState struct {
 	maxAddr         int // maximum allocated address last byte
 	insts           map[instance.Id]*dummyInstance
 	globalPorts     map[network.PortRange]bool
+	accessRules     []network.IngressRule
 	bootstrapped    bool
 	apiListener     net.Listener
 	apiServer       *apiserver.Server
@@ -751,6 +752,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 			// here is fine.
 			logger.Debugf("setting password for %q to %q", owner.Name(), icfg.Controller.MongoInfo.Password)
 			owner.SetPassword(icfg.Controller.MongoInfo.Password)
+			st.SetSecretsKey(testing.SecretsKey)
 
 			estate.apiStatePool = state.NewStatePool(st)
 
@@ -1359,6 +1361,31 @@ func (e *environ) Ports() (ports []network.PortRange, err error) {
 	return
 }
 
+// SetAccessRules is specified in the environs.AccessFirewaller
+// interface.
+func (e *environ) SetAccessRules(rules []network.IngressRule) error {
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	estate.accessRules = append([]network.IngressRule(nil), rules...)
+	return nil
+}
+
+// AccessRules returns the access rules most recently set on the given
+// dummy environ.
+func AccessRules(env environs.Environ) []network.IngressRule {
+	estate, err := env.(*environ).state()
+	if err != nil {
+		panic(err)
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	return estate.accessRules
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &dummy
 }
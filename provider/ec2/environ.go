@@ -56,6 +56,9 @@ var (
 	aliveInstanceStates = []string{"pending", "running"}
 )
 
+var _ environs.IngressFirewaller = (*environ)(nil)
+var _ environs.AccessFirewaller = (*environ)(nil)
+
 type environ struct {
 	name string
 
@@ -201,7 +204,56 @@ func (e *environ) ConstraintsValidator() (constraints.Validator, error) {
 		instTypeNames[i] = itype.Name
 	}
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
-	return validator, nil
+	return &zonesValidator{Validator: validator, env: e}, nil
+}
+
+// zonesValidator is a constraints.Validator which registers the region's
+// availability zones as the vocabulary of the zones constraint only when
+// it validates a value using that constraint, so that validating any
+// other constraints needs no call to EC2.
+type zonesValidator struct {
+	constraints.Validator
+	env        *environ
+	registered bool
+}
+
+// Validate is defined on constraints.Validator.
+func (v *zonesValidator) Validate(cons constraints.Value) ([]string, error) {
+	if err := v.registerZones(cons); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return v.Validator.Validate(cons)
+}
+
+// Merge is defined on constraints.Validator.
+func (v *zonesValidator) Merge(consFallback, cons constraints.Value) (constraints.Value, error) {
+	if err := v.registerZones(consFallback, cons); err != nil {
+		return constraints.Value{}, errors.Trace(err)
+	}
+	return v.Validator.Merge(consFallback, cons)
+}
+
+func (v *zonesValidator) registerZones(values ...constraints.Value) error {
+	if v.registered {
+		return nil
+	}
+	for _, cons := range values {
+		if !cons.HasZones() {
+			continue
+		}
+		zones, err := v.env.AvailabilityZones()
+		if err != nil {
+			return errors.Annotate(err, "cannot get availability zones")
+		}
+		zoneNames := make([]string, len(zones))
+		for i, zone := range zones {
+			zoneNames[i] = zone.Name()
+		}
+		v.Validator.RegisterVocabulary(constraints.Zones, zoneNames)
+		v.registered = true
+		return nil
+	}
+	return nil
 }
 
 func archMatches(arches []string, arch *string) bool {
@@ -360,8 +412,8 @@ const (
 )
 
 // DistributeInstances implements the state.InstanceDistributor policy.
-func (e *environ) DistributeInstances(candidates, distributionGroup []instance.Id) ([]instance.Id, error) {
-	return common.DistributeInstances(e, candidates, distributionGroup)
+func (e *environ) DistributeInstances(candidates, distributionGroup []instance.Id, limitZones []string) ([]instance.Id, error) {
+	return common.DistributeInstances(e, candidates, distributionGroup, limitZones)
 }
 
 var availabilityZoneAllocations = common.AvailabilityZoneAllocations
@@ -405,8 +457,8 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	}
 
 	// If no availability zone is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// the known zones (limited by any zones constraint) for optimal spread
+	// across the instance distribution group.
 	var zoneInstances []common.AvailabilityZoneInstances
 	if len(availabilityZones) == 0 {
 		var err error
@@ -421,6 +473,9 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		if err != nil {
 			return nil, err
 		}
+		if args.Constraints.HasZones() {
+			zoneInstances = common.FilterZoneInstances(zoneInstances, *args.Constraints.Zones)
+		}
 		for _, z := range zoneInstances {
 			availabilityZones = append(availabilityZones, z.ZoneName)
 		}
@@ -468,7 +523,11 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	} else {
 		apiPort = args.InstanceConfig.APIInfo.Ports()[0]
 	}
-	groups, err := e.setUpGroups(args.ControllerUUID, args.InstanceConfig.MachineId, apiPort)
+	accessRules, err := network.AccessRules(e.Config().SSHAllow(), args.APIAllow, apiPort)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	groups, err := e.setUpGroups(args.ControllerUUID, args.InstanceConfig.MachineId, accessRules)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot set up groups")
 	}
@@ -1119,37 +1178,46 @@ func (e *environ) allControllerManagedVolumes(controllerUUID string) ([]string,
 }
 
 func portsToIPPerms(ports []network.PortRange) []ec2.IPPerm {
-	ipPerms := make([]ec2.IPPerm, len(ports))
-	for i, p := range ports {
-		ipPerms[i] = ec2.IPPerm{
-			Protocol:  p.Protocol,
-			FromPort:  p.FromPort,
-			ToPort:    p.ToPort,
-			SourceIPs: []string{"0.0.0.0/0"},
+	return rulesToIPPerms(network.OpenIngressRules(ports))
+}
+
+// rulesToIPPerms returns an IP permission for each source CIDR of each
+// of the given rules, so that duplicate permissions can be authorized
+// individually.
+func rulesToIPPerms(rules []network.IngressRule) []ec2.IPPerm {
+	var ipPerms []ec2.IPPerm
+	for _, r := range rules {
+		for _, sourceCIDR := range r.SourceRanges() {
+			ipPerms = append(ipPerms, ec2.IPPerm{
+				Protocol:  r.Protocol,
+				FromPort:  r.FromPort,
+				ToPort:    r.ToPort,
+				SourceIPs: []string{sourceCIDR},
+			})
 		}
 	}
 	return ipPerms
 }
 
-func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+func (e *environ) openRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
-	// Give permissions for anyone to access the given ports.
+	// Give permissions for the rules' sources to access the given ports.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	ipPerms := portsToIPPerms(ports)
+	ipPerms := rulesToIPPerms(rules)
 	_, err = e.ec2().AuthorizeSecurityGroup(g, ipPerms)
 	if err != nil && ec2ErrCode(err) == "InvalidPermission.Duplicate" {
-		if len(ports) == 1 {
+		if len(ipPerms) == 1 {
 			return nil
 		}
-		// If there's more than one port and we get a duplicate error,
-		// then we go through authorizing each port individually,
-		// otherwise the ports that were *not* duplicates will have
-		// been ignored
+		// If there's more than one permission and we get a duplicate
+		// error, then we go through authorizing each permission
+		// individually, otherwise the permissions that were *not*
+		// duplicates will have been ignored
 		for i := range ipPerms {
 			_, err := e.ec2().AuthorizeSecurityGroup(g, ipPerms[i:i+1])
 			if err != nil && ec2ErrCode(err) != "InvalidPermission.Duplicate" {
@@ -1164,71 +1232,115 @@ func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error
 	return nil
 }
 
-func (e *environ) closePortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+func (e *environ) closeRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
-	// Revoke permissions for anyone to access the given ports.
+	// Revoke permissions for the rules' sources to access the given ports.
 	// Note that ec2 allows the revocation of permissions that aren't
 	// granted, so this is naturally idempotent.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	_, err = e.ec2().RevokeSecurityGroup(g, portsToIPPerms(ports))
+	_, err = e.ec2().RevokeSecurityGroup(g, rulesToIPPerms(rules))
 	if err != nil {
 		return fmt.Errorf("cannot close ports: %v", err)
 	}
 	return nil
 }
 
-func (e *environ) portsInGroup(name string) (ports []network.PortRange, err error) {
+func (e *environ) rulesInGroup(name string) (rules []network.IngressRule, err error) {
 	group, err := e.groupInfoByName(name)
 	if err != nil {
 		return nil, err
 	}
-	for _, p := range group.IPPerms {
-		if len(p.SourceIPs) != 1 {
-			logger.Errorf("expected exactly one IP permission, found: %v", p)
+	return ipPermsToRules(group.IPPerms)
+}
+
+// ipPermsToRules returns the ingress rules granted by the given IP
+// permissions. EC2 merges the source IPs of all the permissions for the
+// same ports, so a port open to all sources is reported as an open rule
+// separate from one for any specific sources it is also open to.
+func ipPermsToRules(perms []ec2.IPPerm) ([]network.IngressRule, error) {
+	var rules []network.IngressRule
+	for _, p := range perms {
+		if len(p.SourceIPs) == 0 {
+			logger.Errorf("expected at least one source IP in permission, found: %v", p)
 			continue
 		}
-		ports = append(ports, network.PortRange{
+		portRange := network.PortRange{
 			Protocol: p.Protocol,
 			FromPort: p.FromPort,
 			ToPort:   p.ToPort,
-		})
+		}
+		var sourceCIDRs []string
+		for _, sourceIP := range p.SourceIPs {
+			if sourceIP == network.AnyCIDR {
+				rules = append(rules, network.NewOpenIngressRule(portRange))
+				continue
+			}
+			sourceCIDRs = append(sourceCIDRs, sourceIP)
+		}
+		if len(sourceCIDRs) == 0 {
+			continue
+		}
+		rule, err := network.NewIngressRule(portRange, sourceCIDRs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
 	}
-	network.SortPortRanges(ports)
-	return ports, nil
+	network.SortIngressRules(rules)
+	return rules, nil
 }
 
 func (e *environ) OpenPorts(ports []network.PortRange) error {
+	return e.OpenIngressRules(network.OpenIngressRules(ports))
+}
+
+func (e *environ) ClosePorts(ports []network.PortRange) error {
+	return e.CloseIngressRules(network.OpenIngressRules(ports))
+}
+
+func (e *environ) Ports() ([]network.PortRange, error) {
+	rules, err := e.IngressRules()
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulePortRanges(rules), nil
+}
+
+// OpenIngressRules is part of the environs.IngressFirewaller interface.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for opening ports on model", e.Config().FirewallMode())
 	}
-	if err := e.openPortsInGroup(e.globalGroupName(), ports); err != nil {
+	if err := e.openRulesInGroup(e.globalGroupName(), rules); err != nil {
 		return errors.Trace(err)
 	}
-	logger.Infof("opened ports in global group: %v", ports)
+	logger.Infof("opened ports in global group: %v", rules)
 	return nil
 }
 
-func (e *environ) ClosePorts(ports []network.PortRange) error {
+// CloseIngressRules is part of the environs.IngressFirewaller interface.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for closing ports on model", e.Config().FirewallMode())
 	}
-	if err := e.closePortsInGroup(e.globalGroupName(), ports); err != nil {
+	if err := e.closeRulesInGroup(e.globalGroupName(), rules); err != nil {
 		return errors.Trace(err)
 	}
-	logger.Infof("closed ports in global group: %v", ports)
+	logger.Infof("closed ports in global group: %v", rules)
 	return nil
 }
 
-func (e *environ) Ports() ([]network.PortRange, error) {
+// IngressRules is part of the environs.IngressFirewaller interface.
+func (e *environ) IngressRules() ([]network.IngressRule, error) {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return nil, errors.Errorf("invalid firewall mode %q for retrieving ports from model", e.Config().FirewallMode())
 	}
-	return e.portsInGroup(e.globalGroupName())
+	return e.rulesInGroup(e.globalGroupName())
 }
 
 func (*environ) Provider() environs.EnvironProvider {
@@ -1457,34 +1569,13 @@ func (e *environ) jujuGroupName() string {
 // other instances that might be running on the same EC2 account.  In
 // addition, a specific machine security group is created for each
 // machine, so that its firewall rules can be configured per machine.
-func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]ec2.SecurityGroup, error) {
+//
+// The SSH and API ports are opened in the global group, to the sources
+// given by accessRules.
+func (e *environ) setUpGroups(controllerUUID, machineId string, accessRules []network.IngressRule) ([]ec2.SecurityGroup, error) {
 
 	// Ensure there's a global group for Juju-related traffic.
-	jujuGroup, err := e.ensureGroup(controllerUUID, e.jujuGroupName(),
-		[]ec2.IPPerm{{
-			Protocol:  "tcp",
-			FromPort:  22,
-			ToPort:    22,
-			SourceIPs: []string{"0.0.0.0/0"},
-		}, {
-			Protocol:  "tcp",
-			FromPort:  apiPort,
-			ToPort:    apiPort,
-			SourceIPs: []string{"0.0.0.0/0"},
-		}, {
-			Protocol: "tcp",
-			FromPort: 0,
-			ToPort:   65535,
-		}, {
-			Protocol: "udp",
-			FromPort: 0,
-			ToPort:   65535,
-		}, {
-			Protocol: "icmp",
-			FromPort: -1,
-			ToPort:   -1,
-		}},
-	)
+	jujuGroup, err := e.ensureGroup(controllerUUID, e.jujuGroupName(), jujuGroupPerms(accessRules, e.usesFan()))
 	if err != nil {
 		return nil, err
 	}
@@ -1502,6 +1593,60 @@ func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]
 	return []ec2.SecurityGroup{jujuGroup, machineGroup}, nil
 }
 
+// ipipProtocol is the IP protocol number of IP-in-IP encapsulation,
+// which the Fan uses to carry overlay traffic between hosts.
+const ipipProtocol = "4"
+
+// jujuGroupPerms returns the permissions of the global group for
+// Juju-related traffic: the given SSH and API access rules, and
+// unrestricted traffic between the machines in the group. If fan is
+// true, the Fan's encapsulated overlay traffic between the machines
+// is also allowed.
+func jujuGroupPerms(accessRules []network.IngressRule, fan bool) []ec2.IPPerm {
+	perms := append(rulesToIPPerms(accessRules), []ec2.IPPerm{{
+		Protocol: "tcp",
+		FromPort: 0,
+		ToPort:   65535,
+	}, {
+		Protocol: "udp",
+		FromPort: 0,
+		ToPort:   65535,
+	}, {
+		Protocol: "icmp",
+		FromPort: -1,
+		ToPort:   -1,
+	}}...)
+	if fan {
+		// Ports do not apply to IP-in-IP, and are reported as zero.
+		perms = append(perms, ec2.IPPerm{Protocol: ipipProtocol})
+	}
+	return perms
+}
+
+// usesFan reports whether the model's containers are placed on the Fan.
+func (e *environ) usesFan() bool {
+	return e.Config().ContainerNetworkingMethod() == config.ContainerNetworkingFan
+}
+
+// SetAccessRules is specified in the environs.AccessFirewaller
+// interface. The rules are applied to the global group for Juju-related
+// traffic, which every machine in the model belongs to.
+func (e *environ) SetAccessRules(rules []network.IngressRule) error {
+	name := e.jujuGroupName()
+	resp, err := e.securityGroupsByNameOrID(name)
+	if err != nil {
+		return errors.Annotatef(err, "fetching security group %q", name)
+	}
+	if len(resp.Groups) == 0 {
+		// No machines have been started yet. The rules will be
+		// applied when the group is created.
+		return nil
+	}
+	info := resp.Groups[0]
+	have := newPermSetForGroup(info.IPPerms, info.SecurityGroup)
+	return e.updateGroupPerms(info.SecurityGroup, have, jujuGroupPerms(rules, e.usesFan()))
+}
+
 // zeroGroup holds the zero security group.
 var zeroGroup ec2.SecurityGroup
 
@@ -1577,6 +1722,21 @@ func (e *environ) ensureGroup(controllerUUID, name string, perms []ec2.IPPerm) (
 		have = newPermSetForGroup(info.IPPerms, g)
 	}
 
+	if err := e.updateGroupPerms(g, have, perms); err != nil {
+		return zeroGroup, err
+	}
+	return g, nil
+}
+
+// updateGroupPerms sets the permissions of the group g, which
+// currently has the permissions in have, to perms.
+func (e *environ) updateGroupPerms(g ec2.SecurityGroup, have permSet, perms []ec2.IPPerm) error {
+	inVPCLogSuffix := ""
+	if chosenVPCID := e.ecfg().vpcID(); isVPCIDSet(chosenVPCID) {
+		inVPCLogSuffix = fmt.Sprintf(" (in VPC %q)", chosenVPCID)
+	}
+
+	ec2inst := e.ec2()
 	want := newPermSetForGroup(perms, g)
 	revoke := make(permSet)
 	for p := range have {
@@ -1587,8 +1747,7 @@ func (e *environ) ensureGroup(controllerUUID, name string, perms []ec2.IPPerm) (
 	if len(revoke) > 0 {
 		_, err := ec2inst.RevokeSecurityGroup(g, revoke.ipPerms())
 		if err != nil {
-			err = errors.Annotatef(err, "revoking security group %q%s", g.Id, inVPCLogSuffix)
-			return zeroGroup, err
+			return errors.Annotatef(err, "revoking security group %q%s", g.Id, inVPCLogSuffix)
 		}
 	}
 
@@ -1601,11 +1760,10 @@ func (e *environ) ensureGroup(controllerUUID, name string, perms []ec2.IPPerm) (
 	if len(add) > 0 {
 		_, err := ec2inst.AuthorizeSecurityGroup(g, add.ipPerms())
 		if err != nil {
-			err = errors.Annotatef(err, "authorizing security group %q%s", g.Id, inVPCLogSuffix)
-			return zeroGroup, err
+			return errors.Annotatef(err, "authorizing security group %q%s", g.Id, inVPCLogSuffix)
 		}
 	}
-	return g, nil
+	return nil
 }
 
 // permKey represents a permission for a group or an ip address range to access
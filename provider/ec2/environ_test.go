@@ -4,6 +4,7 @@
 package ec2
 
 import (
+	jc "github.com/juju/testing/checkers"
 	amzec2 "gopkg.in/amz.v3/ec2"
 	gc "gopkg.in/check.v1"
 
@@ -165,3 +166,63 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 		c.Assert(ipperms, gc.DeepEquals, t.expected)
 	}
 }
+
+func (*Suite) TestIPPermsToRules(c *gc.C) {
+	rules, err := ipPermsToRules([]amzec2.IPPerm{{
+		Protocol:  "tcp",
+		FromPort:  80,
+		ToPort:    80,
+		SourceIPs: []string{"10.0.0.0/8", "0.0.0.0/0", "192.168.1.0/24"},
+	}, {
+		Protocol:  "udp",
+		FromPort:  53,
+		ToPort:    53,
+		SourceIPs: []string{"0.0.0.0/0"},
+	}, {
+		Protocol:  "tcp",
+		FromPort:  22,
+		ToPort:    22,
+		SourceIPs: []string{"10.0.0.0/8"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{22, 22, "tcp"}, "10.0.0.0/8"),
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}),
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8", "192.168.1.0/24"),
+		network.MustNewIngressRule(network.PortRange{53, 53, "udp"}),
+	})
+}
+
+func (*Suite) TestRulesToIPPerms(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8", "192.168.1.0/24"),
+		network.MustNewIngressRule(network.PortRange{53, 53, "udp"}),
+	}
+	c.Assert(rulesToIPPerms(rules), gc.DeepEquals, []amzec2.IPPerm{{
+		Protocol:  "tcp",
+		FromPort:  80,
+		ToPort:    80,
+		SourceIPs: []string{"10.0.0.0/8"},
+	}, {
+		Protocol:  "tcp",
+		FromPort:  80,
+		ToPort:    80,
+		SourceIPs: []string{"192.168.1.0/24"},
+	}, {
+		Protocol:  "udp",
+		FromPort:  53,
+		ToPort:    53,
+		SourceIPs: []string{"0.0.0.0/0"},
+	}})
+}
+
+func (*Suite) TestJujuGroupPermsFan(c *gc.C) {
+	withoutFan := jujuGroupPerms(nil, false)
+	c.Assert(withoutFan, gc.HasLen, 3)
+	for _, perm := range withoutFan {
+		c.Assert(perm.Protocol, gc.Not(gc.Equals), "4")
+	}
+	withFan := jujuGroupPerms(nil, true)
+	c.Assert(withFan[:3], gc.DeepEquals, withoutFan)
+	c.Assert(withFan[3:], gc.DeepEquals, []amzec2.IPPerm{{Protocol: "4"}})
+}
@@ -600,6 +600,36 @@ func (t *localServerSuite) TestInstanceSecurityGroupsWitheInstanceStatusFilter(c
 	c.Assert(groupsFilteredForTerminatedInstances, gc.HasLen, 0)
 }
 
+func (t *localServerSuite) TestSetAccessRules(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	groupNames := amzec2.SecurityGroupNames(ec2.JujuGroupName(env))
+	sourceIPs := func(port int) []string {
+		resp, err := t.srv.client.SecurityGroups(groupNames, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(resp.Groups, gc.HasLen, 1)
+		var ips []string
+		for _, perm := range resp.Groups[0].IPPerms {
+			if perm.FromPort == port {
+				ips = append(ips, perm.SourceIPs...)
+			}
+		}
+		sort.Strings(ips)
+		return ips
+	}
+	apiPort := coretesting.FakeControllerConfig().APIPort()
+	c.Assert(sourceIPs(22), jc.DeepEquals, []string{"0.0.0.0/0"})
+	c.Assert(sourceIPs(apiPort), jc.DeepEquals, []string{"0.0.0.0/0"})
+
+	rules, err := network.AccessRules(
+		[]string{"10.0.0.0/8", "192.168.0.0/16"}, []string{"172.16.0.0/12"}, apiPort,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.(environs.AccessFirewaller).SetAccessRules(rules)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sourceIPs(22), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+	c.Assert(sourceIPs(apiPort), jc.DeepEquals, []string{"172.16.0.0/12"})
+}
+
 func (t *localServerSuite) TestDestroyControllerModelDeleteSecurityGroupInsistentlyError(c *gc.C) {
 	env := t.prepareAndBootstrap(c)
 	msg := "destroy security group error"
@@ -1167,6 +1197,36 @@ func (t *localServerSuite) TestConstraintsValidatorVocab(c *gc.C) {
 	cons = constraints.MustParse("instance-type=foo")
 	_, err = validator.Validate(cons)
 	c.Assert(err, gc.ErrorMatches, "invalid constraint value: instance-type=foo\nvalid values are:.*")
+	cons = constraints.MustParse("zones=test-available,test-missing")
+	_, err = validator.Validate(cons)
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: zones=test-missing\nvalid values are:.*")
+}
+
+func (t *localServerSuite) TestConstraintsValidatorFetchesZonesOnlyForZones(c *gc.C) {
+	var calls int
+	t.PatchValue(ec2.EC2AvailabilityZones, func(e *amzec2.EC2, f *amzec2.Filter) (*amzec2.AvailabilityZonesResp, error) {
+		calls++
+		return &amzec2.AvailabilityZonesResp{
+			Zones: []amzec2.AvailabilityZoneInfo{{
+				AvailabilityZone: amzec2.AvailabilityZone{Name: "az1"},
+				State:            "available",
+			}},
+		}, nil
+	})
+	env := t.Prepare(c)
+	validator, err := env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("arch=amd64 mem=1G"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Merge(constraints.MustParse("mem=1G"), constraints.MustParse("arch=amd64"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, gc.Equals, 0)
+
+	_, err = validator.Validate(constraints.MustParse("zones=az1"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("zones=az2"))
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: zones=az2\nvalid values are: \\[az1\\]")
+	c.Assert(calls, gc.Equals, 1)
 }
 
 func (t *localServerSuite) TestConstraintsMerge(c *gc.C) {
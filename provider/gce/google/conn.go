@@ -48,6 +48,10 @@ type rawConnectionWrapper interface {
 	// does not exist then this is a noop. The call blocks until the
 	// firewall is added or the request fails.
 	RemoveFirewall(projectID, name string) error
+	// ListFirewalls sends an API request to GCE for the information
+	// about all firewalls whose names match the provided pattern. If
+	// none match then the list is empty.
+	ListFirewalls(projectID, pattern string) ([]*compute.Firewall, error)
 	// ListAvailabilityZones returns the list of availability zones for a given
 	// GCE region. If none are found the the list is empty. Any failure in
 	// the low-level request is returned as an error.
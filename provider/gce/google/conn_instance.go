@@ -103,10 +103,10 @@ func (gce *Connection) removeInstance(id, zone string) error {
 
 	fwname := id
 	err = gce.raw.RemoveFirewall(gce.projectID, fwname)
-	if errors.IsNotFound(err) {
-		return nil
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
 	}
-	if err != nil {
+	if err := gce.removeSourceFirewalls(fwname); err != nil {
 		return errors.Trace(err)
 	}
 	return nil
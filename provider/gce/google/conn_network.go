@@ -44,9 +44,86 @@ func (gce Connection) Ports(fwname string) ([]network.PortRange, error) {
 // opened or the request fails.
 func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error {
 	// TODO(ericsnow) Short-circuit if ports is empty.
+	return gce.openPortsInFirewall(fwname, fwname, nil, ports)
+}
+
+// ClosePorts sends a request to the GCE API to close the provided port
+// ranges on the named firewall. If the firewall does not exist nothing
+// happens. If the firewall is left with no ports then it is removed.
+// Otherwise it will be left with just the open ports it has that do not
+// match the provided port ranges. The call blocks until the ports are
+// closed or the request fails.
+func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) error {
+	return gce.closePortsInFirewall(fwname, fwname, nil, ports)
+}
+
+// IngressRules builds a list of all ingress rules open for the given
+// firewall name, including those restricted to specific source CIDRs,
+// and returns it. If no firewalls exist then the list will be empty and
+// no error is returned.
+func (gce Connection) IngressRules(fwname string) ([]network.IngressRule, error) {
+	firewalls, err := gce.raw.ListFirewalls(gce.projectID, firewallNamePattern(fwname))
+	if err != nil {
+		return nil, errors.Annotate(err, "while getting ingress rules from GCE")
+	}
 
+	var rules []network.IngressRule
+	for _, firewall := range firewalls {
+		for _, allowed := range firewall.Allowed {
+			for _, portRangeStr := range allowed.Ports {
+				portRange, err := network.ParsePortRange(portRangeStr)
+				if err != nil {
+					return nil, errors.Annotate(err, "bad ports from GCE")
+				}
+				portRange.Protocol = allowed.IPProtocol
+				rule, err := network.NewIngressRule(portRange, firewall.SourceRanges...)
+				if err != nil {
+					return nil, errors.Annotate(err, "bad source ranges from GCE")
+				}
+				rules = append(rules, rule)
+			}
+		}
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// OpenIngressRules sends requests to the GCE API to open the provided
+// ingress rules for the given firewall name. Rules open to any source
+// are added to the named firewall itself; rules restricted to a set of
+// source CIDRs are added to a separate firewall for that set, targeting
+// the same instances. The call blocks until the rules are opened or a
+// request fails.
+func (gce Connection) OpenIngressRules(fwname string, rules ...network.IngressRule) error {
+	for _, group := range groupRulesBySource(fwname, rules) {
+		err := gce.openPortsInFirewall(group.name, fwname, group.sourceCIDRs, group.ports)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// CloseIngressRules sends requests to the GCE API to close the provided
+// ingress rules for the given firewall name. Firewalls left with no
+// ports are removed. The call blocks until the rules are closed or a
+// request fails.
+func (gce Connection) CloseIngressRules(fwname string, rules ...network.IngressRule) error {
+	for _, group := range groupRulesBySource(fwname, rules) {
+		err := gce.closePortsInFirewall(group.name, fwname, group.sourceCIDRs, group.ports)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// openPortsInFirewall opens the provided port ranges on the named
+// firewall, creating it if necessary with the given target tag and
+// source CIDRs.
+func (gce Connection) openPortsInFirewall(name, target string, sourceCIDRs []string, ports []network.PortRange) error {
 	// Compose the full set of open ports.
-	currentPorts, err := gce.Ports(fwname)
+	currentPorts, err := gce.Ports(name)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -59,7 +136,7 @@ func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error
 	// Send the request, depending on the current ports.
 	if currentPortsSet.IsEmpty() {
 		// Create a new firewall.
-		firewall := firewallSpec(fwname, inputPortsSet)
+		firewall := sourceFirewallSpec(name, target, sourceCIDRs, inputPortsSet)
 		if err := gce.raw.AddFirewall(gce.projectID, firewall); err != nil {
 			return errors.Annotatef(err, "opening port(s) %+v", ports)
 		}
@@ -68,22 +145,18 @@ func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error
 
 	// Update an existing firewall.
 	newPortsSet := currentPortsSet.Union(inputPortsSet)
-	firewall := firewallSpec(fwname, newPortsSet)
-	if err := gce.raw.UpdateFirewall(gce.projectID, fwname, firewall); err != nil {
+	firewall := sourceFirewallSpec(name, target, sourceCIDRs, newPortsSet)
+	if err := gce.raw.UpdateFirewall(gce.projectID, name, firewall); err != nil {
 		return errors.Annotatef(err, "opening port(s) %+v", ports)
 	}
 	return nil
 }
 
-// ClosePorts sends a request to the GCE API to close the provided port
-// ranges on the named firewall. If the firewall does not exist nothing
-// happens. If the firewall is left with no ports then it is removed.
-// Otherwise it will be left with just the open ports it has that do not
-// match the provided port ranges. The call blocks until the ports are
-// closed or the request fails.
-func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) error {
+// closePortsInFirewall closes the provided port ranges on the named
+// firewall, removing it if it is left with no open ports.
+func (gce Connection) closePortsInFirewall(name, target string, sourceCIDRs []string, ports []network.PortRange) error {
 	// Compose the full set of open ports.
-	currentPorts, err := gce.Ports(fwname)
+	currentPorts, err := gce.Ports(name)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -98,16 +171,32 @@ func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) erro
 	if newPortsSet.IsEmpty() {
 		// Delete a firewall.
 		// TODO(ericsnow) Handle case where firewall does not exist.
-		if err := gce.raw.RemoveFirewall(gce.projectID, fwname); err != nil {
+		if err := gce.raw.RemoveFirewall(gce.projectID, name); err != nil {
 			return errors.Annotatef(err, "closing port(s) %+v", ports)
 		}
 		return nil
 	}
 
 	// Update an existing firewall.
-	firewall := firewallSpec(fwname, newPortsSet)
-	if err := gce.raw.UpdateFirewall(gce.projectID, fwname, firewall); err != nil {
+	firewall := sourceFirewallSpec(name, target, sourceCIDRs, newPortsSet)
+	if err := gce.raw.UpdateFirewall(gce.projectID, name, firewall); err != nil {
 		return errors.Annotatef(err, "closing port(s) %+v", ports)
 	}
 	return nil
 }
+
+// removeSourceFirewalls removes all firewalls restricting the given
+// firewall name to specific source CIDRs.
+func (gce Connection) removeSourceFirewalls(fwname string) error {
+	firewalls, err := gce.raw.ListFirewalls(gce.projectID, sourceFirewallNamePattern(fwname))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, firewall := range firewalls {
+		err := gce.raw.RemoveFirewall(gce.projectID, firewall.Name)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -173,3 +173,56 @@ func (s *connSuite) TestConnectionClosePortsUpdate(c *gc.C) {
 		}},
 	})
 }
+
+func (s *connSuite) TestConnectionIngressRules(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:         "spam",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80-81"},
+		}},
+	}, {
+		Name:         "spam-93997fe8",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}}
+
+	rules, err := s.Conn.IngressRules("spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 81, "tcp"}),
+		network.MustNewIngressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+	})
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "spam(-[0-9a-f]{8})?")
+}
+
+func (s *connSuite) TestConnectionOpenIngressRulesRestricted(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("spam")
+
+	rule := network.MustNewIngressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8")
+	err := s.Conn.OpenIngressRules("spam", rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewall")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "spam-93997fe8")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddFirewall")
+	c.Check(s.FakeConn.Calls[1].Firewall, jc.DeepEquals, &compute.Firewall{
+		Name:         "spam-93997fe8",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	})
+}
@@ -4,6 +4,11 @@
 package google
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
 	"google.golang.org/api/compute/v1"
 
 	"github.com/juju/juju/network"
@@ -78,6 +83,73 @@ func firewallSpec(name string, ps network.PortSet) *compute.Firewall {
 	return &firewall
 }
 
+// sourceFirewallSpec returns a compute.Firewall for the provided name,
+// opening the port range set to the given source CIDRs on instances
+// tagged with target. If no source CIDRs are given the ports are open
+// to any source.
+func sourceFirewallSpec(name, target string, sourceCIDRs []string, ps network.PortSet) *compute.Firewall {
+	firewall := firewallSpec(name, ps)
+	firewall.TargetTags = []string{target}
+	if len(sourceCIDRs) > 0 {
+		firewall.SourceRanges = sourceCIDRs
+	}
+	return firewall
+}
+
+// sourceFirewallName returns the name of the firewall holding the
+// rules for the given firewall name that are restricted to the given
+// source CIDRs. Rules open to any source use the firewall name itself.
+func sourceFirewallName(fwname string, sourceCIDRs []string) string {
+	if len(sourceCIDRs) == 0 {
+		return fwname
+	}
+	hash := sha256.Sum256([]byte(strings.Join(sourceCIDRs, ",")))
+	return fmt.Sprintf("%s-%x", fwname, hash[:4])
+}
+
+// firewallNamePattern returns a pattern matching the given firewall
+// name and all of its source-restricted firewalls.
+func firewallNamePattern(fwname string) string {
+	return fwname + "(-[0-9a-f]{8})?"
+}
+
+// sourceFirewallNamePattern returns a pattern matching only the
+// source-restricted firewalls of the given firewall name.
+func sourceFirewallNamePattern(fwname string) string {
+	return fwname + "-[0-9a-f]{8}"
+}
+
+// ruleGroup holds the port ranges of a set of ingress rules sharing
+// the same source CIDRs, and the firewall that holds them.
+type ruleGroup struct {
+	name        string
+	sourceCIDRs []string
+	ports       []network.PortRange
+}
+
+// groupRulesBySource groups the given ingress rules by source CIDRs,
+// ordered by firewall name.
+func groupRulesBySource(fwname string, rules []network.IngressRule) []*ruleGroup {
+	groups := make(map[string]*ruleGroup)
+	var names []string
+	for _, rule := range rules {
+		name := sourceFirewallName(fwname, rule.SourceCIDRs)
+		group, ok := groups[name]
+		if !ok {
+			group = &ruleGroup{name: name, sourceCIDRs: rule.SourceCIDRs}
+			groups[name] = group
+			names = append(names, name)
+		}
+		group.ports = append(group.ports, rule.PortRange)
+	}
+	sort.Strings(names)
+	result := make([]*ruleGroup, len(names))
+	for i, name := range names {
+		result[i] = groups[name]
+	}
+	return result
+}
+
 func extractAddresses(interfaces ...*compute.NetworkInterface) []network.Address {
 	var addresses []network.Address
 
@@ -162,6 +162,25 @@ func (rc *rawConn) RemoveFirewall(projectID, name string) error {
 	return errors.Trace(convertRawAPIError(err))
 }
 
+func (rc *rawConn) ListFirewalls(projectID, pattern string) ([]*compute.Firewall, error) {
+	call := rc.Firewalls.List(projectID)
+	call = call.Filter("name eq " + pattern)
+
+	var results []*compute.Firewall
+	for {
+		firewallList, err := call.Do()
+		if err != nil {
+			return nil, errors.Annotate(err, "while listing firewalls from GCE")
+		}
+		results = append(results, firewallList.Items...)
+		if firewallList.NextPageToken == "" {
+			break
+		}
+		call = call.PageToken(firewallList.NextPageToken)
+	}
+	return results, nil
+}
+
 func (rc *rawConn) ListAvailabilityZones(projectID, region string) ([]*compute.Zone, error) {
 	call := rc.Zones.List(projectID)
 	if region != "" {
@@ -167,6 +167,7 @@ type fakeConn struct {
 	Instance      *compute.Instance
 	Instances     []*compute.Instance
 	Firewall      *compute.Firewall
+	Firewalls     []*compute.Firewall
 	Zones         []*compute.Zone
 	Err           error
 	FailOnCall    int
@@ -315,6 +316,21 @@ func (rc *fakeConn) RemoveFirewall(projectID, name string) error {
 	return err
 }
 
+func (rc *fakeConn) ListFirewalls(projectID, pattern string) ([]*compute.Firewall, error) {
+	call := fakeCall{
+		FuncName:  "ListFirewalls",
+		ProjectID: projectID,
+		Name:      pattern,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.Firewalls, err
+}
+
 func (rc *fakeConn) ListAvailabilityZones(projectID, region string) ([]*compute.Zone, error) {
 	call := fakeCall{
 		FuncName:  "ListAvailabilityZones",
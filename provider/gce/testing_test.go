@@ -101,6 +101,8 @@ type BaseSuiteUnpatched struct {
 var _ environs.Environ = (*environ)(nil)
 var _ simplestreams.HasRegion = (*environ)(nil)
 var _ instance.Instance = (*environInstance)(nil)
+var _ environs.IngressFirewaller = (*environ)(nil)
+var _ instance.IngressFirewaller = (*environInstance)(nil)
 
 func (s *BaseSuiteUnpatched) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
@@ -449,6 +451,7 @@ type fakeConnCall struct {
 	InstanceSpec google.InstanceSpec
 	FirewallName string
 	PortRanges   []network.PortRange
+	IngressRules []network.IngressRule
 	Region       string
 	Disks        []google.DiskSpec
 	VolumeName   string
@@ -462,6 +465,7 @@ type fakeConn struct {
 	Inst       *google.Instance
 	Insts      []google.Instance
 	PortRanges []network.PortRange
+	Rules      []network.IngressRule
 	Zones      []google.AvailabilityZone
 
 	GoogleDisks   []*google.Disk
@@ -549,6 +553,32 @@ func (fc *fakeConn) ClosePorts(fwname string, ports ...network.PortRange) error
 	return fc.err()
 }
 
+func (fc *fakeConn) IngressRules(fwname string) ([]network.IngressRule, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "IngressRules",
+		FirewallName: fwname,
+	})
+	return fc.Rules, fc.err()
+}
+
+func (fc *fakeConn) OpenIngressRules(fwname string, rules ...network.IngressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "OpenIngressRules",
+		FirewallName: fwname,
+		IngressRules: rules,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) CloseIngressRules(fwname string, rules ...network.IngressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "CloseIngressRules",
+		FirewallName: fwname,
+		IngressRules: rules,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) AvailabilityZones(region string) ([]google.AvailabilityZone, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "AvailabilityZones",
@@ -7,6 +7,8 @@ package lxd
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -14,6 +16,7 @@ import (
 
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/instance"
@@ -27,9 +30,34 @@ func isController(icfg *instancecfg.InstanceConfig) bool {
 	return multiwatcher.AnyJobNeedsState(icfg.Jobs...)
 }
 
-// MaintainInstance is specified in the InstanceBroker interface.
-func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
-	return nil
+// MaintainInstance is specified in the InstanceBroker interface. It
+// ensures that the instance has exactly the charm LXD profiles given,
+// as required after its units' charms are upgraded.
+func (env *environ) MaintainInstance(args environs.StartInstanceParams) error {
+	hostname, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	charmProfiles, err := env.writeCharmProfiles(args.CharmLXDProfiles)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current, err := env.raw.InstanceProfiles(hostname)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var profiles []string
+	for _, name := range current {
+		if !lxdprofile.IsCharmProfile(env.ecfg.UUID(), name) {
+			profiles = append(profiles, name)
+		}
+	}
+	profiles = append(profiles, charmProfiles...)
+	if reflect.DeepEqual(profiles, current) {
+		return nil
+	}
+	logger.Infof("applying profiles %v to instance %q", profiles, hostname)
+	return errors.Trace(env.raw.SetInstanceProfiles(hostname, profiles))
 }
 
 // StartInstance implements environs.InstanceBroker.
@@ -164,6 +192,11 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams) (*lxdclien
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+
+	charmProfiles, err := env.writeCharmProfiles(args.CharmLXDProfiles)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	//tags := []string{
 	//	env.globalFirewallName(),
 	//	machineID,
@@ -179,14 +212,14 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams) (*lxdclien
 		//Disks:             getDisks(spec, args.Constraints),
 		//NetworkInterfaces: []string{"ExternalNAT"},
 		Metadata: metadata,
-		Profiles: []string{
+		Profiles: append([]string{
 			//TODO(wwitzel3) allow the user to specify lxc profiles to apply. This allows the
 			// user to setup any custom devices order config settings for their environment.
 			// Also we must ensure that a device with the parent: lxcbr0 exists in at least
 			// one of the profiles.
 			"default",
 			env.profileName(),
-		},
+		}, charmProfiles...),
 		//Tags:              tags,
 		// Network is omitted (left empty).
 	}
@@ -205,6 +238,38 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams) (*lxdclien
 	return inst, nil
 }
 
+// writeCharmProfiles ensures that the given charm LXD profiles exist,
+// and returns their names in a stable order.
+func (env *environ) writeCharmProfiles(profiles map[string]*lxdprofile.Profile) ([]string, error) {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		if !lxdprofile.IsCharmProfile(env.ecfg.UUID(), name) {
+			return nil, errors.NotValidf("charm LXD profile name %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		hasProfile, err := env.raw.HasProfile(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if hasProfile {
+			continue
+		}
+		profile := profiles[name]
+		devices := make(lxdclient.Devices, len(profile.Devices))
+		for device, props := range profile.Devices {
+			devices[device] = lxdclient.Device(props)
+		}
+		logger.Infof("creating LXD profile %q", name)
+		if err := env.raw.CreateProfileWithDevices(name, profile.Config, devices); err != nil {
+			return nil, errors.Annotatef(err, "cannot create LXD profile %q", name)
+		}
+	}
+	return names, nil
+}
+
 // getMetadata builds the raw "user-defined" metadata for the new
 // instance (relative to the provided args) and returns it.
 func getMetadata(args environs.StartInstanceParams) (map[string]string, error) {
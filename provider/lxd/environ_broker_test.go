@@ -6,12 +6,17 @@
 package lxd_test
 
 import (
+	"fmt"
+
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/lxd"
+	"github.com/juju/juju/tools/lxdclient"
 )
 
 type environBrokerSuite struct {
@@ -47,6 +52,80 @@ func (s *environBrokerSuite) TestStartInstanceNoTools(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "no matching tools available")
 }
 
+func (s *environBrokerSuite) TestStartInstanceWithCharmLXDProfiles(c *gc.C) {
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	profile := &lxdprofile.Profile{
+		Config: map[string]string{"security.nesting": "true"},
+		Devices: map[string]map[string]string{
+			"tun": {"type": "unix-char", "path": "/dev/net/tun"},
+		},
+	}
+	name := lxdprofile.Name(s.Config.UUID(), "mysql", 2, profile)
+	args := s.StartInstArgs
+	args.CharmLXDProfiles = map[string]*lxdprofile.Profile{name: profile}
+	_, err := s.Env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var profileArgs []interface{}
+	var spec lxdclient.InstanceSpec
+	for _, call := range s.Stub.Calls() {
+		switch call.FuncName {
+		case "CreateProfileWithDevices":
+			profileArgs = call.Args
+		case "AddInstance":
+			spec = call.Args[0].(lxdclient.InstanceSpec)
+		}
+	}
+	c.Assert(profileArgs, jc.DeepEquals, []interface{}{
+		name,
+		map[string]string{"security.nesting": "true"},
+		lxdclient.Devices{"tun": {"type": "unix-char", "path": "/dev/net/tun"}},
+	})
+	c.Assert(spec.Profiles, jc.DeepEquals, []string{"default", "juju-testenv", name})
+}
+
+func (s *environBrokerSuite) TestStartInstanceRejectsForeignCharmLXDProfile(c *gc.C) {
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	profile := &lxdprofile.Profile{Config: map[string]string{"security.nesting": "true"}}
+	otherModel := lxdprofile.Name("deadbeef-0bad-400d-8000-4b1d0d06f00e", "mysql", 2, profile)
+	for _, name := range []string{"default", otherModel} {
+		args := s.StartInstArgs
+		args.CharmLXDProfiles = map[string]*lxdprofile.Profile{name: profile}
+		_, err := s.Env.StartInstance(args)
+		c.Check(err, gc.ErrorMatches, fmt.Sprintf(`charm LXD profile name %q not valid`, name))
+	}
+}
+
+func (s *environBrokerSuite) TestMaintainInstanceReplacesCharmProfiles(c *gc.C) {
+	profile := &lxdprofile.Profile{Config: map[string]string{"security.nesting": "true"}}
+	oldName := lxdprofile.Name(s.Config.UUID(), "mysql", 2, profile)
+	newName := lxdprofile.Name(s.Config.UUID(), "mysql", 3, profile)
+	s.Client.Profiles = []string{"default", "juju-testenv", oldName}
+
+	args := s.StartInstArgs
+	args.CharmLXDProfiles = map[string]*lxdprofile.Profile{newName: profile}
+	err := s.Env.MaintainInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	namespace, err := instance.NewNamespace(s.Config.UUID())
+	c.Assert(err, jc.ErrorIsNil)
+	hostname, err := namespace.Hostname(args.InstanceConfig.MachineId)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCallNames(c, "HasProfile", "CreateProfileWithDevices", "InstanceProfiles", "SetInstanceProfiles")
+	s.Stub.CheckCall(c, 3, "SetInstanceProfiles", hostname, []string{"default", "juju-testenv", newName})
+}
+
+func (s *environBrokerSuite) TestMaintainInstanceUnchanged(c *gc.C) {
+	s.Client.Profiles = []string{"default", "juju-testenv"}
+
+	err := s.Env.MaintainInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCallNames(c, "InstanceProfiles")
+}
+
 func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
 	err := s.Env.StopInstances(s.Instance.Id())
 	c.Assert(err, jc.ErrorIsNil)
@@ -111,6 +111,7 @@ func (s *environPolSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"cpu-cores=2",
 		"cpu-power=250",
 		"virt-type=kvm",
+		"zones=az1",
 	}, " "))
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
@@ -121,6 +122,7 @@ func (s *environPolSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"cpu-cores",
 		"cpu-power",
 		"virt-type",
+		"zones",
 	}
 	c.Check(unsupported, jc.SameContents, expected)
 }
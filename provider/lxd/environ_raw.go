@@ -25,10 +25,13 @@ type lxdInstances interface {
 	AddInstance(lxdclient.InstanceSpec) (*lxdclient.Instance, error)
 	RemoveInstances(string, ...string) error
 	Addresses(string) ([]network.Address, error)
+	InstanceProfiles(string) ([]string, error)
+	SetInstanceProfiles(string, []string) error
 }
 
 type lxdProfiles interface {
 	CreateProfile(string, map[string]string) error
+	CreateProfileWithDevices(string, map[string]string, lxdclient.Devices) error
 	HasProfile(string) (bool, error)
 }
 
@@ -310,6 +310,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 	// Patch out all expensive external deps.
 	s.Env.raw = &rawProvider{
 		lxdInstances: s.Client,
+		lxdProfiles:  s.Client,
 		lxdImages:    s.Client,
 		Firewaller:   s.Firewaller,
 	}
@@ -471,8 +472,9 @@ func (sc *stubCommon) DestroyEnv() error {
 type StubClient struct {
 	*gitjujutesting.Stub
 
-	Insts []lxdclient.Instance
-	Inst  *lxdclient.Instance
+	Insts    []lxdclient.Instance
+	Inst     *lxdclient.Instance
+	Profiles []string
 }
 
 func (conn *StubClient) Instances(prefix string, statuses ...string) ([]lxdclient.Instance, error) {
@@ -511,6 +513,39 @@ func (conn *StubClient) EnsureImageExists(series string, _ []lxdclient.Remote, _
 	return nil
 }
 
+func (conn *StubClient) CreateProfile(name string, config map[string]string) error {
+	conn.AddCall("CreateProfile", name, config)
+	return conn.NextErr()
+}
+
+func (conn *StubClient) CreateProfileWithDevices(name string, config map[string]string, devices lxdclient.Devices) error {
+	conn.AddCall("CreateProfileWithDevices", name, config, devices)
+	return conn.NextErr()
+}
+
+func (conn *StubClient) HasProfile(name string) (bool, error) {
+	conn.AddCall("HasProfile", name)
+	if err := conn.NextErr(); err != nil {
+		return false, errors.Trace(err)
+	}
+
+	return false, nil
+}
+
+func (conn *StubClient) InstanceProfiles(name string) ([]string, error) {
+	conn.AddCall("InstanceProfiles", name)
+	if err := conn.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return conn.Profiles, nil
+}
+
+func (conn *StubClient) SetInstanceProfiles(name string, profiles []string) error {
+	conn.AddCall("SetInstanceProfiles", name, profiles)
+	return conn.NextErr()
+}
+
 func (conn *StubClient) Addresses(name string) ([]network.Address, error) {
 	conn.AddCall("Addresses", name)
 	if err := conn.NextErr(); err != nil {
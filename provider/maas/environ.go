@@ -806,8 +806,8 @@ func (environ *maasEnviron) startNode2(node maas2Instance, series string, userda
 }
 
 // DistributeInstances implements the state.InstanceDistributor policy.
-func (e *maasEnviron) DistributeInstances(candidates, distributionGroup []instance.Id) ([]instance.Id, error) {
-	return common.DistributeInstances(e, candidates, distributionGroup)
+func (e *maasEnviron) DistributeInstances(candidates, distributionGroup []instance.Id, limitZones []string) ([]instance.Id, error) {
+	return common.DistributeInstances(e, candidates, distributionGroup, limitZones)
 }
 
 var availabilityZoneAllocations = common.AvailabilityZoneAllocations
@@ -837,8 +837,8 @@ func (environ *maasEnviron) StartInstance(args environs.StartInstanceParams) (
 	}
 
 	// If no placement is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// the known zones (limited by any zones constraint) for optimal spread
+	// across the instance distribution group.
 	if args.Placement == "" {
 		var group []instance.Id
 		var err error
@@ -857,6 +857,12 @@ func (environ *maasEnviron) StartInstance(args environs.StartInstanceParams) (
 		} else if err != nil {
 			return nil, errors.Annotate(err, "cannot get availability zone allocations")
 		} else if len(zoneInstances) > 0 {
+			if args.Constraints.HasZones() {
+				zoneInstances = common.FilterZoneInstances(zoneInstances, *args.Constraints.Zones)
+				if len(zoneInstances) == 0 {
+					return nil, errors.Errorf("no available zones match constraint zones=%s", strings.Join(*args.Constraints.Zones, ","))
+				}
+			}
 			for _, z := range zoneInstances {
 				availabilityZones = append(availabilityZones, z.ZoneName)
 			}
@@ -494,6 +494,7 @@ func ResolveNetwork(e environs.Environ, networkName string) (string, error) {
 }
 
 var PortsToRuleInfo = portsToRuleInfo
+var RulesToRuleInfo = rulesToRuleInfo
 var RuleMatchesPortRange = ruleMatchesPortRange
 
 var MakeServiceURL = &makeServiceURL
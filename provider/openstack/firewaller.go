@@ -50,7 +50,8 @@ type Firewaller interface {
 	GetSecurityGroups(ids ...instance.Id) ([]string, error)
 
 	// Implementations should set up initial security groups, if any.
-	SetUpGroups(controllerUUID, machineId string, apiPort int) ([]nova.SecurityGroup, error)
+	// The accessRules hold the SSH and API access rules for the machine.
+	SetUpGroups(controllerUUID, machineId string, accessRules []network.IngressRule) ([]nova.SecurityGroup, error)
 
 	// Set of initial networks, that should be added by default to all new instances.
 	InitialNetworks() []nova.ServerNetworks
@@ -65,6 +66,38 @@ type Firewaller interface {
 	InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error)
 }
 
+// IngressFirewaller is an optional interface that may be implemented by
+// a Firewaller which can restrict open ports to specific source CIDRs.
+// Firewallers that do not implement it can only open ports to any source.
+type IngressFirewaller interface {
+	// OpenIngressRules opens the given ingress rules for the whole environment.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules for the whole environment.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole environment.
+	IngressRules() ([]network.IngressRule, error)
+
+	// OpenInstanceIngressRules opens the given ingress rules for the specified instance.
+	OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// CloseInstanceIngressRules closes the given ingress rules for the specified instance.
+	CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// InstanceIngressRules returns the ingress rules opened for the specified instance.
+	InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error)
+}
+
+// AccessFirewaller is an optional interface that may be implemented by
+// a Firewaller which opens the SSH and API ports in a security group
+// shared by all of the model's machines.
+type AccessFirewaller interface {
+	// SetAccessRules replaces the SSH and API access rules with the
+	// given rules.
+	SetAccessRules(rules []network.IngressRule) error
+}
+
 type firewallerFactory struct {
 }
 
@@ -77,6 +110,9 @@ type defaultFirewaller struct {
 	environ *Environ
 }
 
+var _ IngressFirewaller = (*defaultFirewaller)(nil)
+var _ AccessFirewaller = (*defaultFirewaller)(nil)
+
 // InitialNetworks implements Firewaller interface.
 func (c *defaultFirewaller) InitialNetworks() []nova.ServerNetworks {
 	return []nova.ServerNetworks{}
@@ -93,8 +129,8 @@ func (c *defaultFirewaller) InitialNetworks() []nova.ServerNetworks {
 // Note: ideally we'd have a better way to determine group membership so that 2
 // people that happen to share an openstack account and name their environment
 // "openstack" don't end up destroying each other's machines.
-func (c *defaultFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int) ([]nova.SecurityGroup, error) {
-	jujuGroup, err := c.setUpGlobalGroup(c.jujuGroupName(controllerUUID), apiPort)
+func (c *defaultFirewaller) SetUpGroups(controllerUUID, machineId string, accessRules []network.IngressRule) ([]nova.SecurityGroup, error) {
+	jujuGroup, err := c.setUpGlobalGroup(c.jujuGroupName(controllerUUID), accessRules)
 	if err != nil {
 		return nil, err
 	}
@@ -119,21 +155,10 @@ func (c *defaultFirewaller) SetUpGroups(controllerUUID, machineId string, apiPor
 	return groups, nil
 }
 
-func (c *defaultFirewaller) setUpGlobalGroup(groupName string, apiPort int) (nova.SecurityGroup, error) {
-	return c.ensureGroup(groupName,
+func (c *defaultFirewaller) setUpGlobalGroup(groupName string, accessRules []network.IngressRule) (nova.SecurityGroup, error) {
+	return c.ensureGroup(groupName, append(
+		rulesToRuleInfo("", accessRules),
 		[]nova.RuleInfo{
-			{
-				IPProtocol: "tcp",
-				FromPort:   22,
-				ToPort:     22,
-				Cidr:       "0.0.0.0/0",
-			},
-			{
-				IPProtocol: "tcp",
-				FromPort:   apiPort,
-				ToPort:     apiPort,
-				Cidr:       "0.0.0.0/0",
-			},
 			{
 				IPProtocol: "tcp",
 				FromPort:   1,
@@ -149,7 +174,53 @@ func (c *defaultFirewaller) setUpGlobalGroup(groupName string, apiPort int) (nov
 				FromPort:   -1,
 				ToPort:     -1,
 			},
-		})
+		}...,
+	))
+}
+
+// SetAccessRules implements AccessFirewaller. The access rules are
+// those rules of the model's juju group which have a source CIDR; the
+// remaining rules allow traffic between the machines in the group.
+func (c *defaultFirewaller) SetAccessRules(rules []network.IngressRule) error {
+	group, err := c.matchingGroup("^" + c.jujuGroupRegexp() + "$")
+	if errors.IsNotFound(err) {
+		// No machines have been started yet. The rules will be
+		// applied when the group is created.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	want := make(map[nova.RuleInfo]bool)
+	for _, ruleInfo := range rulesToRuleInfo(group.Id, rules) {
+		want[ruleInfo] = true
+	}
+	novaclient := c.environ.nova()
+	for _, p := range group.Rules {
+		cidr := p.IPRange["cidr"]
+		if cidr == "" || p.IPProtocol == nil || p.FromPort == nil || p.ToPort == nil {
+			continue
+		}
+		ruleInfo := nova.RuleInfo{
+			ParentGroupId: group.Id,
+			FromPort:      *p.FromPort,
+			ToPort:        *p.ToPort,
+			IPProtocol:    *p.IPProtocol,
+			Cidr:          cidr,
+		}
+		if want[ruleInfo] {
+			delete(want, ruleInfo)
+			continue
+		}
+		if err := novaclient.DeleteSecurityGroupRule(p.Id); err != nil {
+			return errors.Annotatef(err, "deleting security group rule %q", p.Id)
+		}
+	}
+	for ruleInfo := range want {
+		if _, err := novaclient.CreateSecurityGroupRule(ruleInfo); err != nil {
+			return errors.Annotate(err, "creating security group rule")
+		}
+	}
+	return nil
 }
 
 // zeroGroup holds the zero security group.
@@ -308,79 +379,117 @@ func deleteSecurityGroup(novaclient *nova.Client, name, id string) {
 
 // OpenPorts implements Firewaller interface.
 func (c *defaultFirewaller) OpenPorts(ports []network.PortRange) error {
+	return c.OpenIngressRules(network.OpenIngressRules(ports))
+}
+
+// ClosePorts implements Firewaller interface.
+func (c *defaultFirewaller) ClosePorts(ports []network.PortRange) error {
+	return c.CloseIngressRules(network.OpenIngressRules(ports))
+}
+
+// Ports implements Firewaller interface.
+func (c *defaultFirewaller) Ports() ([]network.PortRange, error) {
+	rules, err := c.IngressRules()
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulePortRanges(rules), nil
+}
+
+// OpenInstancePorts implements Firewaller interface.
+func (c *defaultFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+	return c.OpenInstanceIngressRules(inst, machineId, network.OpenIngressRules(ports))
+}
+
+// CloseInstancePorts implements Firewaller interface.
+func (c *defaultFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+	return c.CloseInstanceIngressRules(inst, machineId, network.OpenIngressRules(ports))
+}
+
+// InstancePorts implements Firewaller interface.
+func (c *defaultFirewaller) InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error) {
+	rules, err := c.InstanceIngressRules(inst, machineId)
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulePortRanges(rules), nil
+}
+
+// OpenIngressRules implements IngressFirewaller interface.
+func (c *defaultFirewaller) OpenIngressRules(rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model",
 			c.environ.Config().FirewallMode())
 	}
-	if err := c.openPortsInGroup(c.globalGroupRegexp(), ports); err != nil {
+	if err := c.openRulesInGroup(c.globalGroupRegexp(), rules); err != nil {
 		return err
 	}
-	logger.Infof("opened ports in global group: %v", ports)
+	logger.Infof("opened ports in global group: %v", rules)
 	return nil
 }
 
-// ClosePorts implements Firewaller interface.
-func (c *defaultFirewaller) ClosePorts(ports []network.PortRange) error {
+// CloseIngressRules implements IngressFirewaller interface.
+func (c *defaultFirewaller) CloseIngressRules(rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model",
 			c.environ.Config().FirewallMode())
 	}
-	if err := c.closePortsInGroup(c.globalGroupRegexp(), ports); err != nil {
+	if err := c.closeRulesInGroup(c.globalGroupRegexp(), rules); err != nil {
 		return err
 	}
-	logger.Infof("closed ports in global group: %v", ports)
+	logger.Infof("closed ports in global group: %v", rules)
 	return nil
 }
 
-// Ports implements Firewaller interface.
-func (c *defaultFirewaller) Ports() ([]network.PortRange, error) {
+// IngressRules implements IngressFirewaller interface.
+func (c *defaultFirewaller) IngressRules() ([]network.IngressRule, error) {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model",
 			c.environ.Config().FirewallMode())
 	}
-	return c.portsInGroup(c.globalGroupRegexp())
+	return c.rulesInGroup(c.globalGroupRegexp())
 }
 
-// OpenInstancePorts implements Firewaller interface.
-func (c *defaultFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+// OpenInstanceIngressRules implements IngressFirewaller interface.
+func (c *defaultFirewaller) OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
 			c.environ.Config().FirewallMode())
 	}
 	nameRegexp := c.machineGroupRegexp(machineId)
-	if err := c.openPortsInGroup(nameRegexp, ports); err != nil {
+	if err := c.openRulesInGroup(nameRegexp, rules); err != nil {
 		return err
 	}
-	logger.Infof("opened ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, ports)
+	logger.Infof("opened ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
 	return nil
 }
 
-// CloseInstancePorts implements Firewaller interface.
-func (c *defaultFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+// CloseInstanceIngressRules implements IngressFirewaller interface.
+func (c *defaultFirewaller) CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
 			c.environ.Config().FirewallMode())
 	}
 	nameRegexp := c.machineGroupRegexp(machineId)
-	if err := c.closePortsInGroup(nameRegexp, ports); err != nil {
+	if err := c.closeRulesInGroup(nameRegexp, rules); err != nil {
 		return err
 	}
-	logger.Infof("closed ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, ports)
+	logger.Infof("closed ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
 	return nil
 }
 
-// InstancePorts implements Firewaller interface.
-func (c *defaultFirewaller) InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error) {
+// InstanceIngressRules implements IngressFirewaller interface.
+func (c *defaultFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
 			c.environ.Config().FirewallMode())
 	}
 	nameRegexp := c.machineGroupRegexp(machineId)
-	portRanges, err := c.portsInGroup(nameRegexp)
+	rules, err := c.rulesInGroup(nameRegexp)
 	if err != nil {
 		return nil, err
 	}
-	return portRanges, nil
+	return rules, nil
 }
 
 func (c *defaultFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup, error) {
@@ -405,15 +514,15 @@ func (c *defaultFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup
 	return matchingGroups[0], nil
 }
 
-func (c *defaultFirewaller) openPortsInGroup(nameRegExp string, portRanges []network.PortRange) error {
+func (c *defaultFirewaller) openRulesInGroup(nameRegExp string, rules []network.IngressRule) error {
 	group, err := c.matchingGroup(nameRegExp)
 	if err != nil {
 		return err
 	}
 	novaclient := c.environ.nova()
-	rules := portsToRuleInfo(group.Id, portRanges)
-	for _, rule := range rules {
-		_, err := novaclient.CreateSecurityGroupRule(rule)
+	ruleInfos := rulesToRuleInfo(group.Id, rules)
+	for _, ruleInfo := range ruleInfos {
+		_, err := novaclient.CreateSecurityGroupRule(ruleInfo)
 		if err != nil {
 			// TODO: if err is not rule already exists, raise?
 			logger.Debugf("error creating security group rule: %v", err.Error())
@@ -432,8 +541,17 @@ func ruleMatchesPortRange(rule nova.SecurityGroupRule, portRange network.PortRan
 		*rule.ToPort == portRange.ToPort
 }
 
-func (c *defaultFirewaller) closePortsInGroup(nameRegExp string, portRanges []network.PortRange) error {
-	if len(portRanges) == 0 {
+// ruleSourceCIDR returns the source CIDR of the supplied nova security
+// group rule. Rules without a CIDR are treated as open to any source.
+func ruleSourceCIDR(rule nova.SecurityGroupRule) string {
+	if cidr := rule.IPRange["cidr"]; cidr != "" {
+		return cidr
+	}
+	return network.AnyCIDR
+}
+
+func (c *defaultFirewaller) closeRulesInGroup(nameRegExp string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
 	group, err := c.matchingGroup(nameRegExp)
@@ -442,35 +560,53 @@ func (c *defaultFirewaller) closePortsInGroup(nameRegExp string, portRanges []ne
 	}
 	novaclient := c.environ.nova()
 	// TODO: Hey look ma, it's quadratic
-	for _, portRange := range portRanges {
-		for _, p := range group.Rules {
-			if !ruleMatchesPortRange(p, portRange) {
-				continue
-			}
-			err := novaclient.DeleteSecurityGroupRule(p.Id)
-			if err != nil {
-				return err
+	for _, rule := range rules {
+		for _, sourceCIDR := range rule.SourceRanges() {
+			for _, p := range group.Rules {
+				if !ruleMatchesPortRange(p, rule.PortRange) || ruleSourceCIDR(p) != sourceCIDR {
+					continue
+				}
+				err := novaclient.DeleteSecurityGroupRule(p.Id)
+				if err != nil {
+					return err
+				}
+				break
 			}
-			break
 		}
 	}
 	return nil
 }
 
-func (c *defaultFirewaller) portsInGroup(nameRegexp string) (portRanges []network.PortRange, err error) {
+func (c *defaultFirewaller) rulesInGroup(nameRegexp string) ([]network.IngressRule, error) {
 	group, err := c.matchingGroup(nameRegexp)
 	if err != nil {
 		return nil, err
 	}
+	// Nova has a rule per source CIDR, so collect the CIDRs for each
+	// port range into a single ingress rule.
+	var portRanges []network.PortRange
+	sourceCIDRs := make(map[network.PortRange][]string)
 	for _, p := range group.Rules {
-		portRanges = append(portRanges, network.PortRange{
+		portRange := network.PortRange{
 			Protocol: *p.IPProtocol,
 			FromPort: *p.FromPort,
 			ToPort:   *p.ToPort,
-		})
+		}
+		if _, ok := sourceCIDRs[portRange]; !ok {
+			portRanges = append(portRanges, portRange)
+		}
+		sourceCIDRs[portRange] = append(sourceCIDRs[portRange], ruleSourceCIDR(p))
+	}
+	rules := make([]network.IngressRule, len(portRanges))
+	for i, portRange := range portRanges {
+		rule, err := network.NewIngressRule(portRange, sourceCIDRs[portRange]...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules[i] = rule
 	}
-	network.SortPortRanges(portRanges)
-	return portRanges, nil
+	network.SortIngressRules(rules)
+	return rules, nil
 }
 
 func (c *defaultFirewaller) globalGroupName(controllerUUID string) string {
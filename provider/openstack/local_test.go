@@ -600,6 +600,31 @@ func (s *localServerSuite) TestDestroyEnvironmentDeletesSecurityGroupsFWModeGlob
 	assertSecurityGroups(c, env, []string{"default"})
 }
 
+func (s *localServerSuite) TestSetAccessRules(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"ssh-allow": "10.0.0.0/8"})
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	groupName := fmt.Sprintf("juju-%v-%v", s.ControllerUUID, env.Config().UUID())
+	sourceCIDRs := func(port int) []string {
+		group, err := openstack.GetNovaClient(env).SecurityGroupByName(groupName)
+		c.Assert(err, jc.ErrorIsNil)
+		var cidrs []string
+		for _, rule := range group.Rules {
+			if rule.FromPort != nil && *rule.FromPort == port {
+				cidrs = append(cidrs, rule.IPRange["cidr"])
+			}
+		}
+		return cidrs
+	}
+	c.Assert(sourceCIDRs(22), jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	rules, err := network.AccessRules([]string{"192.168.0.0/16"}, nil, 17070)
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.(environs.AccessFirewaller).SetAccessRules(rules)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sourceCIDRs(22), jc.DeepEquals, []string{"192.168.0.0/16"})
+	c.Assert(sourceCIDRs(17070), jc.DeepEquals, []string{"0.0.0.0/0"})
+}
+
 func (s *localServerSuite) TestDestroyController(c *gc.C) {
 	env := s.openEnviron(c, coretesting.Attrs{"uuid": utils.MustNewUUID().String()})
 	controllerEnv := s.env
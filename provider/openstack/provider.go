@@ -214,6 +214,8 @@ var _ simplestreams.HasRegion = (*Environ)(nil)
 var _ state.Prechecker = (*Environ)(nil)
 var _ instance.Distributor = (*Environ)(nil)
 var _ environs.InstanceTagger = (*Environ)(nil)
+var _ environs.IngressFirewaller = (*Environ)(nil)
+var _ environs.AccessFirewaller = (*Environ)(nil)
 
 type openstackInstance struct {
 	e        *Environ
@@ -231,6 +233,7 @@ func (inst *openstackInstance) String() string {
 }
 
 var _ instance.Instance = (*openstackInstance)(nil)
+var _ instance.IngressFirewaller = (*openstackInstance)(nil)
 
 func (inst *openstackInstance) Refresh() error {
 	inst.mu.Lock()
@@ -377,6 +380,42 @@ func (inst *openstackInstance) Ports(machineId string) ([]network.PortRange, err
 	return inst.e.firewaller.InstancePorts(inst, machineId)
 }
 
+// OpenIngressRules is part of the instance.IngressFirewaller interface.
+func (inst *openstackInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	if fw, ok := inst.e.firewaller.(IngressFirewaller); ok {
+		return fw.OpenInstanceIngressRules(inst, machineId, rules)
+	}
+	portRanges, err := openPortRanges(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return inst.e.firewaller.OpenInstancePorts(inst, machineId, portRanges)
+}
+
+// CloseIngressRules is part of the instance.IngressFirewaller interface.
+func (inst *openstackInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	if fw, ok := inst.e.firewaller.(IngressFirewaller); ok {
+		return fw.CloseInstanceIngressRules(inst, machineId, rules)
+	}
+	portRanges, err := openPortRanges(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return inst.e.firewaller.CloseInstancePorts(inst, machineId, portRanges)
+}
+
+// IngressRules is part of the instance.IngressFirewaller interface.
+func (inst *openstackInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	if fw, ok := inst.e.firewaller.(IngressFirewaller); ok {
+		return fw.InstanceIngressRules(inst, machineId)
+	}
+	portRanges, err := inst.e.firewaller.InstancePorts(inst, machineId)
+	if err != nil {
+		return nil, err
+	}
+	return network.OpenIngressRules(portRanges), nil
+}
+
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
 	ecfg := e.ecfgUnlocked
@@ -844,8 +883,8 @@ func (e *Environ) assignPublicIP(fip *nova.FloatingIP, serverId string) (err err
 }
 
 // DistributeInstances implements the state.InstanceDistributor policy.
-func (e *Environ) DistributeInstances(candidates, distributionGroup []instance.Id) ([]instance.Id, error) {
-	return common.DistributeInstances(e, candidates, distributionGroup)
+func (e *Environ) DistributeInstances(candidates, distributionGroup []instance.Id, limitZones []string) ([]instance.Id, error) {
+	return common.DistributeInstances(e, candidates, distributionGroup, limitZones)
 }
 
 var availabilityZoneAllocations = common.AvailabilityZoneAllocations
@@ -873,8 +912,8 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	}
 
 	// If no availability zone is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// the known zones (limited by any zones constraint) for optimal spread
+	// across the instance distribution group.
 	if len(availabilityZones) == 0 {
 		var group []instance.Id
 		var err error
@@ -891,6 +930,12 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		} else if err != nil {
 			return nil, err
 		} else {
+			if args.Constraints.HasZones() {
+				zoneInstances = common.FilterZoneInstances(zoneInstances, *args.Constraints.Zones)
+				if len(zoneInstances) == 0 {
+					return nil, errors.Errorf("no available zones match constraint zones=%s", strings.Join(*args.Constraints.Zones, ","))
+				}
+			}
 			for _, zone := range zoneInstances {
 				availabilityZones = append(availabilityZones, zone.ZoneName)
 			}
@@ -963,8 +1008,12 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		// All ports are the same so pick the first.
 		apiPort = args.InstanceConfig.APIInfo.Ports()[0]
 	}
+	accessRules, err := network.AccessRules(e.Config().SSHAllow(), args.APIAllow, apiPort)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	var groupNames = make([]nova.SecurityGroupName, 0)
-	groups, err := e.firewaller.SetUpGroups(args.ControllerUUID, args.InstanceConfig.MachineId, apiPort)
+	groups, err := e.firewaller.SetUpGroups(args.ControllerUUID, args.InstanceConfig.MachineId, accessRules)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot set up groups")
 	}
@@ -1343,17 +1392,25 @@ func (e *Environ) machinesFilter() *nova.Filter {
 
 // portsToRuleInfo maps port ranges to nova rules
 func portsToRuleInfo(groupId string, ports []network.PortRange) []nova.RuleInfo {
-	rules := make([]nova.RuleInfo, len(ports))
-	for i, portRange := range ports {
-		rules[i] = nova.RuleInfo{
-			ParentGroupId: groupId,
-			FromPort:      portRange.FromPort,
-			ToPort:        portRange.ToPort,
-			IPProtocol:    portRange.Protocol,
-			Cidr:          "0.0.0.0/0",
+	return rulesToRuleInfo(groupId, network.OpenIngressRules(ports))
+}
+
+// rulesToRuleInfo maps ingress rules to nova rules, one for each source
+// CIDR of each rule.
+func rulesToRuleInfo(groupId string, rules []network.IngressRule) []nova.RuleInfo {
+	var ruleInfos []nova.RuleInfo
+	for _, rule := range rules {
+		for _, sourceCIDR := range rule.SourceRanges() {
+			ruleInfos = append(ruleInfos, nova.RuleInfo{
+				ParentGroupId: groupId,
+				FromPort:      rule.FromPort,
+				ToPort:        rule.ToPort,
+				IPProtocol:    rule.Protocol,
+				Cidr:          sourceCIDR,
+			})
 		}
 	}
-	return rules
+	return ruleInfos
 }
 
 func (e *Environ) OpenPorts(ports []network.PortRange) error {
@@ -1368,6 +1425,65 @@ func (e *Environ) Ports() ([]network.PortRange, error) {
 	return e.firewaller.Ports()
 }
 
+// OpenIngressRules is part of the environs.IngressFirewaller interface.
+func (e *Environ) OpenIngressRules(rules []network.IngressRule) error {
+	if fw, ok := e.firewaller.(IngressFirewaller); ok {
+		return fw.OpenIngressRules(rules)
+	}
+	portRanges, err := openPortRanges(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return e.firewaller.OpenPorts(portRanges)
+}
+
+// CloseIngressRules is part of the environs.IngressFirewaller interface.
+func (e *Environ) CloseIngressRules(rules []network.IngressRule) error {
+	if fw, ok := e.firewaller.(IngressFirewaller); ok {
+		return fw.CloseIngressRules(rules)
+	}
+	portRanges, err := openPortRanges(rules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return e.firewaller.ClosePorts(portRanges)
+}
+
+// IngressRules is part of the environs.IngressFirewaller interface.
+func (e *Environ) IngressRules() ([]network.IngressRule, error) {
+	if fw, ok := e.firewaller.(IngressFirewaller); ok {
+		return fw.IngressRules()
+	}
+	portRanges, err := e.firewaller.Ports()
+	if err != nil {
+		return nil, err
+	}
+	return network.OpenIngressRules(portRanges), nil
+}
+
+// SetAccessRules is part of the environs.AccessFirewaller interface.
+func (e *Environ) SetAccessRules(rules []network.IngressRule) error {
+	if fw, ok := e.firewaller.(AccessFirewaller); ok {
+		return fw.SetAccessRules(rules)
+	}
+	return errors.NotSupportedf("restricting SSH and API access")
+}
+
+// openPortRanges returns the port ranges of the given ingress rules,
+// for use with firewallers that cannot restrict source CIDRs. An error
+// satisfying errors.IsNotSupported is returned if any rule is
+// restricted to specific source CIDRs.
+func openPortRanges(rules []network.IngressRule) ([]network.PortRange, error) {
+	portRanges := make([]network.PortRange, len(rules))
+	for i, rule := range rules {
+		if !rule.IsOpen() {
+			return nil, errors.NotSupportedf("restricting source CIDRs for %v", rule)
+		}
+		portRanges[i] = rule.PortRange
+	}
+	return portRanges, nil
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
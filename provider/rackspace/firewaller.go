@@ -71,7 +71,7 @@ func (c *rackspaceFirewaller) GetSecurityGroups(ids ...instance.Id) ([]string, e
 }
 
 // SetUpGroups implements OpenstackFirewaller interface.
-func (c *rackspaceFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int) ([]nova.SecurityGroup, error) {
+func (c *rackspaceFirewaller) SetUpGroups(controllerUUID, machineId string, accessRules []network.IngressRule) ([]nova.SecurityGroup, error) {
 	return nil, nil
 }
 
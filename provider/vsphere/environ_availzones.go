@@ -101,8 +101,8 @@ func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([
 	}
 
 	// If no availability zone is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// the known zones (limited by any zones constraint) for optimal spread
+	// across the instance distribution group.
 	var group []instance.Id
 	var err error
 	if args.DistributionGroup != nil {
@@ -115,6 +115,9 @@ func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if args.Constraints.HasZones() {
+		zoneInstances = common.FilterZoneInstances(zoneInstances, *args.Constraints.Zones)
+	}
 	logger.Infof("found %d zones: %v", len(zoneInstances), zoneInstances)
 
 	var zoneNames []string
@@ -223,6 +223,23 @@ func InstallAndStart(svc ServiceActions) error {
 	return errors.Trace(err)
 }
 
+// InstallForSeries installs the named service using the init system of
+// the given series, which may differ from the init system running on the
+// host. The service is not started. It is used when the OS of the host
+// has been upgraded in place, so that the service is started by the new
+// init system once the host is rebooted.
+func InstallForSeries(name string, conf common.Conf, series string) error {
+	svc, err := NewService(name, conf, series)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("installing service %q for series %q", name, series)
+	if err := svc.Install(); err != nil {
+		return errors.Annotatef(err, "failed to install service %q", name)
+	}
+	return nil
+}
+
 // discoverService is patched out during some tests.
 var discoverService = func(name string) (Service, error) {
 	return DiscoverService(name, common.Conf{})
@@ -246,6 +246,11 @@ func allCollections() collectionSchema {
 		// meterStatusC is the collection used to store meter status information.
 		meterStatusC:  {},
 		settingsrefsC: {},
+
+		// unitStatesC holds the state the uniter persists for each
+		// unit, so that it survives the loss of the unit's machine.
+		unitStatesC: {},
+
 		relationsC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "endpoints.relationname"},
@@ -264,6 +269,11 @@ func allCollections() collectionSchema {
 		rebootC:        {},
 		sshHostKeysC:   {},
 
+		// This collection holds the cloud instances that are tagged
+		// as belonging to a model but are not known to any machine.
+		// It is written by the instance drift worker.
+		leakedInstancesC: {},
+
 		// -----
 
 		// These collections hold information associated with storage.
@@ -320,6 +330,23 @@ func allCollections() collectionSchema {
 
 		// -----
 
+		// These collections hold charm secrets and the audit trail of
+		// operations on them.
+		secretsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "secret-id"},
+			}, {
+				Key: []string{"model-uuid", "owner"},
+			}},
+		},
+		secretAuditC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "secret-id"},
+			}},
+		},
+
+		// -----
+
 		// This collection holds information associated with charm payloads.
 		payloadsC: {
 			indexes: []mgo.Index{{
@@ -361,6 +388,15 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// This collection holds a record of the hooks run by each
+		// unit, pruned alongside status history.
+		hookHistoryC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "unit", "finished"},
+			}},
+		},
+
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {},
 
@@ -406,6 +442,7 @@ const (
 	guimetadataC             = "guimetadata"
 	guisettingsC             = "guisettings"
 	instanceDataC            = "instanceData"
+	leakedInstancesC         = "leakedInstances"
 	leasesC                  = "leases"
 	machinesC                = "machines"
 	meterStatusC             = "meterStatus"
@@ -428,6 +465,8 @@ const (
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
 	restoreInfoC             = "restoreInfo"
+	secretAuditC             = "secretaudit"
+	secretsC                 = "secrets"
 	sequenceC                = "sequence"
 	applicationsC            = "applications"
 	endpointBindingsC        = "endpointbindings"
@@ -448,6 +487,8 @@ const (
 	txnLogC                  = "txns.log"
 	txnsC                    = "txns"
 	unitsC                   = "units"
+	unitStatesC              = "unitstates"
+	hookHistoryC             = "hookhistory"
 	upgradeInfoC             = "upgradeInfo"
 	userLastLoginC           = "userLastLogin"
 	usermodelnameC           = "usermodelname"
@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
@@ -23,6 +25,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 )
 
@@ -48,7 +51,9 @@ type applicationDoc struct {
 	UnitCount            int        `bson:"unitcount"`
 	RelationCount        int        `bson:"relationcount"`
 	Exposed              bool       `bson:"exposed"`
+	ExposedCIDRs         []string   `bson:"exposed-cidrs,omitempty"`
 	MinUnits             int        `bson:"minunits"`
+	Trust                bool       `bson:"trust,omitempty"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
 }
@@ -191,7 +196,11 @@ func (s *Application) destroyOps() ([]txn.Op, error) {
 	// removed, the application can also be removed.
 	if s.doc.UnitCount == 0 && s.doc.RelationCount == removeCount {
 		hasLastRefs := bson.D{{"life", Alive}, {"unitcount", 0}, {"relationcount", removeCount}}
-		return append(ops, s.removeOps(hasLastRefs)...), nil
+		removeOps, err := s.removeOps(hasLastRefs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, removeOps...), nil
 	}
 	// In all other cases, application removal will be handled as a consequence
 	// of the removal of the last unit or relation referencing it. If any
@@ -245,7 +254,7 @@ func removeResourcesOps(st *State, serviceID string) ([]txn.Op, error) {
 
 // removeOps returns the operations required to remove the service. Supplied
 // asserts will be included in the operation on the application document.
-func (s *Application) removeOps(asserts bson.D) []txn.Op {
+func (s *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	settingsDocID := s.st.docID(s.settingsKey())
 	ops := []txn.Op{
 		{
@@ -276,7 +285,11 @@ func (s *Application) removeOps(asserts bson.D) []txn.Op {
 	if s.doc.CharmURL.Schema == "local" {
 		ops = append(ops, s.st.newCleanupOp(cleanupCharmForDyingService, s.doc.CharmURL.String()))
 	}
-	return ops
+	secretsOps, err := removeSecretsOps(s.st, s.Tag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, secretsOps...), nil
 }
 
 // IsExposed returns whether this application is exposed. The explicitly open
@@ -286,29 +299,91 @@ func (s *Application) IsExposed() bool {
 	return s.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
+// ExposedCIDRs returns the source CIDRs that an exposed application's
+// open ports may be accessed from. An empty result means the ports are
+// accessible from anywhere. See SetExposedToCIDRs.
+func (s *Application) ExposedCIDRs() []string {
+	return s.doc.ExposedCIDRs
+}
+
+// SetExposed marks the application as exposed to all sources.
 // See ClearExposed and IsExposed.
 func (s *Application) SetExposed() error {
-	return s.setExposed(true)
+	return s.setExposed(true, nil)
+}
+
+// SetExposedToCIDRs marks the application as exposed, with its open
+// ports accessible only from the given source CIDRs. If no CIDRs are
+// given, or any of them matches all addresses, the application is
+// exposed to all sources.
+// See ClearExposed, IsExposed and ExposedCIDRs.
+func (s *Application) SetExposedToCIDRs(cidrs []string) error {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	sources := set.NewStrings(cidrs...)
+	if sources.IsEmpty() || sources.Contains(network.AnyCIDR) {
+		// Any other CIDRs are redundant, and providers report a rule
+		// open to all sources without any.
+		return s.setExposed(true, nil)
+	}
+	return s.setExposed(true, sources.SortedValues())
 }
 
 // ClearExposed removes the exposed flag from the service.
 // See SetExposed and IsExposed.
 func (s *Application) ClearExposed() error {
-	return s.setExposed(false)
+	return s.setExposed(false, nil)
 }
 
-func (s *Application) setExposed(exposed bool) (err error) {
+func (s *Application) setExposed(exposed bool, cidrs []string) (err error) {
+	var update bson.D
+	if len(cidrs) > 0 {
+		update = bson.D{{"$set", bson.D{
+			{"exposed", exposed},
+			{"exposed-cidrs", cidrs},
+		}}}
+	} else {
+		update = bson.D{
+			{"$set", bson.D{{"exposed", exposed}}},
+			{"$unset", bson.D{{"exposed-cidrs", nil}}},
+		}
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     s.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := s.st.runTransaction(ops); err != nil {
 		return fmt.Errorf("cannot set exposed flag for application %q to %v: %v", s, exposed, onAbort(err, errNotAlive))
 	}
 	s.doc.Exposed = exposed
+	s.doc.ExposedCIDRs = cidrs
+	return nil
+}
+
+// Trust reports whether the application has been trusted with access
+// to the model's cloud credential. See SetTrust.
+func (s *Application) Trust() bool {
+	return s.doc.Trust
+}
+
+// SetTrust grants or revokes the application's access to the model's
+// cloud credential.
+func (s *Application) SetTrust(trust bool) error {
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"trust", trust}}}},
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return errors.Errorf("cannot set trust for application %q to %v: %v", s, trust, onAbort(err, errNotAlive))
+	}
+	s.doc.Trust = trust
 	return nil
 }
 
@@ -1157,6 +1232,11 @@ func (s *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		return nil, errors.Trace(err)
 	}
 	ops = append(ops, resOps...)
+	secretsOps, err := removeSecretsOps(s.st, u.Tag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, secretsOps...)
 
 	observedFieldsMatch := bson.D{
 		{"charmurl", u.doc.CharmURL},
@@ -1170,8 +1250,10 @@ func (s *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 			Remove: true,
 		},
 		removeMeterStatusOp(s.st, u.globalMeterStatusKey()),
+		removeUnitStateOp(s.st, u.globalKey()),
 		removeStatusOp(s.st, u.globalAgentKey()),
 		removeStatusOp(s.st, u.globalKey()),
+		removeStatusOp(s.st, u.globalHealthKey()),
 		removeConstraintsOp(s.st, u.globalAgentKey()),
 		annotationRemoveOp(s.st, u.globalKey()),
 		s.st.newCleanupOp(cleanupRemovedUnit, u.doc.Name),
@@ -1189,7 +1271,11 @@ func (s *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 	}
 	if s.doc.Life == Dying && s.doc.RelationCount == 0 && s.doc.UnitCount == 1 {
 		hasLastRef := bson.D{{"life", Dying}, {"relationcount", 0}, {"unitcount", 1}}
-		return append(ops, s.removeOps(hasLastRef)...), nil
+		removeOps, err := s.removeOps(hasLastRef)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, removeOps...), nil
 	}
 	svcOp := txn.Op{
 		C:      applicationsC,
@@ -1450,6 +1536,140 @@ func (s *Application) EndpointBindings() (map[string]string, error) {
 	return bindings, nil
 }
 
+// UpdateEndpointBindings merges the given bindings with the existing ones,
+// using the current charm metadata to validate the result. Each machine
+// already hosting a unit of the application must have at least one address
+// in every space an endpoint is being bound to.
+func (s *Application) UpdateEndpointBindings(bindings map[string]string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		ch, _, err := s.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		units, err := s.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := validateEndpointBindingsForUnits(s.st, bindings, units); err != nil {
+			return nil, errors.Trace(err)
+		}
+		bindingsOp, err := updateEndpointBindingsOp(s.st, s.globalKey(), bindings, ch.Meta())
+		if err == jujutxn.ErrNoOperations {
+			return nil, err
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Assert the charm and units haven't changed since we validated
+		// the bindings against them.
+		sameCharmAndUnits := bson.D{
+			{"charmurl", s.doc.CharmURL},
+			{"unitcount", len(units)},
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     s.doc.DocID,
+			Assert: append(isAliveDoc, sameCharmAndUnits...),
+		}, bindingsOp}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update endpoint bindings for application %q", s)
+	}
+	return nil
+}
+
+// UpdateApplicationSeries changes the series of the application, which
+// is used when adding new units. The application's charm must support
+// the series; if force is true, the charm need only support the OS of
+// the series. The series of existing units is changed by updating the
+// series of the machines they are assigned to.
+func (s *Application) UpdateApplicationSeries(toSeries string, force bool) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		if s.doc.Series == toSeries {
+			return nil, jujutxn.ErrNoOperations
+		}
+		ch, _, err := s.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := checkCharmSeries(ch, toSeries, force); err != nil {
+			return nil, errors.Trace(err)
+		}
+		sameCharmAndSeries := bson.D{
+			{"charmurl", s.doc.CharmURL},
+			{"series", s.doc.Series},
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     s.doc.DocID,
+			Assert: append(isAliveDoc, sameCharmAndSeries...),
+			Update: bson.D{{"$set", bson.D{{"series", toSeries}}}},
+		}}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update series for application %q", s)
+	}
+	s.doc.Series = toSeries
+	return nil
+}
+
+// checkCharmSeries returns an error if the charm does not support the
+// given series. Charms written for a single series only support that
+// series, unless force is true. With force, multi-series charms need
+// only support the OS of the series.
+func checkCharmSeries(ch *Charm, toSeries string, force bool) error {
+	if ch.URL().Series != "" {
+		if ch.URL().Series != toSeries && !force {
+			return errors.Errorf("charm %q only supports series %q", ch.URL(), ch.URL().Series)
+		}
+		return nil
+	}
+	supportedSeries := ch.Meta().Series
+	if len(supportedSeries) == 0 {
+		return nil
+	}
+	for _, chSeries := range supportedSeries {
+		if chSeries == toSeries {
+			return nil
+		}
+	}
+	if !force {
+		return errors.Errorf(
+			"series %q not supported by charm %q, supported series are: %s",
+			toSeries, ch.URL(), strings.Join(supportedSeries, ", "),
+		)
+	}
+	toOS, err := series.GetOSFromSeries(toSeries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, chSeries := range supportedSeries {
+		chOS, err := series.GetOSFromSeries(chSeries)
+		if err != nil {
+			continue
+		}
+		if chOS == toOS {
+			return nil
+		}
+	}
+	return errors.Errorf("OS %q not supported by charm %q", toOS, ch.URL())
+}
+
 // defaultEndpointBindings returns a map with each endpoint from the current
 // charm metadata bound to an empty space. If no charm URL is set yet, it
 // returns an empty map.
@@ -129,6 +129,50 @@ func (s *ServiceSuite) TestClientServiceSetCharmWrongOS(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot upgrade charm, OS "Ubuntu" not supported by charm`)
 }
 
+func (s *ServiceSuite) TestUpdateApplicationSeries(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	svc := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+
+	err := svc.UpdateApplicationSeries("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.Series(), gc.Equals, "trusty")
+
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.Series(), gc.Equals, "trusty")
+}
+
+func (s *ServiceSuite) TestUpdateApplicationSeriesUnsupported(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	svc := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+
+	err := svc.UpdateApplicationSeries("xenial", false)
+	c.Assert(err, gc.ErrorMatches, `cannot update series for application "application": series "xenial" not supported by charm ".*", supported series are: precise, trusty`)
+	c.Assert(svc.Series(), gc.Equals, "precise")
+}
+
+func (s *ServiceSuite) TestUpdateApplicationSeriesUnsupportedForce(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	svc := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+
+	err := svc.UpdateApplicationSeries("xenial", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.Series(), gc.Equals, "xenial")
+}
+
+func (s *ServiceSuite) TestUpdateApplicationSeriesWrongOS(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	svc := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+
+	err := svc.UpdateApplicationSeries("win2012r2", true)
+	c.Assert(err, gc.ErrorMatches, `cannot update series for application "application": OS "Windows" not supported by charm ".*"`)
+}
+
+func (s *ServiceSuite) TestUpdateApplicationSeriesSingleSeriesCharm(c *gc.C) {
+	err := s.mysql.UpdateApplicationSeries("precise", false)
+	c.Assert(err, gc.ErrorMatches, `cannot update series for application "mysql": charm ".*" only supports series "quantal"`)
+}
+
 func (s *ServiceSuite) TestSetCharmPreconditions(c *gc.C) {
 	logging := s.AddTestingCharm(c, "logging")
 	cfg := state.SetCharmConfig{Charm: logging}
@@ -175,6 +219,76 @@ func (s *ServiceSuite) TestSetCharmUpdatesBindings(c *gc.C) {
 	})
 }
 
+func (s *ServiceSuite) TestUpdateEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 44)
+	service := s.AddTestingServiceWithBindings(c, "yoursql", ch, nil)
+
+	err = service.UpdateEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := service.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings, jc.DeepEquals, map[string]string{
+		"server":  "db",
+		"client":  "",
+		"cluster": "",
+	})
+}
+
+func (s *ServiceSuite) TestUpdateEndpointBindingsUnknownEndpointOrSpace(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 44)
+	service := s.AddTestingServiceWithBindings(c, "yoursql", ch, nil)
+
+	err = service.UpdateEndpointBindings(map[string]string{"bogus": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot update endpoint bindings for application "yoursql": unknown endpoint "bogus" not valid`)
+	err = service.UpdateEndpointBindings(map[string]string{"server": "bogus"})
+	c.Assert(err, gc.ErrorMatches, `cannot update endpoint bindings for application "yoursql": unknown space "bogus" not valid`)
+}
+
+func (s *ServiceSuite) TestUpdateEndpointBindingsChecksUnitMachineAddresses(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.10.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("db", "", []string{"10.0.0.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("storage", "", []string{"10.10.0.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 44)
+	service := s.AddTestingServiceWithBindings(c, "yoursql", ch, nil)
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "eth0",
+		Type: state.EthernetDevice,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth0",
+		ConfigMethod: state.StaticAddress,
+		CIDRAddress:  "10.0.0.5/24",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = service.UpdateEndpointBindings(map[string]string{"server": "storage"})
+	c.Assert(err, gc.ErrorMatches, `cannot update endpoint bindings for application "yoursql": `+
+		`binding to space "storage": machine "`+machine.Id()+`" hosting unit "yoursql/0" has no address in that space not valid`)
+
+	err = service.UpdateEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := service.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "db")
+}
+
 func (s *ServiceSuite) TestSetCharmWithWeirdlyNamedEndpoints(c *gc.C) {
 	// This test ensures if special characters appear in endpoint names of the
 	// charm metadata, they are properly escaped before saving to mongo, and
@@ -1345,6 +1459,86 @@ func (s *ServiceSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ServiceSuite) TestServiceExposedToCIDRs(c *gc.C) {
+	err := s.mysql.SetExposedToCIDRs([]string{"192.168.1.0/24", "10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	// Exposing to all sources clears the CIDRs.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+
+	// As does unexposing.
+	err = s.mysql.SetExposedToCIDRs([]string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ServiceSuite) TestSetExposedToAnyCIDR(c *gc.C) {
+	err := s.mysql.SetExposedToCIDRs([]string{"10.0.0.0/8", "0.0.0.0/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ServiceSuite) TestSetTrust(c *gc.C) {
+	c.Assert(s.mysql.Trust(), jc.IsFalse)
+	err := s.mysql.SetTrust(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.Trust(), jc.IsTrue)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.Trust(), jc.IsTrue)
+
+	err = s.mysql.SetTrust(false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.Trust(), jc.IsFalse)
+}
+
+func (s *ServiceSuite) TestSetTrustNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetTrust(true)
+	c.Assert(err, gc.ErrorMatches, `cannot set trust for application "mysql" to true: not found or not alive`)
+}
+
+func (s *ServiceSuite) TestAddApplicationTrusted(c *gc.C) {
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "trusted",
+		Charm: s.charm,
+		Trust: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Trust(), jc.IsTrue)
+}
+
+func (s *ServiceSuite) TestServiceExposedToInvalidCIDR(c *gc.C) {
+	err := s.mysql.SetExposedToCIDRs([]string{"10.0.0.0/8", "bad"})
+	c.Assert(err, gc.ErrorMatches, `CIDR "bad" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
 func (s *ServiceSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
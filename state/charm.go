@@ -6,6 +6,7 @@ package state
 import (
 	"net/url"
 	"regexp"
+	"strings"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
@@ -17,6 +18,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/storage"
 	jujuversion "github.com/juju/juju/version"
@@ -64,6 +66,9 @@ type charmDoc struct {
 	Actions *charm.Actions `bson:"actions"`
 	Metrics *charm.Metrics `bson:"metrics"`
 
+	// LXDProfile holds the LXD profile supplied by the charm, if any.
+	LXDProfile *lxdProfileDoc `bson:"lxd-profile,omitempty"`
+
 	// DEPRECATED: BundleURL is deprecated, and exists here
 	// only for migration purposes. We should remove this
 	// when migrations are no longer necessary.
@@ -76,6 +81,44 @@ type charmDoc struct {
 	Macaroon      []byte `bson:"macaroon"`
 }
 
+// lxdProfileDoc represents the LXD profile supplied by a charm.
+type lxdProfileDoc struct {
+	Description string                       `bson:"description,omitempty"`
+	Config      map[string]string            `bson:"config,omitempty"`
+	Devices     map[string]map[string]string `bson:"devices,omitempty"`
+}
+
+// newLXDProfileDoc returns a document holding the given profile, with
+// any "$" and "." in configuration keys and device names escaped, or
+// nil if the profile is empty.
+func newLXDProfileDoc(profile *lxdprofile.Profile) *lxdProfileDoc {
+	if profile.Empty() {
+		return nil
+	}
+	doc := &lxdProfileDoc{
+		Description: profile.Description,
+		Config:      replaceKeys(profile.Config, escapeReplacer),
+	}
+	if len(profile.Devices) > 0 {
+		doc.Devices = make(map[string]map[string]string)
+		for name, device := range profile.Devices {
+			doc.Devices[escapeReplacer.Replace(name)] = replaceKeys(device, escapeReplacer)
+		}
+	}
+	return doc
+}
+
+func replaceKeys(m map[string]string, replacer *strings.Replacer) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[replacer.Replace(k)] = v
+	}
+	return result
+}
+
 // CharmInfo contains all the data necessary to store a charm's metadata.
 type CharmInfo struct {
 	Charm       charm.Charm
@@ -83,6 +126,7 @@ type CharmInfo struct {
 	StoragePath string
 	SHA256      string
 	Macaroon    macaroon.Slice
+	LXDProfile  *lxdprofile.Profile
 }
 
 // insertCharmOps returns the txn operations necessary to insert the supplied
@@ -100,6 +144,7 @@ func insertCharmOps(st *State, info CharmInfo) ([]txn.Op, error) {
 		Config:       safeConfig(info.Charm),
 		Metrics:      info.Charm.Metrics(),
 		Actions:      info.Charm.Actions(),
+		LXDProfile:   newLXDProfileDoc(info.LXDProfile),
 		BundleSha256: info.SHA256,
 		StoragePath:  info.StoragePath,
 	}
@@ -166,6 +211,7 @@ func updateCharmOps(
 		{"config", safeConfig(info.Charm)},
 		{"actions", info.Charm.Actions()},
 		{"metrics", info.Charm.Metrics()},
+		{"lxd-profile", newLXDProfileDoc(info.LXDProfile)},
 		{"storagepath", info.StoragePath},
 		{"bundlesha256", info.SHA256},
 		{"pendingupload", false},
@@ -328,6 +374,26 @@ func (c *Charm) Actions() *charm.Actions {
 	return c.doc.Actions
 }
 
+// LXDProfile returns the LXD profile supplied by the charm, or nil
+// if the charm does not supply one.
+func (c *Charm) LXDProfile() *lxdprofile.Profile {
+	doc := c.doc.LXDProfile
+	if doc == nil {
+		return nil
+	}
+	profile := &lxdprofile.Profile{
+		Description: doc.Description,
+		Config:      replaceKeys(doc.Config, unescapeReplacer),
+	}
+	if len(doc.Devices) > 0 {
+		profile.Devices = make(map[string]map[string]string)
+		for name, device := range doc.Devices {
+			profile.Devices[unescapeReplacer.Replace(name)] = replaceKeys(device, unescapeReplacer)
+		}
+	}
+	return profile
+}
+
 // StoragePath returns the storage path of the charm bundle.
 func (c *Charm) StoragePath() string {
 	return c.doc.StoragePath
@@ -18,6 +18,7 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testcharms"
 )
@@ -126,6 +127,33 @@ func (s *CharmSuite) TestAddCharmWithAuth(c *gc.C) {
 	c.Assert(ms, gc.DeepEquals, info.Macaroon)
 }
 
+func (s *CharmSuite) TestAddCharmWithLXDProfile(c *gc.C) {
+	info := s.dummyCharm(c, "")
+	info.LXDProfile = &lxdprofile.Profile{
+		Description: "nested containers",
+		Config: map[string]string{
+			"security.nesting": "true",
+		},
+		Devices: map[string]map[string]string{
+			"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+		},
+	}
+	_, err := s.State.AddCharm(info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	dummy, err := s.State.Charm(info.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dummy.LXDProfile(), jc.DeepEquals, info.LXDProfile)
+}
+
+func (s *CharmSuite) TestAddCharmWithoutLXDProfile(c *gc.C) {
+	info := s.dummyCharm(c, "")
+	info.LXDProfile = &lxdprofile.Profile{Description: "empty"}
+	dummy, err := s.State.AddCharm(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dummy.LXDProfile(), gc.IsNil)
+}
+
 func (s *CharmSuite) TestAddCharmUpdatesPlaceholder(c *gc.C) {
 	// Check that adding charms updates any existing placeholder charm
 	// with the same URL.
@@ -31,6 +31,7 @@ const (
 	cleanupAttachmentsForDyingFilesystem cleanupKind = "filesystemAttachments"
 	cleanupModelsForDyingController      cleanupKind = "models"
 	cleanupMachinesForDyingModel         cleanupKind = "modelMachines"
+	cleanupSecretAudit                   cleanupKind = "secretAudit"
 )
 
 // cleanupDoc represents a potentially large set of documents that should be
@@ -108,6 +109,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupModelsForDyingController()
 		case cleanupMachinesForDyingModel:
 			err = st.cleanupMachinesForDyingModel()
+		case cleanupSecretAudit:
+			err = st.cleanupSecretAudit(doc.Prefix)
 		default:
 			handler, ok := cleanupHandlers[doc.Kind]
 			if !ok {
@@ -169,6 +172,21 @@ func (st *State) cleanupRelationSettings(prefix string) error {
 	return nil
 }
 
+// cleanupSecretAudit removes the audit trail of the removed secret with
+// the given ID.
+func (st *State) cleanupSecretAudit(secretID string) error {
+	audit, closer := st.getCollection(secretAuditC)
+	defer closer()
+	// Audit records of removed secrets are not otherwise referenced,
+	// and are not watched, so are safe to delete directly.
+	auditW := audit.Writeable()
+
+	if _, err := auditW.RemoveAll(bson.D{{"secret-id", secretID}}); err != nil {
+		return errors.Annotatef(err, "cannot remove audit records of secret %q", secretID)
+	}
+	return nil
+}
+
 // cleanupModelsForDyingController sets all models to dying, if
 // they are not already Dying or Dead. It's expected to be used when a
 // controller is destroyed.
@@ -62,8 +62,11 @@ func (st *State) UpdateCloudCredentials(user names.UserTag, cloudName string, cr
 		if err != nil {
 			return nil, errors.Annotate(err, "validating cloud credentials")
 		}
-		ops = append(ops, updateCloudCredentialsOps(user, cloudName, credentials)...)
-		return ops, nil
+		updateOps, err := st.updateCloudCredentialsOps(user, cloudName, credentials)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, updateOps...), nil
 	}
 	if err := st.run(buildTxn); err != nil {
 		return errors.Annotatef(
@@ -74,28 +77,148 @@ func (st *State) UpdateCloudCredentials(user names.UserTag, cloudName string, cr
 	return nil
 }
 
+// UpdateCloudCredentialsAndModels updates the user's cloud credentials
+// and, in the same transaction, applies the given config attributes,
+// keyed by model UUID, to the models that use them. Each model's new
+// config is validated before anything is written, and the transaction
+// asserts that every model is still alive and still uses the credential
+// named for it in modelCredentials.
+func (st *State) UpdateCloudCredentialsAndModels(
+	user names.UserTag,
+	cloudName string,
+	credentials map[string]cloud.Credential,
+	modelCredentials map[string]string,
+	modelAttrs map[string]map[string]interface{},
+) error {
+	cloud, err := st.Cloud(cloudName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops, err := validateCloudCredentials(cloud, cloudName, credentials)
+	if err != nil {
+		return errors.Annotate(err, "validating cloud credentials")
+	}
+	updateOps, err := st.updateCloudCredentialsOps(user, cloudName, credentials)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, updateOps...)
+	for modelUUID, attrs := range modelAttrs {
+		modelOps, err := st.updateCredentialModelOps(modelUUID, modelCredentials[modelUUID], attrs)
+		if err != nil {
+			return errors.Annotatef(err, "updating model %q", modelUUID)
+		}
+		ops = append(ops, modelOps...)
+	}
+	// The model settings live in other models, so the transaction must
+	// not be filtered to this state's model.
+	if err := st.runRawTransaction(ops); err == txn.ErrAborted {
+		return errors.Errorf(
+			"updating cloud credentials for user %q, cloud %q: credentials or models changed concurrently",
+			user.String(), cloudName,
+		)
+	} else if err != nil {
+		return errors.Annotatef(
+			err, "updating cloud credentials for user %q, cloud %q",
+			user.String(), cloudName,
+		)
+	}
+	return nil
+}
+
+// updateCredentialModelOps returns the txn.Ops that apply the given
+// config attributes to the model with the given UUID, asserting that it
+// is alive and uses the named credential. The ops hold full document
+// ids, for use in a raw transaction.
+func (st *State) updateCredentialModelOps(modelUUID, credentialName string, attrs map[string]interface{}) ([]txn.Op, error) {
+	modelSt, err := st.ForModel(names.NewModelTag(modelUUID))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer modelSt.Close()
+
+	modelSettings, err := readSettings(modelSt, settingsC, modelGlobalKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	oldConfig, err := modelSt.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	validCfg, err := modelSt.buildAndValidateModelConfig(attrs, nil, oldConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelSettings.Update(validCfg.AllAttrs())
+	_, ops := modelSettings.settingsUpdateOps()
+	for i := range ops {
+		ops[i].Id = modelSt.docID(modelGlobalKey)
+	}
+	return append(ops, txn.Op{
+		C:  modelsC,
+		Id: modelUUID,
+		Assert: bson.D{
+			{"life", Alive},
+			{"cloud-credential", credentialName},
+		},
+	}), nil
+}
+
 // updateCloudCredentialsOps returns a list of txn.Ops that will create
 // or update a set of cloud credentials for a user.
-func updateCloudCredentialsOps(user names.UserTag, cloudName string, credentials map[string]cloud.Credential) []txn.Op {
-	owner := user.Canonical()
+func (st *State) updateCloudCredentialsOps(user names.UserTag, cloudName string, credentials map[string]cloud.Credential) ([]txn.Op, error) {
+	coll, cleanup := st.getCollection(cloudCredentialsC)
+	defer cleanup()
+
 	ops := make([]txn.Op, 0, len(credentials))
 	for name, credential := range credentials {
-		ops = append(ops, txn.Op{
-			C:      cloudCredentialsC,
-			Id:     cloudCredentialDocID(user, cloudName, name),
-			Assert: txn.DocMissing,
-			Insert: &cloudCredentialDoc{
-				Owner:      owner,
-				Cloud:      cloudName,
-				Name:       name,
-				AuthType:   string(credential.AuthType()),
-				Attributes: credential.Attributes(),
-			},
-		})
+		docID := cloudCredentialDocID(user, cloudName, name)
+		count, err := coll.FindId(docID).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if count > 0 {
+			ops = append(ops, txn.Op{
+				C:      cloudCredentialsC,
+				Id:     docID,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"auth-type", string(credential.AuthType())},
+					{"attributes", credential.Attributes()},
+				}}},
+			})
+			continue
+		}
+		ops = append(ops, createCloudCredentialOp(user, cloudName, name, credential))
+	}
+	return ops, nil
+}
+
+// createCloudCredentialsOps returns a list of txn.Ops that will create
+// a set of cloud credentials for a user, asserting none of them exist.
+func createCloudCredentialsOps(user names.UserTag, cloudName string, credentials map[string]cloud.Credential) []txn.Op {
+	ops := make([]txn.Op, 0, len(credentials))
+	for name, credential := range credentials {
+		ops = append(ops, createCloudCredentialOp(user, cloudName, name, credential))
 	}
 	return ops
 }
 
+func createCloudCredentialOp(user names.UserTag, cloudName, name string, credential cloud.Credential) txn.Op {
+	return txn.Op{
+		C:      cloudCredentialsC,
+		Id:     cloudCredentialDocID(user, cloudName, name),
+		Assert: txn.DocMissing,
+		Insert: &cloudCredentialDoc{
+			Owner:      user.Canonical(),
+			Cloud:      cloudName,
+			Name:       name,
+			AuthType:   string(credential.AuthType()),
+			Attributes: credential.Attributes(),
+		},
+	}
+}
+
 func cloudCredentialDocID(user names.UserTag, cloudName, credentialName string) string {
 	return fmt.Sprintf("%s#%s#%s", user.Canonical(), cloudName, credentialName)
 }
@@ -0,0 +1,138 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type CloudCredentialsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&CloudCredentialsSuite{})
+
+func (s *CloudCredentialsSuite) TestUpdateCloudCredentialsNew(c *gc.C) {
+	owner := names.NewUserTag("bob")
+	cred := cloud.NewCredential(cloud.EmptyAuthType, map[string]string{"foo": "bar"})
+	err := s.State.UpdateCloudCredentials(owner, "dummy", map[string]cloud.Credential{
+		"cred1": cred,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cred.Label = "cred1"
+	creds, err := s.State.CloudCredentials(owner, "dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(creds, jc.DeepEquals, map[string]cloud.Credential{"cred1": cred})
+}
+
+func (s *CloudCredentialsSuite) TestUpdateCloudCredentialsReplacesExisting(c *gc.C) {
+	owner := names.NewUserTag("bob")
+	err := s.State.UpdateCloudCredentials(owner, "dummy", map[string]cloud.Credential{
+		"cred1": cloud.NewCredential(cloud.EmptyAuthType, map[string]string{"foo": "bar"}),
+		"cred2": cloud.NewCredential(cloud.EmptyAuthType, map[string]string{"baz": "qux"}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rotated := cloud.NewCredential(cloud.EmptyAuthType, map[string]string{"foo": "rotated"})
+	err = s.State.UpdateCloudCredentials(owner, "dummy", map[string]cloud.Credential{
+		"cred1": rotated,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	untouched := cloud.NewCredential(cloud.EmptyAuthType, map[string]string{"baz": "qux"})
+	untouched.Label = "cred2"
+	rotated.Label = "cred1"
+	creds, err := s.State.CloudCredentials(owner, "dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(creds, jc.DeepEquals, map[string]cloud.Credential{
+		"cred1": rotated,
+		"cred2": untouched,
+	})
+}
+
+func (s *CloudCredentialsSuite) TestUpdateCloudCredentialsInvalidAuthType(c *gc.C) {
+	owner := names.NewUserTag("bob")
+	err := s.State.UpdateCloudCredentials(owner, "dummy", map[string]cloud.Credential{
+		"cred1": cloud.NewCredential(cloud.UserPassAuthType, nil),
+	})
+	c.Assert(err, gc.ErrorMatches, `updating cloud credentials for user "user-bob", cloud "dummy": validating cloud credentials: credential "cred1" with auth-type "userpass" is not supported \(expected one of \["empty"\]\)`)
+}
+
+func (s *CloudCredentialsSuite) newCredentialModel(c *gc.C, owner names.UserTag, credentialName string) *state.State {
+	cfg := testing.CustomModelConfig(c, testing.Attrs{
+		"name": "credential-model",
+		"uuid": utils.MustNewUUID().String(),
+		"foo":  "bar",
+	})
+	_, st, err := s.State.NewModel(state.ModelArgs{
+		CloudName:       "dummy",
+		CloudCredential: credentialName,
+		Config:          cfg,
+		Owner:           owner,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { st.Close() })
+	return st
+}
+
+func (s *CloudCredentialsSuite) TestUpdateCloudCredentialsAndModels(c *gc.C) {
+	owner := names.NewUserTag("bob")
+	err := s.State.UpdateCloudCredentials(owner, "dummy", map[string]cloud.Credential{
+		"cred1": cloud.NewCredential(cloud.EmptyAuthType, map[string]string{"foo": "bar"}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	st := s.newCredentialModel(c, owner, "cred1")
+
+	rotated := cloud.NewCredential(cloud.EmptyAuthType, map[string]string{"foo": "rotated"})
+	err = s.State.UpdateCloudCredentialsAndModels(owner, "dummy",
+		map[string]cloud.Credential{"cred1": rotated},
+		map[string]string{st.ModelUUID(): "cred1"},
+		map[string]map[string]interface{}{st.ModelUUID(): {"foo": "rotated"}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rotated.Label = "cred1"
+	creds, err := s.State.CloudCredentials(owner, "dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(creds, jc.DeepEquals, map[string]cloud.Credential{"cred1": rotated})
+	cfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["foo"], gc.Equals, "rotated")
+}
+
+func (s *CloudCredentialsSuite) TestUpdateCloudCredentialsAndModelsCredentialChanged(c *gc.C) {
+	owner := names.NewUserTag("bob")
+	original := cloud.NewCredential(cloud.EmptyAuthType, map[string]string{"foo": "bar"})
+	err := s.State.UpdateCloudCredentials(owner, "dummy", map[string]cloud.Credential{
+		"cred1": original,
+		"cred2": original,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	st := s.newCredentialModel(c, owner, "cred2")
+
+	err = s.State.UpdateCloudCredentialsAndModels(owner, "dummy",
+		map[string]cloud.Credential{
+			"cred1": cloud.NewCredential(cloud.EmptyAuthType, map[string]string{"foo": "rotated"}),
+		},
+		map[string]string{st.ModelUUID(): "cred1"},
+		map[string]map[string]interface{}{st.ModelUUID(): {"foo": "rotated"}},
+	)
+	c.Assert(err, gc.ErrorMatches, `updating cloud credentials for user "user-bob", cloud "dummy": credentials or models changed concurrently`)
+
+	// Neither the credential nor the model was changed.
+	creds, err := s.State.CloudCredentials(owner, "dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(creds["cred1"].Attributes(), jc.DeepEquals, map[string]string{"foo": "bar"})
+	cfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["foo"], gc.Equals, "bar")
+}
@@ -25,3 +25,36 @@ func (st *State) ControllerConfig() (jujucontroller.Config, error) {
 	}
 	return settings.Map(), nil
 }
+
+// UpdateControllerConfig allows changing some of the configuration
+// for the controller. Changes passed in updateAttrs will be applied
+// to the current config, and keys in removeAttrs will be unset (and
+// so revert to their defaults). Only a subset of keys can be changed
+// after bootstrapping.
+func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, removeAttrs []string) error {
+	if len(updateAttrs)+len(removeAttrs) == 0 {
+		return nil
+	}
+	settings, err := readSettings(st, controllersC, controllerSettingsGlobalKey)
+	if err != nil {
+		return errors.Annotate(err, "controller config")
+	}
+	validCfg, err := jujucontroller.Config(settings.Map()).ValidateUpdate(updateAttrs, removeAttrs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, attr := range removeAttrs {
+		settings.Delete(attr)
+	}
+	for attr := range updateAttrs {
+		settings.Set(attr, validCfg[attr])
+	}
+	_, err = settings.Write()
+	return errors.Trace(err)
+}
+
+// WatchControllerConfig returns a NotifyWatcher that notifies
+// when the controller config changes.
+func (st *State) WatchControllerConfig() NotifyWatcher {
+	return newEntityWatcher(st, controllersC, controllerSettingsGlobalKey)
+}
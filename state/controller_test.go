@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 )
 
 type ControllerConfigSuite struct {
@@ -24,7 +25,9 @@ func (s *ControllerConfigSuite) TestControllerAndModelConfigInitialisation(c *gc
 	c.Assert(err, jc.ErrorIsNil)
 
 	optional := func(attr string) bool {
-		return attr == controller.IdentityURL || attr == controller.IdentityPublicKey
+		return attr == controller.IdentityURL ||
+			attr == controller.IdentityPublicKey ||
+			attr == controller.APIAllowKey
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -42,3 +45,59 @@ func (s *ControllerConfigSuite) TestControllerConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg["controller-uuid"], gc.Equals, m.ControllerUUID())
 }
+
+func (s *ControllerConfigSuite) TestUpdateControllerConfig(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AuditingEnabled: "true",
+		controller.IdentityURL:     "https://example.com",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditingEnabled(), jc.IsTrue)
+	c.Assert(cfg.IdentityURL(), gc.Equals, "https://example.com")
+
+	err = s.State.UpdateControllerConfig(nil, []string{controller.IdentityURL})
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err = s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := cfg[controller.IdentityURL]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ControllerConfigSuite) TestUpdateControllerConfigRejectsInvalid(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.ApiPort: 1234,
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot change "api-port" after bootstrap`)
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.IdentityURL: "http://example.com",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, "URL needs to be https")
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIPort(), gc.Not(gc.Equals), 1234)
+	c.Assert(cfg.IdentityURL(), gc.Equals, "")
+}
+
+func (s *ControllerConfigSuite) TestWatchControllerConfig(c *gc.C) {
+	w := s.State.WatchControllerConfig()
+	defer statetesting.AssertStop(c, w)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AuditingEnabled: true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changes to other controller documents are not reported.
+	err = s.State.SetAPIHostPorts(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
@@ -15,6 +15,7 @@ import (
 // and asks the InstanceDistributor policy (if any) which ones are suitable
 // for assigning the unit to. If there is no InstanceDistributor, or the
 // distribution group is empty, then all of the candidates will be returned.
+// Any zones constraint on the unit limits the zones considered.
 func distributeUnit(u *Unit, candidates []instance.Id) ([]instance.Id, error) {
 	if len(candidates) == 0 {
 		return nil, nil
@@ -38,7 +39,15 @@ func distributeUnit(u *Unit, candidates []instance.Id) ([]instance.Id, error) {
 	if len(distributionGroup) == 0 {
 		return candidates, nil
 	}
-	return distributor.DistributeInstances(candidates, distributionGroup)
+	cons, err := u.Constraints()
+	if err != nil {
+		return nil, err
+	}
+	var limitZones []string
+	if cons.HasZones() {
+		limitZones = *cons.Zones
+	}
+	return distributor.DistributeInstances(candidates, distributionGroup, limitZones)
 }
 
 // ServiceInstances returns the instance IDs of provisioned
@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 )
@@ -26,13 +27,15 @@ var _ = gc.Suite(&InstanceDistributorSuite{})
 type mockInstanceDistributor struct {
 	candidates        []instance.Id
 	distributionGroup []instance.Id
+	limitZones        []string
 	result            []instance.Id
 	err               error
 }
 
-func (p *mockInstanceDistributor) DistributeInstances(candidates, distributionGroup []instance.Id) ([]instance.Id, error) {
+func (p *mockInstanceDistributor) DistributeInstances(candidates, distributionGroup []instance.Id, limitZones []string) ([]instance.Id, error) {
 	p.candidates = candidates
 	p.distributionGroup = distributionGroup
+	p.limitZones = limitZones
 	result := p.result
 	if result == nil {
 		result = candidates
@@ -89,6 +92,17 @@ func (s *InstanceDistributorSuite) TestDistributeInstances(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, eligibleMachinesInUse)
 }
 
+func (s *InstanceDistributorSuite) TestDistributeInstancesWithZones(c *gc.C) {
+	err := s.wordpress.SetConstraints(constraints.MustParse("zones=az1,az2"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.setupScenario(c)
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = unit.AssignToCleanMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.distributor.limitZones, jc.DeepEquals, []string{"az1", "az2"})
+}
+
 func (s *InstanceDistributorSuite) TestDistributeInstancesInvalidInstances(c *gc.C) {
 	s.setupScenario(c)
 	unit, err := s.wordpress.AddUnit()
@@ -271,6 +271,56 @@ func validateEndpointBindingsForCharm(st *State, bindings map[string]string, cha
 	return nil
 }
 
+// validateEndpointBindingsForUnits verifies that every machine hosting one of
+// the given units has at least one address in each space any endpoint is
+// being bound to. Units not yet assigned to a machine are skipped, as their
+// machine will be provisioned taking the bindings into account. An error
+// satisfying errors.IsNotValid() is returned for the first machine found
+// without a suitable address.
+func validateEndpointBindingsForUnits(st *State, bindings map[string]string, units []*Unit) error {
+	boundSpaces := set.NewStrings()
+	for _, space := range bindings {
+		if space != "" {
+			boundSpaces.Add(space)
+		}
+	}
+	if boundSpaces.IsEmpty() {
+		return nil
+	}
+
+	checkedMachines := set.NewStrings()
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if checkedMachines.Contains(machineId) {
+			continue
+		}
+		checkedMachines.Add(machineId)
+
+		machine, err := st.Machine(machineId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		machineSpaces, err := machine.spacesOfAddresses()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, space := range boundSpaces.SortedValues() {
+			if !machineSpaces.Contains(space) {
+				return errors.NotValidf(
+					"binding to space %q: machine %q hosting unit %q has no address in that space",
+					space, machineId, unit.Name(),
+				)
+			}
+		}
+	}
+	return nil
+}
+
 // DefaultEndpointBindingsForCharm populates a bindings map containing each
 // endpoint of the given charm metadata (relation name or extra-binding name)
 // bound to an empty space.
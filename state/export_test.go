@@ -38,6 +38,7 @@ const (
 	StorageInstancesC = storageInstancesC
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
+	SecretsC          = secretsC
 )
 
 var (
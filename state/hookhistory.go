@@ -0,0 +1,136 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// HookRecord describes a single execution of a hook by a unit.
+type HookRecord struct {
+	// Hook is the name of the hook that ran.
+	Hook string
+
+	// RelationId is the id of the relation the hook ran for, or -1 if
+	// it is not a relation hook.
+	RelationId int
+
+	// RemoteUnit is the name of the remote unit the hook ran for, if
+	// any.
+	RemoteUnit string
+
+	// Started and Finished record when the hook started and finished
+	// running.
+	Started  time.Time
+	Finished time.Time
+
+	// ExitCode is the exit code of the hook's process, or -1 if the
+	// hook did not exit normally.
+	ExitCode int
+
+	// Error holds the error the hook failed with, if any.
+	Error string
+
+	// Output holds the last lines written by the hook.
+	Output []string
+}
+
+// hookHistoryDoc records a single execution of a hook by a unit.
+type hookHistoryDoc struct {
+	ModelUUID  string   `bson:"model-uuid"`
+	Unit       string   `bson:"unit"`
+	Hook       string   `bson:"hook"`
+	RelationId int      `bson:"relation-id"`
+	RemoteUnit string   `bson:"remote-unit,omitempty"`
+	Started    int64    `bson:"started"`
+	Finished   int64    `bson:"finished"`
+	ExitCode   int      `bson:"exit-code"`
+	Error      string   `bson:"error,omitempty"`
+	Output     []string `bson:"output,omitempty"`
+}
+
+// AddHookHistory records the execution of the supplied hooks by the
+// unit.
+func (u *Unit) AddHookHistory(records []HookRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(records))
+	for i, record := range records {
+		if record.Hook == "" {
+			return errors.NotValidf("hook record without hook name")
+		}
+		docs[i] = &hookHistoryDoc{
+			Unit:       u.Name(),
+			Hook:       record.Hook,
+			RelationId: record.RelationId,
+			RemoteUnit: record.RemoteUnit,
+			Started:    record.Started.UnixNano(),
+			Finished:   record.Finished.UnixNano(),
+			ExitCode:   record.ExitCode,
+			Error:      record.Error,
+			Output:     record.Output,
+		}
+	}
+	history, closer := u.st.getCollection(hookHistoryC)
+	defer closer()
+	if err := history.Writeable().Insert(docs...); err != nil {
+		return errors.Annotatef(err, "cannot record hook history for unit %q", u.Name())
+	}
+	return nil
+}
+
+// HookHistory returns the hooks most recently run by the unit, newest
+// first. If size is positive, at most size records are returned.
+func (u *Unit) HookHistory(size int) ([]HookRecord, error) {
+	history, closer := u.st.getCollection(hookHistoryC)
+	defer closer()
+
+	query := history.Find(bson.D{{"unit", u.Name()}}).Sort("-finished")
+	if size > 0 {
+		query = query.Limit(size)
+	}
+	var docs []hookHistoryDoc
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook history for unit %q", u.Name())
+	}
+	records := make([]HookRecord, len(docs))
+	for i, doc := range docs {
+		records[i] = HookRecord{
+			Hook:       doc.Hook,
+			RelationId: doc.RelationId,
+			RemoteUnit: doc.RemoteUnit,
+			Started:    time.Unix(0, doc.Started),
+			Finished:   time.Unix(0, doc.Finished),
+			ExitCode:   doc.ExitCode,
+			Error:      doc.Error,
+			Output:     doc.Output,
+		}
+	}
+	return records, nil
+}
+
+// eraseHookHistory removes all hook history recorded for the unit.
+func (u *Unit) eraseHookHistory() error {
+	history, closer := u.st.getCollection(hookHistoryC)
+	defer closer()
+	_, err := history.Writeable().RemoveAll(bson.D{{"unit", u.Name()}})
+	return err
+}
+
+// PruneHookHistory removes hook history entries until only those
+// newer than <maxHistoryTime> remain and the collection is smaller
+// than <maxHistoryMB>, in the same way PruneStatusHistory does for
+// status history.
+func PruneHookHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
+	if err := validatePruneCriteria(maxHistoryTime, maxHistoryMB); err != nil {
+		return errors.Trace(err)
+	}
+	history, closer := st.getRawCollection(hookHistoryC)
+	defer closer()
+	return pruneHistory(history, "finished", "hook history", maxHistoryTime, maxHistoryMB)
+}
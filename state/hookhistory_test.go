@@ -0,0 +1,114 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type HookHistorySuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = factory.NewFactory(s.State).MakeUnit(c, nil)
+}
+
+func (s *HookHistorySuite) TestHookHistoryEmpty(c *gc.C) {
+	records, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 0)
+}
+
+func (s *HookHistorySuite) TestAddHookHistory(c *gc.C) {
+	now := time.Now().Round(time.Second)
+	install := state.HookRecord{
+		Hook:       "install",
+		RelationId: -1,
+		Started:    now,
+		Finished:   now.Add(time.Second),
+		Output:     []string{"installing", "done"},
+	}
+	joined := state.HookRecord{
+		Hook:       "db-relation-joined",
+		RelationId: 1,
+		RemoteUnit: "mysql/0",
+		Started:    now.Add(2 * time.Second),
+		Finished:   now.Add(3 * time.Second),
+		ExitCode:   1,
+		Error:      "exit status 1",
+	}
+	err := s.unit.AddHookHistory([]state.HookRecord{install, joined})
+	c.Assert(err, jc.ErrorIsNil)
+
+	records, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 2)
+	c.Check(records[0].Started.Equal(joined.Started), jc.IsTrue)
+	records[0].Started, records[0].Finished = joined.Started, joined.Finished
+	c.Check(records[0], jc.DeepEquals, joined)
+	c.Check(records[1].Hook, gc.Equals, "install")
+	c.Check(records[1].Output, jc.DeepEquals, install.Output)
+
+	records, err = s.unit.HookHistory(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0].Hook, gc.Equals, "db-relation-joined")
+}
+
+func (s *HookHistorySuite) TestAddHookHistoryRequiresHook(c *gc.C) {
+	err := s.unit.AddHookHistory([]state.HookRecord{{RelationId: -1}})
+	c.Assert(err, gc.ErrorMatches, "hook record without hook name not valid")
+}
+
+func (s *HookHistorySuite) TestHookHistoryRemovedWithUnit(c *gc.C) {
+	now := time.Now()
+	err := s.unit.AddHookHistory([]state.HookRecord{{
+		Hook: "install", RelationId: -1, Started: now, Finished: now,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	records, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 0)
+}
+
+func (s *HookHistorySuite) TestPruneHookHistoryByDate(c *gc.C) {
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+	err := s.unit.AddHookHistory([]state.HookRecord{{
+		Hook: "install", RelationId: -1, Started: old, Finished: old,
+	}, {
+		Hook: "start", RelationId: -1, Started: now, Finished: now,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneHookHistory(s.State, 24*time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	records, err := s.unit.HookHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0].Hook, gc.Equals, "start")
+}
+
+func (s *HookHistorySuite) TestPruneHookHistoryValidatesCriteria(c *gc.C) {
+	err := state.PruneHookHistory(s.State, 0, 0)
+	c.Assert(err, gc.ErrorMatches, "backlog size and time constraints are both 0 not valid")
+}
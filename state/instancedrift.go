@@ -0,0 +1,110 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/instance"
+)
+
+// LeakedInstance describes a cloud instance that is tagged as
+// belonging to the model, but which is not recorded against any
+// machine in the model.
+type LeakedInstance struct {
+	// InstanceId is the provider-specific ID of the instance.
+	InstanceId instance.Id
+
+	// FirstSeen records when the instance was first reported
+	// as leaked.
+	FirstSeen time.Time
+}
+
+// leakedInstanceDoc represents the MongoDB document that records
+// an instance reported as leaked.
+type leakedInstanceDoc struct {
+	DocID      string    `bson:"_id"`
+	ModelUUID  string    `bson:"model-uuid"`
+	InstanceId string    `bson:"instanceid"`
+	FirstSeen  time.Time `bson:"first-seen"`
+}
+
+// LeakedInstances returns the instances most recently reported as
+// leaked, ordered by instance ID.
+func (st *State) LeakedInstances() ([]LeakedInstance, error) {
+	coll, closer := st.getCollection(leakedInstancesC)
+	defer closer()
+
+	var docs []leakedInstanceDoc
+	if err := coll.Find(nil).Sort("instanceid").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get leaked instances")
+	}
+	result := make([]LeakedInstance, len(docs))
+	for i, doc := range docs {
+		result[i] = LeakedInstance{
+			InstanceId: instance.Id(doc.InstanceId),
+			FirstSeen:  doc.FirstSeen,
+		}
+	}
+	return result, nil
+}
+
+// SetLeakedInstances replaces the set of instances reported as leaked.
+// Instances that were already reported keep their original FirstSeen
+// time; instances no longer reported are forgotten.
+func (st *State) SetLeakedInstances(ids []instance.Id) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		existing, err := st.LeakedInstances()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		wanted := make(map[instance.Id]bool)
+		for _, id := range ids {
+			wanted[id] = true
+		}
+		var ops []txn.Op
+		for _, leaked := range existing {
+			if wanted[leaked.InstanceId] {
+				delete(wanted, leaked.InstanceId)
+				continue
+			}
+			ops = append(ops, txn.Op{
+				C:      leakedInstancesC,
+				Id:     st.docID(string(leaked.InstanceId)),
+				Assert: txn.DocExists,
+				Remove: true,
+			})
+		}
+		added := make([]string, 0, len(wanted))
+		for id := range wanted {
+			added = append(added, string(id))
+		}
+		sort.Strings(added)
+		now := nowToTheSecond()
+		for _, id := range added {
+			ops = append(ops, txn.Op{
+				C:      leakedInstancesC,
+				Id:     st.docID(id),
+				Assert: txn.DocMissing,
+				Insert: &leakedInstanceDoc{
+					DocID:      st.docID(id),
+					ModelUUID:  st.ModelUUID(),
+					InstanceId: id,
+					FirstSeen:  now,
+				},
+			})
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	err := st.run(buildTxn)
+	return errors.Annotate(err, "cannot set leaked instances")
+}
@@ -0,0 +1,57 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+)
+
+type LeakedInstancesSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&LeakedInstancesSuite{})
+
+func (s *LeakedInstancesSuite) TestNoLeakedInstances(c *gc.C) {
+	leaked, err := s.State.LeakedInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(leaked, gc.HasLen, 0)
+}
+
+func (s *LeakedInstancesSuite) TestSetLeakedInstances(c *gc.C) {
+	err := s.State.SetLeakedInstances([]instance.Id{"i-2", "i-1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	leaked, err := s.State.LeakedInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(leaked, gc.HasLen, 2)
+	c.Assert(leaked[0].InstanceId, gc.Equals, instance.Id("i-1"))
+	c.Assert(leaked[1].InstanceId, gc.Equals, instance.Id("i-2"))
+	c.Assert(leaked[0].FirstSeen.IsZero(), jc.IsFalse)
+	firstSeen := leaked[1].FirstSeen
+
+	// Setting the same instances again is a no-op.
+	err = s.State.SetLeakedInstances([]instance.Id{"i-1", "i-2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Instances no longer reported are forgotten, while those
+	// still reported keep their original FirstSeen time.
+	err = s.State.SetLeakedInstances([]instance.Id{"i-2", "i-3"})
+	c.Assert(err, jc.ErrorIsNil)
+	leaked, err = s.State.LeakedInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(leaked, gc.HasLen, 2)
+	c.Assert(leaked[0].InstanceId, gc.Equals, instance.Id("i-2"))
+	c.Assert(leaked[0].FirstSeen.Equal(firstSeen), jc.IsTrue)
+	c.Assert(leaked[1].InstanceId, gc.Equals, instance.Id("i-3"))
+
+	err = s.State.SetLeakedInstances(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	leaked, err = s.State.LeakedInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(leaked, gc.HasLen, 0)
+}
@@ -175,6 +175,30 @@ func (s *ipAddressesStateSuite) TestSubnetMethodReturnsNotFoundErrorWithUnknownO
 	}
 }
 
+func (s *ipAddressesStateSuite) TestSubnetMethodReturnsFanSubnet(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{
+		CIDR:             "252.16.0.0/12",
+		FanLocalUnderlay: "172.31.16.0/20",
+		FanOverlay:       "252.0.0.0/8",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, addresses := s.addNamedDeviceWithAddresses(c, "eth0", "252.16.5.23/8", "252.64.0.1/8")
+	byValue := make(map[string]*state.Address)
+	for _, addr := range addresses {
+		byValue[addr.Value()] = addr
+	}
+
+	result, err := byValue["252.16.5.23"].Subnet()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.CIDR(), gc.Equals, "252.16.0.0/12")
+	c.Assert(byValue["252.16.5.23"].SubnetCIDR(), gc.Equals, "252.16.0.0/12")
+
+	// Addresses outside every known segment keep the overlay CIDR.
+	_, err = byValue["252.64.0.1"].Subnet()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(byValue["252.64.0.1"].SubnetCIDR(), gc.Equals, "252.0.0.0/8")
+}
+
 func (s *ipAddressesStateSuite) TestRemoveSuccess(c *gc.C) {
 	_, existingAddresses := s.addNamedDeviceWithAddresses(c, "eth0", "0.1.2.3/24")
 
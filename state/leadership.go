@@ -37,6 +37,31 @@ func (st *State) LeadershipChecker() leadership.Checker {
 	return leadershipChecker{st.workers.LeadershipManager()}
 }
 
+// ApplicationLeader returns the name of the unit currently holding
+// leadership of the named application. A NotFound error is returned
+// if no unit of the application is leader.
+func (st *State) ApplicationLeader(applicationName string) (string, error) {
+	app, err := st.Application(applicationName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	manager := st.workers.LeadershipManager()
+	for _, unit := range units {
+		err := manager.Token(applicationName, unit.Name()).Check(nil)
+		if errors.Cause(err) == corelease.ErrNotHeld {
+			continue
+		} else if err != nil {
+			return "", errors.Trace(err)
+		}
+		return unit.Name(), nil
+	}
+	return "", errors.NotFoundf("leader of application %q", applicationName)
+}
+
 // HackLeadership stops the state's internal leadership manager to prevent it
 // from interfering with apiserver shutdown.
 func (st *State) HackLeadership() {
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils"
+	"github.com/juju/utils/series"
 	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
@@ -1047,6 +1048,96 @@ func (m *Machine) Units() (units []*Unit, err error) {
 	return units, nil
 }
 
+// UpdateMachineSeries changes the series of the machine, and of every
+// unit assigned to it, after the machine's OS has been upgraded in place.
+// The charms of all the units must support the series; if force is true,
+// they need only support the OS of the series.
+func (m *Machine) UpdateMachineSeries(toSeries string, force bool) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		if m.doc.Series == toSeries {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if _, err := series.GetOSFromSeries(toSeries); err != nil {
+			return nil, errors.Trace(err)
+		}
+		// The units and their charms are read afresh on every attempt,
+		// and the ops below assert that they have not changed, so that
+		// units assigned, subordinates added or charms upgraded
+		// concurrently are also checked for support of the series.
+		units, err := m.Units()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineAssert := append(isAliveDoc, bson.DocElem{"series", m.doc.Series})
+		machineAssert = append(machineAssert, unchangedStringsAssert("principals", m.doc.Principals)...)
+		ops := []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: machineAssert,
+			Update: bson.D{{"$set", bson.D{{"series", toSeries}}}},
+		}}
+		checkedApps := make(map[string]bool)
+		for _, unit := range units {
+			app, err := unit.Application()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ch, _, err := app.Charm()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := checkCharmSeries(ch, toSeries, force); err != nil {
+				return nil, errors.Annotatef(err, "unit %q", unit.Name())
+			}
+			if !checkedApps[app.Name()] {
+				checkedApps[app.Name()] = true
+				ops = append(ops, txn.Op{
+					C:      applicationsC,
+					Id:     app.doc.DocID,
+					Assert: bson.D{{"charmurl", ch.URL()}},
+				})
+			}
+			unitAssert := bson.D{{"series", unit.doc.Series}}
+			if unit.IsPrincipal() {
+				unitAssert = append(unitAssert, unchangedStringsAssert("subordinates", unit.doc.Subordinates)...)
+			}
+			ops = append(ops, txn.Op{
+				C:      unitsC,
+				Id:     unit.doc.DocID,
+				Assert: unitAssert,
+				Update: bson.D{{"$set", bson.D{{"series", toSeries}}}},
+			})
+		}
+		return ops, nil
+	}
+	if err := m.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update series for machine %q", m)
+	}
+	m.doc.Series = toSeries
+	return nil
+}
+
+// unchangedStringsAssert returns a txn assertion that the named field
+// of a document holds exactly the given values, where an empty or
+// missing field matches no values.
+func unchangedStringsAssert(field string, values []string) bson.D {
+	if len(values) == 0 {
+		return bson.D{{"$or", []bson.D{
+			{{field, bson.D{{"$size", 0}}}},
+			{{field, bson.D{{"$exists", false}}}},
+		}}}
+	}
+	return bson.D{{field, values}}
+}
+
 // SetProvisioned sets the provider specific machine id, nonce and also metadata for
 // this machine. Once set, the instance id cannot be changed.
 //
@@ -692,6 +692,14 @@ func (m *Machine) newIPAddressDocFromArgs(args *LinkLayerDeviceAddress) (*ipAddr
 	addressValue := ip.String()
 	subnetCIDR := ipNet.String()
 	subnet, err := m.st.Subnet(subnetCIDR)
+	if errors.IsNotFound(err) {
+		// Addresses on a Fan bridge are within the whole overlay; link
+		// them to the segment owned by the host, if it is known.
+		subnet, err = m.st.fanSubnetForAddress(subnetCIDR, ip)
+		if err == nil {
+			subnetCIDR = subnet.CIDR()
+		}
+	}
 	if errors.IsNotFound(err) {
 		logger.Infof(
 			"address %q on machine %q uses unknown or machine-local subnet %q",
@@ -828,6 +836,28 @@ func (m *Machine) AllAddresses() ([]*Address, error) {
 	return allAddresses, nil
 }
 
+// spacesOfAddresses returns the names of all spaces the machine has at least
+// one address in. Addresses not linked to a known subnet are ignored.
+func (m *Machine) spacesOfAddresses() (set.Strings, error) {
+	addresses, err := m.AllAddresses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spaces := set.NewStrings()
+	for _, addr := range addresses {
+		subnet, err := addr.Subnet()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "cannot get subnet for address %q", addr)
+		}
+		if space := subnet.SpaceName(); space != "" {
+			spaces.Add(space)
+		}
+	}
+	return spaces, nil
+}
+
 // SetParentLinkLayerDevicesBeforeTheirChildren splits the given devicesArgs
 // into multiple sets of args and calls SetLinkLayerDevices() for each set, such
 // that child devices are set only after their parents.
@@ -797,6 +797,86 @@ func (s *MachineSuite) TestMachineAvailabilityZoneMissing(c *gc.C) {
 	c.Check(zone, gc.Equals, "")
 }
 
+func (s *MachineSuite) TestUpdateMachineSeries(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	svc := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+	unit, err := svc.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("precise", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.UpdateMachineSeries("trusty", false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.Series(), gc.Equals, "trusty")
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.Series(), gc.Equals, "trusty")
+	err = unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Series(), gc.Equals, "trusty")
+}
+
+func (s *MachineSuite) TestUpdateMachineSeriesUnsupportedByUnit(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	svc := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+	unit, err := svc.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("precise", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.UpdateMachineSeries("xenial", false)
+	c.Assert(err, gc.ErrorMatches, `cannot update series for machine "2": unit "application/0": series "xenial" not supported by charm .*`)
+
+	err = machine.UpdateMachineSeries("xenial", true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Series(), gc.Equals, "xenial")
+}
+
+func (s *MachineSuite) TestUpdateMachineSeriesUnitAssignedConcurrently(c *gc.C) {
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	svc := state.AddTestingServiceForSeries(c, s.State, "precise", "application", ch)
+	unit, err := svc.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("precise", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	preciseCharm := state.AddTestingCharmForSeries(c, s.State, "precise", "mysql")
+	mysql := state.AddTestingServiceForSeries(c, s.State, "precise", "mysql", preciseCharm)
+	mysqlUnit, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	defer state.SetBeforeHooks(c, s.State, func() {
+		c.Assert(mysqlUnit.AssignToMachine(machine), jc.ErrorIsNil)
+	}).Check()
+
+	err = machine.UpdateMachineSeries("trusty", false)
+	c.Assert(err, gc.ErrorMatches, `cannot update series for machine ".*": unit "mysql/0": charm ".*" only supports series "precise"`)
+	err = unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Series(), gc.Equals, "precise")
+}
+
+func (s *MachineSuite) TestUpdateMachineSeriesInvalidSeries(c *gc.C) {
+	err := s.machine.UpdateMachineSeries("nonsense", false)
+	c.Assert(err, gc.ErrorMatches, `cannot update series for machine "1": .*`)
+	c.Assert(s.machine.Series(), gc.Equals, "quantal")
+}
+
+func (s *MachineSuite) TestUpdateMachineSeriesDeadMachine(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.UpdateMachineSeries("trusty", false)
+	c.Assert(err, gc.ErrorMatches, `cannot update series for machine "1": not found or not alive`)
+}
+
 func (s *MachineSuite) TestMachineSetCheckProvisioned(c *gc.C) {
 	// Check before provisioning.
 	c.Assert(s.machine.CheckProvisioned("fake_nonce"), jc.IsFalse)
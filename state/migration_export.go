@@ -28,6 +28,9 @@ func (st *State) Export() (description.Model, error) {
 		dbModel: dbModel,
 		logger:  loggo.GetLogger("juju.state.export-model"),
 	}
+	if err := export.checkNoSecrets(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	if err := export.readAllStatuses(); err != nil {
 		return nil, errors.Annotate(err, "reading statuses")
 	}
@@ -133,6 +136,24 @@ type exporter struct {
 	units map[string][]*Unit
 }
 
+// checkNoSecrets returns an error if the model holds any charm secrets.
+// Secrets are encrypted with a key derived from the source controller's
+// secrets key, so they cannot be moved to another controller; rather
+// than silently dropping them, the export fails.
+func (e *exporter) checkNoSecrets() error {
+	secrets, closer := e.st.getCollection(secretsC)
+	defer closer()
+
+	count, err := secrets.Count()
+	if err != nil {
+		return errors.Annotate(err, "cannot count secrets")
+	}
+	if count > 0 {
+		return errors.NotSupportedf("migrating a model with %d charm secrets", count)
+	}
+	return nil
+}
+
 func (e *exporter) sequences() error {
 	sequences, closer := e.st.getCollection(sequenceC)
 	defer closer()
@@ -474,6 +495,11 @@ func (e *exporter) applications() error {
 		return errors.Trace(err)
 	}
 
+	unitStates, err := e.readAllUnitStates()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	leaders, err := e.readApplicationLeaders()
 	if err != nil {
 		return errors.Trace(err)
@@ -482,7 +508,7 @@ func (e *exporter) applications() error {
 	for _, application := range applications {
 		applicationUnits := e.units[application.Name()]
 		leader := leaders[application.Name()]
-		if err := e.addApplication(application, refcounts, applicationUnits, meterStatus, leader); err != nil {
+		if err := e.addApplication(application, refcounts, applicationUnits, meterStatus, unitStates, leader); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -502,7 +528,7 @@ func (e *exporter) readApplicationLeaders() (map[string]string, error) {
 	return result, nil
 }
 
-func (e *exporter) addApplication(application *Application, refcounts map[string]int, units []*Unit, meterStatus map[string]*meterStatusDoc, leader string) error {
+func (e *exporter) addApplication(application *Application, refcounts map[string]int, units []*Unit, meterStatus map[string]*meterStatusDoc, unitStates map[string]*unitStateDoc, leader string) error {
 	settingsKey := application.settingsKey()
 	leadershipKey := leadershipSettingsKey(application.Name())
 
@@ -529,6 +555,8 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 		ForceCharm:           application.doc.ForceCharm,
 		Exposed:              application.doc.Exposed,
 		MinUnits:             application.doc.MinUnits,
+		Trust:                application.doc.Trust,
+		ExposedCIDRs:         application.doc.ExposedCIDRs,
 		Settings:             applicationSettingsDoc.Settings,
 		SettingsRefCount:     refCount,
 		Leader:               leader,
@@ -571,6 +599,15 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 			MeterStatusCode: unitMeterStatus.Code,
 			MeterStatusInfo: unitMeterStatus.Info,
 		}
+		if unitState, found := unitStates[unit.globalKey()]; found {
+			relationState, err := relationStateFromDoc(unitState.RelationState)
+			if err != nil {
+				return errors.Annotatef(err, "state for unit %s", unit.Name())
+			}
+			args.UniterState = unitState.UniterState
+			args.RelationState = relationState
+			args.SkippedHooks = skippedHooksFromDoc(unitState.SkippedHooks)
+		}
 		if principalName, isSubordinate := unit.PrincipalName(); isSubordinate {
 			args.Principal = names.NewUnitTag(principalName)
 		}
@@ -720,6 +757,12 @@ func (e *exporter) subnets() error {
 	e.logger.Debugf("read %d subnets", len(subnets))
 
 	for _, subnet := range subnets {
+		if subnet.FanOverlay() != "" {
+			// Fan subnets are derived from the model config, and
+			// are recorded again when the model is imported, so
+			// the addresses exported on them remain valid.
+			continue
+		}
 		e.model.AddSubnet(description.SubnetArgs{
 			CIDR:             subnet.CIDR(),
 			ProviderId:       string(subnet.ProviderId()),
@@ -829,6 +872,23 @@ func (e *exporter) readAllMeterStatus() (map[string]*meterStatusDoc, error) {
 	return result, nil
 }
 
+func (e *exporter) readAllUnitStates() (map[string]*unitStateDoc, error) {
+	unitStates, closer := e.st.getCollection(unitStatesC)
+	defer closer()
+
+	docs := []unitStateDoc{}
+	err := unitStates.Find(nil).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get all unit state docs")
+	}
+	e.logger.Debugf("found %d unit state docs", len(docs))
+	result := make(map[string]*unitStateDoc)
+	for i := range docs {
+		result[e.st.localID(docs[i].DocID)] = &docs[i]
+	}
+	return result, nil
+}
+
 func (e *exporter) readLastConnectionTimes() (map[string]time.Time, error) {
 	lastConnections, closer := e.st.getCollection(modelUserLastConnectionC)
 	defer closer()
@@ -1072,6 +1132,7 @@ func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArg
 		Spaces:       optionalStringSlice("spaces"),
 		Tags:         optionalStringSlice("tags"),
 		VirtType:     optionalString("virttype"),
+		Zones:        optionalStringSlice("zones"),
 	}
 	if optionalErr != nil {
 		return description.ConstraintsArgs{}, errors.Trace(optionalErr)
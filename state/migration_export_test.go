@@ -7,6 +7,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
@@ -193,6 +194,10 @@ func (s *MigrationExportSuite) TestMachinesWithVirtConstraint(c *gc.C) {
 	s.assertMachinesMigrated(c, constraints.MustParse("arch=amd64 mem=8G virt-type=kvm"))
 }
 
+func (s *MigrationExportSuite) TestMachinesWithZonesConstraint(c *gc.C) {
+	s.assertMachinesMigrated(c, constraints.MustParse("arch=amd64 mem=8G zones=az1,az2"))
+}
+
 func (s *MigrationExportSuite) assertMachinesMigrated(c *gc.C, cons constraints.Value) {
 	// Add a machine with an LXC container.
 	machine1 := s.Factory.MakeMachine(c, &factory.MachineParams{
@@ -220,6 +225,9 @@ func (s *MigrationExportSuite) assertMachinesMigrated(c *gc.C, cons constraints.
 	if cons.HasVirtType() {
 		c.Assert(constraints.VirtType(), gc.Equals, *cons.VirtType)
 	}
+	if cons.HasZones() {
+		c.Assert(constraints.Zones(), jc.DeepEquals, *cons.Zones)
+	}
 
 	tools, err := machine1.AgentTools()
 	c.Assert(err, jc.ErrorIsNil)
@@ -353,6 +361,18 @@ func (s *MigrationExportSuite) TestMultipleApplications(c *gc.C) {
 	c.Assert(applications, gc.HasLen, 3)
 }
 
+func (s *MigrationExportSuite) TestSecretsNotSupported(c *gc.C) {
+	_, err := s.State.AddSecret(state.CreateSecretParams{
+		Owner: names.NewApplicationTag("mysql"),
+		Data:  map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, "migrating a model with 1 charm secrets not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *MigrationExportSuite) TestUnits(c *gc.C) {
 	unit := s.Factory.MakeUnit(c, &factory.UnitParams{
 		Constraints: constraints.MustParse("arch=amd64 mem=8G"),
@@ -365,6 +385,13 @@ func (s *MigrationExportSuite) TestUnits(c *gc.C) {
 	}
 	err = s.State.SetAnnotations(unit, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
+	uniterState := "uniter state"
+	err = unit.SetState(state.UnitStateUpdate{
+		UniterState:   &uniterState,
+		RelationState: map[int]string{1: "relation state"},
+		SkippedHooks:  []string{"config-changed"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, unit, status.StatusActive, addedHistoryCount)
 	s.primeStatusHistory(c, unit.Agent(), status.StatusIdle, addedHistoryCount)
 
@@ -386,6 +413,9 @@ func (s *MigrationExportSuite) TestUnits(c *gc.C) {
 	c.Assert(exported.MeterStatusCode(), gc.Equals, "GREEN")
 	c.Assert(exported.MeterStatusInfo(), gc.Equals, "some info")
 	c.Assert(exported.WorkloadVersion(), gc.Equals, "steven")
+	c.Assert(exported.UniterState(), gc.Equals, "uniter state")
+	c.Assert(exported.RelationState(), jc.DeepEquals, map[int]string{1: "relation state"})
+	c.Assert(exported.SkippedHooks(), jc.DeepEquals, []string{"config-changed"})
 	c.Assert(exported.Annotations(), jc.DeepEquals, testAnnotations)
 	constraints := exported.Constraints()
 	c.Assert(constraints, gc.NotNil)
@@ -693,6 +693,23 @@ func (i *importer) unit(s description.Application, u description.Unit) error {
 		ops = append(ops, createConstraintsOp(i.st, agentGlobalKey, i.constraints(cons)))
 	}
 
+	// Only units whose uniters persisted state have a unit state doc.
+	if u.UniterState() != "" || len(u.RelationState()) > 0 || len(u.SkippedHooks()) > 0 {
+		docID := i.st.docID(unitGlobalKey(u.Name()))
+		ops = append(ops, txn.Op{
+			C:      unitStatesC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+			Insert: &unitStateDoc{
+				DocID:         docID,
+				ModelUUID:     i.st.ModelUUID(),
+				UniterState:   u.UniterState(),
+				RelationState: relationStateToDoc(u.RelationState()),
+				SkippedHooks:  u.SkippedHooks(),
+			},
+		})
+	}
+
 	if err := i.st.runTransaction(ops); err != nil {
 		return errors.Trace(err)
 	}
@@ -735,6 +752,8 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		RelationCount:        i.relationCount(s.Name()),
 		Exposed:              s.Exposed(),
 		MinUnits:             s.MinUnits(),
+		Trust:                s.Trust(),
+		ExposedCIDRs:         s.ExposedCIDRs(),
 		MetricCredentials:    s.MetricsCredentials(),
 	}, nil
 }
@@ -975,6 +994,22 @@ func (i *importer) subnets() error {
 			return errors.Trace(err)
 		}
 	}
+	// Fan subnets are not exported, as they are derived from the
+	// model config; record them again before the addresses on them
+	// are imported.
+	cfg, err := i.st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fanSubnets, err := i.st.fanSubnetArgs(cfg.FanConfig())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, args := range fanSubnets {
+		if err := i.addSubnet(args); err != nil {
+			return errors.Annotatef(err, "adding fan subnet for %q", args.FanLocalUnderlay)
+		}
+	}
 	i.logger.Debugf("importing subnets succeeded")
 	return nil
 }
@@ -1133,6 +1168,9 @@ func (i *importer) constraints(cons description.Constraints) constraints.Value {
 	if virt := cons.VirtType(); virt != "" {
 		result.VirtType = &virt
 	}
+	if zones := cons.Zones(); len(zones) > 0 {
+		result.Zones = &zones
+	}
 	return result
 }
 
@@ -321,7 +321,7 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	err = application.SetMetricCredentials([]byte("sekrit"))
 	c.Assert(err, jc.ErrorIsNil)
 	// Expose the application.
-	c.Assert(application.SetExposed(), jc.ErrorIsNil)
+	c.Assert(application.SetExposedToCIDRs([]string{"10.0.0.0/8"}), jc.ErrorIsNil)
 	err = s.State.SetAnnotations(application, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, application, status.StatusActive, 5)
@@ -342,6 +342,7 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	c.Assert(imported.ApplicationTag(), gc.Equals, exported.ApplicationTag())
 	c.Assert(imported.Series(), gc.Equals, exported.Series())
 	c.Assert(imported.IsExposed(), gc.Equals, exported.IsExposed())
+	c.Assert(imported.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
 	c.Assert(imported.MetricCredentials(), jc.DeepEquals, exported.MetricCredentials())
 
 	exportedConfig, err := exported.ConfigSettings()
@@ -401,6 +402,13 @@ func (s *MigrationImportSuite) assertUnitsMigrated(c *gc.C, cons constraints.Val
 	c.Assert(err, jc.ErrorIsNil)
 	err = s.State.SetAnnotations(exported, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
+	uniterState := "uniter state"
+	err = exported.SetState(state.UnitStateUpdate{
+		UniterState:   &uniterState,
+		RelationState: map[int]string{1: "relation state"},
+		SkippedHooks:  []string{"config-changed"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, exported, status.StatusActive, 5)
 	s.primeStatusHistory(c, exported.Agent(), status.StatusIdle, 5)
 
@@ -421,6 +429,14 @@ func (s *MigrationImportSuite) assertUnitsMigrated(c *gc.C, cons constraints.Val
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(version, gc.Equals, "amethyst")
 
+	unitState, err := imported.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, &state.UnitState{
+		UniterState:   "uniter state",
+		RelationState: map[int]string{1: "relation state"},
+		SkippedHooks:  []string{"config-changed"},
+	})
+
 	exportedMachineId, err := exported.AssignedMachineId()
 	c.Assert(err, jc.ErrorIsNil)
 	importedMachineId, err := imported.AssignedMachineId()
@@ -644,6 +660,42 @@ func (s *MigrationImportSuite) TestSubnets(c *gc.C) {
 	c.Assert(subnet.SpaceName(), gc.Equals, "bam")
 }
 
+func (s *MigrationImportSuite) TestFanSubnetsAndAddresses(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"fan-config": "172.31.0.0/16=252.0.0.0/8",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "172.31.16.0/20"})
+	c.Assert(err, jc.ErrorIsNil)
+	machine := s.Factory.MakeMachine(c, nil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "fan-252",
+		Type: state.BridgeDevice,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "fan-252",
+		ConfigMethod: state.StaticAddress,
+		CIDRAddress:  "252.16.5.23/8",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	subnet, err := newSt.Subnet("252.16.0.0/12")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.FanLocalUnderlay(), gc.Equals, "172.31.16.0/20")
+	c.Assert(subnet.FanOverlay(), gc.Equals, "252.0.0.0/8")
+
+	addresses, err := newSt.AllIPAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, gc.HasLen, 1)
+	c.Assert(addresses[0].SubnetCIDR(), gc.Equals, "252.16.0.0/12")
+	addrSubnet, err := addresses[0].Subnet()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrSubnet.CIDR(), gc.Equals, "252.16.0.0/12")
+}
+
 func (s *MigrationImportSuite) TestIPAddress(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
 		Constraints: constraints.MustParse("arch=amd64 mem=8G"),
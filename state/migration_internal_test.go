@@ -40,6 +40,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		applicationsC,
 		unitsC,
 		meterStatusC, // red / green status for metrics of units
+		unitStatesC,
 
 		// settings reference counts are only used for applications
 		settingsrefsC,
@@ -81,6 +82,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		userLastLoginC,
 		// userenvnameC is just to provide a unique key constraint.
 		usermodelnameC,
+		// Hook history is diagnostic and isn't migrated.
+		hookHistoryC,
 		// Metrics aren't migrated.
 		metricsC,
 		// Backup and restore information is not migrated.
@@ -129,6 +132,16 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// These are recreated whilst migrating other network entities.
 		providerIDsC,
 		linkLayerDevicesRefsC,
+
+		// Leaked instances are recomputed by the instance drift
+		// worker in the target model.
+		leakedInstancesC,
+
+		// Secrets are encrypted with a key derived from the source
+		// controller's secrets key, so export fails while the model
+		// holds any.
+		secretsC,
+		secretAuditC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -330,6 +343,8 @@ func (s *MigrationSuite) TestServiceDocFields(c *gc.C) {
 		"ForceCharm",
 		"Exposed",
 		"MinUnits",
+		"Trust",
+		"ExposedCIDRs",
 		"MetricCredentials",
 	)
 	s.AssertExportedFields(c, applicationDoc{}, migrated.Union(ignored))
@@ -364,6 +379,9 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"MachineId",
 		// Resolved is not migrated as we check that all is good before we start.
 		"Resolved",
+		// ReplayHooks is not migrated; the skipped hooks it would
+		// replay are migrated with the unit's state.
+		"ReplayHooks",
 		"Tools",
 		// Life isn't migrated as we only migrate live things.
 		"Life",
@@ -408,6 +426,20 @@ func (s *MigrationSuite) TestMeterStatusDocFields(c *gc.C) {
 	s.AssertExportedFields(c, meterStatusDoc{}, fields)
 }
 
+func (s *MigrationSuite) TestUnitStateDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"UniterState",
+		"RelationState",
+		"SkippedHooks",
+	)
+	s.AssertExportedFields(c, unitStateDoc{}, fields)
+}
+
 func (s *MigrationSuite) TestRelationDocFields(c *gc.C) {
 	fields := set.NewStrings(
 		// DocID itself isn't migrated
@@ -512,6 +544,7 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Tags",
 		"Spaces",
 		"VirtType",
+		"Zones",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
@@ -582,6 +615,11 @@ func (s *MigrationSuite) TestSubnetDocFields(c *gc.C) {
 
 		// Currently unused (never set or exposed).
 		"IsPublic",
+
+		// Fan subnets are not exported; they are derived from the
+		// model's fan-config, and are recorded again on import.
+		"FanLocalUnderlay",
+		"FanOverlay",
 	)
 	migrated := set.NewStrings(
 		"CIDR",
@@ -262,6 +262,7 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 		}
 	}()
 	newSt.controllerTag = st.controllerTag
+	newSt.secretsKey = st.secretsKey
 
 	modelOps, err := newSt.modelSetupOps(args, nil)
 	if err != nil {
@@ -510,6 +511,17 @@ func (m *Model) Config() (*config.Config, error) {
 	return st.ModelConfig()
 }
 
+// UpdateConfig adds and removes the given attributes from the model
+// config, as State.UpdateModelConfig does for the model's own State.
+func (m *Model) UpdateConfig(updateAttrs map[string]interface{}, removeAttrs []string) error {
+	st, closeState, err := m.getState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closeState()
+	return st.UpdateModelConfig(updateAttrs, removeAttrs, nil)
+}
+
 // ConfigValues returns the config values for the model.
 func (m *Model) ConfigValues() (config.ConfigValues, error) {
 	st, closeState, err := m.getState()
@@ -6,6 +6,7 @@ package state
 import (
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 )
@@ -49,7 +50,10 @@ func checkModelConfig(cfg *config.Config) error {
 // inheritedConfigAttributes returns the merged collection of inherited config
 // values used as model defaults when adding models or unsetting values.
 func (st *State) inheritedConfigAttributes() (map[string]interface{}, error) {
-	configSources := modelConfigSources(st)
+	configSources, err := st.currentModelConfigSources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	values := make(attrValues)
 	for _, src := range configSources {
 		cfg, err := src.sourceFunc()
@@ -76,11 +80,18 @@ func (st *State) ModelConfigValues() (config.ConfigValues, error) {
 
 	// Read all of the current inherited config values so
 	// we can dynamically reflect the origin of the model config.
-	configSources := modelConfigSources(st)
+	// The hard coded defaults are consulted first so that any
+	// value which has not been overridden is reported as such.
+	inheritedSources, err := st.currentModelConfigSources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	configSources := append([]modelConfigSource{
+		{config.JujuDefaultSource, configDefaultsSource},
+	}, inheritedSources...)
 	sourceNames := make([]string, 0, len(configSources))
 	sourceAttrs := make([]attrValues, 0, len(configSources))
 	for _, src := range configSources {
-		sourceNames = append(sourceNames, src.name)
 		cfg, err := src.sourceFunc()
 		if errors.IsNotFound(err) {
 			continue
@@ -88,6 +99,7 @@ func (st *State) ModelConfigValues() (config.ConfigValues, error) {
 		if err != nil {
 			return nil, errors.Annotatef(err, "reading %s settings", src.name)
 		}
+		sourceNames = append(sourceNames, src.name)
 		sourceAttrs = append(sourceAttrs, cfg)
 	}
 
@@ -220,7 +232,18 @@ func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAtt
 
 	modelSettings.Update(validAttrs)
 	_, ops := modelSettings.settingsUpdateOps()
-	return modelSettings.write(ops)
+	if err := modelSettings.write(ops); err != nil {
+		return errors.Trace(err)
+	}
+	if fanConfig := validCfg.FanConfig(); fanConfig.String() != oldConfig.FanConfig().String() {
+		// Record the Fan subnets before any container is started
+		// on them, so the addresses containers report are linked
+		// to them.
+		if err := st.AddFanSubnets(fanConfig); err != nil {
+			return errors.Annotate(err, "cannot record fan subnets")
+		}
+	}
+	return nil
 }
 
 type modelConfigSourceFunc func() (attrValues, error)
@@ -234,11 +257,26 @@ type modelConfigSource struct {
 // sources, in hierarchical order. Starting from the first source,
 // config is retrieved and each subsequent source adds to the
 // overall config values, later values override earlier ones.
-func modelConfigSources(st *State) []modelConfigSource {
+func modelConfigSources(st *State, cloudName, regionName string) []modelConfigSource {
 	return []modelConfigSource{
 		{config.JujuControllerSource, st.controllerInheritedConfig},
-		// We will also support local cloud region, tenant, user etc
+		{config.JujuRegionSource, st.regionInheritedConfig(cloudName, regionName)},
+	}
+}
+
+// currentModelConfigSources returns the model config sources
+// for the model represented by this state.
+func (st *State) currentModelConfigSources() ([]modelConfigSource, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
+	return modelConfigSources(st, model.Cloud(), model.CloudRegion()), nil
+}
+
+// configDefaultsSource returns the hard coded config defaults.
+func configDefaultsSource() (attrValues, error) {
+	return config.ConfigDefaults(), nil
 }
 
 // controllerInheritedConfig returns the inherited config values
@@ -251,6 +289,172 @@ func (st *State) controllerInheritedConfig() (attrValues, error) {
 	return settings.Map(), nil
 }
 
+// regionSettingsGlobalKey returns the key for the inherited
+// model config of the specified cloud region.
+func regionSettingsGlobalKey(cloudName, regionName string) string {
+	return cloudGlobalKey(cloudName) + "#region#" + regionName
+}
+
+// regionInheritedConfig returns a config source function which
+// returns the inherited config values for the specified cloud region.
+func (st *State) regionInheritedConfig(cloudName, regionName string) modelConfigSourceFunc {
+	return func() (attrValues, error) {
+		if regionName == "" {
+			return nil, errors.NotFoundf("region")
+		}
+		settings, err := readSettings(st, globalSettingsC, regionSettingsGlobalKey(cloudName, regionName))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return settings.Map(), nil
+	}
+}
+
+// ModelConfigDefaultValues returns the default config values to be used
+// when creating a new model, and the origin of those values. Region
+// specific values are reported for each region of the controller's cloud
+// for which defaults have been set.
+func (st *State) ModelConfigDefaultValues() (config.ModelDefaultAttributes, error) {
+	controllerModel, err := st.ControllerModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cloudName := controllerModel.Cloud()
+	controllerCloud, err := st.Cloud(cloudName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make(config.ModelDefaultAttributes)
+	for attr, val := range config.ConfigDefaults() {
+		result[attr] = config.AttributeDefaultValues{Default: val}
+	}
+	controllerAttrs, err := st.controllerInheritedConfig()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Annotate(err, "reading controller settings")
+	}
+	for attr, val := range controllerAttrs {
+		ds := result[attr]
+		ds.Controller = val
+		result[attr] = ds
+	}
+	for _, region := range controllerCloud.Regions {
+		regionAttrs, err := st.regionInheritedConfig(cloudName, region.Name)()
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading region %q settings", region.Name)
+		}
+		for attr, val := range regionAttrs {
+			ds := result[attr]
+			ds.Regions = append(ds.Regions, config.RegionDefaultValue{
+				Name:  region.Name,
+				Value: val,
+			})
+			result[attr] = ds
+		}
+	}
+	return result, nil
+}
+
+// UpdateModelConfigDefaultValues updates the inherited settings used when
+// creating a new model, either for the controller as a whole or, if
+// regionName is not empty, for the named region of the controller's cloud.
+// Existing models are not affected, other than the new values being
+// used when an attribute is removed from a model's config.
+func (st *State) UpdateModelConfigDefaultValues(updateAttrs map[string]interface{}, removeAttrs []string, regionName string) error {
+	if len(updateAttrs)+len(removeAttrs) == 0 {
+		return nil
+	}
+	if err := checkControllerInheritedConfig(updateAttrs); err != nil {
+		return errors.Trace(err)
+	}
+	for _, attr := range modelDefaultsDisallowedAttrs {
+		if _, ok := updateAttrs[attr]; ok {
+			return errors.Errorf("%s cannot be set as a model default", attr)
+		}
+	}
+	if len(updateAttrs) > 0 {
+		var err error
+		updateAttrs, err = validateModelDefaults(updateAttrs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	key := controllerInheritedSettingsGlobalKey
+	if regionName != "" {
+		controllerModel, err := st.ControllerModel()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		cloudName := controllerModel.Cloud()
+		controllerCloud, err := st.Cloud(cloudName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !cloudHasRegion(controllerCloud.Regions, regionName) {
+			return errors.NotValidf("region %q for cloud %q", regionName, cloudName)
+		}
+		key = regionSettingsGlobalKey(cloudName, regionName)
+	}
+
+	settings, err := readSettings(st, globalSettingsC, key)
+	if errors.IsNotFound(err) {
+		if len(updateAttrs) == 0 {
+			// Nothing has been set, so there's nothing to remove.
+			return nil
+		}
+		_, err := createSettings(st, globalSettingsC, key, updateAttrs)
+		return errors.Trace(err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	settings.Update(updateAttrs)
+	for _, attr := range removeAttrs {
+		if _, ok := updateAttrs[attr]; ok {
+			continue
+		}
+		settings.Delete(attr)
+	}
+	_, err = settings.Write()
+	return errors.Trace(err)
+}
+
+// validateModelDefaults checks the given default values against the
+// config schema shared by all providers, and returns them coerced to
+// the types it expects. Provider specific attributes are validated by
+// the provider when a model inheriting them is created.
+func validateModelDefaults(attrs map[string]interface{}) (map[string]interface{}, error) {
+	fields, err := config.Schema(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result, err := config.CoerceForSchema(fields, attrs)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid model defaults")
+	}
+	return result, nil
+}
+
+// modelDefaultsDisallowedAttrs holds the model config attributes
+// which identify a model, and so cannot be shared between models.
+var modelDefaultsDisallowedAttrs = []string{
+	config.NameKey,
+	config.UUIDKey,
+	config.TypeKey,
+}
+
+func cloudHasRegion(regions []cloud.Region, regionName string) bool {
+	for _, region := range regions {
+		if region.Name == regionName {
+			return true
+		}
+	}
+	return false
+}
+
 // composeModelConfigAttributes returns a set of model config settings composed from known
 // sources of default values overridden by model specific attributes.
 func composeModelConfigAttributes(
@@ -5,14 +5,18 @@ package state_test
 
 import (
 	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/mongo/mongotest"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
 )
 
@@ -211,8 +215,12 @@ func (s *ModelConfigSourceSuite) TestModelConfigValues(c *gc.C) {
 	modelCfg, err := s.State.ModelConfig()
 	c.Assert(err, jc.ErrorIsNil)
 	expectedValues := make(config.ConfigValues)
+	defaults := config.ConfigDefaults()
 	for attr, val := range modelCfg.AllAttrs() {
 		source := "model"
+		if defaultVal, ok := defaults[attr]; ok && defaultVal == val {
+			source = "default"
+		}
 		if attr == "http-proxy" {
 			source = "controller"
 		}
@@ -237,8 +245,12 @@ func (s *ModelConfigSourceSuite) TestModelConfigUpdateSource(c *gc.C) {
 	modelCfg, err := s.State.ModelConfig()
 	c.Assert(err, jc.ErrorIsNil)
 	expectedValues := make(config.ConfigValues)
+	defaults := config.ConfigDefaults()
 	for attr, val := range modelCfg.AllAttrs() {
 		source := "model"
+		if defaultVal, ok := defaults[attr]; ok && defaultVal == val {
+			source = "default"
+		}
 		if attr == "apt-mirror" {
 			source = "controller"
 		}
@@ -251,3 +263,194 @@ func (s *ModelConfigSourceSuite) TestModelConfigUpdateSource(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(sources, jc.DeepEquals, expectedValues)
 }
+
+type ModelConfigDefaultsSuite struct {
+	gitjujutesting.MgoSuite
+	st    *state.State
+	owner names.UserTag
+}
+
+var _ = gc.Suite(&ModelConfigDefaultsSuite{})
+
+func (s *ModelConfigDefaultsSuite) SetUpTest(c *gc.C) {
+	s.MgoSuite.SetUpTest(c)
+	s.owner = names.NewUserTag("test@remote")
+	cfg, _ := createTestModelConfig(c, "")
+	controllerCfg := testing.FakeControllerConfig()
+	controllerCfg["controller-uuid"] = cfg.UUID()
+	st, err := state.Initialize(state.InitializeParams{
+		ControllerConfig: controllerCfg,
+		ControllerModelArgs: state.ModelArgs{
+			Owner:       s.owner,
+			Config:      cfg,
+			CloudName:   "dummy",
+			CloudRegion: "dummy-region",
+		},
+		ControllerInheritedConfig: map[string]interface{}{
+			"apt-mirror": "http://cloud-mirror",
+		},
+		CloudName: "dummy",
+		Cloud: cloud.Cloud{
+			Type:      "dummy",
+			AuthTypes: []cloud.AuthType{cloud.EmptyAuthType},
+			Regions:   []cloud.Region{{Name: "dummy-region"}, {Name: "other-region"}},
+		},
+		MongoInfo:     statetesting.NewMongoInfo(),
+		MongoDialOpts: mongotest.DialOpts(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.st = st
+}
+
+func (s *ModelConfigDefaultsSuite) TearDownTest(c *gc.C) {
+	if s.st != nil {
+		err := s.st.Close()
+		c.Check(err, jc.ErrorIsNil)
+	}
+	s.MgoSuite.TearDownTest(c)
+}
+
+func (s *ModelConfigDefaultsSuite) newModel(c *gc.C, name, region string) *state.State {
+	uuid, err := utils.NewUUID()
+	c.Assert(err, jc.ErrorIsNil)
+	cfg := testing.CustomModelConfig(c, testing.Attrs{
+		"name": name,
+		"uuid": uuid.String(),
+	})
+	_, st, err := s.st.NewModel(state.ModelArgs{
+		Config: cfg, Owner: s.owner, CloudName: "dummy", CloudRegion: region,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return st
+}
+
+func (s *ModelConfigDefaultsSuite) TestModelConfigDefaultValues(c *gc.C) {
+	err := s.st.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"http-proxy": "http://region-proxy",
+	}, nil, "dummy-region")
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err := s.st.ModelConfigDefaultValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["apt-mirror"], jc.DeepEquals, config.AttributeDefaultValues{
+		Controller: "http://cloud-mirror",
+	})
+	c.Assert(values["http-proxy"], jc.DeepEquals, config.AttributeDefaultValues{
+		Regions: []config.RegionDefaultValue{{
+			Name:  "dummy-region",
+			Value: "http://region-proxy",
+		}},
+	})
+	c.Assert(values["firewall-mode"], jc.DeepEquals, config.AttributeDefaultValues{
+		Default: config.FwInstance,
+	})
+}
+
+func (s *ModelConfigDefaultsSuite) TestUpdateModelConfigDefaultValues(c *gc.C) {
+	err := s.st.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror":  "http://another-mirror",
+		"no-proxy":    "localhost",
+		"ftp-proxy":   "ftp://proxy",
+		"https-proxy": "https://proxy",
+	}, []string{"ftp-proxy"}, "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.st.UpdateModelConfigDefaultValues(nil, []string{"no-proxy"}, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err := s.st.ModelConfigDefaultValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["apt-mirror"].Controller, gc.Equals, "http://another-mirror")
+	// An attribute being updated takes precedence over its removal.
+	c.Assert(values["ftp-proxy"].Controller, gc.Equals, "ftp://proxy")
+	_, ok := values["no-proxy"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ModelConfigDefaultsSuite) TestUpdateModelConfigDefaultValuesInvalid(c *gc.C) {
+	err := s.st.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"agent-version": "1.2.3",
+	}, nil, "")
+	c.Assert(err, gc.ErrorMatches, "local cloud config cannot contain agent-version")
+	err = s.st.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"name": "foo",
+	}, nil, "")
+	c.Assert(err, gc.ErrorMatches, "name cannot be set as a model default")
+	err = s.st.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://mirror",
+	}, nil, "missing-region")
+	c.Assert(err, gc.ErrorMatches, `region "missing-region" for cloud "dummy" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ModelConfigDefaultsSuite) TestUpdateModelConfigDefaultValuesValidates(c *gc.C) {
+	err := s.st.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"firewall-mode": "bogus",
+	}, nil, "dummy-region")
+	c.Assert(err, gc.ErrorMatches, `invalid model defaults: firewall-mode: .*`)
+	err = s.st.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"enable-os-upgrade": []string{"yes"},
+	}, nil, "")
+	c.Assert(err, gc.ErrorMatches, `invalid model defaults: enable-os-upgrade: expected bool, got .*`)
+
+	values, err := s.st.ModelConfigDefaultValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["firewall-mode"].Regions, gc.HasLen, 0)
+	c.Assert(values["enable-os-upgrade"].Controller, gc.IsNil)
+}
+
+func (s *ModelConfigDefaultsSuite) TestUpdateModelConfigDefaultValuesCoerces(c *gc.C) {
+	err := s.st.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"enable-os-upgrade": "false",
+	}, nil, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err := s.st.ModelConfigDefaultValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["enable-os-upgrade"].Controller, gc.Equals, false)
+}
+
+func (s *ModelConfigDefaultsSuite) TestNewModelInheritsRegionValues(c *gc.C) {
+	err := s.st.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://region-mirror",
+		"http-proxy": "http://region-proxy",
+	}, nil, "other-region")
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.newModel(c, "another", "other-region")
+	defer st.Close()
+	values, err := st.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["apt-mirror"], jc.DeepEquals, config.ConfigValue{
+		Value: "http://region-mirror", Source: "region",
+	})
+	c.Assert(values["http-proxy"], jc.DeepEquals, config.ConfigValue{
+		Value: "http://region-proxy", Source: "region",
+	})
+	c.Assert(values["firewall-mode"].Source, gc.Equals, "default")
+
+	// A model in another region only sees the controller values.
+	st2 := s.newModel(c, "yet-another", "dummy-region")
+	defer st2.Close()
+	values, err = st2.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["apt-mirror"], jc.DeepEquals, config.ConfigValue{
+		Value: "http://cloud-mirror", Source: "controller",
+	})
+	_, ok := values["http-proxy"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ModelConfigDefaultsSuite) TestUpdateModelConfigRemoveUsesRegionValue(c *gc.C) {
+	st := s.newModel(c, "another", "other-region")
+	defer st.Close()
+	err := s.st.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"apt-mirror": "http://region-mirror",
+	}, nil, "other-region")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = st.UpdateModelConfig(nil, []string{"apt-mirror"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["apt-mirror"], gc.Equals, "http://region-mirror")
+}
@@ -264,7 +264,7 @@ func Initialize(args InitializeParams) (_ *State, err error) {
 		createSettingsOp(globalSettingsC, controllerInheritedSettingsGlobalKey, args.ControllerInheritedConfig),
 	}
 	if len(args.CloudCredentials) > 0 {
-		credentialsOps := updateCloudCredentialsOps(
+		credentialsOps := createCloudCredentialsOps(
 			args.ControllerModelArgs.Owner,
 			args.CloudName,
 			args.CloudCredentials,
@@ -330,7 +330,7 @@ func (st *State) modelSetupOps(args ModelArgs, ControllerInheritedConfig map[str
 				return ControllerInheritedConfig, nil
 			})}}
 	} else {
-		configSources = modelConfigSources(st)
+		configSources = modelConfigSources(st, args.CloudName, args.CloudRegion)
 	}
 	modelCfg, err := composeModelConfigAttributes(args.Config.AllAttrs(), configSources...)
 	if err != nil {
@@ -200,7 +200,11 @@ func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.O
 			hasLastRef := bson.D{{"life", Dying}, {"unitcount", 0}, {"relationcount", 1}}
 			removable := append(bson.D{{"_id", ep.ApplicationName}}, hasLastRef...)
 			if err := applications.Find(removable).One(&svc.doc); err == nil {
-				ops = append(ops, svc.removeOps(hasLastRef)...)
+				removeOps, err := svc.removeOps(hasLastRef)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, removeOps...)
 				continue
 			} else if err != mgo.ErrNotFound {
 				return nil, err
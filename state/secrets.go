@@ -0,0 +1,746 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// SecretAction identifies an operation recorded in a secret's audit
+// trail.
+type SecretAction string
+
+const (
+	SecretCreated SecretAction = "create"
+	SecretRead    SecretAction = "read"
+	SecretUpdated SecretAction = "update"
+	SecretGranted SecretAction = "grant"
+	SecretRevoked SecretAction = "revoke"
+	SecretRotated SecretAction = "rotate"
+)
+
+// Secret represents a secret owned by an application or a unit. The
+// secret's value is stored encrypted, and may only be read by its
+// owner and by the applications it has been granted to.
+type Secret struct {
+	st  *State
+	doc secretDoc
+}
+
+// secretDoc represents the MongoDB document holding a secret. The
+// document ID is prefixed with the owner's tag so that the secrets
+// owned by an entity may be watched efficiently.
+type secretDoc struct {
+	DocID          string        `bson:"_id"`
+	ModelUUID      string        `bson:"model-uuid"`
+	SecretID       string        `bson:"secret-id"`
+	Owner          string        `bson:"owner"`
+	Label          string        `bson:"label,omitempty"`
+	Description    string        `bson:"description,omitempty"`
+	Revision       int           `bson:"revision"`
+	Data           string        `bson:"data"`
+	RotateInterval time.Duration `bson:"rotate-interval,omitempty"`
+	NextRotateTime *time.Time    `bson:"next-rotate-time,omitempty"`
+	Grants         []string      `bson:"grants,omitempty"`
+	CreateTime     time.Time     `bson:"create-time"`
+	UpdateTime     time.Time     `bson:"update-time"`
+	TxnRevno       int64         `bson:"txn-revno"`
+}
+
+// secretAuditDoc records a single operation on a secret.
+type secretAuditDoc struct {
+	DocID     string    `bson:"_id"`
+	ModelUUID string    `bson:"model-uuid"`
+	SecretID  string    `bson:"secret-id"`
+	Time      time.Time `bson:"time"`
+	Entity    string    `bson:"entity"`
+	Action    string    `bson:"action"`
+	Revision  int       `bson:"revision"`
+	Detail    string    `bson:"detail,omitempty"`
+}
+
+// SecretAuditRecord describes an operation on a secret: who did what,
+// and when.
+type SecretAuditRecord struct {
+	SecretID string
+	Time     time.Time
+	Entity   string
+	Action   SecretAction
+	Revision int
+	Detail   string
+}
+
+// ID returns the secret's unique identifier.
+func (s *Secret) ID() string {
+	return s.doc.SecretID
+}
+
+// Owner returns the tag of the application or unit that owns the secret.
+func (s *Secret) Owner() (names.Tag, error) {
+	return names.ParseTag(s.doc.Owner)
+}
+
+// Label returns the label the owner gave the secret, if any.
+func (s *Secret) Label() string {
+	return s.doc.Label
+}
+
+// Description returns the secret's description.
+func (s *Secret) Description() string {
+	return s.doc.Description
+}
+
+// Revision returns the secret's revision, which is incremented each
+// time its value changes.
+func (s *Secret) Revision() int {
+	return s.doc.Revision
+}
+
+// RotateInterval returns how often the owner should rotate the secret.
+// It is zero if the secret is not rotated.
+func (s *Secret) RotateInterval() time.Duration {
+	return s.doc.RotateInterval
+}
+
+// NextRotateTime returns when the secret is next due to be rotated, or
+// nil if the secret is not rotated.
+func (s *Secret) NextRotateTime() *time.Time {
+	return s.doc.NextRotateTime
+}
+
+// Grants returns the names of the applications, other than the owner's,
+// that may read the secret.
+func (s *Secret) Grants() []string {
+	grants := make([]string, len(s.doc.Grants))
+	copy(grants, s.doc.Grants)
+	return grants
+}
+
+// CreateTime returns when the secret was created.
+func (s *Secret) CreateTime() time.Time {
+	return s.doc.CreateTime
+}
+
+// UpdateTime returns when the secret's value or settings last changed.
+func (s *Secret) UpdateTime() time.Time {
+	return s.doc.UpdateTime
+}
+
+// IsOwnedBy reports whether the given entity may manage the secret:
+// the owning unit, or any unit of the owning application.
+func (s *Secret) IsOwnedBy(tag names.Tag) bool {
+	if tag.String() == s.doc.Owner {
+		return true
+	}
+	if unitTag, ok := tag.(names.UnitTag); ok {
+		appName, err := names.UnitApplication(unitTag.Id())
+		if err == nil && names.NewApplicationTag(appName).String() == s.doc.Owner {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReadableBy reports whether the given unit may read the secret's
+// value: either it owns the secret, or its application has been
+// granted access.
+func (s *Secret) IsReadableBy(tag names.UnitTag) bool {
+	if s.IsOwnedBy(tag) {
+		return true
+	}
+	appName, err := names.UnitApplication(tag.Id())
+	if err != nil {
+		return false
+	}
+	for _, grant := range s.doc.Grants {
+		if grant == appName {
+			return true
+		}
+	}
+	return false
+}
+
+// secretOwnerPrefix returns the prefix of the IDs of all the secret
+// documents owned by the given entity.
+func secretOwnerPrefix(owner names.Tag) string {
+	return owner.String() + "#"
+}
+
+// validateSecretOwner returns an error if the given tag cannot own
+// secrets.
+func validateSecretOwner(owner names.Tag) error {
+	switch owner.(type) {
+	case names.ApplicationTag, names.UnitTag:
+		return nil
+	}
+	return errors.NotValidf("secret owner %q", owner)
+}
+
+// CreateSecretParams holds the parameters for creating a secret.
+type CreateSecretParams struct {
+	// Owner is the application or unit that owns the secret.
+	Owner names.Tag
+
+	// Actor is the entity creating the secret, recorded in the
+	// secret's audit trail.
+	Actor names.Tag
+
+	// Label optionally identifies the secret among those of its owner.
+	Label string
+
+	// Description describes the secret.
+	Description string
+
+	// Data holds the secret's value.
+	Data map[string]string
+
+	// RotateInterval is how often the owner should rotate the secret.
+	// If it is zero, the secret is not rotated.
+	RotateInterval time.Duration
+}
+
+// AddSecret creates a new secret, and returns it.
+func (st *State) AddSecret(p CreateSecretParams) (*Secret, error) {
+	if err := validateSecretOwner(p.Owner); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(p.Data) == 0 {
+		return nil, errors.NotValidf("empty secret value")
+	}
+	if p.RotateInterval < 0 {
+		return nil, errors.NotValidf("negative rotate interval")
+	}
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := uuid.String()
+	now := nowToTheSecond()
+	doc := secretDoc{
+		DocID:          st.docID(secretOwnerPrefix(p.Owner) + id),
+		ModelUUID:      st.ModelUUID(),
+		SecretID:       id,
+		Owner:          p.Owner.String(),
+		Label:          p.Label,
+		Description:    p.Description,
+		Revision:       1,
+		RotateInterval: p.RotateInterval,
+		CreateTime:     now,
+		UpdateTime:     now,
+	}
+	if p.RotateInterval > 0 {
+		next := now.Add(p.RotateInterval)
+		doc.NextRotateTime = &next
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if p.Label != "" {
+			if _, err := st.SecretByLabel(p.Owner, p.Label); err == nil {
+				return nil, errors.AlreadyExistsf("secret with label %q", p.Label)
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+		}
+		key, err := st.modelSecretsKey()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Data, err = encryptSecretData(key, p.Data); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      secretsC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}, st.secretAuditOp(id, p.Actor, SecretCreated, doc.Revision, "")}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Annotate(err, "cannot add secret")
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// Secret returns the secret with the given ID.
+func (st *State) Secret(id string) (*Secret, error) {
+	return st.findSecret(bson.D{{"secret-id", id}}, "secret %q", id)
+}
+
+// SecretByLabel returns the secret owned by the given entity which has
+// the given label.
+func (st *State) SecretByLabel(owner names.Tag, label string) (*Secret, error) {
+	return st.findSecret(
+		bson.D{{"owner", owner.String()}, {"label", label}},
+		"secret with label %q", label,
+	)
+}
+
+func (st *State) findSecret(query bson.D, format string, args ...interface{}) (*Secret, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	var doc secretDoc
+	err := secrets.Find(query).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf(format, args...)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get "+format, args...)
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// AllSecrets returns all the secrets in the model, ordered by owner
+// and then by creation time.
+func (st *State) AllSecrets() ([]*Secret, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	var docs []secretDoc
+	if err := secrets.Find(nil).Sort("owner", "create-time").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get secrets")
+	}
+	result := make([]*Secret, len(docs))
+	for i, doc := range docs {
+		result[i] = &Secret{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// SecretsOwnedBy returns the secrets owned by the given entities.
+func (st *State) SecretsOwnedBy(owners ...names.Tag) ([]*Secret, error) {
+	ownerIds := make([]string, len(owners))
+	for i, owner := range owners {
+		ownerIds[i] = owner.String()
+	}
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	var docs []secretDoc
+	query := bson.D{{"owner", bson.D{{"$in", ownerIds}}}}
+	if err := secrets.Find(query).Sort("create-time").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get secrets")
+	}
+	result := make([]*Secret, len(docs))
+	for i, doc := range docs {
+		result[i] = &Secret{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// SecretValue returns the decrypted value of the secret with the given
+// ID, recording that it was read by the given entity. Callers are
+// responsible for checking that the reader is allowed to see it.
+func (st *State) SecretValue(id string, reader names.Tag) (map[string]string, error) {
+	secret, err := st.Secret(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	key, err := st.modelSecretsKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := decryptSecretData(key, secret.doc.Data)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot decrypt secret %q", id)
+	}
+	ops := []txn.Op{{
+		C:      secretsC,
+		Id:     secret.doc.DocID,
+		Assert: txn.DocExists,
+	}, st.secretAuditOp(id, reader, SecretRead, secret.doc.Revision, "")}
+	if err := st.runTransaction(ops); err != nil {
+		return nil, errors.Annotatef(err, "cannot record read of secret %q", id)
+	}
+	return data, nil
+}
+
+// UpdateSecretParams holds the changes to make to a secret. Fields
+// left nil are not changed.
+type UpdateSecretParams struct {
+	// Actor is the entity changing the secret, recorded in the
+	// secret's audit trail.
+	Actor names.Tag
+
+	// Data holds the secret's new value.
+	Data map[string]string
+
+	// Description holds the secret's new description.
+	Description *string
+
+	// RotateInterval holds how often the owner should rotate the
+	// secret from now on.
+	RotateInterval *time.Duration
+}
+
+// UpdateSecret changes the value or settings of the secret with the
+// given ID. Setting a new value increments the secret's revision and,
+// if the secret is rotated, counts as a rotation.
+func (st *State) UpdateSecret(id string, p UpdateSecretParams) (*Secret, error) {
+	if p.Data != nil && len(p.Data) == 0 {
+		return nil, errors.NotValidf("empty secret value")
+	}
+	if p.RotateInterval != nil && *p.RotateInterval < 0 {
+		return nil, errors.NotValidf("negative rotate interval")
+	}
+	var secret *Secret
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var err error
+		if secret, err = st.Secret(id); err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc := &secret.doc
+		now := nowToTheSecond()
+		var set bson.D
+		if p.Data != nil {
+			key, err := st.modelSecretsKey()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if doc.Data, err = encryptSecretData(key, p.Data); err != nil {
+				return nil, errors.Trace(err)
+			}
+			doc.Revision++
+			set = append(set, bson.DocElem{"data", doc.Data}, bson.DocElem{"revision", doc.Revision})
+		}
+		if p.Description != nil {
+			doc.Description = *p.Description
+			set = append(set, bson.DocElem{"description", doc.Description})
+		}
+		if p.RotateInterval != nil {
+			doc.RotateInterval = *p.RotateInterval
+			set = append(set, bson.DocElem{"rotate-interval", doc.RotateInterval})
+		}
+		if p.Data != nil || p.RotateInterval != nil {
+			doc.NextRotateTime = nil
+			if doc.RotateInterval > 0 {
+				next := now.Add(doc.RotateInterval)
+				doc.NextRotateTime = &next
+			}
+			set = append(set, bson.DocElem{"next-rotate-time", doc.NextRotateTime})
+		}
+		if len(set) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		doc.UpdateTime = now
+		set = append(set, bson.DocElem{"update-time", now})
+		return []txn.Op{{
+			C:      secretsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Update: bson.D{{"$set", set}},
+		}, st.secretAuditOp(id, p.Actor, SecretUpdated, doc.Revision, "")}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot update secret %q", id)
+	}
+	return secret, nil
+}
+
+// GrantSecret allows the units of the given application to read the
+// secret with the given ID.
+func (st *State) GrantSecret(id string, app names.ApplicationTag, actor names.Tag) error {
+	return st.changeSecretGrant(id, app, actor, SecretGranted)
+}
+
+// RevokeSecret stops the units of the given application from reading
+// the secret with the given ID.
+func (st *State) RevokeSecret(id string, app names.ApplicationTag, actor names.Tag) error {
+	return st.changeSecretGrant(id, app, actor, SecretRevoked)
+}
+
+func (st *State) changeSecretGrant(id string, app names.ApplicationTag, actor names.Tag, action SecretAction) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		secret, err := st.Secret(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		granted := false
+		for _, grant := range secret.doc.Grants {
+			if grant == app.Id() {
+				granted = true
+				break
+			}
+		}
+		var update bson.D
+		switch {
+		case action == SecretGranted && !granted:
+			update = bson.D{{"$addToSet", bson.D{{"grants", app.Id()}}}}
+		case action == SecretRevoked && granted:
+			update = bson.D{{"$pull", bson.D{{"grants", app.Id()}}}}
+		default:
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      secretsC,
+			Id:     secret.doc.DocID,
+			Assert: bson.D{{"txn-revno", secret.doc.TxnRevno}},
+			Update: update,
+		}, st.secretAuditOp(id, actor, action, secret.doc.Revision, app.Id())}, nil
+	}
+	err := st.run(buildTxn)
+	return errors.Annotatef(err, "cannot %s access to secret %q for %q", action, id, app.Id())
+}
+
+// SecretRotated records that the owner of the secret with the given ID
+// has been asked to rotate it, and schedules the next rotation.
+func (st *State) SecretRotated(id string, actor names.Tag) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		secret, err := st.Secret(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if secret.doc.RotateInterval <= 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		next := nowToTheSecond().Add(secret.doc.RotateInterval)
+		return []txn.Op{{
+			C:      secretsC,
+			Id:     secret.doc.DocID,
+			Assert: bson.D{{"txn-revno", secret.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{{"next-rotate-time", next}}}},
+		}, st.secretAuditOp(id, actor, SecretRotated, secret.doc.Revision, "")}, nil
+	}
+	err := st.run(buildTxn)
+	return errors.Annotatef(err, "cannot record rotation of secret %q", id)
+}
+
+// SecretAuditRecords returns the audit trail of the secret with the
+// given ID, oldest first.
+func (st *State) SecretAuditRecords(id string) ([]SecretAuditRecord, error) {
+	audit, closer := st.getCollection(secretAuditC)
+	defer closer()
+
+	var docs []secretAuditDoc
+	if err := audit.Find(bson.D{{"secret-id", id}}).Sort("time", "_id").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get audit records for secret %q", id)
+	}
+	result := make([]SecretAuditRecord, len(docs))
+	for i, doc := range docs {
+		result[i] = SecretAuditRecord{
+			SecretID: doc.SecretID,
+			Time:     doc.Time,
+			Entity:   doc.Entity,
+			Action:   SecretAction(doc.Action),
+			Revision: doc.Revision,
+			Detail:   doc.Detail,
+		}
+	}
+	return result, nil
+}
+
+// WatchSecrets returns a StringsWatcher that notifies of changes to
+// the secrets owned by any of the given entities. The watcher reports
+// secret IDs.
+func (st *State) WatchSecrets(owners ...names.Tag) StringsWatcher {
+	prefixes := make([]string, len(owners))
+	for i, owner := range owners {
+		prefixes[i] = secretOwnerPrefix(owner)
+	}
+	hasOwnerPrefix := func(localID string) (string, bool) {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(localID, prefix) {
+				return prefix, true
+			}
+		}
+		return "", false
+	}
+	return newcollectionWatcher(st, colWCfg{
+		col: secretsC,
+		filter: func(id interface{}) bool {
+			k, err := st.strictLocalID(id.(string))
+			if err != nil {
+				return false
+			}
+			_, ok := hasOwnerPrefix(k)
+			return ok
+		},
+		idconv: func(localID string) string {
+			prefix, _ := hasOwnerPrefix(localID)
+			return strings.TrimPrefix(localID, prefix)
+		},
+	})
+}
+
+// removeSecretsOps returns the operations that remove the secrets owned
+// by the given entity. Their audit trails are removed by cleanups.
+func removeSecretsOps(st *State, owner names.Tag) ([]txn.Op, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	var docs []secretDoc
+	query := bson.D{{"owner", owner.String()}}
+	if err := secrets.Find(query).Select(bson.D{{"secret-id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get secrets owned by %s", owner)
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		ops = append(ops, txn.Op{
+			C:      secretsC,
+			Id:     doc.DocID,
+			Remove: true,
+		}, st.newCleanupOp(cleanupSecretAudit, doc.SecretID))
+	}
+	return ops, nil
+}
+
+// secretAuditOp returns a txn.Op that records an operation on a secret.
+func (st *State) secretAuditOp(id string, actor names.Tag, action SecretAction, revision int, detail string) txn.Op {
+	docID := st.docID(bson.NewObjectId().Hex())
+	entity := ""
+	if actor != nil {
+		entity = actor.String()
+	}
+	return txn.Op{
+		C:      secretAuditC,
+		Id:     docID,
+		Assert: txn.DocMissing,
+		Insert: &secretAuditDoc{
+			DocID:     docID,
+			ModelUUID: st.ModelUUID(),
+			SecretID:  id,
+			Time:      GetClock().Now().UTC(),
+			Entity:    entity,
+			Action:    string(action),
+			Revision:  revision,
+			Detail:    detail,
+		},
+	}
+}
+
+// NewSecretsKey returns a new, base64 encoded, controller secrets key
+// suitable for recording in the controller's StateServingInfo.
+func NewSecretsKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", errors.Trace(err)
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// EnsureSecretsKey records a new controller secrets key in the state
+// serving info if and only if it does not already hold one.
+func EnsureSecretsKey(st *State) error {
+	info, err := st.StateServingInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if info.SecretsKey != "" {
+		return nil
+	}
+	key, err := NewSecretsKey()
+	if err != nil {
+		return errors.Annotate(err, "cannot generate secrets key")
+	}
+	ops := []txn.Op{{
+		C:      controllersC,
+		Id:     stateServingInfoKey,
+		Assert: bson.D{{"secretskey", bson.D{{"$in", []interface{}{nil, ""}}}}},
+		Update: bson.D{{"$set", bson.D{{"secretskey", key}}}},
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		// Another controller recorded a key first.
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "cannot set secrets key")
+	}
+	return nil
+}
+
+// SetSecretsKey sets the controller secrets key from which the keys
+// that encrypt each model's secrets are derived, as held in the
+// controller agent's StateServingInfo. States created with ForModel
+// inherit the key. A State without a key reads the one recorded in
+// the database's StateServingInfo whenever it needs it.
+func (st *State) SetSecretsKey(key []byte) {
+	st.secretsKey = key
+}
+
+// modelSecretsKey returns the key used to encrypt the model's secrets,
+// derived from the controller's secrets key and the model's UUID.
+func (st *State) modelSecretsKey() ([]byte, error) {
+	key := st.secretsKey
+	if len(key) == 0 {
+		info, err := st.StateServingInfo()
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Annotate(err, "cannot read controller secrets key")
+		}
+		if key, err = base64.StdEncoding.DecodeString(info.SecretsKey); err != nil {
+			return nil, errors.Annotate(err, "cannot decode controller secrets key")
+		}
+	}
+	if len(key) == 0 {
+		return nil, errors.NotProvisionedf("controller secrets key")
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(st.ModelUUID()))
+	return mac.Sum(nil), nil
+}
+
+// encryptSecretData encrypts the secret value with AES-GCM, returning
+// the nonce and ciphertext encoded as base64.
+func encryptSecretData(key []byte, data map[string]string) (string, error) {
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	gcm, err := newSecretsCipher(key)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.Annotate(err, "cannot generate nonce")
+	}
+	sealed := gcm.Seal(nonce, nonce, plaintext, nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptSecretData reverses encryptSecretData.
+func decryptSecretData(key []byte, encoded string) (map[string]string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	gcm, err := newSecretsCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var data map[string]string
+	if err := json.Unmarshal(plaintext, &data); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+func newSecretsCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,378 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	owner names.ApplicationTag
+	unit  names.UnitTag
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.owner = names.NewApplicationTag("mysql")
+	s.unit = names.NewUnitTag("mysql/0")
+}
+
+func (s *SecretsSuite) addSecret(c *gc.C, label string, interval time.Duration) *state.Secret {
+	secret, err := s.State.AddSecret(state.CreateSecretParams{
+		Owner:          s.owner,
+		Actor:          s.unit,
+		Label:          label,
+		Description:    "database password",
+		Data:           map[string]string{"password": "sekrit"},
+		RotateInterval: interval,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return secret
+}
+
+func (s *SecretsSuite) TestAddSecret(c *gc.C) {
+	secret := s.addSecret(c, "db", 0)
+	c.Assert(secret.ID(), gc.Not(gc.Equals), "")
+	owner, err := secret.Owner()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.Equals, names.Tag(s.owner))
+	c.Assert(secret.Label(), gc.Equals, "db")
+	c.Assert(secret.Revision(), gc.Equals, 1)
+	c.Assert(secret.NextRotateTime(), gc.IsNil)
+
+	got, err := s.State.Secret(secret.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.Description(), gc.Equals, "database password")
+
+	got, err = s.State.SecretByLabel(s.owner, "db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.ID(), gc.Equals, secret.ID())
+}
+
+func (s *SecretsSuite) TestAddSecretDuplicateLabel(c *gc.C) {
+	s.addSecret(c, "db", 0)
+	_, err := s.State.AddSecret(state.CreateSecretParams{
+		Owner: s.owner,
+		Label: "db",
+		Data:  map[string]string{"password": "other"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *SecretsSuite) TestAddSecretInvalid(c *gc.C) {
+	_, err := s.State.AddSecret(state.CreateSecretParams{
+		Owner: names.NewMachineTag("0"),
+		Data:  map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, gc.ErrorMatches, `secret owner "machine-0" not valid`)
+
+	_, err = s.State.AddSecret(state.CreateSecretParams{Owner: s.owner})
+	c.Assert(err, gc.ErrorMatches, `empty secret value not valid`)
+}
+
+func (s *SecretsSuite) TestSecretNotFound(c *gc.C) {
+	_, err := s.State.Secret("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `secret "missing" not found`)
+}
+
+func (s *SecretsSuite) TestSecretValueEncryptedAtRest(c *gc.C) {
+	secret := s.addSecret(c, "", 0)
+
+	coll, closer := state.GetRawCollection(s.State, state.SecretsC)
+	defer closer()
+	var raw bson.M
+	err := coll.Find(bson.D{{"secret-id", secret.ID()}}).One(&raw)
+	c.Assert(err, jc.ErrorIsNil)
+	data, ok := raw["data"].(string)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(strings.Contains(data, "sekrit"), jc.IsFalse)
+
+	value, err := s.State.SecretValue(secret.ID(), s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"password": "sekrit"})
+}
+
+func (s *SecretsSuite) TestSecretsNeedControllerKey(c *gc.C) {
+	s.State.SetSecretsKey(nil)
+	_, err := s.State.AddSecret(state.CreateSecretParams{
+		Owner: s.owner,
+		Data:  map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+	c.Assert(err, gc.ErrorMatches, "cannot add secret: controller secrets key not provisioned")
+}
+
+func (s *SecretsSuite) TestEnsureSecretsKey(c *gc.C) {
+	err := s.State.SetStateServingInfo(state.StateServingInfo{
+		APIPort:    69,
+		StatePort:  80,
+		Cert:       "Some cert",
+		PrivateKey: "Some key",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.EnsureSecretsKey(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := s.State.StateServingInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.SecretsKey, gc.Not(gc.Equals), "")
+
+	// The key is only generated once.
+	err = state.EnsureSecretsKey(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	again, err := s.State.StateServingInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again.SecretsKey, gc.Equals, info.SecretsKey)
+}
+
+func (s *SecretsSuite) TestSecretsKeyReadFromState(c *gc.C) {
+	key, err := state.NewSecretsKey()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetStateServingInfo(state.StateServingInfo{
+		APIPort:    69,
+		StatePort:  80,
+		Cert:       "Some cert",
+		PrivateKey: "Some key",
+		SecretsKey: key,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A State given no key, such as one opened by a controller agent
+	// whose config predates the key, uses the one recorded in state.
+	s.State.SetSecretsKey(nil)
+	secret := s.addSecret(c, "", 0)
+	value, err := s.State.SecretValue(secret.ID(), s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"password": "sekrit"})
+}
+
+func (s *SecretsSuite) TestSecretValueWrongControllerKey(c *gc.C) {
+	secret := s.addSecret(c, "", 0)
+
+	s.State.SetSecretsKey([]byte("fedcba9876543210fedcba9876543210"))
+	_, err := s.State.SecretValue(secret.ID(), s.unit)
+	c.Assert(err, gc.ErrorMatches, `cannot decrypt secret ".*": .*`)
+}
+
+func (s *SecretsSuite) TestUpdateSecret(c *gc.C) {
+	secret := s.addSecret(c, "", time.Hour)
+	c.Assert(secret.NextRotateTime(), gc.NotNil)
+
+	description := "new description"
+	updated, err := s.State.UpdateSecret(secret.ID(), state.UpdateSecretParams{
+		Actor:       s.unit,
+		Data:        map[string]string{"password": "changed"},
+		Description: &description,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated.Revision(), gc.Equals, 2)
+	c.Assert(updated.Description(), gc.Equals, description)
+	c.Assert(updated.NextRotateTime(), gc.NotNil)
+
+	value, err := s.State.SecretValue(secret.ID(), s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"password": "changed"})
+
+	// Turning off rotation clears the next rotation time.
+	interval := time.Duration(0)
+	updated, err = s.State.UpdateSecret(secret.ID(), state.UpdateSecretParams{
+		Actor:          s.unit,
+		RotateInterval: &interval,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated.Revision(), gc.Equals, 2)
+	c.Assert(updated.NextRotateTime(), gc.IsNil)
+}
+
+func (s *SecretsSuite) TestGrantRevoke(c *gc.C) {
+	secret := s.addSecret(c, "", 0)
+	wordpress := names.NewUnitTag("wordpress/0")
+	c.Assert(secret.IsOwnedBy(s.unit), jc.IsTrue)
+	c.Assert(secret.IsReadableBy(s.unit), jc.IsTrue)
+	c.Assert(secret.IsReadableBy(wordpress), jc.IsFalse)
+
+	err := s.State.GrantSecret(secret.ID(), names.NewApplicationTag("wordpress"), s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err = s.State.Secret(secret.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Grants(), jc.DeepEquals, []string{"wordpress"})
+	c.Assert(secret.IsReadableBy(wordpress), jc.IsTrue)
+	c.Assert(secret.IsOwnedBy(wordpress), jc.IsFalse)
+
+	// Granting again is a no-op.
+	err = s.State.GrantSecret(secret.ID(), names.NewApplicationTag("wordpress"), s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RevokeSecret(secret.ID(), names.NewApplicationTag("wordpress"), s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err = s.State.Secret(secret.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Grants(), gc.HasLen, 0)
+	c.Assert(secret.IsReadableBy(wordpress), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestAuditRecords(c *gc.C) {
+	secret := s.addSecret(c, "", 0)
+	_, err := s.State.SecretValue(secret.ID(), names.NewUnitTag("mysql/1"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.GrantSecret(secret.ID(), names.NewApplicationTag("wordpress"), s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+
+	records, err := s.State.SecretAuditRecords(secret.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 3)
+	c.Assert(records[0].Action, gc.Equals, state.SecretCreated)
+	c.Assert(records[0].Entity, gc.Equals, "unit-mysql-0")
+	c.Assert(records[1].Action, gc.Equals, state.SecretRead)
+	c.Assert(records[1].Entity, gc.Equals, "unit-mysql-1")
+	c.Assert(records[2].Action, gc.Equals, state.SecretGranted)
+	c.Assert(records[2].Detail, gc.Equals, "wordpress")
+}
+
+func (s *SecretsSuite) TestSecretRotated(c *gc.C) {
+	secret := s.addSecret(c, "", time.Hour)
+	before := *secret.NextRotateTime()
+
+	err := s.State.SecretRotated(secret.ID(), s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err = s.State.Secret(secret.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.NextRotateTime().Before(before), jc.IsFalse)
+
+	records, err := s.State.SecretAuditRecords(secret.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records[len(records)-1].Action, gc.Equals, state.SecretRotated)
+}
+
+func (s *SecretsSuite) TestSecretsOwnedBy(c *gc.C) {
+	secret := s.addSecret(c, "", 0)
+	_, err := s.State.AddSecret(state.CreateSecretParams{
+		Owner: names.NewApplicationTag("wordpress"),
+		Data:  map[string]string{"key": "value"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	owned, err := s.State.SecretsOwnedBy(s.owner, s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owned, gc.HasLen, 1)
+	c.Assert(owned[0].ID(), gc.Equals, secret.ID())
+
+	all, err := s.State.AllSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+}
+
+func (s *SecretsSuite) TestWatchSecrets(c *gc.C) {
+	existing := s.addSecret(c, "", 0)
+
+	w := s.State.WatchSecrets(s.owner, s.unit)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(existing.ID())
+	wc.AssertNoChange()
+
+	// Secrets owned by other entities are not reported.
+	_, err := s.State.AddSecret(state.CreateSecretParams{
+		Owner: names.NewApplicationTag("mysql-router"),
+		Data:  map[string]string{"key": "value"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	interval := time.Hour
+	_, err = s.State.UpdateSecret(existing.ID(), state.UpdateSecretParams{
+		RotateInterval: &interval,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(existing.ID())
+	wc.AssertNoChange()
+}
+
+func (s *SecretsSuite) TestRemoveUnitRemovesSecrets(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	owned, err := s.State.AddSecret(state.CreateSecretParams{
+		Owner: unit.Tag(),
+		Actor: unit.Tag(),
+		Data:  map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	other := s.addSecret(c, "", 0)
+
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Secret(owned.ID())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.Secret(other.ID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	records, err := s.State.SecretAuditRecords(owned.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 0)
+	records, err = s.State.SecretAuditRecords(other.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 1)
+}
+
+func (s *SecretsSuite) TestRemoveApplicationRemovesSecrets(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	owned, err := s.State.AddSecret(state.CreateSecretParams{
+		Owner: app.Tag(),
+		Data:  map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// With no units or relations, the application is removed at once.
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Secret(owned.ID())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	records, err := s.State.SecretAuditRecords(owned.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestRemoveModelRemovesSecrets(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	_, err := st.AddSecret(state.CreateSecretParams{
+		Owner: s.owner,
+		Data:  map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.ProcessDyingModel()
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.RemoveAllModelDocs()
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := st.AllSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+}
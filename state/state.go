@@ -86,6 +86,11 @@ type State struct {
 	// represented by this state runs.
 	cloudName string
 
+	// secretsKey is the controller secrets key from which the keys
+	// encrypting each model's secrets are derived, as read from the
+	// agent's StateServingInfo.
+	secretsKey []byte
+
 	// leaseClientId is used by the lease infrastructure to
 	// differentiate between machines whose clocks may be
 	// relatively-skewed.
@@ -128,6 +133,9 @@ type StateServingInfo struct {
 	// this will be passed as the KeyFile argument to MongoDB
 	SharedSecret   string
 	SystemIdentity string
+	// SecretsKey holds, base64 encoded, the key from which the keys
+	// encrypting each model's secrets are derived.
+	SecretsKey string
 }
 
 // IsController returns true if this state instance has the bootstrap
@@ -279,6 +287,7 @@ func (st *State) ForModel(model names.ModelTag) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	newSt.secretsKey = st.secretsKey
 	if err := newSt.start(st.controllerTag); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -897,6 +906,9 @@ type AddApplicationArgs struct {
 	Placement        []*instance.Placement
 	Constraints      constraints.Value
 	Resources        map[string]string
+	// Trust records whether the application may access the
+	// model's cloud credential.
+	Trust bool
 }
 
 // AddApplication creates a new application, running the supplied charm, with the
@@ -1034,6 +1046,7 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		Channel:       string(args.Channel),
 		RelationCount: len(peers),
 		Life:          Alive,
+		Trust:         args.Trust,
 	}
 
 	svc := newApplication(st, svcDoc)
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type LeadershipSuite struct {
@@ -134,6 +135,26 @@ func (s *LeadershipSuite) TestHackLeadershipUnblocksClaimer(c *gc.C) {
 	}
 }
 
+func (s *LeadershipSuite) TestApplicationLeader(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	_, err := s.State.ApplicationLeader(app.Name())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.claimer.ClaimLeadership(app.Name(), unit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	leader, err := s.State.ApplicationLeader(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(leader, gc.Equals, unit.Name())
+}
+
+func (s *LeadershipSuite) TestApplicationLeaderUnknownApplication(c *gc.C) {
+	_, err := s.State.ApplicationLeader("missing")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *LeadershipSuite) expire(c *gc.C, applicationname string) {
 	s.clock.Advance(time.Hour)
 	select {
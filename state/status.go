@@ -250,6 +250,17 @@ func statusHistory(args *statusHistoryArgs) ([]status.StatusInfo, error) {
 // that the collection is smaller than <maxLogsMB> after the
 // deletion.
 func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
+	if err := validatePruneCriteria(maxHistoryTime, maxHistoryMB); err != nil {
+		return errors.Trace(err)
+	}
+	history, closer := st.getRawCollection(statusesHistoryC)
+	defer closer()
+	return pruneHistory(history, "updated", "status history", maxHistoryTime, maxHistoryMB)
+}
+
+// validatePruneCriteria returns an error unless the supplied history
+// pruning criteria are usable.
+func validatePruneCriteria(maxHistoryTime time.Duration, maxHistoryMB int) error {
 	if maxHistoryMB < 0 {
 		return errors.NotValidf("non-positive maxHistoryMB")
 	}
@@ -259,15 +270,21 @@ func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB in
 	if maxHistoryMB == 0 && maxHistoryTime == 0 {
 		return errors.NotValidf("backlog size and time constraints are both 0")
 	}
-	history, closer := st.getRawCollection(statusesHistoryC)
-	defer closer()
+	return nil
+}
 
-	// Status Record Age
+// pruneHistory removes entries from the history collection, whose
+// timeField holds the time of each entry in nanoseconds, until only
+// entries newer than <maxHistoryTime> remain and the collection is
+// smaller than <maxHistoryMB>. The description names the history in
+// errors.
+func pruneHistory(history *mgo.Collection, timeField, description string, maxHistoryTime time.Duration, maxHistoryMB int) error {
+	// Record Age
 	// TODO(perrito666): 2016-04-26 lp:1558657
 	if maxHistoryTime > 0 {
 		t := time.Now().Add(-maxHistoryTime)
 		_, err := history.RemoveAll(bson.D{
-			{"updated", bson.M{"$lt": t.UnixNano()}},
+			{timeField, bson.M{"$lt": t.UnixNano()}},
 		})
 		if err != nil {
 			return errors.Trace(err)
@@ -279,7 +296,7 @@ func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB in
 	// Collection Size
 	collMB, err := getCollectionMB(history)
 	if err != nil {
-		return errors.Annotate(err, "retrieving status history collection size")
+		return errors.Annotatef(err, "retrieving %s collection size", description)
 	}
 	if collMB <= maxHistoryMB {
 		return nil
@@ -291,25 +308,25 @@ func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB in
 		return nil
 	}
 	if err != nil {
-		return errors.Annotate(err, "counting status history records")
+		return errors.Annotatef(err, "counting %s records", description)
 	}
-	// We are making the assumption that status sizes can be averaged for
+	// We are making the assumption that entry sizes can be averaged for
 	// large numbers and we will get a reasonable approach on the size.
 	// Note: Capped collections are not used for this because they, currently
 	// at least, lack a way to be resized and the size is expected to change
 	// as real life data of the history usage is gathered.
-	sizePerStatus := float64(collMB) / float64(count)
-	if sizePerStatus == 0 {
-		return errors.New("unexpected result calculating status history entry size")
+	sizePerEntry := float64(collMB) / float64(count)
+	if sizePerEntry == 0 {
+		return errors.Errorf("unexpected result calculating %s entry size", description)
 	}
-	deleteStatuses := count - int(float64(collMB-maxHistoryMB)/sizePerStatus)
-	result := historicalStatusDoc{}
-	err = history.Find(nil).Sort("-updated").Skip(deleteStatuses).One(&result)
+	deleteEntries := count - int(float64(collMB-maxHistoryMB)/sizePerEntry)
+	var result bson.M
+	err = history.Find(nil).Select(bson.M{timeField: 1}).Sort("-" + timeField).Skip(deleteEntries).One(&result)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	_, err = history.RemoveAll(bson.D{
-		{"updated", bson.M{"$lt": result.Updated}},
+		{timeField, bson.M{"$lt": result[timeField]}},
 	})
 	if err != nil {
 		return errors.Trace(err)
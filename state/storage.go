@@ -865,8 +865,9 @@ func validateStorageConstraintsAgainstCharm(
 
 // validateStoragePool validates the storage pool for the model.
 // If machineId is non-nil, the storage scope will be validated against
-// the machineId; if the storage is not machine-scoped, then the machineId
-// will be updated to "".
+// the machineId; if the storage is host-scoped, then the machineId will
+// be updated to that of the container's host; if the storage is not
+// machine-scoped, then the machineId will be updated to "".
 func validateStoragePool(
 	st *State, poolName string, kind storage.StorageKind, machineId *string,
 ) error {
@@ -903,6 +904,18 @@ func validateStoragePool(
 			if *machineId == "" {
 				return errors.Annotate(err, "machine unspecified for machine-scoped storage")
 			}
+		case storage.ScopeHost:
+			if *machineId == "" {
+				return errors.New("machine unspecified for host-scoped storage")
+			}
+			hostId := ParentId(*machineId)
+			if hostId == "" {
+				return errors.Errorf("%q provider only supports storage for containers", providerType)
+			}
+			// Host-scoped storage is managed by the machine
+			// hosting the container, so we inform the caller
+			// that the storage scope should be that machine.
+			*machineId = hostId
 		default:
 			// The storage is not machine-scoped, so we clear out
 			// the machine ID to inform the caller that the storage
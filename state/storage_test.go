@@ -44,6 +44,10 @@ func (s *StorageStateSuiteBase) SetUpSuite(c *gc.C) {
 		StorageScope: storage.ScopeMachine,
 		IsDynamic:    true,
 	})
+	registry.RegisterProvider("hostscoped", &dummy.StorageProvider{
+		StorageScope: storage.ScopeHost,
+		IsDynamic:    true,
+	})
 	registry.RegisterProvider("environscoped-block", &dummy.StorageProvider{
 		StorageScope: storage.ScopeEnviron,
 		SupportsFunc: func(k storage.StorageKind) bool {
@@ -55,12 +59,13 @@ func (s *StorageStateSuiteBase) SetUpSuite(c *gc.C) {
 		IsDynamic: false,
 	})
 	registry.RegisterEnvironStorageProviders(
-		"someprovider", "environscoped", "machinescoped",
+		"someprovider", "environscoped", "machinescoped", "hostscoped",
 		"environscoped-block", "static",
 	)
 	s.AddCleanup(func(c *gc.C) {
 		registry.RegisterProvider("environscoped", nil)
 		registry.RegisterProvider("machinescoped", nil)
+		registry.RegisterProvider("hostscoped", nil)
 		registry.RegisterProvider("environscoped-block", nil)
 		registry.RegisterProvider("static", nil)
 	})
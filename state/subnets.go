@@ -33,6 +33,16 @@ type SubnetInfo struct {
 	// SpaceName is the name of the space the subnet is associated with. It
 	// can be empty if the subnet is not associated with a space yet.
 	SpaceName string
+
+	// FanLocalUnderlay is the CIDR of the subnet whose hosts own this
+	// segment of a Fan overlay network. It is empty for subnets which
+	// are not part of a Fan overlay.
+	FanLocalUnderlay string
+
+	// FanOverlay is the CIDR of the Fan overlay network this subnet is
+	// a segment of. It is empty for subnets which are not part of a Fan
+	// overlay.
+	FanOverlay string
 }
 
 type Subnet struct {
@@ -50,8 +60,10 @@ type subnetDoc struct {
 	AvailabilityZone string `bson:"availabilityzone,omitempty"`
 	// TODO: add IsPublic to SubnetArgs, add an IsPublic method and add
 	// IsPublic to migration import/export.
-	IsPublic  bool   `bson:"is-public,omitempty"`
-	SpaceName string `bson:"space-name,omitempty"`
+	IsPublic         bool   `bson:"is-public,omitempty"`
+	SpaceName        string `bson:"space-name,omitempty"`
+	FanLocalUnderlay string `bson:"fan-local-underlay,omitempty"`
+	FanOverlay       string `bson:"fan-overlay,omitempty"`
 }
 
 // Life returns whether the subnet is Alive, Dying or Dead.
@@ -155,6 +167,20 @@ func (s *Subnet) SpaceName() string {
 	return s.doc.SpaceName
 }
 
+// FanLocalUnderlay returns the CIDR of the subnet whose hosts own this
+// segment of a Fan overlay, or the empty string if the subnet is not
+// part of a Fan overlay.
+func (s *Subnet) FanLocalUnderlay() string {
+	return s.doc.FanLocalUnderlay
+}
+
+// FanOverlay returns the CIDR of the Fan overlay network this subnet is
+// a segment of, or the empty string if the subnet is not part of a Fan
+// overlay.
+func (s *Subnet) FanOverlay() string {
+	return s.doc.FanOverlay
+}
+
 // Validate validates the subnet, checking the CIDR, and VLANTag, if present.
 func (s *Subnet) Validate() error {
 	if s.doc.CIDR != "" {
@@ -222,9 +248,33 @@ func (st *State) AddSubnet(args SubnetInfo) (subnet *Subnet, err error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if args.FanOverlay == "" {
+		if err := st.addFanSubnetsForUnderlay(subnet); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	return subnet, nil
 }
 
+// addFanSubnetsForUnderlay records the segments of the model's Fan
+// overlay networks owned by the hosts in the given subnet.
+func (st *State) addFanSubnetsForUnderlay(subnet *Subnet) error {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	args, err := fanSubnetArgsForUnderlay(subnet, cfg.FanConfig())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, arg := range args {
+		if _, err := st.AddSubnet(arg); err != nil && !errors.IsAlreadyExists(err) {
+			return errors.Annotatef(err, "adding fan subnet %q", arg.CIDR)
+		}
+	}
+	return nil
+}
+
 func (st *State) newSubnetFromArgs(args SubnetInfo) (*Subnet, error) {
 	subnetID := st.docID(args.CIDR)
 	subDoc := subnetDoc{
@@ -236,6 +286,8 @@ func (st *State) newSubnetFromArgs(args SubnetInfo) (*Subnet, error) {
 		ProviderId:       string(args.ProviderId),
 		AvailabilityZone: args.AvailabilityZone,
 		SpaceName:        args.SpaceName,
+		FanLocalUnderlay: args.FanLocalUnderlay,
+		FanOverlay:       args.FanOverlay,
 	}
 	subnet := &Subnet{doc: subDoc, st: st}
 	err := subnet.Validate()
@@ -256,6 +308,8 @@ func (st *State) addSubnetOps(args SubnetInfo) []txn.Op {
 		ProviderId:       string(args.ProviderId),
 		AvailabilityZone: args.AvailabilityZone,
 		SpaceName:        args.SpaceName,
+		FanLocalUnderlay: args.FanLocalUnderlay,
+		FanOverlay:       args.FanOverlay,
 	}
 	ops := []txn.Op{
 		{
@@ -302,3 +356,95 @@ func (st *State) AllSubnets() (subnets []*Subnet, err error) {
 	}
 	return subnets, nil
 }
+
+// AddFanSubnets records the segments of the given Fan overlay networks
+// owned by the hosts in each known subnet of the Fan underlays. Each
+// segment inherits the space and availability zone of its underlay
+// subnet, so that container addresses on the Fan are treated like those
+// of their hosts. Segments which already exist are left untouched.
+//
+// The segments are recorded whenever the model's fan-config changes,
+// and when a subnet in a Fan underlay is added.
+func (st *State) AddFanSubnets(fanConfig network.FanConfig) error {
+	args, err := st.fanSubnetArgs(fanConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, arg := range args {
+		if _, err := st.AddSubnet(arg); err != nil && !errors.IsAlreadyExists(err) {
+			return errors.Annotatef(err, "adding fan subnet for %q", arg.FanLocalUnderlay)
+		}
+	}
+	return nil
+}
+
+// fanSubnetArgs returns the SubnetInfo for each segment of the given
+// Fan overlay networks owned by the hosts in the model's subnets.
+func (st *State) fanSubnetArgs(fanConfig network.FanConfig) ([]SubnetInfo, error) {
+	if len(fanConfig) == 0 {
+		return nil, nil
+	}
+	subnets, err := st.AllSubnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []SubnetInfo
+	for _, subnet := range subnets {
+		args, err := fanSubnetArgsForUnderlay(subnet, fanConfig)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, args...)
+	}
+	return result, nil
+}
+
+// fanSubnetArgsForUnderlay returns the SubnetInfo for each segment of
+// the given Fan overlay networks owned by the hosts in the given subnet.
+func fanSubnetArgsForUnderlay(subnet *Subnet, fanConfig network.FanConfig) ([]SubnetInfo, error) {
+	if subnet.FanOverlay() != "" || subnet.Life() != Alive {
+		return nil, nil
+	}
+	var result []SubnetInfo
+	for _, entry := range fanConfig {
+		segment, err := network.CalculateOverlaySegment(subnet.CIDR(), entry)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if segment == nil {
+			continue
+		}
+		result = append(result, SubnetInfo{
+			CIDR:             segment.String(),
+			AvailabilityZone: subnet.AvailabilityZone(),
+			SpaceName:        subnet.SpaceName(),
+			FanLocalUnderlay: subnet.CIDR(),
+			FanOverlay:       entry.Overlay.String(),
+		})
+	}
+	return result, nil
+}
+
+// fanSubnetForAddress returns the segment of the Fan overlay network
+// with the given CIDR which contains the given address. Fan bridges
+// give containers addresses within the whole overlay, so this is used
+// to link those addresses to the segment owned by the host.
+func (st *State) fanSubnetForAddress(overlayCIDR string, ip net.IP) (*Subnet, error) {
+	subnets, closer := st.getCollection(subnetsC)
+	defer closer()
+
+	var docs []subnetDoc
+	if err := subnets.Find(bson.D{{"fan-overlay", overlayCIDR}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get fan subnets of %q", overlayCIDR)
+	}
+	for _, doc := range docs {
+		_, ipNet, err := net.ParseCIDR(doc.CIDR)
+		if err != nil {
+			continue
+		}
+		if ipNet.Contains(ip) {
+			return &Subnet{st, doc}, nil
+		}
+	}
+	return nil, errors.NotFoundf("fan subnet of %q containing %q", overlayCIDR, ip)
+}
@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
 
@@ -272,3 +273,95 @@ func (s *SubnetSuite) TestAllSubnets(c *gc.C) {
 		c.Assert(subnet.AvailabilityZone(), gc.Equals, subnetInfos[i].AvailabilityZone)
 	}
 }
+
+func (s *SubnetSuite) TestAddSubnetWithFanFields(c *gc.C) {
+	subnet, err := s.State.AddSubnet(state.SubnetInfo{
+		CIDR:             "252.16.0.0/12",
+		FanLocalUnderlay: "172.31.16.0/20",
+		FanOverlay:       "252.0.0.0/8",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.FanLocalUnderlay(), gc.Equals, "172.31.16.0/20")
+	c.Assert(subnet.FanOverlay(), gc.Equals, "252.0.0.0/8")
+
+	subnet, err = s.State.Subnet("252.16.0.0/12")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.FanLocalUnderlay(), gc.Equals, "172.31.16.0/20")
+	c.Assert(subnet.FanOverlay(), gc.Equals, "252.0.0.0/8")
+}
+
+func (s *SubnetSuite) TestAddFanSubnets(c *gc.C) {
+	for _, info := range []state.SubnetInfo{
+		{CIDR: "172.31.16.0/20", AvailabilityZone: "zone1", SpaceName: "dmz"},
+		{CIDR: "172.31.32.0/20", AvailabilityZone: "zone2", SpaceName: "dmz"},
+		{CIDR: "10.0.0.0/24"},
+	} {
+		_, err := s.State.AddSubnet(info)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	fanConfig, err := network.ParseFanConfig("172.31.0.0/16=252.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AddFanSubnets(fanConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	// Adding them again is a no-op.
+	err = s.State.AddFanSubnets(fanConfig)
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnets, err := s.State.AllSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, gc.HasLen, 5)
+
+	for _, expect := range []state.SubnetInfo{{
+		CIDR:             "252.16.0.0/12",
+		AvailabilityZone: "zone1",
+		SpaceName:        "dmz",
+		FanLocalUnderlay: "172.31.16.0/20",
+		FanOverlay:       "252.0.0.0/8",
+	}, {
+		CIDR:             "252.32.0.0/12",
+		AvailabilityZone: "zone2",
+		SpaceName:        "dmz",
+		FanLocalUnderlay: "172.31.32.0/20",
+		FanOverlay:       "252.0.0.0/8",
+	}} {
+		subnet, err := s.State.Subnet(expect.CIDR)
+		c.Assert(err, jc.ErrorIsNil)
+		s.assertSubnetMatchesInfo(c, subnet, expect)
+		c.Assert(subnet.FanLocalUnderlay(), gc.Equals, expect.FanLocalUnderlay)
+		c.Assert(subnet.FanOverlay(), gc.Equals, expect.FanOverlay)
+	}
+}
+
+func (s *SubnetSuite) TestAddSubnetAddsFanSubnets(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"fan-config": "172.31.0.0/16=252.0.0.0/8",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "172.31.16.0/20", SpaceName: "dmz"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnet, err := s.State.Subnet("252.16.0.0/12")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.FanLocalUnderlay(), gc.Equals, "172.31.16.0/20")
+	c.Assert(subnet.FanOverlay(), gc.Equals, "252.0.0.0/8")
+	c.Assert(subnet.SpaceName(), gc.Equals, "dmz")
+}
+
+func (s *SubnetSuite) TestUpdateModelConfigAddsFanSubnets(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "172.31.16.0/20", AvailabilityZone: "zone1"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Subnet("252.16.0.0/12")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"fan-config": "172.31.0.0/16=252.0.0.0/8",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnet, err := s.State.Subnet("252.16.0.0/12")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.FanLocalUnderlay(), gc.Equals, "172.31.16.0/20")
+	c.Assert(subnet.AvailabilityZone(), gc.Equals, "zone1")
+}
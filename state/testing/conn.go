@@ -47,6 +47,7 @@ func Initialize(c *gc.C, owner names.UserTag, cfg *config.Config, controllerInhe
 		NewPolicy:     newPolicy,
 	})
 	c.Assert(err, jc.ErrorIsNil)
+	st.SetSecretsKey(testing.SecretsKey)
 	return st
 }
 
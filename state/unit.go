@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state/presence"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/tools"
 )
 
@@ -87,6 +88,7 @@ type unitDoc struct {
 	StorageAttachmentCount int `bson:"storageattachmentcount"`
 	MachineId              string
 	Resolved               ResolvedMode
+	ReplayHooks            bool
 	Tools                  *tools.Tools `bson:",omitempty"`
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
@@ -337,7 +339,7 @@ func (u *Unit) Destroy() (err error) {
 	}
 	if err = unit.st.run(buildTxn); err == nil {
 		if historyErr := unit.eraseHistory(); historyErr != nil {
-			logger.Errorf("cannot delete history for unit %q: %v", unit.globalKey(), err)
+			logger.Errorf("cannot delete history for unit %q: %v", unit.globalKey(), historyErr)
 		}
 		if err = unit.Refresh(); errors.IsNotFound(err) {
 			return nil
@@ -351,13 +353,12 @@ func (u *Unit) eraseHistory() error {
 	defer closer()
 	historyW := history.Writeable()
 
-	if _, err := historyW.RemoveAll(bson.D{{"statusid", u.globalKey()}}); err != nil {
-		return err
-	}
-	if _, err := historyW.RemoveAll(bson.D{{"statusid", u.globalAgentKey()}}); err != nil {
-		return err
+	for _, key := range []string{u.globalKey(), u.globalAgentKey(), u.globalHealthKey()} {
+		if _, err := historyW.RemoveAll(bson.D{{"globalkey", key}}); err != nil {
+			return err
+		}
 	}
-	return nil
+	return u.eraseHookHistory()
 }
 
 // destroyOps returns the operations required to destroy the unit. If it
@@ -659,7 +660,13 @@ func (u *Unit) Remove() (err error) {
 		}
 		return nil, jujutxn.ErrNoOperations
 	}
-	return unit.st.run(buildTxn)
+	if err := unit.st.run(buildTxn); err != nil {
+		return err
+	}
+	if historyErr := unit.eraseHistory(); historyErr != nil {
+		logger.Errorf("cannot delete history for unit %q: %v", unit.globalKey(), historyErr)
+	}
+	return nil
 }
 
 // Resolved returns the resolved mode for the unit.
@@ -1419,19 +1426,39 @@ func validateDynamicMachineStoragePools(m *Machine, pools set.Strings) error {
 		return nil
 	}
 	if m.ContainerType() != "" {
+		// Only host-scoped storage, which is created on the
+		// container's host and passed through to the container,
+		// may currently be added to containers.
+		//
 		// TODO(axw) consult storage providers to check if they
 		// support adding storage to containers. Loop is fine,
 		// for example.
-		//
-		// TODO(axw) later we might allow *any* storage, and
-		// passthrough/bindmount storage. That would imply either
-		// container creation time only, or requiring containers
-		// to be restarted to pick up new configuration.
-		return errors.NotSupportedf("adding storage to %s container", m.ContainerType())
+		hostScoped, err := hostScopedStoragePools(m.st, pools)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !hostScoped {
+			return errors.NotSupportedf("adding storage to %s container", m.ContainerType())
+		}
 	}
 	return validateDynamicStoragePools(m.st, pools)
 }
 
+// hostScopedStoragePools reports whether all of the specified storage
+// pools use host-scoped storage providers.
+func hostScopedStoragePools(st *State, pools set.Strings) (bool, error) {
+	for pool := range pools {
+		_, provider, err := poolStorageProvider(st, pool)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if provider.Scope() != storage.ScopeHost {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // validateDynamicStoragePools validates that all of the specified storage
 // providers support dynamic storage provisioning. If any provider doesn't
 // support dynamic storage, then an IsNotSupported error is returned.
@@ -2277,6 +2304,55 @@ func (u *Unit) ClearResolved() error {
 	return nil
 }
 
+// ReplayHooksRequested reports whether the unit's agent has been asked
+// to run the hooks it skipped again.
+func (u *Unit) ReplayHooksRequested() bool {
+	return u.doc.ReplayHooks
+}
+
+// RequestReplayHooks asks the unit's agent to run again the hooks it
+// skipped when the unit was resolved without retrying its failed hooks.
+// The agent runs them once the unit is no longer in an error state.
+func (u *Unit) RequestReplayHooks() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot replay hooks for unit %q", u)
+	skipped, err := u.SkippedHooks()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(skipped) == 0 {
+		return errors.New("no skipped hooks")
+	}
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"replayhooks", true}}}},
+	}}
+	if err := u.st.runTransaction(ops); err == txn.ErrAborted {
+		return ErrDead
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	u.doc.ReplayHooks = true
+	return nil
+}
+
+// ClearReplayHooks removes any request to replay the unit's skipped
+// hooks.
+func (u *Unit) ClearReplayHooks() error {
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"replayhooks", false}}}},
+	}}
+	if err := u.st.runTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot clear replay hooks request for unit %q", u)
+	}
+	u.doc.ReplayHooks = false
+	return nil
+}
+
 // StorageConstraints returns the unit's storage constraints.
 func (u *Unit) StorageConstraints() (map[string]StorageConstraints, error) {
 	// TODO(axw) eventually we should be able to override service
@@ -0,0 +1,92 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+)
+
+// unitHealthGlobalKey returns the global database key for the health
+// status of the named unit.
+func unitHealthGlobalKey(name string) string {
+	return unitGlobalKey(name) + "#sat#health"
+}
+
+// globalHealthKey returns the global database key for the unit's
+// health status.
+func (u *Unit) globalHealthKey() string {
+	return unitHealthGlobalKey(u.doc.Name)
+}
+
+// Health returns the result of the unit's charm-defined health checks,
+// as last reported by the unit agent. It returns an error satisfying
+// errors.IsNotFound if the unit has never reported its health, which
+// is the case for units whose charms define no health checks.
+func (u *Unit) Health() (status.StatusInfo, error) {
+	return getStatus(u.st, u.globalHealthKey(), "health")
+}
+
+// SetHealth records the result of the unit's charm-defined health
+// checks.
+func (u *Unit) SetHealth(health status.StatusInfo) error {
+	if !status.ValidHealthStatus(health.Status) {
+		return errors.Errorf("cannot set invalid health status %q", health.Status)
+	}
+	if health.Since == nil {
+		return errors.Errorf("cannot set health status without a timestamp")
+	}
+	// Unlike the unit's other statuses, the health status document is
+	// only created when the unit first reports its health, so that
+	// units whose charms define no health checks never show one.
+	doc := statusDoc{
+		Status:     health.Status,
+		StatusInfo: health.Message,
+		StatusData: utils.EscapeKeys(health.Data),
+		Updated:    health.Since.UnixNano(),
+	}
+	globalKey := u.globalHealthKey()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.Life() == Dead {
+			return nil, ErrDead
+		}
+		txnRevno, err := u.st.readTxnRevno(statusesC, globalKey)
+		if errors.Cause(err) == mgo.ErrNotFound {
+			return []txn.Op{{
+				C:      unitsC,
+				Id:     u.doc.DocID,
+				Assert: notDeadDoc,
+			}, createStatusOp(u.st, globalKey, doc)}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      statusesC,
+			Id:     globalKey,
+			Assert: bson.D{{"txn-revno", txnRevno}},
+			Update: bson.D{{"$set", &doc}},
+		}}, nil
+	}
+	if err := u.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set health of unit %q", u)
+	}
+	probablyUpdateStatusHistory(u.st, globalKey, doc)
+	return nil
+}
+
+// HealthHistory returns a HistoryGetter which enables the caller to
+// request past changes to the unit's health.
+func (u *Unit) HealthHistory() *HistoryGetter {
+	return &HistoryGetter{st: u.st, globalKey: u.globalHealthKey()}
+}
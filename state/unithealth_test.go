@@ -0,0 +1,112 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UnitHealthSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitHealthSuite{})
+
+func (s *UnitHealthSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = factory.NewFactory(s.State).MakeUnit(c, nil)
+}
+
+func (s *UnitHealthSuite) TestHealthNotReported(c *gc.C) {
+	_, err := s.unit.Health()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UnitHealthSuite) TestSetHealth(c *gc.C) {
+	now := time.Now()
+	err := s.unit.SetHealth(status.StatusInfo{
+		Status:  status.StatusUnhealthy,
+		Message: `check "web" failed`,
+		Data:    map[string]interface{}{"web": "connection refused"},
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	health, err := s.unit.Health()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(health.Status, gc.Equals, status.StatusUnhealthy)
+	c.Check(health.Message, gc.Equals, `check "web" failed`)
+	c.Check(health.Data, jc.DeepEquals, map[string]interface{}{"web": "connection refused"})
+
+	later := now.Add(time.Minute)
+	err = s.unit.SetHealth(status.StatusInfo{
+		Status: status.StatusHealthy,
+		Since:  &later,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	health, err = unit.Health()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(health.Status, gc.Equals, status.StatusHealthy)
+	c.Check(health.Message, gc.Equals, "")
+	c.Check(health.Since.Equal(later), jc.IsTrue)
+
+	history, err := unit.HealthHistory().StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Status, gc.Equals, status.StatusHealthy)
+	c.Check(history[1].Status, gc.Equals, status.StatusUnhealthy)
+}
+
+func (s *UnitHealthSuite) TestSetHealthInvalid(c *gc.C) {
+	now := time.Now()
+	err := s.unit.SetHealth(status.StatusInfo{
+		Status: status.StatusActive,
+		Since:  &now,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set invalid health status "active"`)
+}
+
+func (s *UnitHealthSuite) TestSetHealthDead(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	err = s.unit.SetHealth(status.StatusInfo{
+		Status: status.StatusHealthy,
+		Since:  &now,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set health of unit "[a-z0-9-]+/0": not found or dead`)
+}
+
+func (s *UnitHealthSuite) TestRemoveUnitRemovesHealth(c *gc.C) {
+	now := time.Now()
+	err := s.unit.SetHealth(status.StatusInfo{
+		Status: status.StatusHealthy,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.unit.Health()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	history, err := s.unit.HealthHistory().StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
@@ -0,0 +1,206 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// UnitState holds the state the uniter persists for a unit, so that an
+// agent which has lost its local files can resume where it stopped.
+type UnitState struct {
+	// UniterState is the uniter's serialized operation state.
+	UniterState string
+
+	// RelationState maps relation ids to the uniter's serialized
+	// state for each relation the unit is in.
+	RelationState map[int]string
+
+	// SkippedHooks describes the hooks the uniter skipped when the
+	// unit was resolved without retrying its failed hooks.
+	SkippedHooks []string
+}
+
+// UnitStateUpdate describes a change to the state the uniter persists
+// for a unit. Fields left nil are not changed.
+type UnitStateUpdate struct {
+	// UniterState, if set, replaces the uniter's operation state.
+	UniterState *string
+
+	// RelationState, if not nil, replaces the state of all of the
+	// unit's relations.
+	RelationState map[int]string
+
+	// SkippedHooks, if not nil, replaces the descriptions of the hooks
+	// the uniter has skipped.
+	SkippedHooks []string
+}
+
+// unitStateDoc records the state the uniter persists for a unit.
+type unitStateDoc struct {
+	DocID         string            `bson:"_id"`
+	ModelUUID     string            `bson:"model-uuid"`
+	UniterState   string            `bson:"uniter-state,omitempty"`
+	RelationState map[string]string `bson:"relation-state,omitempty"`
+	SkippedHooks  []string          `bson:"skipped-hooks,omitempty"`
+}
+
+// State returns the state the uniter has persisted for the unit. If
+// nothing has been persisted yet, the returned state is empty.
+func (u *Unit) State() (*UnitState, error) {
+	unitStates, closer := u.st.getCollection(unitStatesC)
+	defer closer()
+
+	var doc unitStateDoc
+	err := unitStates.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return &UnitState{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get state for unit %q", u.Name())
+	}
+	relationState, err := relationStateFromDoc(doc.RelationState)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get state for unit %q", u.Name())
+	}
+	return &UnitState{
+		UniterState:   doc.UniterState,
+		RelationState: relationState,
+		SkippedHooks:  skippedHooksFromDoc(doc.SkippedHooks),
+	}, nil
+}
+
+// SkippedHooks returns descriptions of the hooks the uniter skipped
+// when the unit was resolved without retrying its failed hooks, and
+// which have not been replayed since.
+func (u *Unit) SkippedHooks() ([]string, error) {
+	unitStates, closer := u.st.getCollection(unitStatesC)
+	defer closer()
+
+	var doc unitStateDoc
+	err := unitStates.FindId(u.globalKey()).Select(bson.D{{"skipped-hooks", 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get skipped hooks for unit %q", u.Name())
+	}
+	return skippedHooksFromDoc(doc.SkippedHooks), nil
+}
+
+// SetState updates the state the uniter persists for the unit. It
+// fails if the unit is dead.
+func (u *Unit) SetState(update UnitStateUpdate) error {
+	var set bson.D
+	if update.UniterState != nil {
+		set = append(set, bson.DocElem{"uniter-state", *update.UniterState})
+	}
+	if update.RelationState != nil {
+		set = append(set, bson.DocElem{"relation-state", relationStateToDoc(update.RelationState)})
+	}
+	if update.SkippedHooks != nil {
+		set = append(set, bson.DocElem{"skipped-hooks", update.SkippedHooks})
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	unitStates, closer := u.st.getCollection(unitStatesC)
+	defer closer()
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.Life() == Dead {
+			return nil, ErrDead
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		count, err := unitStates.FindId(u.globalKey()).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if count == 0 {
+			doc := &unitStateDoc{
+				DocID:     u.st.docID(u.globalKey()),
+				ModelUUID: u.st.ModelUUID(),
+			}
+			if update.UniterState != nil {
+				doc.UniterState = *update.UniterState
+			}
+			if update.RelationState != nil {
+				doc.RelationState = relationStateToDoc(update.RelationState)
+			}
+			doc.SkippedHooks = update.SkippedHooks
+			return append(ops, txn.Op{
+				C:      unitStatesC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      unitStatesC,
+			Id:     u.st.docID(u.globalKey()),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", set}},
+		}), nil
+	}
+	if err := u.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set state for unit %q", u.Name())
+	}
+	return nil
+}
+
+// removeUnitStateOp returns the operation needed to remove the state
+// the uniter persisted for the unit with the given global key.
+func removeUnitStateOp(st *State, globalKey string) txn.Op {
+	return txn.Op{
+		C:      unitStatesC,
+		Id:     st.docID(globalKey),
+		Remove: true,
+	}
+}
+
+// relationStateToDoc converts relation state keyed by relation id
+// into a form that can be stored in mongo, which requires string keys.
+func relationStateToDoc(relationState map[int]string) map[string]string {
+	result := make(map[string]string, len(relationState))
+	for id, state := range relationState {
+		result[strconv.Itoa(id)] = state
+	}
+	return result
+}
+
+func relationStateFromDoc(relationState map[string]string) (map[int]string, error) {
+	if len(relationState) == 0 {
+		return nil, nil
+	}
+	result := make(map[int]string, len(relationState))
+	for key, state := range relationState {
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, errors.Errorf("invalid relation id %q", key)
+		}
+		result[id] = state
+	}
+	return result, nil
+}
+
+// skippedHooksFromDoc returns nil rather than an empty slice when no
+// hooks have been skipped.
+func skippedHooksFromDoc(skippedHooks []string) []string {
+	if len(skippedHooks) == 0 {
+		return nil
+	}
+	return skippedHooks
+}
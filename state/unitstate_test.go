@@ -0,0 +1,155 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UnitStateSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitStateSuite{})
+
+func (s *UnitStateSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = factory.NewFactory(s.State).MakeUnit(c, nil)
+}
+
+func (s *UnitStateSuite) TestStateEmpty(c *gc.C) {
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, &state.UnitState{})
+}
+
+func (s *UnitStateSuite) TestSetState(c *gc.C) {
+	uniterState := "kind: continue\nstep: pending\n"
+	err := s.unit.SetState(state.UnitStateUpdate{
+		UniterState:   &uniterState,
+		RelationState: map[int]string{1: "members: {}\n"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, &state.UnitState{
+		UniterState:   uniterState,
+		RelationState: map[int]string{1: "members: {}\n"},
+	})
+}
+
+func (s *UnitStateSuite) TestSetStatePartial(c *gc.C) {
+	uniterState := "kind: continue\nstep: pending\n"
+	err := s.unit.SetState(state.UnitStateUpdate{
+		UniterState:   &uniterState,
+		RelationState: map[int]string{1: "members: {}\n"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newUniterState := "kind: run-hook\nstep: pending\n"
+	err = s.unit.SetState(state.UnitStateUpdate{UniterState: &newUniterState})
+	c.Assert(err, jc.ErrorIsNil)
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, &state.UnitState{
+		UniterState:   newUniterState,
+		RelationState: map[int]string{1: "members: {}\n"},
+	})
+
+	err = s.unit.SetState(state.UnitStateUpdate{RelationState: map[int]string{}})
+	c.Assert(err, jc.ErrorIsNil)
+	unitState, err = s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, &state.UnitState{
+		UniterState: newUniterState,
+	})
+}
+
+func (s *UnitStateSuite) TestSkippedHooks(c *gc.C) {
+	skipped, err := s.unit.SkippedHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(skipped, gc.HasLen, 0)
+
+	uniterState := "kind: continue\nstep: pending\n"
+	err = s.unit.SetState(state.UnitStateUpdate{
+		UniterState:  &uniterState,
+		SkippedHooks: []string{"config-changed", "relation-changed (1; mysql/0)"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	skipped, err = s.unit.SkippedHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(skipped, jc.DeepEquals, []string{"config-changed", "relation-changed (1; mysql/0)"})
+
+	err = s.unit.SetState(state.UnitStateUpdate{UniterState: &uniterState})
+	c.Assert(err, jc.ErrorIsNil)
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState.SkippedHooks, jc.DeepEquals, skipped)
+
+	err = s.unit.SetState(state.UnitStateUpdate{SkippedHooks: []string{}})
+	c.Assert(err, jc.ErrorIsNil)
+	skipped, err = s.unit.SkippedHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(skipped, gc.IsNil)
+}
+
+func (s *UnitStateSuite) TestRequestReplayHooks(c *gc.C) {
+	err := s.unit.RequestReplayHooks()
+	c.Assert(err, gc.ErrorMatches, `cannot replay hooks for unit ".*": no skipped hooks`)
+	c.Assert(s.unit.ReplayHooksRequested(), jc.IsFalse)
+
+	err = s.unit.SetState(state.UnitStateUpdate{SkippedHooks: []string{"config-changed"}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.RequestReplayHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.ReplayHooksRequested(), jc.IsTrue)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.ReplayHooksRequested(), jc.IsTrue)
+
+	err = s.unit.ClearReplayHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.ReplayHooksRequested(), jc.IsFalse)
+}
+
+func (s *UnitStateSuite) TestRequestReplayHooksDead(c *gc.C) {
+	err := s.unit.SetState(state.UnitStateUpdate{SkippedHooks: []string{"config-changed"}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.RequestReplayHooks()
+	c.Assert(err, gc.ErrorMatches, `cannot replay hooks for unit ".*": not found or dead`)
+}
+
+func (s *UnitStateSuite) TestSetStateDead(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	uniterState := "kind: continue\nstep: pending\n"
+	err = s.unit.SetState(state.UnitStateUpdate{UniterState: &uniterState})
+	c.Assert(err, gc.ErrorMatches, `cannot set state for unit ".*": not found or dead`)
+}
+
+func (s *UnitStateSuite) TestStateRemovedWithUnit(c *gc.C) {
+	uniterState := "kind: continue\nstep: pending\n"
+	err := s.unit.SetState(state.UnitStateUpdate{UniterState: &uniterState})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	coll := s.MgoSuite.Session.DB("juju").C("unitstates")
+	count, err := coll.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
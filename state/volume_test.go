@@ -425,6 +425,49 @@ func (s *VolumeStateSuite) TestWatchMachineVolumeAttachments(c *gc.C) {
 	wc.AssertChangeInSingleEvent("0:0/7", "0:0/8") // added
 }
 
+func (s *VolumeStateSuite) TestHostScopedVolumeForContainer(c *gc.C) {
+	_, unit, storageTag := s.setupSingleStorage(c, "block", "hostscoped")
+	host, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, host.Id(), instance.KVM)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchMachineVolumeAttachments(host.MachineTag())
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	err = unit.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The volume is scoped to the container's host, which manages
+	// it, but is attached to (and bound to) the container.
+	volume := s.storageInstanceVolume(c, storageTag)
+	c.Assert(volume.VolumeTag(), gc.Equals, names.NewVolumeTag("0/0"))
+	c.Assert(volume.LifeBinding(), gc.Equals, container.MachineTag())
+	s.volumeAttachment(c, container.MachineTag(), volume.VolumeTag())
+	wc.AssertChangeInSingleEvent("0/kvm/0:0/0")
+	wc.AssertNoChange()
+
+	w2 := s.State.WatchMachineVolumeAttachments(container.MachineTag())
+	defer testing.AssertStop(c, w2)
+	wc2 := testing.NewStringsWatcherC(c, s.State, w2)
+	wc2.AssertChangeInSingleEvent() // initial
+	wc2.AssertNoChange()
+}
+
+func (s *VolumeStateSuite) TestHostScopedVolumeRequiresContainer(c *gc.C) {
+	_, unit, _ := s.setupSingleStorage(c, "block", "hostscoped")
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "storage-block/0" to machine 0: .*"hostscoped" provider only supports storage for containers`)
+}
+
 func (s *VolumeStateSuite) TestParseVolumeAttachmentId(c *gc.C) {
 	assertValid := func(id string, m names.MachineTag, v names.VolumeTag) {
 		machineTag, volumeTag, err := state.ParseVolumeAttachmentId(id)
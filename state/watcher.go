@@ -13,6 +13,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -286,28 +287,40 @@ func (st *State) watchModelMachinestorageAttachments(collection string) StringsW
 
 // WatchMachineVolumeAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all volume attachments related to the specified
-// machine, for volumes scoped to the machine.
+// machine, for volumes scoped to the machine. This includes attachments
+// of those volumes to containers hosted by the machine.
 func (st *State) WatchMachineVolumeAttachments(m names.MachineTag) StringsWatcher {
 	return st.watchMachineStorageAttachments(m, volumeAttachmentsC)
 }
 
 // WatchMachineFilesystemAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all filesystem attachments related to the specified
-// machine, for filesystems scoped to the machine.
+// machine, for filesystems scoped to the machine. This includes attachments
+// of those filesystems to containers hosted by the machine.
 func (st *State) WatchMachineFilesystemAttachments(m names.MachineTag) StringsWatcher {
 	return st.watchMachineStorageAttachments(m, filesystemAttachmentsC)
 }
 
 func (st *State) watchMachineStorageAttachments(m names.MachineTag, collection string) StringsWatcher {
-	pattern := fmt.Sprintf("^%s:%s/.*", st.docID(m.Id()), m.Id())
+	// Storage scoped to the machine may be attached to the machine
+	// itself, or (for host-scoped storage) to one of its containers.
+	pattern := fmt.Sprintf("^%s(/[a-z]+/[0-9]+)?:%s/[0-9]+$", st.docID(m.Id()), m.Id())
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
-	prefix := m.Id() + fmt.Sprintf(":%s/", m.Id())
+	prefix := m.Id() + "/"
 	filter := func(id interface{}) bool {
 		k, err := st.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
-		return strings.HasPrefix(k, prefix)
+		parts := strings.SplitN(k, ":", 2)
+		if len(parts) != 2 {
+			return false
+		}
+		machineId, storageId := parts[0], parts[1]
+		if machineId != m.Id() && ParentId(machineId) != m.Id() {
+			return false
+		}
+		return strings.HasPrefix(storageId, prefix) && !strings.Contains(storageId[len(prefix):], "/")
 	}
 	return newLifecycleWatcher(st, collection, members, filter, nil)
 }
@@ -1279,6 +1292,12 @@ func (s *Application) WatchLeaderSettings() NotifyWatcher {
 	return newEntityWatcher(s.st, settingsC, docId)
 }
 
+// WatchEndpointBindings returns a watcher for observing changes to a
+// service's endpoint bindings.
+func (s *Application) WatchEndpointBindings() NotifyWatcher {
+	return newEntityWatcher(s.st, endpointBindingsC, s.st.docID(s.globalKey()))
+}
+
 // Watch returns a watcher for observing changes to a unit.
 func (u *Unit) Watch() NotifyWatcher {
 	return newEntityWatcher(u.st, unitsC, u.doc.DocID)
@@ -1685,6 +1704,96 @@ func (w *machineAddressesWatcher) loop() error {
 	}
 }
 
+// applicationCharmsWatcher notifies when the charm URL of any
+// application in the model changes, or when applications are added
+// or removed.
+type applicationCharmsWatcher struct {
+	commonWatcher
+	out chan struct{}
+}
+
+var _ NotifyWatcher = (*applicationCharmsWatcher)(nil)
+
+// WatchApplicationCharms returns a NotifyWatcher that notifies when
+// the charm of any application in the model changes, as it does when
+// the application is deployed or its charm is upgraded. Other changes
+// to applications are not reported.
+func (st *State) WatchApplicationCharms() NotifyWatcher {
+	w := &applicationCharmsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		out:           make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *applicationCharmsWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *applicationCharmsWatcher) loop() error {
+	in := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(applicationsC, in, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(applicationsC, in)
+
+	charmURLs, err := w.charmURLs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case change := <-in:
+			if _, ok := collect(change, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			newCharmURLs, err := w.charmURLs()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !reflect.DeepEqual(newCharmURLs, charmURLs) {
+				charmURLs = newCharmURLs
+				out = w.out
+			}
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
+// charmURLs returns the charm URLs of all applications in the model,
+// keyed on application document id.
+func (w *applicationCharmsWatcher) charmURLs() (map[string]string, error) {
+	coll, closer := w.st.getCollection(applicationsC)
+	defer closer()
+
+	var doc struct {
+		DocID    string     `bson:"_id"`
+		CharmURL *charm.URL `bson:"charmurl"`
+	}
+	result := make(map[string]string)
+	iter := coll.Find(nil).Select(bson.D{{"_id", 1}, {"charmurl", 1}}).Iter()
+	for iter.Next(&doc) {
+		result[doc.DocID] = ""
+		if doc.CharmURL != nil {
+			result[doc.DocID] = doc.CharmURL.String()
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
 // WatchCleanups starts and returns a CleanupWatcher.
 func (st *State) WatchCleanups() NotifyWatcher {
 	return newNotifyCollWatcher(st, cleanupsC, isLocalID(st))
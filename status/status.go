@@ -176,6 +176,19 @@ const (
 	StatusAvailable Status = "available"
 )
 
+const (
+	// Status values specific to unit health.
+
+	// StatusHealthy is set when all of a unit's charm-defined health
+	// checks are passing.
+	StatusHealthy Status = "healthy"
+
+	// StatusUnhealthy is set when at least one of a unit's charm-defined
+	// health checks has failed more times in a row than its threshold
+	// allows.
+	StatusUnhealthy Status = "unhealthy"
+)
+
 const (
 	// Status values that are common to several entities.
 
@@ -191,6 +204,11 @@ const (
 	StatusProvisioning      Status = "allocating"
 	StatusRunning           Status = "running"
 	StatusProvisioningError Status = "provisioning error"
+
+	// StatusVanished indicates that the machine's instance can no
+	// longer be found in the cloud, most likely because it was
+	// terminated outside of Juju.
+	StatusVanished Status = "vanished"
 )
 
 const (
@@ -212,6 +230,7 @@ func (status Status) KnownInstanceStatus() bool {
 		StatusProvisioningError,
 		StatusAllocating,
 		StatusRunning,
+		StatusVanished,
 		StatusUnknown:
 		return true
 	}
@@ -267,6 +286,20 @@ func ValidWorkloadStatus(status Status) bool {
 	}
 }
 
+// ValidHealthStatus returns true if status has a valid value (that is to
+// say, a value that it's OK to set) for the health of units.
+func ValidHealthStatus(status Status) bool {
+	switch status {
+	case
+		StatusHealthy,
+		StatusUnhealthy,
+		StatusUnknown:
+		return true
+	default:
+		return false
+	}
+}
+
 // WorkloadMatches returns true if the candidate matches status,
 // taking into account that the candidate may be a legacy
 // status value which has been deprecated.
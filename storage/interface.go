@@ -16,12 +16,14 @@ type ProviderType string
 // Scope defines the scope of the storage that a provider manages.
 // Machine-scoped storage must be managed from within the machine,
 // whereas environment-level storage must be managed by an environment
-// storage provisioner.
+// storage provisioner. Host-scoped storage is attached to containers,
+// but must be managed from within the machine hosting them.
 type Scope int
 
 const (
 	ScopeEnviron Scope = iota
 	ScopeMachine
+	ScopeHost
 )
 
 // Provider is an interface for obtaining storage sources.
@@ -14,7 +14,9 @@ var errNoMountPoint = errors.New("filesystem mount point not specified")
 // CommonProviders returns the storage providers used by all environments.
 func CommonProviders() map[storage.ProviderType]storage.Provider {
 	return map[storage.ProviderType]storage.Provider{
+		KVMProviderType:    &kvmProvider{logAndExec},
 		LoopProviderType:   &loopProvider{logAndExec},
+		LXDProviderType:    &lxdProvider{connectLocalLXD},
 		RootfsProviderType: &rootfsProvider{logAndExec},
 		TmpfsProviderType:  &tmpfsProvider{logAndExec},
 	}
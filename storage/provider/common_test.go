@@ -26,7 +26,9 @@ func (s *providerCommonSuite) TestCommonProvidersExported(c *gc.C) {
 		c.Check(ok, jc.IsTrue)
 	}
 	c.Assert(common, jc.SameContents, []storage.ProviderType{
+		provider.KVMProviderType,
 		provider.LoopProviderType,
+		provider.LXDProviderType,
 		provider.RootfsProviderType,
 		provider.TmpfsProviderType,
 	})
@@ -140,3 +140,30 @@ func MountedDirs(fsSource storage.FilesystemSource) set.Strings {
 	}
 	panic("unexpectd type")
 }
+
+type LXDStorageClient lxdStorageClient
+
+func LXDProvider(newClient func() (LXDStorageClient, error)) storage.Provider {
+	return &lxdProvider{func() (lxdStorageClient, error) {
+		return newClient()
+	}}
+}
+
+func LXDFilesystemSource(pool, prefix string, client LXDStorageClient) storage.FilesystemSource {
+	return &lxdFilesystemSource{client, pool, prefix}
+}
+
+func KVMProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &kvmProvider{run}
+}
+
+func KVMVolumeSource(storageDir string, run func(string, ...string) (string, error)) storage.VolumeSource {
+	return &kvmVolumeSource{
+		&MockDirFuncs{
+			osDirFuncs{run},
+			set.NewStrings(),
+		},
+		run,
+		storageDir,
+	}
+}
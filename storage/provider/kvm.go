@@ -0,0 +1,288 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+)
+
+// KVMProviderType is the type of the storage provider that creates
+// disk images on a container's host, and attaches them to KVM
+// containers as block devices.
+const KVMProviderType = storage.ProviderType("kvm")
+
+// kvmProvider creates volume sources which create qcow2 disk images
+// on the host machine, and attach them to its KVM containers.
+type kvmProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var _ storage.Provider = (*kvmProvider)(nil)
+
+// ValidateConfig is defined on the Provider interface.
+func (*kvmProvider) ValidateConfig(*storage.Config) error {
+	// KVM provider has no configuration.
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *kvmProvider) VolumeSource(
+	environConfig *config.Config,
+	sourceConfig *storage.Config,
+) (storage.VolumeSource, error) {
+	storageDir, ok := sourceConfig.ValueString(storage.ConfigStorageDir)
+	if !ok || storageDir == "" {
+		return nil, errors.New("storage directory not specified")
+	}
+	return &kvmVolumeSource{
+		&osDirFuncs{p.run},
+		p.run,
+		storageDir,
+	}, nil
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (*kvmProvider) FilesystemSource(
+	environConfig *config.Config,
+	sourceConfig *storage.Config,
+) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is defined on the Provider interface.
+func (*kvmProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is defined on the Provider interface.
+func (*kvmProvider) Scope() storage.Scope {
+	return storage.ScopeHost
+}
+
+// Dynamic is defined on the Provider interface.
+func (*kvmProvider) Dynamic() bool {
+	return true
+}
+
+// kvmVolumeSource manages volumes backed by qcow2 disk images,
+// attaching them to KVM containers with virsh.
+type kvmVolumeSource struct {
+	dirFuncs   dirFuncs
+	run        runCommandFunc
+	storageDir string
+}
+
+var _ storage.VolumeSource = (*kvmVolumeSource)(nil)
+
+// CreateVolumes is defined on the VolumeSource interface.
+func (s *kvmVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := s.createVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume")
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (s *kvmVolumeSource) createVolume(params storage.VolumeParams) (*storage.Volume, error) {
+	imagePath := s.volumeImagePath(params.Tag)
+	if err := ensureDir(s.dirFuncs, filepath.Dir(imagePath)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := s.run(
+		"qemu-img", "create", "-f", "qcow2", imagePath,
+		fmt.Sprintf("%dM", params.Size),
+	); err != nil {
+		return nil, errors.Annotatef(err, "creating disk image %q", imagePath)
+	}
+	return &storage.Volume{
+		params.Tag,
+		storage.VolumeInfo{
+			VolumeId: params.Tag.String(),
+			Size:     params.Size,
+			// The image outlives any one attachment, and may be
+			// detached from its container and attached to another.
+			Persistent: true,
+		},
+	}, nil
+}
+
+func (s *kvmVolumeSource) volumeImagePath(tag names.VolumeTag) string {
+	return filepath.Join(s.storageDir, tag.String()+".qcow2")
+}
+
+// ListVolumes is defined on the VolumeSource interface.
+func (s *kvmVolumeSource) ListVolumes() ([]string, error) {
+	return nil, errors.NotImplementedf("ListVolumes")
+}
+
+// DescribeVolumes is defined on the VolumeSource interface.
+func (s *kvmVolumeSource) DescribeVolumes(volumeIds []string) ([]storage.DescribeVolumesResult, error) {
+	return nil, errors.NotImplementedf("DescribeVolumes")
+}
+
+// DestroyVolumes is defined on the VolumeSource interface.
+func (s *kvmVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
+	results := make([]error, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		if err := s.destroyVolume(volumeId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", volumeId)
+		}
+	}
+	return results, nil
+}
+
+func (s *kvmVolumeSource) destroyVolume(volumeId string) error {
+	tag, err := names.ParseVolumeTag(volumeId)
+	if err != nil {
+		return errors.Errorf("invalid KVM volume ID %q", volumeId)
+	}
+	err = os.Remove(s.volumeImagePath(tag))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "removing disk image")
+	}
+	return nil
+}
+
+// ValidateVolumeParams is defined on the VolumeSource interface.
+func (s *kvmVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	return nil
+}
+
+// AttachVolumes is defined on the VolumeSource interface.
+func (s *kvmVolumeSource) AttachVolumes(args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *kvmVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	if arg.InstanceId == "" {
+		return nil, errors.Errorf("container %v not provisioned", arg.Machine.Id())
+	}
+	imagePath := s.volumeImagePath(arg.Volume)
+	disks, err := s.domainDisks(arg.InstanceId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	target, ok := disks[imagePath]
+	if !ok {
+		target, err = freeDiskTarget(disks)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		args := []string{
+			"attach-disk", string(arg.InstanceId), imagePath, target,
+			"--driver", "qemu", "--subdriver", "qcow2", "--persistent",
+		}
+		if arg.ReadOnly {
+			args = append(args, "--mode", "readonly")
+		}
+		if _, err := s.run("virsh", args...); err != nil {
+			return nil, errors.Annotatef(err, "attaching disk to container %q", arg.InstanceId)
+		}
+	}
+	return &storage.VolumeAttachment{
+		arg.Volume,
+		arg.Machine,
+		storage.VolumeAttachmentInfo{
+			DeviceName: target,
+			ReadOnly:   arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachVolumes is defined on the VolumeSource interface.
+func (s *kvmVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := s.detachVolume(arg); err != nil {
+			results[i] = errors.Annotatef(err, "detaching volume %s", arg.Volume.Id())
+		}
+	}
+	return results, nil
+}
+
+func (s *kvmVolumeSource) detachVolume(arg storage.VolumeAttachmentParams) error {
+	if arg.InstanceId == "" {
+		// The container was never provisioned,
+		// so there is nothing to detach from.
+		return nil
+	}
+	disks, err := s.domainDisks(arg.InstanceId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	target, ok := disks[s.volumeImagePath(arg.Volume)]
+	if !ok {
+		return nil
+	}
+	if _, err := s.run("virsh", "detach-disk", string(arg.InstanceId), target, "--persistent"); err != nil {
+		return errors.Annotatef(err, "detaching disk from container %q", arg.InstanceId)
+	}
+	return nil
+}
+
+// domainDisks returns the disks attached to the libvirt domain with
+// the given instance ID, as a map of source path to target device name.
+func (s *kvmVolumeSource) domainDisks(instanceId instance.Id) (map[string]string, error) {
+	stdout, err := s.run("virsh", "domblklist", string(instanceId))
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing disks of container %q", instanceId)
+	}
+	// The output is a header line and a separator line,
+	// followed by a "<target> <source>" line for each disk.
+	disks := make(map[string]string)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	for i, line := range lines {
+		if i < 2 {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		disks[fields[1]] = fields[0]
+	}
+	return disks, nil
+}
+
+// freeDiskTarget returns the first virtio disk target name
+// not used by any of the given disks.
+func freeDiskTarget(disks map[string]string) (string, error) {
+	used := make(map[string]bool)
+	for _, target := range disks {
+		used[target] = true
+	}
+	// vda is the container's root disk.
+	for c := 'b'; c <= 'z'; c++ {
+		target := "vd" + string(c)
+		if !used[target] {
+			return target, nil
+		}
+	}
+	return "", errors.New("no free disk targets")
+}
@@ -0,0 +1,188 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&kvmSuite{})
+
+type kvmSuite struct {
+	testing.BaseSuite
+	storageDir string
+	commands   *mockRunCommand
+}
+
+func (s *kvmSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.storageDir = c.MkDir()
+	s.commands = &mockRunCommand{c: c}
+}
+
+func (s *kvmSuite) TearDownTest(c *gc.C) {
+	s.commands.assertDrained()
+	s.BaseSuite.TearDownTest(c)
+}
+
+const domblklist = `Target     Source
+------------------------------------------------
+vda        /var/lib/uvtool/libvirt/images/juju-abcdef-1-kvm-0.qcow
+`
+
+func (s *kvmSuite) attachmentParams() storage.VolumeAttachmentParams {
+	return storage.VolumeAttachmentParams{
+		Volume:   names.NewVolumeTag("1/0"),
+		VolumeId: "volume-1-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("1/kvm/0"),
+			InstanceId: "juju-abcdef-1-kvm-0",
+		},
+	}
+}
+
+func (s *kvmSuite) TestVolumeSource(c *gc.C) {
+	p := provider.KVMProvider(s.commands.run)
+	cfg, err := storage.NewConfig("name", provider.KVMProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(nil, cfg)
+	c.Assert(err, gc.ErrorMatches, "storage directory not specified")
+	cfg, err = storage.NewConfig("name", provider.KVMProviderType, map[string]interface{}{
+		"storage-dir": s.storageDir,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(nil, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *kvmSuite) TestSupports(c *gc.C) {
+	p := provider.KVMProvider(s.commands.run)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+}
+
+func (s *kvmSuite) TestScope(c *gc.C) {
+	p := provider.KVMProvider(s.commands.run)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeHost)
+}
+
+func (s *kvmSuite) TestCreateVolumes(c *gc.C) {
+	source := provider.KVMVolumeSource(s.storageDir, s.commands.run)
+	imagePath := filepath.Join(s.storageDir, "volume-1-0.qcow2")
+	s.commands.expect("qemu-img", "create", "-f", "qcow2", imagePath, "2048M")
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("1/0"),
+		Size: 2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("1/0"),
+		storage.VolumeInfo{
+			VolumeId:   "volume-1-0",
+			Size:       2048,
+			Persistent: true,
+		},
+	})
+}
+
+func (s *kvmSuite) TestDestroyVolumes(c *gc.C) {
+	source := provider.KVMVolumeSource(s.storageDir, s.commands.run)
+	imagePath := filepath.Join(s.storageDir, "volume-1-0.qcow2")
+	err := ioutil.WriteFile(imagePath, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	errs, err := source.DestroyVolumes([]string{"volume-1-0", "volume-1-1", "invalid"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `destroying "invalid": invalid KVM volume ID "invalid"`)
+
+	_, err = os.Stat(imagePath)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *kvmSuite) TestAttachVolumes(c *gc.C) {
+	source := provider.KVMVolumeSource(s.storageDir, s.commands.run)
+	imagePath := filepath.Join(s.storageDir, "volume-1-0.qcow2")
+	cmd := s.commands.expect("virsh", "domblklist", "juju-abcdef-1-kvm-0")
+	cmd.respond(domblklist, nil)
+	s.commands.expect(
+		"virsh", "attach-disk", "juju-abcdef-1-kvm-0", imagePath, "vdb",
+		"--driver", "qemu", "--subdriver", "qcow2", "--persistent",
+	)
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{s.attachmentParams()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment, jc.DeepEquals, &storage.VolumeAttachment{
+		names.NewVolumeTag("1/0"),
+		names.NewMachineTag("1/kvm/0"),
+		storage.VolumeAttachmentInfo{
+			DeviceName: "vdb",
+		},
+	})
+}
+
+func (s *kvmSuite) TestAttachVolumesAlreadyAttached(c *gc.C) {
+	source := provider.KVMVolumeSource(s.storageDir, s.commands.run)
+	imagePath := filepath.Join(s.storageDir, "volume-1-0.qcow2")
+	cmd := s.commands.expect("virsh", "domblklist", "juju-abcdef-1-kvm-0")
+	cmd.respond(domblklist+"vdc        "+imagePath+"\n", nil)
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{s.attachmentParams()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment.DeviceName, gc.Equals, "vdc")
+}
+
+func (s *kvmSuite) TestAttachVolumesNotProvisioned(c *gc.C) {
+	source := provider.KVMVolumeSource(s.storageDir, s.commands.run)
+	params := s.attachmentParams()
+	params.InstanceId = ""
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{params})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "attaching volume 1/0: container 1/kvm/0 not provisioned")
+}
+
+func (s *kvmSuite) TestDetachVolumes(c *gc.C) {
+	source := provider.KVMVolumeSource(s.storageDir, s.commands.run)
+	imagePath := filepath.Join(s.storageDir, "volume-1-0.qcow2")
+	cmd := s.commands.expect("virsh", "domblklist", "juju-abcdef-1-kvm-0")
+	cmd.respond(domblklist+"vdb        "+imagePath+"\n", nil)
+	s.commands.expect("virsh", "detach-disk", "juju-abcdef-1-kvm-0", "vdb", "--persistent")
+
+	errs, err := source.DetachVolumes([]storage.VolumeAttachmentParams{s.attachmentParams()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], jc.ErrorIsNil)
+}
+
+func (s *kvmSuite) TestDetachVolumesNotAttached(c *gc.C) {
+	source := provider.KVMVolumeSource(s.storageDir, s.commands.run)
+	cmd := s.commands.expect("virsh", "domblklist", "juju-abcdef-1-kvm-0")
+	cmd.respond(domblklist, nil)
+
+	errs, err := source.DetachVolumes([]storage.VolumeAttachmentParams{s.attachmentParams()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], jc.ErrorIsNil)
+}
@@ -0,0 +1,290 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+const (
+	// LXDProviderType is the type of the storage provider that creates
+	// volumes in an LXD storage pool on a container's host, and attaches
+	// them to LXD containers as filesystems.
+	LXDProviderType = storage.ProviderType("lxd")
+
+	// LXDPool is the name of the storage pool configuration attribute
+	// that names the LXD storage pool in which volumes are created.
+	LXDPool = "lxd-pool"
+
+	defaultLXDPool = "default"
+)
+
+var lxdConfigChecker = schema.FieldMap(
+	schema.Fields{
+		LXDPool: schema.String(),
+	},
+	schema.Defaults{
+		LXDPool: defaultLXDPool,
+	},
+)
+
+// lxdStorageClient is the part of the LXD client used to manage
+// storage volumes and the containers' disk devices.
+type lxdStorageClient interface {
+	StorageSupported() (bool, error)
+	CreateStoragePoolVolume(pool, name string, config map[string]string) error
+	DeleteStoragePoolVolume(pool, name string) error
+	HasContainerDevice(container, name string) (bool, error)
+	AddContainerDisk(container, name string, disk lxdclient.Device) error
+	RemoveContainerDevice(container, name string) error
+}
+
+// connectLocalLXD connects to the LXD server on the local machine.
+func connectLocalLXD() (lxdStorageClient, error) {
+	cfg, err := lxdclient.Config{Remote: lxdclient.Local}.WithDefaults()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client, err := lxdclient.Connect(cfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "connecting to local LXD")
+	}
+	return client, nil
+}
+
+// lxdProvider creates filesystem sources which create LXD storage
+// volumes on the host machine, and attach them to its containers.
+type lxdProvider struct {
+	// newClient connects to the LXD server on the local machine.
+	newClient func() (lxdStorageClient, error)
+}
+
+var _ storage.Provider = (*lxdProvider)(nil)
+
+// ValidateConfig is defined on the Provider interface.
+func (*lxdProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := lxdPoolName(cfg)
+	return errors.Trace(err)
+}
+
+func lxdPoolName(cfg *storage.Config) (string, error) {
+	out, err := lxdConfigChecker.Coerce(cfg.Attrs(), nil)
+	if err != nil {
+		return "", errors.Annotate(err, "validating LXD storage config")
+	}
+	pool := out.(map[string]interface{})[LXDPool].(string)
+	if pool == "" {
+		return "", errors.Errorf("%s must not be empty", LXDPool)
+	}
+	return pool, nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (*lxdProvider) VolumeSource(
+	environConfig *config.Config,
+	sourceConfig *storage.Config,
+) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface. LXD has only
+// supported storage volumes since version 2.9; with older versions,
+// an error satisfying errors.IsNotSupported is returned.
+func (p *lxdProvider) FilesystemSource(
+	environConfig *config.Config,
+	sourceConfig *storage.Config,
+) (storage.FilesystemSource, error) {
+	pool, err := lxdPoolName(sourceConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	namespace, err := instance.NewNamespace(environConfig.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client, err := p.newClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	supported, err := client.StorageSupported()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !supported {
+		return nil, errors.NotSupportedf("LXD storage volumes before LXD 2.9")
+	}
+	return &lxdFilesystemSource{
+		client: client,
+		pool:   pool,
+		prefix: namespace.Prefix(),
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*lxdProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*lxdProvider) Scope() storage.Scope {
+	return storage.ScopeHost
+}
+
+// Dynamic is defined on the Provider interface.
+func (*lxdProvider) Dynamic() bool {
+	return true
+}
+
+// lxdFilesystemSource manages filesystems backed by LXD storage
+// volumes, using the LXD API.
+type lxdFilesystemSource struct {
+	client lxdStorageClient
+	pool   string
+	prefix string
+}
+
+var _ storage.FilesystemSource = (*lxdFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *lxdFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	return nil
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *lxdFilesystemSource) CreateFilesystems(args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.createFilesystem(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating filesystem")
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *lxdFilesystemSource) createFilesystem(params storage.FilesystemParams) (*storage.Filesystem, error) {
+	volumeName := s.prefix + params.Tag.String()
+	config := map[string]string{
+		"size": fmt.Sprintf("%dMiB", params.Size),
+	}
+	if err := s.client.CreateStoragePoolVolume(s.pool, volumeName, config); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.Filesystem{
+		params.Tag,
+		params.Volume,
+		storage.FilesystemInfo{
+			FilesystemId: volumeName,
+			Size:         params.Size,
+		},
+	}, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *lxdFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
+	results := make([]error, len(filesystemIds))
+	for i, filesystemId := range filesystemIds {
+		if !strings.HasPrefix(filesystemId, s.prefix) {
+			results[i] = errors.Errorf("invalid LXD filesystem ID %q", filesystemId)
+			continue
+		}
+		if err := s.client.DeleteStoragePoolVolume(s.pool, filesystemId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", filesystemId)
+		}
+	}
+	return results, nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *lxdFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching filesystem %v", arg.Filesystem.Id())
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *lxdFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	if arg.Path == "" {
+		return nil, errNoMountPoint
+	}
+	if arg.InstanceId == "" {
+		return nil, errors.Errorf("container %v not provisioned", arg.Machine.Id())
+	}
+	container := string(arg.InstanceId)
+	deviceName := arg.Filesystem.String()
+	attached, err := s.client.HasContainerDevice(container, deviceName)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing devices of container %q", container)
+	}
+	if !attached {
+		disk := lxdclient.Device{
+			"pool":   s.pool,
+			"source": arg.FilesystemId,
+			"path":   arg.Path,
+		}
+		if arg.ReadOnly {
+			disk["readonly"] = "true"
+		}
+		if err := s.client.AddContainerDisk(container, deviceName, disk); err != nil {
+			return nil, errors.Annotatef(err, "adding disk device to container %q", container)
+		}
+	}
+	return &storage.FilesystemAttachment{
+		arg.Filesystem,
+		arg.Machine,
+		storage.FilesystemAttachmentInfo{
+			Path:     arg.Path,
+			ReadOnly: arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *lxdFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := s.detachFilesystem(arg); err != nil {
+			results[i] = errors.Annotatef(err, "detaching filesystem %v", arg.Filesystem.Id())
+		}
+	}
+	return results, nil
+}
+
+func (s *lxdFilesystemSource) detachFilesystem(arg storage.FilesystemAttachmentParams) error {
+	if arg.InstanceId == "" {
+		// The container was never provisioned,
+		// so there is nothing to detach from.
+		return nil
+	}
+	container := string(arg.InstanceId)
+	deviceName := arg.Filesystem.String()
+	attached, err := s.client.HasContainerDevice(container, deviceName)
+	if err != nil {
+		return errors.Annotatef(err, "listing devices of container %q", container)
+	}
+	if !attached {
+		return nil
+	}
+	if err := s.client.RemoveContainerDevice(container, deviceName); err != nil {
+		return errors.Annotatef(err, "removing disk device from container %q", container)
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+var _ = gc.Suite(&lxdSuite{})
+
+type lxdSuite struct {
+	testing.BaseSuite
+	client *mockLXDStorageClient
+}
+
+func (s *lxdSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.client = &mockLXDStorageClient{supported: true}
+}
+
+func (s *lxdSuite) newClient() (provider.LXDStorageClient, error) {
+	return s.client, nil
+}
+
+func (s *lxdSuite) lxdFilesystemSource() storage.FilesystemSource {
+	return provider.LXDFilesystemSource("pool0", "juju-abcdef-", s.client)
+}
+
+func (s *lxdSuite) TestFilesystemSource(c *gc.C) {
+	p := provider.LXDProvider(s.newClient)
+	cfg, err := storage.NewConfig("name", provider.LXDProviderType, map[string]interface{}{
+		"lxd-pool": "fast",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(testing.ModelConfig(c), cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "StorageSupported")
+}
+
+func (s *lxdSuite) TestFilesystemSourceOldLXD(c *gc.C) {
+	s.client.supported = false
+	p := provider.LXDProvider(s.newClient)
+	cfg, err := storage.NewConfig("name", provider.LXDProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(testing.ModelConfig(c), cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "LXD storage volumes before LXD 2.9 not supported")
+}
+
+func (s *lxdSuite) TestValidateConfig(c *gc.C) {
+	p := provider.LXDProvider(s.newClient)
+	cfg, err := storage.NewConfig("name", provider.LXDProviderType, map[string]interface{}{
+		"lxd-pool": "fast",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.ValidateConfig(cfg), jc.ErrorIsNil)
+
+	cfg, err = storage.NewConfig("name", provider.LXDProviderType, map[string]interface{}{
+		"lxd-pool": "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.ValidateConfig(cfg), gc.ErrorMatches, "lxd-pool must not be empty")
+
+	cfg, err = storage.NewConfig("name", provider.LXDProviderType, map[string]interface{}{
+		"lxd-pool": 123,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.ValidateConfig(cfg), gc.ErrorMatches, `validating LXD storage config: lxd-pool: expected string, got int\(123\)`)
+}
+
+func (s *lxdSuite) TestSupports(c *gc.C) {
+	p := provider.LXDProvider(s.newClient)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+}
+
+func (s *lxdSuite) TestScope(c *gc.C) {
+	p := provider.LXDProvider(s.newClient)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeHost)
+}
+
+func (s *lxdSuite) TestCreateFilesystems(c *gc.C) {
+	source := s.lxdFilesystemSource()
+
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("1/0"),
+		Size: 2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Filesystem, jc.DeepEquals, &storage.Filesystem{
+		names.NewFilesystemTag("1/0"),
+		names.VolumeTag{},
+		storage.FilesystemInfo{
+			FilesystemId: "juju-abcdef-filesystem-1-0",
+			Size:         2048,
+		},
+	})
+	s.client.CheckCalls(c, []gitjujutesting.StubCall{{
+		"CreateStoragePoolVolume", []interface{}{
+			"pool0", "juju-abcdef-filesystem-1-0", map[string]string{"size": "2048MiB"},
+		},
+	}})
+}
+
+func (s *lxdSuite) TestDestroyFilesystems(c *gc.C) {
+	source := s.lxdFilesystemSource()
+
+	results, err := source.DestroyFilesystems([]string{"juju-abcdef-filesystem-1-0", "something-else"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], gc.ErrorMatches, `invalid LXD filesystem ID "something-else"`)
+	s.client.CheckCalls(c, []gitjujutesting.StubCall{{
+		"DeleteStoragePoolVolume", []interface{}{"pool0", "juju-abcdef-filesystem-1-0"},
+	}})
+}
+
+func (s *lxdSuite) TestAttachFilesystems(c *gc.C) {
+	source := s.lxdFilesystemSource()
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("1/0"),
+		FilesystemId: "juju-abcdef-filesystem-1-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("1/lxd/0"),
+			InstanceId: "juju-abcdef-1-lxd-0",
+			ReadOnly:   true,
+		},
+		Path: "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].FilesystemAttachment, jc.DeepEquals, &storage.FilesystemAttachment{
+		names.NewFilesystemTag("1/0"),
+		names.NewMachineTag("1/lxd/0"),
+		storage.FilesystemAttachmentInfo{
+			Path:     "/srv",
+			ReadOnly: true,
+		},
+	})
+	s.client.CheckCalls(c, []gitjujutesting.StubCall{{
+		"HasContainerDevice", []interface{}{"juju-abcdef-1-lxd-0", "filesystem-1-0"},
+	}, {
+		"AddContainerDisk", []interface{}{"juju-abcdef-1-lxd-0", "filesystem-1-0", lxdclient.Device{
+			"pool":     "pool0",
+			"source":   "juju-abcdef-filesystem-1-0",
+			"path":     "/srv",
+			"readonly": "true",
+		}},
+	}})
+}
+
+func (s *lxdSuite) TestAttachFilesystemsAlreadyAttached(c *gc.C) {
+	source := s.lxdFilesystemSource()
+	s.client.devices = []string{"filesystem-1-0"}
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("1/0"),
+		FilesystemId: "juju-abcdef-filesystem-1-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("1/lxd/0"),
+			InstanceId: "juju-abcdef-1-lxd-0",
+		},
+		Path: "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "HasContainerDevice")
+}
+
+func (s *lxdSuite) TestDetachFilesystems(c *gc.C) {
+	source := s.lxdFilesystemSource()
+	s.client.devices = []string{"filesystem-1-0"}
+
+	results, err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("1/0"),
+		FilesystemId: "juju-abcdef-filesystem-1-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("1/lxd/0"),
+			InstanceId: "juju-abcdef-1-lxd-0",
+		},
+		Path: "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0], jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "HasContainerDevice", "RemoveContainerDevice")
+	s.client.CheckCall(c, 1, "RemoveContainerDevice", "juju-abcdef-1-lxd-0", "filesystem-1-0")
+}
+
+type mockLXDStorageClient struct {
+	gitjujutesting.Stub
+	supported bool
+	devices   []string
+}
+
+func (m *mockLXDStorageClient) StorageSupported() (bool, error) {
+	m.AddCall("StorageSupported")
+	return m.supported, m.NextErr()
+}
+
+func (m *mockLXDStorageClient) CreateStoragePoolVolume(pool, name string, config map[string]string) error {
+	m.AddCall("CreateStoragePoolVolume", pool, name, config)
+	return m.NextErr()
+}
+
+func (m *mockLXDStorageClient) DeleteStoragePoolVolume(pool, name string) error {
+	m.AddCall("DeleteStoragePoolVolume", pool, name)
+	return m.NextErr()
+}
+
+func (m *mockLXDStorageClient) HasContainerDevice(container, name string) (bool, error) {
+	m.AddCall("HasContainerDevice", container, name)
+	for _, device := range m.devices {
+		if device == name {
+			return true, m.NextErr()
+		}
+	}
+	return false, m.NextErr()
+}
+
+func (m *mockLXDStorageClient) AddContainerDisk(container, name string, disk lxdclient.Device) error {
+	m.AddCall("AddContainerDisk", container, name, disk)
+	return m.NextErr()
+}
+
+func (m *mockLXDStorageClient) RemoveContainerDevice(container, name string) error {
+	m.AddCall("RemoveContainerDevice", container, name)
+	return m.NextErr()
+}
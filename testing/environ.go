@@ -98,6 +98,10 @@ const (
 
 const DefaultMongoPassword = "conn-from-name-secret"
 
+// SecretsKey is the key from which test controllers derive the keys
+// encrypting their models' secrets.
+var SecretsKey = []byte("0123456789abcdef0123456789abcdef")
+
 // FakeJujuXDGDataHomeSuite isolates the user's home directory and
 // sets up a Juju home with a sample environment and certificate.
 type FakeJujuXDGDataHomeSuite struct {
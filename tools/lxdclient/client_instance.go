@@ -33,6 +33,7 @@ type rawInstanceClient interface {
 	WaitForSuccess(waitURL string) error
 	ContainerState(name string) (*shared.ContainerState, error)
 	ContainerDeviceAdd(container, devname, devtype string, props []string) (*lxd.Response, error)
+	ApplyProfile(container, profile string) (*lxd.Response, error)
 }
 
 type instanceClient struct {
@@ -153,6 +154,29 @@ func (client *instanceClient) Instance(name string) (*Instance, error) {
 	return inst, nil
 }
 
+// InstanceProfiles returns the names of the profiles applied to the
+// given instance, in order.
+func (client *instanceClient) InstanceProfiles(name string) ([]string, error) {
+	info, err := client.raw.ContainerInfo(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return info.Profiles, nil
+}
+
+// SetInstanceProfiles replaces the profiles applied to the given
+// instance with those given, in order.
+func (client *instanceClient) SetInstanceProfiles(name string, profiles []string) error {
+	resp, err := client.raw.ApplyProfile(name, strings.Join(profiles, ","))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := client.raw.WaitForSuccess(resp.Operation); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 func (client *instanceClient) Status(name string) (string, error) {
 	info, err := client.raw.ContainerInfo(name)
 	if err != nil {
@@ -7,6 +7,7 @@ package lxdclient_test
 
 import (
 	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd"
 	lxdshared "github.com/lxc/lxd/shared"
 	gc "gopkg.in/check.v1"
 
@@ -177,3 +178,49 @@ func (s *addressesSuite) TestAddresses(c *gc.C) {
 		},
 	})
 }
+
+type profilesSuite struct {
+	jujutesting.BaseSuite
+}
+
+var _ = gc.Suite(&profilesSuite{})
+
+type profilesTester struct {
+	lxdclient.RawInstanceClient
+
+	applied map[string]string
+	waited  []string
+}
+
+func (p *profilesTester) ContainerInfo(name string) (*lxdshared.ContainerInfo, error) {
+	return &lxdshared.ContainerInfo{
+		Name:     name,
+		Profiles: []string{"default", "juju-foo-bar-1"},
+	}, nil
+}
+
+func (p *profilesTester) ApplyProfile(container, profile string) (*lxd.Response, error) {
+	p.applied[container] = profile
+	return &lxd.Response{Operation: "/1.0/operations/1"}, nil
+}
+
+func (p *profilesTester) WaitForSuccess(waitURL string) error {
+	p.waited = append(p.waited, waitURL)
+	return nil
+}
+
+func (s *profilesSuite) TestInstanceProfiles(c *gc.C) {
+	client := lxdclient.NewInstanceClient(&profilesTester{})
+	profiles, err := client.InstanceProfiles("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(profiles, jc.DeepEquals, []string{"default", "juju-foo-bar-1"})
+}
+
+func (s *profilesSuite) TestSetInstanceProfiles(c *gc.C) {
+	raw := &profilesTester{applied: make(map[string]string)}
+	client := lxdclient.NewInstanceClient(raw)
+	err := client.SetInstanceProfiles("test", []string{"default", "juju-foo-bar-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(raw.applied, jc.DeepEquals, map[string]string{"test": "default,juju-foo-bar-2"})
+	c.Check(raw.waited, jc.DeepEquals, []string{"/1.0/operations/1"})
+}
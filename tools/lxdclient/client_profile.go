@@ -6,6 +6,9 @@
 package lxdclient
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/lxc/lxd"
 )
@@ -57,6 +60,34 @@ func (p profileClient) CreateProfile(name string, config map[string]string) erro
 	return nil
 }
 
+// CreateProfileWithDevices attempts to create a new lxc profile, set
+// the given config, and add the given devices to it. Each device must
+// specify its type with the "type" key.
+func (p profileClient) CreateProfileWithDevices(name string, config map[string]string, devices Devices) error {
+	if err := p.CreateProfile(name, config); err != nil {
+		return errors.Trace(err)
+	}
+	deviceNames := make([]string, 0, len(devices))
+	for deviceName := range devices {
+		deviceNames = append(deviceNames, deviceName)
+	}
+	sort.Strings(deviceNames)
+	for _, deviceName := range deviceNames {
+		device := devices[deviceName]
+		var props []string
+		for k, v := range device {
+			if k != "type" {
+				props = append(props, fmt.Sprintf("%s=%s", k, v))
+			}
+		}
+		sort.Strings(props)
+		if _, err := p.raw.ProfileDeviceAdd(name, deviceName, device["type"], props); err != nil {
+			return errors.Annotatef(err, "cannot add device %q", deviceName)
+		}
+	}
+	return nil
+}
+
 // HasProfile returns true/false if the profile exists.
 func (p profileClient) HasProfile(name string) (bool, error) {
 	profiles, err := p.raw.ListProfiles()
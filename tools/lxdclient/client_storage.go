@@ -0,0 +1,167 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/lxc/lxd"
+	"github.com/lxc/lxd/shared"
+)
+
+// minStorageMajor and minStorageMinor hold the earliest LXD version
+// with the storage API, which was added in LXD 2.9.
+const (
+	minStorageMajor = 2
+	minStorageMinor = 9
+)
+
+type rawStorageClient interface {
+	ServerStatus() (*shared.ServerState, error)
+	ContainerInfo(name string) (*shared.ContainerInfo, error)
+	ContainerDeviceAdd(container, devname, devtype string, props []string) (*lxd.Response, error)
+	ContainerDeviceDelete(container, devname string) (*lxd.Response, error)
+	WaitForSuccess(waitURL string) error
+}
+
+// storageRequestFunc sends a request with the given method and JSON
+// body to the given path of the LXD API, and returns an error if the
+// request fails. It is used for the parts of the storage API that the
+// LXD client does not wrap.
+type storageRequestFunc func(method, path string, body interface{}) error
+
+type storageClient struct {
+	raw     rawStorageClient
+	request storageRequestFunc
+}
+
+// newStorageRequestFunc returns a storageRequestFunc that sends
+// requests using the given raw client's connection.
+func newStorageRequestFunc(raw *lxd.Client) storageRequestFunc {
+	return func(method, path string, body interface{}) error {
+		var data bytes.Buffer
+		if body != nil {
+			if err := json.NewEncoder(&data).Encode(body); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		req, err := http.NewRequest(method, raw.BaseURL+"/1.0"+path, &data)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := raw.Http.Do(req)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer resp.Body.Close()
+		if _, err := lxd.HoistResponse(resp, lxd.Sync); err != nil {
+			return errors.Trace(err)
+		}
+		return nil
+	}
+}
+
+// StorageSupported returns true if the LXD server is recent enough
+// to support storage pools and volumes.
+func (c *storageClient) StorageSupported() (bool, error) {
+	status, err := c.raw.ServerStatus()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return serverVersionAtLeast(status.Environment.ServerVersion, minStorageMajor, minStorageMinor), nil
+}
+
+// CreateStoragePoolVolume creates a custom storage volume with the
+// given name and configuration in the named storage pool.
+func (c *storageClient) CreateStoragePoolVolume(pool, name string, config map[string]string) error {
+	body := map[string]interface{}{
+		"name":   name,
+		"type":   "custom",
+		"config": config,
+	}
+	path := fmt.Sprintf("/storage-pools/%s/volumes", url.QueryEscape(pool))
+	if err := c.request("POST", path, body); err != nil {
+		return errors.Annotatef(err, "creating storage volume %q in pool %q", name, pool)
+	}
+	return nil
+}
+
+// DeleteStoragePoolVolume deletes the named custom storage volume
+// from the named storage pool.
+func (c *storageClient) DeleteStoragePoolVolume(pool, name string) error {
+	path := fmt.Sprintf("/storage-pools/%s/volumes/custom/%s", url.QueryEscape(pool), url.QueryEscape(name))
+	if err := c.request("DELETE", path, nil); err != nil {
+		return errors.Annotatef(err, "deleting storage volume %q from pool %q", name, pool)
+	}
+	return nil
+}
+
+// HasContainerDevice returns true if the named container has a device
+// with the given name.
+func (c *storageClient) HasContainerDevice(container, name string) (bool, error) {
+	info, err := c.raw.ContainerInfo(container)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	_, ok := info.Devices[name]
+	return ok, nil
+}
+
+// AddContainerDisk adds a disk device with the given name and
+// properties to the named container.
+func (c *storageClient) AddContainerDisk(container, name string, disk Device) error {
+	props := deviceProperties(disk)
+	sort.Strings(props)
+	resp, err := c.raw.ContainerDeviceAdd(container, name, "disk", props)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.waitForOperation(resp))
+}
+
+// RemoveContainerDevice removes the named device from the named
+// container.
+func (c *storageClient) RemoveContainerDevice(container, name string) error {
+	resp, err := c.raw.ContainerDeviceDelete(container, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.waitForOperation(resp))
+}
+
+func (c *storageClient) waitForOperation(resp *lxd.Response) error {
+	if resp == nil || resp.Operation == "" {
+		return nil
+	}
+	return errors.Trace(c.raw.WaitForSuccess(resp.Operation))
+}
+
+// serverVersionAtLeast returns true if the given LXD server version
+// is at least major.minor.
+func serverVersionAtLeast(version string, major, minor int) bool {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return false
+	}
+	gotMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	gotMinor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return gotMajor > major || (gotMajor == major && gotMinor >= minor)
+}
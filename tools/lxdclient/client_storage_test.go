@@ -0,0 +1,137 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd"
+	lxdshared "github.com/lxc/lxd/shared"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+type storageSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+type storageTester struct {
+	jujutesting.Stub
+	version string
+}
+
+func (s *storageTester) ServerStatus() (*lxdshared.ServerState, error) {
+	s.AddCall("ServerStatus")
+	var status lxdshared.ServerState
+	status.Environment.ServerVersion = s.version
+	return &status, s.NextErr()
+}
+
+func (s *storageTester) ContainerInfo(name string) (*lxdshared.ContainerInfo, error) {
+	s.AddCall("ContainerInfo", name)
+	return &lxdshared.ContainerInfo{
+		Name:    name,
+		Devices: lxdshared.Devices{"filesystem-0": {"type": "disk"}},
+	}, s.NextErr()
+}
+
+func (s *storageTester) ContainerDeviceAdd(container, devname, devtype string, props []string) (*lxd.Response, error) {
+	s.AddCall("ContainerDeviceAdd", container, devname, devtype, props)
+	return &lxd.Response{Operation: "/1.0/operations/1"}, s.NextErr()
+}
+
+func (s *storageTester) ContainerDeviceDelete(container, devname string) (*lxd.Response, error) {
+	s.AddCall("ContainerDeviceDelete", container, devname)
+	return &lxd.Response{Operation: "/1.0/operations/2"}, s.NextErr()
+}
+
+func (s *storageTester) WaitForSuccess(waitURL string) error {
+	s.AddCall("WaitForSuccess", waitURL)
+	return s.NextErr()
+}
+
+func (s *storageTester) request(method, path string, body interface{}) error {
+	s.AddCall("request", method, path, body)
+	return s.NextErr()
+}
+
+func (s *storageSuite) TestStorageSupported(c *gc.C) {
+	for _, test := range []struct {
+		version   string
+		supported bool
+	}{
+		{"2.0.5", false},
+		{"2.8", false},
+		{"2.9", true},
+		{"2.12", true},
+		{"3.0.0", true},
+		{"", false},
+	} {
+		c.Logf("LXD %q", test.version)
+		raw := &storageTester{version: test.version}
+		supported, err := lxdclient.NewStorageClient(raw, raw.request).StorageSupported()
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(supported, gc.Equals, test.supported)
+	}
+}
+
+func (s *storageSuite) TestCreateStoragePoolVolume(c *gc.C) {
+	raw := &storageTester{}
+	client := lxdclient.NewStorageClient(raw, raw.request)
+	err := client.CreateStoragePoolVolume("default", "juju-abcdef-filesystem-0", map[string]string{"size": "1024MiB"})
+	c.Assert(err, jc.ErrorIsNil)
+	raw.CheckCalls(c, []jujutesting.StubCall{{
+		"request", []interface{}{"POST", "/storage-pools/default/volumes", map[string]interface{}{
+			"name":   "juju-abcdef-filesystem-0",
+			"type":   "custom",
+			"config": map[string]string{"size": "1024MiB"},
+		}},
+	}})
+}
+
+func (s *storageSuite) TestDeleteStoragePoolVolumeError(c *gc.C) {
+	raw := &storageTester{}
+	raw.SetErrors(errors.New("not found"))
+	client := lxdclient.NewStorageClient(raw, raw.request)
+	err := client.DeleteStoragePoolVolume("default", "juju-abcdef-filesystem-0")
+	c.Assert(err, gc.ErrorMatches, `deleting storage volume "juju-abcdef-filesystem-0" from pool "default": not found`)
+	raw.CheckCall(c, 0, "request", "DELETE", "/storage-pools/default/volumes/custom/juju-abcdef-filesystem-0", nil)
+}
+
+func (s *storageSuite) TestContainerDisks(c *gc.C) {
+	raw := &storageTester{}
+	client := lxdclient.NewStorageClient(raw, raw.request)
+
+	has, err := client.HasContainerDevice("juju-abcdef-1", "filesystem-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(has, jc.IsTrue)
+	has, err = client.HasContainerDevice("juju-abcdef-1", "filesystem-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(has, jc.IsFalse)
+
+	err = client.AddContainerDisk("juju-abcdef-1", "filesystem-1", lxdclient.Device{
+		"pool":   "default",
+		"source": "juju-abcdef-filesystem-1",
+		"path":   "/srv",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.RemoveContainerDevice("juju-abcdef-1", "filesystem-0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	raw.CheckCallNames(c,
+		"ContainerInfo", "ContainerInfo",
+		"ContainerDeviceAdd", "WaitForSuccess",
+		"ContainerDeviceDelete", "WaitForSuccess",
+	)
+	raw.CheckCall(c, 2, "ContainerDeviceAdd", "juju-abcdef-1", "filesystem-1", "disk", []string{
+		"path=/srv", "pool=default", "source=juju-abcdef-filesystem-1",
+	})
+	raw.CheckCall(c, 5, "WaitForSuccess", "/1.0/operations/2")
+}
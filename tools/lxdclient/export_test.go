@@ -20,6 +20,15 @@ func NewInstanceClient(raw RawInstanceClient) *instanceClient {
 	}
 }
 
+type RawStorageClient rawStorageClient
+
+func NewStorageClient(raw RawStorageClient, request func(method, path string, body interface{}) error) *storageClient {
+	return &storageClient{
+		raw:     rawStorageClient(raw),
+		request: request,
+	}
+}
+
 func PatchGenerateCertificate(s *testing.CleanupSuite, cert, key string) {
 	s.PatchValue(&generateCertificate, func() ([]byte, []byte, error) {
 		return []byte(cert), []byte(key), nil
@@ -27,20 +27,22 @@ type machineRanges map[network.PortRange]bool
 // machines and reflects those changes onto the backing environment.
 // Uses Firewaller API V1.
 type Firewaller struct {
-	catacomb        catacomb.Catacomb
-	st              *firewaller.State
-	environ         environs.Environ
-	modelWatcher    watcher.NotifyWatcher
-	machinesWatcher watcher.StringsWatcher
-	portsWatcher    watcher.StringsWatcher
-	machineds       map[names.MachineTag]*machineData
-	unitsChange     chan *unitsChange
-	unitds          map[names.UnitTag]*unitData
-	applicationids  map[names.ApplicationTag]*serviceData
-	exposedChange   chan *exposedChange
-	globalMode      bool
-	globalPortRef   map[network.PortRange]int
-	machinePorts    map[names.MachineTag]machineRanges
+	catacomb          catacomb.Catacomb
+	st                *firewaller.State
+	environ           environs.Environ
+	modelWatcher      watcher.NotifyWatcher
+	controllerWatcher watcher.NotifyWatcher
+	machinesWatcher   watcher.StringsWatcher
+	portsWatcher      watcher.StringsWatcher
+	machineds         map[names.MachineTag]*machineData
+	unitsChange       chan *unitsChange
+	unitds            map[names.UnitTag]*unitData
+	applicationids    map[names.ApplicationTag]*serviceData
+	exposedChange     chan *exposedChange
+	globalMode        bool
+	globalRuleRef     map[string]int
+	machinePorts      map[names.MachineTag]machineRanges
+	accessRules       []network.IngressRule
 }
 
 // NewFirewaller returns a new Firewaller or a new FirewallerV0,
@@ -89,7 +91,7 @@ func (fw *Firewaller) setUp() error {
 	case config.FwInstance:
 	case config.FwGlobal:
 		fw.globalMode = true
-		fw.globalPortRef = make(map[network.PortRange]int)
+		fw.globalRuleRef = make(map[string]int)
 	case config.FwNone:
 		logger.Infof("stopping firewaller (not required)")
 		fw.Kill()
@@ -98,6 +100,20 @@ func (fw *Firewaller) setUp() error {
 		return errors.Errorf("unknown firewall-mode %q", config.FwNone)
 	}
 
+	if _, ok := fw.environ.(environs.AccessFirewaller); ok {
+		fw.controllerWatcher, err = fw.st.WatchForControllerConfigChanges()
+		if errors.IsNotImplemented(err) {
+			// The controller is too old to report api-allow, so
+			// access rules are not managed until it is upgraded.
+			logger.Debugf("not restricting SSH and API access: %v", err)
+			fw.controllerWatcher = nil
+		} else if err != nil {
+			return errors.Trace(err)
+		} else if err := fw.catacomb.Add(fw.controllerWatcher); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	fw.machinesWatcher, err = fw.st.WatchModelMachines()
 	if err != nil {
 		return errors.Trace(err)
@@ -124,6 +140,10 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var controllerChange watcher.NotifyChannel
+	if fw.controllerWatcher != nil {
+		controllerChange = fw.controllerWatcher.Changes()
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
@@ -142,6 +162,17 @@ func (fw *Firewaller) loop() error {
 				// hopefully be replaced with EnvironObserver.
 				logger.Errorf("loaded invalid environment configuration: %v", err)
 			}
+			if err := fw.updateAccessRules(); err != nil {
+				return errors.Trace(err)
+			}
+		case _, ok := <-controllerChange:
+			logger.Debugf("got controller config changes")
+			if !ok {
+				return errors.New("controller configuration watcher closed")
+			}
+			if err := fw.updateAccessRules(); err != nil {
+				return errors.Trace(err)
+			}
 		case change, ok := <-fw.machinesWatcher.Changes():
 			if !ok {
 				return errors.New("machines watcher closed")
@@ -182,6 +213,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.serviced.exposed = change.exposed
+			change.serviced.exposedCIDRs = change.cidrs
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -193,6 +225,44 @@ func (fw *Firewaller) loop() error {
 	}
 }
 
+// updateAccessRules restricts the SSH and API ports opened on every
+// machine to the sources allowed by the model's ssh-allow and the
+// controller's api-allow configuration, if the environ supports it.
+func (fw *Firewaller) updateAccessRules() error {
+	accessFirewaller, ok := fw.environ.(environs.AccessFirewaller)
+	if !ok {
+		return nil
+	}
+	controllerCfg, err := fw.st.ControllerConfig()
+	if errors.IsNotImplemented(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	rules, err := network.AccessRules(
+		fw.environ.Config().SSHAllow(),
+		controllerCfg.APIAllow(),
+		controllerCfg.APIPort(),
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if fw.accessRules != nil &&
+		len(diffRules(rules, fw.accessRules)) == 0 &&
+		len(diffRules(fw.accessRules, rules)) == 0 {
+		return nil
+	}
+	logger.Infof("setting access rules %v", rules)
+	err = accessFirewaller.SetAccessRules(rules)
+	if errors.IsNotSupported(err) {
+		logger.Warningf("cannot restrict SSH and API access: %v", err)
+	} else if err != nil {
+		return errors.Annotate(err, "cannot set access rules")
+	}
+	fw.accessRules = rules
+	return nil
+}
+
 // startMachine creates a new data value for tracking details of the
 // machine and starts watching the machine for units added or removed.
 func (fw *Firewaller) startMachine(tag names.MachineTag) error {
@@ -200,7 +270,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		fw:           fw,
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
-		openedPorts:  make([]network.PortRange, 0),
+		openedRules:  make([]network.IngressRule, 0),
 		definedPorts: make(map[network.PortRange]names.UnitTag),
 	}
 	m, err := machined.machine()
@@ -313,16 +383,21 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 	if err != nil {
 		return err
 	}
+	cidrs, err := exposedCIDRs(service)
+	if err != nil {
+		return err
+	}
 	serviced := &serviceData{
-		fw:          fw,
-		application: service,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:           fw,
+		application:  service,
+		exposed:      exposed,
+		exposedCIDRs: cidrs,
+		unitds:       make(map[names.UnitTag]*unitData),
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
 		Work: func() error {
-			return serviced.watchLoop(exposed)
+			return serviced.watchLoop(exposed, cidrs)
 		},
 	})
 	if err != nil {
@@ -336,14 +411,15 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 }
 
 // reconcileGlobal compares the initially started watcher for machines,
-// units and services with the opened and closed ports globally and
-// opens and closes the appropriate ports for the whole environment.
+// units and services with the opened and closed ingress rules globally and
+// opens and closes the appropriate rules for the whole environment.
 func (fw *Firewaller) reconcileGlobal() error {
-	initialPortRanges, err := fw.environ.Ports()
+	initialRules, err := fw.environIngressRules()
 	if err != nil {
 		return err
 	}
-	collector := make(map[network.PortRange]bool)
+	initialRules = network.SplitIngressRules(initialRules)
+	collector := make(map[string]network.IngressRule)
 	for _, machined := range fw.machineds {
 		for portRange, unitTag := range machined.definedPorts {
 			unitd, known := machined.unitds[unitTag]
@@ -352,30 +428,35 @@ func (fw *Firewaller) reconcileGlobal() error {
 				continue
 			}
 			if unitd.serviced.exposed {
-				collector[portRange] = true
+				rule, err := unitd.serviced.ingressRule(portRange)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				collector[rule.String()] = rule
 			}
 		}
 	}
-	wantedPorts := []network.PortRange{}
-	for port := range collector {
-		wantedPorts = append(wantedPorts, port)
+	wantedRules := []network.IngressRule{}
+	for _, rule := range collector {
+		wantedRules = append(wantedRules, rule)
 	}
-	// Check which ports to open or to close.
-	toOpen := diffRanges(wantedPorts, initialPortRanges)
-	toClose := diffRanges(initialPortRanges, wantedPorts)
-	if len(toOpen) > 0 {
-		logger.Infof("opening global ports %v", toOpen)
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+	wantedRules = network.SplitIngressRules(wantedRules)
+	// Check which rules to open or to close.
+	toOpen := diffRules(wantedRules, initialRules)
+	toClose := diffRules(initialRules, wantedRules)
+	if len(toClose) > 0 {
+		network.SortIngressRules(toClose)
+		logger.Infof("closing global ingress rules %v", toClose)
+		if err := fw.closeEnvironIngressRules(toClose); err != nil {
 			return err
 		}
-		network.SortPortRanges(toOpen)
 	}
-	if len(toClose) > 0 {
-		logger.Infof("closing global ports %v", toClose)
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+	if len(toOpen) > 0 {
+		network.SortIngressRules(toOpen)
+		logger.Infof("opening global ingress rules %v", toOpen)
+		if err := fw.openEnvironIngressRules(toOpen); err != nil {
 			return err
 		}
-		network.SortPortRanges(toClose)
 	}
 	return nil
 }
@@ -411,31 +492,32 @@ func (fw *Firewaller) reconcileInstances() error {
 			return err
 		}
 		machineId := machined.tag.Id()
-		initialPortRanges, err := instances[0].Ports(machineId)
+		initialRules, err := instanceIngressRules(instances[0], machineId)
 		if err != nil {
 			return err
 		}
+		initialRules = network.SplitIngressRules(initialRules)
 
-		// Check which ports to open or to close.
-		toOpen := diffRanges(machined.openedPorts, initialPortRanges)
-		toClose := diffRanges(initialPortRanges, machined.openedPorts)
-		if len(toOpen) > 0 {
-			logger.Infof("opening instance port ranges %v for %q",
-				toOpen, machined.tag)
-			if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+		// Check which rules to close or to open.
+		toOpen := diffRules(machined.openedRules, initialRules)
+		toClose := diffRules(initialRules, machined.openedRules)
+		if len(toClose) > 0 {
+			network.SortIngressRules(toClose)
+			logger.Infof("closing instance ingress rules %v for %q",
+				toClose, machined.tag)
+			if err := closeInstanceIngressRules(instances[0], machineId, toClose); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toOpen)
 		}
-		if len(toClose) > 0 {
-			logger.Infof("closing instance port ranges %v for %q",
-				toClose, machined.tag)
-			if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+		if len(toOpen) > 0 {
+			network.SortIngressRules(toOpen)
+			logger.Infof("opening instance ingress rules %v for %q",
+				toOpen, machined.tag)
+			if err := openInstanceIngressRules(instances[0], machineId, toOpen); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toClose)
 		}
 	}
 	return nil
@@ -554,10 +636,10 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 	return nil
 }
 
-// flushMachine opens and closes ports for the passed machine.
+// flushMachine opens and closes ingress rules for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
-	// Gather ports to open and close.
-	want := []network.PortRange{}
+	// Gather rules to open and close.
+	want := []network.IngressRule{}
 	for portRange, unitTag := range machined.definedPorts {
 		unitd, known := machined.unitds[unitTag]
 		if !known {
@@ -565,59 +647,74 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 			continue
 		}
 		if unitd.serviced.exposed {
-			want = append(want, portRange)
+			rule, err := unitd.serviced.ingressRule(portRange)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			want = append(want, rule)
 		}
 	}
-	toOpen := diffRanges(want, machined.openedPorts)
-	toClose := diffRanges(machined.openedPorts, want)
-	machined.openedPorts = want
+	// Compare rules source by source: providers merge the sources of
+	// rules for the same port range, and in global mode several
+	// applications may open the same port range to different sources.
+	want = network.SplitIngressRules(want)
+	toOpen := diffRules(want, machined.openedRules)
+	toClose := diffRules(machined.openedRules, want)
+	machined.openedRules = want
 	if fw.globalMode {
-		return fw.flushGlobalPorts(toOpen, toClose)
+		return fw.flushGlobalRules(toOpen, toClose)
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	return fw.flushInstanceRules(machined, toOpen, toClose)
 }
 
-// flushGlobalPorts opens and closes global ports in the environment.
-// It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
-// modify the environment.
-func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) error {
-	// Filter which ports are really to open or close.
-	var toOpen, toClose []network.PortRange
-	for _, portRange := range rawOpen {
-		if fw.globalPortRef[portRange] == 0 {
-			toOpen = append(toOpen, portRange)
+// flushGlobalRules opens and closes global ingress rules in the
+// environment. It keeps a reference count for rules so that only 0-to-1
+// and 1-to-0 events modify the environment. The rules must each have a
+// single source, as returned by network.SplitIngressRules, so that the
+// count for a source is shared by every application opening the port
+// range to it.
+func (fw *Firewaller) flushGlobalRules(rawOpen, rawClose []network.IngressRule) error {
+	// Filter which rules are really to open or close.
+	var toOpen, toClose []network.IngressRule
+	for _, rule := range rawOpen {
+		key := rule.String()
+		if fw.globalRuleRef[key] == 0 {
+			toOpen = append(toOpen, rule)
 		}
-		fw.globalPortRef[portRange]++
-	}
-	for _, portRange := range rawClose {
-		fw.globalPortRef[portRange]--
-		if fw.globalPortRef[portRange] == 0 {
-			toClose = append(toClose, portRange)
-			delete(fw.globalPortRef, portRange)
+		fw.globalRuleRef[key]++
+	}
+	for _, rule := range rawClose {
+		key := rule.String()
+		fw.globalRuleRef[key]--
+		if fw.globalRuleRef[key] == 0 {
+			toClose = append(toClose, rule)
+			delete(fw.globalRuleRef, key)
 		}
 	}
-	// Open and close the ports.
-	if len(toOpen) > 0 {
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+	// Close rules before opening their replacements, so that rules
+	// sharing a port range with different source CIDRs do not clash.
+	if len(toClose) > 0 {
+		network.SortIngressRules(toClose)
+		if err := fw.closeEnvironIngressRules(toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v in environment", toOpen)
+		logger.Infof("closed ingress rules %v in environment", toClose)
 	}
-	if len(toClose) > 0 {
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+	if len(toOpen) > 0 {
+		network.SortIngressRules(toOpen)
+		if err := fw.openEnvironIngressRules(toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v in environment", toClose)
+		logger.Infof("opened ingress rules %v in environment", toOpen)
 	}
 	return nil
 }
 
-// flushInstancePorts opens and closes ports global on the machine.
-func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.PortRange) error {
+// flushInstanceRules opens and closes ingress rules on the machine's
+// instance.
+func (fw *Firewaller) flushInstanceRules(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
 	// This is important because when a machine is first created,
 	// it will have no instance id but also no open ports -
@@ -641,26 +738,119 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	if err != nil {
 		return err
 	}
-	// Open and close the ports.
-	if len(toOpen) > 0 {
-		if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+	// Close rules before opening their replacements, so that rules
+	// sharing a port range with different source CIDRs do not clash.
+	if len(toClose) > 0 {
+		network.SortIngressRules(toClose)
+		if err := closeInstanceIngressRules(instances[0], machineId, toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
+		logger.Infof("closed ingress rules %v on %q", toClose, machined.tag)
 	}
-	if len(toClose) > 0 {
-		if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+	if len(toOpen) > 0 {
+		network.SortIngressRules(toOpen)
+		if err := openInstanceIngressRules(instances[0], machineId, toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v on %q", toClose, machined.tag)
+		logger.Infof("opened ingress rules %v on %q", toOpen, machined.tag)
 	}
 	return nil
 }
 
+// environIngressRules returns the ingress rules opened for the whole
+// environment. Environs that cannot restrict rules to source CIDRs
+// report their open ports as rules open to any source.
+func (fw *Firewaller) environIngressRules() ([]network.IngressRule, error) {
+	if environ, ok := fw.environ.(environs.IngressFirewaller); ok {
+		return environ.IngressRules()
+	}
+	portRanges, err := fw.environ.Ports()
+	if err != nil {
+		return nil, err
+	}
+	return network.OpenIngressRules(portRanges), nil
+}
+
+// openEnvironIngressRules opens the given ingress rules for the whole
+// environment.
+func (fw *Firewaller) openEnvironIngressRules(rules []network.IngressRule) error {
+	if environ, ok := fw.environ.(environs.IngressFirewaller); ok {
+		return environ.OpenIngressRules(rules)
+	}
+	portRanges := supportedPortRanges(rules)
+	if len(portRanges) == 0 {
+		return nil
+	}
+	return fw.environ.OpenPorts(portRanges)
+}
+
+// closeEnvironIngressRules closes the given ingress rules for the whole
+// environment.
+func (fw *Firewaller) closeEnvironIngressRules(rules []network.IngressRule) error {
+	if environ, ok := fw.environ.(environs.IngressFirewaller); ok {
+		return environ.CloseIngressRules(rules)
+	}
+	portRanges := network.IngressRulePortRanges(rules)
+	if len(portRanges) == 0 {
+		return nil
+	}
+	return fw.environ.ClosePorts(portRanges)
+}
+
+// instanceIngressRules returns the ingress rules opened on the given
+// instance. Instances that cannot restrict rules to source CIDRs report
+// their open ports as rules open to any source.
+func instanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	if inst, ok := inst.(instance.IngressFirewaller); ok {
+		return inst.IngressRules(machineId)
+	}
+	portRanges, err := inst.Ports(machineId)
+	if err != nil {
+		return nil, err
+	}
+	return network.OpenIngressRules(portRanges), nil
+}
+
+// openInstanceIngressRules opens the given ingress rules on the instance.
+func openInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if inst, ok := inst.(instance.IngressFirewaller); ok {
+		return inst.OpenIngressRules(machineId, rules)
+	}
+	portRanges := supportedPortRanges(rules)
+	if len(portRanges) == 0 {
+		return nil
+	}
+	return inst.OpenPorts(machineId, portRanges)
+}
+
+// closeInstanceIngressRules closes the given ingress rules on the
+// instance.
+func closeInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if inst, ok := inst.(instance.IngressFirewaller); ok {
+		return inst.CloseIngressRules(machineId, rules)
+	}
+	portRanges := network.IngressRulePortRanges(rules)
+	if len(portRanges) == 0 {
+		return nil
+	}
+	return inst.ClosePorts(machineId, portRanges)
+}
+
+// supportedPortRanges returns the port ranges of the given rules that
+// can be opened by a provider which does not support restricting access
+// to source CIDRs. Restricted rules are never widened to allow access
+// from anywhere; they are logged and skipped instead.
+func supportedPortRanges(rules []network.IngressRule) []network.PortRange {
+	for _, rule := range rules {
+		if !rule.IsOpen() {
+			logger.Errorf("cannot open %v: provider does not support restricting source CIDRs", rule)
+		}
+	}
+	return network.IngressRulePortRanges(rules)
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -755,7 +945,7 @@ type machineData struct {
 	fw          *Firewaller
 	tag         names.MachineTag
 	unitds      map[names.UnitTag]*unitData
-	openedPorts []network.PortRange
+	openedRules []network.IngressRule
 	// ports defined by units on this machine
 	definedPorts map[network.PortRange]names.UnitTag
 }
@@ -805,23 +995,38 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposed flag and source CIDRs for
+// one specific service.
 type exposedChange struct {
 	serviced *serviceData
 	exposed  bool
+	cidrs    []string
 }
 
 // serviceData holds service details and watches exposure changes.
 type serviceData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb     catacomb.Catacomb
+	fw           *Firewaller
+	application  *firewaller.Application
+	exposed      bool
+	exposedCIDRs []string
+	unitds       map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// ingressRule returns the ingress rule allowing access to the given
+// port range of the exposed service. The rule is normalised as the
+// providers report it, so that it compares equal to the opened rule.
+func (sd *serviceData) ingressRule(portRange network.PortRange) (network.IngressRule, error) {
+	rule, err := network.NewIngressRule(portRange, sd.exposedCIDRs...)
+	if err != nil {
+		return network.IngressRule{}, errors.Annotatef(err, "application %q", sd.application.Name())
+	}
+	return rule, nil
+}
+
+// watchLoop watches the service's exposed flag and source CIDRs for
+// changes.
+func (sd *serviceData) watchLoop(exposed bool, cidrs []string) error {
 	serviceWatcher, err := sd.application.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -847,13 +1052,18 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			changeCIDRs, err := exposedCIDRs(sd.application)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if change == exposed && stringsEqual(changeCIDRs, cidrs) {
 				continue
 			}
 
 			exposed = change
+			cidrs = changeCIDRs
 			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
+			case sd.fw.exposedChange <- &exposedChange{sd, change, changeCIDRs}:
 			case <-sd.catacomb.Dying():
 				return sd.catacomb.ErrDying()
 			}
@@ -861,6 +1071,17 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 	}
 }
 
+// exposedCIDRs returns the source CIDRs the given application is exposed
+// to. Controllers that do not support exposing to CIDRs always expose
+// applications to all sources, which is reported as no CIDRs.
+func exposedCIDRs(application *firewaller.Application) ([]string, error) {
+	cidrs, err := application.ExposedCIDRs()
+	if errors.IsNotImplemented(err) {
+		return nil, nil
+	}
+	return cidrs, err
+}
+
 // Kill is part of the worker.Worker interface.
 func (sd *serviceData) Kill() {
 	sd.catacomb.Kill(nil)
@@ -871,12 +1092,12 @@ func (sd *serviceData) Wait() error {
 	return sd.catacomb.Wait()
 }
 
-// diffRanges returns all the port rangess that exist in A but not B.
-func diffRanges(A, B []network.PortRange) (missing []network.PortRange) {
+// diffRules returns all the ingress rules that exist in A but not B.
+func diffRules(A, B []network.IngressRule) (missing []network.IngressRule) {
 next:
 	for _, a := range A {
 		for _, b := range B {
-			if a == b {
+			if a.String() == b.String() {
 				continue next
 			}
 		}
@@ -885,6 +1106,20 @@ next:
 	return
 }
 
+// stringsEqual reports whether the two string slices hold the same
+// values in the same order.
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // parsePortsKey parses a ports document global key coming from the ports
 // watcher (e.g. "42:0.1.2.0/24") and returns the machine and subnet tags from
 // its components (in the last example "machine-42" and "subnet-0.1.2.0/24").
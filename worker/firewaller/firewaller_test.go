@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/api"
 	apifirewaller "github.com/juju/juju/api/firewaller"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju"
@@ -114,6 +115,25 @@ func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.Por
 	}
 }
 
+// assertAccessRules waits for the SSH and API access rules set on the
+// environment to match the expected rules.
+func (s *firewallerBaseSuite) assertAccessRules(c *gc.C, expected []network.IngressRule) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got := dummy.AccessRules(s.Environ)
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %q; got %q", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
 func (s *firewallerBaseSuite) addUnit(c *gc.C, svc *state.Application) (*state.Unit, *state.Machine) {
 	units, err := juju.AddUnits(s.State, svc, 1, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -153,6 +173,37 @@ func (s *InstanceModeSuite) TestStartStop(c *gc.C) {
 	statetesting.AssertKillAndWait(c, fw)
 }
 
+func (s *InstanceModeSuite) TestAccessRules(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	apiPort := network.PortRange{s.ControllerConfig.APIPort(), s.ControllerConfig.APIPort(), "tcp"}
+	sshPort := network.MustParsePortRange("22/tcp")
+	s.assertAccessRules(c, []network.IngressRule{
+		network.NewOpenIngressRule(sshPort),
+		network.NewOpenIngressRule(apiPort),
+	})
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		config.SSHAllowKey: "10.0.0.0/8",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAccessRules(c, []network.IngressRule{
+		network.MustNewIngressRule(sshPort, "10.0.0.0/8"),
+		network.NewOpenIngressRule(apiPort),
+	})
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.APIAllowKey: "192.168.0.0/16",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAccessRules(c, []network.IngressRule{
+		network.MustNewIngressRule(sshPort, "10.0.0.0/8"),
+		network.MustNewIngressRule(apiPort, "192.168.0.0/16"),
+	})
+}
+
 func (s *InstanceModeSuite) TestNotExposedService(c *gc.C) {
 	fw, err := firewaller.NewFirewaller(s.firewaller)
 	c.Assert(err, jc.ErrorIsNil)
@@ -200,6 +251,32 @@ func (s *InstanceModeSuite) TestExposedService(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), []network.PortRange{{8080, 8080, "tcp"}})
 }
 
+func (s *InstanceModeSuite) TestExposedServiceToCIDRsUnsupported(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+
+	// The dummy provider cannot restrict source CIDRs, so no ports
+	// are opened rather than opening them to everyone.
+	err = svc.SetExposedToCIDRs([]string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+
+	err = u.OpenPort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), nil)
+
+	// Exposing to all sources opens the ports.
+	err = svc.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.PortRange{{8080, 8080, "tcp"}})
+}
+
 func (s *InstanceModeSuite) TestMultipleExposedServices(c *gc.C) {
 	fw, err := firewaller.NewFirewaller(s.firewaller)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/exec"
+
+	"github.com/juju/juju/core/charmmeta"
+)
+
+// Check describes a health check declared in the "health-checks"
+// section of a charm's metadata. Exactly one of Exec, HTTP and TCP
+// is set.
+type Check charmmeta.HealthCheck
+
+// ReadChecks returns the health checks declared in the metadata of the
+// charm in the given directory, sorted by name. A charm may declare no
+// health checks, in which case no checks and no error are returned.
+func ReadChecks(charmDir string) ([]Check, error) {
+	meta, err := charmmeta.ReadDir(charmDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var checks []Check
+	for _, check := range meta.HealthChecks {
+		checks = append(checks, Check(check))
+	}
+	return checks, nil
+}
+
+// String returns a description of what the check does.
+func (check Check) String() string {
+	switch {
+	case check.Exec != "":
+		return fmt.Sprintf("exec %q", check.Exec)
+	case check.HTTP != "":
+		return fmt.Sprintf("GET %s", check.HTTP)
+	default:
+		return fmt.Sprintf("connect to port %d", check.TCP)
+	}
+}
+
+// RunCheck runs the given check once, returning an error describing
+// why it failed, if it did. Exec checks are run in the given charm
+// directory.
+func RunCheck(check Check, charmDir string) error {
+	switch {
+	case check.Exec != "":
+		return runExec(check.Exec, charmDir, check.Timeout)
+	case check.HTTP != "":
+		return getHTTP(check.HTTP, check.Timeout)
+	default:
+		return dialTCP(check.TCP, check.Timeout)
+	}
+}
+
+func runExec(command, charmDir string, timeout time.Duration) error {
+	cmd := exec.RunParams{
+		Commands:    command,
+		WorkingDir:  charmDir,
+		Environment: os.Environ(),
+		Clock:       clock.WallClock,
+	}
+	if err := cmd.Run(); err != nil {
+		return errors.Trace(err)
+	}
+	cancel := make(chan struct{})
+	timer := time.AfterFunc(timeout, func() { close(cancel) })
+	defer timer.Stop()
+
+	result, err := cmd.WaitWithCancel(cancel)
+	if errors.Cause(err) == exec.ErrCancelled {
+		return errors.Errorf("timed out after %v", timeout)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Code != 0 {
+		output := strings.TrimSpace(string(result.Stderr))
+		if output == "" {
+			output = strings.TrimSpace(string(result.Stdout))
+		}
+		if output == "" {
+			return errors.Errorf("exit status %d", result.Code)
+		}
+		return errors.Errorf("exit status %d: %s", result.Code, output)
+	}
+	return nil
+}
+
+func getHTTP(target string, timeout time.Duration) error {
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("%s returned %s", target, resp.Status)
+	}
+	return nil
+}
+
+func dialTCP(port int, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort("localhost", strconv.Itoa(port)), timeout)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return conn.Close()
+}
@@ -0,0 +1,92 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/healthcheck"
+)
+
+type ChecksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ChecksSuite{})
+
+func (s *ChecksSuite) TestReadChecks(c *gc.C) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "metadata.yaml"), []byte(`
+name: mysql
+summary: database
+health-checks:
+  db:
+    tcp: 3306
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	checks, err := healthcheck.ReadChecks(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 1)
+	c.Check(checks[0].Name, gc.Equals, "db")
+	c.Check(checks[0].TCP, gc.Equals, 3306)
+}
+
+func (s *ChecksSuite) TestReadChecksMissingMetadata(c *gc.C) {
+	_, err := healthcheck.ReadChecks(c.MkDir())
+	c.Assert(err, gc.ErrorMatches, "cannot read charm metadata: .*")
+}
+
+func (s *ChecksSuite) TestRunExecCheck(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("exec checks are tested with a POSIX shell")
+	}
+	check := healthcheck.Check{Name: "script", Exec: "exit 0", Timeout: time.Minute}
+	err := healthcheck.RunCheck(check, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+
+	check.Exec = "echo queue stalled >&2; exit 2"
+	err = healthcheck.RunCheck(check, c.MkDir())
+	c.Assert(err, gc.ErrorMatches, "exit status 2: queue stalled")
+}
+
+func (s *ChecksSuite) TestRunHTTPCheck(c *gc.C) {
+	code := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(code)
+	}))
+	defer server.Close()
+
+	check := healthcheck.Check{Name: "web", HTTP: server.URL, Timeout: time.Minute}
+	err := healthcheck.RunCheck(check, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	code = http.StatusServiceUnavailable
+	err = healthcheck.RunCheck(check, "")
+	c.Assert(err, gc.ErrorMatches, ".* returned 503 Service Unavailable")
+}
+
+func (s *ChecksSuite) TestRunTCPCheck(c *gc.C) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	c.Assert(err, jc.ErrorIsNil)
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	check := healthcheck.Check{Name: "db", TCP: port, Timeout: time.Minute}
+	err = healthcheck.RunCheck(check, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	listener.Close()
+	err = healthcheck.RunCheck(check, "")
+	c.Assert(err, gc.NotNil)
+}
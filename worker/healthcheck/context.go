@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// hookContext is the restricted context in which the
+// health-status-changed hook runs.
+type hookContext struct {
+	jujuc.RestrictedContext
+
+	unitName string
+	id       string
+	health   status.Status
+	info     string
+}
+
+func newHookContext(unitName string, health status.Status, info string) *hookContext {
+	// TODO(fwereade): 2016-03-17 lp:1558657
+	id := fmt.Sprintf("%s-%s-%d", unitName, "health-check", rand.New(rand.NewSource(time.Now().Unix())).Int63())
+	return &hookContext{
+		unitName: unitName,
+		id:       id,
+		health:   health,
+		info:     info,
+	}
+}
+
+// HookVars implements runner.Context.
+func (ctx *hookContext) HookVars(paths context.Paths) ([]string, error) {
+	vars := []string{
+		"JUJU_CHARM_DIR=" + paths.GetCharmDir(),
+		"JUJU_CONTEXT_ID=" + ctx.id,
+		"JUJU_AGENT_SOCKET=" + paths.GetJujucSocket(),
+		"JUJU_UNIT_NAME=" + ctx.unitName,
+		"JUJU_HEALTH_STATUS=" + ctx.health.String(),
+		"JUJU_HEALTH_INFO=" + ctx.info,
+	}
+	return append(vars, context.OSDependentEnvVars(paths)...), nil
+}
+
+// UnitName implements runner.Context.
+func (ctx *hookContext) UnitName() string {
+	return ctx.unitName
+}
+
+// Flush implements runner.Context.
+func (ctx *hookContext) Flush(_ string, err error) error {
+	return err
+}
+
+// SetProcess implements runner.Context.
+func (ctx *hookContext) SetProcess(process context.HookProcess) {}
+
+// ActionData implements runner.Context.
+func (ctx *hookContext) ActionData() (*context.ActionData, error) {
+	return nil, jujuc.ErrRestrictedContext
+}
+
+// HasExecutionSetUnitStatus implements runner.Context.
+func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
+
+// ResetExecutionSetUnitStatus implements runner.Context.
+func (ctx *hookContext) ResetExecutionSetUnitStatus() {}
+
+// HookTimeout implements runner.Context.
+func (ctx *hookContext) HookTimeout() time.Duration { return 0 }
+
+// Id implements runner.Context.
+func (ctx *hookContext) Id() string { return ctx.id }
+
+// Prepare implements runner.Context.
+func (ctx *hookContext) Prepare() error {
+	return jujuc.ErrRestrictedContext
+}
+
+// Component implements runner.Context.
+func (ctx *hookContext) Component(name string) (jujuc.ContextComponent, error) {
+	return nil, errors.NotFoundf("context component %q", name)
+}
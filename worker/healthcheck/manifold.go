@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthcheck provides a worker that runs the health checks
+// declared in a unit's charm metadata, reports the unit's health to
+// the controller, and runs the health-status-changed hook when it
+// changes.
+package healthcheck
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/fortress"
+	"github.com/juju/juju/worker/uniter"
+)
+
+var logger = loggo.GetLogger("juju.worker.healthcheck")
+
+// ManifoldConfig identifies the resource names upon which the health
+// check manifold depends.
+type ManifoldConfig struct {
+	AgentName       string
+	APICallerName   string
+	CharmDirName    string
+	MachineLockName string
+	Clock           clock.Clock
+
+	NewHookRunner func(names.UnitTag, string, agent.Config, clock.Clock) HookRunner
+	NewFacade     func(base.APICaller, names.UnitTag) Facade
+	NewWorker     func(Config) (worker.Worker, error)
+}
+
+// validate is called by start to check for bad configuration.
+func (config ManifoldConfig) validate() error {
+	if config.MachineLockName == "" {
+		return errors.NotValidf("missing MachineLockName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("missing Clock")
+	}
+	if config.NewHookRunner == nil {
+		return errors.NotValidf("missing NewHookRunner")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("missing NewFacade")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("missing NewWorker")
+	}
+	return nil
+}
+
+// start is a StartFunc for a health check manifold.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, err
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, err
+	}
+	var charmDir fortress.Guest
+	if err := context.Get(config.CharmDirName, &charmDir); err != nil {
+		return nil, err
+	}
+
+	agentConfig := agent.CurrentConfig()
+	tag := agentConfig.Tag()
+	unitTag, ok := tag.(names.UnitTag)
+	if !ok {
+		return nil, errors.Errorf("expected unit tag, got %v", tag)
+	}
+	paths := uniter.NewWorkerPaths(agentConfig.DataDir(), unitTag, "health-check")
+	return config.NewWorker(Config{
+		Facade:       config.NewFacade(apiCaller, unitTag),
+		Runner:       config.NewHookRunner(unitTag, config.MachineLockName, agentConfig, config.Clock),
+		CharmDir:     charmDir,
+		Clock:        config.Clock,
+		CharmDirPath: paths.GetCharmDir(),
+		ReadChecks:   ReadChecks,
+		RunCheck:     RunCheck,
+	})
+}
+
+// Manifold returns a health check manifold.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.APICallerName,
+			config.CharmDirName,
+		},
+		Start: config.start,
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/healthcheck"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func validManifoldConfig() healthcheck.ManifoldConfig {
+	return healthcheck.ManifoldConfig{
+		AgentName:       "agent",
+		APICallerName:   "api-caller",
+		CharmDirName:    "charm-dir",
+		MachineLockName: "machine-lock",
+		Clock:           coretesting.NewClock(time.Now()),
+		NewHookRunner:   healthcheck.NewHookRunner,
+		NewFacade:       healthcheck.NewFacade,
+		NewWorker:       healthcheck.NewWorker,
+	}
+}
+
+func (*ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := healthcheck.Manifold(validManifoldConfig())
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"agent", "api-caller", "charm-dir"})
+}
+
+func (*ManifoldSuite) TestStartMissingDependencies(c *gc.C) {
+	for _, name := range []string{"agent", "api-caller", "charm-dir"} {
+		c.Logf("missing %q", name)
+		resources := dt.StubResources{
+			"agent":      dt.StubResource{Output: struct{}{}},
+			"api-caller": dt.StubResource{Output: struct{}{}},
+			"charm-dir":  dt.StubResource{Output: struct{}{}},
+		}
+		resources[name] = dt.StubResource{Error: dependency.ErrMissing}
+		manifold := healthcheck.Manifold(validManifoldConfig())
+		worker, err := manifold.Start(resources.Context())
+		c.Check(worker, gc.IsNil)
+		c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	}
+}
+
+func (*ManifoldSuite) TestStartInvalidConfig(c *gc.C) {
+	for i, test := range []struct {
+		change func(*healthcheck.ManifoldConfig)
+		err    string
+	}{{
+		change: func(config *healthcheck.ManifoldConfig) { config.MachineLockName = "" },
+		err:    "missing MachineLockName not valid",
+	}, {
+		change: func(config *healthcheck.ManifoldConfig) { config.Clock = nil },
+		err:    "missing Clock not valid",
+	}, {
+		change: func(config *healthcheck.ManifoldConfig) { config.NewHookRunner = nil },
+		err:    "missing NewHookRunner not valid",
+	}, {
+		change: func(config *healthcheck.ManifoldConfig) { config.NewFacade = nil },
+		err:    "missing NewFacade not valid",
+	}, {
+		change: func(config *healthcheck.ManifoldConfig) { config.NewWorker = nil },
+		err:    "missing NewWorker not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := validManifoldConfig()
+		test.change(&config)
+		manifold := healthcheck.Manifold(config)
+		worker, err := manifold.Start(dt.StubResources{}.Context())
+		c.Check(worker, gc.IsNil)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
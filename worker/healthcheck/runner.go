@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/mutex"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/core/machinelock"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/runner"
+)
+
+// HealthStatusChanged is the name of the hook run when the result of a
+// unit's health checks changes.
+const HealthStatusChanged = "health-status-changed"
+
+// HookRunner implements the functionality necessary to run a
+// health-status-changed hook.
+type HookRunner interface {
+	RunHook(health status.Status, info string, abort <-chan struct{}) error
+}
+
+// hookRunner implements HookRunner.
+type hookRunner struct {
+	machineLockName string
+	config          agent.Config
+	tag             names.UnitTag
+	clock           clock.Clock
+}
+
+// NewHookRunner returns a HookRunner that runs the unit's
+// health-status-changed hook while holding the machine lock.
+func NewHookRunner(tag names.UnitTag, lockName string, config agent.Config, clock clock.Clock) HookRunner {
+	return &hookRunner{
+		tag:             tag,
+		machineLockName: lockName,
+		config:          config,
+		clock:           clock,
+	}
+}
+
+// acquireExecutionLock acquires the machine-level execution lock.
+func (r *hookRunner) acquireExecutionLock(abort <-chan struct{}) (mutex.Releaser, error) {
+	spec := mutex.Spec{
+		Name:   r.machineLockName,
+		Clock:  r.clock,
+		Delay:  250 * time.Millisecond,
+		Cancel: abort,
+	}
+	logger.Debugf("acquire lock %q for health check hook execution", r.machineLockName)
+	releaser, err := machinelock.Acquire(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Debugf("lock %q acquired", r.machineLockName)
+	return releaser, nil
+}
+
+// RunHook is part of the HookRunner interface.
+func (r *hookRunner) RunHook(health status.Status, info string, abort <-chan struct{}) error {
+	paths := uniter.NewWorkerPaths(r.config.DataDir(), r.tag, "health-check")
+	ctx := newHookContext(r.tag.Id(), health, info)
+	hr := runner.NewRunner(ctx, paths, r.clock)
+	releaser, err := r.acquireExecutionLock(abort)
+	if err != nil {
+		return errors.Annotate(err, "failed to acquire machine lock")
+	}
+	// Defer the logging first so it is executed after the Release. LIFO.
+	defer logger.Debugf("release lock %q for health check hook execution", r.machineLockName)
+	defer releaser.Release()
+	return hr.RunHook(HealthStatusChanged)
+}
@@ -0,0 +1,27 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/healthcheck"
+	"github.com/juju/juju/worker"
+)
+
+// NewFacade creates a *healthcheck.Client and returns it as a Facade.
+func NewFacade(apiCaller base.APICaller, tag names.UnitTag) Facade {
+	return healthcheck.NewClient(apiCaller, tag)
+}
+
+// NewWorker creates a *Worker and returns it as a worker.Worker.
+func NewWorker(config Config) (worker.Worker, error) {
+	worker, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
@@ -0,0 +1,283 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker/catacomb"
+	"github.com/juju/juju/worker/fortress"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+// pollInterval is the longest the worker waits before reading the
+// charm's health checks again, so that checks added or changed by a
+// charm upgrade are picked up.
+const pollInterval = time.Minute
+
+// Facade exposes controller functionality required by a Worker.
+type Facade interface {
+	SetHealth(health status.Status, info string, data map[string]interface{}) error
+}
+
+// Config holds the dependencies and configuration for a Worker.
+type Config struct {
+	Facade   Facade
+	Runner   HookRunner
+	CharmDir fortress.Guest
+	Clock    clock.Clock
+
+	// CharmDirPath is the directory holding the unit's charm.
+	CharmDirPath string
+
+	// ReadChecks returns the health checks declared by the charm in
+	// the given directory.
+	ReadChecks func(charmDir string) ([]Check, error)
+
+	// RunCheck runs a single health check.
+	RunCheck func(check Check, charmDir string) error
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional Worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Runner == nil {
+		return errors.NotValidf("nil Runner")
+	}
+	if config.CharmDir == nil {
+		return errors.NotValidf("nil CharmDir")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.CharmDirPath == "" {
+		return errors.NotValidf("empty CharmDirPath")
+	}
+	if config.ReadChecks == nil {
+		return errors.NotValidf("nil ReadChecks")
+	}
+	if config.RunCheck == nil {
+		return errors.NotValidf("nil RunCheck")
+	}
+	return nil
+}
+
+// New returns a Worker that runs the health checks declared by
+// the unit's charm, reports the unit's health to the controller, and
+// runs the health-status-changed hook whenever the unit's health
+// changes.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+		checks: make(map[string]*checkState),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker runs a unit's health checks.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	checks map[string]*checkState
+
+	// health and message hold what was last reported to the
+	// controller; health is empty if nothing has been reported.
+	health  status.Status
+	message string
+}
+
+// checkState records the recent results of a health check.
+type checkState struct {
+	check    Check
+	next     time.Time
+	passed   bool
+	failures int
+	err      error
+}
+
+// failing returns whether the check has failed at least as many times
+// in a row as its threshold allows.
+func (cs *checkState) failing() bool {
+	return cs.failures >= cs.check.Threshold
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	wait := w.config.Clock.After(0)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-wait:
+		}
+		// The checks and the hook may depend on the charm directory,
+		// so they only run while it is available.
+		err := w.config.CharmDir.Visit(w.runDueChecks, w.catacomb.Dying())
+		if err == fortress.ErrAborted {
+			return w.catacomb.ErrDying()
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		wait = w.config.Clock.After(w.nextRun().Sub(w.config.Clock.Now()))
+	}
+}
+
+// runDueChecks runs the checks that are due, and reports any resulting
+// change in the unit's health.
+func (w *Worker) runDueChecks() error {
+	checks, err := w.config.ReadChecks(w.config.CharmDirPath)
+	if err != nil {
+		// A charm with broken health checks is no reason to stop
+		// the worker; try again when the charm may have changed.
+		logger.Errorf("cannot read health checks: %v", err)
+		w.checks = make(map[string]*checkState)
+		return nil
+	}
+	now := w.config.Clock.Now()
+	w.updateChecks(checks, now)
+	for _, check := range checks {
+		cs := w.checks[check.Name]
+		if now.Before(cs.next) {
+			continue
+		}
+		cs.next = now.Add(check.Interval)
+		cs.err = w.config.RunCheck(check, w.config.CharmDirPath)
+		if cs.err == nil {
+			cs.passed = true
+			cs.failures = 0
+			continue
+		}
+		cs.failures++
+		logger.Debugf("health check %q failed (%d of %d): %v", check.Name, cs.failures, check.Threshold, cs.err)
+	}
+	return w.report()
+}
+
+// updateChecks replaces the worker's checks with those given. The
+// results of checks whose definitions have not changed are kept; any
+// other check is due immediately.
+func (w *Worker) updateChecks(checks []Check, now time.Time) {
+	current := make(map[string]*checkState)
+	for _, check := range checks {
+		if cs, ok := w.checks[check.Name]; ok && cs.check == check {
+			current[check.Name] = cs
+			continue
+		}
+		current[check.Name] = &checkState{check: check, next: now}
+	}
+	w.checks = current
+}
+
+// nextRun returns the time at which the worker should next run.
+func (w *Worker) nextRun() time.Time {
+	next := w.config.Clock.Now().Add(pollInterval)
+	for _, cs := range w.checks {
+		if cs.next.Before(next) {
+			next = cs.next
+		}
+	}
+	return next
+}
+
+// evaluate returns the unit's health according to the results of its
+// checks. The unit is unhealthy if any check is failing, and healthy
+// once every check has passed; until then, its health is not known
+// and evaluate returns an empty status.
+func (w *Worker) evaluate() (status.Status, string, map[string]interface{}) {
+	if len(w.checks) == 0 {
+		if w.health == "" {
+			// Units whose charms never declared any health checks
+			// do not report their health at all.
+			return "", "", nil
+		}
+		return status.StatusUnknown, "no health checks defined", nil
+	}
+	var failing []string
+	data := make(map[string]interface{})
+	known := true
+	for name, cs := range w.checks {
+		if cs.failing() {
+			failing = append(failing, name)
+			data[name] = cs.err.Error()
+		} else if !cs.passed {
+			known = false
+		}
+	}
+	switch {
+	case len(failing) > 0:
+		return status.StatusUnhealthy, failingMessage(failing), data
+	case known:
+		return status.StatusHealthy, "", nil
+	}
+	return "", "", nil
+}
+
+// failingMessage describes the named failing checks.
+func failingMessage(names []string) string {
+	sort.Strings(names)
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+	if len(quoted) == 1 {
+		return fmt.Sprintf("health check %s failing", quoted[0])
+	}
+	return fmt.Sprintf("health checks %s failing", strings.Join(quoted, ", "))
+}
+
+// report records any change in the unit's health with the controller,
+// and runs the health-status-changed hook if its status has changed.
+func (w *Worker) report() error {
+	health, message, data := w.evaluate()
+	if health == "" || (health == w.health && message == w.message) {
+		return nil
+	}
+	if err := w.config.Facade.SetHealth(health, message, data); err != nil {
+		return errors.Annotate(err, "cannot set unit health")
+	}
+	changed := health != w.health
+	w.health, w.message = health, message
+	if !changed {
+		return nil
+	}
+	logger.Infof("unit health changed to %s", health)
+	err := w.config.Runner.RunHook(health, message, w.catacomb.Dying())
+	switch cause := errors.Cause(err); {
+	case context.IsMissingHookError(cause):
+		logger.Infof("skipped %q hook (missing)", HealthStatusChanged)
+	case err != nil:
+		logger.Errorf("health check worker encountered hook error: %v", err)
+	}
+	return nil
+}
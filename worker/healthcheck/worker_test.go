@@ -0,0 +1,342 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/fortress"
+	"github.com/juju/juju/worker/healthcheck"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *coretesting.Clock
+	facade *fakeFacade
+	runner *fakeHookRunner
+	charm  *fakeCharm
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var webCheck = healthcheck.Check{
+	Name:      "web",
+	HTTP:      "http://localhost:8080/health",
+	Interval:  time.Minute,
+	Timeout:   10 * time.Second,
+	Threshold: 2,
+}
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Now())
+	s.facade = &fakeFacade{}
+	s.runner = &fakeHookRunner{}
+	s.charm = &fakeCharm{
+		checks:  []healthcheck.Check{webCheck},
+		results: make(map[string]error),
+	}
+}
+
+func (s *WorkerSuite) config() healthcheck.Config {
+	return healthcheck.Config{
+		Facade:       s.facade,
+		Runner:       s.runner,
+		CharmDir:     fakeGuest{},
+		Clock:        s.clock,
+		CharmDirPath: "/var/lib/juju/agents/unit-wordpress-0/charm",
+		ReadChecks:   s.charm.ReadChecks,
+		RunCheck:     s.charm.RunCheck,
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) *healthcheck.Worker {
+	w, err := healthcheck.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	// The first run is due immediately.
+	s.waitAlarms(c, 2)
+	return w
+}
+
+// waitAlarms waits until the worker has started waiting n times.
+func (s *WorkerSuite) waitAlarms(c *gc.C, n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case <-s.clock.Alarms():
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for worker to wait")
+		}
+	}
+}
+
+// advance moves the clock on by d, and waits for the worker to run.
+func (s *WorkerSuite) advance(c *gc.C, d time.Duration) {
+	s.clock.Advance(d)
+	s.waitAlarms(c, 1)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		change func(*healthcheck.Config)
+		err    string
+	}{{
+		change: func(config *healthcheck.Config) { config.Facade = nil },
+		err:    "nil Facade not valid",
+	}, {
+		change: func(config *healthcheck.Config) { config.Runner = nil },
+		err:    "nil Runner not valid",
+	}, {
+		change: func(config *healthcheck.Config) { config.CharmDir = nil },
+		err:    "nil CharmDir not valid",
+	}, {
+		change: func(config *healthcheck.Config) { config.Clock = nil },
+		err:    "nil Clock not valid",
+	}, {
+		change: func(config *healthcheck.Config) { config.CharmDirPath = "" },
+		err:    "empty CharmDirPath not valid",
+	}, {
+		change: func(config *healthcheck.Config) { config.ReadChecks = nil },
+		err:    "nil ReadChecks not valid",
+	}, {
+		change: func(config *healthcheck.Config) { config.RunCheck = nil },
+		err:    "nil RunCheck not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config()
+		test.change(&config)
+		c.Check(config.Validate(), jc.Satisfies, errors.IsNotValid)
+		_, err := healthcheck.New(config)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestHealthy(c *gc.C) {
+	s.startWorker(c)
+	c.Check(s.charm.runs(), jc.DeepEquals, []string{"web"})
+	c.Check(s.facade.reports(), jc.DeepEquals, []healthReport{{health: status.StatusHealthy}})
+	c.Check(s.runner.hooks(), jc.DeepEquals, []healthReport{{health: status.StatusHealthy}})
+
+	// Nothing is reported again while the unit stays healthy.
+	s.advance(c, time.Minute)
+	c.Check(s.charm.runs(), jc.DeepEquals, []string{"web", "web"})
+	c.Check(s.facade.reports(), gc.HasLen, 1)
+	c.Check(s.runner.hooks(), gc.HasLen, 1)
+}
+
+func (s *WorkerSuite) TestUnhealthyAfterThreshold(c *gc.C) {
+	s.charm.setResult("web", errors.New("connection refused"))
+	s.startWorker(c)
+	// A check that has never passed, and has not yet reached its
+	// threshold, leaves the unit's health unknown.
+	c.Check(s.facade.reports(), gc.HasLen, 0)
+
+	s.advance(c, time.Minute)
+	unhealthy := healthReport{
+		health:  status.StatusUnhealthy,
+		message: `health check "web" failing`,
+		data:    map[string]interface{}{"web": "connection refused"},
+	}
+	c.Check(s.facade.reports(), jc.DeepEquals, []healthReport{unhealthy})
+	c.Check(s.runner.hooks(), jc.DeepEquals, []healthReport{{
+		health:  status.StatusUnhealthy,
+		message: `health check "web" failing`,
+	}})
+}
+
+func (s *WorkerSuite) TestRecovery(c *gc.C) {
+	s.startWorker(c)
+	s.charm.setResult("web", errors.New("connection refused"))
+
+	// A single failure is within the check's threshold.
+	s.advance(c, time.Minute)
+	c.Check(s.facade.reports(), gc.HasLen, 1)
+
+	s.advance(c, time.Minute)
+	s.charm.setResult("web", nil)
+	s.advance(c, time.Minute)
+
+	c.Check(s.runner.hooks(), jc.DeepEquals, []healthReport{
+		{health: status.StatusHealthy},
+		{health: status.StatusUnhealthy, message: `health check "web" failing`},
+		{health: status.StatusHealthy},
+	})
+}
+
+func (s *WorkerSuite) TestIntervals(c *gc.C) {
+	slowCheck := healthcheck.Check{
+		Name:      "db",
+		TCP:       5432,
+		Interval:  5 * time.Minute,
+		Timeout:   10 * time.Second,
+		Threshold: 1,
+	}
+	s.charm.setChecks(webCheck, slowCheck)
+	s.startWorker(c)
+	c.Check(s.charm.runs(), jc.SameContents, []string{"web", "db"})
+
+	for i := 0; i < 4; i++ {
+		s.advance(c, time.Minute)
+	}
+	c.Check(s.charm.runs(), gc.HasLen, 6)
+
+	s.advance(c, time.Minute)
+	c.Check(s.charm.runs(), gc.HasLen, 8)
+}
+
+func (s *WorkerSuite) TestNoChecks(c *gc.C) {
+	s.charm.setChecks()
+	s.startWorker(c)
+	s.advance(c, time.Minute)
+	c.Check(s.facade.reports(), gc.HasLen, 0)
+	c.Check(s.runner.hooks(), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestChecksRemoved(c *gc.C) {
+	s.startWorker(c)
+	s.charm.setChecks()
+	s.advance(c, time.Minute)
+	c.Check(s.facade.reports(), jc.DeepEquals, []healthReport{
+		{health: status.StatusHealthy},
+		{health: status.StatusUnknown, message: "no health checks defined"},
+	})
+}
+
+func (s *WorkerSuite) TestChecksChanged(c *gc.C) {
+	s.charm.setResult("web", errors.New("connection refused"))
+	s.startWorker(c)
+	s.advance(c, time.Minute)
+	c.Check(s.facade.reports(), gc.HasLen, 1)
+
+	// A changed check starts again from scratch.
+	changed := webCheck
+	changed.HTTP = "http://localhost:8080/ping"
+	s.charm.setChecks(changed)
+	s.charm.setResult("web", nil)
+	s.advance(c, time.Minute)
+	c.Check(s.facade.reports(), jc.DeepEquals, []healthReport{{
+		health:  status.StatusUnhealthy,
+		message: `health check "web" failing`,
+		data:    map[string]interface{}{"web": "connection refused"},
+	}, {
+		health: status.StatusHealthy,
+	}})
+}
+
+func (s *WorkerSuite) TestSetHealthError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := healthcheck.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot set unit health: boom")
+}
+
+func (s *WorkerSuite) TestHookErrorIgnored(c *gc.C) {
+	s.runner.err = errors.New("hook failed")
+	w := s.startWorker(c)
+	workertest.CheckAlive(c, w)
+	c.Check(s.runner.hooks(), gc.HasLen, 1)
+}
+
+type healthReport struct {
+	health  status.Status
+	message string
+	data    map[string]interface{}
+}
+
+type fakeFacade struct {
+	mu       sync.Mutex
+	reported []healthReport
+	err      error
+}
+
+func (f *fakeFacade) SetHealth(health status.Status, info string, data map[string]interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.reported = append(f.reported, healthReport{health, info, data})
+	return nil
+}
+
+func (f *fakeFacade) reports() []healthReport {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]healthReport(nil), f.reported...)
+}
+
+type fakeHookRunner struct {
+	mu       sync.Mutex
+	hookRuns []healthReport
+	err      error
+}
+
+func (r *fakeHookRunner) RunHook(health status.Status, info string, abort <-chan struct{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hookRuns = append(r.hookRuns, healthReport{health: health, message: info})
+	return r.err
+}
+
+func (r *fakeHookRunner) hooks() []healthReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]healthReport(nil), r.hookRuns...)
+}
+
+type fakeCharm struct {
+	mu      sync.Mutex
+	checks  []healthcheck.Check
+	results map[string]error
+	ran     []string
+}
+
+func (f *fakeCharm) ReadChecks(charmDir string) ([]healthcheck.Check, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]healthcheck.Check(nil), f.checks...), nil
+}
+
+func (f *fakeCharm) RunCheck(check healthcheck.Check, charmDir string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ran = append(f.ran, check.Name)
+	return f.results[check.Name]
+}
+
+func (f *fakeCharm) setChecks(checks ...healthcheck.Check) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.checks = checks
+}
+
+func (f *fakeCharm) setResult(name string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results[name] = err
+}
+
+func (f *fakeCharm) runs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.ran...)
+}
+
+// fakeGuest is a fortress.Guest whose fortress is always unlocked.
+type fakeGuest struct{}
+
+func (fakeGuest) Visit(visit fortress.Visit, _ fortress.Abort) error {
+	return visit()
+}
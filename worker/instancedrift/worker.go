@@ -0,0 +1,217 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package instancedrift provides a worker that periodically compares
+// the machines recorded in a model with the instances found in its
+// cloud. Machines whose instances have disappeared, for example
+// because they were terminated outside of Juju, are marked as
+// vanished; instances tagged as belonging to the model but unknown
+// to any machine are reported as leaked. The worker never destroys
+// instances: that is left to the provisioner, according to the
+// model's provisioner-harvest-mode.
+package instancedrift
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/instancedrift"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.instancedrift")
+
+// Facade exposes the controller capabilities required by the worker.
+type Facade interface {
+	MachineInstances() ([]instancedrift.MachineInstance, error)
+	MarkVanished(tags ...names.MachineTag) error
+	SetLeakedInstances(ids []instance.Id) error
+}
+
+// Environ exposes the cloud capabilities required by the worker.
+type Environ interface {
+	AllInstances() ([]instance.Instance, error)
+}
+
+// Config defines the operation of an instance drift worker.
+type Config struct {
+
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Environ is the worker's view of the cloud.
+	Environ Environ
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between checks for drift.
+	Period time.Duration
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Environ == nil {
+		return errors.NotValidf("nil Environ")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that checks for drift between the model
+// and its cloud once when started, and subsequently every Period.
+//
+// An instance is only considered vanished or leaked once it has been
+// seen to be so by two consecutive checks, so that instances that are
+// in the middle of being provisioned or are not yet visible through
+// an eventually consistent cloud API are not misreported.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &driftWorker{
+		config:    config,
+		missing:   make(set.Strings),
+		untracked: make(set.Strings),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type driftWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// missing holds the ids of instances recorded against
+	// machines, but not found in the cloud, by the last check.
+	missing set.Strings
+
+	// untracked holds the ids of instances found in the cloud,
+	// but not recorded against any machine, by the last check.
+	untracked set.Strings
+
+	// reported holds the ids of the instances last reported
+	// as leaked, or nil if nothing has been reported yet.
+	reported set.Strings
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *driftWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *driftWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *driftWorker) loop() error {
+	var delay time.Duration
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(delay):
+			if err := w.check(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		delay = w.config.Period
+	}
+}
+
+// check compares the model's machines with the cloud's instances,
+// marking vanished machines and reporting leaked instances.
+func (w *driftWorker) check() error {
+	machines, err := w.config.Facade.MachineInstances()
+	if err != nil {
+		return errors.Annotate(err, "cannot get machine instances")
+	}
+	instances, err := w.config.Environ.AllInstances()
+	if err != nil && err != environs.ErrNoInstances {
+		return errors.Annotate(err, "cannot get cloud instances")
+	}
+	inCloud := make(set.Strings)
+	for _, inst := range instances {
+		inCloud.Add(string(inst.Id()))
+	}
+
+	tracked := make(set.Strings)
+	missing := make(set.Strings)
+	var vanished []names.MachineTag
+	for _, m := range machines {
+		if m.InstanceId == "" {
+			continue
+		}
+		id := string(m.InstanceId)
+		tracked.Add(id)
+		if inCloud.Contains(id) {
+			continue
+		}
+		missing.Add(id)
+		if w.missing.Contains(id) && m.InstanceStatus != status.StatusVanished {
+			vanished = append(vanished, m.Tag)
+		}
+	}
+	w.missing = missing
+	if len(vanished) > 0 {
+		logger.Warningf("instances of machines %v not found in cloud", vanished)
+		if err := w.config.Facade.MarkVanished(vanished...); err != nil {
+			return errors.Annotate(err, "cannot mark machines as vanished")
+		}
+	}
+
+	untracked := inCloud.Difference(tracked)
+	leaked := untracked.Intersection(w.untracked)
+	w.untracked = untracked
+	return w.report(leaked)
+}
+
+// report records the leaked instances, if they differ from those
+// last reported.
+func (w *driftWorker) report(leaked set.Strings) error {
+	if w.reported != nil && leaked.Difference(w.reported).IsEmpty() && w.reported.Difference(leaked).IsEmpty() {
+		return nil
+	}
+	if !leaked.IsEmpty() {
+		logger.Warningf("instances %v are not known to any machine", leaked.SortedValues())
+	}
+	if err := w.config.Facade.SetLeakedInstances(instanceIds(leaked)); err != nil {
+		return errors.Annotate(err, "cannot record leaked instances")
+	}
+	w.reported = leaked
+	return nil
+}
+
+func instanceIds(ids set.Strings) []instance.Id {
+	values := ids.SortedValues()
+	result := make([]instance.Id, len(values))
+	for i, id := range values {
+		result[i] = instance.Id(id)
+	}
+	return result
+}
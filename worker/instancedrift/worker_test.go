@@ -0,0 +1,227 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instancedrift_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	apiinstancedrift "github.com/juju/juju/api/instancedrift"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/instancedrift"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	stub    *testing.Stub
+	clock   *coretesting.Clock
+	calls   chan string
+	facade  *mockFacade
+	environ *mockEnviron
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+const period = time.Minute
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &testing.Stub{}
+	s.clock = coretesting.NewClock(time.Now())
+	s.calls = make(chan string, 100)
+	s.facade = &mockFacade{
+		stub:  s.stub,
+		calls: s.calls,
+		machines: []apiinstancedrift.MachineInstance{{
+			Tag:            names.NewMachineTag("0"),
+			InstanceId:     "i-0",
+			InstanceStatus: status.StatusRunning,
+		}, {
+			Tag:            names.NewMachineTag("1"),
+			InstanceId:     "i-1",
+			InstanceStatus: status.StatusRunning,
+		}, {
+			Tag: names.NewMachineTag("2"),
+		}},
+	}
+	s.environ = &mockEnviron{
+		stub:      s.stub,
+		calls:     s.calls,
+		instances: []instance.Instance{mockInstance{id: "i-0"}, mockInstance{id: "i-leaked"}},
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := instancedrift.NewWorker(instancedrift.Config{
+		Facade:  s.facade,
+		Environ: s.environ,
+		Clock:   s.clock,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *WorkerSuite) TestReportsAfterSecondCheck(c *gc.C) {
+	w := s.startWorker(c)
+	defer worker.Stop(w)
+
+	s.waitCall(c, "SetLeakedInstances")
+	s.stub.CheckCall(c, 2, "SetLeakedInstances", []instance.Id{})
+	s.advance(c)
+	s.waitCall(c, "SetLeakedInstances")
+	s.stub.CheckCallNames(c,
+		"MachineInstances", "AllInstances", "SetLeakedInstances",
+		"MachineInstances", "AllInstances", "MarkVanished", "SetLeakedInstances",
+	)
+	s.stub.CheckCall(c, 5, "MarkVanished", []names.MachineTag{names.NewMachineTag("1")})
+	s.stub.CheckCall(c, 6, "SetLeakedInstances", []instance.Id{"i-leaked"})
+}
+
+func (s *WorkerSuite) TestAlreadyVanishedNotMarked(c *gc.C) {
+	s.facade.machines[1].InstanceStatus = status.StatusVanished
+	s.environ.instances = []instance.Instance{mockInstance{id: "i-0"}}
+	w := s.startWorker(c)
+	defer worker.Stop(w)
+
+	s.waitCall(c, "SetLeakedInstances")
+	s.advance(c)
+	s.waitCall(c, "AllInstances")
+	s.waitNoCall(c)
+	s.stub.CheckCallNames(c,
+		"MachineInstances", "AllInstances", "SetLeakedInstances",
+		"MachineInstances", "AllInstances",
+	)
+}
+
+func (s *WorkerSuite) TestTransientlyMissingNotReported(c *gc.C) {
+	s.environ.instances = []instance.Instance{mockInstance{id: "i-0"}}
+	w := s.startWorker(c)
+	defer worker.Stop(w)
+
+	s.waitCall(c, "SetLeakedInstances")
+	s.environ.setInstances(mockInstance{id: "i-0"}, mockInstance{id: "i-1"})
+	s.advance(c)
+	s.waitCall(c, "AllInstances")
+	s.waitNoCall(c)
+	s.stub.CheckCallNames(c,
+		"MachineInstances", "AllInstances", "SetLeakedInstances",
+		"MachineInstances", "AllInstances",
+	)
+}
+
+func (s *WorkerSuite) TestAllInstancesError(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("cloud says no"))
+	w := s.startWorker(c)
+	err := w.Wait()
+	c.Assert(err, gc.ErrorMatches, "cannot get cloud instances: cloud says no")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := instancedrift.NewWorker(instancedrift.Config{
+		Facade:  s.facade,
+		Environ: s.environ,
+		Clock:   s.clock,
+		Period:  period,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+// advance waits for the worker to wait on the clock, and then
+// advances the clock by the worker's period.
+func (s *WorkerSuite) advance(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to wait")
+	}
+	s.clock.Advance(period)
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C, name string) {
+	timeout := time.After(coretesting.LongWait)
+	for {
+		select {
+		case call := <-s.calls:
+			if call == name {
+				return
+			}
+		case <-timeout:
+			c.Fatalf("timed out waiting for %s call", name)
+		}
+	}
+}
+
+func (s *WorkerSuite) waitNoCall(c *gc.C) {
+	select {
+	case call := <-s.calls:
+		c.Fatalf("unexpected %s call", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	stub     *testing.Stub
+	calls    chan<- string
+	machines []apiinstancedrift.MachineInstance
+}
+
+func (f *mockFacade) MachineInstances() ([]apiinstancedrift.MachineInstance, error) {
+	f.stub.AddCall("MachineInstances")
+	f.calls <- "MachineInstances"
+	return f.machines, f.stub.NextErr()
+}
+
+func (f *mockFacade) MarkVanished(tags ...names.MachineTag) error {
+	f.stub.AddCall("MarkVanished", tags)
+	f.calls <- "MarkVanished"
+	return f.stub.NextErr()
+}
+
+func (f *mockFacade) SetLeakedInstances(ids []instance.Id) error {
+	f.stub.AddCall("SetLeakedInstances", ids)
+	f.calls <- "SetLeakedInstances"
+	return f.stub.NextErr()
+}
+
+type mockEnviron struct {
+	stub      *testing.Stub
+	calls     chan<- string
+	mu        sync.Mutex
+	instances []instance.Instance
+}
+
+func (e *mockEnviron) setInstances(instances ...instance.Instance) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.instances = instances
+}
+
+func (e *mockEnviron) AllInstances() ([]instance.Instance, error) {
+	e.stub.AddCall("AllInstances")
+	e.mu.Lock()
+	instances := e.instances
+	e.mu.Unlock()
+	e.calls <- "AllInstances"
+	return instances, e.stub.NextErr()
+}
+
+type mockInstance struct {
+	instance.Instance
+	id instance.Id
+}
+
+func (i mockInstance) Id() instance.Id {
+	return i.id
+}
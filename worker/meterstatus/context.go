@@ -80,6 +80,9 @@ func (ctx *limitedContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *limitedContext) ResetExecutionSetUnitStatus() {}
 
+// HookTimeout implements runner.Context.
+func (ctx *limitedContext) HookTimeout() time.Duration { return 0 }
+
 // Id implements runner.Context.
 func (ctx *limitedContext) Id() string { return ctx.id }
 
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/worker/uniter"
 	"github.com/juju/juju/worker/uniter/runner"
 )
@@ -49,7 +50,7 @@ func (w *hookRunner) acquireExecutionLock(interrupt <-chan struct{}) (mutex.Rele
 		Cancel: interrupt,
 	}
 	logger.Debugf("acquire lock %q for meter status hook execution", w.machineLockName)
-	releaser, err := mutex.Acquire(spec)
+	releaser, err := machinelock.Acquire(spec)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -65,7 +66,7 @@ func (w *hookRunner) RunHook(code, info string, interrupt <-chan struct{}) (runE
 		"JUJU_METER_STATUS": code,
 		"JUJU_METER_INFO":   info,
 	})
-	r := runner.NewRunner(ctx, paths)
+	r := runner.NewRunner(ctx, paths, w.clock)
 	releaser, err := w.acquireExecutionLock(interrupt)
 	if err != nil {
 		return errors.Annotate(err, "failed to acquire machine lock")
@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/os"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
@@ -243,7 +244,7 @@ func (h *hookRunner) do(recorder spool.MetricRecorder) error {
 		return errors.Annotatef(err, "error adding 'juju-units' metric")
 	}
 
-	r := runner.NewRunner(ctx, h.paths)
+	r := runner.NewRunner(ctx, h.paths, clock.WallClock)
 	err = r.RunHook(string(hooks.CollectMetrics))
 	if err != nil {
 		return errors.Annotatef(err, "error running 'collect-metrics' hook")
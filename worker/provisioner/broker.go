@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/tools"
@@ -65,6 +66,72 @@ func prepareOrGetContainerInterfaceInfo(
 	return preparedInfo, nil
 }
 
+// newFanNetworkConfig is defined here so it can be overridden for
+// testing.
+var newFanNetworkConfig = container.FanNetworkConfig
+
+// containerNetworkConfig returns the network config for a new container,
+// according to the model's container networking method. For the "fan"
+// method the container is placed on the host's Fan bridge; otherwise it
+// is placed on the given bridge device and, unless the method is
+// "local", given addresses allocated by the provider where possible.
+func containerNetworkConfig(
+	api APICalls,
+	containerConfig params.ContainerConfig,
+	machineID string,
+	bridgeDevice string,
+	networkInfo []network.InterfaceInfo,
+	log loggo.Logger,
+) (*container.NetworkConfig, error) {
+	switch containerConfig.ContainerNetworkingMethod {
+	case config.ContainerNetworkingFan:
+		fanConfig, err := network.ParseFanConfig(containerConfig.FanConfig)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid fan config")
+		}
+		netConfig, err := newFanNetworkConfig(fanConfig)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot place container %q on the fan", machineID)
+		}
+		log.Debugf("using fan bridge %q for container %q", netConfig.Device, machineID)
+		interfaces, err := finishNetworkConfig(netConfig.Device, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for i := range interfaces {
+			interfaces[i].MTU = netConfig.MTU
+		}
+		netConfig.Interfaces = interfaces
+		return netConfig, nil
+	case config.ContainerNetworkingLocal:
+		log.Debugf("using local bridge %q for container %q", bridgeDevice, machineID)
+	default:
+		preparedInfo, err := prepareOrGetContainerInterfaceInfo(
+			api,
+			machineID,
+			bridgeDevice,
+			true, // allocate if possible, do not maintain existing.
+			networkInfo,
+			log,
+		)
+		if err != nil {
+			// It's not fatal (yet) if we couldn't pre-allocate addresses for the
+			// container.
+			logger.Warningf("failed to prepare container %q network config: %v", machineID, err)
+		} else {
+			networkInfo = preparedInfo
+		}
+	}
+
+	netConfig := container.BridgeNetworkConfig(bridgeDevice, 0, networkInfo)
+	interfaces, err := finishNetworkConfig(bridgeDevice, networkInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	netConfig.Interfaces = interfaces
+	return netConfig, nil
+}
+
 // finishNetworkConfig populates the ParentInterfaceName, DNSServers, and
 // DNSSearchDomains fields on each element, when they are not set. The given
 // bridgeDevice is used for ParentInterfaceName, while the DNS config is
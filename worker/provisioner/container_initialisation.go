@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm"
 	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
@@ -161,7 +162,7 @@ func (cs *ContainerSetup) runInitialiser(abort <-chan struct{}, containerType in
 		Cancel: abort,
 	}
 	logger.Debugf("acquire lock %q for container initialisation", cs.initLockName)
-	releaser, err := mutex.Acquire(spec)
+	releaser, err := machinelock.Acquire(spec)
 	if err != nil {
 		return errors.Annotate(err, "failed to acquire initialization lock")
 	}
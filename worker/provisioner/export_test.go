@@ -28,6 +28,7 @@ func GetRetryWatcher(p Provisioner) (watcher.NotifyWatcher, error) {
 var (
 	ContainerManagerConfig = containerManagerConfig
 	GetToolsFinder         = &getToolsFinder
+	NewFanNetworkConfig    = &newFanNetworkConfig
 	ResolvConf             = &resolvConf
 	RetryStrategyDelay     = &retryStrategyDelay
 	RetryStrategyCount     = &retryStrategyCount
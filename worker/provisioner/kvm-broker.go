@@ -59,28 +59,17 @@ func (broker *kvmBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		return nil, err
 	}
 
-	preparedInfo, err := prepareOrGetContainerInterfaceInfo(
+	network, err := containerNetworkConfig(
 		broker.api,
+		config,
 		machineId,
 		bridgeDevice,
-		true, // allocate if possible, do not maintain existing.
 		args.NetworkInfo,
 		kvmLogger,
 	)
-	if err != nil {
-		// It's not fatal (yet) if we couldn't pre-allocate addresses for the
-		// container.
-		logger.Warningf("failed to prepare container %q network config: %v", machineId, err)
-	} else {
-		args.NetworkInfo = preparedInfo
-	}
-
-	network := container.BridgeNetworkConfig(bridgeDevice, 0, args.NetworkInfo)
-	interfaces, err := finishNetworkConfig(bridgeDevice, args.NetworkInfo)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	network.Interfaces = interfaces
 
 	// The provisioner worker will provide all tools it knows about
 	// (after applying explicitly specified constraints), which may
@@ -130,7 +119,7 @@ func (broker *kvmBroker) StartInstance(args environs.StartInstanceParams) (*envi
 	return &environs.StartInstanceResult{
 		Instance:    inst,
 		Hardware:    hardware,
-		NetworkInfo: interfaces,
+		NetworkInfo: network.Interfaces,
 	}, nil
 }
 
@@ -4,12 +4,15 @@
 package provisioner
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -48,28 +51,17 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		return nil, err
 	}
 
-	preparedInfo, err := prepareOrGetContainerInterfaceInfo(
+	network, err := containerNetworkConfig(
 		broker.api,
+		config,
 		machineId,
 		bridgeDevice,
-		true, // allocate if possible, do not maintain existing.
 		args.NetworkInfo,
 		lxdLogger,
 	)
-	if err != nil {
-		// It's not fatal (yet) if we couldn't pre-allocate addresses for the
-		// container.
-		logger.Warningf("failed to prepare container %q network config: %v", machineId, err)
-	} else {
-		args.NetworkInfo = preparedInfo
-	}
-
-	network := container.BridgeNetworkConfig(bridgeDevice, 0, args.NetworkInfo)
-	interfaces, err := finishNetworkConfig(bridgeDevice, args.NetworkInfo)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	network.Interfaces = interfaces
 
 	// The provisioner worker will provide all tools it knows about
 	// (after applying explicitly specified constraints), which may
@@ -101,6 +93,12 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		return nil, err
 	}
 
+	profiles, err := broker.writeCharmProfiles(args.CharmLXDProfiles)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args.InstanceConfig.Profiles = profiles
+
 	storageConfig := &container.StorageConfig{}
 	inst, hardware, err := broker.manager.CreateContainer(
 		args.InstanceConfig, args.Constraints,
@@ -113,7 +111,7 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 	return &environs.StartInstanceResult{
 		Instance:    inst,
 		Hardware:    hardware,
-		NetworkInfo: interfaces,
+		NetworkInfo: network.Interfaces,
 	}, nil
 }
 
@@ -137,7 +135,8 @@ func (broker *lxdBroker) AllInstances() (result []instance.Instance, err error)
 
 // MaintainInstance ensures the container's host has the required iptables and
 // routing rules to make the container visible to both the host and other
-// machines on the same subnet.
+// machines on the same subnet, and that the container has the LXD profiles
+// supplied by the charms of its units.
 func (broker *lxdBroker) MaintainInstance(args environs.StartInstanceParams) error {
 	machineID := args.InstanceConfig.MachineId
 
@@ -156,5 +155,50 @@ func (broker *lxdBroker) MaintainInstance(args environs.StartInstanceParams) err
 		args.NetworkInfo,
 		lxdLogger,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	return errors.Trace(broker.maintainCharmProfiles(machineID, args.CharmLXDProfiles))
+}
+
+// writeCharmProfiles ensures that the given charm LXD profiles exist,
+// and returns their names in a stable order.
+func (broker *lxdBroker) writeCharmProfiles(profiles map[string]*lxdprofile.Profile) ([]string, error) {
+	if len(profiles) == 0 {
+		return nil, nil
+	}
+	profileManager, ok := broker.manager.(container.LXDProfileManager)
+	if !ok {
+		return nil, errors.NotSupportedf("charm LXD profiles")
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := profileManager.MaybeWriteLXDProfile(name, profiles[name]); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return names, nil
+}
+
+// maintainCharmProfiles ensures that the container for the given
+// machine has exactly the given charm LXD profiles applied, as
+// required after its units' charms are upgraded.
+func (broker *lxdBroker) maintainCharmProfiles(machineID string, profiles map[string]*lxdprofile.Profile) error {
+	profileManager, ok := broker.manager.(container.LXDProfileManager)
+	if !ok {
+		return nil
+	}
+	names, err := broker.writeCharmProfiles(profiles)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	hostname, err := broker.manager.Namespace().Hostname(machineID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(profileManager.ReplaceCharmProfiles(instance.Id(hostname), names))
 }
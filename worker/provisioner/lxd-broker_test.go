@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -125,6 +126,47 @@ func (s *lxdBrokerSuite) TestStartInstancePopulatesFallbackNetworkInfo(c *gc.C)
 	}})
 }
 
+func (s *lxdBrokerSuite) TestStartInstanceOnFan(c *gc.C) {
+	patchResolvConf(s, c)
+	var fanConfigs []network.FanConfig
+	s.PatchValue(provisioner.NewFanNetworkConfig, func(fanConfig network.FanConfig) (*container.NetworkConfig, error) {
+		fanConfigs = append(fanConfigs, fanConfig)
+		return container.BridgeNetworkConfig("fan-252", 8951, nil), nil
+	})
+	s.api.fakeContainerConfig.FanConfig = "172.31.0.0/16=252.0.0.0/8"
+	s.api.fakeContainerConfig.ContainerNetworkingMethod = "fan"
+
+	result := s.startInstance(c, "1/lxd/0")
+	s.api.CheckCallNames(c, "ContainerConfig")
+	c.Assert(fanConfigs, gc.HasLen, 1)
+	c.Assert(fanConfigs[0].String(), gc.Equals, "172.31.0.0/16=252.0.0.0/8")
+	c.Assert(result.NetworkInfo, jc.DeepEquals, []network.InterfaceInfo{{
+		DeviceIndex:         0,
+		InterfaceName:       "eth0",
+		InterfaceType:       network.EthernetInterface,
+		ConfigType:          network.ConfigDHCP,
+		ParentInterfaceName: "fan-252",
+		MTU:                 8951,
+		DNSServers:          network.NewAddresses("ns1.dummy", "ns2.dummy"),
+		DNSSearchDomains:    []string{"dummy", "invalid"},
+	}})
+	call := s.manager.Calls()[0]
+	networkConfig := call.Args[3].(*container.NetworkConfig)
+	c.Assert(networkConfig.Device, gc.Equals, "fan-252")
+	c.Assert(networkConfig.MTU, gc.Equals, 8951)
+}
+
+func (s *lxdBrokerSuite) TestStartInstanceLocalNetworking(c *gc.C) {
+	patchResolvConf(s, c)
+	s.api.fakeContainerConfig.ContainerNetworkingMethod = "local"
+
+	result := s.startInstance(c, "1/lxd/0")
+	s.api.CheckCallNames(c, "ContainerConfig")
+	c.Assert(result.NetworkInfo, gc.HasLen, 1)
+	c.Assert(result.NetworkInfo[0].ParentInterfaceName, gc.Equals, "lxdbr0")
+	c.Assert(result.NetworkInfo[0].ConfigType, gc.Equals, network.ConfigDHCP)
+}
+
 func (s *lxdBrokerSuite) TestStartInstanceNoHostArchTools(c *gc.C) {
 	_, err := s.broker.StartInstance(environs.StartInstanceParams{
 		Tools: coretools.List{{
@@ -137,6 +179,49 @@ func (s *lxdBrokerSuite) TestStartInstanceNoHostArchTools(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `need tools for arch amd64, only found \[arm64\]`)
 }
 
+func (s *lxdBrokerSuite) TestStartInstanceWithCharmLXDProfiles(c *gc.C) {
+	profile := &lxdprofile.Profile{Config: map[string]string{"security.nesting": "true"}}
+	_, err := s.broker.StartInstance(environs.StartInstanceParams{
+		Tools:          makePossibleTools(),
+		InstanceConfig: makeInstanceConfig(c, s, "1/lxd/0"),
+		StatusCallback: makeNoOpStatusCallback(),
+		CharmLXDProfiles: map[string]*lxdprofile.Profile{
+			"juju-foo-b-1": profile,
+			"juju-foo-a-2": profile,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.manager.CheckCallNames(c, "MaybeWriteLXDProfile", "MaybeWriteLXDProfile", "CreateContainer")
+	s.manager.CheckCall(c, 0, "MaybeWriteLXDProfile", "juju-foo-a-2", profile)
+	s.manager.CheckCall(c, 1, "MaybeWriteLXDProfile", "juju-foo-b-1", profile)
+	instanceConfig := s.manager.Calls()[2].Args[0].(*instancecfg.InstanceConfig)
+	c.Assert(instanceConfig.Profiles, jc.DeepEquals, []string{"juju-foo-a-2", "juju-foo-b-1"})
+}
+
+func (s *lxdBrokerSuite) TestMaintainInstanceReplacesCharmProfiles(c *gc.C) {
+	profile := &lxdprofile.Profile{Config: map[string]string{"security.nesting": "true"}}
+	err := s.broker.MaintainInstance(environs.StartInstanceParams{
+		InstanceConfig: makeInstanceConfig(c, s, "1/lxd/0"),
+		CharmLXDProfiles: map[string]*lxdprofile.Profile{
+			"juju-foo-a-3": profile,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.manager.CheckCallNames(c, "MaybeWriteLXDProfile", "ReplaceCharmProfiles")
+	hostname, err := s.manager.Namespace().Hostname("1/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.manager.CheckCall(c, 1, "ReplaceCharmProfiles", instance.Id(hostname), []string{"juju-foo-a-3"})
+}
+
+func (s *lxdBrokerSuite) TestMaintainInstanceRemovesCharmProfiles(c *gc.C) {
+	err := s.broker.MaintainInstance(environs.StartInstanceParams{
+		InstanceConfig: makeInstanceConfig(c, s, "1/lxd/0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.manager.CheckCallNames(c, "ReplaceCharmProfiles")
+	c.Assert(s.manager.Calls()[0].Args[1], gc.HasLen, 0)
+}
+
 type fakeContainerManager struct {
 	gitjujutesting.Stub
 }
@@ -172,3 +257,13 @@ func (m *fakeContainerManager) IsInitialized() bool {
 	m.PopNoErr()
 	return true
 }
+
+func (m *fakeContainerManager) MaybeWriteLXDProfile(name string, profile *lxdprofile.Profile) error {
+	m.MethodCall(m, "MaybeWriteLXDProfile", name, profile)
+	return m.NextErr()
+}
+
+func (m *fakeContainerManager) ReplaceCharmProfiles(id instance.Id, names []string) error {
+	m.MethodCall(m, "ReplaceCharmProfiles", id, names)
+	return m.NextErr()
+}
@@ -227,10 +227,25 @@ func (p *environProvisioner) loop() error {
 		return errors.Trace(err)
 	}
 
+	// Charm LXD profiles must be updated on the instances of the LXD
+	// provider when their units' charms are upgraded.
+	var charmChanges watcher.NotifyChannel
+	if modelConfig.Type() == "lxd" {
+		charmChanges, err = p.watchApplicationCharms()
+		if err != nil {
+			return loggedErrorStack(errors.Trace(err))
+		}
+	}
+
 	for {
 		select {
 		case <-p.catacomb.Dying():
 			return p.catacomb.ErrDying()
+		case _, ok := <-charmChanges:
+			if !ok {
+				return errors.New("application charm watch closed")
+			}
+			task.MaintainMachines()
 		case _, ok := <-modelConfigChanges:
 			if !ok {
 				return errors.New("model configuration watcher closed")
@@ -247,6 +262,24 @@ func (p *environProvisioner) loop() error {
 	}
 }
 
+// watchApplicationCharms starts a watcher of the charms of the
+// model's applications, and returns its changes channel. Controllers
+// that cannot report charm changes return a nil channel, leaving
+// profiles to be applied only when machines are provisioned.
+func (p *provisioner) watchApplicationCharms() (watcher.NotifyChannel, error) {
+	charmWatcher, err := p.st.WatchApplicationCharms()
+	if errors.IsNotImplemented(err) {
+		logger.Debugf("controller cannot watch application charms: %v", err)
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := p.catacomb.Add(charmWatcher); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return charmWatcher.Changes(), nil
+}
+
 func (p *environProvisioner) getMachineWatcher() (watcher.StringsWatcher, error) {
 	return p.st.WatchModelMachines()
 }
@@ -322,6 +355,16 @@ func (p *containerProvisioner) loop() error {
 		return errors.Trace(err)
 	}
 
+	// Charm LXD profiles must be updated on the containers when
+	// their units' charms are upgraded.
+	var charmChanges watcher.NotifyChannel
+	if p.containerType == instance.LXD {
+		charmChanges, err = p.watchApplicationCharms()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	for {
 		select {
 		case <-p.catacomb.Dying():
@@ -336,6 +379,11 @@ func (p *containerProvisioner) loop() error {
 			}
 			p.configObserver.notify(modelConfig)
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+		case _, ok := <-charmChanges:
+			if !ok {
+				return errors.New("application charm watch closed")
+			}
+			task.MaintainMachines()
 		}
 	}
 }
@@ -16,7 +16,9 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/controller/authentication"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
@@ -42,6 +44,11 @@ type ProvisionerTask interface {
 	// should harvest machines. See config.HarvestMode for
 	// documentation of behavior.
 	SetHarvestMode(mode config.HarvestMode)
+
+	// MaintainMachines requests that the task maintains all of the
+	// provisioned machines it knows about, for example to apply
+	// changed charm LXD profiles to them.
+	MaintainMachines()
 }
 
 type MachineGetter interface {
@@ -93,6 +100,7 @@ func NewProvisionerTask(
 		auth:                       auth,
 		harvestMode:                harvestMode,
 		harvestModeChan:            make(chan config.HarvestMode, 1),
+		maintainChan:               make(chan struct{}, 1),
 		machines:                   make(map[string]*apiprovisioner.Machine),
 		imageStream:                imageStream,
 		secureServerConnection:     secureServerConnection,
@@ -123,6 +131,7 @@ type provisionerTask struct {
 	secureServerConnection     bool
 	harvestMode                config.HarvestMode
 	harvestModeChan            chan config.HarvestMode
+	maintainChan               chan struct{}
 	retryStartInstanceStrategy RetryStrategy
 	// instance id -> instance
 	instances map[instance.Id]instance.Instance
@@ -148,6 +157,9 @@ func (task *provisionerTask) loop() error {
 	// as unknown.
 	var harvestModeChan chan config.HarvestMode
 
+	// Likewise, don't maintain machines until we know about them.
+	var maintainChan chan struct{}
+
 	// When the watcher is started, it will have the initial changes be all
 	// the machines that are relevant. Also, since this is available straight
 	// away, we know there will be some changes right off the bat.
@@ -167,6 +179,7 @@ func (task *provisionerTask) loop() error {
 			// We've seen a set of changes. Enable modification of
 			// harvesting mode.
 			harvestModeChan = task.harvestModeChan
+			maintainChan = task.maintainChan
 		case harvestMode := <-harvestModeChan:
 			if harvestMode == task.harvestMode {
 				break
@@ -179,6 +192,10 @@ func (task *provisionerTask) loop() error {
 					return errors.Annotate(err, "failed to process machines after safe mode disabled")
 				}
 			}
+		case <-maintainChan:
+			if err := task.maintainAllMachines(); err != nil {
+				logger.Errorf("failed to maintain machines: %v", err)
+			}
 		case <-task.retryChanges:
 			if err := task.processMachinesWithTransientErrors(); err != nil {
 				return errors.Annotate(err, "failed to process machines with transient errors")
@@ -195,6 +212,15 @@ func (task *provisionerTask) SetHarvestMode(mode config.HarvestMode) {
 	}
 }
 
+// MaintainMachines implements ProvisionerTask.MaintainMachines().
+func (task *provisionerTask) MaintainMachines() {
+	select {
+	case task.maintainChan <- struct{}{}:
+	default:
+		// A request is already pending.
+	}
+}
+
 func (task *provisionerTask) processMachinesWithTransientErrors() error {
 	machines, statusResults, err := task.machineGetter.MachinesWithTransientErrors()
 	if err != nil {
@@ -619,16 +645,59 @@ func constructStartInstanceParams(
 		SubnetsToZones:    subnetsToZones,
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     possibleImageMetadata,
+		APIAllow:          controller.Config(provisioningInfo.ControllerConfig).APIAllow(),
+		CharmLXDProfiles:  charmLXDProfiles(provisioningInfo.CharmLXDProfiles),
 		StatusCallback:    machine.SetInstanceStatus,
 	}, nil
 }
 
+// maintainAllMachines maintains all known alive machines that have
+// been provisioned, whether or not they are containers, so that the
+// broker can apply changes such as upgraded charm LXD profiles.
+func (task *provisionerTask) maintainAllMachines() error {
+	var maintain []*apiprovisioner.Machine
+	for _, machine := range task.machines {
+		if machine.Life() != params.Alive {
+			continue
+		}
+		if _, err := machine.InstanceId(); params.IsCodeNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "failed to load machine id:%s, details:%v", machine.Id(), machine)
+		}
+		maintain = append(maintain, machine)
+	}
+	return task.maintainMachines(maintain)
+}
+
+// charmLXDProfiles converts the charm LXD profiles in provisioning
+// info into those passed to the broker.
+func charmLXDProfiles(in map[string]params.CharmLXDProfile) map[string]*lxdprofile.Profile {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]*lxdprofile.Profile, len(in))
+	for name, profile := range in {
+		out[name] = &lxdprofile.Profile{
+			Description: profile.Description,
+			Config:      profile.Config,
+			Devices:     profile.Devices,
+		}
+	}
+	return out
+}
+
 func (task *provisionerTask) maintainMachines(machines []*apiprovisioner.Machine) error {
 	for _, m := range machines {
 		logger.Infof("maintainMachines: %v", m)
+		pInfo, err := m.ProvisioningInfo()
+		if err != nil {
+			return errors.Annotatef(err, "cannot get provisioning info for machine %v", m)
+		}
 		startInstanceParams := environs.StartInstanceParams{}
 		startInstanceParams.InstanceConfig = &instancecfg.InstanceConfig{}
 		startInstanceParams.InstanceConfig.MachineId = m.Id()
+		startInstanceParams.CharmLXDProfiles = charmLXDProfiles(pInfo.CharmLXDProfiles)
 		if err := task.broker.MaintainInstance(startInstanceParams); err != nil {
 			return errors.Annotatef(err, "cannot maintain machine %v", m)
 		}
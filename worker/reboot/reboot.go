@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/reboot"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
 )
@@ -75,14 +76,14 @@ func (r *Reboot) Handle(_ <-chan struct{}) error {
 	switch rAction {
 	case params.ShouldReboot:
 		logger.Debugf("acquiring mutex %q for reboot", r.machineLockName)
-		if _, err := mutex.Acquire(spec); err != nil {
+		if _, err := machinelock.Acquire(spec); err != nil {
 			return errors.Trace(err)
 		}
 		logger.Debugf("mutex %q acquired, won't release", r.machineLockName)
 		return worker.ErrRebootMachine
 	case params.ShouldShutdown:
 		logger.Debugf("acquiring mutex %q for shutdown", r.machineLockName)
-		if _, err := mutex.Acquire(spec); err != nil {
+		if _, err := machinelock.Acquire(spec); err != nil {
 			return errors.Trace(err)
 		}
 		logger.Debugf("mutex %q acquired, won't release", r.machineLockName)
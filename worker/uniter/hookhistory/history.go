@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hookhistory keeps a bounded record of the hooks run by a unit
+// and reports it to the controller.
+package hookhistory
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.hookhistory")
+
+// DefaultCapacity is the number of hook executions a History keeps
+// when no other capacity is given.
+const DefaultCapacity = 50
+
+// Reporter records hook executions on the controller.
+type Reporter interface {
+	AddHookHistory(records []params.HookRecord) error
+}
+
+// History is a ring buffer of the hooks most recently run by a unit.
+// Each record added is reported to the controller; records that could
+// not be reported are retried with the next one, for as long as they
+// remain in the buffer. If the controller cannot record hook history,
+// records are only kept in the buffer.
+type History struct {
+	mu         sync.Mutex
+	reporter   Reporter
+	records    []params.HookRecord
+	next       int
+	full       bool
+	unreported int
+}
+
+// New returns a History holding at most capacity records, which reports
+// records to the supplied reporter. If capacity is not positive,
+// DefaultCapacity is used.
+func New(capacity int, reporter Reporter) *History {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &History{
+		reporter: reporter,
+		records:  make([]params.HookRecord, capacity),
+	}
+}
+
+// Add adds the record to the history, evicting the oldest record if the
+// history is full, and reports any unreported records.
+func (h *History) Add(record params.HookRecord) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records[h.next] = record
+	h.next = (h.next + 1) % len(h.records)
+	if h.next == 0 {
+		h.full = true
+	}
+	if h.unreported < len(h.records) {
+		h.unreported++
+	}
+	if h.reporter == nil {
+		return
+	}
+	records := h.lastRecords(h.unreported)
+	if err := h.reporter.AddHookHistory(records); errors.IsNotImplemented(err) {
+		logger.Debugf("controller cannot record hook history, not reporting it")
+		h.reporter = nil
+		return
+	} else if err != nil {
+		logger.Warningf("cannot report hook history: %v", err)
+		return
+	}
+	h.unreported = 0
+}
+
+// Records returns the records in the history, oldest first.
+func (h *History) Records() []params.HookRecord {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	count := h.next
+	if h.full {
+		count = len(h.records)
+	}
+	return h.lastRecords(count)
+}
+
+// lastRecords returns the last count records added, oldest first. It
+// must be called with the mutex held.
+func (h *History) lastRecords(count int) []params.HookRecord {
+	result := make([]params.HookRecord, count)
+	start := h.next - count
+	if start < 0 {
+		start += len(h.records)
+	}
+	for i := range result {
+		result[i] = h.records[(start+i)%len(h.records)]
+	}
+	return result
+}
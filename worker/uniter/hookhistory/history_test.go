@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hookhistory_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/hookhistory"
+)
+
+type HistorySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&HistorySuite{})
+
+func hookRecords(hooks ...string) []params.HookRecord {
+	records := make([]params.HookRecord, len(hooks))
+	for i, hook := range hooks {
+		records[i] = params.HookRecord{Hook: hook, RelationId: -1}
+	}
+	return records
+}
+
+func (s *HistorySuite) TestRecordsEmpty(c *gc.C) {
+	history := hookhistory.New(3, nil)
+	c.Assert(history.Records(), gc.HasLen, 0)
+}
+
+func (s *HistorySuite) TestRecordsEvictsOldest(c *gc.C) {
+	history := hookhistory.New(3, nil)
+	for _, record := range hookRecords("install", "config-changed", "start", "update-status") {
+		history.Add(record)
+	}
+	c.Assert(history.Records(), jc.DeepEquals, hookRecords("config-changed", "start", "update-status"))
+}
+
+func (s *HistorySuite) TestAddReports(c *gc.C) {
+	reporter := &fakeReporter{}
+	history := hookhistory.New(3, reporter)
+	history.Add(hookRecords("install")[0])
+	history.Add(hookRecords("start")[0])
+	c.Assert(reporter.reported, jc.DeepEquals, [][]params.HookRecord{
+		hookRecords("install"),
+		hookRecords("start"),
+	})
+}
+
+func (s *HistorySuite) TestAddRetriesUnreported(c *gc.C) {
+	reporter := &fakeReporter{err: errors.New("boom")}
+	history := hookhistory.New(3, reporter)
+	for _, record := range hookRecords("install", "config-changed", "start", "update-status") {
+		history.Add(record)
+	}
+	reporter.err = nil
+	history.Add(hookRecords("stop")[0])
+	// Only the records still held by the history are reported.
+	c.Assert(reporter.reported[len(reporter.reported)-1], jc.DeepEquals,
+		hookRecords("start", "update-status", "stop"))
+
+	history.Add(hookRecords("remove")[0])
+	c.Assert(reporter.reported[len(reporter.reported)-1], jc.DeepEquals, hookRecords("remove"))
+}
+
+func (s *HistorySuite) TestAddStopsReportingIfNotImplemented(c *gc.C) {
+	reporter := &fakeReporter{err: errors.NotImplementedf("AddHookHistory")}
+	history := hookhistory.New(3, reporter)
+	history.Add(hookRecords("install")[0])
+	history.Add(hookRecords("start")[0])
+	c.Assert(reporter.reported, gc.HasLen, 1)
+	c.Assert(history.Records(), jc.DeepEquals, hookRecords("install", "start"))
+}
+
+type fakeReporter struct {
+	reported [][]params.HookRecord
+	err      error
+}
+
+func (r *fakeReporter) AddHookHistory(records []params.HookRecord) error {
+	r.reported = append(r.reported, records)
+	return r.err
+}
@@ -99,6 +99,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				HookRetryStrategy:    hookRetryStrategy,
 				NewOperationExecutor: operation.NewExecutor,
 				Clock:                manifoldConfig.Clock,
+				UpdateAgentService:   NewUpdateAgentServiceFunc(agentConfig),
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
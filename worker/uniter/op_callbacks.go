@@ -62,6 +62,16 @@ func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
 		return opc.u.relations.CommitHook(hi)
 	case hi.Kind.IsStorage():
 		return opc.u.storage.CommitHook(hi)
+	case hi.Kind == hook.SecretRotate:
+		err := opc.u.st.SecretRotated(hi.SecretId)
+		if params.IsCodeUnauthorized(err) {
+			// The unit is no longer responsible for rotating the
+			// secret, probably because it lost leadership while
+			// the hook was running.
+			logger.Warningf("cannot record rotation of secret %q: %v", hi.SecretId, err)
+			return nil
+		}
+		return errors.Trace(err)
 	}
 	return nil
 }
@@ -619,6 +619,7 @@ func (s *DeploySuite) testDoesNotNeedGlobalMachineLock(c *gc.C, newDeploy newDep
 	op, err := newDeploy(factory, curl("cs:quantal/x-0"))
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(op.NeedsGlobalMachineLock(), jc.IsFalse)
+	c.Assert(op.NeedsUnitLock(), jc.IsFalse)
 }
 
 func (s *DeploySuite) TestDoesNotNeedGlobalMachineLock_Install(c *gc.C) {
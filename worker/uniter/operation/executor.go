@@ -9,6 +9,8 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/mutex"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/worker/uniter/hook"
 )
 
 type executorStep struct {
@@ -30,17 +32,52 @@ type executor struct {
 	file               *StateFile
 	state              *State
 	acquireMachineLock func() (mutex.Releaser, error)
+	acquireUnitLock    func() (mutex.Releaser, error)
+}
+
+// ExecutorConfig holds the configuration for a new Executor.
+type ExecutorConfig struct {
+	// StateFilePath is the path of the file holding the uniter's
+	// operation state.
+	StateFilePath string
+
+	// UnitState, if set, keeps the operation state on the controller,
+	// so that the file at StateFilePath is only a local cache. The
+	// state is sent to the controller once each operation is committed.
+	UnitState UnitStateReadWriter
+
+	// GetInstallCharm returns the charm to install if no state has
+	// been recorded yet.
+	GetInstallCharm func() (*corecharm.URL, error)
+
+	// GetCurrentCharm returns the charm the unit is running. It is
+	// used to deploy the charm again when the state is restored from
+	// the controller, and is only needed if UnitState is set.
+	GetCurrentCharm func() (*corecharm.URL, error)
+
+	// AcquireMachineLock acquires the global machine lock exclusively,
+	// for operations which need it.
+	AcquireMachineLock func() (mutex.Releaser, error)
+
+	// AcquireUnitLock acquires the global machine lock shared with
+	// other units, for hooks and actions which do not need it
+	// exclusively. It must still exclude holders of the exclusive lock.
+	// Other operations hold no lock.
+	AcquireUnitLock func() (mutex.Releaser, error)
 }
 
 // NewExecutor returns an Executor which takes its starting state from the
-// supplied path, and records state changes there. If no state file exists,
-// the executor's starting state will include a queued Install hook, for
-// the charm identified by the supplied func.
-func NewExecutor(stateFilePath string, getInstallCharm func() (*corecharm.URL, error), acquireLock func() (mutex.Releaser, error)) (Executor, error) {
-	file := NewStateFile(stateFilePath)
+// configured state file, and records state changes there. If no state has
+// been recorded, the executor's starting state will include a queued
+// Install hook, for the charm identified by GetInstallCharm.
+func NewExecutor(config ExecutorConfig) (Executor, error) {
+	file := NewStateFile(config.StateFilePath)
+	if config.UnitState != nil {
+		file = NewControllerStateFile(config.StateFilePath, config.UnitState)
+	}
 	state, err := file.Read()
 	if err == ErrNoStateFile {
-		charmURL, err := getInstallCharm()
+		charmURL, err := config.GetInstallCharm()
 		if err != nil {
 			return nil, err
 		}
@@ -51,11 +88,22 @@ func NewExecutor(stateFilePath string, getInstallCharm func() (*corecharm.URL, e
 		}
 	} else if err != nil {
 		return nil, err
+	} else if file.restored {
+		if state, err = redeployState(*state, config.GetCurrentCharm); err != nil {
+			return nil, errors.Annotate(err, "cannot redeploy charm")
+		}
+		if err := file.Write(state); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := file.Sync(); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 	return &executor{
 		file:               file,
 		state:              state,
-		acquireMachineLock: acquireLock,
+		acquireMachineLock: config.AcquireMachineLock,
+		acquireUnitLock:    config.AcquireUnitLock,
 	}, nil
 }
 
@@ -64,12 +112,56 @@ func (x *executor) State() State {
 	return *x.state
 }
 
+// redeployState returns the state in which the uniter deploys its charm
+// again, given state restored from the controller. The charm directory
+// and the deployer's own state are lost along with the machine the state
+// was written on, so the charm must be deployed before any hook can run.
+// A hook that was running is run again once the charm is deployed, as it
+// would be after an upgrade; otherwise the charm is deployed as an upgrade,
+// and the charm's upgrade-charm hook runs.
+func redeployState(st State, getCurrentCharm func() (*corecharm.URL, error)) (*State, error) {
+	switch st.Kind {
+	case Install, Upgrade:
+		// The operation deploys its charm when run from the start.
+		st.Step = Queued
+		return &st, nil
+	}
+	charmURL, err := getCurrentCharm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Infof("deploying charm %q again after restoring state", charmURL)
+	if !st.Installed {
+		return stateChange{
+			Kind:     Install,
+			Step:     Queued,
+			CharmURL: charmURL,
+		}.apply(st), nil
+	}
+	var interruptedHook *hook.Info
+	if st.Kind == RunHook {
+		interruptedHook = st.Hook
+	}
+	return stateChange{
+		Kind:     Upgrade,
+		Step:     Queued,
+		CharmURL: charmURL,
+		Hook:     interruptedHook,
+	}.apply(st), nil
+}
+
 // Run is part of the Executor interface.
 func (x *executor) Run(op Operation) (runErr error) {
 	logger.Debugf("running operation %v", op)
 
+	var acquireLock func() (mutex.Releaser, error)
 	if op.NeedsGlobalMachineLock() {
-		releaser, err := x.acquireMachineLock()
+		acquireLock = x.acquireMachineLock
+	} else if op.NeedsUnitLock() {
+		acquireLock = x.acquireUnitLock
+	}
+	if acquireLock != nil {
+		releaser, err := acquireLock()
 		if err != nil {
 			return errors.Annotate(err, "could not acquire lock")
 		}
@@ -86,13 +178,19 @@ func (x *executor) Run(op Operation) (runErr error) {
 	default:
 		return err
 	}
-	return x.do(op, stepCommit)
+	if err := x.do(op, stepCommit); err != nil {
+		return err
+	}
+	return x.syncState()
 }
 
 // Skip is part of the Executor interface.
 func (x *executor) Skip(op Operation) error {
 	logger.Debugf("skipping operation %v", op)
-	return x.do(op, stepCommit)
+	if err := x.do(op, stepCommit); err != nil {
+		return err
+	}
+	return x.syncState()
 }
 
 func (x *executor) do(op Operation, step executorStep) (err error) {
@@ -120,3 +218,9 @@ func (x *executor) writeState(newState State) error {
 	x.state = &newState
 	return nil
 }
+
+// syncState sends the state recorded by a committed operation to the
+// controller, if the executor keeps its state there.
+func (x *executor) syncState() error {
+	return errors.Annotatef(x.file.Sync(), "syncing state")
+}
@@ -4,17 +4,23 @@
 package operation_test
 
 import (
+	"fmt"
+	"math/rand"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/mutex"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	ft "github.com/juju/testing/filetesting"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/core/machinelock"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 )
@@ -34,6 +40,14 @@ func failAcquireLock() (mutex.Releaser, error) {
 	return nil, errors.New("wat")
 }
 
+type noopReleaser struct{}
+
+func (noopReleaser) Release() {}
+
+func noopAcquireLock() (mutex.Releaser, error) {
+	return noopReleaser{}, nil
+}
+
 func (s *NewExecutorSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 	s.basePath = c.MkDir()
@@ -44,14 +58,24 @@ func (s *NewExecutorSuite) path(path string) string {
 }
 
 func (s *NewExecutorSuite) TestNewExecutorNoFileNoCharm(c *gc.C) {
-	executor, err := operation.NewExecutor(s.path("missing"), failGetInstallCharm, failAcquireLock)
+	executor, err := operation.NewExecutor(operation.ExecutorConfig{
+		StateFilePath:      s.path("missing"),
+		GetInstallCharm:    failGetInstallCharm,
+		AcquireMachineLock: failAcquireLock,
+		AcquireUnitLock:    failAcquireLock,
+	})
 	c.Assert(executor, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, "lol!")
 }
 
 func (s *NewExecutorSuite) TestNewExecutorInvalidFile(c *gc.C) {
 	ft.File{"existing", "", 0666}.Create(c, s.basePath)
-	executor, err := operation.NewExecutor(s.path("existing"), failGetInstallCharm, failAcquireLock)
+	executor, err := operation.NewExecutor(operation.ExecutorConfig{
+		StateFilePath:      s.path("existing"),
+		GetInstallCharm:    failGetInstallCharm,
+		AcquireMachineLock: failAcquireLock,
+		AcquireUnitLock:    failAcquireLock,
+	})
 	c.Assert(executor, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, `cannot read ".*": invalid operation state: .*`)
 }
@@ -61,7 +85,12 @@ func (s *NewExecutorSuite) TestNewExecutorNoFile(c *gc.C) {
 	getInstallCharm := func() (*corecharm.URL, error) {
 		return charmURL, nil
 	}
-	executor, err := operation.NewExecutor(s.path("missing"), getInstallCharm, failAcquireLock)
+	executor, err := operation.NewExecutor(operation.ExecutorConfig{
+		StateFilePath:      s.path("missing"),
+		GetInstallCharm:    getInstallCharm,
+		AcquireMachineLock: failAcquireLock,
+		AcquireUnitLock:    failAcquireLock,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(executor.State(), gc.DeepEquals, operation.State{
 		Kind:     operation.Install,
@@ -81,7 +110,12 @@ started: true
 op: continue
 opstep: pending
 `[1:], 0666}.Create(c, s.basePath)
-	executor, err := operation.NewExecutor(s.path("existing"), failGetInstallCharm, failAcquireLock)
+	executor, err := operation.NewExecutor(operation.ExecutorConfig{
+		StateFilePath:      s.path("existing"),
+		GetInstallCharm:    failGetInstallCharm,
+		AcquireMachineLock: failAcquireLock,
+		AcquireUnitLock:    failAcquireLock,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(executor.State(), gc.DeepEquals, operation.State{
 		Kind:    operation.Continue,
@@ -90,6 +124,99 @@ opstep: pending
 	})
 }
 
+// newRestoringExecutor returns an executor whose state is restored from
+// the controller, which holds the supplied state.
+func (s *NewExecutorSuite) newRestoringExecutor(c *gc.C, st operation.State) (operation.Executor, *fakeUnitState) {
+	unitState := &fakeUnitState{}
+	file := operation.NewControllerStateFile(filepath.Join(c.MkDir(), "lost"), unitState)
+	err := file.Write(&st)
+	c.Assert(err, jc.ErrorIsNil)
+	err = file.Sync()
+	c.Assert(err, jc.ErrorIsNil)
+
+	getCurrentCharm := func() (*corecharm.URL, error) {
+		return corecharm.MustParseURL("cs:quantal/nyancat-324"), nil
+	}
+	executor, err := operation.NewExecutor(operation.ExecutorConfig{
+		StateFilePath:      s.path("missing"),
+		UnitState:          unitState,
+		GetInstallCharm:    failGetInstallCharm,
+		GetCurrentCharm:    getCurrentCharm,
+		AcquireMachineLock: failAcquireLock,
+		AcquireUnitLock:    failAcquireLock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return executor, unitState
+}
+
+func (s *NewExecutorSuite) TestNewExecutorRestoredRedeploysCharm(c *gc.C) {
+	executor, unitState := s.newRestoringExecutor(c, operation.State{
+		Kind:      operation.Continue,
+		Step:      operation.Pending,
+		Installed: true,
+		Started:   true,
+		Hook:      &hook.Info{Kind: hooks.ConfigChanged},
+	})
+	expect := operation.State{
+		Kind:      operation.Upgrade,
+		Step:      operation.Queued,
+		Installed: true,
+		Started:   true,
+		CharmURL:  corecharm.MustParseURL("cs:quantal/nyancat-324"),
+	}
+	c.Assert(executor.State(), gc.DeepEquals, expect)
+
+	// The redeploy is recorded locally and on the controller, so it is
+	// not lost if the uniter restarts before running it.
+	assertWroteState(c, s.path("missing"), expect)
+	restored, err := operation.NewControllerStateFile(filepath.Join(c.MkDir(), "lost"), unitState).Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*restored, gc.DeepEquals, expect)
+}
+
+func (s *NewExecutorSuite) TestNewExecutorRestoredRedeploysCharmInterruptedHook(c *gc.C) {
+	executor, _ := s.newRestoringExecutor(c, operation.State{
+		Kind:      operation.RunHook,
+		Step:      operation.Pending,
+		Installed: true,
+		Hook:      relhook,
+	})
+	c.Assert(executor.State(), gc.DeepEquals, operation.State{
+		Kind:      operation.Upgrade,
+		Step:      operation.Queued,
+		Installed: true,
+		CharmURL:  corecharm.MustParseURL("cs:quantal/nyancat-324"),
+		Hook:      relhook,
+	})
+}
+
+func (s *NewExecutorSuite) TestNewExecutorRestoredRedeploysCharmBeforeInstallHook(c *gc.C) {
+	executor, _ := s.newRestoringExecutor(c, operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Queued,
+		Hook: &hook.Info{Kind: hooks.Install},
+	})
+	c.Assert(executor.State(), gc.DeepEquals, operation.State{
+		Kind:     operation.Install,
+		Step:     operation.Queued,
+		CharmURL: corecharm.MustParseURL("cs:quantal/nyancat-324"),
+	})
+}
+
+func (s *NewExecutorSuite) TestNewExecutorRestoredDeployRunsAgain(c *gc.C) {
+	charmURL := corecharm.MustParseURL("cs:quantal/nyancat-323")
+	executor, _ := s.newRestoringExecutor(c, operation.State{
+		Kind:     operation.Install,
+		Step:     operation.Done,
+		CharmURL: charmURL,
+	})
+	c.Assert(executor.State(), gc.DeepEquals, operation.State{
+		Kind:     operation.Install,
+		Step:     operation.Queued,
+		CharmURL: charmURL,
+	})
+}
+
 type ExecutorSuite struct {
 	testing.IsolationSuite
 }
@@ -106,7 +233,12 @@ func newExecutor(c *gc.C, st *operation.State) (operation.Executor, string) {
 	path := filepath.Join(c.MkDir(), "state")
 	err := operation.NewStateFile(path).Write(st)
 	c.Assert(err, jc.ErrorIsNil)
-	executor, err := operation.NewExecutor(path, failGetInstallCharm, failAcquireLock)
+	executor, err := operation.NewExecutor(operation.ExecutorConfig{
+		StateFilePath:      path,
+		GetInstallCharm:    failGetInstallCharm,
+		AcquireMachineLock: failAcquireLock,
+		AcquireUnitLock:    noopAcquireLock,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 	return executor, path
 }
@@ -169,6 +301,47 @@ func (s *ExecutorSuite) TestSucceedWithStateChanges(c *gc.C) {
 	c.Assert(executor.State(), gc.DeepEquals, *op.commit.newState)
 }
 
+func (s *ExecutorSuite) TestSyncsControllerStateOnCommit(c *gc.C) {
+	initialState := justInstalledState()
+	path := filepath.Join(c.MkDir(), "state")
+	err := operation.NewStateFile(path).Write(&initialState)
+	c.Assert(err, jc.ErrorIsNil)
+	unitState := &fakeUnitState{}
+	executor, err := operation.NewExecutor(operation.ExecutorConfig{
+		StateFilePath:      path,
+		UnitState:          unitState,
+		GetInstallCharm:    failGetInstallCharm,
+		AcquireMachineLock: failAcquireLock,
+		AcquireUnitLock:    noopAcquireLock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	op := &mockOperation{
+		prepare: newStep(&operation.State{
+			Kind: operation.RunHook,
+			Step: operation.Pending,
+			Hook: &hook.Info{Kind: hooks.ConfigChanged},
+		}, nil),
+		execute: newStep(&operation.State{
+			Kind: operation.RunHook,
+			Step: operation.Done,
+			Hook: &hook.Info{Kind: hooks.ConfigChanged},
+		}, nil),
+		commit: newStep(&operation.State{
+			Kind: operation.Continue,
+			Step: operation.Pending,
+		}, nil),
+	}
+	err = executor.Run(op)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the committed state is sent to the controller.
+	c.Assert(unitState.setCalls, gc.Equals, 1)
+	restored, err := operation.NewControllerStateFile(filepath.Join(c.MkDir(), "lost"), unitState).Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*restored, gc.DeepEquals, *op.commit.newState)
+}
+
 func (s *ExecutorSuite) TestErrSkipExecute(c *gc.C) {
 	initialState := justInstalledState()
 	executor, statePath := newExecutor(c, &initialState)
@@ -326,12 +499,17 @@ func (s *ExecutorSuite) TestFailCommitWithStateChange(c *gc.C) {
 	c.Assert(executor.State(), gc.DeepEquals, *op.commit.newState)
 }
 
-func (s *ExecutorSuite) initLockTest(c *gc.C, lockFunc func() (mutex.Releaser, error)) operation.Executor {
+func (s *ExecutorSuite) initLockTest(c *gc.C, machineLockFunc, unitLockFunc func() (mutex.Releaser, error)) operation.Executor {
 	initialState := justInstalledState()
 	statePath := filepath.Join(c.MkDir(), "state")
 	err := operation.NewStateFile(statePath).Write(&initialState)
 	c.Assert(err, jc.ErrorIsNil)
-	executor, err := operation.NewExecutor(statePath, failGetInstallCharm, lockFunc)
+	executor, err := operation.NewExecutor(operation.ExecutorConfig{
+		StateFilePath:      statePath,
+		GetInstallCharm:    failGetInstallCharm,
+		AcquireMachineLock: machineLockFunc,
+		AcquireUnitLock:    unitLockFunc,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	return executor
@@ -347,7 +525,31 @@ func (s *ExecutorSuite) TestLockSucceedsStepsCalled(c *gc.C) {
 
 	mockLock := &mockLockFunc{op: op}
 	lockFunc := mockLock.newSucceedingLock()
-	executor := s.initLockTest(c, lockFunc)
+	executor := s.initLockTest(c, lockFunc, failAcquireLock)
+
+	err := executor.Run(op)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(mockLock.calledLock, jc.IsTrue)
+	c.Assert(mockLock.calledUnlock, jc.IsTrue)
+	c.Assert(mockLock.noStepsCalledOnLock, jc.IsTrue)
+
+	expectedStepsOnUnlock := []bool{true, true, true}
+	c.Assert(mockLock.stepsCalledOnUnlock, gc.DeepEquals, expectedStepsOnUnlock)
+}
+
+func (s *ExecutorSuite) TestUnitLockWithoutMachineLock(c *gc.C) {
+	op := &mockOperation{
+		needsLock:     false,
+		needsUnitLock: true,
+		prepare:       newStep(nil, nil),
+		execute:       newStep(nil, nil),
+		commit:        newStep(nil, nil),
+	}
+
+	mockLock := &mockLockFunc{op: op}
+	lockFunc := mockLock.newSucceedingLock()
+	executor := s.initLockTest(c, failAcquireLock, lockFunc)
 
 	err := executor.Run(op)
 	c.Assert(err, jc.ErrorIsNil)
@@ -360,6 +562,22 @@ func (s *ExecutorSuite) TestLockSucceedsStepsCalled(c *gc.C) {
 	c.Assert(mockLock.stepsCalledOnUnlock, gc.DeepEquals, expectedStepsOnUnlock)
 }
 
+func (s *ExecutorSuite) TestNoLockWithoutMachineOrUnitLock(c *gc.C) {
+	op := &mockOperation{
+		prepare: newStep(nil, nil),
+		execute: newStep(nil, nil),
+		commit:  newStep(nil, nil),
+	}
+	executor := s.initLockTest(c, failAcquireLock, failAcquireLock)
+
+	err := executor.Run(op)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(op.prepare.called, jc.IsTrue)
+	c.Assert(op.execute.called, jc.IsTrue)
+	c.Assert(op.commit.called, jc.IsTrue)
+}
+
 func (s *ExecutorSuite) TestLockFailsOpsStepsNotCalled(c *gc.C) {
 	op := &mockOperation{
 		needsLock: true,
@@ -370,7 +588,7 @@ func (s *ExecutorSuite) TestLockFailsOpsStepsNotCalled(c *gc.C) {
 
 	mockLock := &mockLockFunc{op: op}
 	lockFunc := mockLock.newFailingLock()
-	executor := s.initLockTest(c, lockFunc)
+	executor := s.initLockTest(c, lockFunc, failAcquireLock)
 
 	err := executor.Run(op)
 	c.Assert(err, gc.ErrorMatches, "could not acquire lock: wat")
@@ -387,7 +605,7 @@ func (s *ExecutorSuite) TestLockFailsOpsStepsNotCalled(c *gc.C) {
 func (s *ExecutorSuite) testLockUnlocksOnError(c *gc.C, op *mockOperation) (error, *mockLockFunc) {
 	mockLock := &mockLockFunc{op: op}
 	lockFunc := mockLock.newSucceedingLock()
-	executor := s.initLockTest(c, lockFunc)
+	executor := s.initLockTest(c, lockFunc, failAcquireLock)
 
 	err := executor.Run(op)
 
@@ -502,10 +720,11 @@ func (step *mockStep) run(state operation.State) (*operation.State, error) {
 }
 
 type mockOperation struct {
-	needsLock bool
-	prepare   *mockStep
-	execute   *mockStep
-	commit    *mockStep
+	needsLock     bool
+	needsUnitLock bool
+	prepare       *mockStep
+	execute       *mockStep
+	commit        *mockStep
 }
 
 func (op *mockOperation) String() string {
@@ -516,6 +735,10 @@ func (op *mockOperation) NeedsGlobalMachineLock() bool {
 	return op.needsLock
 }
 
+func (op *mockOperation) NeedsUnitLock() bool {
+	return op.needsUnitLock
+}
+
 func (op *mockOperation) Prepare(state operation.State) (*operation.State, error) {
 	return op.prepare.run(state)
 }
@@ -527,3 +750,147 @@ func (op *mockOperation) Execute(state operation.State) (*operation.State, error
 func (op *mockOperation) Commit(state operation.State) (*operation.State, error) {
 	return op.commit.run(state)
 }
+
+type MachineLockSuite struct {
+	testing.IsolationSuite
+	spec mutex.Spec
+}
+
+var _ = gc.Suite(&MachineLockSuite{})
+
+func (s *MachineLockSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.spec = mutex.Spec{
+		Name:  fmt.Sprintf("executor-test-%d", rand.Int63()),
+		Clock: clock.WallClock,
+		Delay: 10 * time.Millisecond,
+	}
+}
+
+// newUnitExecutor returns an executor for the named unit that takes the
+// machine lock exclusively or shared, as the uniter does.
+func (s *MachineLockSuite) newUnitExecutor(c *gc.C, unitName string) operation.Executor {
+	initialState := justInstalledState()
+	statePath := filepath.Join(c.MkDir(), "state")
+	err := operation.NewStateFile(statePath).Write(&initialState)
+	c.Assert(err, jc.ErrorIsNil)
+	executor, err := operation.NewExecutor(operation.ExecutorConfig{
+		StateFilePath:   statePath,
+		GetInstallCharm: failGetInstallCharm,
+		AcquireMachineLock: func() (mutex.Releaser, error) {
+			return machinelock.Acquire(s.spec)
+		},
+		AcquireUnitLock: func() (mutex.Releaser, error) {
+			return machinelock.AcquireShared(s.spec, unitName)
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return executor
+}
+
+// blockingOperation is a hook operation which reports when it starts
+// executing, and does not finish until it is released.
+type blockingOperation struct {
+	mockOperation
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingOperation(needsLock bool) *blockingOperation {
+	return &blockingOperation{
+		mockOperation: mockOperation{
+			needsLock:     needsLock,
+			needsUnitLock: !needsLock,
+			prepare:       newStep(nil, nil),
+			commit:        newStep(nil, nil),
+		},
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (op *blockingOperation) Execute(state operation.State) (*operation.State, error) {
+	close(op.started)
+	<-op.release
+	return nil, nil
+}
+
+func runInBackground(executor operation.Executor, op operation.Operation) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- executor.Run(op)
+	}()
+	return done
+}
+
+func assertStarted(c *gc.C, op *blockingOperation) {
+	select {
+	case <-op.started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for operation to start")
+	}
+}
+
+func assertNotStarted(c *gc.C, op *blockingOperation) {
+	select {
+	case <-op.started:
+		c.Fatalf("operation started while lock was held")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func assertFinished(c *gc.C, done <-chan error) {
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for operation to finish")
+	}
+}
+
+func (s *MachineLockSuite) TestUnitHooksRunTogether(c *gc.C) {
+	mysql := newBlockingOperation(false)
+	mysqlDone := runInBackground(s.newUnitExecutor(c, "mysql/0"), mysql)
+	assertStarted(c, mysql)
+
+	wordpress := newBlockingOperation(false)
+	wordpressDone := runInBackground(s.newUnitExecutor(c, "wordpress/0"), wordpress)
+	assertStarted(c, wordpress)
+
+	close(mysql.release)
+	close(wordpress.release)
+	assertFinished(c, mysqlDone)
+	assertFinished(c, wordpressDone)
+}
+
+func (s *MachineLockSuite) TestMachineHookWaitsForUnitHook(c *gc.C) {
+	mysql := newBlockingOperation(false)
+	mysqlDone := runInBackground(s.newUnitExecutor(c, "mysql/0"), mysql)
+	assertStarted(c, mysql)
+
+	wordpress := newBlockingOperation(true)
+	wordpressDone := runInBackground(s.newUnitExecutor(c, "wordpress/0"), wordpress)
+	assertNotStarted(c, wordpress)
+
+	close(mysql.release)
+	assertFinished(c, mysqlDone)
+	assertStarted(c, wordpress)
+	close(wordpress.release)
+	assertFinished(c, wordpressDone)
+}
+
+func (s *MachineLockSuite) TestUnitHookWaitsForMachineHook(c *gc.C) {
+	wordpress := newBlockingOperation(true)
+	wordpressDone := runInBackground(s.newUnitExecutor(c, "wordpress/0"), wordpress)
+	assertStarted(c, wordpress)
+
+	mysql := newBlockingOperation(false)
+	mysqlDone := runInBackground(s.newUnitExecutor(c, "mysql/0"), mysql)
+	assertNotStarted(c, mysql)
+
+	close(wordpress.release)
+	assertFinished(c, wordpressDone)
+	assertStarted(c, mysql)
+	close(mysql.release)
+	assertFinished(c, mysqlDone)
+}
@@ -20,11 +20,25 @@ type FactoryParams struct {
 	Callbacks      Callbacks
 	Abort          <-chan struct{}
 	MetricSpoolDir string
+
+	// LockPolicy decides which hooks and actions need the machine
+	// lock. If it is nil, they all do.
+	LockPolicy LockPolicy
+
+	// HookRecorder records each hook run. If it is nil, hook runs are
+	// not recorded.
+	HookRecorder HookRecorder
 }
 
 // NewFactory returns a Factory that creates Operations backed by the supplied
 // parameters.
 func NewFactory(params FactoryParams) Factory {
+	if params.LockPolicy == nil {
+		params.LockPolicy = machineLockPolicy{}
+	}
+	if params.HookRecorder == nil {
+		params.HookRecorder = nopHookRecorder{}
+	}
 	return &factory{
 		config: params,
 	}
@@ -81,6 +95,8 @@ func (f *factory) NewRunHook(hookInfo hook.Info) (Operation, error) {
 		info:          hookInfo,
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
+		lockPolicy:    f.config.LockPolicy,
+		recorder:      f.config.HookRecorder,
 	}, nil
 }
 
@@ -93,6 +109,28 @@ func (f *factory) NewSkipHook(hookInfo hook.Info) (Operation, error) {
 	return &skipOperation{hookOp}, nil
 }
 
+// NewReplayHooks is part of the Factory interface.
+func (f *factory) NewReplayHooks() (Operation, error) {
+	return &replayHooks{}, nil
+}
+
+// NewReplayHook is part of the Factory interface.
+func (f *factory) NewReplayHook(hookInfo hook.Info) (Operation, error) {
+	hookOp, err := f.NewRunHook(hookInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &replayHook{hookOp, hookInfo}, nil
+}
+
+// NewDiscardReplayHook is part of the Factory interface.
+func (f *factory) NewDiscardReplayHook(hookInfo hook.Info) (Operation, error) {
+	if err := hookInfo.Validate(); err != nil {
+		return nil, err
+	}
+	return &discardReplayHook{info: hookInfo}, nil
+}
+
 // NewAction is part of the Factory interface.
 func (f *factory) NewAction(actionId string) (Operation, error) {
 	if !names.IsValidAction(actionId) {
@@ -102,6 +140,7 @@ func (f *factory) NewAction(actionId string) (Operation, error) {
 		actionId:      actionId,
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
+		lockPolicy:    f.config.LockPolicy,
 	}, nil
 }
 
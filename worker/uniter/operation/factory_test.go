@@ -199,6 +199,36 @@ func (s *FactorySuite) TestNewHookString_Skip(c *gc.C) {
 	c.Check(op.String(), gc.Equals, "skip run relation-joined (123; foo/22) hook")
 }
 
+func (s *FactorySuite) TestNewHookError_Replay(c *gc.C) {
+	s.testNewHookError(c, (operation.Factory).NewReplayHook)
+}
+
+func (s *FactorySuite) TestNewHookError_DiscardReplay(c *gc.C) {
+	s.testNewHookError(c, (operation.Factory).NewDiscardReplayHook)
+}
+
+func (s *FactorySuite) TestNewHookString_Replay(c *gc.C) {
+	op, err := s.factory.NewReplayHook(hook.Info{
+		Kind:       hooks.RelationChanged,
+		RemoteUnit: "foo/22",
+		RelationId: 123,
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(op.String(), gc.Equals, "replay relation-changed (123; foo/22) hook")
+}
+
+func (s *FactorySuite) TestNewHookString_DiscardReplay(c *gc.C) {
+	op, err := s.factory.NewDiscardReplayHook(hook.Info{Kind: hooks.ConfigChanged})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(op.String(), gc.Equals, "discard config-changed hook queued for replay")
+}
+
+func (s *FactorySuite) TestNewReplayHooksString(c *gc.C) {
+	op, err := s.factory.NewReplayHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "queue skipped hooks for replay")
+}
+
 func (s *FactorySuite) TestNewAcceptLeadershipString(c *gc.C) {
 	op, err := s.factory.NewAcceptLeadership()
 	c.Assert(err, jc.ErrorIsNil)
@@ -9,6 +9,7 @@ import (
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -28,6 +29,11 @@ type Operation interface {
 	// NeedsGlobalMachineLock returns a bool expressing whether we need to lock the machine.
 	NeedsGlobalMachineLock() bool
 
+	// NeedsUnitLock returns a bool expressing whether, when it does not
+	// need to lock the machine, the operation must still hold the unit's
+	// share of the machine lock.
+	NeedsUnitLock() bool
+
 	// Prepare ensures that the operation is valid and ready to be executed.
 	// If it returns a non-nil state, that state will be validated and recorded.
 	// If it returns ErrSkipExecute, it indicates that the operation can be
@@ -87,6 +93,20 @@ type Factory interface {
 	// completed successfully, without executing the hook.
 	NewSkipHook(hookInfo hook.Info) (Operation, error)
 
+	// NewReplayHooks creates an operation to queue the hooks that were
+	// skipped, when hook errors were resolved without retrying the
+	// hooks, to be run again.
+	NewReplayHooks() (Operation, error)
+
+	// NewReplayHook creates an operation to execute the supplied hook,
+	// which must be the next hook queued for replay.
+	NewReplayHook(hookInfo hook.Info) (Operation, error)
+
+	// NewDiscardReplayHook creates an operation to remove the supplied
+	// hook, which must be the next hook queued for replay, from the
+	// queue without executing it.
+	NewDiscardReplayHook(hookInfo hook.Info) (Operation, error)
+
 	// NewAction creates an operation to execute the supplied action.
 	NewAction(actionId string) (Operation, error)
 
@@ -163,3 +183,17 @@ type StorageUpdater interface {
 	// with the specified tags.
 	UpdateStorage([]names.StorageTag) error
 }
+
+// HookRecorder is an interface used for recording the hooks run by the
+// unit.
+type HookRecorder interface {
+	// Add records a single hook run.
+	Add(record params.HookRecord)
+}
+
+// nopHookRecorder is the HookRecorder used when none is supplied; it
+// discards every record.
+type nopHookRecorder struct{}
+
+// Add is part of the HookRecorder interface.
+func (nopHookRecorder) Add(params.HookRecord) {}
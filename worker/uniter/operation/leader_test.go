@@ -117,6 +117,7 @@ func (s *LeaderSuite) TestAcceptLeadership_DoesNotNeedGlobalMachineLock(c *gc.C)
 	op, err := factory.NewAcceptLeadership()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(op.NeedsGlobalMachineLock(), jc.IsFalse)
+	c.Assert(op.NeedsUnitLock(), jc.IsFalse)
 }
 
 func (s *LeaderSuite) TestResignLeadership_Prepare_Leader(c *gc.C) {
@@ -190,4 +191,5 @@ func (s *LeaderSuite) TestResignLeadership_DoesNotNeedGlobalMachineLock(c *gc.C)
 	op, err := factory.NewResignLeadership()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(op.NeedsGlobalMachineLock(), jc.IsFalse)
+	c.Assert(op.NeedsUnitLock(), jc.IsFalse)
 }
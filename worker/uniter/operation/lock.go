@@ -4,6 +4,14 @@
 
 package operation
 
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable/hooks"
+
+	"github.com/juju/juju/core/charmmeta"
+	"github.com/juju/juju/environs/config"
+)
+
 // DoesNotRequireMachineLock is embedded in the various operations to express whether
 // they need a global machine lock or not.
 type RequiresMachineLock struct{}
@@ -12,6 +20,10 @@ type RequiresMachineLock struct{}
 // It is embedded in the various operations.
 func (RequiresMachineLock) NeedsGlobalMachineLock() bool { return true }
 
+// NeedsUnitLock is part of the Operation interface.
+// It is embedded in the various operations.
+func (RequiresMachineLock) NeedsUnitLock() bool { return false }
+
 // DoesNotRequireMachineLock is embedded in the various operations to express whether
 // they need a global machine lock or not.
 type DoesNotRequireMachineLock struct{}
@@ -19,3 +31,82 @@ type DoesNotRequireMachineLock struct{}
 // NeedsGlobalMachineLock is part of the Operation interface.
 // It is embedded in the various operations.
 func (DoesNotRequireMachineLock) NeedsGlobalMachineLock() bool { return false }
+
+// NeedsUnitLock is part of the Operation interface.
+// It is embedded in the various operations.
+func (DoesNotRequireMachineLock) NeedsUnitLock() bool { return false }
+
+// LockPolicy decides whether hooks and actions must hold the global
+// machine lock while they run. Hooks and actions which do not need the
+// machine lock hold the lock of the unit running them instead; other
+// operations which do not need the machine lock hold no lock at all.
+type LockPolicy interface {
+	// HookNeedsMachineLock reports whether a hook of the given kind
+	// must hold the machine lock.
+	HookNeedsMachineLock(kind hooks.Kind) bool
+
+	// ActionNeedsMachineLock reports whether an action must hold the
+	// machine lock.
+	ActionNeedsMachineLock() bool
+}
+
+// NewLockPolicy returns a LockPolicy which applies the model's hook-lock
+// setting, as returned by getModelPolicy, to the charm in charmDir.
+// Install and upgrade-charm hooks always need the machine lock, as do
+// all hooks and actions of a charm whose metadata sets
+// "hook-lock: machine". If the policy cannot be determined, the machine
+// lock is used.
+func NewLockPolicy(getModelPolicy func() (string, error), charmDir string) LockPolicy {
+	return &lockPolicy{
+		getModelPolicy: getModelPolicy,
+		charmDir:       charmDir,
+	}
+}
+
+type lockPolicy struct {
+	getModelPolicy func() (string, error)
+	charmDir       string
+}
+
+// HookNeedsMachineLock is part of the LockPolicy interface.
+func (p *lockPolicy) HookNeedsMachineLock(kind hooks.Kind) bool {
+	switch kind {
+	case hooks.Install, hooks.UpgradeCharm:
+		return true
+	}
+	return p.needsMachineLock()
+}
+
+// ActionNeedsMachineLock is part of the LockPolicy interface.
+func (p *lockPolicy) ActionNeedsMachineLock() bool {
+	return p.needsMachineLock()
+}
+
+func (p *lockPolicy) needsMachineLock() bool {
+	modelPolicy, err := p.getModelPolicy()
+	if err != nil {
+		logger.Warningf("cannot read hook-lock setting, using machine lock: %v", err)
+		return true
+	}
+	if modelPolicy != config.HookLockUnit {
+		return true
+	}
+	meta, err := charmmeta.ReadDir(p.charmDir)
+	if errors.IsNotFound(err) {
+		return false
+	} else if err != nil {
+		logger.Warningf("cannot read charm hook-lock, using machine lock: %v", err)
+		return true
+	}
+	return meta.HookLock == charmmeta.HookLockMachine
+}
+
+// machineLockPolicy is the LockPolicy used when none is supplied: every
+// hook and action holds the machine lock.
+type machineLockPolicy struct{}
+
+// HookNeedsMachineLock is part of the LockPolicy interface.
+func (machineLockPolicy) HookNeedsMachineLock(hooks.Kind) bool { return true }
+
+// ActionNeedsMachineLock is part of the LockPolicy interface.
+func (machineLockPolicy) ActionNeedsMachineLock() bool { return true }
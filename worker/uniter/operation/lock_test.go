@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable/hooks"
+
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type LockPolicySuite struct {
+	testing.IsolationSuite
+	charmDir string
+}
+
+var _ = gc.Suite(&LockPolicySuite{})
+
+func (s *LockPolicySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.charmDir = c.MkDir()
+}
+
+func (s *LockPolicySuite) writeMetadata(c *gc.C, content string) {
+	err := ioutil.WriteFile(filepath.Join(s.charmDir, "metadata.yaml"), []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func modelPolicy(policy string) func() (string, error) {
+	return func() (string, error) {
+		return policy, nil
+	}
+}
+
+func (s *LockPolicySuite) TestMachinePolicy(c *gc.C) {
+	policy := operation.NewLockPolicy(modelPolicy("machine"), s.charmDir)
+	c.Assert(policy.HookNeedsMachineLock(hooks.ConfigChanged), jc.IsTrue)
+	c.Assert(policy.ActionNeedsMachineLock(), jc.IsTrue)
+}
+
+func (s *LockPolicySuite) TestUnitPolicy(c *gc.C) {
+	s.writeMetadata(c, "name: wordpress\n")
+	policy := operation.NewLockPolicy(modelPolicy("unit"), s.charmDir)
+	c.Assert(policy.HookNeedsMachineLock(hooks.ConfigChanged), jc.IsFalse)
+	c.Assert(policy.HookNeedsMachineLock(hooks.Install), jc.IsTrue)
+	c.Assert(policy.HookNeedsMachineLock(hooks.UpgradeCharm), jc.IsTrue)
+	c.Assert(policy.ActionNeedsMachineLock(), jc.IsFalse)
+}
+
+func (s *LockPolicySuite) TestUnitPolicyNoCharm(c *gc.C) {
+	policy := operation.NewLockPolicy(modelPolicy("unit"), s.charmDir)
+	c.Assert(policy.HookNeedsMachineLock(hooks.ConfigChanged), jc.IsFalse)
+}
+
+func (s *LockPolicySuite) TestUnitPolicyCharmNeedsMachineLock(c *gc.C) {
+	s.writeMetadata(c, "name: wordpress\nhook-lock: machine\n")
+	policy := operation.NewLockPolicy(modelPolicy("unit"), s.charmDir)
+	c.Assert(policy.HookNeedsMachineLock(hooks.ConfigChanged), jc.IsTrue)
+	c.Assert(policy.ActionNeedsMachineLock(), jc.IsTrue)
+}
+
+func (s *LockPolicySuite) TestMachinePolicyOverridesCharm(c *gc.C) {
+	s.writeMetadata(c, "name: wordpress\nhook-lock: unit\n")
+	policy := operation.NewLockPolicy(modelPolicy("machine"), s.charmDir)
+	c.Assert(policy.HookNeedsMachineLock(hooks.ConfigChanged), jc.IsTrue)
+}
+
+func (s *LockPolicySuite) TestInvalidCharmPolicy(c *gc.C) {
+	s.writeMetadata(c, "name: wordpress\nhook-lock: application\n")
+	policy := operation.NewLockPolicy(modelPolicy("unit"), s.charmDir)
+	c.Assert(policy.HookNeedsMachineLock(hooks.ConfigChanged), jc.IsTrue)
+}
+
+func (s *LockPolicySuite) TestModelPolicyError(c *gc.C) {
+	getModelPolicy := func() (string, error) {
+		return "", errors.New("boom")
+	}
+	policy := operation.NewLockPolicy(getModelPolicy, s.charmDir)
+	c.Assert(policy.HookNeedsMachineLock(hooks.ConfigChanged), jc.IsTrue)
+}
@@ -0,0 +1,132 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/uniter/hook"
+)
+
+// replayHooks queues the hooks skipped by resolving hook errors without
+// retrying them, so that they run again.
+type replayHooks struct {
+	DoesNotRequireMachineLock
+}
+
+// String is part of the Operation interface.
+func (op *replayHooks) String() string {
+	return "queue skipped hooks for replay"
+}
+
+// Prepare is part of the Operation interface.
+func (op *replayHooks) Prepare(state State) (*State, error) {
+	return nil, ErrSkipExecute
+}
+
+// Execute is part of the Operation interface.
+func (op *replayHooks) Execute(state State) (*State, error) {
+	return nil, errors.New("prepare always errors; Execute is never valid")
+}
+
+// Commit is part of the Operation interface.
+func (op *replayHooks) Commit(state State) (*State, error) {
+	if len(state.SkippedHooks) == 0 {
+		return nil, nil
+	}
+	replay := make([]hook.Info, 0, len(state.ReplayHooks)+len(state.SkippedHooks))
+	replay = append(replay, state.ReplayHooks...)
+	state.ReplayHooks = append(replay, state.SkippedHooks...)
+	state.SkippedHooks = nil
+	return &state, nil
+}
+
+// replayHook runs the next hook queued for replay.
+type replayHook struct {
+	Operation
+	info hook.Info
+}
+
+// String is part of the Operation interface.
+func (op *replayHook) String() string {
+	return fmt.Sprintf("replay %s hook", describeHook(op.info))
+}
+
+// Prepare is part of the Operation interface.
+func (op *replayHook) Prepare(state State) (*State, error) {
+	if err := checkReplayState(state, op.info); err != nil {
+		return nil, err
+	}
+	newState, err := op.Operation.Prepare(state)
+	if err != nil && errors.Cause(err) != ErrSkipExecute {
+		return nil, err
+	}
+	if newState == nil {
+		newState = &state
+	}
+	// The hook is taken off the queue before it runs; if it fails,
+	// it is resolved like any other hook.
+	newState.ReplayHooks = newState.ReplayHooks[1:]
+	return newState, err
+}
+
+// Commit is part of the Operation interface.
+func (op *replayHook) Commit(state State) (*State, error) {
+	// The hook's effects on the local state, such as relation
+	// membership, were committed when it was skipped, and may since
+	// have been superseded, so they are not committed again.
+	return stateChange{
+		Kind: Continue,
+		Step: Pending,
+	}.apply(state), nil
+}
+
+// discardReplayHook removes the next hook queued for replay without
+// running it, because it can no longer run.
+type discardReplayHook struct {
+	DoesNotRequireMachineLock
+	info hook.Info
+}
+
+// String is part of the Operation interface.
+func (op *discardReplayHook) String() string {
+	return fmt.Sprintf("discard %s hook queued for replay", describeHook(op.info))
+}
+
+// Prepare is part of the Operation interface.
+func (op *discardReplayHook) Prepare(state State) (*State, error) {
+	if err := checkReplayState(state, op.info); err != nil {
+		return nil, err
+	}
+	return nil, ErrSkipExecute
+}
+
+// Execute is part of the Operation interface.
+func (op *discardReplayHook) Execute(state State) (*State, error) {
+	return nil, errors.New("prepare always errors; Execute is never valid")
+}
+
+// Commit is part of the Operation interface.
+func (op *discardReplayHook) Commit(state State) (*State, error) {
+	if err := checkReplayState(state, op.info); err != nil {
+		return nil, err
+	}
+	logger.Warningf("discarding %s hook queued for replay", describeHook(op.info))
+	state.ReplayHooks = state.ReplayHooks[1:]
+	return &state, nil
+}
+
+// checkReplayState returns an error unless no other operation is in
+// progress and info is the next hook queued for replay.
+func checkReplayState(state State, info hook.Info) error {
+	if state.Kind != Continue {
+		return errors.Errorf("cannot replay %s hook during %s operation", describeHook(info), state.Kind)
+	}
+	if len(state.ReplayHooks) == 0 || state.ReplayHooks[0] != info {
+		return errors.Errorf("cannot replay %s hook: not next in replay queue", describeHook(info))
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable/hooks"
+
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type ReplaySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ReplaySuite{})
+
+var (
+	skippedConfigChanged   = hook.Info{Kind: hooks.ConfigChanged}
+	skippedRelationChanged = hook.Info{
+		Kind:          hooks.RelationChanged,
+		RelationId:    123,
+		RemoteUnit:    "foo/22",
+		ChangeVersion: 3,
+	}
+)
+
+func (s *ReplaySuite) TestSkipRecordsFailedHook(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{
+		Callbacks: &CommitHookCallbacks{MockCommitHook: &MockCommitHook{}},
+	})
+	op, err := factory.NewSkipHook(skippedRelationChanged)
+	c.Assert(err, jc.ErrorIsNil)
+
+	failed := operation.State{
+		Started:      true,
+		Kind:         operation.RunHook,
+		Step:         operation.Pending,
+		Hook:         &skippedRelationChanged,
+		SkippedHooks: []hook.Info{skippedConfigChanged},
+	}
+	newState, err := op.Commit(failed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Started:      true,
+		Kind:         operation.Continue,
+		Step:         operation.Pending,
+		SkippedHooks: []hook.Info{skippedConfigChanged, skippedRelationChanged},
+	})
+
+	// Skipping the same hook again does not record it twice.
+	newState, err = op.Commit(failed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState.SkippedHooks, jc.DeepEquals, []hook.Info{skippedConfigChanged, skippedRelationChanged})
+}
+
+func (s *ReplaySuite) TestSkipDoesNotRecordCompletedHook(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{
+		Callbacks: &CommitHookCallbacks{MockCommitHook: &MockCommitHook{}},
+	})
+	op, err := factory.NewSkipHook(skippedConfigChanged)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Commit(operation.State{
+		Started: true,
+		Kind:    operation.RunHook,
+		Step:    operation.Done,
+		Hook:    &skippedConfigChanged,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState.SkippedHooks, gc.HasLen, 0)
+}
+
+func (s *ReplaySuite) TestReplayHooks(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{})
+	op, err := factory.NewReplayHooks()
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Prepare(operation.State{})
+	c.Assert(newState, gc.IsNil)
+	c.Assert(err, gc.Equals, operation.ErrSkipExecute)
+
+	newState, err = op.Commit(operation.State{
+		Kind:         operation.Continue,
+		Step:         operation.Pending,
+		SkippedHooks: []hook.Info{skippedRelationChanged},
+		ReplayHooks:  []hook.Info{skippedConfigChanged},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:        operation.Continue,
+		Step:        operation.Pending,
+		ReplayHooks: []hook.Info{skippedConfigChanged, skippedRelationChanged},
+	})
+}
+
+func (s *ReplaySuite) TestReplayHooksNothingSkipped(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{})
+	op, err := factory.NewReplayHooks()
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Commit(operation.State{
+		Kind: operation.Continue,
+		Step: operation.Pending,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, gc.IsNil)
+}
+
+func (s *ReplaySuite) TestReplayHook(c *gc.C) {
+	runnerFactory := NewRunHookRunnerFactory(nil)
+	callbacks := &ExecuteHookCallbacks{
+		PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+		MockNotifyHookCompleted: &MockNotify{},
+		MockNotifyHookFailed:    &MockNotify{},
+	}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+	})
+	op, err := factory.NewReplayHook(skippedConfigChanged)
+	c.Assert(err, jc.ErrorIsNil)
+
+	state := operation.State{
+		Started:     true,
+		Kind:        operation.Continue,
+		Step:        operation.Pending,
+		ReplayHooks: []hook.Info{skippedConfigChanged, skippedRelationChanged},
+	}
+	newState, err := op.Prepare(state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Started:     true,
+		Kind:        operation.RunHook,
+		Step:        operation.Pending,
+		Hook:        &skippedConfigChanged,
+		ReplayHooks: []hook.Info{skippedRelationChanged},
+	})
+
+	newState, err = op.Execute(*newState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*runnerFactory.MockNewHookRunner.runner.MockRunHook.gotName, gc.Equals, "some-hook-name")
+
+	newState, err = op.Commit(*newState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Started:     true,
+		Kind:        operation.Continue,
+		Step:        operation.Pending,
+		ReplayHooks: []hook.Info{skippedRelationChanged},
+	})
+}
+
+func (s *ReplaySuite) TestReplayHookNotNext(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{})
+	op, err := factory.NewReplayHook(skippedConfigChanged)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Prepare(operation.State{
+		Kind:        operation.Continue,
+		Step:        operation.Pending,
+		ReplayHooks: []hook.Info{skippedRelationChanged, skippedConfigChanged},
+	})
+	c.Assert(newState, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "cannot replay config-changed hook: not next in replay queue")
+
+	newState, err = op.Prepare(operation.State{
+		Kind:        operation.RunHook,
+		Step:        operation.Queued,
+		Hook:        &hook.Info{Kind: hooks.Start},
+		ReplayHooks: []hook.Info{skippedConfigChanged},
+	})
+	c.Assert(newState, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "cannot replay config-changed hook during run-hook operation")
+}
+
+func (s *ReplaySuite) TestDiscardReplayHook(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{})
+	op, err := factory.NewDiscardReplayHook(skippedRelationChanged)
+	c.Assert(err, jc.ErrorIsNil)
+
+	state := operation.State{
+		Kind:        operation.Continue,
+		Step:        operation.Pending,
+		ReplayHooks: []hook.Info{skippedRelationChanged, skippedConfigChanged},
+	}
+	newState, err := op.Prepare(state)
+	c.Assert(newState, gc.IsNil)
+	c.Assert(err, gc.Equals, operation.ErrSkipExecute)
+
+	newState, err = op.Commit(state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:        operation.Continue,
+		Step:        operation.Pending,
+		ReplayHooks: []hook.Info{skippedConfigChanged},
+	})
+}
+
+func (s *ReplaySuite) TestNeedsGlobalMachineLock(c *gc.C) {
+	factory := operation.NewFactory(operation.FactoryParams{})
+	op, err := factory.NewReplayHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.NeedsGlobalMachineLock(), jc.IsFalse)
+	c.Assert(op.NeedsUnitLock(), jc.IsFalse)
+
+	op, err = factory.NewDiscardReplayHook(skippedConfigChanged)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.NeedsGlobalMachineLock(), jc.IsFalse)
+	c.Assert(op.NeedsUnitLock(), jc.IsFalse)
+
+	op, err = factory.NewReplayHook(skippedConfigChanged)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.NeedsGlobalMachineLock(), jc.IsTrue)
+	c.Assert(op.NeedsUnitLock(), jc.IsTrue)
+}
@@ -16,11 +16,10 @@ type runAction struct {
 
 	callbacks     Callbacks
 	runnerFactory runner.Factory
+	lockPolicy    LockPolicy
 
 	name   string
 	runner runner.Runner
-
-	RequiresMachineLock
 }
 
 // String is part of the Operation interface.
@@ -28,6 +27,16 @@ func (ra *runAction) String() string {
 	return fmt.Sprintf("run action %s", ra.actionId)
 }
 
+// NeedsGlobalMachineLock is part of the Operation interface.
+func (ra *runAction) NeedsGlobalMachineLock() bool {
+	return ra.lockPolicy.ActionNeedsMachineLock()
+}
+
+// NeedsUnitLock is part of the Operation interface.
+func (ra *runAction) NeedsUnitLock() bool {
+	return true
+}
+
 // Prepare ensures that the action is valid and can be executed. If not, it
 // will return ErrSkipExecute. It preserves any hook recorded in the supplied
 // state.
@@ -286,4 +286,5 @@ func (s *RunActionSuite) TestNeedsGlobalMachineLock(c *gc.C) {
 	op, err := factory.NewAction(someActionId)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(op.NeedsGlobalMachineLock(), jc.IsTrue)
+	c.Assert(op.NeedsUnitLock(), jc.IsTrue)
 }
@@ -178,4 +178,5 @@ func (s *RunCommandsSuite) TestNeedsGlobalMachineLock(c *gc.C) {
 	op, err := factory.NewCommands(someCommandArgs, sendResponse.Call)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(op.NeedsGlobalMachineLock(), jc.IsTrue)
+	c.Assert(op.NeedsUnitLock(), jc.IsFalse)
 }
@@ -5,10 +5,14 @@ package operation
 
 import (
 	"fmt"
+	"os/exec"
+	"syscall"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -21,27 +25,43 @@ type runHook struct {
 
 	callbacks     Callbacks
 	runnerFactory runner.Factory
+	lockPolicy    LockPolicy
+	recorder      HookRecorder
 
 	name   string
 	runner runner.Runner
-
-	RequiresMachineLock
 }
 
 // String is part of the Operation interface.
 func (rh *runHook) String() string {
+	return fmt.Sprintf("run %s hook", describeHook(rh.info))
+}
+
+// describeHook returns the kind of the supplied hook, followed by the
+// relation or storage it is for, if any.
+func describeHook(info hook.Info) string {
 	suffix := ""
 	switch {
-	case rh.info.Kind.IsRelation():
-		if rh.info.RemoteUnit == "" {
-			suffix = fmt.Sprintf(" (%d)", rh.info.RelationId)
+	case info.Kind.IsRelation():
+		if info.RemoteUnit == "" {
+			suffix = fmt.Sprintf(" (%d)", info.RelationId)
 		} else {
-			suffix = fmt.Sprintf(" (%d; %s)", rh.info.RelationId, rh.info.RemoteUnit)
+			suffix = fmt.Sprintf(" (%d; %s)", info.RelationId, info.RemoteUnit)
 		}
-	case rh.info.Kind.IsStorage():
-		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
+	case info.Kind.IsStorage():
+		suffix = fmt.Sprintf(" (%s)", info.StorageId)
 	}
-	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
+	return fmt.Sprintf("%s%s", info.Kind, suffix)
+}
+
+// NeedsGlobalMachineLock is part of the Operation interface.
+func (rh *runHook) NeedsGlobalMachineLock() bool {
+	return rh.lockPolicy.HookNeedsMachineLock(rh.info.Kind)
+}
+
+// NeedsUnitLock is part of the Operation interface.
+func (rh *runHook) NeedsUnitLock() bool {
+	return true
 }
 
 // Prepare ensures the hook can be executed.
@@ -91,8 +111,12 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	ranHook := true
 	step := Done
 
+	started := time.Now()
 	err := rh.runner.RunHook(rh.name)
 	cause := errors.Cause(err)
+	if !context.IsMissingHookError(cause) {
+		rh.recordHook(started, err)
+	}
 	switch {
 	case context.IsMissingHookError(cause):
 		ranHook = false
@@ -103,6 +127,16 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case cause == context.ErrReboot:
 		err = ErrNeedsReboot
 	case err == nil:
+	case runner.IsHookTimedOutError(cause):
+		logger.Errorf("hook %q timed out: %v", rh.name, err)
+		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+		// Record the timeout, so the failure can be reported as such.
+		return stateChange{
+			Kind:         RunHook,
+			Step:         Pending,
+			Hook:         &rh.info,
+			HookTimedOut: true,
+		}.apply(state), ErrHookFailed
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
@@ -129,6 +163,40 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	}.apply(state), err
 }
 
+// recordHook passes the outcome of the hook run that began at started
+// to the hook recorder.
+func (rh *runHook) recordHook(started time.Time, err error) {
+	record := params.HookRecord{
+		Hook:       rh.name,
+		RelationId: -1,
+		RemoteUnit: rh.info.RemoteUnit,
+		Started:    started,
+		Finished:   time.Now(),
+		Output:     rh.runner.HookOutput(),
+	}
+	if rh.info.Kind.IsRelation() {
+		record.RelationId = rh.info.RelationId
+	}
+	switch cause := errors.Cause(err); cause {
+	case nil, context.ErrReboot, context.ErrRequeueAndReboot:
+	default:
+		record.ExitCode = hookExitCode(cause)
+		record.Error = err.Error()
+	}
+	rh.recorder.Add(record)
+}
+
+// hookExitCode returns the exit code of the hook process that failed
+// with err, or -1 if the process did not exit normally.
+func hookExitCode(err error) int {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if waitStatus, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return waitStatus.ExitStatus()
+		}
+	}
+	return -1
+}
+
 func (rh *runHook) beforeHook() error {
 	var err error
 	switch rh.info.Kind {
@@ -4,6 +4,8 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -12,6 +14,7 @@ import (
 
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
@@ -272,6 +275,80 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 }
 
+func (s *RunHookSuite) TestExecuteTimedOutError(c *gc.C) {
+	runErr := errors.Trace(runner.NewHookTimedOutError("config-changed", time.Minute))
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hooks.ConfigChanged, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:         operation.RunHook,
+		Step:         operation.Pending,
+		Hook:         &hook.Info{Kind: hooks.ConfigChanged},
+		HookTimedOut: true,
+	})
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
+	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+}
+
+func (s *RunHookSuite) executeRecordedHook(c *gc.C, info hook.Info, runErr error) *MockHookRecorder {
+	runnerFactory := NewRunHookRunnerFactory(runErr)
+	runnerFactory.MockNewHookRunner.runner.MockRunHook.output = []string{"some output"}
+	callbacks := &ExecuteHookCallbacks{
+		PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+		MockNotifyHookCompleted: &MockNotify{},
+		MockNotifyHookFailed:    &MockNotify{},
+	}
+	recorder := &MockHookRecorder{}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+		HookRecorder:  recorder,
+	})
+	op, err := factory.NewRunHook(info)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	op.Execute(operation.State{})
+	return recorder
+}
+
+func (s *RunHookSuite) TestExecuteRecordsHook(c *gc.C) {
+	recorder := s.executeRecordedHook(c, hook.Info{Kind: hooks.ConfigChanged}, nil)
+	c.Assert(recorder.records, gc.HasLen, 1)
+	record := recorder.records[0]
+	c.Check(record.Hook, gc.Equals, "some-hook-name")
+	c.Check(record.RelationId, gc.Equals, -1)
+	c.Check(record.ExitCode, gc.Equals, 0)
+	c.Check(record.Error, gc.Equals, "")
+	c.Check(record.Output, jc.DeepEquals, []string{"some output"})
+	c.Check(record.Finished.Before(record.Started), jc.IsFalse)
+}
+
+func (s *RunHookSuite) TestExecuteRecordsFailedRelationHook(c *gc.C) {
+	info := hook.Info{
+		Kind:       hooks.RelationJoined,
+		RelationId: 123,
+		RemoteUnit: "mysql/0",
+	}
+	recorder := s.executeRecordedHook(c, info, errors.New("graaargh"))
+	c.Assert(recorder.records, gc.HasLen, 1)
+	record := recorder.records[0]
+	c.Check(record.RelationId, gc.Equals, 123)
+	c.Check(record.RemoteUnit, gc.Equals, "mysql/0")
+	c.Check(record.ExitCode, gc.Equals, -1)
+	c.Check(record.Error, gc.Equals, "graaargh")
+}
+
+func (s *RunHookSuite) TestExecuteDoesNotRecordMissingHook(c *gc.C) {
+	runErr := context.NewMissingHookError("blah-blah")
+	recorder := s.executeRecordedHook(c, hook.Info{Kind: hooks.ConfigChanged}, runErr)
+	c.Assert(recorder.records, gc.HasLen, 0)
+}
+
 func (s *RunHookSuite) testExecuteSuccess(
 	c *gc.C, before, after operation.State, setStatusCalled bool,
 ) {
@@ -715,6 +792,7 @@ func (s *RunHookSuite) testNeedsGlobalMachineLock(c *gc.C, newHook newHook, expe
 	op, err := newHook(factory, hook.Info{Kind: hooks.ConfigChanged})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(op.NeedsGlobalMachineLock(), gc.Equals, expected)
+	c.Assert(op.NeedsUnitLock(), gc.Equals, expected)
 }
 
 func (s *RunHookSuite) TestNeedsGlobalMachineLock_Run(c *gc.C) {
@@ -724,3 +802,18 @@ func (s *RunHookSuite) TestNeedsGlobalMachineLock_Run(c *gc.C) {
 func (s *RunHookSuite) TestNeedsGlobalMachineLock_Skip(c *gc.C) {
 	s.testNeedsGlobalMachineLock(c, (operation.Factory).NewSkipHook, false)
 }
+
+func (s *RunHookSuite) TestNeedsGlobalMachineLock_UnitPolicy(c *gc.C) {
+	getModelPolicy := func() (string, error) { return "unit", nil }
+	factory := operation.NewFactory(operation.FactoryParams{
+		LockPolicy: operation.NewLockPolicy(getModelPolicy, c.MkDir()),
+	})
+	op, err := factory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.NeedsGlobalMachineLock(), jc.IsFalse)
+	c.Assert(op.NeedsUnitLock(), jc.IsTrue)
+
+	op, err = factory.NewRunHook(hook.Info{Kind: hooks.Install})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.NeedsGlobalMachineLock(), jc.IsTrue)
+}
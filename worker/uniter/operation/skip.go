@@ -5,6 +5,8 @@ package operation
 
 import (
 	"fmt"
+
+	"github.com/juju/juju/worker/uniter/hook"
 )
 
 type skipOperation struct {
@@ -21,6 +23,11 @@ func (op *skipOperation) NeedsGlobalMachineLock() bool {
 	return false
 }
 
+// NeedsUnitLock is part of the Operation interface.
+func (op *skipOperation) NeedsUnitLock() bool {
+	return false
+}
+
 // Prepare is part of the Operation interface.
 func (op *skipOperation) Prepare(state State) (*State, error) {
 	return nil, ErrSkipExecute
@@ -30,3 +37,44 @@ func (op *skipOperation) Prepare(state State) (*State, error) {
 func (op *skipOperation) Execute(state State) (*State, error) {
 	return nil, ErrSkipExecute
 }
+
+// Commit is part of the Operation interface.
+func (op *skipOperation) Commit(state State) (*State, error) {
+	newState, err := op.Operation.Commit(state)
+	if err != nil || newState == nil {
+		return newState, err
+	}
+	if state.Kind == RunHook && state.Step == Pending {
+		// The hook failed, and the unit was resolved without
+		// retrying it; remember it so it can be replayed.
+		logger.Infof("recording skipped %s hook", describeHook(*state.Hook))
+		newState.SkippedHooks = addSkippedHook(newState.SkippedHooks, *state.Hook)
+	}
+	return newState, nil
+}
+
+// addSkippedHook returns skippedHooks with info appended, unless the
+// same hook has already been skipped. The supplied slice is not modified.
+func addSkippedHook(skippedHooks []hook.Info, info hook.Info) []hook.Info {
+	for _, skipped := range skippedHooks {
+		if skipped == info {
+			return skippedHooks
+		}
+	}
+	result := make([]hook.Info, len(skippedHooks), len(skippedHooks)+1)
+	copy(result, skippedHooks)
+	return append(result, info)
+}
+
+// describeSkippedHooks describes the hooks that have been skipped and
+// not yet run again, including those queued to be replayed.
+func describeSkippedHooks(st *State) []string {
+	descriptions := []string{}
+	for _, info := range st.ReplayHooks {
+		descriptions = append(descriptions, describeHook(info))
+	}
+	for _, info := range st.SkippedHooks {
+		descriptions = append(descriptions, describeHook(info))
+	}
+	return descriptions
+}
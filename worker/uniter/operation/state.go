@@ -9,7 +9,9 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils"
 	"gopkg.in/juju/charm.v6-unstable"
+	goyaml "gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/hook"
 )
 
@@ -93,6 +95,19 @@ type State struct {
 	// Charm describes the charm being deployed by an Install or Upgrade
 	// operation, and is otherwise blank.
 	CharmURL *charm.URL `yaml:"charm,omitempty"`
+
+	// HookTimedOut indicates that the hook being run by a RunHook
+	// operation failed because it was killed after running for longer
+	// than its timeout.
+	HookTimedOut bool `yaml:"hook-timed-out,omitempty"`
+
+	// SkippedHooks holds the hooks that failed and were then skipped,
+	// because the unit was resolved without retrying them.
+	SkippedHooks []hook.Info `yaml:"skipped-hooks,omitempty"`
+
+	// ReplayHooks holds previously skipped hooks which have been queued
+	// to run again, in the order they will run.
+	ReplayHooks []hook.Info `yaml:"replay-hooks,omitempty"`
 }
 
 // validate returns an error if the state violates expectations.
@@ -151,6 +166,13 @@ func (st State) validate() (err error) {
 	default:
 		return errors.Errorf("unknown operation step %q", st.Step)
 	}
+	for _, skippedHooks := range [][]hook.Info{st.SkippedHooks, st.ReplayHooks} {
+		for _, skipped := range skippedHooks {
+			if err := skipped.Validate(); err != nil {
+				return errors.Annotate(err, "invalid skipped hook")
+			}
+		}
+	}
 	if hasHook {
 		return st.Hook.Validate()
 	}
@@ -165,6 +187,7 @@ type stateChange struct {
 	ActionId        *string
 	CharmURL        *charm.URL
 	HasRunStatusSet bool
+	HookTimedOut    bool
 }
 
 func (change stateChange) apply(state State) *State {
@@ -174,27 +197,57 @@ func (change stateChange) apply(state State) *State {
 	state.ActionId = change.ActionId
 	state.CharmURL = change.CharmURL
 	state.StatusSet = state.StatusSet || change.HasRunStatusSet
+	state.HookTimedOut = change.HookTimedOut
 	return &state
 }
 
+// UnitStateReadWriter reads and writes the uniter state the controller
+// holds for a unit.
+type UnitStateReadWriter interface {
+	State() (params.UnitStateResult, error)
+	SetState(params.SetUnitStateArg) error
+}
+
 // StateFile holds the disk state for a uniter.
 type StateFile struct {
-	path string
+	path      string
+	unitState UnitStateReadWriter
+
+	// restored records whether the last Read restored the state from
+	// the controller.
+	restored bool
+
+	// unsynced holds the state last written, if it has not yet been
+	// sent to the controller.
+	unsynced *params.SetUnitStateArg
+
+	// synced holds the uniter state last sent to, or read from, the
+	// controller.
+	synced string
 }
 
 // NewStateFile returns a new StateFile using path.
 func NewStateFile(path string) *StateFile {
-	return &StateFile{path}
+	return &StateFile{path: path}
+}
+
+// NewControllerStateFile returns a new StateFile which keeps the state
+// on the controller through unitState, using the file at path only as a
+// local cache. If the file is lost along with the unit's machine, the
+// state is restored from the controller.
+func NewControllerStateFile(path string, unitState UnitStateReadWriter) *StateFile {
+	return &StateFile{path: path, unitState: unitState}
 }
 
-// Read reads a State from the file. If the file does not exist it returns
-// ErrNoStateFile.
+// Read reads a State from the file. If the file does not exist and the
+// controller holds no state for the unit, it returns ErrNoStateFile.
 func (f *StateFile) Read() (*State, error) {
 	var st State
 	if err := utils.ReadYaml(f.path, &st); err != nil {
-		if os.IsNotExist(err) {
-			return nil, ErrNoStateFile
+		if !os.IsNotExist(err) {
+			return nil, errors.Annotatef(err, "cannot read %q", f.path)
 		}
+		return f.restore()
 	}
 	if err := st.validate(); err != nil {
 		return nil, errors.Errorf("cannot read %q: %v", f.path, err)
@@ -202,10 +255,77 @@ func (f *StateFile) Read() (*State, error) {
 	return &st, nil
 }
 
-// Write stores the supplied state to the file.
+// restore reads the State held by the controller, and writes it to the
+// local file.
+func (f *StateFile) restore() (*State, error) {
+	if f.unitState == nil {
+		return nil, ErrNoStateFile
+	}
+	unitState, err := f.unitState.State()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read uniter state from controller")
+	}
+	if unitState.UniterState == "" {
+		return nil, ErrNoStateFile
+	}
+	var st State
+	if err := goyaml.Unmarshal([]byte(unitState.UniterState), &st); err != nil {
+		return nil, errors.Annotate(err, "cannot parse uniter state from controller")
+	}
+	if err := st.validate(); err != nil {
+		return nil, errors.Annotate(err, "invalid uniter state from controller")
+	}
+	logger.Infof("restoring uniter state from controller")
+	if err := utils.WriteYaml(f.path, &st); err != nil {
+		return nil, errors.Trace(err)
+	}
+	f.restored = true
+	f.synced = unitState.UniterState
+	return &st, nil
+}
+
+// Write stores the supplied state to the file. If the StateFile was
+// created with NewControllerStateFile, the state is sent to the
+// controller by the next call to Sync.
 func (f *StateFile) Write(st *State) error {
 	if err := st.validate(); err != nil {
 		return errors.Trace(err)
 	}
-	return utils.WriteYaml(f.path, st)
+	if err := utils.WriteYaml(f.path, st); err != nil {
+		return errors.Trace(err)
+	}
+	if f.unitState == nil {
+		return nil
+	}
+	data, err := goyaml.Marshal(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	uniterState := string(data)
+	skippedHooks := describeSkippedHooks(st)
+	f.unsynced = &params.SetUnitStateArg{
+		UniterState:  &uniterState,
+		SkippedHooks: &skippedHooks,
+	}
+	return nil
+}
+
+// Sync sends the state last written to the controller, unless it has
+// already been sent. The executor syncs once an operation has been
+// committed, rather than on every write, so the controller is not called
+// for each step of every operation.
+func (f *StateFile) Sync() error {
+	if f.unsynced == nil {
+		return nil
+	}
+	if *f.unsynced.UniterState == f.synced {
+		f.unsynced = nil
+		return nil
+	}
+	if err := f.unitState.SetState(*f.unsynced); err != nil {
+		return errors.Annotate(err, "cannot write uniter state to controller")
+	}
+	f.synced = *f.unsynced.UniterState
+	f.unsynced = nil
+	return nil
 }
@@ -4,14 +4,17 @@
 package operation_test
 
 import (
+	"os"
 	"path/filepath"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 )
@@ -169,6 +172,20 @@ var stateTests = []struct {
 	},
 	// Continue operation.
 	{
+		st: operation.State{
+			Kind:         operation.Continue,
+			Step:         operation.Pending,
+			SkippedHooks: []hook.Info{{Kind: hooks.RelationJoined}},
+		},
+		err: `invalid skipped hook: "relation-joined" hook requires a remote unit`,
+	}, {
+		st: operation.State{
+			Kind:         operation.Continue,
+			Step:         operation.Pending,
+			SkippedHooks: []hook.Info{*relhook},
+			ReplayHooks:  []hook.Info{{Kind: hooks.ConfigChanged}},
+		},
+	}, {
 		st: operation.State{
 			Kind:     operation.Continue,
 			Step:     operation.Pending,
@@ -215,3 +232,144 @@ func (s *StateFileSuite) TestStates(c *gc.C) {
 		c.Assert(st, jc.DeepEquals, &t.st)
 	}
 }
+
+type fakeUnitState struct {
+	uniterState  string
+	skippedHooks []string
+	setCalls     int
+	err          error
+}
+
+func (f *fakeUnitState) State() (params.UnitStateResult, error) {
+	return params.UnitStateResult{UniterState: f.uniterState}, f.err
+}
+
+func (f *fakeUnitState) SetState(arg params.SetUnitStateArg) error {
+	f.setCalls++
+	if f.err != nil {
+		return f.err
+	}
+	if arg.UniterState != nil {
+		f.uniterState = *arg.UniterState
+	}
+	if arg.SkippedHooks != nil {
+		f.skippedHooks = *arg.SkippedHooks
+	}
+	return nil
+}
+
+func (s *StateFileSuite) TestControllerStateFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "uniter")
+	unitState := &fakeUnitState{}
+	file := operation.NewControllerStateFile(path, unitState)
+	_, err := file.Read()
+	c.Assert(err, gc.Equals, operation.ErrNoStateFile)
+
+	st := operation.State{
+		Kind: operation.Continue,
+		Step: operation.Pending,
+	}
+	err = file.Write(&st)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState.setCalls, gc.Equals, 0)
+	err = file.Sync()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState.uniterState, gc.Not(gc.Equals), "")
+
+	// Lose the local file; the state is restored from the controller.
+	err = os.Remove(path)
+	c.Assert(err, jc.ErrorIsNil)
+	restored, err := file.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored, jc.DeepEquals, &st)
+
+	// The local file is written again as a cache.
+	restored, err = operation.NewStateFile(path).Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored, jc.DeepEquals, &st)
+}
+
+func (s *StateFileSuite) TestControllerStateFileSkippedHooks(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "uniter")
+	unitState := &fakeUnitState{}
+	file := operation.NewControllerStateFile(path, unitState)
+
+	err := file.Write(&operation.State{
+		Kind:         operation.Continue,
+		Step:         operation.Pending,
+		SkippedHooks: []hook.Info{*relhook},
+		ReplayHooks:  []hook.Info{{Kind: hooks.ConfigChanged}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = file.Sync()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState.skippedHooks, jc.DeepEquals, []string{
+		"config-changed",
+		"relation-joined (0; some-thing/123)",
+	})
+
+	err = file.Write(&operation.State{
+		Kind: operation.Continue,
+		Step: operation.Pending,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = file.Sync()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState.skippedHooks, gc.HasLen, 0)
+}
+
+func (s *StateFileSuite) TestControllerStateFileSyncsLastWrite(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "uniter")
+	unitState := &fakeUnitState{}
+	file := operation.NewControllerStateFile(path, unitState)
+
+	err := file.Write(&operation.State{
+		Kind: operation.RunHook,
+		Step: operation.Pending,
+		Hook: &hook.Info{Kind: hooks.ConfigChanged},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	st := operation.State{
+		Kind: operation.Continue,
+		Step: operation.Pending,
+	}
+	err = file.Write(&st)
+	c.Assert(err, jc.ErrorIsNil)
+	err = file.Sync()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState.setCalls, gc.Equals, 1)
+
+	// Unchanged state is not sent again.
+	err = file.Write(&st)
+	c.Assert(err, jc.ErrorIsNil)
+	err = file.Sync()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState.setCalls, gc.Equals, 1)
+
+	err = os.Remove(path)
+	c.Assert(err, jc.ErrorIsNil)
+	restored, err := file.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored, jc.DeepEquals, &st)
+}
+
+func (s *StateFileSuite) TestControllerStateFileWriteError(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "uniter")
+	unitState := &fakeUnitState{err: errors.New("boom")}
+	file := operation.NewControllerStateFile(path, unitState)
+	err := file.Write(&operation.State{
+		Kind: operation.Continue,
+		Step: operation.Pending,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = file.Sync()
+	c.Assert(err, gc.ErrorMatches, "cannot write uniter state to controller: boom")
+}
+
+func (s *StateFileSuite) TestControllerStateFileReadError(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "uniter")
+	unitState := &fakeUnitState{err: errors.New("boom")}
+	file := operation.NewControllerStateFile(path, unitState)
+	_, err := file.Read()
+	c.Assert(err, gc.ErrorMatches, "cannot read uniter state from controller: boom")
+}
@@ -10,6 +10,7 @@ import (
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -351,6 +352,7 @@ type MockRunHook struct {
 	gotName         *string
 	err             error
 	setStatusCalled bool
+	output          []string
 }
 
 func (mock *MockRunHook) Call(hookName string) error {
@@ -382,6 +384,21 @@ func (r *MockRunner) RunHook(hookName string) error {
 	return r.MockRunHook.Call(hookName)
 }
 
+func (r *MockRunner) HookOutput() []string {
+	if r.MockRunHook == nil {
+		return nil
+	}
+	return r.MockRunHook.output
+}
+
+type MockHookRecorder struct {
+	records []params.HookRecord
+}
+
+func (r *MockHookRecorder) Add(record params.HookRecord) {
+	r.records = append(r.records, record)
+}
+
 func NewDeployCallbacks() *DeployCallbacks {
 	return &DeployCallbacks{
 		MockGetArchiveInfo:  &MockGetArchiveInfo{info: &MockBundleInfo{}},
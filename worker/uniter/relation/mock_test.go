@@ -31,6 +31,10 @@ func (m *mockOperation) NeedsGlobalMachineLock() bool {
 	return false
 }
 
+func (m *mockOperation) NeedsUnitLock() bool {
+	return false
+}
+
 func (m *mockOperation) Prepare(state operation.State) (*operation.State, error) {
 	return &state, nil
 }
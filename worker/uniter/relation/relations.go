@@ -4,6 +4,9 @@
 package relation
 
 import (
+	"os"
+	"strconv"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
@@ -77,11 +80,17 @@ type relations struct {
 	charmDir     string
 	relationsDir string
 	relationers  map[int]*Relationer
+	unitState    operation.UnitStateReadWriter
 	abort        <-chan struct{}
 }
 
-// NewRelations returns a new Relations instance.
-func NewRelations(st *uniter.State, tag names.UnitTag, charmDir, relationsDir string, abort <-chan struct{}) (Relations, error) {
+// NewRelations returns a new Relations instance. If unitState is not nil,
+// relation state is also kept on the controller, and restored from there
+// if relationsDir has been lost.
+func NewRelations(
+	st *uniter.State, tag names.UnitTag, charmDir, relationsDir string,
+	unitState operation.UnitStateReadWriter, abort <-chan struct{},
+) (Relations, error) {
 	unit, err := st.Unit(tag)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -92,6 +101,7 @@ func NewRelations(st *uniter.State, tag names.UnitTag, charmDir, relationsDir st
 		charmDir:     charmDir,
 		relationsDir: relationsDir,
 		relationers:  make(map[int]*Relationer),
+		unitState:    unitState,
 		abort:        abort,
 	}
 	if err := r.init(); err != nil {
@@ -116,6 +126,9 @@ func (r *relations) init() error {
 		}
 		joinedRelations[relation.Id()] = relation
 	}
+	if err := r.restoreState(); err != nil {
+		return errors.Trace(err)
+	}
 	knownDirs, err := ReadAllStateDirs(r.relationsDir)
 	if err != nil {
 		return errors.Trace(err)
@@ -342,7 +355,56 @@ func (r *relations) CommitHook(hookInfo hook.Info) error {
 	if hookInfo.Kind == hooks.RelationBroken {
 		delete(r.relationers, hookInfo.RelationId)
 	}
-	return relationer.CommitHook(hookInfo)
+	if err := relationer.CommitHook(hookInfo); err != nil {
+		return errors.Trace(err)
+	}
+	return r.saveState()
+}
+
+// restoreState writes the relation state kept on the controller to the
+// local relations directory, if that directory has been lost.
+func (r *relations) restoreState() error {
+	if r.unitState == nil {
+		return nil
+	}
+	if _, err := os.Stat(r.relationsDir); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	unitState, err := r.unitState.State()
+	if err != nil {
+		return errors.Annotate(err, "cannot read relation state from controller")
+	}
+	for key, data := range unitState.RelationState {
+		relationId, err := strconv.Atoi(key)
+		if err != nil {
+			return errors.NotValidf("relation id %q", key)
+		}
+		logger.Infof("restoring state of relation %d from controller", relationId)
+		if err := restoreStateDir(r.relationsDir, relationId, data); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// saveState writes the state of all the unit's relations to the
+// controller.
+func (r *relations) saveState() error {
+	if r.unitState == nil {
+		return nil
+	}
+	relationState := make(map[string]string)
+	for id, relationer := range r.relationers {
+		data, err := marshalState(relationer.dir.State())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		relationState[strconv.Itoa(id)] = data
+	}
+	err := r.unitState.SetState(params.SetUnitStateArg{RelationState: &relationState})
+	return errors.Annotate(err, "cannot write relation state to controller")
 }
 
 // GetInfo is part of the Relations interface.
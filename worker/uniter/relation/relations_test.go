@@ -48,6 +48,7 @@ type relationsSuite struct {
 
 	stateDir     string
 	relationsDir string
+	unitState    operation.UnitStateReadWriter
 }
 
 var _ = gc.Suite(&relationsSuite{})
@@ -109,6 +110,22 @@ func (s *relationsSuite) SetUpTest(c *gc.C) {
 	err = ioutil.WriteFile(filepath.Join(s.stateDir, "metadata.yaml"), []byte(minimalMetadata), 0755)
 	c.Assert(err, jc.ErrorIsNil)
 	s.relationsDir = filepath.Join(c.MkDir(), "relations")
+	s.unitState = nil
+}
+
+type fakeUnitState struct {
+	relationState map[string]string
+}
+
+func (f *fakeUnitState) State() (params.UnitStateResult, error) {
+	return params.UnitStateResult{RelationState: f.relationState}, nil
+}
+
+func (f *fakeUnitState) SetState(arg params.SetUnitStateArg) error {
+	if arg.RelationState != nil {
+		f.relationState = *arg.RelationState
+	}
+	return nil
 }
 
 func assertNumCalls(c *gc.C, numCalls *int32, expected int32) {
@@ -127,7 +144,7 @@ func (s *relationsSuite) setupRelations(c *gc.C) relation.Relations {
 		uniterApiCall("JoinedRelations", unitEntity, params.StringsResults{Results: []params.StringsResult{{Result: []string{}}}}, nil),
 	)
 	st := uniter.NewState(apiCaller, unitTag)
-	r, err := relation.NewRelations(st, unitTag, s.stateDir, s.relationsDir, abort)
+	r, err := relation.NewRelations(st, unitTag, s.stateDir, s.relationsDir, nil, abort)
 	c.Assert(err, jc.ErrorIsNil)
 	assertNumCalls(c, &numCalls, 2)
 	return r
@@ -170,7 +187,7 @@ func (s *relationsSuite) TestNewRelationsWithExistingRelations(c *gc.C) {
 		uniterApiCall("EnterScope", relationUnits, params.ErrorResults{Results: []params.ErrorResult{{}}}, nil),
 	)
 	st := uniter.NewState(apiCaller, unitTag)
-	r, err := relation.NewRelations(st, unitTag, s.stateDir, s.relationsDir, abort)
+	r, err := relation.NewRelations(st, unitTag, s.stateDir, s.relationsDir, nil, abort)
 	c.Assert(err, jc.ErrorIsNil)
 	assertNumCalls(c, &numCalls, 6)
 
@@ -196,7 +213,7 @@ func (s *relationsSuite) TestNextOpNothing(c *gc.C) {
 		uniterApiCall("GetPrincipal", unitEntity, params.StringBoolResults{Results: []params.StringBoolResult{{Result: "", Ok: false}}}, nil),
 	)
 	st := uniter.NewState(apiCaller, unitTag)
-	r, err := relation.NewRelations(st, unitTag, s.stateDir, s.relationsDir, abort)
+	r, err := relation.NewRelations(st, unitTag, s.stateDir, s.relationsDir, nil, abort)
 	c.Assert(err, jc.ErrorIsNil)
 	assertNumCalls(c, &numCalls, 2)
 
@@ -247,7 +264,7 @@ func (s *relationsSuite) assertHookRelationJoined(c *gc.C, numCalls *int32, apiC
 
 	apiCaller := mockAPICaller(c, numCalls, apiCalls...)
 	st := uniter.NewState(apiCaller, unitTag)
-	r, err := relation.NewRelations(st, unitTag, s.stateDir, s.relationsDir, abort)
+	r, err := relation.NewRelations(st, unitTag, s.stateDir, s.relationsDir, s.unitState, abort)
 	c.Assert(err, jc.ErrorIsNil)
 	assertNumCalls(c, numCalls, 2)
 
@@ -466,6 +483,63 @@ func (s *relationsSuite) TestCommitHook(c *gc.C) {
 	c.Assert(stateFile, jc.DoesNotExist)
 }
 
+func (s *relationsSuite) TestCommitHookSavesState(c *gc.C) {
+	unitState := &fakeUnitState{}
+	s.unitState = unitState
+	var numCalls int32
+	s.assertHookRelationJoined(c, &numCalls, relationJoinedApiCalls()...)
+	c.Assert(unitState.relationState, jc.DeepEquals, map[string]string{
+		"1": "members:\n  wordpress: 1\nchanged-pending: wordpress\n",
+	})
+}
+
+func (s *relationsSuite) TestNewRelationsRestoresState(c *gc.C) {
+	unitTag := names.NewUnitTag("wordpress/0")
+	abort := make(chan struct{})
+
+	var numCalls int32
+	unitEntity := params.Entities{Entities: []params.Entity{params.Entity{Tag: "unit-wordpress-0"}}}
+	relationUnits := params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: "relation-wordpress.db#mysql.db", Unit: "unit-wordpress-0"},
+	}}
+	relationResults := params.RelationResults{
+		Results: []params.RelationResult{
+			{
+				Id:   1,
+				Key:  "wordpress:db mysql:db",
+				Life: params.Alive,
+				Endpoint: multiwatcher.Endpoint{
+					ApplicationName: "wordpress",
+					Relation:        multiwatcher.CharmRelation{Name: "mysql", Role: string(charm.RoleProvider), Interface: "db"},
+				}},
+		},
+	}
+
+	apiCaller := mockAPICaller(c, &numCalls,
+		uniterApiCall("Life", unitEntity, params.LifeResults{Results: []params.LifeResult{{Life: params.Alive}}}, nil),
+		uniterApiCall("JoinedRelations", unitEntity, params.StringsResults{Results: []params.StringsResult{{Result: []string{"relation-wordpress:db mysql:db"}}}}, nil),
+		uniterApiCall("Relation", relationUnits, relationResults, nil),
+		uniterApiCall("Relation", relationUnits, relationResults, nil),
+		uniterApiCall("Watch", unitEntity, params.NotifyWatchResults{Results: []params.NotifyWatchResult{{NotifyWatcherId: "1"}}}, nil),
+		uniterApiCall("EnterScope", relationUnits, params.ErrorResults{Results: []params.ErrorResult{{}}}, nil),
+	)
+	unitState := &fakeUnitState{relationState: map[string]string{
+		"1": "members:\n  mysql/0: 2\n",
+	}}
+	st := uniter.NewState(apiCaller, unitTag)
+	r, err := relation.NewRelations(st, unitTag, s.stateDir, s.relationsDir, unitState, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	assertNumCalls(c, &numCalls, 6)
+
+	info := r.GetInfo()
+	c.Assert(info, gc.HasLen, 1)
+	c.Assert(info[1].MemberNames, jc.DeepEquals, []string{"mysql/0"})
+
+	data, err := ioutil.ReadFile(filepath.Join(s.relationsDir, "1", "mysql-0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "change-version: 2\n")
+}
+
 func (s *relationsSuite) TestImplicitRelationNoHooks(c *gc.C) {
 	unitTag := names.NewUnitTag("wordpress/0")
 	abort := make(chan struct{})
@@ -500,7 +574,7 @@ func (s *relationsSuite) TestImplicitRelationNoHooks(c *gc.C) {
 	var numCalls int32
 	apiCaller := mockAPICaller(c, &numCalls, apiCalls...)
 	st := uniter.NewState(apiCaller, unitTag)
-	r, err := relation.NewRelations(st, unitTag, s.stateDir, s.relationsDir, abort)
+	r, err := relation.NewRelations(st, unitTag, s.stateDir, s.relationsDir, nil, abort)
 	c.Assert(err, jc.ErrorIsNil)
 
 	localState := resolver.LocalState{
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
+	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/worker/uniter/hook"
 )
@@ -232,3 +233,50 @@ type diskInfo struct {
 	ChangeVersion  *int64 `yaml:"change-version"`
 	ChangedPending bool   `yaml:"changed-pending,omitempty"`
 }
+
+// stateInfo defines the relation state serialization used when the
+// state is kept on the controller.
+type stateInfo struct {
+	Members        map[string]int64 `yaml:"members,omitempty"`
+	ChangedPending string           `yaml:"changed-pending,omitempty"`
+}
+
+// marshalState serializes the supplied relation state, so that it can be
+// kept on the controller.
+func marshalState(st *State) (string, error) {
+	data, err := goyaml.Marshal(stateInfo{
+		Members:        st.Members,
+		ChangedPending: st.ChangedPending,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+// restoreStateDir writes the relation state serialized in data, as
+// produced by marshalState, to the subdirectory of dirPath named for the
+// supplied relationId.
+func restoreStateDir(dirPath string, relationId int, data string) (err error) {
+	d := &StateDir{
+		path:  filepath.Join(dirPath, strconv.Itoa(relationId)),
+		state: State{relationId, map[string]int64{}, ""},
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot restore relation state to %q", d.path)
+	var info stateInfo
+	if err := goyaml.Unmarshal([]byte(data), &info); err != nil {
+		return err
+	}
+	if err := d.Ensure(); err != nil {
+		return err
+	}
+	for unitName, changeVersion := range info.Members {
+		name := strings.Replace(unitName, "/", "-", 1)
+		changeVersion := changeVersion
+		di := diskInfo{&changeVersion, unitName == info.ChangedPending}
+		if err := utils.WriteYaml(filepath.Join(d.path, name), &di); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ package remotestate_test
 import (
 	"sync"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -185,12 +186,20 @@ type mockUnit struct {
 	tag                   names.UnitTag
 	life                  params.Life
 	resolved              params.ResolvedMode
+	replayHooks           bool
+	series                string
 	service               mockService
 	unitWatcher           *mockNotifyWatcher
 	addressesWatcher      *mockNotifyWatcher
 	configSettingsWatcher *mockNotifyWatcher
 	storageWatcher        *mockStringsWatcher
 	actionWatcher         *mockStringsWatcher
+	secretsWatcher        *mockStringsWatcher
+	secretRotations       []params.SecretRotation
+
+	// olderController makes the unit behave as if the controller
+	// supports only an older version of the Uniter facade.
+	olderController bool
 }
 
 func (u *mockUnit) Life() params.Life {
@@ -205,6 +214,20 @@ func (u *mockUnit) Resolved() (params.ResolvedMode, error) {
 	return u.resolved, nil
 }
 
+func (u *mockUnit) ReplayHooksRequested() (bool, error) {
+	if u.olderController {
+		return false, errors.NotImplementedf("ReplayHooksRequested")
+	}
+	return u.replayHooks, nil
+}
+
+func (u *mockUnit) Series() (string, error) {
+	if u.olderController {
+		return "", errors.NotImplementedf("Series")
+	}
+	return u.series, nil
+}
+
 func (u *mockUnit) Application() (remotestate.Application, error) {
 	return &u.service, nil
 }
@@ -233,6 +256,20 @@ func (u *mockUnit) WatchActionNotifications() (watcher.StringsWatcher, error) {
 	return u.actionWatcher, nil
 }
 
+func (u *mockUnit) WatchSecretRotations() (watcher.StringsWatcher, error) {
+	if u.olderController {
+		return nil, errors.NotImplementedf("WatchSecretRotations")
+	}
+	return u.secretsWatcher, nil
+}
+
+func (u *mockUnit) SecretRotations() ([]params.SecretRotation, error) {
+	if u.olderController {
+		return nil, errors.NotImplementedf("SecretRotations")
+	}
+	return u.secretRotations, nil
+}
+
 type mockService struct {
 	tag                   names.ApplicationTag
 	life                  params.Life
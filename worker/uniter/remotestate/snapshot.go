@@ -36,10 +36,18 @@ type Snapshot struct {
 	// should upgrade even in an error state.
 	ForceCharmUpgrade bool
 
+	// Series is the series of the unit, which changes when
+	// the OS of its machine is upgraded in place.
+	Series string
+
 	// ResolvedMode reports the method of resolving
 	// hook execution errors.
 	ResolvedMode params.ResolvedMode
 
+	// ReplayHooks reports whether the hooks skipped when
+	// resolving hook errors should be run again.
+	ReplayHooks bool
+
 	// RetryHookVersion increments each time a failed
 	// hook is meant to be retried if ResolvedMode is
 	// set to ResolvedNone.
@@ -68,6 +76,10 @@ type Snapshot struct {
 	// Commands is the list of IDs of commands to be
 	// executed by this unit.
 	Commands []string
+
+	// SecretsToRotate is the list of IDs of secrets
+	// that are due to be rotated by this unit.
+	SecretsToRotate []string
 }
 
 type RelationSnapshot struct {
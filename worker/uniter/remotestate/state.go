@@ -25,6 +25,8 @@ type Unit interface {
 	Life() params.Life
 	Refresh() error
 	Resolved() (params.ResolvedMode, error)
+	ReplayHooksRequested() (bool, error)
+	Series() (string, error)
 	Application() (Application, error)
 	Tag() names.UnitTag
 	Watch() (watcher.NotifyWatcher, error)
@@ -32,6 +34,8 @@ type Unit interface {
 	WatchConfigSettings() (watcher.NotifyWatcher, error)
 	WatchStorage() (watcher.StringsWatcher, error)
 	WatchActionNotifications() (watcher.StringsWatcher, error)
+	WatchSecretRotations() (watcher.StringsWatcher, error)
+	SecretRotations() ([]params.SecretRotation, error)
 }
 
 type Application interface {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
@@ -35,6 +36,11 @@ type RemoteStateWatcher struct {
 	updateStatusChannel       func() <-chan time.Time
 	commandChannel            <-chan string
 	retryHookChannel          <-chan struct{}
+	clock                     clock.Clock
+
+	// secretRotations holds the next rotation time of each secret
+	// the unit is responsible for rotating, keyed by secret ID.
+	secretRotations map[string]time.Time
 
 	catacomb catacomb.Catacomb
 
@@ -52,6 +58,7 @@ type WatcherConfig struct {
 	CommandChannel      <-chan string
 	RetryHookChannel    <-chan struct{}
 	UnitTag             names.UnitTag
+	Clock               clock.Clock
 }
 
 // NewWatcher returns a RemoteStateWatcher that handles state changes pertaining to the
@@ -67,6 +74,8 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		updateStatusChannel:       config.UpdateStatusChannel,
 		commandChannel:            config.CommandChannel,
 		retryHookChannel:          config.RetryHookChannel,
+		clock:                     config.Clock,
+		secretRotations:           make(map[string]time.Time),
 		// Note: it is important that the out channel be buffered!
 		// The remote state watcher will perform a non-blocking send
 		// on the channel to wake up the observer. It is non-blocking
@@ -126,6 +135,8 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	copy(snapshot.Actions, w.current.Actions)
 	snapshot.Commands = make([]string, len(w.current.Commands))
 	copy(snapshot.Commands, w.current.Commands)
+	snapshot.SecretsToRotate = make([]string, len(w.current.SecretsToRotate))
+	copy(snapshot.SecretsToRotate, w.current.SecretsToRotate)
 	return snapshot
 }
 
@@ -135,6 +146,12 @@ func (w *RemoteStateWatcher) ClearResolvedMode() {
 	w.mu.Unlock()
 }
 
+func (w *RemoteStateWatcher) ClearReplayHooks() {
+	w.mu.Lock()
+	w.current.ReplayHooks = false
+	w.mu.Unlock()
+}
+
 func (w *RemoteStateWatcher) CommandCompleted(completed string) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -150,6 +167,26 @@ func (w *RemoteStateWatcher) CommandCompleted(completed string) {
 	}
 }
 
+// SecretRotated is called when the secret-rotate hook has completed
+// for the secret with the given ID.
+func (w *RemoteStateWatcher) SecretRotated(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	// The secret's next rotation time will be updated by the
+	// controller; forget the old one so it is not triggered again.
+	delete(w.secretRotations, id)
+	for i, rotate := range w.current.SecretsToRotate {
+		if rotate != id {
+			continue
+		}
+		w.current.SecretsToRotate = append(
+			w.current.SecretsToRotate[:i],
+			w.current.SecretsToRotate[i+1:]...,
+		)
+		break
+	}
+}
+
 func (w *RemoteStateWatcher) setUp(unitTag names.UnitTag) (err error) {
 	// TODO(dfc) named return value is a time bomb
 	// TODO(axw) move this logic.
@@ -256,6 +293,27 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	var seenSecretsChange bool
+	var secretsChanges watcher.StringsChannel
+	secretsw, err := w.unit.WatchSecretRotations()
+	if errors.IsNotImplemented(err) {
+		// Older controllers do not support secrets, so there
+		// are none to rotate.
+		logger.Debugf("controller does not support secrets")
+	} else if err != nil {
+		return errors.Trace(err)
+	} else {
+		if err := w.catacomb.Add(secretsw); err != nil {
+			return errors.Trace(err)
+		}
+		secretsChanges = secretsw.Changes()
+		requiredEvents++
+	}
+
+	// secretRotateTimer fires when the next secret is due to be
+	// rotated; it is nil if no secrets need rotating.
+	var secretRotateTimer <-chan time.Time
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -384,11 +442,32 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenStorageChange)
 
+		case _, ok := <-secretsChanges:
+			logger.Debugf("got secrets change: ok=%t", ok)
+			if !ok {
+				return errors.New("secrets watcher closed")
+			}
+			if err := w.secretRotationsChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			secretRotateTimer = w.scheduleSecretRotations()
+			observedEvent(&seenSecretsChange)
+
+		case <-secretRotateTimer:
+			logger.Debugf("secret rotation timer triggered")
+			secretRotateTimer = w.scheduleSecretRotations()
+
 		case <-waitMinion:
 			logger.Debugf("got leadership change: minion")
 			if err := w.leadershipChanged(false); err != nil {
 				return errors.Trace(err)
 			}
+			// The unit is no longer responsible for rotating
+			// the application's secrets.
+			if err := w.secretRotationsChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			secretRotateTimer = w.scheduleSecretRotations()
 			waitMinion = nil
 			waitLeader = w.leadershipTracker.WaitLeader().Ready()
 
@@ -397,6 +476,12 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err := w.leadershipChanged(true); err != nil {
 				return errors.Trace(err)
 			}
+			// The unit is now responsible for rotating the
+			// application's secrets.
+			if err := w.secretRotationsChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			secretRotateTimer = w.scheduleSecretRotations()
 			waitLeader = nil
 			waitMinion = w.leadershipTracker.WaitMinion().Ready()
 
@@ -458,6 +543,68 @@ func (w *RemoteStateWatcher) commandsChanged(id string) error {
 	return nil
 }
 
+// secretRotationsChanged is called when the secrets the unit is
+// responsible for rotating, or their rotation schedules, change.
+func (w *RemoteStateWatcher) secretRotationsChanged() error {
+	rotations, err := w.unit.SecretRotations()
+	if errors.IsNotImplemented(err) {
+		// Older controllers do not support secrets.
+		rotations = nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.secretRotations = make(map[string]time.Time)
+	for _, rotation := range rotations {
+		w.secretRotations[rotation.ID] = rotation.NextRotateTime
+	}
+	// Drop any pending rotations of secrets the unit is no
+	// longer responsible for.
+	var toRotate []string
+	for _, id := range w.current.SecretsToRotate {
+		if _, ok := w.secretRotations[id]; ok {
+			toRotate = append(toRotate, id)
+		}
+	}
+	w.current.SecretsToRotate = toRotate
+	return nil
+}
+
+// scheduleSecretRotations records the secrets that are due to be
+// rotated, and returns a channel that will fire when the next one
+// is due, or nil if there is none.
+func (w *RemoteStateWatcher) scheduleSecretRotations() <-chan time.Time {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := w.clock.Now()
+	var next time.Time
+	for id, when := range w.secretRotations {
+		if !when.After(now) {
+			if !containsString(w.current.SecretsToRotate, id) {
+				w.current.SecretsToRotate = append(w.current.SecretsToRotate, id)
+			}
+			continue
+		}
+		if next.IsZero() || when.Before(next) {
+			next = when
+		}
+	}
+	if next.IsZero() {
+		return nil
+	}
+	return w.clock.After(next.Sub(now))
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 // retryHookTimerTriggered is called when the retry hook timer expires.
 func (w *RemoteStateWatcher) retryHookTimerTriggered() error {
 	w.mu.Lock()
@@ -475,10 +622,23 @@ func (w *RemoteStateWatcher) unitChanged() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	// Older controllers cannot ask the unit to replay skipped hooks.
+	replayHooks, err := w.unit.ReplayHooksRequested()
+	if err != nil && !errors.IsNotImplemented(err) {
+		return errors.Trace(err)
+	}
+	// Older controllers do not report the unit's series, and cannot
+	// change it.
+	series, err := w.unit.Series()
+	if err != nil && !errors.IsNotImplemented(err) {
+		return errors.Trace(err)
+	}
 	w.mu.Lock()
 	defer w.mu.Unlock()
 	w.current.Life = w.unit.Life()
 	w.current.ResolvedMode = resolved
+	w.current.ReplayHooks = replayHooks
+	w.current.Series = series
 	return nil
 }
 
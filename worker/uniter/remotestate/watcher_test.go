@@ -32,12 +32,12 @@ var statusTickDuration = 10 * time.Second
 
 var _ = gc.Suite(&WatcherSuite{})
 
-func (s *WatcherSuite) SetUpTest(c *gc.C) {
-	s.BaseSuite.SetUpTest(c)
-	s.st = &mockState{
+func newMockState() *mockState {
+	return &mockState{
 		unit: mockUnit{
-			tag:  names.NewUnitTag("mysql/0"),
-			life: params.Alive,
+			tag:    names.NewUnitTag("mysql/0"),
+			life:   params.Alive,
+			series: "trusty",
 			service: mockService{
 				tag:                   names.NewApplicationTag("mysql"),
 				life:                  params.Alive,
@@ -52,12 +52,18 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			configSettingsWatcher: newMockNotifyWatcher(),
 			storageWatcher:        newMockStringsWatcher(),
 			actionWatcher:         newMockStringsWatcher(),
+			secretsWatcher:        newMockStringsWatcher(),
 		},
 		relations:                 make(map[names.RelationTag]*mockRelation),
 		storageAttachment:         make(map[params.StorageAttachmentId]params.StorageAttachment),
 		relationUnitsWatchers:     make(map[names.RelationTag]*mockRelationUnitsWatcher),
 		storageAttachmentWatchers: make(map[names.StorageTag]*mockNotifyWatcher),
 	}
+}
+
+func (s *WatcherSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.st = newMockState()
 
 	s.leadership = &mockLeadershipTracker{
 		claimTicket:  mockTicket{make(chan struct{}, 1), true},
@@ -66,15 +72,19 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 	}
 
 	s.clock = testing.NewClock(time.Now())
+	s.startWatcher(c)
+}
+
+func (s *WatcherSuite) startWatcher(c *gc.C) {
 	statusTicker := func() <-chan time.Time {
 		return s.clock.After(statusTickDuration)
 	}
-
 	w, err := remotestate.NewWatcher(remotestate.WatcherConfig{
 		State:               s.st,
 		LeadershipTracker:   s.leadership,
 		UnitTag:             s.st.unit.tag,
 		UpdateStatusChannel: statusTicker,
+		Clock:               s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = w
@@ -106,6 +116,7 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	s.st.unit.configSettingsWatcher.changes <- struct{}{}
 	s.st.unit.storageWatcher.changes <- []string{}
 	s.st.unit.actionWatcher.changes <- []string{}
+	s.st.unit.secretsWatcher.changes <- []string{}
 	s.st.unit.service.serviceWatcher.changes <- struct{}{}
 	s.st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	s.st.unit.service.relationsWatcher.changes <- []string{}
@@ -119,6 +130,7 @@ func signalAll(st *mockState, l *mockLeadershipTracker) {
 	st.unit.configSettingsWatcher.changes <- struct{}{}
 	st.unit.storageWatcher.changes <- []string{}
 	st.unit.actionWatcher.changes <- []string{}
+	st.unit.secretsWatcher.changes <- []string{}
 	st.unit.service.serviceWatcher.changes <- struct{}{}
 	st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	st.unit.service.relationsWatcher.changes <- []string{}
@@ -137,6 +149,7 @@ func (s *WatcherSuite) TestSnapshot(c *gc.C) {
 		CharmModifiedVersion:  s.st.unit.service.charmModifiedVersion,
 		CharmURL:              s.st.unit.service.curl,
 		ForceCharmUpgrade:     s.st.unit.service.forceUpgrade,
+		Series:                s.st.unit.series,
 		ResolvedMode:          s.st.unit.resolved,
 		ConfigVersion:         2, // config settings and addresses
 		LeaderSettingsVersion: 1,
@@ -159,6 +172,11 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().Life, gc.Equals, params.Dying)
 
+	s.st.unit.series = "xenial"
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().Series, gc.Equals, "xenial")
+
 	s.st.unit.addressesWatcher.changes <- struct{}{}
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().ConfigVersion, gc.Equals, initial.ConfigVersion+1)
@@ -195,6 +213,42 @@ func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().Actions, gc.DeepEquals, []string{"an-action"})
 }
 
+func (s *WatcherSuite) TestSecretRotations(c *gc.C) {
+	now := s.clock.Now()
+	s.st.unit.secretRotations = []params.SecretRotation{
+		{ID: "due", NextRotateTime: now.Add(-time.Minute)},
+		{ID: "later", NextRotateTime: now.Add(time.Hour)},
+	}
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretsToRotate, jc.DeepEquals, []string{"due"})
+
+	s.watcher.SecretRotated("due")
+	c.Assert(s.watcher.Snapshot().SecretsToRotate, gc.HasLen, 0)
+
+	// Advance the clock past the next rotation time.
+	s.waitAlarmsStable(c)
+	s.clock.Advance(time.Hour)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretsToRotate, jc.DeepEquals, []string{"later"})
+}
+
+func (s *WatcherSuite) TestSecretRotationsNoLongerResponsible(c *gc.C) {
+	s.st.unit.secretRotations = []params.SecretRotation{
+		{ID: "app-secret", NextRotateTime: s.clock.Now()},
+	}
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretsToRotate, jc.DeepEquals, []string{"app-secret"})
+
+	// When the unit loses leadership, it is no longer responsible
+	// for rotating the application's secrets.
+	s.st.unit.secretRotations = nil
+	s.leadership.minionTicket.ch <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretsToRotate, gc.HasLen, 0)
+}
+
 func (s *WatcherSuite) TestClearResolvedMode(c *gc.C) {
 	s.st.unit.resolved = params.ResolvedRetryHooks
 	signalAll(s.st, s.leadership)
@@ -208,6 +262,38 @@ func (s *WatcherSuite) TestClearResolvedMode(c *gc.C) {
 	c.Assert(snap.ResolvedMode, gc.Equals, params.ResolvedNone)
 }
 
+func (s *WatcherSuite) TestClearReplayHooks(c *gc.C) {
+	s.st.unit.replayHooks = true
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	snap := s.watcher.Snapshot()
+	c.Assert(snap.ReplayHooks, jc.IsTrue)
+
+	s.watcher.ClearReplayHooks()
+	snap = s.watcher.Snapshot()
+	c.Assert(snap.ReplayHooks, jc.IsFalse)
+}
+
+func (s *WatcherSuite) TestOlderController(c *gc.C) {
+	// Restart the watcher against a controller with an older version
+	// of the Uniter facade, which does not report the unit's series
+	// or support secrets or replaying skipped hooks.
+	s.watcher.Kill()
+	c.Assert(s.watcher.Wait(), jc.ErrorIsNil)
+	s.st = newMockState()
+	s.st.unit.olderController = true
+	s.st.unit.replayHooks = true
+	s.startWatcher(c)
+
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	snap := s.watcher.Snapshot()
+	c.Assert(snap.Series, gc.Equals, "")
+	c.Assert(snap.SecretsToRotate, gc.HasLen, 0)
+	c.Assert(snap.ReplayHooks, jc.IsFalse)
+}
+
 func (s *WatcherSuite) TestLeadershipChanged(c *gc.C) {
 	s.leadership.claimTicket.result = false
 	signalAll(s.st, s.leadership)
@@ -6,6 +6,7 @@ package uniter
 import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/hook"
@@ -17,8 +18,10 @@ import (
 // ResolverConfig defines configuration for the uniter resolver.
 type ResolverConfig struct {
 	ClearResolved       func() error
+	ClearReplayHooks    func() error
 	ReportHookError     func(hook.Info) error
 	FixDeployer         func() error
+	UpdateSeries        func(series string) error
 	ShouldRetryHooks    bool
 	StartRetryHookTimer func()
 	StopRetryHookTimer  func()
@@ -27,6 +30,7 @@ type ResolverConfig struct {
 	Relations           resolver.Resolver
 	Storage             resolver.Resolver
 	Commands            resolver.Resolver
+	Secrets             resolver.Resolver
 }
 
 type uniterResolver struct {
@@ -70,6 +74,11 @@ func (s *uniterResolver) NextOp(
 		if err := s.config.FixDeployer(); err != nil {
 			return nil, errors.Trace(err)
 		}
+		if remoteState.Series != "" {
+			if err := s.config.UpdateSeries(remoteState.Series); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 	}
 
 	if s.retryHookTimerStarted && (localState.Kind != operation.RunHook || localState.Step != operation.Pending) {
@@ -100,6 +109,11 @@ func (s *uniterResolver) NextOp(
 		return op, err
 	}
 
+	op, err = s.config.Secrets.NextOp(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		return op, err
+	}
+
 	switch localState.Kind {
 	case operation.RunHook:
 		switch localState.Step {
@@ -276,6 +290,11 @@ func (s *uniterResolver) nextOp(
 		return op, err
 	}
 
+	op, err = s.nextOpReplayHooks(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		return op, err
+	}
+
 	// UpdateStatus hook runs if nothing else needs to.
 	if localState.UpdateStatusVersion != remoteState.UpdateStatusVersion {
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.UpdateStatus})
@@ -283,3 +302,52 @@ func (s *uniterResolver) nextOp(
 
 	return nil, resolver.ErrNoOperation
 }
+
+// nextOpReplayHooks returns an operation to replay the hooks skipped
+// when resolving hook errors without retrying them, if that has been
+// requested. It is only called once the unit is out of any error state.
+func (s *uniterResolver) nextOpReplayHooks(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	if remoteState.ReplayHooks {
+		if err := s.config.ClearReplayHooks(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(localState.SkippedHooks) > 0 {
+			return opFactory.NewReplayHooks()
+		}
+	}
+	if len(localState.ReplayHooks) == 0 {
+		return nil, resolver.ErrNoOperation
+	}
+	next := localState.ReplayHooks[0]
+	if !canReplayHook(next, localState, remoteState) {
+		return opFactory.NewDiscardReplayHook(next)
+	}
+	return opFactory.NewReplayHook(next)
+}
+
+// canReplayHook reports whether the skipped hook still makes sense to
+// run: the relation or storage it is for must still exist, and
+// leadership hooks must agree with the unit's current leadership.
+func canReplayHook(
+	info hook.Info,
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+) bool {
+	switch {
+	case info.Kind.IsRelation():
+		_, ok := remoteState.Relations[info.RelationId]
+		return ok
+	case info.Kind.IsStorage():
+		_, ok := remoteState.Storage[names.NewStorageTag(info.StorageId)]
+		return ok
+	case info.Kind == hook.LeaderElected:
+		return localState.Leader
+	case info.Kind == hook.LeaderDeposed:
+		return !localState.Leader
+	}
+	return true
+}
@@ -33,14 +33,22 @@ type resolverSuite struct {
 	resolver             resolver.Resolver
 	resolverConfig       uniter.ResolverConfig
 
-	clearResolved   func() error
-	reportHookError func(hook.Info) error
+	clearResolved    func() error
+	clearReplayHooks func() error
+	reportHookError  func(hook.Info) error
+	updatedSeries    []string
+}
+
+func (s *resolverSuite) updateSeries(series string) error {
+	s.updatedSeries = append(s.updatedSeries, series)
+	return nil
 }
 
 var _ = gc.Suite(&resolverSuite{})
 
 func (s *resolverSuite) SetUpTest(c *gc.C) {
 	s.stub = testing.Stub{}
+	s.updatedSeries = nil
 	s.charmURL = charm.MustParseURL("cs:precise/mysql-2")
 	s.remoteState = remotestate.Snapshot{
 		CharmModifiedVersion: s.charmModifiedVersion,
@@ -55,14 +63,20 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		return errors.New("unexpected resolved")
 	}
 
+	s.clearReplayHooks = func() error {
+		return errors.New("unexpected replay hooks")
+	}
+
 	s.reportHookError = func(hook.Info) error {
 		return errors.New("unexpected report hook error")
 	}
 
 	s.resolverConfig = uniter.ResolverConfig{
 		ClearResolved:       func() error { return s.clearResolved() },
+		ClearReplayHooks:    func() error { return s.clearReplayHooks() },
 		ReportHookError:     func(info hook.Info) error { return s.reportHookError(info) },
 		FixDeployer:         func() error { return nil },
+		UpdateSeries:        func(series string) error { return s.updateSeries(series) },
 		StartRetryHookTimer: func() { s.stub.AddCall("StartRetryHookTimer") },
 		StopRetryHookTimer:  func() { s.stub.AddCall("StopRetryHookTimer") },
 		ShouldRetryHooks:    true,
@@ -71,6 +85,7 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		Relations:           relation.NewRelationsResolver(&dummyRelations{}),
 		Storage:             storage.NewResolver(attachments),
 		Commands:            nopResolver{},
+		Secrets:             nopResolver{},
 	}
 
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
@@ -253,3 +268,102 @@ func (s *resolverSuite) TestRunHookStopRetryTimer(c *gc.C) {
 	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StopRetryHookTimer")
 }
+
+func (s *resolverSuite) TestUpdateSeries(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: false,
+			Started:   true,
+		},
+	}
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	c.Assert(s.updatedSeries, gc.HasLen, 0)
+
+	s.remoteState.Series = "xenial"
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	c.Assert(s.updatedSeries, jc.DeepEquals, []string{"xenial"})
+}
+
+func (s *resolverSuite) TestReplayHooksRequested(c *gc.C) {
+	s.clearReplayHooks = func() error {
+		s.stub.AddCall("ClearReplayHooks")
+		return nil
+	}
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:         operation.Continue,
+			Installed:    true,
+			Started:      true,
+			SkippedHooks: []hook.Info{{Kind: hooks.ConfigChanged}},
+		},
+	}
+	s.remoteState.ReplayHooks = true
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "queue skipped hooks for replay")
+	s.stub.CheckCallNames(c, "ClearReplayHooks")
+
+	// Nothing is queued when there are no skipped hooks.
+	localState.SkippedHooks = nil
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCallNames(c, "ClearReplayHooks", "ClearReplayHooks")
+}
+
+func (s *resolverSuite) TestReplayHook(c *gc.C) {
+	relationChanged := hook.Info{
+		Kind:       hooks.RelationChanged,
+		RelationId: 1,
+		RemoteUnit: "mysql/0",
+	}
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:        operation.Continue,
+			Installed:   true,
+			Started:     true,
+			ReplayHooks: []hook.Info{relationChanged},
+		},
+	}
+	s.remoteState.Relations = map[int]remotestate.RelationSnapshot{
+		1: {Life: params.Alive},
+	}
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "replay relation-changed (1; mysql/0) hook")
+
+	// The hook is discarded once its relation has gone.
+	s.remoteState.Relations = nil
+	op, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "discard relation-changed (1; mysql/0) hook queued for replay")
+}
+
+func (s *resolverSuite) TestReplayHookWaitsForHookError(c *gc.C) {
+	s.reportHookError = func(hook.Info) error { return nil }
+	s.resolverConfig.ShouldRetryHooks = false
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:        operation.RunHook,
+			Step:        operation.Pending,
+			Installed:   true,
+			Started:     true,
+			Hook:        &hook.Info{Kind: hooks.ConfigChanged},
+			ReplayHooks: []hook.Info{{Kind: hooks.ConfigChanged}},
+		},
+	}
+	s.remoteState.ReplayHooks = true
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
@@ -159,6 +159,10 @@ type HookContext struct {
 	// proxySettings are the current proxy settings that the uniter knows about.
 	proxySettings proxy.Settings
 
+	// hookTimeout is the maximum time a hook may run for, as set in
+	// the model config. Zero means there is no limit.
+	hookTimeout time.Duration
+
 	// meterStatus is the status of the unit's metering.
 	meterStatus *meterStatus
 
@@ -189,6 +193,9 @@ type HookContext struct {
 	// storageId is the tag of the storage instance associated with the running hook.
 	storageTag names.StorageTag
 
+	// secretId is the ID of the secret associated with the running hook.
+	secretId string
+
 	// hasRunSetStatus is true if a call to the status-set was made during the
 	// invocation of a hook.
 	// This attribute is persisted to local uniter state at the end of the hook
@@ -384,6 +391,12 @@ func (ctx *HookContext) ResetExecutionSetUnitStatus() {
 	ctx.hasRunStatusSet = false
 }
 
+// HookTimeout returns the maximum time a hook may run for, as set in
+// the model config. Zero means there is no limit.
+func (ctx *HookContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
 func (ctx *HookContext) PublicAddress() (string, error) {
 	if ctx.publicAddress == "" {
 		return "", errors.NotFoundf("public address")
@@ -475,6 +488,26 @@ func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	return result, nil
 }
 
+// GoalState returns the units expected in the unit's application and
+// in the applications related to it.
+func (ctx *HookContext) GoalState() (*params.GoalState, error) {
+	goalState, err := ctx.unit.GoalState()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &goalState, nil
+}
+
+// CloudSpec returns the model's cloud specification and credential,
+// if the unit's application is trusted to read them.
+func (ctx *HookContext) CloudSpec() (*params.CloudSpec, error) {
+	spec, err := ctx.unit.CloudSpec()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return spec, nil
+}
+
 // ActionName returns the name of the action.
 func (ctx *HookContext) ActionName() (string, error) {
 	if ctx.actionData == nil {
@@ -591,6 +624,9 @@ func (context *HookContext) HookVars(paths Paths) ([]string, error) {
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	if context.secretId != "" {
+		vars = append(vars, "JUJU_SECRET_ID="+context.secretId)
+	}
 	if context.actionData != nil {
 		vars = append(vars,
 			"JUJU_ACTION_NAME="+context.actionData.Name,
@@ -98,6 +98,29 @@ func (s *InterfaceSuite) TestUnitNetworkConfig(c *gc.C) {
 	c.Check(netConfig, gc.IsNil)
 }
 
+func (s *InterfaceSuite) TestGoalState(c *gc.C) {
+	// The goal state computation is tested in apiserver/uniter; this
+	// only checks that the context passes it through.
+	ctx := s.GetContext(c, -1, "")
+	goalState, err := ctx.GoalState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(goalState.Units, gc.DeepEquals, params.UnitsGoalState{
+		"u/0": {Status: "waiting"},
+	})
+}
+
+func (s *InterfaceSuite) TestCloudSpec(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	_, err := ctx.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, `application "u" is not trusted to access the cloud credential`)
+
+	err = s.service.SetTrust(true)
+	c.Assert(err, jc.ErrorIsNil)
+	spec, err := ctx.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Type, gc.Equals, "dummy")
+}
+
 func (s *InterfaceSuite) TestUnitStatus(c *gc.C) {
 	ctx := s.GetContext(c, -1, "")
 	defer context.PatchCachedStatus(ctx.(runner.Context), "maintenance", "working", map[string]interface{}{"hello": "world"})()
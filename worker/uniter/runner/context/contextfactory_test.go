@@ -162,6 +162,18 @@ func (s *ContextFactorySuite) TestRelationHookContext(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *ContextFactorySuite) TestSecretRotateHookContext(c *gc.C) {
+	hi := hook.Info{
+		Kind:     hook.SecretRotate,
+		SecretId: "9f5a6ab4",
+	}
+	ctx, err := s.factory.HookContext(hi)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertCoreContext(c, ctx)
+	s.AssertNotRelationContext(c, ctx)
+	c.Assert(context.ContextSecretId(ctx), gc.Equals, "9f5a6ab4")
+}
+
 func (s *ContextFactorySuite) TestNewHookContextWithStorage(c *gc.C) {
 	// We need to set up a unit that has storage metadata defined.
 	ch := s.AddTestingCharm(c, "storage-block")
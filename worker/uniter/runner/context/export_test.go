@@ -164,6 +164,10 @@ func ContextEnvInfo(hctx *HookContext) (name, uuid string) {
 	return hctx.envName, hctx.uuid
 }
 
+func ContextSecretId(hctx *HookContext) string {
+	return hctx.secretId
+}
+
 func ContextMachineTag(hctx *HookContext) names.MachineTag {
 	return hctx.assignedMachineTag
 }
@@ -0,0 +1,57 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// CreateSecret is part of the jujuc.Context interface.
+func (ctx *HookContext) CreateSecret(args *jujuc.SecretCreateArgs) (string, error) {
+	var owner names.Tag = ctx.unit.Tag()
+	if args.ApplicationOwned {
+		owner = ctx.unit.ApplicationTag()
+	}
+	id, err := ctx.state.CreateSecret(params.CreateSecretArg{
+		OwnerTag:       owner.String(),
+		Label:          args.Label,
+		Description:    args.Description,
+		Data:           args.Value,
+		RotateInterval: args.RotateInterval,
+	})
+	return id, errors.Trace(err)
+}
+
+// UpdateSecret is part of the jujuc.Context interface.
+func (ctx *HookContext) UpdateSecret(id string, args *jujuc.SecretUpdateArgs) error {
+	err := ctx.state.UpdateSecret(params.UpdateSecretArg{
+		ID:             id,
+		Data:           args.Value,
+		Description:    args.Description,
+		RotateInterval: args.RotateInterval,
+	})
+	return errors.Trace(err)
+}
+
+// GetSecret is part of the jujuc.Context interface.
+func (ctx *HookContext) GetSecret(id, label string) (map[string]string, error) {
+	value, err := ctx.state.SecretValue(params.GetSecretArg{ID: id, Label: label})
+	return value, errors.Trace(err)
+}
+
+// GrantSecret is part of the jujuc.Context interface.
+func (ctx *HookContext) GrantSecret(id, application string) error {
+	err := ctx.state.GrantSecret(id, names.NewApplicationTag(application))
+	return errors.Trace(err)
+}
+
+// RevokeSecret is part of the jujuc.Context interface.
+func (ctx *HookContext) RevokeSecret(id, application string) error {
+	err := ctx.state.RevokeSecret(id, names.NewApplicationTag(application))
+	return errors.Trace(err)
+}
@@ -5,6 +5,7 @@ package runner
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 )
@@ -28,3 +29,25 @@ func IsBadActionError(err error) bool {
 func NewBadActionError(actionName, problem string) error {
 	return &badActionError{actionName, problem}
 }
+
+type hookTimedOutError struct {
+	hookName string
+	timeout  time.Duration
+}
+
+func (e *hookTimedOutError) Error() string {
+	return fmt.Sprintf("hook %q timed out after %v", e.hookName, e.timeout)
+}
+
+// IsHookTimedOutError returns whether the error was returned because a
+// hook ran for longer than its timeout and was killed.
+func IsHookTimedOutError(err error) bool {
+	_, ok := errors.Cause(err).(*hookTimedOutError)
+	return ok
+}
+
+// NewHookTimedOutError returns an error indicating that the named hook
+// was killed after running for longer than the given timeout.
+func NewHookTimedOutError(hookName string, timeout time.Duration) error {
+	return &hookTimedOutError{hookName, timeout}
+}
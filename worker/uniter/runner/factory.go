@@ -5,6 +5,7 @@ package runner
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -38,6 +39,7 @@ func NewFactory(
 	state *uniter.State,
 	paths context.Paths,
 	contextFactory context.ContextFactory,
+	clock clock.Clock,
 ) (
 	Factory, error,
 ) {
@@ -45,6 +47,7 @@ func NewFactory(
 		state:          state,
 		paths:          paths,
 		contextFactory: contextFactory,
+		clock:          clock,
 	}
 
 	return f, nil
@@ -58,6 +61,7 @@ type factory struct {
 
 	// Fields that shouldn't change in a factory's lifetime.
 	paths context.Paths
+	clock clock.Clock
 }
 
 // NewCommandRunner exists to satisfy the Factory interface.
@@ -66,7 +70,7 @@ func (f *factory) NewCommandRunner(commandInfo context.CommandInfo) (Runner, err
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
+	runner := NewRunner(ctx, f.paths, f.clock)
 	return runner, nil
 }
 
@@ -80,7 +84,7 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
+	runner := NewRunner(ctx, f.paths, f.clock)
 	return runner, nil
 }
 
@@ -123,7 +127,7 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 
 	actionData := context.NewActionData(name, &tag, params)
 	ctx, err := f.contextFactory.ActionContext(actionData)
-	runner := NewRunner(ctx, f.paths)
+	runner := NewRunner(ctx, f.paths, f.clock)
 	return runner, nil
 }
 
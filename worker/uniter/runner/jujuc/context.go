@@ -53,6 +53,7 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextSecrets
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -106,6 +107,14 @@ type ContextUnit interface {
 
 	// Config returns the current service configuration of the executing unit.
 	ConfigSettings() (charm.Settings, error)
+
+	// GoalState returns the units expected in the executing unit's
+	// application and in the applications related to it.
+	GoalState() (*params.GoalState, error)
+
+	// CloudSpec returns the model's cloud specification and credential.
+	// Only units of trusted applications may read it.
+	CloudSpec() (*params.CloudSpec, error)
 }
 
 // ContextStatus is the part of a hook context related to the unit's status.
@@ -186,6 +195,49 @@ type ContextLeadership interface {
 	WriteLeaderSettings(map[string]string) error
 }
 
+// ContextSecrets is the part of a hook context related to secrets
+// owned by, or shared with, the unit and its application.
+type ContextSecrets interface {
+	// CreateSecret creates a secret with the supplied value and settings,
+	// and returns its ID. The secret is owned by the unit, or by its
+	// application if requested and the unit is the application's leader.
+	CreateSecret(*SecretCreateArgs) (string, error)
+
+	// UpdateSecret changes the value or settings of the secret with the
+	// supplied ID.
+	UpdateSecret(string, *SecretUpdateArgs) error
+
+	// GetSecret returns the value of the secret with the supplied ID or,
+	// if the ID is empty, of the secret the unit or its application
+	// created with the supplied label.
+	GetSecret(id, label string) (map[string]string, error)
+
+	// GrantSecret allows the units of the supplied related application
+	// to read the secret with the supplied ID.
+	GrantSecret(id, application string) error
+
+	// RevokeSecret stops the units of the supplied application from
+	// reading the secret with the supplied ID.
+	RevokeSecret(id, application string) error
+}
+
+// SecretCreateArgs holds the parameters for creating a secret.
+type SecretCreateArgs struct {
+	Value            map[string]string
+	Label            string
+	Description      string
+	RotateInterval   time.Duration
+	ApplicationOwned bool
+}
+
+// SecretUpdateArgs holds the changes to make to a secret. Nil fields
+// are left unchanged.
+type SecretUpdateArgs struct {
+	Value          map[string]string
+	Description    *string
+	RotateInterval *time.Duration
+}
+
 // ContextMetrics is the part of a hook context related to metrics.
 type ContextMetrics interface {
 	// AddMetric records a metric to return after hook execution.
@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+)
+
+// credentialGetCommand implements the credential-get command.
+type credentialGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+}
+
+// NewCredentialGetCommand returns a new credentialGetCommand with the
+// given context.
+func NewCredentialGetCommand(ctx Context) (cmd.Command, error) {
+	return &credentialGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *credentialGetCommand) Info() *cmd.Info {
+	doc := `
+credential-get prints the model's cloud specification: the type and name
+of the cloud, the region and endpoints in use, and the credential the
+model authenticates with.
+
+Only units of applications that have been trusted by the operator, with
+"juju deploy --trust" or "juju trust", may read the credential. Each read
+is recorded in the controller's audit log.
+`
+	return &cmd.Info{
+		Name:    "credential-get",
+		Purpose: "print the model's cloud specification and credential",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *credentialGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *credentialGetCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *credentialGetCommand) Run(ctx *cmd.Context) error {
+	spec, err := c.ctx.CloudSpec()
+	if err != nil {
+		return errors.Annotate(err, "cannot read cloud credential")
+	}
+	out := cloudSpecOutput{
+		Type:            spec.Type,
+		Name:            spec.Name,
+		Region:          spec.Region,
+		Endpoint:        spec.Endpoint,
+		StorageEndpoint: spec.StorageEndpoint,
+	}
+	if spec.Credential != nil {
+		out.Credential = &credentialOutput{
+			AuthType:   spec.Credential.AuthType,
+			Attributes: spec.Credential.Attributes,
+		}
+	}
+	return c.out.Write(ctx, out)
+}
+
+// cloudSpecOutput is the serialisation format of credential-get.
+type cloudSpecOutput struct {
+	Type            string            `yaml:"type" json:"type"`
+	Name            string            `yaml:"name" json:"name"`
+	Region          string            `yaml:"region,omitempty" json:"region,omitempty"`
+	Endpoint        string            `yaml:"endpoint,omitempty" json:"endpoint,omitempty"`
+	StorageEndpoint string            `yaml:"storage-endpoint,omitempty" json:"storage-endpoint,omitempty"`
+	Credential      *credentialOutput `yaml:"credential,omitempty" json:"credential,omitempty"`
+}
+
+type credentialOutput struct {
+	AuthType   string            `yaml:"auth-type" json:"auth-type"`
+	Attributes map[string]string `yaml:"attrs,omitempty" json:"attrs,omitempty"`
+}
@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type CredentialGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&CredentialGetSuite{})
+
+func (s *CredentialGetSuite) createCommand(c *gc.C, err error) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Unit.CloudSpec = params.CloudSpec{
+		Type:     "ec2",
+		Name:     "aws",
+		Region:   "us-east-1",
+		Endpoint: "https://ec2.us-east-1.amazonaws.com",
+		Credential: &params.CloudCredential{
+			AuthType: "access-key",
+			Attributes: map[string]string{
+				"access-key": "key",
+				"secret-key": "secret",
+			},
+		},
+	}
+	s.Stub.SetErrors(err)
+	com, err := jujuc.NewCommand(hctx, cmdString("credential-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+func (s *CredentialGetSuite) TestOutputFormat(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		out  string
+	}{{
+		nil,
+		"" +
+			"type: ec2\n" +
+			"name: aws\n" +
+			"region: us-east-1\n" +
+			"endpoint: https://ec2.us-east-1.amazonaws.com\n" +
+			"credential:\n" +
+			"  auth-type: access-key\n" +
+			"  attrs:\n" +
+			"    access-key: key\n" +
+			"    secret-key: secret\n",
+	}, {
+		[]string{"--format", "json"},
+		`{"type":"ec2","name":"aws","region":"us-east-1","endpoint":"https://ec2.us-east-1.amazonaws.com",` +
+			`"credential":{"auth-type":"access-key","attrs":{"access-key":"key","secret-key":"secret"}}}` + "\n",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		com := s.createCommand(c, nil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+	}
+}
+
+func (s *CredentialGetSuite) TestNotTrusted(c *gc.C) {
+	com := s.createCommand(c, errors.New(`application "u" is not trusted to access the cloud credential`))
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot read cloud credential: application \"u\" is not trusted to access the cloud credential\n")
+}
+
+func (s *CredentialGetSuite) TestInitError(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"foo"})
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: unrecognized args: [\"foo\"]\n")
+}
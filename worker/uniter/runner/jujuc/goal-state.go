@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+)
+
+// goalStateCommand implements the goal-state command.
+type goalStateCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+}
+
+// NewGoalStateCommand returns a new goalStateCommand with the given context.
+func NewGoalStateCommand(ctx Context) (cmd.Command, error) {
+	return &goalStateCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *goalStateCommand) Info() *cmd.Info {
+	doc := `
+goal-state prints the units expected in the unit's application, and, for
+each of the application's relation endpoints, the applications and units
+expected at the other end of the relation.
+
+Unlike relation-list, which only shows units that have already joined a
+relation, goal-state is computed from the units and relations that the
+model is expected to contain. Each unit is given one of these statuses:
+
+    waiting   the unit has been added but its agent has not yet started
+    joining   the unit is running but has not yet joined the relation
+    joined    the unit is running (and, for relations, has joined)
+    dying     the unit, or the relation, is being removed
+`
+	return &cmd.Info{
+		Name:    "goal-state",
+		Purpose: "print the intended topology of the unit's application and relations",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *goalStateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *goalStateCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *goalStateCommand) Run(ctx *cmd.Context) error {
+	goalState, err := c.ctx.GoalState()
+	if err != nil {
+		return errors.Annotate(err, "cannot read goal state")
+	}
+	return c.out.Write(ctx, goalState)
+}
@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type GoalStateSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&GoalStateSuite{})
+
+func (s *GoalStateSuite) createCommand(c *gc.C, err error) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Unit.GoalState = params.GoalState{
+		Units: params.UnitsGoalState{
+			"mysql/0": {Status: "joined"},
+			"mysql/1": {Status: "waiting"},
+		},
+		Relations: map[string]params.UnitsGoalState{
+			"server": {
+				"wordpress":   {Status: "joined"},
+				"wordpress/0": {Status: "joining"},
+			},
+		},
+	}
+	s.Stub.SetErrors(err)
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+func (s *GoalStateSuite) TestOutputFormat(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		out  string
+	}{{
+		nil,
+		"" +
+			"units:\n" +
+			"  mysql/0:\n" +
+			"    status: joined\n" +
+			"  mysql/1:\n" +
+			"    status: waiting\n" +
+			"relations:\n" +
+			"  server:\n" +
+			"    wordpress:\n" +
+			"      status: joined\n" +
+			"    wordpress/0:\n" +
+			"      status: joining\n",
+	}, {
+		[]string{"--format", "json"},
+		`{"units":{"mysql/0":{"status":"joined"},"mysql/1":{"status":"waiting"}},` +
+			`"relations":{"server":{"wordpress":{"status":"joined"},"wordpress/0":{"status":"joining"}}}}` + "\n",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		com := s.createCommand(c, nil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+	}
+}
+
+func (s *GoalStateSuite) TestInitError(c *gc.C) {
+	com := s.createCommand(c, nil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"foo"})
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: unrecognized args: [\"foo\"]\n")
+}
+
+func (s *GoalStateSuite) TestGoalStateError(c *gc.C) {
+	com := s.createCommand(c, errors.New("boom"))
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot read goal state: boom\n")
+}
@@ -25,6 +25,12 @@ type RestrictedContext struct{}
 // ConfigSettings implements jujuc.Context.
 func (*RestrictedContext) ConfigSettings() (charm.Settings, error) { return nil, ErrRestrictedContext }
 
+// GoalState implements jujuc.Context.
+func (*RestrictedContext) GoalState() (*params.GoalState, error) { return nil, ErrRestrictedContext }
+
+// CloudSpec implements jujuc.Context.
+func (*RestrictedContext) CloudSpec() (*params.CloudSpec, error) { return nil, ErrRestrictedContext }
+
 // UnitStatus implements jujuc.Context.
 func (*RestrictedContext) UnitStatus() (*StatusInfo, error) { return nil, ErrRestrictedContext }
 
@@ -69,6 +75,25 @@ func (*RestrictedContext) NetworkConfig(bindingName string) ([]params.NetworkCon
 	return nil, ErrRestrictedContext
 }
 
+// CreateSecret implements jujuc.Context.
+func (*RestrictedContext) CreateSecret(*SecretCreateArgs) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// UpdateSecret implements jujuc.Context.
+func (*RestrictedContext) UpdateSecret(string, *SecretUpdateArgs) error { return ErrRestrictedContext }
+
+// GetSecret implements jujuc.Context.
+func (*RestrictedContext) GetSecret(id, label string) (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// GrantSecret implements jujuc.Context.
+func (*RestrictedContext) GrantSecret(id, application string) error { return ErrRestrictedContext }
+
+// RevokeSecret implements jujuc.Context.
+func (*RestrictedContext) RevokeSecret(id, application string) error { return ErrRestrictedContext }
+
 // IsLeader implements jujuc.Context.
 func (*RestrictedContext) IsLeader() (bool, error) { return false, ErrRestrictedContext }
 
@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+	"launchpad.net/gnuflag"
+)
+
+// secretAddCommand implements the secret-add command.
+type secretAddCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	owner          string
+	label          string
+	description    string
+	rotateInterval time.Duration
+	value          map[string]string
+}
+
+// NewSecretAddCommand returns a new secretAddCommand with the given context.
+func NewSecretAddCommand(ctx Context) (cmd.Command, error) {
+	return &secretAddCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretAddCommand) Info() *cmd.Info {
+	doc := `
+secret-add stores the supplied key/value pairs as a new secret, encrypted by
+the controller, and prints the secret's ID. The secret is owned by the unit
+unless --owner application is given, in which case it is owned by the
+application and may only be created by the application's leader.
+
+A label may be given to find the secret later with secret-get --label. If a
+rotation interval is given, the secret-rotate hook will run on the unit
+responsible for the secret each time the interval elapses.
+`
+	return &cmd.Info{
+		Name:    "secret-add",
+		Args:    "<key>=<value> [...]",
+		Purpose: "add a new secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretAddCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.owner, "owner", "unit", `the owner of the secret, "unit" or "application"`)
+	f.StringVar(&c.label, "label", "", "a label to find the secret by")
+	f.StringVar(&c.description, "description", "", "a description of the secret")
+	f.DurationVar(&c.rotateInterval, "rotate", 0, "how often the secret should be rotated")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretAddCommand) Init(args []string) (err error) {
+	if c.owner != "unit" && c.owner != "application" {
+		return errors.Errorf(`owner must be "unit" or "application", got %q`, c.owner)
+	}
+	if c.rotateInterval < 0 {
+		return errors.Errorf("rotate interval %v not valid", c.rotateInterval)
+	}
+	if len(args) == 0 {
+		return errors.New("no secret value specified")
+	}
+	c.value, err = keyvalues.Parse(args, true)
+	return err
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretAddCommand) Run(ctx *cmd.Context) error {
+	id, err := c.ctx.CreateSecret(&SecretCreateArgs{
+		Value:            c.value,
+		Label:            c.label,
+		Description:      c.description,
+		RotateInterval:   c.rotateInterval,
+		ApplicationOwned: c.owner == "application",
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot add secret")
+	}
+	fmt.Fprintln(ctx.Stdout, id)
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretAddSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretAddSuite{})
+
+func (s *SecretAddSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *SecretAddSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret value specified",
+	}, {
+		args: []string{"nonsense"},
+		err:  `expected "key=value", got "nonsense"`,
+	}, {
+		args: []string{"--owner", "model", "foo=bar"},
+		err:  `owner must be "unit" or "application", got "model"`,
+	}, {
+		args: []string{"--rotate", "-1h", "foo=bar"},
+		err:  `rotate interval -1h0m0s not valid`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, com := s.createCommand(c, nil)
+		err := testing.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretAddSuite) TestAdd(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{
+		"--owner", "application", "--label", "db", "--description", "database password",
+		"--rotate", "24h", "password=sekrit",
+	})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "secret-0\n")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCall(c, 0, "CreateSecret", &jujuc.SecretCreateArgs{
+		Value:            map[string]string{"password": "sekrit"},
+		Label:            "db",
+		Description:      "database password",
+		RotateInterval:   24 * time.Hour,
+		ApplicationOwned: true,
+	})
+	c.Check(hctx.info.Secrets.Labels, jc.DeepEquals, map[string]string{"db": "secret-0"})
+}
+
+func (s *SecretAddSuite) TestAddError(c *gc.C) {
+	_, com := s.createCommand(c, errors.New("not the leader"))
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"password=sekrit"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot add secret: not the leader\n")
+}
@@ -0,0 +1,78 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+)
+
+// secretGetCommand implements the secret-get command.
+type secretGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	id    string
+	label string
+	key   string
+	out   cmd.Output
+}
+
+// NewSecretGetCommand returns a new secretGetCommand with the given context.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &secretGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGetCommand) Info() *cmd.Info {
+	doc := `
+secret-get prints the value of the secret with the given ID, or, with --label,
+of the secret the unit or its application added with that label. A unit may
+read secrets owned by itself or its application, and secrets that a related
+application has granted it access to. If a key is given, only the value of
+that key is printed.
+`
+	return &cmd.Info{
+		Name:    "secret-get",
+		Args:    "[<ID>] [<key>]",
+		Purpose: "print the value of a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.StringVar(&c.label, "label", "", "the label of the secret to print")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGetCommand) Init(args []string) error {
+	if c.label == "" {
+		if len(args) == 0 {
+			return errors.New("no secret ID or label specified")
+		}
+		c.id, args = args[0], args[1:]
+	}
+	if len(args) > 0 {
+		c.key, args = args[0], args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGetCommand) Run(ctx *cmd.Context) error {
+	value, err := c.ctx.GetSecret(c.id, c.label)
+	if err != nil {
+		return errors.Annotate(err, "cannot read secret")
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, value)
+	}
+	if v, ok := value[c.key]; ok {
+		return c.out.Write(ctx, v)
+	}
+	return c.out.Write(ctx, nil)
+}
@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGetSuite{})
+
+func (s *SecretGetSuite) createCommand(c *gc.C) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Secrets.Values = map[string]map[string]string{
+		"secret-0": {"password": "sekrit", "user": "admin"},
+	}
+	hctx.info.Secrets.Labels = map[string]string{"db": "secret-0"}
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+func (s *SecretGetSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret ID or label specified",
+	}, {
+		args: []string{"secret-0", "password", "user"},
+		err:  `unrecognized args: \["user"\]`,
+	}, {
+		args: []string{"--label", "db", "password", "user"},
+		err:  `unrecognized args: \["user"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		err := testing.InitCommand(s.createCommand(c), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretGetSuite) TestGet(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		out  string
+	}{{
+		args: []string{"secret-0"},
+		out:  "password: sekrit\nuser: admin\n",
+	}, {
+		args: []string{"secret-0", "password"},
+		out:  "sekrit\n",
+	}, {
+		args: []string{"--label", "db", "user"},
+		out:  "admin\n",
+	}, {
+		args: []string{"--format", "json", "secret-0", "password"},
+		out:  `"sekrit"` + "\n",
+	}, {
+		args: []string{"secret-0", "missing"},
+		out:  "",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		ctx := testing.Context(c)
+		code := cmd.Main(s.createCommand(c), ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	}
+}
+
+func (s *SecretGetSuite) TestGetNotFound(c *gc.C) {
+	ctx := testing.Context(c)
+	code := cmd.Main(s.createCommand(c), ctx, []string{"secret-1"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot read secret: secret \"secret-1\" not found\n")
+}
@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+// secretGrantCommand implements the secret-grant command.
+type secretGrantCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	id          string
+	application string
+}
+
+// NewSecretGrantCommand returns a new secretGrantCommand with the given context.
+func NewSecretGrantCommand(ctx Context) (cmd.Command, error) {
+	return &secretGrantCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGrantCommand) Info() *cmd.Info {
+	doc := `
+secret-grant allows the units of a related application to read the secret
+with the given ID. Access may later be withdrawn with secret-revoke.
+`
+	return &cmd.Info{
+		Name:    "secret-grant",
+		Args:    "<ID> <application>",
+		Purpose: "grant a related application access to a secret",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGrantCommand) Init(args []string) (err error) {
+	c.id, c.application, err = parseSecretGrantArgs(args)
+	return err
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGrantCommand) Run(_ *cmd.Context) error {
+	err := c.ctx.GrantSecret(c.id, c.application)
+	return errors.Annotatef(err, "cannot grant access to secret")
+}
+
+// parseSecretGrantArgs returns the secret ID and application name
+// supplied to secret-grant or secret-revoke.
+func parseSecretGrantArgs(args []string) (id, application string, err error) {
+	if len(args) < 2 {
+		return "", "", errors.New("secret ID and application name required")
+	}
+	id, application = args[0], args[1]
+	if !names.IsValidApplication(application) {
+		return "", "", errors.Errorf("invalid application name %q", application)
+	}
+	return id, application, cmd.CheckEmpty(args[2:])
+}
@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGrantSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGrantSuite{})
+
+func (s *SecretGrantSuite) createCommand(c *gc.C, name string) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString(name))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *SecretGrantSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"secret-0"},
+		err:  "secret ID and application name required",
+	}, {
+		args: []string{"secret-0", "mysql/0"},
+		err:  `invalid application name "mysql/0"`,
+	}, {
+		args: []string{"secret-0", "mysql", "wordpress"},
+		err:  `unrecognized args: \["wordpress"\]`,
+	}} {
+		for _, name := range []string{"secret-grant", "secret-revoke"} {
+			c.Logf("test %d: %s %v", i, name, t.args)
+			_, com := s.createCommand(c, name)
+			err := testing.InitCommand(com, t.args)
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
+func (s *SecretGrantSuite) TestGrantAndRevoke(c *gc.C) {
+	hctx, com := s.createCommand(c, "secret-grant")
+	code := cmd.Main(com, testing.Context(c), []string{"secret-0", "mysql"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(hctx.info.Secrets.Grants, jc.DeepEquals, map[string][]string{"secret-0": {"mysql"}})
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-revoke"))
+	c.Assert(err, jc.ErrorIsNil)
+	code = cmd.Main(com, testing.Context(c), []string{"secret-0", "mysql"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(hctx.info.Secrets.Grants["secret-0"], gc.HasLen, 0)
+	s.Stub.CheckCallNames(c, "GrantSecret", "RevokeSecret")
+}
@@ -0,0 +1,49 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+)
+
+// secretRevokeCommand implements the secret-revoke command.
+type secretRevokeCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	id          string
+	application string
+}
+
+// NewSecretRevokeCommand returns a new secretRevokeCommand with the given context.
+func NewSecretRevokeCommand(ctx Context) (cmd.Command, error) {
+	return &secretRevokeCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretRevokeCommand) Info() *cmd.Info {
+	doc := `
+secret-revoke stops the units of an application from reading the secret with
+the given ID, undoing an earlier secret-grant.
+`
+	return &cmd.Info{
+		Name:    "secret-revoke",
+		Args:    "<ID> <application>",
+		Purpose: "revoke an application's access to a secret",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretRevokeCommand) Init(args []string) (err error) {
+	c.id, c.application, err = parseSecretGrantArgs(args)
+	return err
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretRevokeCommand) Run(_ *cmd.Context) error {
+	err := c.ctx.RevokeSecret(c.id, c.application)
+	return errors.Annotatef(err, "cannot revoke access to secret")
+}
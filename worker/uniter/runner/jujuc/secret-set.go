@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+	"launchpad.net/gnuflag"
+)
+
+// secretSetCommand implements the secret-set command.
+type secretSetCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	id             string
+	description    string
+	rotateInterval time.Duration
+	args           *SecretUpdateArgs
+	flagSet        *gnuflag.FlagSet
+}
+
+// NewSecretSetCommand returns a new secretSetCommand with the given context.
+func NewSecretSetCommand(ctx Context) (cmd.Command, error) {
+	return &secretSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretSetCommand) Info() *cmd.Info {
+	doc := `
+secret-set replaces the value of the secret with the given ID with the supplied
+key/value pairs, creating a new revision of the secret; and changes its
+description or rotation interval if requested. Only the unit that owns the
+secret, or the leader of the application that owns it, may change it.
+`
+	return &cmd.Info{
+		Name:    "secret-set",
+		Args:    "<ID> [<key>=<value> ...]",
+		Purpose: "update a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretSetCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.description, "description", "", "a new description of the secret")
+	f.DurationVar(&c.rotateInterval, "rotate", 0, "how often the secret should now be rotated")
+	// The flags are only parsed after SetFlags is called, so store the
+	// flag set to find out in Init which settings were supplied.
+	c.flagSet = f
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretSetCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no secret ID specified")
+	}
+	if c.rotateInterval < 0 {
+		return errors.Errorf("rotate interval %v not valid", c.rotateInterval)
+	}
+	c.id = args[0]
+	c.args = &SecretUpdateArgs{}
+	if len(args) > 1 {
+		c.args.Value, err = keyvalues.Parse(args[1:], true)
+		if err != nil {
+			return err
+		}
+	}
+	c.flagSet.Visit(func(flag *gnuflag.Flag) {
+		switch flag.Name {
+		case "description":
+			c.args.Description = &c.description
+		case "rotate":
+			c.args.RotateInterval = &c.rotateInterval
+		}
+	})
+	if c.args.Value == nil && c.args.Description == nil && c.args.RotateInterval == nil {
+		return errors.New("nothing to update")
+	}
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretSetCommand) Run(_ *cmd.Context) error {
+	err := c.ctx.UpdateSecret(c.id, c.args)
+	return errors.Annotatef(err, "cannot update secret")
+}
@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretSetSuite{})
+
+func (s *SecretSetSuite) createCommand(c *gc.C) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Secrets.Values = map[string]map[string]string{
+		"secret-0": {"password": "sekrit"},
+	}
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *SecretSetSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret ID specified",
+	}, {
+		args: []string{"secret-0"},
+		err:  "nothing to update",
+	}, {
+		args: []string{"secret-0", "nonsense"},
+		err:  `expected "key=value", got "nonsense"`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, com := s.createCommand(c)
+		err := testing.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretSetSuite) TestSetValue(c *gc.C) {
+	hctx, com := s.createCommand(c)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret-0", "password=changed"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCall(c, 0, "UpdateSecret", "secret-0", &jujuc.SecretUpdateArgs{
+		Value: map[string]string{"password": "changed"},
+	})
+	c.Check(hctx.info.Secrets.Values["secret-0"], jc.DeepEquals, map[string]string{"password": "changed"})
+}
+
+func (s *SecretSetSuite) TestSetSettings(c *gc.C) {
+	_, com := s.createCommand(c)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret-0", "--description", "", "--rotate", "1h"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	description := ""
+	interval := time.Hour
+	s.Stub.CheckCall(c, 0, "UpdateSecret", "secret-0", &jujuc.SecretUpdateArgs{
+		Description:    &description,
+		RotateInterval: &interval,
+	})
+}
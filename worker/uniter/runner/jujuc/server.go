@@ -45,6 +45,7 @@ func RegisterCommand(name string, f creator) {
 var baseCommands = map[string]creator{
 	"close-port" + cmdSuffix:              NewClosePortCommand,
 	"config-get" + cmdSuffix:              NewConfigGetCommand,
+	"credential-get" + cmdSuffix:          NewCredentialGetCommand,
 	"juju-log" + cmdSuffix:                NewJujuLogCommand,
 	"open-port" + cmdSuffix:               NewOpenPortCommand,
 	"opened-ports" + cmdSuffix:            NewOpenedPortsCommand,
@@ -61,6 +62,7 @@ var baseCommands = map[string]creator{
 	"status-get" + cmdSuffix:              NewStatusGetCommand,
 	"status-set" + cmdSuffix:              NewStatusSetCommand,
 	"network-get" + cmdSuffix:             NewNetworkGetCommand,
+	"goal-state" + cmdSuffix:              NewGoalStateCommand,
 	"application-version-set" + cmdSuffix: NewApplicationVersionSetCommand,
 }
 
@@ -76,6 +78,14 @@ var leaderCommands = map[string]creator{
 	"leader-set" + cmdSuffix: NewLeaderSetCommand,
 }
 
+var secretCommands = map[string]creator{
+	"secret-add" + cmdSuffix:    NewSecretAddCommand,
+	"secret-get" + cmdSuffix:    NewSecretGetCommand,
+	"secret-grant" + cmdSuffix:  NewSecretGrantCommand,
+	"secret-revoke" + cmdSuffix: NewSecretRevokeCommand,
+	"secret-set" + cmdSuffix:    NewSecretSetCommand,
+}
+
 func allEnabledCommands() map[string]creator {
 	all := map[string]creator{}
 	add := func(m map[string]creator) {
@@ -86,6 +96,7 @@ func allEnabledCommands() map[string]creator {
 	add(baseCommands)
 	add(storageCommands)
 	add(leaderCommands)
+	add(secretCommands)
 	add(registeredCommands)
 	return all
 }
@@ -253,6 +253,8 @@ var newCommandTests = []struct {
 }{
 	{"close-port", ""},
 	{"config-get", ""},
+	{"credential-get", ""},
+	{"goal-state", ""},
 	{"juju-log", ""},
 	{"open-port", ""},
 	{"opened-ports", ""},
@@ -265,6 +267,11 @@ var newCommandTests = []struct {
 	{"storage-get", ""},
 	{"status-get", ""},
 	{"status-set", ""},
+	{"secret-add", ""},
+	{"secret-get", ""},
+	{"secret-grant", ""},
+	{"secret-revoke", ""},
+	{"secret-set", ""},
 	// The error message contains .exe on Windows
 	{"random", "unknown command: random(.exe)?"},
 }
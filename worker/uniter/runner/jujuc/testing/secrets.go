@@ -0,0 +1,113 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Secrets holds the values for the hook context.
+type Secrets struct {
+	// Values maps secret IDs to their values.
+	Values map[string]map[string]string
+	// Labels maps labels to secret IDs.
+	Labels map[string]string
+	// Grants maps secret IDs to the applications that may read them.
+	Grants map[string][]string
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// CreateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) CreateSecret(args *jujuc.SecretCreateArgs) (string, error) {
+	c.stub.AddCall("CreateSecret", args)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	if c.info.Values == nil {
+		c.info.Values = make(map[string]map[string]string)
+	}
+	id := fmt.Sprintf("secret-%d", len(c.info.Values))
+	c.info.Values[id] = args.Value
+	if args.Label != "" {
+		if c.info.Labels == nil {
+			c.info.Labels = make(map[string]string)
+		}
+		c.info.Labels[args.Label] = id
+	}
+	return id, nil
+}
+
+// UpdateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) UpdateSecret(id string, args *jujuc.SecretUpdateArgs) error {
+	c.stub.AddCall("UpdateSecret", id, args)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if _, ok := c.info.Values[id]; !ok {
+		return errors.NotFoundf("secret %q", id)
+	}
+	if args.Value != nil {
+		c.info.Values[id] = args.Value
+	}
+	return nil
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GetSecret(id, label string) (map[string]string, error) {
+	c.stub.AddCall("GetSecret", id, label)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if id == "" {
+		id = c.info.Labels[label]
+	}
+	value, ok := c.info.Values[id]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", id)
+	}
+	return value, nil
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GrantSecret(id, application string) error {
+	c.stub.AddCall("GrantSecret", id, application)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.info.Grants == nil {
+		c.info.Grants = make(map[string][]string)
+	}
+	c.info.Grants[id] = append(c.info.Grants[id], application)
+	return nil
+}
+
+// RevokeSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) RevokeSecret(id, application string) error {
+	c.stub.AddCall("RevokeSecret", id, application)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	var grants []string
+	for _, app := range c.info.Grants[id] {
+		if app != application {
+			grants = append(grants, app)
+		}
+	}
+	c.info.Grants[id] = grants
+	return nil
+}
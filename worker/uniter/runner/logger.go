@@ -13,11 +13,13 @@ import (
 )
 
 type hookLogger struct {
-	r       io.ReadCloser
-	done    chan struct{}
-	mu      sync.Mutex
-	stopped bool
-	logger  loggo.Logger
+	r        io.ReadCloser
+	done     chan struct{}
+	mu       sync.Mutex
+	stopped  bool
+	logger   loggo.Logger
+	maxLines int
+	lines    []string
 }
 
 func (l *hookLogger) run() {
@@ -38,6 +40,7 @@ func (l *hookLogger) run() {
 			return
 		}
 		l.logger.Infof("%s", line)
+		l.keep(string(line))
 		l.mu.Unlock()
 	}
 }
@@ -58,3 +61,27 @@ func (l *hookLogger) stop() {
 	l.stopped = true
 	l.mu.Unlock()
 }
+
+// keep records the line, retaining only the last maxLines lines. It
+// must be called with the mutex held.
+func (l *hookLogger) keep(line string) {
+	if l.maxLines <= 0 {
+		return
+	}
+	if len(l.lines) == l.maxLines {
+		l.lines = append(l.lines[:0], l.lines[1:]...)
+	}
+	l.lines = append(l.lines, line)
+}
+
+// tail returns the last lines written by the hook.
+func (l *hookLogger) tail() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.lines) == 0 {
+		return nil
+	}
+	lines := make([]string, len(l.lines))
+	copy(lines, l.lines)
+	return lines
+}
@@ -0,0 +1,19 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup does nothing on Windows, where processes are not
+// grouped for killing.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the process. Processes it has started are
+// left running on Windows.
+func killProcessGroup(p *os.Process) error {
+	return p.Kill()
+}
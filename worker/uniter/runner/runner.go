@@ -19,6 +19,7 @@ import (
 	utilexec "github.com/juju/utils/exec"
 
 	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/charmmeta"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/debug"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -36,6 +37,10 @@ type Runner interface {
 	// RunHook executes the hook with the supplied name.
 	RunHook(name string) error
 
+	// HookOutput returns the last lines written by the most recent
+	// hook run by RunHook.
+	HookOutput() []string
+
 	// RunAction executes the action with the supplied name.
 	RunAction(name string) error
 
@@ -52,20 +57,28 @@ type Context interface {
 	SetProcess(process context.HookProcess)
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
+	HookTimeout() time.Duration
 
 	Prepare() error
 	Flush(badge string, failure error) error
 }
 
-// NewRunner returns a Runner backed by the supplied context and paths.
-func NewRunner(context Context, paths context.Paths) Runner {
-	return &runner{context, paths}
+// NewRunner returns a Runner backed by the supplied context and paths,
+// which uses the clock to time out hooks and commands.
+func NewRunner(context Context, paths context.Paths, clock clock.Clock) Runner {
+	return &runner{context: context, paths: paths, clock: clock}
 }
 
+// hookOutputLines is the number of lines of each hook's output kept
+// for its hook history record.
+const hookOutputLines = 20
+
 // runner implements Runner.
 type runner struct {
 	context Context
 	paths   context.Paths
+	clock   clock.Clock
+	output  []string
 }
 
 func (runner *runner) Context() Context {
@@ -74,13 +87,13 @@ func (runner *runner) Context() Context {
 
 // RunCommands exists to satisfy the Runner interface.
 func (runner *runner) RunCommands(commands string) (*utilexec.ExecResponse, error) {
-	result, err := runner.runCommandsWithTimeout(commands, 0, clock.WallClock)
+	result, err := runner.runCommandsWithTimeout(commands, 0)
 	return result, runner.context.Flush("run commands", err)
 }
 
 // runCommandsWithTimeout is a helper to abstract common code between run commands and
 // juju-run as an action
-func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Duration, clock clock.Clock) (*utilexec.ExecResponse, error) {
+func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Duration) (*utilexec.ExecResponse, error) {
 	srv, err := runner.startJujucServer()
 	if err != nil {
 		return nil, err
@@ -95,7 +108,7 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 		Commands:    commands,
 		WorkingDir:  runner.paths.GetCharmDir(),
 		Environment: env,
-		Clock:       clock,
+		Clock:       runner.clock,
 	}
 
 	err = command.Run()
@@ -108,7 +121,7 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 	if timeout != 0 {
 		cancel = make(chan struct{})
 		go func() {
-			<-clock.After(timeout)
+			<-runner.clock.After(timeout)
 			close(cancel)
 		}()
 	}
@@ -135,7 +148,7 @@ func (runner *runner) runJujuRunAction() (err error) {
 		logger.Debugf("unable to read juju-run action timeout, will continue running action without one")
 	}
 
-	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout))
 
 	if err != nil {
 		return runner.context.Flush("juju-run", err)
@@ -195,15 +208,40 @@ func (runner *runner) RunAction(actionName string) error {
 	if actionName == actions.JujuRunActionName {
 		return runner.runJujuRunAction()
 	}
-	return runner.runCharmHookWithLocation(actionName, "actions")
+	return runner.runCharmHookWithLocation(actionName, "actions", 0)
 }
 
 // RunHook exists to satisfy the Runner interface.
 func (runner *runner) RunHook(hookName string) error {
-	return runner.runCharmHookWithLocation(hookName, "hooks")
+	runner.output = nil
+	timeout, err := runner.hookTimeout()
+	if err != nil {
+		return runner.context.Flush(hookName, err)
+	}
+	return runner.runCharmHookWithLocation(hookName, "hooks", timeout)
+}
+
+// HookOutput exists to satisfy the Runner interface.
+func (runner *runner) HookOutput() []string {
+	return runner.output
+}
+
+// hookTimeout returns the maximum time a hook may run for: the charm's
+// hook-timeout if its metadata sets one, and otherwise the model's.
+func (runner *runner) hookTimeout() (time.Duration, error) {
+	meta, err := charmmeta.ReadDir(runner.paths.GetCharmDir())
+	if errors.IsNotFound(err) {
+		return runner.context.HookTimeout(), nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if meta.HookTimeout != nil {
+		return *meta.HookTimeout, nil
+	}
+	return runner.context.HookTimeout(), nil
 }
 
-func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) error {
+func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, timeout time.Duration) error {
 	srv, err := runner.startJujucServer()
 	if err != nil {
 		return err
@@ -226,12 +264,12 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 		logger.Infof("executing %s via debug-hooks", hookName)
 		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
 	} else {
-		err = runner.runCharmHook(hookName, env, charmLocation)
+		err = runner.runCharmHook(hookName, env, charmLocation, timeout)
 	}
 	return runner.context.Flush(hookName, err)
 }
 
-func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
+func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string, timeout time.Duration) error {
 	charmDir := runner.paths.GetCharmDir()
 	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
 	if err != nil {
@@ -241,6 +279,7 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	setProcessGroup(ps)
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -248,9 +287,10 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	ps.Stdout = outWriter
 	ps.Stderr = outWriter
 	hookLogger := &hookLogger{
-		r:      outReader,
-		done:   make(chan struct{}),
-		logger: runner.getLogger(hookName),
+		r:        outReader,
+		done:     make(chan struct{}),
+		logger:   runner.getLogger(hookName),
+		maxLines: hookOutputLines,
 	}
 	go hookLogger.run()
 	err = ps.Start()
@@ -259,12 +299,37 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
 		// Block until execution finishes
-		err = ps.Wait()
+		err = waitWithTimeout(ps, hookName, timeout, runner.clock)
 	}
 	hookLogger.stop()
+	runner.output = hookLogger.tail()
 	return errors.Trace(err)
 }
 
+// waitWithTimeout waits for the hook's process to finish. If it runs
+// for longer than the timeout, the process and any others it started
+// are killed. A zero timeout means the hook may run for ever.
+func waitWithTimeout(ps *exec.Cmd, hookName string, timeout time.Duration, clock clock.Clock) error {
+	if timeout <= 0 {
+		return ps.Wait()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-clock.After(timeout):
+	}
+	logger.Warningf("hook %q timed out after %v, killing it", hookName, timeout)
+	if err := killProcessGroup(ps.Process); err != nil {
+		logger.Errorf("cannot kill hook %q: %v", hookName, err)
+	}
+	<-done
+	return NewHookTimedOutError(hookName, timeout)
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
@@ -292,3 +357,8 @@ type hookProcess struct {
 func (p hookProcess) Pid() int {
 	return p.Process.Pid
 }
+
+// Kill kills the hook's process along with any others it started.
+func (p hookProcess) Kill() error {
+	return killProcessGroup(p.Process)
+}
@@ -14,11 +14,13 @@ import (
 	"github.com/juju/errors"
 	envtesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/exec"
 	"github.com/juju/utils/proxy"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
@@ -43,7 +45,7 @@ func (s *RunCommandSuite) TestRunCommandsEnvStdOutAndErrAndRC(c *gc.C) {
 	ctx, err := s.contextFactory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
 	c.Assert(err, jc.ErrorIsNil)
 	paths := runnertesting.NewRealPaths(c)
-	runner := runner.NewRunner(ctx, paths)
+	runner := runner.NewRunner(ctx, paths, clock.WallClock)
 
 	commands := `
 echo $JUJU_CHARM_DIR
@@ -120,7 +122,7 @@ func (s *RunHookSuite) TestRunHook(c *gc.C) {
 		c.Assert(err, jc.ErrorIsNil)
 
 		paths := runnertesting.NewRealPaths(c)
-		rnr := runner.NewRunner(ctx, paths)
+		rnr := runner.NewRunner(ctx, paths, clock.WallClock)
 		var hookExists bool
 		if t.spec.perm != 0 {
 			spec := t.spec
@@ -155,6 +157,7 @@ type MockContext struct {
 	flushBadge      string
 	flushFailure    error
 	flushResult     error
+	hookTimeout     time.Duration
 }
 
 func (ctx *MockContext) UnitName() string {
@@ -176,6 +179,10 @@ func (ctx *MockContext) SetProcess(process context.HookProcess) {
 	ctx.expectPid = process.Pid()
 }
 
+func (ctx *MockContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
 func (ctx *MockContext) Prepare() error {
 	return nil
 }
@@ -227,7 +234,7 @@ func (s *RunMockContextSuite) TestRunHookFlushSuccess(c *gc.C) {
 		name: hookName,
 		perm: 0700,
 	}, s.paths.GetCharmDir())
-	actualErr := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	actualErr := runner.NewRunner(ctx, s.paths, clock.WallClock).RunHook("something-happened")
 	c.Assert(actualErr, gc.Equals, expectErr)
 	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
 	c.Assert(ctx.flushFailure, gc.IsNil)
@@ -245,13 +252,97 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 		perm: 0700,
 		code: 123,
 	}, s.paths.GetCharmDir())
-	actualErr := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	actualErr := runner.NewRunner(ctx, s.paths, clock.WallClock).RunHook("something-happened")
 	c.Assert(actualErr, gc.Equals, expectErr)
 	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
 	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 123")
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookOutput(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:    "hooks",
+		name:   hookName,
+		perm:   0700,
+		stdout: "hello",
+		stderr: "oops",
+	}, s.paths.GetCharmDir())
+	rnr := runner.NewRunner(ctx, s.paths, clock.WallClock)
+	err := rnr.RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rnr.HookOutput(), jc.SameContents, []string{"hello", "oops"})
+}
+
+func (s *RunMockContextSuite) TestRunHookTimedOut(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook processes are not killed as a group on windows")
+	}
+	ctx := &MockContext{
+		hookTimeout: time.Minute,
+	}
+	makeCharm(c, hookSpec{
+		dir:        "hooks",
+		name:       hookName,
+		perm:       0700,
+		background: "not printed",
+		sleep:      10,
+	}, s.paths.GetCharmDir())
+	clock := coretesting.NewClock(time.Now())
+	done := make(chan error, 1)
+	go func() {
+		done <- runner.NewRunner(ctx, s.paths, clock).RunHook("something-happened")
+	}()
+	select {
+	case <-clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("hook timeout not started")
+	}
+	clock.Advance(time.Minute)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("hook not killed")
+	}
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(runner.IsHookTimedOutError(ctx.flushFailure), jc.IsTrue)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `hook "something-happened" timed out after 1m0s`)
+}
+
+func (s *RunMockContextSuite) TestRunHookCharmTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook processes are not killed as a group on windows")
+	}
+	ctx := &MockContext{
+		hookTimeout: time.Hour,
+	}
+	makeCharm(c, hookSpec{
+		dir:   "hooks",
+		name:  hookName,
+		perm:  0700,
+		sleep: 10,
+	}, s.paths.GetCharmDir())
+	metadata := filepath.Join(s.paths.GetCharmDir(), "metadata.yaml")
+	err := ioutil.WriteFile(metadata, []byte("name: wordpress\nhook-timeout: 100ms\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runner.NewRunner(ctx, s.paths, clock.WallClock).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runner.IsHookTimedOutError(ctx.flushFailure), jc.IsTrue)
+}
+
+func (s *RunMockContextSuite) TestRunHookInvalidCharmTimeout(c *gc.C) {
+	ctx := &MockContext{}
+	metadata := filepath.Join(s.paths.GetCharmDir(), "metadata.yaml")
+	err := ioutil.WriteFile(metadata, []byte("name: wordpress\nhook-timeout: soon\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runner.NewRunner(ctx, s.paths, clock.WallClock).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `charm hook-timeout "soon" not valid`)
+}
+
 func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
@@ -263,7 +354,7 @@ func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 		name: hookName,
 		perm: 0700,
 	}, s.paths.GetCharmDir())
-	actualErr := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	actualErr := runner.NewRunner(ctx, s.paths, clock.WallClock).RunAction("something-happened")
 	c.Assert(actualErr, gc.Equals, expectErr)
 	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
 	c.Assert(ctx.flushFailure, gc.IsNil)
@@ -282,7 +373,7 @@ func (s *RunMockContextSuite) TestRunActionFlushFailure(c *gc.C) {
 		perm: 0700,
 		code: 123,
 	}, s.paths.GetCharmDir())
-	actualErr := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	actualErr := runner.NewRunner(ctx, s.paths, clock.WallClock).RunAction("something-happened")
 	c.Assert(actualErr, gc.Equals, expectErr)
 	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
 	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 123")
@@ -295,7 +386,7 @@ func (s *RunMockContextSuite) TestRunActionParamsFailure(c *gc.C) {
 		actionData:      &context.ActionData{},
 		actionParamsErr: expectErr,
 	}
-	actualErr := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	actualErr := runner.NewRunner(ctx, s.paths, clock.WallClock).RunAction("juju-run")
 	c.Assert(errors.Cause(actualErr), gc.Equals, expectErr)
 }
 
@@ -308,7 +399,7 @@ func (s *RunMockContextSuite) TestRunActionSuccessful(c *gc.C) {
 		},
 		actionResults: map[string]interface{}{},
 	}
-	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	err := runner.NewRunner(ctx, s.paths, clock.WallClock).RunAction("juju-run")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ctx.flushBadge, gc.Equals, "juju-run")
 	c.Assert(ctx.flushFailure, gc.IsNil)
@@ -327,7 +418,7 @@ func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 		},
 		actionResults: map[string]interface{}{},
 	}
-	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	err := runner.NewRunner(ctx, s.paths, clock.WallClock).RunAction("juju-run")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ctx.flushBadge, gc.Equals, "juju-run")
 	c.Assert(ctx.flushFailure, gc.Equals, exec.ErrCancelled)
@@ -341,7 +432,7 @@ func (s *RunMockContextSuite) TestRunCommandsFlushSuccess(c *gc.C) {
 	ctx := &MockContext{
 		flushResult: expectErr,
 	}
-	_, actualErr := runner.NewRunner(ctx, s.paths).RunCommands(echoPidScript)
+	_, actualErr := runner.NewRunner(ctx, s.paths, clock.WallClock).RunCommands(echoPidScript)
 	c.Assert(actualErr, gc.Equals, expectErr)
 	c.Assert(ctx.flushBadge, gc.Equals, "run commands")
 	c.Assert(ctx.flushFailure, gc.IsNil)
@@ -353,7 +444,7 @@ func (s *RunMockContextSuite) TestRunCommandsFlushFailure(c *gc.C) {
 	ctx := &MockContext{
 		flushResult: expectErr,
 	}
-	_, actualErr := runner.NewRunner(ctx, s.paths).RunCommands(echoPidScript + "; exit 123")
+	_, actualErr := runner.NewRunner(ctx, s.paths, clock.WallClock).RunCommands(echoPidScript + "; exit 123")
 	c.Assert(actualErr, gc.Equals, expectErr)
 	c.Assert(ctx.flushBadge, gc.Equals, "run commands")
 	c.Assert(ctx.flushFailure, gc.IsNil) // exit code in _ result, as tested elsewhere
@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.secrets")
+
+// secretsResolver is a Resolver that returns operations to run the
+// secret-rotate hook for secrets that are due to be rotated. When the
+// hook is committed, the "secretRotated" callback is invoked to remove
+// the secret from the remote state.
+type secretsResolver struct {
+	secretRotated func(id string)
+}
+
+// NewSecretsResolver returns a new Resolver that returns operations to
+// run the secret-rotate hook whenever the remote state's
+// "SecretsToRotate" is non-empty. When the hook operation is committed,
+// the ID of the rotated secret is passed to the "secretRotated" callback.
+func NewSecretsResolver(secretRotated func(string)) resolver.Resolver {
+	return &secretsResolver{secretRotated}
+}
+
+// NextOp is part of the resolver.Resolver interface.
+func (s *secretsResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	if !localState.Installed || localState.Kind != operation.Continue {
+		return nil, resolver.ErrNoOperation
+	}
+	if remoteState.Life != params.Alive || len(remoteState.SecretsToRotate) == 0 {
+		return nil, resolver.ErrNoOperation
+	}
+	id := remoteState.SecretsToRotate[0]
+	logger.Debugf("secret %q is due to be rotated", id)
+	op, err := opFactory.NewRunHook(hook.Info{
+		Kind:     hook.SecretRotate,
+		SecretId: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &secretRotator{op, func() { s.secretRotated(id) }}, nil
+}
+
+type secretRotator struct {
+	operation.Operation
+	secretRotated func()
+}
+
+func (r *secretRotator) Commit(st operation.State) (*operation.State, error) {
+	result, err := r.Operation.Commit(st)
+	if err == nil {
+		r.secretRotated()
+	}
+	return result, err
+}
@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/secrets"
+)
+
+type resolverSuite struct {
+	rotated    []string
+	resolver   resolver.Resolver
+	opFactory  *mockOpFactory
+	localState resolver.LocalState
+}
+
+var _ = gc.Suite(&resolverSuite{})
+
+func (s *resolverSuite) SetUpTest(c *gc.C) {
+	s.rotated = nil
+	s.resolver = secrets.NewSecretsResolver(func(id string) {
+		s.rotated = append(s.rotated, id)
+	})
+	s.opFactory = &mockOpFactory{}
+	s.localState = resolver.LocalState{
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+		},
+	}
+}
+
+func (s *resolverSuite) TestNothingToRotate(c *gc.C) {
+	_, err := s.resolver.NextOp(s.localState, remotestate.Snapshot{Life: params.Alive}, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestRotate(c *gc.C) {
+	remoteState := remotestate.Snapshot{
+		Life:            params.Alive,
+		SecretsToRotate: []string{"9f5a6ab4", "7c1d2e3f"},
+	}
+	op, err := s.resolver.NextOp(s.localState, remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.opFactory.hookInfo, jc.DeepEquals, hook.Info{
+		Kind:     hook.SecretRotate,
+		SecretId: "9f5a6ab4",
+	})
+	c.Assert(s.rotated, gc.HasLen, 0)
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.rotated, jc.DeepEquals, []string{"9f5a6ab4"})
+}
+
+func (s *resolverSuite) TestNotRotatedWhileBusy(c *gc.C) {
+	remoteState := remotestate.Snapshot{
+		Life:            params.Alive,
+		SecretsToRotate: []string{"9f5a6ab4"},
+	}
+	s.localState.Kind = operation.RunHook
+	_, err := s.resolver.NextOp(s.localState, remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	s.localState.Kind = operation.Continue
+	remoteState.Life = params.Dying
+	_, err = s.resolver.NextOp(s.localState, remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+type mockOpFactory struct {
+	operation.Factory
+	hookInfo hook.Info
+}
+
+func (f *mockOpFactory) NewRunHook(info hook.Info) (operation.Operation, error) {
+	f.hookInfo = info
+	return &mockOp{}, nil
+}
+
+type mockOp struct {
+	operation.Operation
+}
+
+func (op *mockOp) Commit(st operation.State) (*operation.State, error) {
+	return &st, nil
+}
@@ -0,0 +1,35 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/shell"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/service"
+)
+
+// NewUpdateAgentServiceFunc returns a function that rewrites the init
+// system service files of the unit agent with the given config, for the
+// init system used by a series. The service is described in the same
+// way as when the unit was deployed by the machine agent.
+func NewUpdateAgentServiceFunc(agentConfig agent.Config) func(series string) error {
+	return func(series string) error {
+		renderer, err := shell.NewRenderer("")
+		if err != nil {
+			return errors.Trace(err)
+		}
+		tag := agentConfig.Tag()
+		info := service.NewAgentInfo(
+			service.AgentKindUnit,
+			tag.Id(),
+			agentConfig.DataDir(),
+			agentConfig.LogDir(),
+		)
+		containerType := agentConfig.Value(agent.ContainerType)
+		conf := service.ContainerAgentConf(info, renderer, containerType)
+		return service.InstallForSeries("jujud-"+tag.String(), conf, series)
+	}
+}
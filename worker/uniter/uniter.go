@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/catacomb"
@@ -28,6 +29,7 @@ import (
 	"github.com/juju/juju/worker/uniter/actions"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/hookhistory"
 	uniterleadership "github.com/juju/juju/worker/uniter/leadership"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/relation"
@@ -37,6 +39,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/secrets"
 	"github.com/juju/juju/worker/uniter/storage"
 	jujuos "github.com/juju/utils/os"
 )
@@ -79,6 +82,7 @@ type Uniter struct {
 	charmDirGuard     fortress.Guard
 
 	hookLockName string
+	unitLockName string
 
 	// TODO(axw) move the runListener and run-command code outside of the
 	// uniter, and introduce a separate worker. Each worker would feed
@@ -101,6 +105,14 @@ type Uniter struct {
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
+
+	// series is the series the unit agent's service was last
+	// installed for.
+	series string
+
+	// updateAgentService rewrites the unit agent's init system
+	// service files for a new series.
+	updateAgentService func(series string) error
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -116,13 +128,14 @@ type UniterParams struct {
 	HookRetryStrategy    params.RetryStrategy
 	NewOperationExecutor NewExecutorFunc
 	Clock                clock.Clock
+	UpdateAgentService   func(series string) error
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
 	Observer UniterExecutionObserver
 }
 
-type NewExecutorFunc func(string, func() (*corecharm.URL, error), func() (mutex.Releaser, error)) (operation.Executor, error)
+type NewExecutorFunc func(operation.ExecutorConfig) (operation.Executor, error)
 
 // NewUniter creates a new Uniter which will install, run, and upgrade
 // a charm on behalf of the unit with the given unitTag, by executing
@@ -132,6 +145,7 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 		st:                   uniterParams.UniterFacade,
 		paths:                NewPaths(uniterParams.DataDir, uniterParams.UnitTag),
 		hookLockName:         uniterParams.MachineLockName,
+		unitLockName:         uniterParams.UnitTag.String(),
 		leadershipTracker:    uniterParams.LeadershipTracker,
 		charmDirGuard:        uniterParams.CharmDirGuard,
 		updateStatusAt:       uniterParams.UpdateStatusSignal,
@@ -140,6 +154,7 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 		observer:             uniterParams.Observer,
 		clock:                uniterParams.Clock,
 		downloader:           uniterParams.Downloader,
+		updateAgentService:   uniterParams.UpdateAgentService,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -237,6 +252,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				UpdateStatusChannel: u.updateStatusAt,
 				CommandChannel:      u.commandChannel,
 				RetryHookChannel:    retryHookChan,
+				Clock:               u.clock,
 			})
 		if err != nil {
 			return errors.Trace(err)
@@ -267,6 +283,14 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		return nil
 	}
 
+	clearReplayHooks := func() error {
+		if err := u.unit.ClearReplayHooks(); err != nil {
+			return errors.Trace(err)
+		}
+		watcher.ClearReplayHooks()
+		return nil
+	}
+
 	for {
 		if err = restartWatcher(); err != nil {
 			err = errors.Annotate(err, "(re)starting watcher")
@@ -275,8 +299,10 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 
 		uniterResolver := NewUniterResolver(ResolverConfig{
 			ClearResolved:       clearResolved,
+			ClearReplayHooks:    clearReplayHooks,
 			ReportHookError:     u.reportHookError,
 			FixDeployer:         u.deployer.Fix,
+			UpdateSeries:        u.updateSeries,
 			ShouldRetryHooks:    u.hookRetryStrategy.ShouldRetry,
 			StartRetryHookTimer: retryHookTimer.Start,
 			StopRetryHookTimer:  retryHookTimer.Reset,
@@ -287,6 +313,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			Commands: runcommands.NewCommandsResolver(
 				u.commands, watcher.CommandCompleted,
 			),
+			Secrets: secrets.NewSecretsResolver(watcher.SecretRotated),
 		})
 
 		// We should not do anything until there has been a change
@@ -386,6 +413,23 @@ func (u *Uniter) terminate() error {
 	}
 }
 
+// updateSeries rewrites the unit agent's init system service files
+// when the unit's series changes, such as after the OS of its machine
+// has been upgraded in place.
+func (u *Uniter) updateSeries(series string) error {
+	if series == u.series {
+		return nil
+	}
+	logger.Infof("unit series changed from %q to %q", u.series, series)
+	if u.updateAgentService != nil {
+		if err := u.updateAgentService(series); err != nil {
+			return errors.Annotatef(err, "updating agent service for series %q", series)
+		}
+	}
+	u.series = series
+	return nil
+}
+
 func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	u.unit, err = u.st.Unit(unitTag)
 	if err != nil {
@@ -398,15 +442,32 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		// and inescapable, whereas this one is not.
 		return worker.ErrTerminateAgent
 	}
+	u.series, err = u.unit.Series()
+	if errors.IsNotImplemented(err) {
+		// Older controllers do not report the unit's series, and
+		// cannot change it.
+		u.series = ""
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	// The series may have changed while the agent was stopped, before
+	// its service files were rewritten; they are checked at startup so
+	// that the change is never missed.
+	if u.series != "" && u.updateAgentService != nil {
+		if err := u.updateAgentService(u.series); err != nil {
+			return errors.Annotatef(err, "updating agent service for series %q", u.series)
+		}
+	}
 	if err := jujuc.EnsureSymlinks(u.paths.ToolsDir); err != nil {
 		return err
 	}
 	if err := os.MkdirAll(u.paths.State.RelationsDir, 0755); err != nil {
 		return errors.Trace(err)
 	}
+	unitState := u.unitState()
 	relations, err := relation.NewRelations(
 		u.st, unitTag, u.paths.State.CharmDir,
-		u.paths.State.RelationsDir, u.catacomb.Dying(),
+		u.paths.State.RelationsDir, unitState, u.catacomb.Dying(),
 	)
 	if err != nil {
 		return errors.Annotatef(err, "cannot create relations")
@@ -438,7 +499,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		return err
 	}
 	runnerFactory, err := runner.NewFactory(
-		u.st, u.paths, contextFactory,
+		u.st, u.paths, contextFactory, u.clock,
 	)
 	if err != nil {
 		return errors.Trace(err)
@@ -449,9 +510,18 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		Callbacks:      &operationCallbacks{u},
 		Abort:          u.catacomb.Dying(),
 		MetricSpoolDir: u.paths.GetMetricsSpoolDir(),
+		LockPolicy:     operation.NewLockPolicy(u.modelHookLock, u.paths.State.CharmDir),
+		HookRecorder:   hookhistory.New(hookhistory.DefaultCapacity, u.unit),
 	})
 
-	operationExecutor, err := u.newOperationExecutor(u.paths.State.OperationsFile, u.getServiceCharmURL, u.acquireExecutionLock)
+	operationExecutor, err := u.newOperationExecutor(operation.ExecutorConfig{
+		StateFilePath:      u.paths.State.OperationsFile,
+		UnitState:          unitState,
+		GetInstallCharm:    u.getServiceCharmURL,
+		GetCurrentCharm:    u.unit.CharmURL,
+		AcquireMachineLock: u.acquireExecutionLock,
+		AcquireUnitLock:    u.acquireUnitExecutionLock,
+	})
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -509,25 +579,65 @@ func (u *Uniter) RunCommands(args RunCommandsArgs) (results *exec.ExecResponse,
 	return u.runListener.RunCommands(args)
 }
 
-// acquireExecutionLock acquires the machine-level execution lock, and
-// returns a func that must be called to unlock it. It's used by operation.Executor
-// when running operations that execute external code.
+// acquireExecutionLock acquires the machine-level execution lock
+// exclusively, and returns a func that must be called to unlock it. It's
+// used by operation.Executor when running operations that execute external
+// code and need the whole machine to themselves.
 func (u *Uniter) acquireExecutionLock() (mutex.Releaser, error) {
+	logger.Debugf("acquire lock %q for uniter hook execution", u.hookLockName)
+	releaser, err := machinelock.Acquire(u.lockSpec())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Debugf("lock %q acquired", u.hookLockName)
+	return releaser, nil
+}
+
+// acquireUnitExecutionLock acquires the machine-level execution lock
+// shared with other units, so that the unit's operations run in parallel
+// with theirs but never while the lock is held exclusively. It's used by
+// operation.Executor when running hooks and actions that do not need the
+// machine-level lock exclusively.
+func (u *Uniter) acquireUnitExecutionLock() (mutex.Releaser, error) {
+	logger.Debugf("acquire lock %q shared for uniter hook execution", u.hookLockName)
+	releaser, err := machinelock.AcquireShared(u.lockSpec(), u.unitLockName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Debugf("lock %q acquired shared", u.hookLockName)
+	return releaser, nil
+}
+
+// unitState returns the store of the uniter's state on the controller,
+// or nil if the controller cannot hold it, in which case the state is
+// only kept in local files.
+func (u *Uniter) unitState() operation.UnitStateReadWriter {
+	if u.st.BestAPIVersion() < 9 {
+		logger.Debugf("controller cannot hold uniter state, keeping it locally")
+		return nil
+	}
+	return u.unit
+}
+
+func (u *Uniter) lockSpec() mutex.Spec {
 	// We want to make sure we don't block forever when locking, but take the
 	// Uniter's catacomb into account.
-	spec := mutex.Spec{
+	return mutex.Spec{
 		Name:   u.hookLockName,
 		Clock:  u.clock,
 		Delay:  250 * time.Millisecond,
 		Cancel: u.catacomb.Dying(),
 	}
-	logger.Debugf("acquire lock %q for uniter hook execution", u.hookLockName)
-	releaser, err := mutex.Acquire(spec)
+}
+
+// modelHookLock returns the model's hook-lock setting, which decides
+// whether hooks hold the machine-level or the unit's execution lock.
+func (u *Uniter) modelHookLock() (string, error) {
+	modelConfig, err := u.st.ModelConfig()
 	if err != nil {
-		return nil, errors.Trace(err)
+		return "", errors.Trace(err)
 	}
-	logger.Debugf("lock %q acquired", u.hookLockName)
-	return releaser, nil
+	return modelConfig.HookLock(), nil
 }
 
 func (u *Uniter) reportHookError(hookInfo hook.Info) error {
@@ -549,5 +659,9 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if u.operationExecutor.State().HookTimedOut {
+		statusData["timed-out"] = true
+		statusMessage = fmt.Sprintf("hook timed out: %q", hookName)
+	}
 	return setAgentStatus(u, status.StatusError, statusMessage, statusData)
 }
@@ -16,7 +16,6 @@ import (
 	"time"
 
 	"github.com/juju/errors"
-	"github.com/juju/mutex"
 	jc "github.com/juju/testing/checkers"
 	ft "github.com/juju/testing/filetesting"
 	"github.com/juju/utils/clock"
@@ -155,6 +154,22 @@ func (s *UniterSuite) TestUniterStartup(c *gc.C) {
 	})
 }
 
+func (s *UniterSuite) TestUniterAgentServiceSeries(c *gc.C) {
+	s.runUniterTests(c, []uniterTest{
+		ut(
+			"service files are checked at startup and when the series changes",
+			quickStart{},
+			waitAgentServiceSeries{[]string{"quantal"}},
+			updateMachineSeries{"trusty"},
+			waitAgentServiceSeries{[]string{"quantal", "trusty"}},
+			stopUniter{},
+			startUniter{},
+			waitUnitAgent{status: status.StatusIdle},
+			waitAgentServiceSeries{[]string{"quantal", "trusty", "trusty"}},
+		),
+	})
+}
+
 func (s *UniterSuite) TestUniterBootstrap(c *gc.C) {
 	//TODO(bogdanteleaga): Fix this on windows
 	if runtime.GOOS == "windows" {
@@ -2136,8 +2151,8 @@ func (m *mockExecutor) Run(op operation.Operation) error {
 }
 
 func (s *UniterSuite) TestOperationErrorReported(c *gc.C) {
-	executorFunc := func(stateFilePath string, getInstallCharm func() (*corecharm.URL, error), acquireLock func() (mutex.Releaser, error)) (operation.Executor, error) {
-		e, err := operation.NewExecutor(stateFilePath, getInstallCharm, acquireLock)
+	executorFunc := func(config operation.ExecutorConfig) (operation.Executor, error) {
+		e, err := operation.NewExecutor(config)
 		c.Assert(err, jc.ErrorIsNil)
 		return &mockExecutor{e}, nil
 	}
@@ -35,6 +35,7 @@ import (
 	apiuniter "github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/core/leadership"
 	coreleadership "github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/juju/sockets"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
@@ -101,6 +102,7 @@ type context struct {
 	relationUnits          map[string]*state.RelationUnit
 	subordinate            *state.Unit
 	updateStatusHookTicker *manualTicker
+	agentServiceSeries     []string
 	err                    string
 
 	wg             sync.WaitGroup
@@ -500,7 +502,13 @@ func (s startUniter) step(c *gc.C, ctx *context) {
 		MachineLockName:      hookExecutionLockName(),
 		UpdateStatusSignal:   ctx.updateStatusHookTicker.ReturnTimer,
 		NewOperationExecutor: operationExecutor,
-		Observer:             ctx,
+		UpdateAgentService: func(series string) error {
+			ctx.mu.Lock()
+			defer ctx.mu.Unlock()
+			ctx.agentServiceSeries = append(ctx.agentServiceSeries, series)
+			return nil
+		},
+		Observer: ctx,
 		// TODO(axw) 2015-11-02 #1512191
 		// update tests that rely on timing to advance clock
 		// appropriately.
@@ -510,6 +518,41 @@ func (s startUniter) step(c *gc.C, ctx *context) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+type updateMachineSeries struct {
+	series string
+}
+
+func (s updateMachineSeries) step(c *gc.C, ctx *context) {
+	machineId, err := ctx.unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := ctx.st.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.UpdateMachineSeries(s.series, true)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type waitAgentServiceSeries struct {
+	series []string
+}
+
+func (s waitAgentServiceSeries) step(c *gc.C, ctx *context) {
+	timeout := time.After(worstCase)
+	for {
+		ctx.mu.Lock()
+		series := ctx.agentServiceSeries
+		ctx.mu.Unlock()
+		if len(series) >= len(s.series) {
+			c.Assert(series, jc.DeepEquals, s.series)
+			return
+		}
+		select {
+		case <-timeout:
+			c.Fatalf("timed out waiting for agent service series %v, got %v", s.series, series)
+		case <-time.After(coretesting.ShortWait):
+		}
+	}
+}
+
 type waitUniterDead struct {
 	err string
 }
@@ -797,7 +840,7 @@ func (s waitHooks) step(c *gc.C, ctx *context) {
 	waitExecutionLockReleased := func() {
 		spec := hookLockSpec()
 		spec.Timeout = worstCase
-		releaser, err := mutex.Acquire(spec)
+		releaser, err := machinelock.Acquire(spec)
 		if err != nil {
 			c.Fatalf("failed to acquire execution lock: %v", err)
 		}
@@ -1395,7 +1438,7 @@ func hookLockSpec() mutex.Spec {
 
 func (h *hookLock) acquire() *hookStep {
 	return &hookStep{stepFunc: func(c *gc.C, ctx *context) {
-		releaser, err := mutex.Acquire(hookLockSpec())
+		releaser, err := machinelock.Acquire(hookLockSpec())
 		c.Assert(err, jc.ErrorIsNil)
 		h.releaser = releaser
 	}}